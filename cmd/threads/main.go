@@ -24,6 +24,6 @@ func main() {
 
 	// Execute root command
 	if err := cmd.Execute(ctx); err != nil {
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -418,6 +418,9 @@ func TestGetPublishingLimits_Success(t *testing.T) {
 	if limits == nil {
 		t.Fatal("expected limits to not be nil")
 	}
+	if limits.FetchedAt.IsZero() {
+		t.Error("expected FetchedAt to be set")
+	}
 }
 
 // Tests for GetUserFields with mocked HTTP
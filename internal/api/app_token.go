@@ -0,0 +1,47 @@
+package api
+
+import (
+	"strings"
+)
+
+// TokenTypeApp is the TokenInfo.TokenType of app access tokens
+const TokenTypeApp = "app"
+
+// AppAccessToken builds an app access token from the app's client credentials.
+// App tokens identify the app rather than a user. They never expire and are
+// accepted by endpoints such as debug_token that do not act on a user's behalf.
+func AppAccessToken(clientID, clientSecret string) string {
+	return clientID + "|" + clientSecret
+}
+
+// IsAppAccessToken reports whether token has the client_id|client_secret form
+func IsAppAccessToken(token string) bool {
+	clientID, clientSecret, ok := strings.Cut(token, "|")
+	return ok && clientID != "" && clientSecret != "" && !strings.Contains(clientSecret, "|")
+}
+
+// NewAppClient creates a client authenticated with an app access token built
+// from clientID and clientSecret. It performs no network calls.
+//
+// The client is not tied to a user, so endpoints that act on the current user
+// (publishing, GetMe, insights) are unavailable; use it for monitoring tasks
+// such as DebugToken. The token is never refreshed.
+func NewAppClient(clientID, clientSecret string, opts ...Option) (*Client, error) {
+	if clientID == "" || clientSecret == "" {
+		return nil, NewValidationError(400, "App credentials are required", "clientID and clientSecret cannot be empty", "clientID")
+	}
+
+	opts = append([]Option{WithAppCredentials(clientID, clientSecret, "")}, opts...)
+	opts = append(opts, func(o *clientOptions) {
+		o.tokenType = TokenTypeApp
+		o.userID = ""
+	})
+	return New(AppAccessToken(clientID, clientSecret), opts...)
+}
+
+// IsAppToken returns true if the client authenticates with an app access token
+func (c *Client) IsAppToken() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tokenInfo != nil && c.tokenInfo.TokenType == TokenTypeApp
+}
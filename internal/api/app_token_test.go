@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsAppAccessToken(t *testing.T) {
+	tests := []struct {
+		token string
+		want  bool
+	}{
+		{"123|secret", true},
+		{AppAccessToken("app", "s3cret"), true},
+		{"THQWJYeF0", false},
+		{"|secret", false},
+		{"123|", false},
+		{"1|2|3", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsAppAccessToken(tt.token); got != tt.want {
+			t.Errorf("IsAppAccessToken(%q) = %v, want %v", tt.token, got, tt.want)
+		}
+	}
+}
+
+func TestNewAppClient(t *testing.T) {
+	if _, err := NewAppClient("", "secret"); !IsValidationError(err) {
+		t.Errorf("expected validation error for missing client ID, got %v", err)
+	}
+
+	var gotAuth, gotAccessToken string
+	client, err := NewAppClient("app-id", "app-secret", WithHTTPClient(http.DefaultClient))
+	if err != nil {
+		t.Fatalf("NewAppClient failed: %v", err)
+	}
+	if !client.IsAppToken() {
+		t.Error("expected app token client")
+	}
+	if client.IsTokenExpired() || client.IsTokenExpiringSoon(DefaultTokenLifetime*2) {
+		t.Error("app tokens should never expire")
+	}
+	if err := client.RefreshToken(context.Background()); !IsAuthenticationError(err) {
+		t.Errorf("expected refresh to be rejected, got %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotAccessToken = r.URL.Query().Get("access_token")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"is_valid":true,"user_id":"42","scopes":["threads_basic"]}}`))
+	}))
+	defer server.Close()
+	client.httpClient.baseURL = server.URL
+
+	resp, err := client.DebugToken(context.Background(), "user-token")
+	if err != nil {
+		t.Fatalf("DebugToken failed: %v", err)
+	}
+	if !resp.Data.IsValid {
+		t.Error("expected valid token response")
+	}
+	if gotAccessToken != "app-id|app-secret" || gotAuth != "Bearer app-id|app-secret" {
+		t.Errorf("expected app token to be used, got access_token=%q auth=%q", gotAccessToken, gotAuth)
+	}
+}
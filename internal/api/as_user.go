@@ -0,0 +1,52 @@
+package api
+
+import (
+	"time"
+)
+
+// AsUser returns a client that issues requests for userID with accessToken.
+//
+// The returned client shares the parent's HTTP transport, rate limiter and
+// configuration, so server-side integrations can manage many accounts without
+// opening a connection pool per account. Token state is kept separate: each
+// scoped client refreshes and stores only its own token, in its own in-memory
+// storage, and is safe to use concurrently with the parent and with other
+// scoped clients. The parent's OnTokenRefresh hook is not copied, since it
+// would save the scoped token as the parent's; set one with UpdateConfig to
+// persist refreshed tokens of userID. The publishing quota in the parent's
+// RateLimitStore is the parent account's, so scoped clients neither check nor
+// update it.
+//
+// The token is assumed to expire DefaultTokenLifetime from now; call
+// SetTokenInfo on the returned client if the exact expiry is known.
+func (c *Client) AsUser(userID, accessToken string) (*Client, error) {
+	if accessToken == "" {
+		return nil, NewValidationError(400, "Access token is required", "accessToken cannot be empty", "accessToken")
+	}
+
+	config := c.GetConfig()
+	config.TokenStorage = &MemoryTokenStorage{}
+	config.OnTokenRefresh = nil
+
+	scoped := &Client{
+		httpClient:   c.httpClient,
+		rateLimiter:  c.rateLimiter,
+		tokenStorage: config.TokenStorage,
+		scoped:       true,
+	}
+	scoped.config.Store(config)
+
+	now := time.Now()
+	tokenInfo := &TokenInfo{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresAt:   now.Add(DefaultTokenLifetime),
+		UserID:      userID,
+		CreatedAt:   now,
+	}
+	if err := scoped.SetTokenInfo(tokenInfo); err != nil {
+		return nil, err
+	}
+
+	return scoped, nil
+}
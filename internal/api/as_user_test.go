@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAsUser_SeparateTokensSharedTransport(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]string{} // path -> Authorization header
+
+	parent, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.URL.Path] = r.Header.Get("Authorization")
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		id := strings.TrimPrefix(r.URL.Path, "/")
+		_, _ = fmt.Fprintf(w, `{"id":%q,"text":"hi"}`, id)
+	})
+	defer server.Close()
+
+	alice, err := parent.AsUser("1", "alice-token")
+	if err != nil {
+		t.Fatalf("AsUser failed: %v", err)
+	}
+	bob, err := parent.AsUser("2", "bob-token")
+	if err != nil {
+		t.Fatalf("AsUser failed: %v", err)
+	}
+
+	if alice.httpClient != parent.httpClient || alice.rateLimiter != parent.rateLimiter {
+		t.Error("expected scoped client to share transport and rate limiter")
+	}
+	if alice.tokenStorage == parent.tokenStorage {
+		t.Error("expected scoped client to have its own token storage")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		client, postID := alice, PostID(fmt.Sprintf("a%d", i))
+		if i%2 == 1 {
+			client, postID = bob, PostID(fmt.Sprintf("b%d", i))
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetPost(context.Background(), postID); err != nil {
+				t.Errorf("GetPost failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for path, auth := range seen {
+		want := "Bearer alice-token"
+		if strings.HasPrefix(path, "/b") {
+			want = "Bearer bob-token"
+		}
+		if auth != want {
+			t.Errorf("request %s used %q, want %q", path, auth, want)
+		}
+	}
+
+	if parent.GetTokenInfo().AccessToken != "test-access-token" {
+		t.Error("expected parent token to be unchanged")
+	}
+	if alice.GetTokenInfo().UserID != "1" || bob.GetTokenInfo().UserID != "2" {
+		t.Error("expected scoped clients to keep their own user IDs")
+	}
+}
+
+func TestAsUser_EmptyToken(t *testing.T) {
+	parent, err := New("parent-token")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, err := parent.AsUser("1", ""); !IsValidationError(err) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestAsUser_KeepsParentRefreshHook(t *testing.T) {
+	var refreshes int32
+	parent, server := createTestClient(t, refreshServer(t, &refreshes))
+	defer server.Close()
+
+	var persisted []string
+	parent.currentConfig().OnTokenRefresh = func(token *TokenInfo) error {
+		persisted = append(persisted, token.UserID)
+		return nil
+	}
+
+	scoped, err := parent.AsUser("1", "alice-token")
+	if err != nil {
+		t.Fatalf("AsUser failed: %v", err)
+	}
+	if err := scoped.RefreshToken(context.Background()); err != nil {
+		t.Fatalf("RefreshToken failed: %v", err)
+	}
+	if refreshes != 1 || len(persisted) != 0 {
+		t.Errorf("expected the scoped token refreshed without the parent's hook, got %d refreshes, hook called for %v", refreshes, persisted)
+	}
+}
@@ -56,9 +56,10 @@ func (c *Client) GetAuthURL(scopes []string) string {
 		state = fmt.Sprintf("state_%d", time.Now().Unix())
 	}
 
+	config := c.currentConfig()
 	params := url.Values{
-		"client_id":     {c.config.ClientID},
-		"redirect_uri":  {c.config.RedirectURI},
+		"client_id":     {config.ClientID},
+		"redirect_uri":  {config.RedirectURI},
 		"scope":         {strings.Join(scopes, ",")}, // Use comma-separated scopes
 		"response_type": {"code"},
 		"state":         {state},
@@ -77,15 +78,16 @@ func (c *Client) ExchangeCodeForToken(ctx context.Context, code string) error {
 		return NewValidationError(400, "Authorization code is required", "Code parameter cannot be empty", "code")
 	}
 
+	config := c.currentConfig()
 	data := url.Values{
-		"client_id":     {c.config.ClientID},
-		"client_secret": {c.config.ClientSecret},
+		"client_id":     {config.ClientID},
+		"client_secret": {config.ClientSecret},
 		"grant_type":    {"authorization_code"},
-		"redirect_uri":  {c.config.RedirectURI},
+		"redirect_uri":  {config.RedirectURI},
 		"code":          {code},
 	}
 
-	resp, err := c.httpClient.POST("/oauth/access_token", data, "")
+	resp, err := c.httpClient.POST(ctx, endpointExchangeCode.path(), data, "")
 	if err != nil {
 		return NewNetworkError(0, "Failed to exchange code for token", err.Error(), true)
 	}
@@ -119,14 +121,14 @@ func (c *Client) ExchangeCodeForToken(ctx context.Context, code string) error {
 
 	// Store the token using thread-safe method
 	if err := c.SetTokenInfo(tokenInfo); err != nil {
-		if c.config.Logger != nil {
-			c.config.Logger.Warn("Failed to store token", "error", err.Error())
+		if logger := c.currentConfig().Logger; logger != nil {
+			logger.Warn("Failed to store token", "error", err.Error())
 		}
 	}
 
 	// Log successful authentication if logger is available
-	if c.config.Logger != nil {
-		c.config.Logger.Info("Successfully exchanged authorization code for access token",
+	if logger := c.currentConfig().Logger; logger != nil {
+		logger.Info("Successfully exchanged authorization code for access token",
 			"user_id", fmt.Sprintf("%d", tokenResp.UserID),
 			"token_type", tokenResp.TokenType,
 			"expires_at", expiresAt)
@@ -150,11 +152,11 @@ func (c *Client) GetLongLivedToken(ctx context.Context) error {
 
 	params := url.Values{
 		"grant_type":    {"th_exchange_token"},
-		"client_secret": {c.config.ClientSecret},
+		"client_secret": {c.currentConfig().ClientSecret},
 		"access_token":  {currentToken},
 	}
 
-	resp, err := c.httpClient.GET("/access_token", params, currentToken)
+	resp, err := c.httpClient.GET(ctx, endpointLongLivedToken.path(), params, currentToken)
 	if err != nil {
 		return NewNetworkError(0, "Failed to get long-lived token", err.Error(), true)
 	}
@@ -196,14 +198,14 @@ func (c *Client) GetLongLivedToken(ctx context.Context) error {
 
 	// Store the token using thread-safe method
 	if err := c.SetTokenInfo(tokenInfo); err != nil {
-		if c.config.Logger != nil {
-			c.config.Logger.Warn("Failed to store long-lived token", "error", err.Error())
+		if logger := c.currentConfig().Logger; logger != nil {
+			logger.Warn("Failed to store long-lived token", "error", err.Error())
 		}
 	}
 
 	// Log successful long-lived token conversion if logger is available
-	if c.config.Logger != nil {
-		c.config.Logger.Info("Successfully converted to long-lived token",
+	if logger := c.currentConfig().Logger; logger != nil {
+		logger.Info("Successfully converted to long-lived token",
 			"expires_in_seconds", tokenResp.ExpiresIn,
 			"expires_at", expiresAt,
 			"token_type", tokenResp.TokenType)
@@ -225,12 +227,16 @@ func (c *Client) RefreshToken(ctx context.Context) error {
 		return NewAuthenticationError(401, "No access token to refresh", "Must have an existing token to refresh")
 	}
 
+	if c.IsAppToken() {
+		return NewAuthenticationError(400, "Cannot refresh an app access token", "App access tokens do not expire and cannot be refreshed")
+	}
+
 	params := url.Values{
 		"grant_type":   {"th_refresh_token"},
 		"access_token": {currentToken},
 	}
 
-	resp, err := c.httpClient.GET("/refresh_access_token", params, "")
+	resp, err := c.httpClient.GET(ctx, endpointRefreshToken.path(), params, "")
 	if err != nil {
 		return NewNetworkError(0, "Failed to refresh token", err.Error(), true)
 	}
@@ -272,22 +278,44 @@ func (c *Client) RefreshToken(ctx context.Context) error {
 
 	// Store the token using thread-safe method
 	if err := c.SetTokenInfo(tokenInfo); err != nil {
-		if c.config.Logger != nil {
-			c.config.Logger.Warn("Failed to store refreshed token", "error", err.Error())
+		if logger := c.currentConfig().Logger; logger != nil {
+			logger.Warn("Failed to store refreshed token", "error", err.Error())
 		}
 	}
 
 	// Log successful token refresh if logger is available
-	if c.config.Logger != nil {
-		c.config.Logger.Info("Successfully refreshed access token",
+	if logger := c.currentConfig().Logger; logger != nil {
+		logger.Info("Successfully refreshed access token",
 			"expires_in_seconds", tokenResp.ExpiresIn,
 			"expires_at", expiresAt,
 			"token_type", tokenResp.TokenType)
 	}
 
+	// Let the application persist the new token
+	if onRefresh := c.currentConfig().OnTokenRefresh; onRefresh != nil {
+		tokenCopy := *tokenInfo
+		if err := onRefresh(&tokenCopy); err != nil {
+			return &tokenPersistError{err: err}
+		}
+	}
+
 	return nil
 }
 
+// tokenPersistError reports that a token was refreshed but the
+// OnTokenRefresh callback failed to persist it
+type tokenPersistError struct {
+	err error
+}
+
+func (e *tokenPersistError) Error() string {
+	return fmt.Sprintf("token refreshed but could not be persisted: %v", e.err)
+}
+
+func (e *tokenPersistError) Unwrap() error {
+	return e.err
+}
+
 // handleTokenError processes token-related API errors
 func (c *Client) handleTokenError(statusCode int, body []byte) error {
 	var errorResp struct {
@@ -365,8 +393,8 @@ func (c *Client) LoadTokenFromStorage() error {
 		return err
 	}
 
-	if c.config.Logger != nil {
-		c.config.Logger.Info("Successfully loaded token from storage",
+	if logger := c.currentConfig().Logger; logger != nil {
+		logger.Info("Successfully loaded token from storage",
 			"expires_at", tokenInfo.ExpiresAt,
 			"user_id", tokenInfo.UserID)
 	}
@@ -379,23 +407,24 @@ func (c *Client) LoadTokenFromStorage() error {
 // expiration times, validity checks, and calculated values useful for troubleshooting.
 // The returned map contains various fields like has_token, is_authenticated, expires_at, etc.
 func (c *Client) GetTokenDebugInfo() map[string]interface{} {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	// Each accessor takes the lock on its own: holding it here as well
+	// would deadlock against a waiting writer
+	tokenInfo := c.GetTokenInfo()
 
 	debugInfo := map[string]interface{}{
-		"has_token":        c.accessToken != "",
+		"has_token":        c.getAccessTokenSafe() != "",
 		"is_authenticated": c.IsAuthenticated(),
 		"is_expired":       c.IsTokenExpired(),
 	}
 
-	if c.tokenInfo != nil {
+	if tokenInfo != nil {
 		now := time.Now()
-		timeUntilExpiry := c.tokenInfo.ExpiresAt.Sub(now)
+		timeUntilExpiry := tokenInfo.ExpiresAt.Sub(now)
 
-		debugInfo["token_type"] = c.tokenInfo.TokenType
-		debugInfo["user_id"] = c.tokenInfo.UserID
-		debugInfo["created_at"] = c.tokenInfo.CreatedAt
-		debugInfo["expires_at"] = c.tokenInfo.ExpiresAt
+		debugInfo["token_type"] = tokenInfo.TokenType
+		debugInfo["user_id"] = tokenInfo.UserID
+		debugInfo["created_at"] = tokenInfo.CreatedAt
+		debugInfo["expires_at"] = tokenInfo.ExpiresAt
 		debugInfo["time_until_expiry"] = timeUntilExpiry.String()
 		debugInfo["expires_in_hours"] = timeUntilExpiry.Hours()
 		debugInfo["expires_in_days"] = timeUntilExpiry.Hours() / 24
@@ -443,7 +472,7 @@ func (c *Client) DebugToken(ctx context.Context, inputToken string) (*DebugToken
 		"access_token": {accessToken},
 	}
 
-	resp, err := c.httpClient.GET("/debug_token", params, accessToken)
+	resp, err := c.httpClient.GET(ctx, endpointDebugToken.path(), params, accessToken)
 	if err != nil {
 		return nil, NewNetworkError(0, "Failed to debug token", err.Error(), true)
 	}
@@ -457,8 +486,8 @@ func (c *Client) DebugToken(ctx context.Context, inputToken string) (*DebugToken
 		return nil, NewAPIError(resp.StatusCode, "Failed to parse debug token response", err.Error(), "")
 	}
 
-	if c.config.Logger != nil {
-		c.config.Logger.Debug("Debug token response received",
+	if logger := c.currentConfig().Logger; logger != nil {
+		logger.Debug("Debug token response received",
 			"is_valid", debugResp.Data.IsValid,
 			"expires_at", debugResp.Data.ExpiresAt,
 			"issued_at", debugResp.Data.IssuedAt,
@@ -499,9 +528,9 @@ func (c *Client) SetTokenFromDebugInfo(accessToken string, debugResp *DebugToken
 		return fmt.Errorf("failed to store token info: %w", err)
 	}
 
-	if c.config.Logger != nil {
+	if logger := c.currentConfig().Logger; logger != nil {
 		lifetime := expiresAt.Sub(issuedAt)
-		c.config.Logger.Info("Token info set from debug response",
+		logger.Info("Token info set from debug response",
 			"user_id", debugResp.Data.UserID,
 			"expires_at", expiresAt,
 			"issued_at", issuedAt,
@@ -2,6 +2,11 @@ package api
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -317,3 +322,108 @@ func TestGenerateState(t *testing.T) {
 		t.Error("consecutive GetAuthURL calls should generate different states")
 	}
 }
+
+// refreshServer answers token refresh calls and counts them
+func refreshServer(t *testing.T, refreshes *int32) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "refresh_access_token") {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			return
+		}
+		atomic.AddInt32(refreshes, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+	}
+}
+
+// TestEnsureValidToken_AutoRefreshPersists tests that a refresh inside the window invokes OnTokenRefresh
+func TestEnsureValidToken_AutoRefreshPersists(t *testing.T) {
+	var refreshes int32
+	client, server := createTestClient(t, refreshServer(t, &refreshes))
+	defer server.Close()
+
+	var persisted *TokenInfo
+	client.currentConfig().TokenRefreshWindow = 2 * time.Hour
+	client.currentConfig().OnTokenRefresh = func(token *TokenInfo) error {
+		persisted = token
+		return nil
+	}
+
+	if err := client.EnsureValidToken(context.Background()); err != nil {
+		t.Fatalf("EnsureValidToken failed: %v", err)
+	}
+	if refreshes != 1 {
+		t.Errorf("expected 1 refresh, got %d", refreshes)
+	}
+	if persisted == nil || persisted.AccessToken != "refreshed-token" || persisted.UserID != "12345" {
+		t.Errorf("unexpected persisted token: %+v", persisted)
+	}
+
+	// The refreshed token is valid for 60 days, so no further refresh is needed
+	if err := client.EnsureValidToken(context.Background()); err != nil {
+		t.Fatalf("EnsureValidToken failed: %v", err)
+	}
+	if refreshes != 1 {
+		t.Errorf("expected no additional refresh, got %d", refreshes)
+	}
+}
+
+// TestEnsureValidToken_PersistFailureIsNotFatal tests that a failing callback does not fail API calls
+func TestEnsureValidToken_PersistFailureIsNotFatal(t *testing.T) {
+	var refreshes int32
+	client, server := createTestClient(t, refreshServer(t, &refreshes))
+	defer server.Close()
+
+	client.currentConfig().OnTokenRefresh = func(*TokenInfo) error { return errors.New("keyring locked") }
+
+	if err := client.EnsureValidToken(context.Background()); err != nil {
+		t.Fatalf("EnsureValidToken failed: %v", err)
+	}
+	if err := client.RefreshToken(context.Background()); err == nil || !strings.Contains(err.Error(), "keyring locked") {
+		t.Errorf("expected RefreshToken to report the persistence error, got %v", err)
+	}
+}
+
+// TestEnsureValidToken_SingleRefreshWhenConcurrent tests that concurrent callers share one refresh
+func TestEnsureValidToken_SingleRefreshWhenConcurrent(t *testing.T) {
+	var refreshes int32
+	client, server := createTestClient(t, refreshServer(t, &refreshes))
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.EnsureValidToken(context.Background()); err != nil {
+				t.Errorf("EnsureValidToken failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if refreshes != 1 {
+		t.Errorf("expected a single refresh, got %d", refreshes)
+	}
+}
+
+// TestEnsureValidToken_RefreshDisabled tests that a negative window disables refresh
+func TestEnsureValidToken_RefreshDisabled(t *testing.T) {
+	var refreshes int32
+	client, server := createTestClient(t, refreshServer(t, &refreshes))
+	defer server.Close()
+
+	client.currentConfig().TokenRefreshWindow = -1
+	if err := client.EnsureValidToken(context.Background()); err != nil {
+		t.Fatalf("EnsureValidToken failed: %v", err)
+	}
+
+	client.tokenInfo.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := client.EnsureValidToken(context.Background()); !IsAuthenticationError(err) {
+		t.Errorf("expected authentication error for expired token, got %v", err)
+	}
+	if refreshes != 0 {
+		t.Errorf("expected no refresh, got %d", refreshes)
+	}
+}
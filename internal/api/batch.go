@@ -0,0 +1,292 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// MaxBatchSize is the maximum number of requests the Graph API accepts in a
+// single batch call. Client.Batch splits larger slices into several calls.
+const MaxBatchSize = 50
+
+// BatchRequest is a single operation inside a Graph API batch call
+type BatchRequest struct {
+	Method      string     `json:"method"`
+	RelativeURL string     `json:"relative_url"`
+	Body        url.Values `json:"-"`
+}
+
+// NewBatchGET builds a GET batch request for the given API path and query parameters
+func NewBatchGET(path string, params url.Values) BatchRequest {
+	relativeURL := strings.TrimPrefix(path, "/")
+	if len(params) > 0 {
+		relativeURL += "?" + params.Encode()
+	}
+	return BatchRequest{Method: "GET", RelativeURL: relativeURL}
+}
+
+// NewBatchPOST builds a POST batch request for the given API path and form body
+func NewBatchPOST(path string, body url.Values) BatchRequest {
+	return BatchRequest{Method: "POST", RelativeURL: strings.TrimPrefix(path, "/"), Body: body}
+}
+
+// MarshalJSON encodes the request in the format expected by the batch endpoint,
+// where the body is a URL-encoded string rather than a nested object.
+func (r BatchRequest) MarshalJSON() ([]byte, error) {
+	type wire struct {
+		Method      string `json:"method"`
+		RelativeURL string `json:"relative_url"`
+		Body        string `json:"body,omitempty"`
+	}
+	w := wire{Method: r.Method, RelativeURL: r.RelativeURL}
+	if len(r.Body) > 0 {
+		w.Body = r.Body.Encode()
+	}
+	return json.Marshal(w)
+}
+
+// BatchHeader is a response header returned for a batched request
+type BatchHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// BatchResponse is the result of a single request inside a batch call.
+// A Code of zero means the API did not execute the request (for example
+// because the batch timed out) and it is safe to retry.
+type BatchResponse struct {
+	Code    int           `json:"code"`
+	Headers []BatchHeader `json:"headers,omitempty"`
+	Body    string        `json:"body"`
+}
+
+// Err returns the error for a failed batched request, or nil on success
+func (r *BatchResponse) Err() error {
+	if r.Code == 0 {
+		return NewNetworkError(0, "Batched request was not executed", "the batch call returned no result for this request", true)
+	}
+	if r.Code >= 200 && r.Code < 300 {
+		return nil
+	}
+	return errorFromResponse(&Response{StatusCode: r.Code, Body: []byte(r.Body)})
+}
+
+// Decode unmarshals the body of a successful batched request into v
+func (r *BatchResponse) Decode(v any) error {
+	if err := r.Err(); err != nil {
+		return err
+	}
+	return safeJSONUnmarshal([]byte(r.Body), v, "batch response", "")
+}
+
+// BatchError collects per-item failures from a bulk operation. Keys are the
+// IDs of the items that failed; items not present succeeded.
+type BatchError struct {
+	Errors map[string]error
+}
+
+// Error implements the error interface
+func (e *BatchError) Error() string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	if len(ids) == 1 {
+		return fmt.Sprintf("batch item %s failed: %v", ids[0], e.Errors[ids[0]])
+	}
+	return fmt.Sprintf("%d batch items failed (first: %s: %v)", len(ids), ids[0], e.Errors[ids[0]])
+}
+
+// Batch executes several Graph API requests with as few round trips as possible.
+// Requests are sent in chunks of MaxBatchSize and responses are returned in the
+// same order as requests. An error is returned only when a whole batch call
+// fails; failures of individual requests are reported through BatchResponse.Err.
+// For batch API documentation, see: https://developers.facebook.com/docs/graph-api/batch-requests
+func (c *Client) Batch(ctx context.Context, requests []BatchRequest) ([]BatchResponse, error) {
+	if len(requests) == 0 {
+		return nil, NewValidationError(400, "No batch requests", "at least one request is required", "requests")
+	}
+	for i, req := range requests {
+		if req.Method == "" || req.RelativeURL == "" {
+			return nil, NewValidationError(400, "Invalid batch request", fmt.Sprintf("request %d must have a method and relative URL", i), "requests")
+		}
+	}
+
+	// Ensure we have a valid token
+	if err := c.EnsureValidToken(ctx); err != nil {
+		return nil, err
+	}
+
+	responses := make([]BatchResponse, 0, len(requests))
+	for start := 0; start < len(requests); start += MaxBatchSize {
+		end := min(start+MaxBatchSize, len(requests))
+		chunk, err := c.executeBatch(ctx, requests[start:end])
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, chunk...)
+	}
+
+	return responses, nil
+}
+
+// executeBatch sends one batch call of at most MaxBatchSize requests
+func (c *Client) executeBatch(ctx context.Context, requests []BatchRequest) ([]BatchResponse, error) {
+	payload, err := json.Marshal(requests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode batch requests: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(&RequestOptions{
+		Method:  endpointBatch.Method,
+		Path:    endpointBatch.path(),
+		Body:    url.Values{"batch": {string(payload)}},
+		Context: ctx,
+	}, c.getAccessTokenSafe())
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, c.handleAPIError(resp)
+	}
+
+	// Entries are null when the API did not run a request
+	var raw []*BatchResponse
+	if err := safeJSONUnmarshal(resp.Body, &raw, "batch", resp.RequestID); err != nil {
+		return nil, err
+	}
+	if len(raw) != len(requests) {
+		return nil, NewAPIError(200, "Unexpected batch response", fmt.Sprintf("expected %d results, got %d", len(requests), len(raw)), resp.RequestID)
+	}
+
+	responses := make([]BatchResponse, len(raw))
+	for i, r := range raw {
+		if r != nil {
+			responses[i] = *r
+		}
+	}
+	return responses, nil
+}
+
+// GetPostsInsights retrieves insights for several posts using batch requests.
+// Results are keyed by post ID. If some posts fail, the successful results are
+// still returned together with a *BatchError describing the failures.
+func (c *Client) GetPostsInsights(ctx context.Context, postIDs []PostID, metrics []string) (map[PostID]*InsightsResponse, error) {
+	if len(postIDs) == 0 {
+		return nil, NewValidationError(400, ErrEmptyPostID, "at least one post ID is required", "postIDs")
+	}
+
+	validMetrics := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		if err := c.validatePostInsightMetric(metric); err != nil {
+			return nil, err
+		}
+		validMetrics = append(validMetrics, metric)
+	}
+	if len(validMetrics) == 0 {
+		validMetrics = []string{
+			string(PostInsightViews),
+			string(PostInsightLikes),
+			string(PostInsightReplies),
+			string(PostInsightReposts),
+		}
+	}
+
+	params := url.Values{}
+	params.Set("metric", strings.Join(validMetrics, ","))
+
+	requests := make([]BatchRequest, len(postIDs))
+	for i, postID := range postIDs {
+		if !postID.Valid() {
+			return nil, NewValidationError(400, ErrEmptyPostID, "postID cannot be empty", "postIDs")
+		}
+		requests[i] = NewBatchGET(endpointPostInsights.path(postID.String()), params)
+	}
+
+	responses, err := c.Batch(ctx, requests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post insights: %w", err)
+	}
+
+	results := make(map[PostID]*InsightsResponse, len(postIDs))
+	failed := make(map[string]error)
+	for i, resp := range responses {
+		var insights InsightsResponse
+		if err := resp.Decode(&insights); err != nil {
+			failed[postIDs[i].String()] = err
+			continue
+		}
+		results[postIDs[i]] = &insights
+	}
+
+	if len(failed) > 0 {
+		return results, &BatchError{Errors: failed}
+	}
+	return results, nil
+}
+
+// HideReplies hides several replies using batch requests. If some replies
+// cannot be hidden, a *BatchError describing the failures is returned.
+func (c *Client) HideReplies(ctx context.Context, replyIDs []PostID) error {
+	return c.manageRepliesVisibility(ctx, replyIDs, true)
+}
+
+// UnhideReplies unhides several replies using batch requests. If some replies
+// cannot be unhidden, a *BatchError describing the failures is returned.
+func (c *Client) UnhideReplies(ctx context.Context, replyIDs []PostID) error {
+	return c.manageRepliesVisibility(ctx, replyIDs, false)
+}
+
+// manageRepliesVisibility is the batched counterpart of manageReplyVisibility
+func (c *Client) manageRepliesVisibility(ctx context.Context, replyIDs []PostID, hide bool) error {
+	if len(replyIDs) == 0 {
+		return NewValidationError(400, "Reply ID is required", "at least one reply ID is required", "replyIDs")
+	}
+
+	body := url.Values{}
+	body.Set("hide", fmt.Sprintf("%t", hide))
+
+	requests := make([]BatchRequest, len(replyIDs))
+	for i, replyID := range replyIDs {
+		if !replyID.Valid() {
+			return NewValidationError(400, "Reply ID is required", "Reply ID cannot be empty", "replyIDs")
+		}
+		requests[i] = NewBatchPOST(endpointManageReply.path(replyID.String()), body)
+	}
+
+	action := "unhide"
+	if hide {
+		action = "hide"
+	}
+
+	responses, err := c.Batch(ctx, requests)
+	if err != nil {
+		return fmt.Errorf("failed to %s replies: %w", action, err)
+	}
+
+	failed := make(map[string]error)
+	for i, resp := range responses {
+		if err := resp.Err(); err != nil {
+			failed[replyIDs[i].String()] = err
+		}
+	}
+
+	if logger := c.currentConfig().Logger; logger != nil {
+		logger.Info(fmt.Sprintf("Batch %s of replies completed", action),
+			"requested", len(replyIDs),
+			"failed", len(failed),
+		)
+	}
+
+	if len(failed) > 0 {
+		return &BatchError{Errors: failed}
+	}
+	return nil
+}
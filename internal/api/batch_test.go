@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// batchHandler answers batch calls by passing each decoded request to respond.
+func batchHandler(t *testing.T, calls *int, respond func(req map[string]string) any) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "refresh_access_token") {
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+			return
+		}
+		if r.Method != http.MethodPost || r.URL.Path != "/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		*calls++
+
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		var reqs []map[string]string
+		if err := json.Unmarshal([]byte(r.PostForm.Get("batch")), &reqs); err != nil {
+			t.Fatalf("failed to decode batch param: %v", err)
+		}
+
+		results := make([]any, len(reqs))
+		for i, req := range reqs {
+			results[i] = respond(req)
+		}
+		_ = json.NewEncoder(w).Encode(results)
+	}
+}
+
+func TestBatch_EncodesRequests(t *testing.T) {
+	var seen []map[string]string
+	calls := 0
+	client, server := createTestClient(t, batchHandler(t, &calls, func(req map[string]string) any {
+		seen = append(seen, req)
+		return map[string]any{"code": 200, "body": `{"id":"1"}`}
+	}))
+	defer server.Close()
+
+	body := map[string][]string{"hide": {"true"}}
+	responses, err := client.Batch(context.Background(), []BatchRequest{
+		NewBatchGET("/123/insights", map[string][]string{"metric": {"views"}}),
+		NewBatchPOST("/456/manage_reply", body),
+	})
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if seen[0]["method"] != "GET" || seen[0]["relative_url"] != "123/insights?metric=views" {
+		t.Errorf("unexpected GET request: %v", seen[0])
+	}
+	if seen[1]["method"] != "POST" || seen[1]["relative_url"] != "456/manage_reply" || seen[1]["body"] != "hide=true" {
+		t.Errorf("unexpected POST request: %v", seen[1])
+	}
+}
+
+func TestBatch_ChunksLargeRequests(t *testing.T) {
+	calls := 0
+	client, server := createTestClient(t, batchHandler(t, &calls, func(req map[string]string) any {
+		return map[string]any{"code": 200, "body": "{}"}
+	}))
+	defer server.Close()
+
+	requests := make([]BatchRequest, MaxBatchSize+5)
+	for i := range requests {
+		requests[i] = NewBatchGET(fmt.Sprintf("/%d", i), nil)
+	}
+
+	responses, err := client.Batch(context.Background(), requests)
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	if len(responses) != len(requests) {
+		t.Errorf("expected %d responses, got %d", len(requests), len(responses))
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 batch calls, got %d", calls)
+	}
+}
+
+func TestBatch_Empty(t *testing.T) {
+	client := &Client{}
+	if _, err := client.Batch(context.Background(), nil); err == nil {
+		t.Error("expected error for empty batch")
+	}
+}
+
+func TestBatchResponse_Err(t *testing.T) {
+	tests := []struct {
+		name    string
+		resp    BatchResponse
+		wantErr bool
+		check   func(error) bool
+	}{
+		{"success", BatchResponse{Code: 200, Body: "{}"}, false, nil},
+		{"not executed", BatchResponse{}, true, func(err error) bool { return IsNetworkError(err) }},
+		{"auth", BatchResponse{Code: 403, Body: `{"error":{"message":"denied","code":10}}`}, true, func(err error) bool { return IsAuthenticationError(err) }},
+		{"rate limited", BatchResponse{Code: 429, Body: `{"error":{"message":"slow down"}}`}, true, func(err error) bool { return IsRateLimitError(err) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.resp.Err()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Err() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil && !tt.check(err) {
+				t.Errorf("unexpected error type: %T", err)
+			}
+		})
+	}
+}
+
+func TestGetPostsInsights_PartialFailure(t *testing.T) {
+	calls := 0
+	client, server := createTestClient(t, batchHandler(t, &calls, func(req map[string]string) any {
+		if strings.HasPrefix(req["relative_url"], "bad/") {
+			return map[string]any{"code": 400, "body": `{"error":{"message":"Invalid post"}}`}
+		}
+		return map[string]any{"code": 200, "body": `{"data":[{"name":"views","period":"lifetime","values":[{"value":42}]}]}`}
+	}))
+	defer server.Close()
+
+	results, err := client.GetPostsInsights(context.Background(), []PostID{"good", "bad"}, []string{"views"})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected *BatchError, got %v", err)
+	}
+	if _, ok := batchErr.Errors["bad"]; !ok || len(batchErr.Errors) != 1 {
+		t.Errorf("unexpected failures: %v", batchErr.Errors)
+	}
+	if results["good"] == nil || len(results["good"].Data) != 1 {
+		t.Errorf("expected insights for good post, got %v", results["good"])
+	}
+	if calls != 1 {
+		t.Errorf("expected a single batch call, got %d", calls)
+	}
+}
+
+func TestHideReplies(t *testing.T) {
+	calls := 0
+	client, server := createTestClient(t, batchHandler(t, &calls, func(req map[string]string) any {
+		if req["body"] != "hide=true" {
+			t.Errorf("unexpected body: %q", req["body"])
+		}
+		return map[string]any{"code": 200, "body": `{"success":true}`}
+	}))
+	defer server.Close()
+
+	if err := client.HideReplies(context.Background(), []PostID{"1", "2", "3"}); err != nil {
+		t.Fatalf("HideReplies failed: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single batch call, got %d", calls)
+	}
+}
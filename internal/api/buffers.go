@@ -0,0 +1,110 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/url"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// maxPooledBuffer bounds the buffers kept for reuse, so one large response
+// does not pin its memory for the life of a daemon
+const maxPooledBuffer = 1 << 20
+
+// bufferPool holds the buffers request bodies are encoded into and response
+// bodies are read into
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer) //nolint:errcheck // The pool only holds buffers
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// writeForm appends values to buf URL-encoded, as url.Values.Encode does
+// but without building a string
+func writeForm(buf *bytes.Buffer, values url.Values) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	first := true
+	for _, key := range keys {
+		escaped := url.QueryEscape(key)
+		for _, value := range values[key] {
+			if !first {
+				buf.WriteByte('&')
+			}
+			first = false
+			buf.WriteString(escaped)
+			buf.WriteByte('=')
+			buf.WriteString(url.QueryEscape(value))
+		}
+	}
+}
+
+// readBody reads r to the end through a pooled buffer, returning a copy of
+// exactly the size read, where io.ReadAll would grow its result a few times
+func readBody(r io.Reader) ([]byte, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return bytes.Clone(buf.Bytes()), nil
+}
+
+// pooledBody is a request body encoded into a pooled buffer. The transport
+// may read and close a body after the request returns, and may ask for the
+// body again to retry, so the buffer goes back to the pool only once the
+// request and every body handed out are done with it.
+type pooledBody struct {
+	buf  *bytes.Buffer
+	refs atomic.Int32
+}
+
+// newPooledBody takes buf, holding it until release is called and every
+// body from open is closed
+func newPooledBody(buf *bytes.Buffer) *pooledBody {
+	p := &pooledBody{buf: buf}
+	p.refs.Store(1)
+	return p
+}
+
+// open returns a reader of the body, for http.Request.Body and GetBody
+func (p *pooledBody) open() io.ReadCloser {
+	p.refs.Add(1)
+	return &pooledReader{Reader: bytes.NewReader(p.buf.Bytes()), body: p}
+}
+
+func (p *pooledBody) release() {
+	if p.refs.Add(-1) == 0 {
+		putBuffer(p.buf)
+	}
+}
+
+type pooledReader struct {
+	*bytes.Reader
+	body   *pooledBody
+	closed atomic.Bool
+}
+
+func (r *pooledReader) Close() error {
+	if !r.closed.Swap(true) {
+		r.body.release()
+	}
+	return nil
+}
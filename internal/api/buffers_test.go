@@ -0,0 +1,188 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWriteForm_MatchesEncode(t *testing.T) {
+	for _, values := range []url.Values{
+		{},
+		{"fields": {"id,text"}},
+		{"text": {"Hello, world & friends? 100% ✓"}, "media_type": {"TEXT"}},
+		{"children": {"1", "2", "3"}, "a b": {"c=d"}},
+	} {
+		var buf bytes.Buffer
+		buf.WriteString("prefix?")
+		writeForm(&buf, values)
+		if got, want := buf.String(), "prefix?"+values.Encode(); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestPooledBody_HeldUntilEveryBodyCloses(t *testing.T) {
+	buf := getBuffer()
+	buf.WriteString("text=hi")
+	body := newPooledBody(buf)
+	first, second := body.open(), body.open()
+	body.release()
+
+	first.Close() //nolint:errcheck,gosec // Always nil
+	first.Close() //nolint:errcheck,gosec // A second close must not release again
+	if body.refs.Load() != 1 {
+		t.Fatalf("expected the buffer held by the open body, got %d references", body.refs.Load())
+	}
+	data, err := io.ReadAll(second)
+	if err != nil || string(data) != "text=hi" {
+		t.Errorf("got %q, %v", data, err)
+	}
+	second.Close() //nolint:errcheck,gosec // Always nil
+	if body.refs.Load() != 0 {
+		t.Errorf("expected the buffer released, got %d references", body.refs.Load())
+	}
+}
+
+func TestHTTPClient_PooledRequestBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body) //nolint:errcheck // Test server
+		if r.ContentLength != int64(len(body)) {
+			http.Error(w, "content length mismatch", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		w.Write(body) //nolint:errcheck,gosec // Test server
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(NewConfig(), nil)
+	client.baseURL = server.URL
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			text := strings.Repeat("x", i*100)
+
+			resp, err := client.POST(context.Background(), "/form", url.Values{"text": {text}, "n": {"1"}}, "token")
+			if err != nil {
+				t.Errorf("form: %v", err)
+				return
+			}
+			if got, want := string(resp.Body), "n=1&text="+text; got != want {
+				t.Errorf("form: got %q, want %q", got, want)
+			}
+
+			resp, err = client.POST(context.Background(), "/json", map[string]string{"text": text}, "token")
+			if err != nil {
+				t.Errorf("json: %v", err)
+				return
+			}
+			if got, want := string(resp.Body), `{"text":"`+text+`"}`; got != want {
+				t.Errorf("json: got %q, want %q", got, want)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func newBenchmarkClient(b *testing.B, handler http.HandlerFunc) *HTTPClient {
+	b.Helper()
+	server := httptest.NewServer(handler)
+	b.Cleanup(server.Close)
+
+	client := NewHTTPClient(NewConfig(), nil)
+	client.baseURL = server.URL
+	return client
+}
+
+// respondChunked answers every request with response, without a length so
+// the body is chunked, as the Graph API sends it
+func respondChunked(response []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body) //nolint:errcheck,gosec // Test server
+		w.(http.Flusher).Flush()
+		w.Write(response) //nolint:errcheck,gosec // Test server
+	}
+}
+
+// benchmarkPage is a page of 100 posts, the largest a list returns
+var benchmarkPage = []byte(`{"data":[` + strings.TrimSuffix(strings.Repeat(`{"id":"1234567890","text":"`+strings.Repeat("x", 200)+`"},`, 100), ",") + `]}`)
+
+func BenchmarkHTTPClient_PostForm(b *testing.B) {
+	client := newBenchmarkClient(b, respondChunked([]byte(`{"id":"1234567890"}`)))
+	params := url.Values{
+		"media_type": {"TEXT"},
+		"text":       {strings.Repeat("Hello, Threads! ", 30)},
+		"reply_to":   {"1234567890"},
+	}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := client.POST(ctx, "/me/threads", params, "token"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHTTPClient_GetList(b *testing.B) {
+	client := newBenchmarkClient(b, respondChunked(benchmarkPage))
+	params := url.Values{"fields": {"id,text,timestamp,permalink"}, "limit": {"100"}}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := client.GET(ctx, "/me/threads", params, "token"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadBody(b *testing.B) {
+	data := bytes.Repeat([]byte("x"), 64<<10)
+
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := readBody(bytes.NewReader(data)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("ReadAll", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := io.ReadAll(bytes.NewReader(data)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkWriteForm(b *testing.B) {
+	values := url.Values{"fields": {"id,text,timestamp"}, "limit": {"100"}, "since": {"2026-01-01"}}
+
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			buf := getBuffer()
+			writeForm(buf, values)
+			putBuffer(buf)
+		}
+	})
+	b.Run("Encode", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = []byte(values.Encode())
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrCallBudgetExhausted is returned instead of sending a request once the
+// CallBudget of its context is spent.
+var ErrCallBudgetExhausted = errors.New("API call budget exhausted")
+
+// CallBudget caps the number of HTTP requests sent to the Threads API with a
+// context, so unattended jobs cannot use up the app's rate limit. Every
+// attempt counts, including retries. A CallBudget is safe for concurrent use.
+type CallBudget struct {
+	mu        sync.Mutex
+	max       int
+	used      int
+	exhausted bool
+}
+
+// NewCallBudget returns a budget of max requests; zero or less is unlimited.
+func NewCallBudget(max int) *CallBudget {
+	return &CallBudget{max: max}
+}
+
+type callBudgetKey struct{}
+
+// WithCallBudget returns a context whose requests are counted against b.
+func WithCallBudget(ctx context.Context, b *CallBudget) context.Context {
+	return context.WithValue(ctx, callBudgetKey{}, b)
+}
+
+// Used returns the number of requests sent so far.
+func (b *CallBudget) Used() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.used
+}
+
+// Remaining returns the number of requests left, or -1 when unlimited.
+func (b *CallBudget) Remaining() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.max <= 0 {
+		return -1
+	}
+	return b.max - b.used
+}
+
+// Exhausted reports whether a request was refused because the budget was spent.
+func (b *CallBudget) Exhausted() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.exhausted
+}
+
+// spendCallBudget counts one request against the budget of ctx, if any
+func spendCallBudget(ctx context.Context) error {
+	b, ok := ctx.Value(callBudgetKey{}).(*CallBudget)
+	if !ok || b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.max > 0 && b.used >= b.max {
+		b.exhausted = true
+		return ErrCallBudgetExhausted
+	}
+	b.used++
+	return nil
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCallBudget_RefusesRequestsOnceSpent(t *testing.T) {
+	var sent atomic.Int32
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sent.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "refresh_access_token") {
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"123","text":"hello"}`))
+	})
+	defer server.Close()
+
+	budget := NewCallBudget(3)
+	ctx := WithCallBudget(context.Background(), budget)
+
+	var err error
+	for i := 0; i < 5 && err == nil; i++ {
+		_, err = client.GetPost(ctx, "123")
+	}
+	if !errors.Is(err, ErrCallBudgetExhausted) {
+		t.Fatalf("expected the budget to run out, got %v", err)
+	}
+	if !budget.Exhausted() || budget.Used() != 3 || budget.Remaining() != 0 {
+		t.Errorf("unexpected budget: used %d, remaining %d, exhausted %v", budget.Used(), budget.Remaining(), budget.Exhausted())
+	}
+	if int(sent.Load()) != budget.Used() {
+		t.Errorf("expected %d requests sent, got %d", budget.Used(), sent.Load())
+	}
+}
+
+func TestCallBudget_Unlimited(t *testing.T) {
+	budget := NewCallBudget(0)
+	ctx := WithCallBudget(context.Background(), budget)
+	for range 10 {
+		if err := spendCallBudget(ctx); err != nil {
+			t.Fatalf("expected no limit, got %v", err)
+		}
+	}
+	if budget.Used() != 10 || budget.Remaining() != -1 || budget.Exhausted() {
+		t.Errorf("unexpected budget: used %d, remaining %d", budget.Used(), budget.Remaining())
+	}
+	if err := spendCallBudget(context.Background()); err != nil {
+		t.Errorf("expected no budget without WithCallBudget, got %v", err)
+	}
+}
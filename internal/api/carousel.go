@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// CarouselProgress records the child containers of a carousel as they are
+// created. Saving it after each child lets a carousel that failed midway be
+// resumed without creating, and uploading, its first items again.
+type CarouselProgress struct {
+	// Children holds the container of each item, in item order, once it is
+	// ready; items not created yet are missing or empty
+	Children []ContainerID `json:"children"`
+}
+
+// Done returns the number of children that are ready.
+func (p *CarouselProgress) Done() int {
+	n := 0
+	for _, id := range p.Children {
+		if id.Valid() {
+			n++
+		}
+	}
+	return n
+}
+
+// CarouselOptions controls CreateCarousel. A nil *CarouselOptions uses the
+// defaults.
+type CarouselOptions struct {
+	// Wait controls the wait for each child and for the carousel
+	Wait *WaitOptions
+	// Progress, if set, holds the children of an earlier attempt. They are
+	// reused unless they failed or expired, and Progress is updated as
+	// children are created.
+	Progress *CarouselProgress
+	// OnChild, if set, is called when the child of item index is ready,
+	// usually to save the progress. An error stops the carousel.
+	OnChild func(index int, progress *CarouselProgress) error
+}
+
+// CreateCarousel creates a child container for each item, waits for each to
+// finish processing, and publishes them as a carousel post with the text
+// and settings of content; content.Children is set to the children. Errors
+// about an item name it, starting from item 1.
+func (c *Client) CreateCarousel(ctx context.Context, content *CarouselPostContent, items []CarouselItem, opts *CarouselOptions) (*Post, error) {
+	if content == nil {
+		content = &CarouselPostContent{}
+	}
+	if opts == nil {
+		opts = &CarouselOptions{}
+	}
+	progress := opts.Progress
+	if progress == nil {
+		progress = &CarouselProgress{}
+	}
+	for len(progress.Children) < len(items) {
+		progress.Children = append(progress.Children, "")
+	}
+	progress.Children = progress.Children[:len(items)]
+
+	for i, item := range items {
+		if id := progress.Children[i]; id.Valid() {
+			err := c.ContainerFromID(id).Wait(ctx, opts.Wait)
+			if err == nil {
+				continue
+			}
+			// A child that failed or expired is created again
+			var containerErr *ContainerError
+			if !errors.As(err, &containerErr) || (containerErr.Status != ContainerStatusError && containerErr.Status != ContainerStatusExpired) {
+				return nil, fmt.Errorf("carousel item %d: %w", i+1, err)
+			}
+			progress.Children[i] = ""
+		}
+
+		container, err := c.NewContainer(item)
+		if err == nil {
+			err = container.Create(ctx)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create container for carousel item %d: %w", i+1, err)
+		}
+		if err := container.Wait(ctx, opts.Wait); err != nil {
+			return nil, fmt.Errorf("carousel item %d not ready: %w", i+1, err)
+		}
+		progress.Children[i] = container.ID
+		if opts.OnChild != nil {
+			if err := opts.OnChild(i, progress); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	content.Children = make([]string, len(progress.Children))
+	for i, id := range progress.Children {
+		content.Children[i] = id.String()
+	}
+	container, err := c.NewContainer(content)
+	if err != nil {
+		return nil, err
+	}
+	return container.PublishWithWait(ctx, opts.Wait)
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// CarouselItem describes one media item of a carousel before any container
+// has been created for it
+type CarouselItem struct {
+	MediaType string `json:"media_type"` // IMAGE or VIDEO
+	URL       string `json:"url"`
+	AltText   string `json:"alt_text,omitempty"`
+}
+
+// CarouselViolation is a single problem found by ValidateCarouselPlan.
+// Index is the zero-based item index, or -1 for problems with the carousel
+// as a whole.
+type CarouselViolation struct {
+	Index   int    `json:"index"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// String formats the violation with a one-based item number
+func (v CarouselViolation) String() string {
+	if v.Index < 0 {
+		return v.Message
+	}
+	return fmt.Sprintf("item %d: %s", v.Index+1, v.Message)
+}
+
+// CarouselPlanError reports every violation found in a carousel plan. It
+// unwraps to a *ValidationError so callers handling validation errors
+// generically keep working.
+type CarouselPlanError struct {
+	Violations []CarouselViolation `json:"violations"`
+}
+
+// Error implements the error interface
+func (e *CarouselPlanError) Error() string {
+	return "invalid carousel: " + e.summary()
+}
+
+// Unwrap returns the violations as a single ValidationError
+func (e *CarouselPlanError) Unwrap() error {
+	field := "children"
+	if len(e.Violations) == 1 {
+		field = e.Violations[0].Field
+	}
+	return NewValidationError(400, "Invalid carousel", e.summary(), field)
+}
+
+func (e *CarouselPlanError) summary() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+var (
+	carouselImageExts = []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
+	carouselVideoExts = []string{".mp4", ".mov", ".m4v", ".webm"}
+)
+
+// ValidateCarouselPlan checks a carousel before any container is created:
+// the item count, that every item is an image or video whose URL does not
+// contradict its media type, alt text length and URL schemes. Unlike the
+// per-container checks it collects every problem and returns them together
+// as a *CarouselPlanError, so a bad item is reported before earlier items
+// have been uploaded.
+func ValidateCarouselPlan(items []CarouselItem) error {
+	var violations []CarouselViolation
+
+	if len(items) < MinCarouselItems || len(items) > MaxCarouselItems {
+		violations = append(violations, CarouselViolation{
+			Index:   -1,
+			Field:   "children",
+			Message: fmt.Sprintf("carousel must have %d-%d items (got %d)", MinCarouselItems, MaxCarouselItems, len(items)),
+		})
+	}
+
+	for i, item := range items {
+		violations = append(violations, validateCarouselItem(i, item)...)
+	}
+
+	if len(violations) > 0 {
+		return &CarouselPlanError{Violations: violations}
+	}
+	return nil
+}
+
+// validateCarouselItem returns the violations for a single carousel item
+func validateCarouselItem(index int, item CarouselItem) []CarouselViolation {
+	var violations []CarouselViolation
+	add := func(field, format string, args ...any) {
+		violations = append(violations, CarouselViolation{Index: index, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	mediaType := strings.ToUpper(item.MediaType)
+	if mediaType != MediaTypeImage && mediaType != MediaTypeVideo {
+		add("media_type", "media type must be %s or %s (got %q)", MediaTypeImage, MediaTypeVideo, item.MediaType)
+	}
+
+	if alt := utf8.RuneCountInString(item.AltText); alt > MaxAltTextLength {
+		add("alt_text", "alt text is limited to %d characters (got %d)", MaxAltTextLength, alt)
+	}
+
+	if item.URL == "" {
+		add("media_url", "media URL is required")
+		return violations
+	}
+	u, err := url.Parse(item.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		add("media_url", "media URL must be an absolute http:// or https:// URL")
+		return violations
+	}
+
+	// Catch a video URL declared as an image and vice versa
+	ext := strings.ToLower(path.Ext(u.Path))
+	switch {
+	case mediaType == MediaTypeImage && slices.Contains(carouselVideoExts, ext):
+		add("media_type", "URL looks like a video (%s) but media type is %s", ext, MediaTypeImage)
+	case mediaType == MediaTypeVideo && slices.Contains(carouselImageExts, ext):
+		add("media_type", "URL looks like an image (%s) but media type is %s", ext, MediaTypeVideo)
+	}
+
+	return violations
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateCarouselPlan_Valid(t *testing.T) {
+	items := []CarouselItem{
+		{MediaType: MediaTypeImage, URL: "https://example.com/a.jpg", AltText: "A cat"},
+		{MediaType: MediaTypeVideo, URL: "https://example.com/b.mp4"},
+		{MediaType: "image", URL: "https://example.com/render?id=3"},
+	}
+	if err := ValidateCarouselPlan(items); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateCarouselPlan_ReportsAllViolations(t *testing.T) {
+	items := []CarouselItem{
+		{MediaType: MediaTypeImage, URL: "ftp://example.com/a.jpg"},
+		{MediaType: MediaTypeImage, URL: "https://example.com/b.mp4"},
+		{MediaType: MediaTypeText, URL: "https://example.com/c.jpg", AltText: strings.Repeat("x", MaxAltTextLength+1)},
+	}
+
+	err := ValidateCarouselPlan(items)
+	var planErr *CarouselPlanError
+	if !errors.As(err, &planErr) {
+		t.Fatalf("expected CarouselPlanError, got %T: %v", err, err)
+	}
+
+	want := []CarouselViolation{
+		{Index: 0, Field: "media_url"},
+		{Index: 1, Field: "media_type"},
+		{Index: 2, Field: "media_type"},
+		{Index: 2, Field: "alt_text"},
+	}
+	if len(planErr.Violations) != len(want) {
+		t.Fatalf("expected %d violations, got %d: %v", len(want), len(planErr.Violations), planErr.Violations)
+	}
+	for i, w := range want {
+		got := planErr.Violations[i]
+		if got.Index != w.Index || got.Field != w.Field {
+			t.Errorf("violation %d = {%d %s}, want {%d %s}", i, got.Index, got.Field, w.Index, w.Field)
+		}
+	}
+}
+
+func TestValidateCarouselPlan_Count(t *testing.T) {
+	item := CarouselItem{MediaType: MediaTypeImage, URL: "https://example.com/a.jpg"}
+
+	tooMany := make([]CarouselItem, MaxCarouselItems+1)
+	for i := range tooMany {
+		tooMany[i] = item
+	}
+
+	for name, items := range map[string][]CarouselItem{
+		"too few":  {item},
+		"too many": tooMany,
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCarouselPlan(items)
+			var planErr *CarouselPlanError
+			if !errors.As(err, &planErr) {
+				t.Fatalf("expected CarouselPlanError, got %T: %v", err, err)
+			}
+			if len(planErr.Violations) != 1 || planErr.Violations[0].Index != -1 {
+				t.Errorf("expected a single carousel-level violation, got %v", planErr.Violations)
+			}
+		})
+	}
+}
+
+func TestCarouselPlanError_UnwrapsToValidationError(t *testing.T) {
+	err := ValidateCarouselPlan(nil)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected error to unwrap to ValidationError, got %T", err)
+	}
+	if validationErr.Field != "children" {
+		t.Errorf("expected field 'children', got %q", validationErr.Field)
+	}
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// carouselServer creates a child "c-" plus the file name of each image,
+// failing the images in fail and reporting the children in expired as
+// expired. It records the children created and those of the carousel.
+type carouselServer struct {
+	t       *testing.T
+	mu      sync.Mutex
+	fail    []string
+	expired []string
+	created []string
+	posted  string
+}
+
+func (s *carouselServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.Contains(r.URL.Path, "refresh_access_token"):
+		_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/12345/threads":
+		if err := r.ParseForm(); err != nil {
+			s.t.Errorf("failed to parse form: %v", err)
+		}
+		if r.PostForm.Get("media_type") == MediaTypeCarousel {
+			s.posted = strings.Join(r.PostForm["children"], ",")
+			_, _ = w.Write([]byte(`{"id":"carousel"}`))
+			return
+		}
+		name := strings.TrimSuffix(path.Base(r.PostForm.Get("image_url")), ".jpg")
+		if slices.Contains(s.fail, name) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"Invalid image","type":"OAuthException","code":100}}`))
+			return
+		}
+		s.created = append(s.created, "c-"+name)
+		_, _ = w.Write([]byte(`{"id":"c-` + name + `"}`))
+	case r.Method == http.MethodGet && (strings.HasPrefix(r.URL.Path, "/c-") || r.URL.Path == "/carousel"):
+		id := strings.TrimPrefix(r.URL.Path, "/")
+		status := ContainerStatusFinished
+		if slices.Contains(s.expired, id) {
+			status = ContainerStatusExpired
+		}
+		_, _ = w.Write([]byte(`{"id":"` + id + `","status":"` + status + `"}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/12345/threads_publish":
+		_, _ = w.Write([]byte(`{"id":"p1"}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/p1":
+		_, _ = w.Write([]byte(`{"id":"p1","media_type":"CAROUSEL_ALBUM","timestamp":"2024-01-01T00:00:00Z"}`))
+	default:
+		s.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func carouselImages(names ...string) []CarouselItem {
+	items := make([]CarouselItem, len(names))
+	for i, name := range names {
+		items[i] = CarouselItem{MediaType: MediaTypeImage, URL: "https://example.com/" + name + ".jpg"}
+	}
+	return items
+}
+
+func TestCreateCarousel_ResumesFromProgress(t *testing.T) {
+	server := &carouselServer{t: t, fail: []string{"c"}}
+	client, ts := createTestClient(t, server.ServeHTTP)
+	defer ts.Close()
+
+	items := carouselImages("a", "b", "c")
+	opts := &CarouselOptions{
+		Wait:     &WaitOptions{PollInterval: time.Millisecond},
+		Progress: &CarouselProgress{},
+	}
+	var saved []int
+	opts.OnChild = func(i int, p *CarouselProgress) error {
+		saved = append(saved, i)
+		return nil
+	}
+
+	_, err := client.CreateCarousel(context.Background(), &CarouselPostContent{Text: "Trip"}, items, opts)
+	if err == nil || !strings.Contains(err.Error(), "carousel item 3") {
+		t.Fatalf("expected item 3 to fail, got %v", err)
+	}
+	if opts.Progress.Done() != 2 || !slices.Equal(saved, []int{0, 1}) {
+		t.Fatalf("expected two children saved, got %v after %v", opts.Progress.Children, saved)
+	}
+
+	// The second attempt creates the missing child only
+	server.fail, server.created = nil, nil
+	post, err := client.CreateCarousel(context.Background(), &CarouselPostContent{Text: "Trip"}, items, opts)
+	if err != nil {
+		t.Fatalf("CreateCarousel: %v", err)
+	}
+	if post.ID != "p1" {
+		t.Errorf("expected post p1, got %q", post.ID)
+	}
+	if !slices.Equal(server.created, []string{"c-c"}) || server.posted != "c-a,c-b,c-c" {
+		t.Errorf("expected only c-c created and all children posted, got %v and %q", server.created, server.posted)
+	}
+}
+
+func TestCreateCarousel_RecreatesExpiredChildren(t *testing.T) {
+	server := &carouselServer{t: t, expired: []string{"c-old"}}
+	client, ts := createTestClient(t, server.ServeHTTP)
+	defer ts.Close()
+
+	progress := &CarouselProgress{Children: []ContainerID{"c-a", "c-old"}}
+	_, err := client.CreateCarousel(context.Background(), nil, carouselImages("a", "b"), &CarouselOptions{
+		Wait:     &WaitOptions{PollInterval: time.Millisecond},
+		Progress: progress,
+	})
+	if err != nil {
+		t.Fatalf("CreateCarousel: %v", err)
+	}
+	if !slices.Equal(server.created, []string{"c-b"}) || server.posted != "c-a,c-b" {
+		t.Errorf("expected the expired child made again, got %v and %q", server.created, server.posted)
+	}
+}
@@ -7,13 +7,14 @@
 //
 // # Quick Start
 //
-// For users with an existing access token:
+// For users with an existing access token, construct a client with New and
+// functional options:
 //
-//	client, err := threads.NewClientWithToken("your-access-token", &threads.Config{
-//		ClientID:     "your-client-id",
-//		ClientSecret: "your-client-secret",
-//		RedirectURI:  "your-redirect-uri",
-//	})
+//	client, err := threads.New("your-access-token",
+//		threads.WithUserID("your-user-id"),
+//		threads.WithRetry(&threads.RetryConfig{MaxRetries: 5, InitialDelay: time.Second, MaxDelay: time.Minute, BackoffFactor: 2}),
+//		threads.WithLogger(logger),
+//	)
 //	if err != nil {
 //		log.Fatal(err)
 //	}
@@ -23,6 +24,9 @@
 //		Text: "Hello from Go!",
 //	})
 //
+// The Config struct accepted by NewClient and NewClientWithToken is still
+// supported for backwards compatibility, but new code should prefer New.
+//
 // For OAuth 2.0 authentication flow:
 //
 //	config := &threads.Config{
@@ -48,28 +52,54 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // Client provides access to the Threads API with thread-safe operations.
 // It implements the ClientInterface and all its composed interfaces.
+//
+// All methods are safe for concurrent use, so one client can serve the
+// parallel requests of batch operations and of AsUser clients:
+//   - the token is read under a lock for each request, and a refresh,
+//     automatic or not, replaces it whole; concurrent automatic refreshes
+//     are made once;
+//   - UpdateConfig replaces the configuration whole, and requests already
+//     started finish with the previous one;
+//   - the rate limiter, response cache and HTTP trace have locks of their
+//     own, and waiting out a rate limit blocks neither other requests'
+//     bookkeeping nor their cancellation.
+//
+// The Config given to a client must not be modified afterwards; use
+// UpdateConfig.
 type Client struct {
-	config       *Config
+	config       atomic.Pointer[Config] // Replaced whole by UpdateConfig
 	httpClient   *HTTPClient
 	rateLimiter  *RateLimiter
-	baseURL      string
 	accessToken  string
 	tokenInfo    *TokenInfo
 	tokenStorage TokenStorage
 	mu           sync.RWMutex // Protects token-related fields
+	refreshMu    sync.Mutex   // Serializes automatic token refreshes
+
+	// scoped is set on clients made by AsUser, whose publishing quota is
+	// not the one in the parent's RateLimitStore
+	scoped bool
 }
 
+// TokenRefreshCallback receives each token the client refreshes, including
+// the refreshes EnsureValidToken makes on its own before a request. An error
+// is logged and does not fail the request: the new token is still in use.
+type TokenRefreshCallback func(token *TokenInfo) error
+
 // Config holds configuration settings for the Threads API client.
 // Required fields: ClientID, ClientSecret, RedirectURI.
 // All other fields have sensible defaults.
@@ -117,9 +147,21 @@ type Config struct {
 
 	// TokenStorage provides persistent token storage (optional).
 	// If nil, tokens will be stored in memory only and lost when the client
-	// is destroyed. Implement the TokenStorage interface for persistence.
+	// is destroyed. Use FileTokenStorage or CallbackTokenStorage, or implement
+	// the TokenStorage interface, to persist tokens including refreshed ones.
 	TokenStorage TokenStorage
 
+	// TokenRefreshWindow controls automatic token refresh (optional).
+	// EnsureValidToken refreshes the access token when it expires within this
+	// window. Default: DefaultTokenRefreshWindow. A negative value disables
+	// automatic refresh.
+	TokenRefreshWindow time.Duration
+
+	// OnTokenRefresh is called with the new token after every successful
+	// refresh (optional). Use it to persist refreshed tokens in storage the
+	// client does not manage, such as an application's credential store.
+	OnTokenRefresh TokenRefreshCallback
+
 	// BaseURL is the base URL for the Threads API (optional).
 	// Default: "https://graph.threads.net". Only change this for testing
 	// or if using a proxy/gateway.
@@ -133,6 +175,32 @@ type Config struct {
 	// Default: false. When true, detailed request/response information
 	// will be logged if a Logger is provided.
 	Debug bool
+
+	// HTTPTrace records every request and response, with credentials redacted,
+	// independently of Logger and Debug (optional). See NewHTTPTrace.
+	HTTPTrace *HTTPTrace
+
+	// ResponseCache keeps the responses of read endpoints such as GetUser,
+	// GetPost and GetPublishingLimits on disk (optional). See
+	// NewResponseCache. If nil, every call makes a request.
+	ResponseCache *ResponseCache
+
+	// RateCoordinator shares rate-limit accounting with other clients, such
+	// as other processes of the CLI (optional). If nil, the client only
+	// accounts for its own requests.
+	RateCoordinator RateCoordinator
+
+	// RateLimitStore keeps the rate limits and publishing quota the client
+	// learns, and restores them in the next client (optional). See
+	// NewFileRateLimitStore.
+	RateLimitStore RateLimitStore
+
+	// CompressRequests gzips request bodies of 1 KiB or more (optional).
+	// Default: false, as not every endpoint accepts compressed bodies; once
+	// one answers 415 Unsupported Media Type the request is sent again
+	// uncompressed and the client stops compressing. Responses are always
+	// requested compressed and decompressed transparently.
+	CompressRequests bool
 }
 
 // RetryConfig defines retry behavior for failed requests with exponential backoff.
@@ -174,22 +242,6 @@ type Logger interface {
 	Error(msg string, fields ...any)
 }
 
-// TokenStorage interface for storing and retrieving tokens.
-// The default MemoryTokenStorage loses tokens when the application terminates.
-type TokenStorage interface {
-	// Store saves a token to persistent storage.
-	// Should return an error if the token cannot be saved.
-	Store(token *TokenInfo) error
-
-	// Load retrieves a token from persistent storage.
-	// Should return an error if no token is found or cannot be loaded.
-	Load() (*TokenInfo, error)
-
-	// Delete removes a token from persistent storage.
-	// Should return an error if the token cannot be deleted.
-	Delete() error
-}
-
 // TokenInfo holds information about the current token
 type TokenInfo struct {
 	AccessToken string    `json:"access_token"`
@@ -199,31 +251,6 @@ type TokenInfo struct {
 	CreatedAt   time.Time `json:"created_at"`
 }
 
-// MemoryTokenStorage provides in-memory token storage (default)
-type MemoryTokenStorage struct {
-	token *TokenInfo
-}
-
-// Store saves the token in memory
-func (m *MemoryTokenStorage) Store(token *TokenInfo) error {
-	m.token = token
-	return nil
-}
-
-// Load retrieves the token from memory
-func (m *MemoryTokenStorage) Load() (*TokenInfo, error) {
-	if m.token == nil {
-		return nil, NewAuthenticationError(401, "No token stored", "Token not found in memory storage")
-	}
-	return m.token, nil
-}
-
-// Delete removes the token from memory
-func (m *MemoryTokenStorage) Delete() error {
-	m.token = nil
-	return nil
-}
-
 // NewConfig creates a new configuration with sensible defaults.
 func NewConfig() *Config {
 	return &Config{
@@ -343,12 +370,9 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("ClientSecret is required")
 	}
 
-	if c.RedirectURI == "" {
-		return fmt.Errorf("RedirectURI is required")
-	}
-
-	// Validate redirect URI format
-	if !strings.HasPrefix(c.RedirectURI, "http://") && !strings.HasPrefix(c.RedirectURI, "https://") {
+	// Only the authorization flow uses the redirect URI; clients built from
+	// an existing token, such as those of 'threads auth token', have none
+	if c.RedirectURI != "" && !strings.HasPrefix(c.RedirectURI, "http://") && !strings.HasPrefix(c.RedirectURI, "https://") {
 		return fmt.Errorf("RedirectURI must be a valid HTTP or HTTPS URL")
 	}
 
@@ -376,6 +400,12 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	return c.validateTransport()
+}
+
+// validateTransport validates the HTTP-level settings that every client needs,
+// regardless of whether app credentials are configured
+func (c *Config) validateTransport() error {
 	if c.HTTPTimeout <= 0 {
 		return fmt.Errorf("HTTPTimeout must be positive")
 	}
@@ -439,10 +469,17 @@ func (c *Config) SetDefaults() {
 	if c.UserAgent == "" {
 		c.UserAgent = DefaultUserAgent
 	}
+
+	if c.TokenRefreshWindow == 0 {
+		c.TokenRefreshWindow = DefaultTokenRefreshWindow
+	}
 }
 
 // NewClient creates a new Threads API client with the provided configuration.
 // The client is thread-safe and can be used concurrently from multiple goroutines.
+//
+// Deprecated: use New with functional options, such as WithAppCredentials
+// and WithBaseURL. NewClient is kept for callers that already build a Config.
 func NewClient(config *Config) (*Client, error) {
 	if config == nil {
 		return nil, fmt.Errorf("config cannot be nil")
@@ -456,6 +493,12 @@ func NewClient(config *Config) (*Client, error) {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	return buildClient(config, nil, nil), nil
+}
+
+// buildClient assembles a client from a validated configuration. A nil
+// rateLimiter or transport selects the defaults.
+func buildClient(config *Config, rateLimiter *RateLimiter, transport *http.Client) *Client {
 	// Use memory storage as default if none provided
 	tokenStorage := config.TokenStorage
 	if tokenStorage == nil {
@@ -463,25 +506,32 @@ func NewClient(config *Config) (*Client, error) {
 	}
 
 	// Create rate limiter
-	rateLimiterConfig := &RateLimiterConfig{
-		InitialLimit:      100, // Default limit, will be updated from API responses
-		BackoffMultiplier: 2.0,
-		MaxBackoff:        5 * time.Minute,
-		QueueSize:         100,
-		Logger:            config.Logger,
+	if rateLimiter == nil {
+		rateLimiterConfig := &RateLimiterConfig{
+			InitialLimit:      100, // Default limit, will be updated from API responses
+			BackoffMultiplier: 2.0,
+			MaxBackoff:        5 * time.Minute,
+			QueueSize:         100,
+			Logger:            config.Logger,
+		}
+		rateLimiter = NewRateLimiter(rateLimiterConfig)
 	}
-	rateLimiter := NewRateLimiter(rateLimiterConfig)
 
 	// Create HTTP client
 	httpClient := NewHTTPClient(config, rateLimiter)
+	if transport != nil {
+		httpClient.client = transport
+	}
 
 	client := &Client{
-		config:       config,
 		httpClient:   httpClient,
 		rateLimiter:  rateLimiter,
-		baseURL:      config.BaseURL,
 		tokenStorage: tokenStorage,
 	}
+	client.config.Store(config)
+
+	// Take over the limits saved by earlier clients
+	httpClient.restoreLimits()
 
 	// Try to load existing token from storage
 	if tokenInfo, err := tokenStorage.Load(); err == nil {
@@ -489,7 +539,7 @@ func NewClient(config *Config) (*Client, error) {
 		client.accessToken = tokenInfo.AccessToken
 	}
 
-	return client, nil
+	return client
 }
 
 // NewClientFromEnv creates a new Threads API client using environment variables.
@@ -505,6 +555,9 @@ func NewClientFromEnv() (*Client, error) {
 
 // NewClientWithToken creates a new Threads API client with an existing access token.
 // The function validates the token by calling the debug_token endpoint.
+//
+// Deprecated: use New, which makes no network calls, and call DebugToken
+// when the token needs validating.
 func NewClientWithToken(accessToken string, config *Config) (*Client, error) {
 	if accessToken == "" {
 		return nil, fmt.Errorf("access token cannot be empty")
@@ -548,6 +601,10 @@ func (c *Client) SetTokenInfo(tokenInfo *TokenInfo) error {
 		return fmt.Errorf("tokenInfo cannot be nil")
 	}
 
+	// A copy, so the caller keeping tokenInfo cannot change it under a request
+	tokenCopy := *tokenInfo
+	tokenInfo = &tokenCopy
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -593,6 +650,11 @@ func (c *Client) IsTokenExpired() bool {
 		return true
 	}
 
+	// App access tokens do not expire
+	if c.tokenInfo.TokenType == TokenTypeApp {
+		return false
+	}
+
 	return time.Now().After(c.tokenInfo.ExpiresAt)
 }
 
@@ -605,6 +667,10 @@ func (c *Client) IsTokenExpiringSoon(within time.Duration) bool {
 		return true
 	}
 
+	if c.tokenInfo.TokenType == TokenTypeApp {
+		return false
+	}
+
 	return time.Now().Add(within).After(c.tokenInfo.ExpiresAt)
 }
 
@@ -626,18 +692,44 @@ func (c *Client) ValidateToken() error {
 	return err
 }
 
-// EnsureValidToken ensures the client has a valid, non-expired token
-// It will attempt to refresh the token if it's expired or expiring soon
+// EnsureValidToken ensures the client has a valid, non-expired token.
+// It refreshes the token when it expires within Config.TokenRefreshWindow.
+// Concurrent callers share a single refresh.
 func (c *Client) EnsureValidToken(ctx context.Context) error {
 	if !c.IsAuthenticated() {
 		return NewAuthenticationError(401, "No token available", "Client is not authenticated")
 	}
 
-	// If token is expired or expiring within 1 hour, try to refresh
-	if c.IsTokenExpired() || c.IsTokenExpiringSoon(time.Hour) {
-		if err := c.RefreshToken(ctx); err != nil {
-			return fmt.Errorf("failed to refresh token: %w", err)
+	window := c.currentConfig().TokenRefreshWindow
+	if window < 0 {
+		if c.IsTokenExpired() {
+			return NewAuthenticationError(401, "Token expired", "The access token has expired and automatic refresh is disabled")
 		}
+		return nil
+	}
+
+	if !c.IsTokenExpired() && !c.IsTokenExpiringSoon(window) {
+		return nil
+	}
+
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	// Another goroutine may have refreshed the token while we waited
+	if !c.IsTokenExpired() && !c.IsTokenExpiringSoon(window) {
+		return nil
+	}
+
+	if err := c.RefreshToken(ctx); err != nil {
+		var persistErr *tokenPersistError
+		if errors.As(err, &persistErr) {
+			// The refreshed token is usable even if it could not be saved
+			if logger := c.currentConfig().Logger; logger != nil {
+				logger.Warn("Failed to persist refreshed token", "error", persistErr.err.Error())
+			}
+			return nil
+		}
+		return fmt.Errorf("failed to refresh token: %w", err)
 	}
 
 	return nil
@@ -662,11 +754,18 @@ func (c *Client) ClearToken() error {
 // GetConfig returns a copy of the client configuration
 func (c *Client) GetConfig() *Config {
 	// Return a copy to prevent external modification
-	configCopy := *c.config
+	configCopy := *c.currentConfig()
 	return &configCopy
 }
 
-// UpdateConfig updates the client configuration with validation
+// currentConfig returns the configuration in use, which must not be
+// modified. A method reads it once for settings that belong together.
+func (c *Client) currentConfig() *Config {
+	return c.config.Load()
+}
+
+// UpdateConfig updates the client configuration with validation. Requests
+// already started finish with the previous configuration.
 // Note: This does not affect already established connections
 func (c *Client) UpdateConfig(newConfig *Config) error {
 	if newConfig == nil {
@@ -681,11 +780,7 @@ func (c *Client) UpdateConfig(newConfig *Config) error {
 	// Set defaults for any missing configuration
 	newConfig.SetDefaults()
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	c.config = newConfig
-	c.baseURL = newConfig.BaseURL
+	c.config.Store(newConfig)
 
 	return nil
 }
@@ -743,28 +838,24 @@ func (c *Client) IsRateLimited() bool {
 	return c.rateLimiter.IsRateLimited()
 }
 
-// DisableRateLimiting disables the rate limiter entirely
+// DisableRateLimiting disables the rate limiter entirely, for this client
+// and those sharing its transport (see AsUser). The limits reported by the
+// API are still tracked.
 // Use with caution - this will allow unlimited requests to the API
 func (c *Client) DisableRateLimiting() {
-	c.rateLimiter = nil
+	c.httpClient.rateLimitingOff.Store(true)
 }
 
-// EnableRateLimiting re-enables rate limiting with a new rate limiter
+// EnableRateLimiting re-enables rate limiting after DisableRateLimiting
 func (c *Client) EnableRateLimiting() {
-	if c.rateLimiter == nil {
-		rateLimiterConfig := &RateLimiterConfig{
-			InitialLimit:      100,
-			BackoffMultiplier: 2.0,
-			MaxBackoff:        5 * time.Minute,
-			QueueSize:         100,
-			Logger:            c.config.Logger,
-		}
-		c.rateLimiter = NewRateLimiter(rateLimiterConfig)
-	}
+	c.httpClient.rateLimitingOff.Store(false)
 }
 
 // WaitForRateLimit blocks until it's safe to make another request
 func (c *Client) WaitForRateLimit(ctx context.Context) error {
+	if c.rateLimiter == nil || c.httpClient.rateLimitingOff.Load() {
+		return nil
+	}
 	return c.rateLimiter.Wait(ctx)
 }
 
@@ -779,15 +870,19 @@ func (c *Client) TestAPICall(method, path string, params map[string]string) (*Re
 		queryParams.Set(key, value)
 	}
 
+	ctx := context.Background()
 	switch method {
 	case "GET":
-		return c.httpClient.GET(path, queryParams, token)
+		return c.httpClient.GET(ctx, path, queryParams, token)
 	case "POST":
-		return c.httpClient.POST(path, queryParams, token)
+		return c.httpClient.POST(ctx, path, queryParams, token)
 	default:
-		return c.httpClient.GET(path, queryParams, token)
+		return c.httpClient.GET(ctx, path, queryParams, token)
 	}
 }
 
-// Compile-time check to ensure Client implements ClientInterface
-var _ ClientInterface = (*Client)(nil)
+// Compile-time checks to ensure Client implements ClientInterface and API
+var (
+	_ ClientInterface = (*Client)(nil)
+	_ API             = (*Client)(nil)
+)
@@ -1,7 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -530,6 +537,38 @@ func TestTimeUnmarshalJSON_FallbackToDefault(t *testing.T) {
 	}
 }
 
+func FuzzTimeUnmarshalJSON(f *testing.F) {
+	for _, seed := range []string{
+		`"2024-06-15T10:30:00+0000"`, `"2024-06-15T10:30:00Z"`, `"2024-06-15T10:30:00-0700"`,
+		`"2024-06-15T10:30:00.000Z"`, `null`, `""`, `"`, `1718447400`, `"not a time"`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var parsed Time
+		if err := parsed.UnmarshalJSON(data); err != nil {
+			return
+		}
+		if parsed.Year() < 0 || parsed.Year() > 9999 {
+			return // RFC 3339 cannot represent it
+		}
+
+		// What we write back must read back as the same instant
+		out, err := parsed.MarshalJSON()
+		if err != nil {
+			t.Fatalf("MarshalJSON() of %q error = %v", data, err)
+		}
+		var again Time
+		if err := again.UnmarshalJSON(out); err != nil {
+			t.Fatalf("UnmarshalJSON(%s) error = %v", out, err)
+		}
+		if !again.Equal(parsed.Truncate(time.Second)) {
+			t.Errorf("round trip of %q = %v, want %v", data, again, parsed.Truncate(time.Second))
+		}
+	})
+}
+
 func TestRateLimiter_NewRateLimiter(t *testing.T) {
 	rl := NewRateLimiter(&RateLimiterConfig{
 		InitialLimit: 100,
@@ -554,6 +593,144 @@ func TestRateLimiter_ShouldWait(t *testing.T) {
 	}
 }
 
+func TestRateLimiter_WaitDoesNotHoldLock(t *testing.T) {
+	rl := NewRateLimiter(&RateLimiterConfig{InitialLimit: 100})
+	rl.MarkRateLimited(time.Now().Add(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	waited := make(chan error, 1)
+	go func() { waited <- rl.Wait(ctx) }()
+
+	// Other requests keep reading and updating the limits meanwhile
+	time.Sleep(20 * time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		rl.GetStatus()
+		rl.UpdateFromHeaders(&RateLimitInfo{Limit: 200, Remaining: 10})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the limits usable while a request waits")
+	}
+
+	cancel()
+	select {
+	case err := <-waited:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected the wait cancelled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the wait to end with its context")
+	}
+}
+
+// TestClient_ConcurrentUse exercises the shared state of a client from many
+// goroutines; run with -race, as CI does
+func TestClient_ConcurrentUse(t *testing.T) {
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/refresh_access_token" {
+			_, _ = fmt.Fprint(w, `{"access_token":"refreshed","token_type":"bearer","expires_in":5184000}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"id":%q,"text":"hi"}`, strings.TrimPrefix(r.URL.Path, "/"))
+	})
+	defer server.Close()
+	var refreshed atomic.Int32
+	client.currentConfig().OnTokenRefresh = func(*TokenInfo) error {
+		refreshed.Add(1)
+		return nil
+	}
+	scoped, err := client.AsUser("2", "scoped-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 5 {
+				id := PostID(fmt.Sprintf("p%d-%d", i, j))
+				switch (i + j) % 7 {
+				case 0:
+					if err := client.RefreshToken(ctx); err != nil {
+						t.Errorf("RefreshToken failed: %v", err)
+					}
+				case 1:
+					config := client.GetConfig()
+					config.HTTPTimeout = time.Minute
+					if err := client.UpdateConfig(config); err != nil {
+						t.Errorf("UpdateConfig failed: %v", err)
+					}
+				case 2:
+					client.DisableRateLimiting()
+					client.EnableRateLimiting()
+					client.GetRateLimitStatus()
+				case 3:
+					client.GetTokenDebugInfo()
+					client.GetTokenInfo()
+				case 4:
+					if _, err := scoped.GetPost(ctx, id); err != nil {
+						t.Errorf("GetPost failed: %v", err)
+					}
+				default:
+					if _, err := client.GetPost(ctx, id); err != nil {
+						t.Errorf("GetPost failed: %v", err)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if refreshed.Load() == 0 || client.GetAccessToken() != "refreshed" {
+		t.Errorf("expected refreshes seen, got %d and token %q", refreshed.Load(), client.GetAccessToken())
+	}
+	if scoped.GetAccessToken() != "scoped-token" {
+		t.Errorf("expected the scoped token kept, got %q", scoped.GetAccessToken())
+	}
+}
+
+func TestClient_DisableRateLimiting(t *testing.T) {
+	var requests atomic.Int32
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"id":"1","text":"hi"}`)
+	})
+	defer server.Close()
+	client.currentConfig().TokenRefreshWindow = -1
+	client.rateLimiter.MarkRateLimited(time.Now().Add(time.Hour))
+
+	client.DisableRateLimiting()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := client.GetPost(ctx, "1"); err != nil {
+		t.Fatalf("expected the request sent without waiting, got %v", err)
+	}
+	if !client.IsRateLimited() {
+		t.Error("expected the limit still tracked")
+	}
+	if err := client.WaitForRateLimit(ctx); err != nil {
+		t.Errorf("expected no wait, got %v", err)
+	}
+
+	client.EnableRateLimiting()
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetPost(ctx, "1"); err == nil {
+		t.Error("expected the request to wait out the limit again")
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected 1 request, got %d", requests.Load())
+	}
+}
+
 func TestConfig_SetDefaults(t *testing.T) {
 	config := &Config{}
 	config.SetDefaults()
@@ -596,6 +773,24 @@ func TestConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestConfig_ValidateWithoutRedirectURI(t *testing.T) {
+	// Accounts saved by 'threads auth token' have a token and app
+	// credentials but never ran the authorization flow, so no redirect URI
+	config := &Config{ClientID: "test-id", ClientSecret: "test-secret"}
+	config.SetDefaults()
+	if err := config.Validate(); err != nil {
+		t.Fatalf("expected a config without a redirect URI to pass, got: %v", err)
+	}
+	if _, err := NewClient(config); err != nil {
+		t.Errorf("expected a client, got: %v", err)
+	}
+
+	config.RedirectURI = "example.com/callback"
+	if err := config.Validate(); err == nil {
+		t.Error("expected an error for a redirect URI that is not a URL")
+	}
+}
+
 func TestValidationMoreCases(t *testing.T) {
 	validator := NewValidator()
 
@@ -7,6 +7,9 @@ import (
 
 // getUserID extracts user ID from token info
 func (c *Client) getUserID() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	if c.tokenInfo != nil && c.tokenInfo.UserID != "" {
 		return c.tokenInfo.UserID
 	}
@@ -18,6 +21,16 @@ func (c *Client) getUserID() string {
 
 // handleAPIError processes API error responses
 func (c *Client) handleAPIError(resp *Response) error {
+	return errorFromResponse(resp)
+}
+
+// errorFromResponse converts a failed API response into a typed error and
+// attaches the response metadata to it.
+func errorFromResponse(resp *Response) error {
+	return attachResponseMeta(typedErrorFromResponse(resp), resp.Meta)
+}
+
+func typedErrorFromResponse(resp *Response) error {
 	var apiErr struct {
 		Error struct {
 			Message   string `json:"message"`
@@ -44,8 +57,7 @@ func (c *Client) handleAPIError(resp *Response) error {
 			case 401, 403:
 				return NewAuthenticationError(errorCode, message, details)
 			case 429:
-				retryAfter := resp.RateLimit.RetryAfter
-				return NewRateLimitError(errorCode, message, details, retryAfter)
+				return rateLimitErrorFromResponse(resp, errorCode, message, details)
 			case 400, 422:
 				return NewValidationError(errorCode, message, details, "")
 			default:
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// acceptEncoding is sent with every request. The client decodes responses
+// itself rather than leaving gzip to the transport, so that deflate is
+// accepted too and a damaged body fails as a retryable network error.
+const acceptEncoding = "gzip, deflate"
+
+// compressMinSize is the smallest request body compressed when request
+// compression is on; below it the gzip framing outweighs the savings
+const compressMinSize = 1024
+
+// errCompressionRefused is returned by executeRequest when the API refuses a
+// compressed body with 415; Do sends the request again uncompressed
+var errCompressionRefused = errors.New("request compression refused")
+
+// maxDecompressedBody bounds a decompressed response, so a small response
+// cannot expand without limit
+var maxDecompressedBody int64 = 64 << 20
+
+// gzip writers hold large compression tables, so they are reused
+var gzipWriterPool = sync.Pool{
+	New: func() any { return gzip.NewWriter(io.Discard) },
+}
+
+var gzipReaderPool sync.Pool
+
+// compressBody appends data to dst compressed with gzip
+func compressBody(dst *bytes.Buffer, data []byte) error {
+	zw := gzipWriterPool.Get().(*gzip.Writer) //nolint:errcheck // The pool only holds gzip writers
+	defer gzipWriterPool.Put(zw)
+	zw.Reset(dst)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// decodeBody reads the body of resp, decompressing it as its
+// Content-Encoding says. Empty bodies, such as those of 204 and 304
+// responses, are returned as they are whatever the header says.
+func decodeBody(resp *http.Response) ([]byte, error) {
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if resp.Uncompressed || encoding == "" || encoding == "identity" {
+		return readBody(resp.Body)
+	}
+
+	body := bufio.NewReader(resp.Body)
+	if _, err := body.Peek(1); errors.Is(err, io.EOF) {
+		markDecoded(resp)
+		return []byte{}, nil
+	}
+
+	var r io.Reader
+	switch encoding {
+	case "gzip", "x-gzip":
+		zr, err := newGzipReader(body)
+		if err != nil {
+			return nil, decompressError(encoding, err)
+		}
+		defer gzipReaderPool.Put(zr)
+		r = zr
+	case "deflate":
+		// deflate means zlib-wrapped data, but some servers send it raw
+		if header, _ := body.Peek(2); isZlibHeader(header) { //nolint:errcheck // A short header is raw data
+			zr, err := zlib.NewReader(body)
+			if err != nil {
+				return nil, decompressError(encoding, err)
+			}
+			defer zr.Close() //nolint:errcheck // Closing a reader has nothing to report
+			r = zr
+		} else {
+			fr := flate.NewReader(body)
+			defer fr.Close() //nolint:errcheck // Closing a reader has nothing to report
+			r = fr
+		}
+	default:
+		return nil, fmt.Errorf("unsupported response encoding %q", encoding)
+	}
+
+	data, err := readBody(io.LimitReader(r, maxDecompressedBody+1))
+	if err != nil {
+		return nil, decompressError(encoding, err)
+	}
+	if int64(len(data)) > maxDecompressedBody {
+		return nil, NewNetworkError(0, "Response too large", fmt.Sprintf("the %s response expands beyond %d bytes", encoding, maxDecompressedBody), false)
+	}
+
+	markDecoded(resp)
+	return data, nil
+}
+
+// markDecoded describes the body of resp as decompressed, as the transport
+// does for the gzip responses it decodes
+func markDecoded(resp *http.Response) {
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+func newGzipReader(r io.Reader) (*gzip.Reader, error) {
+	if zr, ok := gzipReaderPool.Get().(*gzip.Reader); ok {
+		if err := zr.Reset(r); err != nil {
+			return nil, err
+		}
+		return zr, nil
+	}
+	return gzip.NewReader(r)
+}
+
+// isZlibHeader reports whether header starts a zlib stream: the deflate
+// method and a checksum making the first two bytes a multiple of 31
+func isZlibHeader(header []byte) bool {
+	return len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0
+}
+
+// decompressError reports a response that could not be decompressed, most
+// likely cut short, so the request is retried
+func decompressError(encoding string, err error) error {
+	return NewNetworkError(0, "Invalid compressed response", fmt.Sprintf("%s: %v", encoding, err), true)
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func gzipData(t testing.TB, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := compressBody(&buf, data); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newCompressionTestClient(t *testing.T, handler http.HandlerFunc) *HTTPClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := NewConfig()
+	config.RetryConfig = &RetryConfig{MaxRetries: 0}
+	client := NewHTTPClient(config, nil)
+	client.baseURL = server.URL
+	return client
+}
+
+func TestHTTPClient_DecodesResponses(t *testing.T) {
+	body := []byte(`{"id":"1","text":"` + strings.Repeat("compressible ", 100) + `"}`)
+	var zlibBody, rawDeflate bytes.Buffer
+	zw := zlib.NewWriter(&zlibBody)
+	zw.Write(body)                                                  //nolint:errcheck,gosec // In-memory
+	zw.Close()                                                      //nolint:errcheck,gosec // In-memory
+	fw, _ := flate.NewWriter(&rawDeflate, flate.DefaultCompression) //nolint:errcheck // Valid level
+	fw.Write(body)                                                  //nolint:errcheck,gosec // In-memory
+	fw.Close()                                                      //nolint:errcheck,gosec // In-memory
+	gzipped := gzipData(t, body)
+
+	tests := []struct {
+		name     string
+		encoding string
+		data     []byte
+		want     string
+		wantErr  string
+		retry    bool
+	}{
+		{name: "gzip", encoding: "gzip", data: gzipped, want: string(body)},
+		{name: "x-gzip", encoding: "X-Gzip", data: gzipped, want: string(body)},
+		{name: "zlib deflate", encoding: "deflate", data: zlibBody.Bytes(), want: string(body)},
+		{name: "raw deflate", encoding: "deflate", data: rawDeflate.Bytes(), want: string(body)},
+		{name: "identity", encoding: "identity", data: body, want: string(body)},
+		{name: "empty", encoding: "gzip", data: nil, want: ""},
+		{name: "truncated", encoding: "gzip", data: gzipped[:len(gzipped)/2], wantErr: "Invalid compressed response", retry: true},
+		{name: "not gzip", encoding: "gzip", data: body, wantErr: "Invalid compressed response", retry: true},
+		{name: "unsupported", encoding: "br", data: body, wantErr: "unsupported response encoding"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newCompressionTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+					http.Error(w, "compression not requested", http.StatusBadRequest)
+					return
+				}
+				w.Header().Set("Content-Encoding", tt.encoding)
+				w.Write(tt.data) //nolint:errcheck,gosec // Test server
+			})
+
+			resp, err := client.GET(context.Background(), "/me", nil, "token")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				if IsRetryable(err) != tt.retry {
+					t.Errorf("expected retryable=%v for %v", tt.retry, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(resp.Body) != tt.want {
+				t.Errorf("got %q, want %q", resp.Body, tt.want)
+			}
+			if tt.encoding != "identity" && resp.Header.Get("Content-Encoding") != "" {
+				t.Error("expected Content-Encoding removed from the decoded response")
+			}
+		})
+	}
+}
+
+func TestDecodeBody_LimitsExpansion(t *testing.T) {
+	orig := maxDecompressedBody
+	maxDecompressedBody = 1 << 10
+	t.Cleanup(func() { maxDecompressedBody = orig })
+
+	resp := &http.Response{
+		Header: http.Header{"Content-Encoding": {"gzip"}},
+		Body:   io.NopCloser(bytes.NewReader(gzipData(t, make([]byte, 1<<20)))),
+	}
+	_, err := decodeBody(resp)
+	if err == nil || !strings.Contains(err.Error(), "too large") || IsRetryable(err) {
+		t.Errorf("expected an oversized response refused for good, got %v", err)
+	}
+}
+
+func TestHTTPClient_CompressesLargeRequestBodies(t *testing.T) {
+	var compressed atomic.Int32
+	client := newCompressionTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body := r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			compressed.Add(1)
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			body = zr
+		}
+		// The whole body is read before replying, as HTTP/1 servers require
+		data, _ := io.ReadAll(body) //nolint:errcheck // Test server
+		w.Write(data)               //nolint:errcheck,gosec // Test server
+	})
+	client.compressRequests = true
+
+	large := url.Values{"text": {strings.Repeat("x", compressMinSize)}}
+	resp, err := client.POST(context.Background(), "/me/threads", large, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body) != large.Encode() || compressed.Load() != 1 {
+		t.Errorf("expected the large body sent compressed, got %d compressed, body %q", compressed.Load(), resp.Body)
+	}
+
+	small := url.Values{"text": {"hi"}}
+	resp, err = client.POST(context.Background(), "/me/threads", small, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body) != "text=hi" || compressed.Load() != 1 {
+		t.Errorf("expected the small body sent as is, got %d compressed, body %q", compressed.Load(), resp.Body)
+	}
+}
+
+func TestHTTPClient_StopsCompressingWhenRefused(t *testing.T) {
+	var requests, compressed atomic.Int32
+	client := newCompressionTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Content-Encoding") != "" {
+			compressed.Add(1)
+			http.Error(w, "unsupported", http.StatusUnsupportedMediaType)
+			return
+		}
+		data, _ := io.ReadAll(r.Body) //nolint:errcheck // Test server
+		w.Write(data)                 //nolint:errcheck,gosec // Test server
+	})
+	client.compressRequests = true
+	coordinator := &fakeCoordinator{}
+	client.coordinator = coordinator
+
+	large := url.Values{"text": {strings.Repeat("x", compressMinSize)}}
+	for range 2 {
+		resp, err := client.POST(context.Background(), "/me/threads", large, "token")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(resp.Body) != large.Encode() {
+			t.Errorf("unexpected body of %d bytes, want %d", len(resp.Body), len(large.Encode()))
+		}
+	}
+	if requests.Load() != 3 || compressed.Load() != 1 {
+		t.Errorf("expected one refused compressed request, then plain ones; got %d requests, %d compressed", requests.Load(), compressed.Load())
+	}
+	if coordinator.acquired != 3 {
+		t.Errorf("expected every request to pass the coordinator, got %d of 3", coordinator.acquired)
+	}
+}
+
+func BenchmarkHTTPClient_GetListGzip(b *testing.B) {
+	compressed := gzipData(b, benchmarkPage)
+	client := newBenchmarkClient(b, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed) //nolint:errcheck,gosec // Test server
+	})
+	params := url.Values{"fields": {"id,text,timestamp,permalink"}, "limit": {"100"}}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := client.GET(ctx, "/me/threads", params, "token"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHTTPClient_PostBatch(b *testing.B) {
+	batch := `[` + strings.TrimSuffix(strings.Repeat(`{"method":"GET","relative_url":"1234567890?fields=id,text,timestamp,permalink"},`, 50), ",") + `]`
+	params := url.Values{"batch": {batch}, "include_headers": {"false"}}
+	ctx := context.Background()
+
+	for _, compress := range []bool{false, true} {
+		name := "plain"
+		if compress {
+			name = "gzip"
+		}
+		b.Run(name, func(b *testing.B) {
+			var sent atomic.Int64
+			client := newBenchmarkClient(b, func(w http.ResponseWriter, r *http.Request) {
+				sent.Store(r.ContentLength)
+				respondChunked([]byte(`[]`))(w, r)
+			})
+			client.compressRequests = compress
+
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := client.POST(ctx, "/", params, "token"); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(sent.Load()), "body-B/op")
+		})
+	}
+}
@@ -13,6 +13,7 @@ const (
 	MaxTextAttachmentLength = 10000 // Maximum characters for text attachment plaintext
 	MaxTextEntities         = 10    // Maximum text spoiler entities per post
 	MaxLinks                = 5     // Maximum number of links in a post
+	MaxAltTextLength        = 1000  // Maximum characters for media alt text
 
 	// Pagination limits
 	MaxPostsPerRequest = 100 // Maximum posts per API request
@@ -34,6 +35,9 @@ const (
 	// HTTP client defaults
 	DefaultHTTPTimeout = 30 * time.Second // Default HTTP request timeout
 	DefaultUserAgent   = "threads-cli/" + Version
+
+	// DefaultTokenRefreshWindow is how long before expiry the client refreshes its token
+	DefaultTokenRefreshWindow = time.Hour
 )
 
 // API Endpoints
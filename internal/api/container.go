@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Container is a Threads media container. Publishing on Threads happens in
+// two steps: a container is created from the post content and processed by
+// Threads, then it is published as a post. Container makes each step of that
+// lifecycle explicit so callers can create, poll and publish on their own
+// schedule, or use PublishWithWait to run them all.
+type Container struct {
+	// ID is set once the container has been created
+	ID ContainerID
+
+	client *Client
+	kind   string
+	params url.Values
+}
+
+// WaitOptions controls how Container.Wait polls for processing to finish.
+// A nil *WaitOptions uses the defaults.
+type WaitOptions struct {
+	// PollInterval is the time between status checks (default DefaultContainerPollInterval)
+	PollInterval time.Duration
+	// Timeout bounds the whole wait (default DefaultContainerPollMaxAttempts * PollInterval)
+	Timeout time.Duration
+	// OnStatus, if set, is called with every status received
+	OnStatus func(*ContainerStatus)
+}
+
+// ContainerError reports a container that cannot be published because
+// processing failed, it expired, it was already published, or processing
+// did not finish in time. Status holds the last status seen.
+type ContainerError struct {
+	*BaseError
+	ContainerID ContainerID `json:"container_id"`
+	Status      string      `json:"status"`
+}
+
+// NewContainerError creates a container error for the given container and status
+func NewContainerError(containerID ContainerID, status, message, details string) *ContainerError {
+	return &ContainerError{
+		BaseError: &BaseError{
+			Code:    0,
+			Message: message,
+			Type:    "container_error",
+			Details: details,
+		},
+		ContainerID: containerID,
+		Status:      status,
+	}
+}
+
+// IsTimeout reports whether the container was still processing when the wait ended
+func (e *ContainerError) IsTimeout() bool {
+	return e.Status == ContainerStatusInProgress
+}
+
+// NewContainer validates content and prepares a container for it without
+// creating it. content must be one of *TextPostContent, *ImagePostContent,
+// *VideoPostContent, *CarouselPostContent or CarouselItem (a carousel child).
+func (c *Client) NewContainer(content any) (*Container, error) {
+	var (
+		kind   string
+		params url.Values
+	)
+
+	switch v := content.(type) {
+	case *TextPostContent:
+		if err := c.ValidateTextPostContent(v); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(v.Text) == "" {
+			return nil, NewValidationError(400, "Text content is required", ErrEmptyPostID, "text")
+		}
+		kind, params = "text", textContainerParams(v)
+
+	case *ImagePostContent:
+		if err := c.ValidateImagePostContent(v); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(v.ImageURL) == "" {
+			return nil, NewValidationError(400, "Image URL is required", "Post must have an image URL", "image_url")
+		}
+		kind, params = "image", imageContainerParams(v)
+
+	case *VideoPostContent:
+		if err := c.ValidateVideoPostContent(v); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(v.VideoURL) == "" {
+			return nil, NewValidationError(400, "Video URL is required", "Post must have a video URL", "video_url")
+		}
+		kind, params = "video", videoContainerParams(v)
+
+	case *CarouselPostContent:
+		if err := c.ValidateCarouselPostContent(v); err != nil {
+			return nil, err
+		}
+		if len(v.Children) == 0 {
+			return nil, NewValidationError(400, "Children containers are required", "Carousel post must have at least one child container", "children")
+		}
+		kind, params = "carousel", carouselContainerParams(v)
+
+	case CarouselItem:
+		var err error
+		params, err = carouselItemParams(v)
+		if err != nil {
+			return nil, err
+		}
+		kind = "carousel item"
+
+	default:
+		return nil, fmt.Errorf("unsupported container content type: %T", content)
+	}
+
+	return &Container{client: c, kind: kind, params: params}, nil
+}
+
+// ContainerFromID returns a handle for a container that has already been created
+func (c *Client) ContainerFromID(containerID ContainerID) *Container {
+	return &Container{ID: containerID, client: c, kind: "media"}
+}
+
+// Create creates the container on Threads and records its ID
+func (ct *Container) Create(ctx context.Context) error {
+	if ct.ID.Valid() {
+		return NewValidationError(400, "Container already created", fmt.Sprintf("container %s has already been created", ct.ID), "container_id")
+	}
+	if ct.params == nil {
+		return NewValidationError(400, "Container has no content", "use Client.NewContainer to prepare a container before creating it", "content")
+	}
+
+	// Ensure we have a valid token
+	if err := ct.client.EnsureValidToken(ctx); err != nil {
+		return err
+	}
+
+	containerID, err := ct.client.createContainer(ctx, ct.params)
+	if err != nil {
+		return err
+	}
+
+	ct.ID = ConvertToContainerID(containerID)
+	return nil
+}
+
+// Status retrieves the current processing status of the container
+func (ct *Container) Status(ctx context.Context) (*ContainerStatus, error) {
+	return ct.client.GetContainerStatus(ctx, ct.ID)
+}
+
+// Wait polls the container until it is ready to publish. It returns a
+// *ContainerError if processing fails, the container expires or is already
+// published, or the timeout is reached, and ctx.Err() if ctx is cancelled.
+func (ct *Container) Wait(ctx context.Context, opts *WaitOptions) error {
+	interval, timeout := DefaultContainerPollInterval, time.Duration(0)
+	var onStatus func(*ContainerStatus)
+	if opts != nil {
+		if opts.PollInterval > 0 {
+			interval = opts.PollInterval
+		}
+		timeout = opts.Timeout
+		onStatus = opts.OnStatus
+	}
+	if timeout <= 0 {
+		timeout = interval * DefaultContainerPollMaxAttempts
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		status, err := ct.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check container status: %w", err)
+		}
+		if onStatus != nil {
+			onStatus(status)
+		}
+
+		switch status.Status {
+		case ContainerStatusFinished:
+			return nil
+		case ContainerStatusError:
+			details := status.ErrorMessage
+			if details == "" {
+				details = "container processing failed with error status"
+			}
+			return NewContainerError(ct.ID, status.Status, "Container processing failed", details)
+		case ContainerStatusExpired:
+			return NewContainerError(ct.ID, status.Status, "Container expired", "container expired before it could be published")
+		case ContainerStatusPublished:
+			return NewContainerError(ct.ID, status.Status, "Container already published", "container has already been published")
+		}
+
+		// Still in progress or an unknown status, wait and retry
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return NewContainerError(ct.ID, ContainerStatusInProgress, "Timed out waiting for container",
+				fmt.Sprintf("container was still %s after %s", status.Status, timeout))
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Publish publishes a created container and returns the resulting post
+func (ct *Container) Publish(ctx context.Context) (*Post, error) {
+	// Ensure we have a valid token
+	if err := ct.client.EnsureValidToken(ctx); err != nil {
+		return nil, err
+	}
+	kind := publishPost
+	if ct.params.Get("reply_to_id") != "" {
+		kind = publishReply
+	}
+	return ct.client.publishContainer(ctx, ct.ID.String(), kind)
+}
+
+// PublishWithWait creates the container if needed, waits for processing to
+// finish and publishes it
+func (ct *Container) PublishWithWait(ctx context.Context, opts *WaitOptions) (*Post, error) {
+	if !ct.ID.Valid() {
+		if err := ct.Create(ctx); err != nil {
+			return nil, fmt.Errorf("failed to create %s container: %w", ct.kind, err)
+		}
+	}
+
+	if err := ct.Wait(ctx, opts); err != nil {
+		return nil, fmt.Errorf("container not ready for publishing: %w", err)
+	}
+
+	post, err := ct.Publish(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish %s post: %w", ct.kind, err)
+	}
+	return post, nil
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// containerHandler serves the container endpoints, returning statuses in
+// order and repeating the last one.
+func containerHandler(t *testing.T, statuses []string) (http.HandlerFunc, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var calls []string
+	polls := 0
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "refresh_access_token") {
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+			return
+		}
+
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/12345/threads":
+			if err := r.ParseForm(); err != nil {
+				t.Errorf("failed to parse form: %v", err)
+			}
+			if got := r.PostForm.Get("media_type"); got != MediaTypeImage {
+				t.Errorf("expected media_type IMAGE, got %q", got)
+			}
+			_, _ = w.Write([]byte(`{"id":"c1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/c1":
+			status := statuses[min(polls, len(statuses)-1)]
+			polls++
+			_, _ = w.Write([]byte(`{"id":"c1","status":"` + status + `","error_message":"bad media"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/12345/threads_publish":
+			_, _ = w.Write([]byte(`{"id":"p1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/p1":
+			_, _ = w.Write([]byte(`{"id":"p1","media_type":"IMAGE","timestamp":"2024-01-01T00:00:00Z"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}, &calls
+}
+
+func TestContainer_PublishWithWait(t *testing.T) {
+	handler, calls := containerHandler(t, []string{ContainerStatusInProgress, ContainerStatusFinished})
+	client, server := createTestClient(t, handler)
+	defer server.Close()
+
+	container, err := client.NewContainer(&ImagePostContent{ImageURL: "https://example.com/a.jpg"})
+	if err != nil {
+		t.Fatalf("NewContainer: %v", err)
+	}
+
+	var seen []string
+	post, err := container.PublishWithWait(context.Background(), &WaitOptions{
+		PollInterval: time.Millisecond,
+		OnStatus:     func(s *ContainerStatus) { seen = append(seen, s.Status) },
+	})
+	if err != nil {
+		t.Fatalf("PublishWithWait: %v", err)
+	}
+
+	if post.ID != "p1" {
+		t.Errorf("expected post p1, got %q", post.ID)
+	}
+	if container.ID != "c1" {
+		t.Errorf("expected container ID c1, got %q", container.ID)
+	}
+	if strings.Join(seen, ",") != "IN_PROGRESS,FINISHED" {
+		t.Errorf("unexpected statuses: %v", seen)
+	}
+	want := "POST /12345/threads,GET /c1,GET /c1,POST /12345/threads_publish,GET /p1"
+	if got := strings.Join(*calls, ","); got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+}
+
+func TestContainer_WaitProcessingError(t *testing.T) {
+	handler, _ := containerHandler(t, []string{ContainerStatusError})
+	client, server := createTestClient(t, handler)
+	defer server.Close()
+
+	err := client.ContainerFromID("c1").Wait(context.Background(), &WaitOptions{PollInterval: time.Millisecond})
+
+	var containerErr *ContainerError
+	if !errors.As(err, &containerErr) {
+		t.Fatalf("expected ContainerError, got %T: %v", err, err)
+	}
+	if containerErr.Status != ContainerStatusError || containerErr.Details != "bad media" {
+		t.Errorf("unexpected error: %+v", containerErr)
+	}
+	if containerErr.IsTimeout() {
+		t.Error("processing error should not be a timeout")
+	}
+}
+
+// TestWaitForContainerReady_Timeout tests that waiting gives up after the timeout
+func TestWaitForContainerReady_Timeout(t *testing.T) {
+	handler, _ := containerHandler(t, []string{ContainerStatusInProgress})
+	client, server := createTestClient(t, handler)
+	defer server.Close()
+
+	err := client.ContainerFromID("c1").Wait(context.Background(), &WaitOptions{
+		PollInterval: time.Millisecond,
+		Timeout:      20 * time.Millisecond,
+	})
+
+	var containerErr *ContainerError
+	if !errors.As(err, &containerErr) {
+		t.Fatalf("expected ContainerError, got %T: %v", err, err)
+	}
+	if !containerErr.IsTimeout() {
+		t.Errorf("expected a timeout, got status %q", containerErr.Status)
+	}
+}
+
+func TestContainer_CreateTwice(t *testing.T) {
+	client := &Client{}
+	container := client.ContainerFromID("c1")
+
+	if err := container.Create(context.Background()); !IsValidationError(err) {
+		t.Errorf("expected validation error for an existing container, got %v", err)
+	}
+}
+
+func TestNewContainer_UnsupportedContent(t *testing.T) {
+	client := &Client{}
+	if _, err := client.NewContainer("text"); err == nil {
+		t.Error("expected error for unsupported content type")
+	}
+}
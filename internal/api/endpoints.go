@@ -0,0 +1,233 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Endpoint describes a Graph API endpoint the client calls. It is the single
+// place paths, default fields and required scopes are spelled out: client
+// methods build requests from it, the CLI checks a token's scopes against it,
+// and 'threads api describe' lists it.
+type Endpoint struct {
+	Method string `json:"method"`
+	// Path is relative to BaseAPIURL; segments in braces, such as
+	// {media-id}, are filled in by the client.
+	Path string `json:"path"`
+	// Params are the query or form parameters the client may send.
+	Params []string `json:"params,omitempty"`
+	// Fields is the fields parameter the client requests by default.
+	Fields string `json:"fields,omitempty"`
+	// Scope is the permission the token needs, if any.
+	Scope string `json:"scope,omitempty"`
+	// Quotas are the publishing quotas a call counts against, as named by
+	// PublishingLimits: posts, replies, deletes or location_searches.
+	Quotas []string `json:"quotas,omitempty"`
+	// Commands are the CLI commands that call the endpoint.
+	Commands []string `json:"commands,omitempty"`
+	// Optional lists the Commands that carry on without the endpoint when
+	// the token lacks its scope.
+	Optional []string `json:"optional,omitempty"`
+	// Cached is set for reads served through the client's ResponseCache.
+	Cached  bool   `json:"cached,omitempty"`
+	Summary string `json:"summary"`
+}
+
+// path returns the path of e with its placeholders replaced by ids, in order
+func (e *Endpoint) path(ids ...string) string {
+	segments := strings.Split(e.Path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && len(ids) > 0 {
+			segments[i], ids = ids[0], ids[1:]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+var paginationParams = []string{"limit", "before", "after"}
+
+// Endpoints the client calls, grouped as in the Threads API reference
+var (
+	endpointPost = &Endpoint{
+		Method: http.MethodGet, Path: "/{media-id}", Params: []string{"fields"}, Fields: PostExtendedFields, Cached: true,
+		Scope: "threads_basic", Commands: []string{"posts get", "unroll", "bookmarks add"}, Summary: "Get a post",
+	}
+	endpointDeletePost = &Endpoint{
+		Method: http.MethodDelete, Path: "/{media-id}",
+		Scope: "threads_delete", Quotas: []string{"deletes"}, Commands: []string{"posts delete"}, Summary: "Delete a post",
+	}
+	endpointUserThreads = &Endpoint{
+		Method: http.MethodGet, Path: "/{user-id}/threads", Params: append([]string{"fields", "since", "until"}, paginationParams...), Fields: PostExtendedFields,
+		Scope: "threads_basic", Commands: []string{"posts list", "posts archive", "insights post", "insights reach", "insights community", "insights rollup"}, Summary: "List a user's posts",
+	}
+	endpointGhostPosts = &Endpoint{
+		Method: http.MethodGet, Path: "/{user-id}/ghost_posts", Params: append([]string{"fields"}, paginationParams...), Fields: GhostPostFields,
+		Scope: "threads_basic", Commands: []string{"posts ghost-list"}, Summary: "List a user's ghost posts",
+	}
+	endpointCreateContainer = &Endpoint{
+		Method: http.MethodPost, Path: "/{user-id}/threads",
+		Params: []string{
+			"media_type", "text", "image_url", "video_url", "alt_text", "reply_control", "reply_to_id", "topic_tag",
+			"location_id", "quote_post_id", "link_attachment", "poll_attachment", "children", "auto_publish_text",
+			"is_carousel_item", "text_entities", "is_spoiler_media", "text_attachment", "gif_attachment", "is_ghost_post",
+		},
+		Scope: "threads_content_publish", Commands: []string{"posts create", "posts carousel", "posts quote", "replies create", "queue run", "queue daemon", "series post"},
+		Summary: "Create a media container, or publish a text post directly",
+	}
+	endpointContainerStatus = &Endpoint{
+		Method: http.MethodGet, Path: "/{container-id}", Params: []string{"fields"}, Fields: ContainerStatusFields,
+		Scope: "threads_content_publish", Commands: []string{"posts create", "posts carousel", "queue run", "queue daemon", "series post"}, Summary: "Get the processing status of a media container",
+	}
+	endpointPublish = &Endpoint{
+		Method: http.MethodPost, Path: "/{user-id}/threads_publish", Params: []string{"creation_id"},
+		Scope: "threads_content_publish", Quotas: []string{"posts", "replies"},
+		Commands: []string{"posts create", "posts carousel", "posts quote", "replies create", "queue run", "queue daemon", "series post"}, Summary: "Publish a media container",
+	}
+	endpointRepost = &Endpoint{
+		Method: http.MethodPost, Path: "/{media-id}/repost",
+		Scope: "threads_content_publish", Commands: []string{"posts repost"}, Summary: "Repost a post",
+	}
+	endpointUnrepost = &Endpoint{
+		Method: http.MethodDelete, Path: "/{repost-id}/unrepost",
+		Scope: "threads_content_publish", Commands: []string{"posts unrepost"}, Summary: "Remove a repost",
+	}
+	endpointPublishingLimit = &Endpoint{
+		Method: http.MethodGet, Path: "/{user-id}/threads_publishing_limit", Params: []string{"fields"}, Fields: PublishingLimitFields, Cached: true,
+		Scope: "threads_basic", Commands: []string{"ratelimit publishing"}, Summary: "Get the publishing quota usage",
+	}
+
+	endpointReplies = &Endpoint{
+		Method: http.MethodGet, Path: "/{media-id}/replies", Params: append([]string{"fields", "reverse"}, paginationParams...), Fields: ReplyFields,
+		Scope: "threads_read_replies", Commands: []string{"replies list"}, Summary: "List the top-level replies to a post",
+	}
+	endpointConversation = &Endpoint{
+		Method: http.MethodGet, Path: "/{media-id}/conversation", Params: append([]string{"fields", "reverse"}, paginationParams...), Fields: ReplyFields,
+		Scope: "threads_read_replies", Commands: []string{"replies conversation", "posts get", "unroll", "insights community"}, Optional: []string{"posts get"}, Summary: "List all replies under a post, at any depth",
+	}
+	endpointManageReply = &Endpoint{
+		Method: http.MethodPost, Path: "/{reply-id}/manage_reply", Params: []string{"hide"},
+		Scope: "threads_manage_replies", Commands: []string{"replies hide", "replies unhide"}, Summary: "Hide or unhide a reply",
+	}
+	endpointUserReplies = &Endpoint{
+		Method: http.MethodGet, Path: "/{user-id}/replies", Params: append([]string{"fields", "since", "until"}, paginationParams...), Fields: ReplyFields,
+		Scope: "threads_read_replies", Summary: "List the replies a user made",
+	}
+
+	endpointUser = &Endpoint{
+		Method: http.MethodGet, Path: "/{user-id}", Params: []string{"fields"}, Fields: UserProfileFields, Cached: true,
+		Scope: "threads_basic", Commands: []string{"me", "users get", "insights rollup"}, Summary: "Get a user profile",
+	}
+	endpointMentions = &Endpoint{
+		Method: http.MethodGet, Path: "/{user-id}/mentions", Params: append([]string{"fields"}, paginationParams...), Fields: PostExtendedFields,
+		Scope: "threads_manage_mentions", Commands: []string{"users mentions", "watch", "insights community"}, Summary: "List posts mentioning a user",
+	}
+	endpointProfileLookup = &Endpoint{
+		Method: http.MethodGet, Path: "/profile_lookup", Params: []string{"username"},
+		Scope: "threads_profile_discovery", Commands: []string{"users lookup"}, Summary: "Look up a public profile by username",
+	}
+	endpointProfilePosts = &Endpoint{
+		Method: http.MethodGet, Path: "/profile_posts", Params: append([]string{"username", "fields", "since", "until"}, paginationParams...), Fields: PostExtendedFields,
+		Scope: "threads_profile_discovery", Commands: []string{"unroll", "bookmarks add", "notes add"}, Optional: []string{"unroll", "bookmarks add", "notes add"}, Summary: "List the posts of a public profile",
+	}
+
+	endpointPostInsights = &Endpoint{
+		Method: http.MethodGet, Path: "/{media-id}/insights", Params: []string{"metric", "period", "since", "until"},
+		Scope: "threads_manage_insights", Commands: []string{"insights post", "insights reach", "posts get", "recycle suggest"}, Optional: []string{"posts get"}, Summary: "Get the insights of a post",
+	}
+	endpointAccountInsights = &Endpoint{
+		Method: http.MethodGet, Path: "/{user-id}/threads_insights", Params: []string{"metric", "period", "breakdown", "since", "until"},
+		Scope: "threads_manage_insights", Commands: []string{"insights account", "insights reach", "insights rollup"}, Summary: "Get the insights of an account",
+	}
+
+	endpointKeywordSearch = &Endpoint{
+		Method: http.MethodGet, Path: "/keyword_search",
+		Params: append([]string{"q", "fields", "search_type", "search_mode", "media_type", "since", "until"}, paginationParams...),
+		Fields: PostExtendedFields,
+		Scope:  "threads_keyword_search", Commands: []string{"search"}, Summary: "Search public posts by keyword or topic tag",
+	}
+	endpointLocationSearch = &Endpoint{
+		Method: http.MethodGet, Path: "/location_search", Params: []string{"q", "latitude", "longitude", "fields"}, Fields: LocationFields,
+		Scope: "threads_location_tagging", Quotas: []string{"location_searches"}, Commands: []string{"locations search"}, Summary: "Search locations by name or coordinates",
+	}
+	endpointLocation = &Endpoint{
+		Method: http.MethodGet, Path: "/{location-id}", Params: []string{"fields"}, Fields: LocationFields,
+		Scope: "threads_location_tagging", Commands: []string{"locations get"}, Summary: "Get a location",
+	}
+
+	endpointExchangeCode = &Endpoint{
+		Method: http.MethodPost, Path: "/oauth/access_token", Params: []string{"client_id", "client_secret", "grant_type", "redirect_uri", "code"},
+		Commands: []string{"auth login"}, Summary: "Exchange an authorization code for a short-lived token",
+	}
+	endpointLongLivedToken = &Endpoint{
+		Method: http.MethodGet, Path: "/access_token", Params: []string{"grant_type", "client_secret", "access_token"},
+		Commands: []string{"auth login"}, Summary: "Exchange a short-lived token for a long-lived one",
+	}
+	endpointRefreshToken = &Endpoint{
+		Method: http.MethodGet, Path: "/refresh_access_token", Params: []string{"grant_type", "access_token"},
+		Commands: []string{"auth refresh"}, Summary: "Refresh a long-lived token",
+	}
+	endpointDebugToken = &Endpoint{
+		Method: http.MethodGet, Path: "/debug_token", Params: []string{"input_token", "access_token"},
+		Commands: []string{"auth debug"}, Summary: "Inspect an access token",
+	}
+
+	endpointSubscribe = &Endpoint{
+		Method: http.MethodPost, Path: "/v1.0/{app-id}/subscriptions", Params: []string{"object", "callback_url", "fields", "verify_token"},
+		Commands: []string{"webhooks subscribe"}, Summary: "Subscribe the app to webhook events",
+	}
+	endpointSubscriptions = &Endpoint{
+		Method: http.MethodGet, Path: "/v1.0/{app-id}/subscriptions", Params: []string{"access_token"},
+		Commands: []string{"webhooks list", "webhooks serve"}, Summary: "List the app's webhook subscriptions",
+	}
+	endpointUnsubscribe = &Endpoint{
+		Method: http.MethodDelete, Path: "/v1.0/{app-id}/subscriptions", Params: []string{"object"},
+		Commands: []string{"webhooks delete"}, Summary: "Delete a webhook subscription",
+	}
+
+	endpointBatch = &Endpoint{
+		Method: http.MethodPost, Path: "/", Params: []string{"batch"},
+		Commands: []string{"insights post", "insights reach", "replies hide", "replies unhide"}, Summary: "Run up to 50 requests in one call",
+	}
+)
+
+var endpoints = []*Endpoint{
+	endpointPost, endpointDeletePost, endpointUserThreads, endpointGhostPosts, endpointCreateContainer,
+	endpointContainerStatus, endpointPublish, endpointRepost, endpointUnrepost, endpointPublishingLimit,
+	endpointReplies, endpointConversation, endpointManageReply, endpointUserReplies,
+	endpointUser, endpointMentions, endpointProfileLookup, endpointProfilePosts,
+	endpointPostInsights, endpointAccountInsights,
+	endpointKeywordSearch, endpointLocationSearch, endpointLocation,
+	endpointExchangeCode, endpointLongLivedToken, endpointRefreshToken, endpointDebugToken,
+	endpointSubscribe, endpointSubscriptions, endpointUnsubscribe,
+	endpointBatch,
+}
+
+// Endpoints returns every endpoint the client calls.
+func Endpoints() []Endpoint {
+	out := make([]Endpoint, len(endpoints))
+	for i, e := range endpoints {
+		out[i] = *e
+		out[i].Params = append([]string(nil), e.Params...)
+		out[i].Quotas = append([]string(nil), e.Quotas...)
+		out[i].Commands = append([]string(nil), e.Commands...)
+		out[i].Optional = append([]string(nil), e.Optional...)
+	}
+	return out
+}
+
+// RequiredScopes returns the scopes a token needs for command, a command path
+// without the root command, in the order its endpoints are declared. Scopes
+// of endpoints the command can do without are left out.
+func RequiredScopes(command string) []string {
+	var scopes []string
+	for _, e := range endpoints {
+		if e.Scope == "" || !slices.Contains(e.Commands, command) || slices.Contains(e.Optional, command) {
+			continue
+		}
+		if !slices.Contains(scopes, e.Scope) {
+			scopes = append(scopes, e.Scope)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestEndpoint_Path(t *testing.T) {
+	tests := []struct {
+		endpoint *Endpoint
+		ids      []string
+		want     string
+	}{
+		{endpointPost, []string{"123"}, "/123"},
+		{endpointPostInsights, []string{"123"}, "/123/insights"},
+		{endpointSubscribe, []string{"app"}, "/v1.0/app/subscriptions"},
+		{endpointKeywordSearch, nil, "/keyword_search"},
+		{endpointBatch, nil, "/"},
+	}
+	for _, tt := range tests {
+		if got := tt.endpoint.path(tt.ids...); got != tt.want {
+			t.Errorf("%s.path(%v) = %q, want %q", tt.endpoint.Path, tt.ids, got, tt.want)
+		}
+	}
+}
+
+func TestEndpoints_Unique(t *testing.T) {
+	seen := map[string]bool{}
+	for _, e := range Endpoints() {
+		key := e.Method + " " + e.Path
+		if seen[key] {
+			t.Errorf("%s is registered twice", key)
+		}
+		seen[key] = true
+		if e.Summary == "" {
+			t.Errorf("%s has no summary", key)
+		}
+		if e.Fields != "" && !slices.Contains(e.Params, "fields") {
+			t.Errorf("%s has default fields but no fields param", key)
+		}
+		if e.Cached && e.Method != http.MethodGet {
+			t.Errorf("%s is cached but changes something", key)
+		}
+		for _, command := range e.Optional {
+			if !slices.Contains(e.Commands, command) {
+				t.Errorf("%s: optional command %q does not call it", key, command)
+			}
+		}
+	}
+}
+
+func TestRequiredScopes(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{"posts get", []string{"threads_basic"}},
+		{"replies hide", []string{"threads_manage_replies"}},
+		{"posts create", []string{"threads_content_publish"}},
+		{"auth login", nil},
+		{"config get", nil},
+	}
+	for _, tt := range tests {
+		if got := RequiredScopes(tt.command); !slices.Equal(got, tt.want) {
+			t.Errorf("RequiredScopes(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
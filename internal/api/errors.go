@@ -13,6 +13,8 @@ type BaseError struct {
 	Message string `json:"message"`
 	Type    string `json:"type"`
 	Details string `json:"details,omitempty"`
+
+	meta *ResponseMeta
 }
 
 // Error implements the error interface
@@ -23,6 +25,16 @@ func (e *BaseError) Error() string {
 	return fmt.Sprintf("threads api error %d (%s): %s", e.Code, e.Type, e.Message)
 }
 
+// ResponseMeta returns metadata for the HTTP response that produced the error,
+// or nil if the error did not come from an API response.
+func (e *BaseError) ResponseMeta() *ResponseMeta {
+	return e.meta
+}
+
+func (e *BaseError) setResponseMeta(meta *ResponseMeta) {
+	e.meta = meta
+}
+
 // AuthenticationError represents authentication-related errors such as
 // invalid tokens, expired tokens, or missing authentication credentials.
 // Common HTTP status codes: 401 (Unauthorized), 403 (Forbidden).
@@ -46,10 +58,13 @@ func NewAuthenticationError(code int, message, details string) *AuthenticationEr
 
 // RateLimitError represents rate limiting errors when API quota is exceeded.
 // Contains RetryAfter duration indicating when the client can retry the request.
+// Bucket and ResetAt are filled in from the platform usage headers when available.
 // Common HTTP status code: 429 (Too Many Requests).
 type RateLimitError struct {
 	*BaseError
-	RetryAfter time.Duration `json:"retry_after"`
+	RetryAfter time.Duration   `json:"retry_after"`
+	Bucket     RateLimitBucket `json:"bucket,omitempty"`
+	ResetAt    time.Time       `json:"reset_at,omitempty"`
 }
 
 // NewRateLimitError creates a new rate limit error with retry information.
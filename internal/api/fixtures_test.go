@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/fixtures"
+)
+
+// fixturePostID is the newest post in the user_posts fixture
+const fixturePostID = "18063927415508136"
+
+// fixtureHandler serves the recorded fixtures by path
+func fixtureHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var name string
+		switch path := r.URL.Path; {
+		case path == "/refresh_access_token":
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+			return
+		case strings.HasSuffix(path, "/threads_publishing_limit"):
+			name = "publishing_limit"
+		case strings.HasSuffix(path, "/threads"):
+			name = "user_posts"
+		case strings.HasSuffix(path, "/replies"):
+			name = "replies"
+		case strings.HasSuffix(path, "/insights"):
+			name = "post_insights"
+		case path == "/"+fixturePostID:
+			name = "post"
+		case path == "/12345":
+			name = "me"
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(fixtures.Get(name))
+	}
+}
+
+func TestFixtures_Decode(t *testing.T) {
+	client, server := createTestClient(t, fixtureHandler(t))
+	defer server.Close()
+	ctx := context.Background()
+
+	t.Run("me", func(t *testing.T) {
+		user, err := client.GetMe(ctx)
+		if err != nil {
+			t.Fatalf("GetMe() error = %v", err)
+		}
+		if user.Username != "user_k3v9q2md" || user.ProfilePicURL == "" || user.Biography == "" {
+			t.Errorf("GetMe() = %+v", user)
+		}
+	})
+
+	t.Run("user posts", func(t *testing.T) {
+		posts, err := client.GetUserPosts(ctx, UserID("12345"), &PaginationOptions{Limit: 3})
+		if err != nil {
+			t.Fatalf("GetUserPosts() error = %v", err)
+		}
+		if len(posts.Data) != 3 {
+			t.Fatalf("got %d posts, want 3", len(posts.Data))
+		}
+		if posts.Data[0].ID != fixturePostID || posts.Data[0].Timestamp.IsZero() {
+			t.Errorf("first post = %+v", posts.Data[0])
+		}
+		if carousel := posts.Data[2]; carousel.Children == nil || len(carousel.Children.Data) != 3 {
+			t.Errorf("carousel children = %+v", carousel.Children)
+		}
+		if posts.Paging.Cursors == nil || posts.Paging.Cursors.After == "" {
+			t.Errorf("paging = %+v, want an after cursor", posts.Paging)
+		}
+	})
+
+	t.Run("post", func(t *testing.T) {
+		post, err := client.GetPost(ctx, PostID(fixturePostID))
+		if err != nil {
+			t.Fatalf("GetPost() error = %v", err)
+		}
+		if post.Owner == nil || post.Owner.ID == "" || post.Shortcode == "" || !post.HasReplies {
+			t.Errorf("GetPost() = %+v", post)
+		}
+	})
+
+	t.Run("replies", func(t *testing.T) {
+		replies, err := client.GetReplies(ctx, PostID(fixturePostID), nil)
+		if err != nil {
+			t.Fatalf("GetReplies() error = %v", err)
+		}
+		if len(replies.Data) != 2 || !replies.Data[0].IsReply {
+			t.Errorf("GetReplies() = %+v", replies.Data)
+		}
+	})
+
+	t.Run("insights", func(t *testing.T) {
+		insights, err := client.GetPostInsights(ctx, PostID(fixturePostID), nil)
+		if err != nil {
+			t.Fatalf("GetPostInsights() error = %v", err)
+		}
+		if len(insights.Data) == 0 || insights.Data[0].Name != "views" || insights.Data[0].Values[0].Value != 1843 {
+			t.Errorf("GetPostInsights() = %+v", insights.Data)
+		}
+	})
+
+	t.Run("publishing limits", func(t *testing.T) {
+		limits, err := client.GetPublishingLimits(ctx)
+		if err != nil {
+			t.Fatalf("GetPublishingLimits() error = %v", err)
+		}
+		if limits.QuotaUsage != 4 || limits.Config.QuotaTotal != 250 || limits.ReplyConfig.QuotaTotal != 1000 {
+			t.Errorf("GetPublishingLimits() = %+v", limits)
+		}
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -20,8 +21,19 @@ type HTTPClient struct {
 	logger      Logger
 	retryConfig *RetryConfig
 	rateLimiter *RateLimiter
-	baseURL     string
-	userAgent   string
+	// rateLimitingOff skips waiting out rate limits; see DisableRateLimiting
+	rateLimitingOff atomic.Bool
+	baseURL         string
+	userAgent       string
+	trace           *HTTPTrace
+	cache           *ResponseCache
+	coordinator     RateCoordinator
+	limits          *limitState
+
+	// compressRequests gzips large request bodies until the API refuses one
+	// with 415 Unsupported Media Type, which sets compressionRefused
+	compressRequests   bool
+	compressionRefused atomic.Bool
 }
 
 // RequestOptions holds options for HTTP requests
@@ -42,6 +54,7 @@ type Response struct {
 	RateLimit  *RateLimitInfo
 	Duration   time.Duration
 	StatusCode int
+	Meta       *ResponseMeta
 }
 
 // RateLimitInfo contains rate limiting information from response headers
@@ -68,6 +81,11 @@ func NewHTTPClient(config *Config, rateLimiter *RateLimiter) *HTTPClient {
 		userAgent = DefaultUserAgent
 	}
 
+	var limits *limitState
+	if config.RateLimitStore != nil {
+		limits = &limitState{store: config.RateLimitStore}
+	}
+
 	return &HTTPClient{
 		client:      httpClient,
 		logger:      config.Logger,
@@ -75,6 +93,12 @@ func NewHTTPClient(config *Config, rateLimiter *RateLimiter) *HTTPClient {
 		rateLimiter: rateLimiter,
 		baseURL:     baseURL,
 		userAgent:   userAgent,
+		trace:       config.HTTPTrace,
+		cache:       config.ResponseCache,
+		coordinator: config.RateCoordinator,
+		limits:      limits,
+
+		compressRequests: config.CompressRequests,
 	}
 }
 
@@ -85,7 +109,7 @@ func (h *HTTPClient) Do(opts *RequestOptions, accessToken string) (*Response, er
 	}
 
 	// Only wait for rate limiter if we've been explicitly rate limited by the API
-	if h.rateLimiter != nil && h.rateLimiter.ShouldWait() {
+	if h.rateLimiter != nil && !h.rateLimitingOff.Load() && h.rateLimiter.ShouldWait() {
 		if err := h.rateLimiter.Wait(opts.Context); err != nil {
 			return nil, fmt.Errorf("rate limiter wait failed: %w", err)
 		}
@@ -111,7 +135,25 @@ func (h *HTTPClient) Do(opts *RequestOptions, accessToken string) (*Response, er
 			}
 		}
 
+		if err := spendCallBudget(opts.Context); err != nil {
+			return nil, err
+		}
+		if err := h.coordinate(opts.Context); err != nil {
+			return nil, err
+		}
+
 		resp, err := h.executeRequest(opts, accessToken)
+		if resp != nil {
+			resp.Meta = newResponseMeta(resp, attempt+1)
+			recordResponseMeta(opts.Context, resp.Meta)
+			err = attachResponseMeta(err, resp.Meta)
+		}
+		if errors.Is(err, errCompressionRefused) {
+			// Sent again uncompressed, which is not a retry; compressionRefused
+			// is now set, so this happens at most once
+			attempt--
+			continue
+		}
 		if err != nil {
 			lastErr = err
 
@@ -128,14 +170,27 @@ func (h *HTTPClient) Do(opts *RequestOptions, accessToken string) (*Response, er
 		if h.rateLimiter != nil && resp.RateLimit != nil {
 			h.rateLimiter.UpdateFromHeaders(resp.RateLimit)
 		}
+		if h.coordinator != nil && resp.RateLimit != nil {
+			h.coordinator.Update(resp.RateLimit)
+		}
+		if resp.RateLimit != nil {
+			h.saveLimits(nil)
+		}
 
 		// Check if we should retry based on status code
 		if h.shouldRetryStatus(resp.StatusCode) {
-			lastErr = h.createErrorFromResponse(resp)
+			lastErr = attachResponseMeta(h.createErrorFromResponse(resp), resp.Meta)
 			h.logRetry(attempt, maxRetries, lastErr)
 			continue
 		}
 
+		// A change may make any cached read of the token stale
+		if h.cache != nil && opts.Method != http.MethodGet {
+			if err := h.cache.invalidate(accessToken); err != nil && h.logger != nil {
+				h.logger.Warn("Failed to clear cached responses", "error", err.Error())
+			}
+		}
+
 		return resp, nil
 	}
 
@@ -147,48 +202,93 @@ func (h *HTTPClient) executeRequest(opts *RequestOptions, accessToken string) (*
 	startTime := time.Now()
 
 	// Build URL
-	fullURL := h.baseURL + opts.Path
+	urlBuf := getBuffer()
+	urlBuf.WriteString(h.baseURL)
+	urlBuf.WriteString(opts.Path)
 	if len(opts.QueryParams) > 0 {
-		fullURL += "?" + opts.QueryParams.Encode()
+		urlBuf.WriteByte('?')
+		writeForm(urlBuf, opts.QueryParams)
 	}
+	fullURL := urlBuf.String()
+	putBuffer(urlBuf)
 
-	// Prepare request body
-	var bodyReader io.Reader
+	// Prepare request body. Forms and JSON are encoded into a pooled buffer.
+	var bodyBytes []byte
 	var contentType string
+	var pooled *pooledBody
 
 	if opts.Body != nil {
 		switch body := opts.Body.(type) {
 		case string:
-			bodyReader = strings.NewReader(body)
+			bodyBytes = []byte(body)
 			contentType = "text/plain"
 		case []byte:
-			bodyReader = bytes.NewReader(body)
+			bodyBytes = body
 			contentType = "application/octet-stream"
 		case url.Values:
-			bodyReader = strings.NewReader(body.Encode())
+			buf := getBuffer()
+			writeForm(buf, body)
+			pooled = newPooledBody(buf)
 			contentType = "application/x-www-form-urlencoded"
 		default:
 			// JSON encode by default
-			jsonData, err := json.Marshal(body)
-			if err != nil {
+			buf := getBuffer()
+			if err := json.NewEncoder(buf).Encode(body); err != nil {
+				putBuffer(buf)
 				return nil, fmt.Errorf("failed to marshal request body: %w", err)
 			}
-			bodyReader = bytes.NewReader(jsonData)
+			// Unlike json.Marshal, Encode ends with a newline
+			buf.Truncate(buf.Len() - 1)
+			pooled = newPooledBody(buf)
 			contentType = "application/json"
 		}
 	}
+	if pooled != nil {
+		defer pooled.release()
+		bodyBytes = pooled.buf.Bytes()
+	}
+
+	// wire is the body as sent, compressed if it is large enough to gain
+	wire, wireBytes := pooled, bodyBytes
+	compressed := h.compressRequests && !h.compressionRefused.Load() && len(bodyBytes) >= compressMinSize
+	if compressed {
+		buf := getBuffer()
+		if err := compressBody(buf, bodyBytes); err != nil {
+			putBuffer(buf)
+			return nil, fmt.Errorf("failed to compress request body: %w", err)
+		}
+		wire = newPooledBody(buf)
+		defer wire.release()
+		wireBytes = wire.buf.Bytes()
+	}
+
+	var bodyReader io.Reader
+	if wireBytes != nil && wire == nil {
+		bodyReader = bytes.NewReader(wireBytes)
+	}
 
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(opts.Context, opts.Method, fullURL, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	if wire != nil && len(wireBytes) > 0 {
+		// The transport closes each body it opens when done with it, which
+		// hands the buffer back to the pool
+		req.Body = wire.open()
+		req.GetBody = func() (io.ReadCloser, error) { return wire.open(), nil }
+		req.ContentLength = int64(len(wireBytes))
+	}
 
 	// Set headers
 	req.Header.Set("User-Agent", h.userAgent)
+	req.Header.Set("Accept-Encoding", acceptEncoding)
 	if contentType != "" {
 		req.Header.Set("Content-Type", contentType)
 	}
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
 	if accessToken != "" {
 		req.Header.Set("Authorization", "Bearer "+accessToken)
 	}
@@ -200,10 +300,12 @@ func (h *HTTPClient) executeRequest(opts *RequestOptions, accessToken string) (*
 
 	// Log request
 	h.logRequest(req, opts.Body)
+	h.trace.traceRequest(req, bodyBytes)
 
 	// Execute request
 	httpResp, err := h.client.Do(req)
 	if err != nil {
+		h.trace.traceError(req, err)
 		return nil, h.wrapNetworkError(err)
 	}
 	defer func(Body io.ReadCloser) {
@@ -214,11 +316,25 @@ func (h *HTTPClient) executeRequest(opts *RequestOptions, accessToken string) (*
 	}(httpResp.Body)
 
 	// Read response body
-	respBody, errRead := io.ReadAll(httpResp.Body)
+	respBody, errRead := decodeBody(httpResp)
 	if errRead != nil {
+		var netErr *NetworkError
+		if errors.As(errRead, &netErr) {
+			h.trace.traceError(req, errRead)
+			return nil, netErr
+		}
 		return nil, fmt.Errorf("failed to read response body: %w", errRead)
 	}
 
+	if compressed && httpResp.StatusCode == http.StatusUnsupportedMediaType {
+		// The API does not take compressed bodies, so stop sending them
+		h.compressionRefused.Store(true)
+		if h.logger != nil {
+			h.logger.Warn("Request compression refused, sending uncompressed bodies", "path", opts.Path)
+		}
+		return nil, errCompressionRefused
+	}
+
 	// Create response wrapper
 	resp := &Response{
 		Response:   httpResp,
@@ -231,6 +347,7 @@ func (h *HTTPClient) executeRequest(opts *RequestOptions, accessToken string) (*
 
 	// Log response
 	h.logResponse(resp)
+	h.trace.traceResponse(req, resp)
 
 	// Check for HTTP errors
 	if httpResp.StatusCode >= 400 {
@@ -311,27 +428,23 @@ func (h *HTTPClient) createErrorFromResponse(resp *Response) error {
 	case 403:
 		return NewAuthenticationError(errorCode, message, details)
 	case 429:
-		retryAfter := time.Duration(0)
-		resetTime := time.Time{}
-		if resp.RateLimit != nil {
-			if resp.RateLimit.RetryAfter > 0 {
-				retryAfter = resp.RateLimit.RetryAfter
-			}
-			if !resp.RateLimit.Reset.IsZero() {
-				resetTime = resp.RateLimit.Reset
-			}
-		}
+		rateLimitErr := rateLimitErrorFromResponse(resp, errorCode, message, details)
 
 		// Mark the rate limiter as rate limited by the API
+		resetTime := rateLimitErr.ResetAt
+		if resetTime.IsZero() {
+			// If no reset time provided, estimate based on retry after
+			resetTime = time.Now().Add(rateLimitErr.RetryAfter)
+		}
 		if h.rateLimiter != nil {
-			if resetTime.IsZero() {
-				// If no reset time provided, estimate based on retry after
-				resetTime = time.Now().Add(retryAfter)
-			}
 			h.rateLimiter.MarkRateLimited(resetTime)
 		}
+		if h.coordinator != nil {
+			h.coordinator.RateLimited(resetTime)
+		}
+		h.saveLimits(nil)
 
-		return NewRateLimitError(errorCode, message, details, retryAfter)
+		return rateLimitErr
 	case 400, 422:
 		return NewValidationError(errorCode, message, details, "")
 	case 500, 502, 503, 504:
@@ -359,36 +472,12 @@ func (h *HTTPClient) wrapNetworkError(err error) error {
 
 // isRetryableError determines if an error should trigger a retry
 func (h *HTTPClient) isRetryableError(err error) bool {
-	// Rate limit errors are retry-able
-	if IsRateLimitError(err) {
-		return true
-	}
-
-	// Temporary network errors are retry-able
-	var netErr *NetworkError
-	if errors.As(err, &netErr) {
-		return netErr.Temporary
-	}
-
-	// Some API errors are retry-able (5xx status codes)
-	var apiErr *APIError
-	if errors.As(err, &apiErr) {
-		return apiErr.Code >= 500 && apiErr.Code < 600
-	}
-
-	return false
+	return IsRetryable(err)
 }
 
 // shouldRetryStatus determines if a status code should trigger a retry
 func (h *HTTPClient) shouldRetryStatus(statusCode int) bool {
-	switch statusCode {
-	case 429: // Too Many Requests
-		return true
-	case 500, 502, 503, 504: // Server errors
-		return true
-	default:
-		return false
-	}
+	return isRetryableStatus(statusCode)
 }
 
 // logRequest logs the outgoing HTTP request
@@ -469,36 +558,40 @@ func (h *HTTPClient) sanitizeHeaders(headers http.Header) map[string]string {
 }
 
 // GET performs a GET request
-func (h *HTTPClient) GET(path string, queryParams url.Values, accessToken string) (*Response, error) {
+func (h *HTTPClient) GET(ctx context.Context, path string, queryParams url.Values, accessToken string) (*Response, error) {
 	return h.Do(&RequestOptions{
 		Method:      "GET",
 		Path:        path,
 		QueryParams: queryParams,
+		Context:     ctx,
 	}, accessToken)
 }
 
 // POST performs a POST request
-func (h *HTTPClient) POST(path string, body interface{}, accessToken string) (*Response, error) {
+func (h *HTTPClient) POST(ctx context.Context, path string, body interface{}, accessToken string) (*Response, error) {
 	return h.Do(&RequestOptions{
-		Method: "POST",
-		Path:   path,
-		Body:   body,
+		Method:  "POST",
+		Path:    path,
+		Body:    body,
+		Context: ctx,
 	}, accessToken)
 }
 
 // PUT performs a PUT request
-func (h *HTTPClient) PUT(path string, body interface{}, accessToken string) (*Response, error) {
+func (h *HTTPClient) PUT(ctx context.Context, path string, body interface{}, accessToken string) (*Response, error) {
 	return h.Do(&RequestOptions{
-		Method: "PUT",
-		Path:   path,
-		Body:   body,
+		Method:  "PUT",
+		Path:    path,
+		Body:    body,
+		Context: ctx,
 	}, accessToken)
 }
 
 // DELETE performs a DELETE request
-func (h *HTTPClient) DELETE(path string, accessToken string) (*Response, error) {
+func (h *HTTPClient) DELETE(ctx context.Context, path string, accessToken string) (*Response, error) {
 	return h.Do(&RequestOptions{
-		Method: "DELETE",
-		Path:   path,
+		Method:  "DELETE",
+		Path:    path,
+		Context: ctx,
 	}, accessToken)
 }
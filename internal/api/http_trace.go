@@ -0,0 +1,165 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultHTTPTraceMaxBody is the number of body bytes an HTTPTrace records
+// per request or response when no limit is given
+const DefaultHTTPTraceMaxBody = 64 * 1024
+
+const redacted = "[REDACTED]"
+
+// sensitiveParams are query, form and JSON keys whose values are never written to a trace
+var sensitiveParams = map[string]bool{
+	"access_token":      true,
+	"client_secret":     true,
+	"code":              true,
+	"refresh_token":     true,
+	"fb_exchange_token": true,
+	"input_token":       true,
+	"appsecret_proof":   true,
+}
+
+// sensitiveHeaders are headers whose values are never written to a trace
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+var sensitiveJSONPattern = regexp.MustCompile(`"(access_token|client_secret|code|refresh_token|fb_exchange_token|input_token|appsecret_proof)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// HTTPTrace writes a full log of every request and response to w, for
+// diagnosing a session after the fact. Credentials are redacted from URLs,
+// headers and bodies, and bodies are truncated to a maximum size. An
+// HTTPTrace is safe for concurrent use.
+type HTTPTrace struct {
+	mu      sync.Mutex
+	w       io.Writer
+	maxBody int
+}
+
+// NewHTTPTrace creates a trace that writes to w. maxBodyBytes limits how much
+// of each body is recorded; zero uses DefaultHTTPTraceMaxBody and a negative
+// value omits bodies entirely.
+func NewHTTPTrace(w io.Writer, maxBodyBytes int) *HTTPTrace {
+	if maxBodyBytes == 0 {
+		maxBodyBytes = DefaultHTTPTraceMaxBody
+	}
+	return &HTTPTrace{w: w, maxBody: maxBodyBytes}
+}
+
+// traceRequest records an outgoing request and its encoded body
+func (t *HTTPTrace) traceRequest(req *http.Request, body []byte) {
+	if t == nil {
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--> %s %s %s\n", time.Now().Format(time.RFC3339Nano), req.Method, redactURL(req.URL))
+	writeTraceHeaders(&b, req.Header)
+	t.writeBody(&b, body, req.Header.Get("Content-Type"))
+	t.write(b.String())
+}
+
+// traceResponse records the response to req
+func (t *HTTPTrace) traceResponse(req *http.Request, resp *Response) {
+	if t == nil {
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<-- %s %d %s (%s) %s %s\n", time.Now().Format(time.RFC3339Nano), resp.StatusCode,
+		http.StatusText(resp.StatusCode), resp.Duration.Round(time.Millisecond), req.Method, redactURL(req.URL))
+	writeTraceHeaders(&b, resp.Header)
+	t.writeBody(&b, resp.Body, resp.Header.Get("Content-Type"))
+	t.write(b.String())
+}
+
+// traceError records a request that failed without a response
+func (t *HTTPTrace) traceError(req *http.Request, err error) {
+	if t == nil {
+		return
+	}
+	t.write(fmt.Sprintf("<-- %s ERROR %s %s: %v\n\n", time.Now().Format(time.RFC3339Nano), req.Method, redactURL(req.URL), err))
+}
+
+func (t *HTTPTrace) write(entry string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	io.WriteString(t.w, entry) //nolint:errcheck // Best-effort output
+}
+
+func (t *HTTPTrace) writeBody(b *strings.Builder, body []byte, contentType string) {
+	if len(body) > 0 && t.maxBody > 0 {
+		text := redactBody(string(body), contentType)
+		if len(text) > t.maxBody {
+			fmt.Fprintf(b, "\n%s\n[truncated %d of %d bytes]\n", text[:t.maxBody], len(text)-t.maxBody, len(text))
+		} else {
+			fmt.Fprintf(b, "\n%s\n", text)
+		}
+	}
+	b.WriteString("\n")
+}
+
+func writeTraceHeaders(b *strings.Builder, headers http.Header) {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := strings.Join(headers[key], ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(key)] {
+			value = redacted
+		}
+		fmt.Fprintf(b, "%s: %s\n", key, value)
+	}
+}
+
+// redactURL returns u as a string with sensitive query parameters redacted
+func redactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	redactedURL := *u
+	redactedURL.RawQuery = encodeRedacted(redactValues(u.Query()))
+	return redactedURL.String()
+}
+
+func redactValues(values url.Values) url.Values {
+	out := make(url.Values, len(values))
+	for key, vals := range values {
+		if sensitiveParams[key] {
+			out[key] = []string{redacted}
+			continue
+		}
+		out[key] = vals
+	}
+	return out
+}
+
+// encodeRedacted encodes values, keeping the redaction marker readable
+func encodeRedacted(values url.Values) string {
+	return strings.ReplaceAll(values.Encode(), url.QueryEscape(redacted), redacted)
+}
+
+// redactBody removes credentials from form-encoded and JSON bodies
+func redactBody(body, contentType string) string {
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(body); err == nil {
+			return encodeRedacted(redactValues(values))
+		}
+	}
+	return sensitiveJSONPattern.ReplaceAllString(body, `"$1"$2"`+redacted+`"`)
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestHTTPTrace_RedactsAndTruncates(t *testing.T) {
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"secret-response-token","bio":"` + strings.Repeat("a", 100) + `"}`))
+	})
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client.httpClient.trace = NewHTTPTrace(&buf, 60)
+
+	form := url.Values{"client_secret": {"app-secret"}, "grant_type": {"th_exchange_token"}}
+	if _, err := client.httpClient.POST(context.Background(), "/oauth/access_token", form, "secret-request-token"); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	log := buf.String()
+	for _, secret := range []string{"app-secret", "secret-request-token", "secret-response-token"} {
+		if strings.Contains(log, secret) {
+			t.Errorf("trace leaked %q:\n%s", secret, log)
+		}
+	}
+	for _, want := range []string{"--> ", "POST", "Authorization: [REDACTED]", "client_secret=[REDACTED]", "grant_type=th_exchange_token", "<-- ", "200 OK", "[truncated"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("trace missing %q:\n%s", want, log)
+		}
+	}
+}
+
+func TestHTTPTrace_OmitsBodies(t *testing.T) {
+	var buf bytes.Buffer
+	trace := NewHTTPTrace(&buf, -1)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://graph.threads.net/me?access_token=tok&fields=id", nil)
+	trace.traceRequest(req, []byte(`{"text":"hello"}`))
+
+	log := buf.String()
+	if strings.Contains(log, "hello") {
+		t.Errorf("expected body to be omitted:\n%s", log)
+	}
+	if !strings.Contains(log, "access_token=[REDACTED]&fields=id") {
+		t.Errorf("expected redacted query string:\n%s", log)
+	}
+}
+
+func TestHTTPTrace_NilIsNoop(t *testing.T) {
+	var trace *HTTPTrace
+	req, _ := http.NewRequest(http.MethodGet, "https://graph.threads.net/me", nil)
+	trace.traceRequest(req, nil)
+	trace.traceResponse(req, &Response{Response: &http.Response{}})
+	trace.traceError(req, nil)
+}
+
+func TestRedactBody_JSON(t *testing.T) {
+	got := redactBody(`{"code": "abc123", "refresh_token":"r\"x", "text":"keep"}`, "application/json")
+	want := `{"code": "[REDACTED]", "refresh_token":"[REDACTED]", "text":"keep"}`
+	if got != want {
+		t.Errorf("redactBody() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// InsightMetricSpec describes which request parameters an insight metric accepts
+type InsightMetricSpec struct {
+	// Periods lists the periods the metric can be requested for
+	Periods []InsightPeriod
+	// Breakdowns lists the supported breakdowns; empty means the metric has none
+	Breakdowns []FollowerDemographicsBreakdown
+	// SupportsTimeRange reports whether since and until may be used with the metric
+	SupportsTimeRange bool
+}
+
+var (
+	allInsightPeriods      = []InsightPeriod{InsightPeriodDay, InsightPeriodLifetime}
+	lifetimeInsightPeriods = []InsightPeriod{InsightPeriodLifetime}
+)
+
+// postInsightMetricSpecs is the registry of supported post insight metrics.
+// Post insights are always cumulative over the lifetime of the post.
+var postInsightMetricSpecs = map[PostInsightMetric]InsightMetricSpec{
+	PostInsightViews:         {Periods: lifetimeInsightPeriods, SupportsTimeRange: true},
+	PostInsightLikes:         {Periods: lifetimeInsightPeriods, SupportsTimeRange: true},
+	PostInsightReplies:       {Periods: lifetimeInsightPeriods, SupportsTimeRange: true},
+	PostInsightReposts:       {Periods: lifetimeInsightPeriods, SupportsTimeRange: true},
+	PostInsightQuotes:        {Periods: lifetimeInsightPeriods, SupportsTimeRange: true},
+	PostInsightShares:        {Periods: lifetimeInsightPeriods, SupportsTimeRange: true},
+	PostInsightLinkClicks:    {Periods: lifetimeInsightPeriods, SupportsTimeRange: true},
+	PostInsightProfileClicks: {Periods: lifetimeInsightPeriods, SupportsTimeRange: true},
+}
+
+// accountInsightMetricSpecs is the registry of supported account insight metrics.
+// Follower metrics are snapshots, so they only support the lifetime period and
+// cannot be limited to a time range.
+var accountInsightMetricSpecs = map[AccountInsightMetric]InsightMetricSpec{
+	AccountInsightViews:          {Periods: allInsightPeriods, SupportsTimeRange: true},
+	AccountInsightLikes:          {Periods: allInsightPeriods, SupportsTimeRange: true},
+	AccountInsightReplies:        {Periods: allInsightPeriods, SupportsTimeRange: true},
+	AccountInsightReposts:        {Periods: allInsightPeriods, SupportsTimeRange: true},
+	AccountInsightQuotes:         {Periods: allInsightPeriods, SupportsTimeRange: true},
+	AccountInsightClicks:         {Periods: allInsightPeriods, SupportsTimeRange: true},
+	AccountInsightFollowersCount: {Periods: lifetimeInsightPeriods},
+	AccountInsightFollowerDemographics: {
+		Periods:    lifetimeInsightPeriods,
+		Breakdowns: []FollowerDemographicsBreakdown{BreakdownCountry, BreakdownCity, BreakdownAge, BreakdownGender},
+	},
+}
+
+// LookupPostInsightMetric returns the spec for a post insight metric
+func LookupPostInsightMetric(metric PostInsightMetric) (InsightMetricSpec, bool) {
+	spec, ok := postInsightMetricSpecs[metric]
+	return spec, ok
+}
+
+// LookupAccountInsightMetric returns the spec for an account insight metric
+func LookupAccountInsightMetric(metric AccountInsightMetric) (InsightMetricSpec, bool) {
+	spec, ok := accountInsightMetricSpecs[metric]
+	return spec, ok
+}
+
+// SupportsPeriod reports whether the metric can be requested for period
+func (s InsightMetricSpec) SupportsPeriod(period InsightPeriod) bool {
+	return slices.Contains(s.Periods, period)
+}
+
+// SupportsBreakdown reports whether the metric can be broken down by breakdown
+func (s InsightMetricSpec) SupportsBreakdown(breakdown FollowerDemographicsBreakdown) bool {
+	return slices.Contains(s.Breakdowns, breakdown)
+}
+
+// insightRequest holds the parameters shared by every metric in an insights call
+type insightRequest struct {
+	period       InsightPeriod
+	breakdown    string
+	hasTimeRange bool
+}
+
+// validate checks the request parameters against the spec of a single metric
+func (s InsightMetricSpec) validate(metric string, req insightRequest) error {
+	if req.period != "" && !s.SupportsPeriod(req.period) {
+		return NewValidationError(400, "Unsupported insight period",
+			fmt.Sprintf("metric '%s' does not support period '%s' (supported: %s)", metric, req.period, joinInsightPeriods(s.Periods)), "metric")
+	}
+	if req.hasTimeRange && !s.SupportsTimeRange {
+		return NewValidationError(400, "Invalid parameters",
+			fmt.Sprintf("metric '%s' does not support since and until parameters", metric), "metric")
+	}
+	if req.breakdown != "" && len(s.Breakdowns) > 0 && !s.SupportsBreakdown(FollowerDemographicsBreakdown(req.breakdown)) {
+		return NewValidationError(400, "Invalid breakdown parameter",
+			fmt.Sprintf("metric '%s' does not support breakdown '%s'", metric, req.breakdown), "breakdown")
+	}
+	return nil
+}
+
+// ValidatePostInsightRequest checks that every metric supports the requested
+// period and time range. An empty period is not checked.
+func ValidatePostInsightRequest(metrics []PostInsightMetric, period InsightPeriod, hasTimeRange bool) error {
+	req := insightRequest{period: period, hasTimeRange: hasTimeRange}
+	for _, metric := range metrics {
+		spec, ok := postInsightMetricSpecs[metric]
+		if !ok {
+			return NewValidationError(400, "Invalid post insight metric",
+				fmt.Sprintf("metric '%s' is not supported for post insights", metric), "metric")
+		}
+		if err := spec.validate(string(metric), req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateAccountInsightRequest checks that every metric supports the requested
+// period, breakdown and time range. A breakdown must be supported by at least
+// one of the metrics. An empty period or breakdown is not checked.
+func ValidateAccountInsightRequest(metrics []AccountInsightMetric, period InsightPeriod, breakdown string, hasTimeRange bool) error {
+	req := insightRequest{period: period, breakdown: breakdown, hasTimeRange: hasTimeRange}
+	breakdownUsed := false
+	for _, metric := range metrics {
+		spec, ok := accountInsightMetricSpecs[metric]
+		if !ok {
+			return NewValidationError(400, "Invalid account insight metric",
+				fmt.Sprintf("metric '%s' is not supported for account insights", metric), "metric")
+		}
+		if err := spec.validate(string(metric), req); err != nil {
+			return err
+		}
+		if len(spec.Breakdowns) > 0 {
+			breakdownUsed = true
+		}
+	}
+
+	if breakdown != "" && !breakdownUsed {
+		return NewValidationError(400, "Invalid breakdown parameter",
+			fmt.Sprintf("breakdown '%s' requires the %s metric", breakdown, AccountInsightFollowerDemographics), "breakdown")
+	}
+	return nil
+}
+
+func joinInsightPeriods(periods []InsightPeriod) string {
+	names := make([]string, len(periods))
+	for i, period := range periods {
+		names[i] = string(period)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInsightMetricRegistry_CoversAvailableMetrics(t *testing.T) {
+	client := &Client{}
+
+	for _, metric := range client.GetAvailablePostInsightMetrics() {
+		if _, ok := LookupPostInsightMetric(metric); !ok {
+			t.Errorf("post metric %s missing from registry", metric)
+		}
+	}
+	for _, metric := range client.GetAvailableAccountInsightMetrics() {
+		if _, ok := LookupAccountInsightMetric(metric); !ok {
+			t.Errorf("account metric %s missing from registry", metric)
+		}
+	}
+}
+
+func TestValidateAccountInsightRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		metrics      []AccountInsightMetric
+		period       InsightPeriod
+		breakdown    string
+		hasTimeRange bool
+		wantMetric   string
+		wantField    string
+	}{
+		{name: "valid views by day", metrics: []AccountInsightMetric{AccountInsightViews}, period: InsightPeriodDay},
+		{name: "valid demographics breakdown", metrics: []AccountInsightMetric{AccountInsightFollowerDemographics}, period: InsightPeriodLifetime, breakdown: "country"},
+		{name: "unknown metric", metrics: []AccountInsightMetric{"bogus"}, wantMetric: "bogus", wantField: "metric"},
+		{name: "followers_count by day", metrics: []AccountInsightMetric{AccountInsightViews, AccountInsightFollowersCount}, period: InsightPeriodDay, wantMetric: "followers_count", wantField: "metric"},
+		{name: "demographics with time range", metrics: []AccountInsightMetric{AccountInsightFollowerDemographics}, hasTimeRange: true, wantMetric: "follower_demographics", wantField: "metric"},
+		{name: "invalid breakdown", metrics: []AccountInsightMetric{AccountInsightFollowerDemographics}, breakdown: "planet", wantMetric: "follower_demographics", wantField: "breakdown"},
+		{name: "breakdown without demographics", metrics: []AccountInsightMetric{AccountInsightViews}, breakdown: "age", wantMetric: "follower_demographics", wantField: "breakdown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAccountInsightRequest(tt.metrics, tt.period, tt.breakdown, tt.hasTimeRange)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected ValidationError, got %T: %v", err, err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("expected field %q, got %q", tt.wantField, validationErr.Field)
+			}
+			if !strings.Contains(validationErr.Details, tt.wantMetric) {
+				t.Errorf("expected details to name %q, got %q", tt.wantMetric, validationErr.Details)
+			}
+		})
+	}
+}
+
+func TestValidatePostInsightRequest_Period(t *testing.T) {
+	if err := ValidatePostInsightRequest([]PostInsightMetric{PostInsightViews}, InsightPeriodLifetime, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := ValidatePostInsightRequest([]PostInsightMetric{PostInsightLikes}, InsightPeriodDay, false)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got %T: %v", err, err)
+	}
+	if !strings.Contains(validationErr.Details, "'likes'") {
+		t.Errorf("expected details to name the metric, got %q", validationErr.Details)
+	}
+}
+
+func TestGetAccountInsightsWithOptions_RejectsBeforeRequest(t *testing.T) {
+	// A zero client has no HTTP client, so reaching the network would panic
+	client := &Client{}
+	since := time.Now().Add(-24 * time.Hour)
+
+	_, err := client.GetAccountInsightsWithOptions(context.Background(), ConvertToUserID("123"), &AccountInsightsOptions{
+		Metrics: []AccountInsightMetric{AccountInsightFollowersCount},
+		Since:   &since,
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got %T: %v", err, err)
+	}
+	if !strings.Contains(validationErr.Details, "followers_count") {
+		t.Errorf("expected details to name the metric, got %q", validationErr.Details)
+	}
+}
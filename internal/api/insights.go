@@ -129,8 +129,8 @@ func (c *Client) GetPostInsights(ctx context.Context, postID PostID, metrics []s
 	params := url.Values{}
 	params.Set("metric", strings.Join(validMetrics, ","))
 
-	path := fmt.Sprintf("/%s/insights", postID.String())
-	response, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointPostInsights.path(postID.String())
+	response, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get post insights: %w", err)
 	}
@@ -183,6 +183,14 @@ func (c *Client) GetPostInsightsWithOptions(ctx context.Context, postID PostID,
 		params.Set("period", string(opts.Period))
 	}
 
+	metrics := make([]PostInsightMetric, len(validMetrics))
+	for i, metric := range validMetrics {
+		metrics[i] = PostInsightMetric(metric)
+	}
+	if err := ValidatePostInsightRequest(metrics, opts.Period, opts.Since != nil || opts.Until != nil); err != nil {
+		return nil, err
+	}
+
 	// Add date range if specified
 	if opts.Since != nil {
 		params.Set("since", fmt.Sprintf("%d", opts.Since.Unix()))
@@ -198,8 +206,8 @@ func (c *Client) GetPostInsightsWithOptions(ctx context.Context, postID PostID,
 		}
 	}
 
-	path := fmt.Sprintf("/%s/insights", postID.String())
-	response, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointPostInsights.path(postID.String())
+	response, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get post insights: %w", err)
 	}
@@ -251,8 +259,12 @@ func (c *Client) GetAccountInsights(ctx context.Context, userID UserID, metrics
 		params.Set("period", string(InsightPeriodLifetime))
 	}
 
-	path := fmt.Sprintf("/%s/threads_insights", userID.String())
-	response, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	if err := ValidateAccountInsightRequest(toAccountInsightMetrics(validMetrics), InsightPeriod(params.Get("period")), "", false); err != nil {
+		return nil, err
+	}
+
+	path := endpointAccountInsights.path(userID.String())
+	response, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account insights: %w", err)
 	}
@@ -307,60 +319,31 @@ func (c *Client) GetAccountInsightsWithOptions(ctx context.Context, userID UserI
 		params.Set("period", string(InsightPeriodLifetime))
 	}
 
-	// Check for metrics that don't support since/until parameters
-	hasFollowerDemographics := false
-	hasFollowersCount := false
-	for _, metric := range validMetrics {
-		if metric == string(AccountInsightFollowerDemographics) {
-			hasFollowerDemographics = true
-		}
-		if metric == string(AccountInsightFollowersCount) {
-			hasFollowersCount = true
-		}
+	// Check period, breakdown and time range against each metric's spec
+	hasTimeRange := opts.Since != nil || opts.Until != nil
+	if err := ValidateAccountInsightRequest(toAccountInsightMetrics(validMetrics), InsightPeriod(params.Get("period")), opts.Breakdown, hasTimeRange); err != nil {
+		return nil, err
 	}
-
-	if hasFollowerDemographics {
-		// follower_demographics doesn't support since/until parameters
-		if opts.Since != nil || opts.Until != nil {
-			return nil, NewValidationError(400, "Invalid parameters",
-				"follower_demographics metric does not support since and until parameters", "metric")
-		}
-
-		// Validate breakdown parameter
-		if opts.Breakdown != "" {
-			if err := c.validateFollowerDemographicsBreakdown(opts.Breakdown); err != nil {
-				return nil, err
-			}
-			params.Set("breakdown", opts.Breakdown)
-		}
+	if opts.Breakdown != "" {
+		params.Set("breakdown", opts.Breakdown)
 	}
 
-	if hasFollowersCount {
-		// followers_count doesn't support since/until parameters
-		if opts.Since != nil || opts.Until != nil {
-			return nil, NewValidationError(400, "Invalid parameters",
-				"followers_count metric does not support since and until parameters", "metric")
-		}
+	// Validate minimum timestamp
+	if opts.Since != nil && opts.Since.Unix() < MinInsightTimestamp {
+		return nil, NewValidationError(400, "Invalid since timestamp",
+			fmt.Sprintf("since timestamp must be >= %d", MinInsightTimestamp), "since")
+	}
+	if opts.Until != nil && opts.Until.Unix() < MinInsightTimestamp {
+		return nil, NewValidationError(400, "Invalid until timestamp",
+			fmt.Sprintf("until timestamp must be >= %d", MinInsightTimestamp), "until")
 	}
 
-	if !hasFollowerDemographics && !hasFollowersCount {
-		// Validate minimum timestamp for other metrics
-		if opts.Since != nil && opts.Since.Unix() < MinInsightTimestamp {
-			return nil, NewValidationError(400, "Invalid since timestamp",
-				fmt.Sprintf("since timestamp must be >= %d", MinInsightTimestamp), "since")
-		}
-		if opts.Until != nil && opts.Until.Unix() < MinInsightTimestamp {
-			return nil, NewValidationError(400, "Invalid until timestamp",
-				fmt.Sprintf("until timestamp must be >= %d", MinInsightTimestamp), "until")
-		}
-
-		// Add date range if specified
-		if opts.Since != nil {
-			params.Set("since", fmt.Sprintf("%d", opts.Since.Unix()))
-		}
-		if opts.Until != nil {
-			params.Set("until", fmt.Sprintf("%d", opts.Until.Unix()))
-		}
+	// Add date range if specified
+	if opts.Since != nil {
+		params.Set("since", fmt.Sprintf("%d", opts.Since.Unix()))
+	}
+	if opts.Until != nil {
+		params.Set("until", fmt.Sprintf("%d", opts.Until.Unix()))
 	}
 
 	// Validate date range
@@ -370,8 +353,8 @@ func (c *Client) GetAccountInsightsWithOptions(ctx context.Context, userID UserI
 		}
 	}
 
-	path := fmt.Sprintf("/%s/threads_insights", userID.String())
-	response, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointAccountInsights.path(userID.String())
+	response, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account insights: %w", err)
 	}
@@ -386,18 +369,7 @@ func (c *Client) GetAccountInsightsWithOptions(ctx context.Context, userID UserI
 
 // validatePostInsightMetric validates if the provided metric is supported for post insights
 func (c *Client) validatePostInsightMetric(metric string) error {
-	validMetrics := map[string]bool{
-		string(PostInsightViews):         true,
-		string(PostInsightLikes):         true,
-		string(PostInsightReplies):       true,
-		string(PostInsightReposts):       true,
-		string(PostInsightQuotes):        true,
-		string(PostInsightShares):        true,
-		string(PostInsightLinkClicks):    true,
-		string(PostInsightProfileClicks): true,
-	}
-
-	if !validMetrics[metric] {
+	if _, ok := LookupPostInsightMetric(PostInsightMetric(metric)); !ok {
 		return NewValidationError(400, "Invalid post insight metric",
 			fmt.Sprintf("metric '%s' is not supported for post insights", metric), "metric")
 	}
@@ -407,18 +379,7 @@ func (c *Client) validatePostInsightMetric(metric string) error {
 
 // validateAccountInsightMetric validates if the provided metric is supported for account insights
 func (c *Client) validateAccountInsightMetric(metric string) error {
-	validMetrics := map[string]bool{
-		string(AccountInsightViews):                true,
-		string(AccountInsightLikes):                true,
-		string(AccountInsightReplies):              true,
-		string(AccountInsightReposts):              true,
-		string(AccountInsightQuotes):               true,
-		string(AccountInsightClicks):               true,
-		string(AccountInsightFollowersCount):       true,
-		string(AccountInsightFollowerDemographics): true,
-	}
-
-	if !validMetrics[metric] {
+	if _, ok := LookupAccountInsightMetric(AccountInsightMetric(metric)); !ok {
 		return NewValidationError(400, "Invalid account insight metric",
 			fmt.Sprintf("metric '%s' is not supported for account insights", metric), "metric")
 	}
@@ -426,6 +387,15 @@ func (c *Client) validateAccountInsightMetric(metric string) error {
 	return nil
 }
 
+// toAccountInsightMetrics converts validated metric names to their typed form
+func toAccountInsightMetrics(metrics []string) []AccountInsightMetric {
+	typed := make([]AccountInsightMetric, len(metrics))
+	for i, metric := range metrics {
+		typed[i] = AccountInsightMetric(metric)
+	}
+	return typed
+}
+
 // validateInsightPeriod validates if the provided period is supported
 func (c *Client) validateInsightPeriod(period string) error {
 	validPeriods := map[string]bool{
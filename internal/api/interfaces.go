@@ -4,6 +4,14 @@ import (
 	"context"
 )
 
+// API is the complete surface of the Threads client, including webhooks.
+// *Client implements it. Depend on API, or on one of the narrower interfaces
+// below, so that callers can substitute a mock in unit tests.
+type API interface {
+	ClientInterface
+	WebhookManager
+}
+
 // ClientInterface is the main interface that composes all Threads API functionality
 // This replaces the large monolithic interface with smaller, focused interfaces
 type ClientInterface interface {
@@ -15,6 +23,7 @@ type ClientInterface interface {
 	LocationManager
 	SearchProvider
 	RateLimitController
+	BatchRequester
 }
 
 // Authenticator handles OAuth 2.0 authentication and token management
@@ -39,6 +48,15 @@ type Authenticator interface {
 
 	// GetTokenDebugInfo returns detailed token information
 	GetTokenDebugInfo() map[string]interface{}
+
+	// GetTokenInfo returns a copy of the current token information
+	GetTokenInfo() *TokenInfo
+
+	// IsAuthenticated returns true if the client has an access token
+	IsAuthenticated() bool
+
+	// IsAppToken returns true if the client uses an app access token
+	IsAppToken() bool
 }
 
 // PostManager handles post creation, retrieval, and management
@@ -63,6 +81,10 @@ type PostCreator interface {
 	// CreateCarouselPost creates a carousel post with multiple media items
 	CreateCarouselPost(ctx context.Context, content *CarouselPostContent) (*Post, error)
 
+	// CreateCarousel creates the children of a carousel from media items,
+	// resuming from earlier progress, and publishes it
+	CreateCarousel(ctx context.Context, content *CarouselPostContent, items []CarouselItem, opts *CarouselOptions) (*Post, error)
+
 	// CreateQuotePost creates a quote post using any supported content type
 	CreateQuotePost(ctx context.Context, content interface{}, quotedPostID string) (*Post, error)
 
@@ -77,6 +99,9 @@ type PostCreator interface {
 
 	// GetContainerStatus retrieves the status of a media container
 	GetContainerStatus(ctx context.Context, containerID ContainerID) (*ContainerStatus, error)
+
+	// NewContainer prepares a container for post content without creating it
+	NewContainer(content any) (*Container, error)
 }
 
 // PostReader handles post retrieval operations
@@ -90,6 +115,9 @@ type PostReader interface {
 	// GetUserPostsWithOptions retrieves posts with enhanced filtering
 	GetUserPostsWithOptions(ctx context.Context, userID UserID, opts *PostsOptions) (*PostsResponse, error)
 
+	// GetUserGhostPosts retrieves a user's ghost posts
+	GetUserGhostPosts(ctx context.Context, userID UserID, opts *PaginationOptions) (*PostsResponse, error)
+
 	// GetUserMentions retrieves posts where the user is mentioned
 	GetUserMentions(ctx context.Context, userID UserID, opts *PaginationOptions) (*PostsResponse, error)
 
@@ -168,6 +196,12 @@ type ReplyManager interface {
 	// UnhideReply unhides a previously hidden reply
 	UnhideReply(ctx context.Context, replyID PostID) error
 
+	// HideReplies hides several replies using batch requests
+	HideReplies(ctx context.Context, replyIDs []PostID) error
+
+	// UnhideReplies unhides several replies using batch requests
+	UnhideReplies(ctx context.Context, replyIDs []PostID) error
+
 	// GetUserReplies retrieves all replies by a user
 	GetUserReplies(ctx context.Context, userID UserID, opts *PostsOptions) (*RepliesResponse, error)
 }
@@ -177,6 +211,12 @@ type InsightsProvider interface {
 	// GetPostInsights retrieves insights for a post
 	GetPostInsights(ctx context.Context, postID PostID, metrics []string) (*InsightsResponse, error)
 
+	// GetPostMetrics retrieves typed post insights, validating the metric names
+	GetPostMetrics(ctx context.Context, postID PostID, metrics []string) (*PostInsights, error)
+
+	// GetPostsInsights retrieves insights for several posts using batch requests
+	GetPostsInsights(ctx context.Context, postIDs []PostID, metrics []string) (map[PostID]*InsightsResponse, error)
+
 	// GetPostInsightsWithOptions retrieves post insights with options
 	GetPostInsightsWithOptions(ctx context.Context, postID PostID, opts *PostInsightsOptions) (*InsightsResponse, error)
 
@@ -185,6 +225,9 @@ type InsightsProvider interface {
 
 	// GetAccountInsightsWithOptions retrieves account insights with options
 	GetAccountInsightsWithOptions(ctx context.Context, userID UserID, opts *AccountInsightsOptions) (*InsightsResponse, error)
+
+	// GetUserInsights retrieves typed account insights, with breakdowns
+	GetUserInsights(ctx context.Context, userID UserID, opts *InsightsOptions) (*UserInsights, error)
 }
 
 // LocationManager handles location-related operations
@@ -202,6 +245,12 @@ type SearchProvider interface {
 	KeywordSearch(ctx context.Context, query string, opts *SearchOptions) (*PostsResponse, error)
 }
 
+// BatchRequester executes several Graph API requests in a single round trip
+type BatchRequester interface {
+	// Batch executes requests through the Graph batch endpoint
+	Batch(ctx context.Context, requests []BatchRequest) ([]BatchResponse, error)
+}
+
 // RateLimitController manages rate limiting behavior
 type RateLimitController interface {
 	// IsRateLimited returns true if currently rate limited
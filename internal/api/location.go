@@ -15,7 +15,7 @@ func (c *Client) SearchLocations(ctx context.Context, query string, latitude, lo
 
 	// Build query parameters
 	params := url.Values{
-		"fields": {LocationFields}, // Include all location fields for search results
+		"fields": {endpointLocationSearch.Fields},
 	}
 
 	// At least one parameter must be provided
@@ -41,7 +41,7 @@ func (c *Client) SearchLocations(ctx context.Context, query string, latitude, lo
 	}
 
 	// Make API call
-	resp, err := c.httpClient.GET("/location_search", params, c.getAccessTokenSafe())
+	resp, err := c.httpClient.GET(ctx, endpointLocationSearch.path(), params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -72,12 +72,12 @@ func (c *Client) GetLocation(ctx context.Context, locationID LocationID) (*Locat
 
 	// Build query parameters with location fields
 	params := url.Values{
-		"fields": {LocationFields},
+		"fields": {endpointLocation.Fields},
 	}
 
 	// Make API call
-	path := fmt.Sprintf("/%s", locationID.String())
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointLocation.path(locationID.String())
+	resp, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get location: %w", err)
 	}
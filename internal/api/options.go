@@ -0,0 +1,218 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultTokenLifetime is the assumed lifetime of a token passed to New when
+// no expiry is supplied. Long-lived Threads tokens are valid for 60 days.
+const DefaultTokenLifetime = 60 * 24 * time.Hour
+
+// Option configures a Client created with New.
+type Option func(*clientOptions)
+
+// clientOptions collects the settings applied by Option values
+type clientOptions struct {
+	config      *Config
+	transport   *http.Client
+	rateLimiter *RateLimiter
+	userID      string
+	expiresAt   time.Time
+	tokenType   string
+}
+
+// New creates a Threads API client authenticated with accessToken.
+//
+// Unlike NewClientWithToken, New performs no network calls and does not require
+// app credentials; supply WithAppCredentials when token refresh or OAuth flows
+// are needed. Options are applied in order, so later options win.
+func New(accessToken string, opts ...Option) (*Client, error) {
+	if accessToken == "" {
+		return nil, fmt.Errorf("access token cannot be empty")
+	}
+
+	o := &clientOptions{config: NewConfig()}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	config := o.config
+	config.SetDefaults()
+	if err := config.validateTransport(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	client := buildClient(config, o.rateLimiter, o.transport)
+
+	expiresAt := o.expiresAt
+	if expiresAt.IsZero() {
+		expiresAt = time.Now().Add(DefaultTokenLifetime)
+	}
+
+	tokenType := o.tokenType
+	if tokenType == "" {
+		tokenType = "Bearer"
+	}
+
+	tokenInfo := &TokenInfo{
+		AccessToken: accessToken,
+		TokenType:   tokenType,
+		ExpiresAt:   expiresAt,
+		UserID:      o.userID,
+		CreatedAt:   time.Now(),
+	}
+	if err := client.SetTokenInfo(tokenInfo); err != nil {
+		return nil, fmt.Errorf("failed to set token: %w", err)
+	}
+
+	return client, nil
+}
+
+// WithHTTPClient sets the underlying *http.Client used for requests.
+// The client's own Timeout takes precedence over WithHTTPTimeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) {
+		o.transport = httpClient
+	}
+}
+
+// WithHTTPTimeout sets the timeout for HTTP requests
+func WithHTTPTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.config.HTTPTimeout = timeout
+	}
+}
+
+// WithRetry sets the retry behavior for failed requests
+func WithRetry(retry *RetryConfig) Option {
+	return func(o *clientOptions) {
+		o.config.RetryConfig = retry
+	}
+}
+
+// WithRateLimiter sets a rate limiter, allowing several clients to share one
+func WithRateLimiter(rateLimiter *RateLimiter) Option {
+	return func(o *clientOptions) {
+		o.rateLimiter = rateLimiter
+	}
+}
+
+// WithLogger sets the logger used for request and rate limit events
+func WithLogger(logger Logger) Option {
+	return func(o *clientOptions) {
+		o.config.Logger = logger
+	}
+}
+
+// WithBaseURL overrides the Threads API base URL (useful for proxies and tests)
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.config.BaseURL = baseURL
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with requests
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.config.UserAgent = userAgent
+	}
+}
+
+// WithTokenStorage sets where the client persists its token
+func WithTokenStorage(storage TokenStorage) Option {
+	return func(o *clientOptions) {
+		o.config.TokenStorage = storage
+	}
+}
+
+// WithAutoRefresh makes the client refresh its token whenever it expires
+// within window, calling persist with each new token. A nil persist only
+// updates the configured TokenStorage. A negative window disables refresh.
+func WithAutoRefresh(window time.Duration, persist TokenRefreshCallback) Option {
+	return func(o *clientOptions) {
+		o.config.TokenRefreshWindow = window
+		o.config.OnTokenRefresh = persist
+	}
+}
+
+// WithAppCredentials sets the app credentials used for token refresh and OAuth
+func WithAppCredentials(clientID, clientSecret, redirectURI string) Option {
+	return func(o *clientOptions) {
+		o.config.ClientID = clientID
+		o.config.ClientSecret = clientSecret
+		o.config.RedirectURI = redirectURI
+	}
+}
+
+// WithScopes sets the OAuth scopes associated with the client
+func WithScopes(scopes ...string) Option {
+	return func(o *clientOptions) {
+		o.config.Scopes = scopes
+	}
+}
+
+// WithDebug enables verbose request logging when a logger is set
+func WithDebug(debug bool) Option {
+	return func(o *clientOptions) {
+		o.config.Debug = debug
+	}
+}
+
+// WithHTTPTrace records every request and response to trace
+func WithHTTPTrace(trace *HTTPTrace) Option {
+	return func(o *clientOptions) {
+		o.config.HTTPTrace = trace
+	}
+}
+
+// WithResponseCache serves the responses of read endpoints from cache while
+// they are fresh, and revalidates them with their ETag afterwards
+func WithResponseCache(cache *ResponseCache) Option {
+	return func(o *clientOptions) {
+		o.config.ResponseCache = cache
+	}
+}
+
+// WithRateCoordinator shares rate-limit accounting with the clients of
+// other processes through coordinator
+func WithRateCoordinator(coordinator RateCoordinator) Option {
+	return func(o *clientOptions) {
+		o.config.RateCoordinator = coordinator
+	}
+}
+
+// WithRateLimitStore restores the rate limits and publishing quota saved
+// in store, and saves them there as they change
+func WithRateLimitStore(store RateLimitStore) Option {
+	return func(o *clientOptions) {
+		o.config.RateLimitStore = store
+	}
+}
+
+// WithRequestCompression gzips large request bodies; see
+// Config.CompressRequests
+func WithRequestCompression(enabled bool) Option {
+	return func(o *clientOptions) {
+		o.config.CompressRequests = enabled
+	}
+}
+
+// WithUserID sets the ID of the user the token belongs to. Endpoints that act
+// on "the current user" (such as publishing) require it.
+func WithUserID(userID string) Option {
+	return func(o *clientOptions) {
+		o.userID = userID
+	}
+}
+
+// WithTokenExpiry sets when the access token expires.
+// Default: DefaultTokenLifetime from the time New is called.
+func WithTokenExpiry(expiresAt time.Time) Option {
+	return func(o *clientOptions) {
+		o.expiresAt = expiresAt
+	}
+}
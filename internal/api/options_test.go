@@ -0,0 +1,143 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNew_RequiresToken(t *testing.T) {
+	if _, err := New(""); err == nil {
+		t.Fatal("expected error for empty access token")
+	}
+}
+
+func TestNew_DefaultsWithoutCredentials(t *testing.T) {
+	client, err := New("test-token")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if !client.IsAuthenticated() {
+		t.Error("expected client to be authenticated")
+	}
+	if client.IsTokenExpiringSoon(24 * time.Hour) {
+		t.Error("expected default token expiry to be in the future")
+	}
+
+	config := client.GetConfig()
+	if config.BaseURL != BaseAPIURL {
+		t.Errorf("expected BaseURL %s, got %s", BaseAPIURL, config.BaseURL)
+	}
+	if config.RetryConfig == nil || config.RetryConfig.MaxRetries != 3 {
+		t.Errorf("expected default retry config, got %+v", config.RetryConfig)
+	}
+}
+
+func TestNew_AppliesOptions(t *testing.T) {
+	var gotAuth, gotUA string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotUA = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"123"}`))
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(&RateLimiterConfig{InitialLimit: 10})
+	expiry := time.Now().Add(48 * time.Hour)
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+
+	client, err := New("test-token",
+		WithBaseURL(server.URL),
+		WithHTTPClient(httpClient),
+		WithRateLimiter(limiter),
+		WithUserAgent("my-bot/1.0"),
+		WithRetry(&RetryConfig{MaxRetries: 0, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffFactor: 1}),
+		WithUserID("user-1"),
+		WithTokenExpiry(expiry),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if client.httpClient.client != httpClient {
+		t.Error("expected custom http.Client to be used")
+	}
+	if client.rateLimiter != limiter || client.httpClient.rateLimiter != limiter {
+		t.Error("expected custom rate limiter to be shared with the HTTP client")
+	}
+	if client.GetRateLimitStatus().Limit != 10 {
+		t.Errorf("expected limit 10, got %d", client.GetRateLimitStatus().Limit)
+	}
+
+	info := client.GetTokenInfo()
+	if info.UserID != "user-1" {
+		t.Errorf("expected user ID user-1, got %s", info.UserID)
+	}
+	if !info.ExpiresAt.Equal(expiry) {
+		t.Errorf("expected expiry %v, got %v", expiry, info.ExpiresAt)
+	}
+
+	if _, err := client.TestAPICall("GET", "/v1.0/me", nil); err != nil {
+		t.Fatalf("TestAPICall() error = %v", err)
+	}
+	if gotAuth != "Bearer test-token" {
+		t.Errorf("expected bearer token header, got %q", gotAuth)
+	}
+	if gotUA != "my-bot/1.0" {
+		t.Errorf("expected custom user agent, got %q", gotUA)
+	}
+}
+
+func TestNew_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"bad base URL", WithBaseURL("ftp://example.com")},
+		{"negative timeout", WithHTTPTimeout(-time.Second)},
+		{"bad retry", WithRetry(&RetryConfig{MaxRetries: -1, InitialDelay: time.Second, MaxDelay: time.Second, BackoffFactor: 2})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New("test-token", tt.opt); err == nil {
+				t.Error("expected configuration error")
+			}
+		})
+	}
+}
+
+func TestNew_AppCredentialsEnableConfig(t *testing.T) {
+	client, err := New("test-token",
+		WithAppCredentials("id", "secret", "https://example.com/callback"),
+		WithScopes("threads_basic"),
+		WithDebug(true),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	config := client.GetConfig()
+	if config.ClientID != "id" || config.ClientSecret != "secret" || config.RedirectURI != "https://example.com/callback" {
+		t.Errorf("unexpected credentials in config: %+v", config)
+	}
+	if len(config.Scopes) != 1 || config.Scopes[0] != "threads_basic" {
+		t.Errorf("unexpected scopes: %v", config.Scopes)
+	}
+	if !config.Debug {
+		t.Error("expected debug to be enabled")
+	}
+}
+
+func TestNew_RequestCompression(t *testing.T) {
+	client, err := New("test-token", WithRequestCompression(true))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if !client.GetConfig().CompressRequests || !client.httpClient.compressRequests {
+		t.Error("expected request compression enabled")
+	}
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// Pager walks a cursor-paginated endpoint one item at a time, fetching
+// pages as needed:
+//
+//	pager := client.PostsPager(userID, nil)
+//	for pager.Next(ctx) {
+//		post := pager.Item()
+//		...
+//	}
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+//
+// Before each page it waits out the client's rate limiter, and it stops as
+// soon as ctx is cancelled.
+type Pager[T any] struct {
+	pages *Iterator[T]
+	// wait blocks until the next page may be fetched; nil never waits
+	wait func(context.Context) error
+
+	buffered []T
+	item     T
+	err      error
+}
+
+// NewPager creates a pager that retrieves pages with fetch. Use the
+// client's pager methods, such as PostsPager, to also respect its rate
+// limiter.
+func NewPager[T any](fetch PageFetcher[T]) *Pager[T] {
+	return &Pager[T]{pages: NewIterator(fetch)}
+}
+
+// newClientPager creates a pager for pages of it that waits for c's rate
+// limiter before each fetch
+func newClientPager[T any](c *Client, it *Iterator[T]) *Pager[T] {
+	return &Pager[T]{pages: it, wait: c.WaitForRateLimit}
+}
+
+// Next advances to the next item, fetching the next page when the current
+// one is used up. It returns false at the end of the results, when ctx is
+// done or when a fetch fails; Err tells the last two apart from the first.
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	if p.err != nil {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+
+	for len(p.buffered) == 0 {
+		if !p.pages.HasNext() {
+			return false
+		}
+		if p.wait != nil {
+			if err := p.wait(ctx); err != nil {
+				p.err = err
+				return false
+			}
+		}
+		page, err := p.pages.Next(ctx)
+		if err != nil {
+			p.err = err
+			return false
+		}
+		if page == nil {
+			return false
+		}
+		p.buffered = page.Data
+	}
+
+	p.item, p.buffered = p.buffered[0], p.buffered[1:]
+	return true
+}
+
+// Item returns the item Next advanced to
+func (p *Pager[T]) Item() T {
+	return p.item
+}
+
+// Err returns the error that stopped the pager, or nil if the results ran
+// out or have not yet
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// Cursor returns the cursor of the next page to be fetched, for resuming
+// later. Items of the current page not yet returned by Next are not
+// covered by it.
+func (p *Pager[T]) Cursor() string {
+	return p.pages.Cursor()
+}
+
+// All returns every remaining item
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for p.Next(ctx) {
+		all = append(all, p.item)
+	}
+	return all, p.err
+}
+
+// Items returns the remaining items for use with range. The loop ends
+// early on an error; check Err after it.
+func (p *Pager[T]) Items(ctx context.Context) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for p.Next(ctx) {
+			if !yield(p.item) {
+				return
+			}
+		}
+	}
+}
+
+// PostsPager returns a pager over the posts of a user
+func (c *Client) PostsPager(userID UserID, opts *PostsOptions) *Pager[Post] {
+	return newClientPager(c, NewPostIterator(c, userID, opts).Iterator)
+}
+
+// RepliesPager returns a pager over the top-level replies to a post
+func (c *Client) RepliesPager(postID PostID, opts *RepliesOptions) *Pager[Post] {
+	return newClientPager(c, NewReplyIterator(c, postID, opts).Iterator)
+}
+
+// KeywordSearchPager returns a pager over the results of a keyword search
+func (c *Client) KeywordSearchPager(query string, opts *SearchOptions) *Pager[Post] {
+	return newClientPager(c, NewSearchIterator(c, query, "keyword", opts).Iterator)
+}
+
+// MentionsPager returns a pager over the posts mentioning a user
+func (c *Client) MentionsPager(userID UserID, opts *PaginationOptions) *Pager[Post] {
+	if opts == nil {
+		opts = &PaginationOptions{Limit: DefaultPostsLimit}
+	}
+	return newClientPager(c, NewIterator(func(ctx context.Context, cursor string) (*Page[Post], error) {
+		pageOpts := *opts
+		if cursor != "" {
+			pageOpts.After = cursor
+		}
+		response, err := c.GetUserMentions(ctx, userID, &pageOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch mentions: %w", err)
+		}
+		return response, nil
+	}))
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPager_Next(t *testing.T) {
+	var calls []string
+	pager := NewPager(pagedFetcher(testPages(), &calls))
+
+	var items []int
+	for pager.Next(context.Background()) {
+		items = append(items, pager.Item())
+	}
+	if pager.Err() != nil {
+		t.Fatalf("Err() = %v", pager.Err())
+	}
+	if fmt.Sprint(items) != "[1 2 3 4 5]" || len(calls) != 3 {
+		t.Errorf("unexpected items %v after fetching %v", items, calls)
+	}
+	if pager.Next(context.Background()) {
+		t.Error("expected the pager to stay exhausted")
+	}
+}
+
+func TestPager_ItemsStopsEarly(t *testing.T) {
+	var calls []string
+	pager := NewPager(pagedFetcher(testPages(), &calls))
+
+	for item := range pager.Items(context.Background()) {
+		if item == 2 {
+			break
+		}
+	}
+	if len(calls) != 1 {
+		t.Errorf("expected only the first page to be fetched, got %v", calls)
+	}
+	if rest, err := pager.All(context.Background()); err != nil || fmt.Sprint(rest) != "[3 4 5]" {
+		t.Errorf("expected All to continue after the break, got %v, %v", rest, err)
+	}
+}
+
+func TestPager_StopsOnCancelAndErrors(t *testing.T) {
+	var calls []string
+	pager := NewPager(pagedFetcher(testPages(), &calls))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if !pager.Next(ctx) {
+		t.Fatal("expected a first item")
+	}
+	cancel()
+	if pager.Next(ctx) || !errors.Is(pager.Err(), context.Canceled) {
+		t.Errorf("expected cancellation to stop the pager, got %v", pager.Err())
+	}
+
+	fetchErr := errors.New("boom")
+	failing := NewPager(func(context.Context, string) (*Page[int], error) { return nil, fetchErr })
+	if items, err := failing.All(context.Background()); !errors.Is(err, fetchErr) || items != nil {
+		t.Errorf("expected the fetch error, got %v, %v", items, err)
+	}
+}
+
+func TestClientPager_WaitsForRateLimiter(t *testing.T) {
+	requests := 0
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [{"id": "1"}]}`)) //nolint:errcheck,gosec // Test server
+	})
+	defer server.Close()
+
+	client.rateLimiter.MarkRateLimited(time.Now().Add(time.Hour))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	pager := client.MentionsPager("12345", nil)
+	if pager.Next(ctx) || !errors.Is(pager.Err(), context.DeadlineExceeded) {
+		t.Errorf("expected the pager to wait for the rate limit, got %v", pager.Err())
+	}
+	if requests != 0 {
+		t.Errorf("expected no request while rate limited, got %d", requests)
+	}
+
+}
+
+func TestClientPager_FollowsCursors(t *testing.T) {
+	var cursors []string
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cursors = append(cursors, r.URL.Query().Get("after"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("after") == "" {
+			w.Write([]byte(`{"data": [{"id": "1"}], "paging": {"cursors": {"after": "c1"}}}`)) //nolint:errcheck,gosec // Test server
+			return
+		}
+		w.Write([]byte(`{"data": [{"id": "2"}]}`)) //nolint:errcheck,gosec // Test server
+	})
+	defer server.Close()
+	// Far from expiry, so the token is not refreshed first
+	if err := client.SetTokenInfo(&TokenInfo{AccessToken: "test-access-token", ExpiresAt: time.Now().Add(30 * 24 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	replies, err := client.RepliesPager("99", nil).All(context.Background())
+	if err != nil || len(replies) != 2 || replies[1].ID != "2" {
+		t.Errorf("unexpected replies: %v, %v", replies, err)
+	}
+	if len(cursors) != 2 || cursors[1] != "c1" {
+		t.Errorf("expected the second page to be fetched with c1, got %q", cursors)
+	}
+}
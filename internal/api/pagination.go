@@ -2,200 +2,202 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 )
 
-// PostIterator provides an iterator for paginating through posts
-type PostIterator struct {
-	client     PostReader
-	userID     UserID
-	options    *PostsOptions
-	nextCursor string
-	done       bool
+// ErrStopIteration can be returned from a ForEach callback to stop iterating
+// without reporting an error
+var ErrStopIteration = errors.New("stop iteration")
+
+// Page is a single page of results from a cursor-paginated endpoint
+type Page[T any] struct {
+	Data   []T    `json:"data"`
+	Paging Paging `json:"paging"`
 }
 
-// NewPostIterator creates a new post iterator
-func NewPostIterator(client PostReader, userID UserID, opts *PostsOptions) *PostIterator {
-	if opts == nil {
-		opts = &PostsOptions{
-			Limit: DefaultPostsLimit,
-		}
+// NextCursor returns the cursor for the following page, or "" on the last page
+func (p *Page[T]) NextCursor() string {
+	if p == nil {
+		return ""
 	}
+	return p.Paging.NextCursor()
+}
 
-	return &PostIterator{
-		client:  client,
-		userID:  userID,
-		options: opts,
-		done:    false,
+// NextCursor returns the after cursor, preferring Cursors.After over the
+// deprecated top-level After field
+func (p Paging) NextCursor() string {
+	if p.Cursors != nil && p.Cursors.After != "" {
+		return p.Cursors.After
 	}
+	return p.After
 }
 
-// Next retrieves the next page of posts
-func (p *PostIterator) Next(ctx context.Context) (*PostsResponse, error) {
-	if p.done {
-		return nil, nil
-	}
+// PageFetcher fetches the page starting at cursor ("" for the first page)
+type PageFetcher[T any] func(ctx context.Context, cursor string) (*Page[T], error)
 
-	// Update cursor for pagination
-	opts := *p.options
-	if p.nextCursor != "" {
-		opts.After = p.nextCursor
+// Iterator walks a cursor-paginated endpoint one page at a time
+type Iterator[T any] struct {
+	fetch      PageFetcher[T]
+	nextCursor string
+	done       bool
+}
+
+// NewIterator creates an iterator that retrieves pages with fetch
+func NewIterator[T any](fetch PageFetcher[T]) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch}
+}
+
+// Next retrieves the next page, returning nil once all pages have been read
+func (it *Iterator[T]) Next(ctx context.Context) (*Page[T], error) {
+	if it.done {
+		return nil, nil
 	}
 
-	// Fetch posts
-	response, err := p.client.GetUserPostsWithOptions(ctx, p.userID, &opts)
+	page, err := it.fetch(ctx, it.nextCursor)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch posts: %w", err)
+		return nil, err
 	}
-
-	// Update cursor for next iteration
-	if response.Paging.Cursors != nil && response.Paging.Cursors.After != "" {
-		p.nextCursor = response.Paging.Cursors.After
-	} else if response.Paging.After != "" {
-		p.nextCursor = response.Paging.After
-	} else {
-		// No more pages
-		p.done = true
+	if page == nil {
+		it.done = true
+		return nil, nil
 	}
 
-	// Mark as done if no posts returned
-	if len(response.Data) == 0 {
-		p.done = true
+	// Stop when there is no cursor or the page came back empty
+	it.nextCursor = page.NextCursor()
+	if it.nextCursor == "" || len(page.Data) == 0 {
+		it.done = true
 	}
 
-	return response, nil
+	return page, nil
 }
 
 // HasNext returns true if there are more pages to fetch
-func (p *PostIterator) HasNext() bool {
-	return !p.done
+func (it *Iterator[T]) HasNext() bool {
+	return !it.done
+}
+
+// Cursor returns the cursor of the next page to be fetched
+func (it *Iterator[T]) Cursor() string {
+	return it.nextCursor
 }
 
 // Reset resets the iterator to start from the beginning
-func (p *PostIterator) Reset() {
-	p.nextCursor = ""
-	p.done = false
+func (it *Iterator[T]) Reset() {
+	it.nextCursor = ""
+	it.done = false
 }
 
-// Collect fetches all remaining pages and returns them as a single slice
-func (p *PostIterator) Collect(ctx context.Context) ([]Post, error) {
-	var allPosts []Post
+// All fetches all remaining pages and returns their items as a single slice
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
 
-	for p.HasNext() {
-		response, err := p.Next(ctx)
+	for it.HasNext() {
+		page, err := it.Next(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		if response != nil {
-			allPosts = append(allPosts, response.Data...)
+		if page != nil {
+			all = append(all, page.Data...)
 		}
 	}
 
-	return allPosts, nil
+	return all, nil
 }
 
-// ReplyIterator provides an iterator for paginating through replies
-type ReplyIterator struct {
-	client     ReplyManager
-	postID     PostID
-	options    *RepliesOptions
-	nextCursor string
-	done       bool
+// Collect is an alias for All
+func (it *Iterator[T]) Collect(ctx context.Context) ([]T, error) {
+	return it.All(ctx)
 }
 
-// NewReplyIterator creates a new reply iterator
-func NewReplyIterator(client ReplyManager, postID PostID, opts *RepliesOptions) *ReplyIterator {
-	if opts == nil {
-		opts = &RepliesOptions{
-			Limit: DefaultPostsLimit,
+// ForEach calls fn for every remaining item, fetching pages as needed.
+// Returning ErrStopIteration from fn stops early without an error.
+func (it *Iterator[T]) ForEach(ctx context.Context, fn func(T) error) error {
+	for it.HasNext() {
+		page, err := it.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if page == nil {
+			break
 		}
-	}
-
-	return &ReplyIterator{
-		client:  client,
-		postID:  postID,
-		options: opts,
-		done:    false,
-	}
-}
-
-// Next retrieves the next page of replies
-func (r *ReplyIterator) Next(ctx context.Context) (*RepliesResponse, error) {
-	if r.done {
-		return nil, nil
-	}
 
-	// Update cursor for pagination
-	opts := *r.options
-	if r.nextCursor != "" {
-		opts.After = r.nextCursor
+		for _, item := range page.Data {
+			if err := fn(item); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					it.done = true
+					return nil
+				}
+				return err
+			}
+		}
 	}
 
-	// Fetch replies
-	response, err := r.client.GetReplies(ctx, r.postID, &opts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch replies: %w", err)
-	}
+	return nil
+}
 
-	// Update cursor for next iteration
-	if response.Paging.Cursors != nil && response.Paging.Cursors.After != "" {
-		r.nextCursor = response.Paging.Cursors.After
-	} else if response.Paging.After != "" {
-		r.nextCursor = response.Paging.After
-	} else {
-		// No more pages
-		r.done = true
-	}
+// PostIterator provides an iterator for paginating through posts
+type PostIterator struct {
+	*Iterator[Post]
+}
 
-	// Mark as done if no replies returned
-	if len(response.Data) == 0 {
-		r.done = true
+// NewPostIterator creates a new post iterator
+func NewPostIterator(client PostReader, userID UserID, opts *PostsOptions) *PostIterator {
+	if opts == nil {
+		opts = &PostsOptions{
+			Limit: DefaultPostsLimit,
+		}
 	}
 
-	return response, nil
-}
+	return &PostIterator{NewIterator(func(ctx context.Context, cursor string) (*Page[Post], error) {
+		pageOpts := *opts
+		if cursor != "" {
+			pageOpts.After = cursor
+		}
 
-// HasNext returns true if there are more pages to fetch
-func (r *ReplyIterator) HasNext() bool {
-	return !r.done
+		response, err := client.GetUserPostsWithOptions(ctx, userID, &pageOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch posts: %w", err)
+		}
+		return response, nil
+	})}
 }
 
-// Reset resets the iterator to start from the beginning
-func (r *ReplyIterator) Reset() {
-	r.nextCursor = ""
-	r.done = false
+// ReplyIterator provides an iterator for paginating through replies
+type ReplyIterator struct {
+	*Iterator[Post]
 }
 
-// Collect fetches all remaining pages and returns them as a single slice
-func (r *ReplyIterator) Collect(ctx context.Context) ([]Post, error) {
-	var allReplies []Post
-
-	for r.HasNext() {
-		response, err := r.Next(ctx)
-		if err != nil {
-			return nil, err
+// NewReplyIterator creates a new reply iterator
+func NewReplyIterator(client ReplyManager, postID PostID, opts *RepliesOptions) *ReplyIterator {
+	if opts == nil {
+		opts = &RepliesOptions{
+			Limit: DefaultPostsLimit,
 		}
+	}
 
-		if response != nil {
-			allReplies = append(allReplies, response.Data...)
+	return &ReplyIterator{NewIterator(func(ctx context.Context, cursor string) (*Page[Post], error) {
+		pageOpts := *opts
+		if cursor != "" {
+			pageOpts.After = cursor
 		}
-	}
 
-	return allReplies, nil
+		response, err := client.GetReplies(ctx, postID, &pageOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch replies: %w", err)
+		}
+		return response, nil
+	})}
 }
 
 // SearchIterator provides an iterator for paginating through search results
 type SearchIterator struct {
-	client     SearchProvider
-	query      string
-	options    *SearchOptions
-	searchType string // "keyword" or "tag"
-	nextCursor string
-	done       bool
+	*Iterator[Post]
 }
 
-// NewSearchIterator creates a new search iterator
+// NewSearchIterator creates a new search iterator.
+// searchType is "keyword" or "tag".
 func NewSearchIterator(client SearchProvider, query string, searchType string, opts *SearchOptions) *SearchIterator {
 	if opts == nil {
 		opts = &SearchOptions{
@@ -203,88 +205,24 @@ func NewSearchIterator(client SearchProvider, query string, searchType string, o
 		}
 	}
 
-	return &SearchIterator{
-		client:     client,
-		query:      query,
-		searchType: searchType,
-		options:    opts,
-		done:       false,
-	}
-}
-
-// Next retrieves the next page of search results
-func (s *SearchIterator) Next(ctx context.Context) (*PostsResponse, error) {
-	if s.done {
-		return nil, nil
-	}
-
-	// Update cursor for pagination
-	opts := *s.options
-	if s.nextCursor != "" {
-		opts.After = s.nextCursor
-	}
-
-	// Perform search based on type
-	var response *PostsResponse
-	var err error
-
-	switch s.searchType {
-	case "keyword":
-		response, err = s.client.KeywordSearch(ctx, s.query, &opts)
-	case "tag":
-		opts.SearchMode = SearchModeTag
-		response, err = s.client.KeywordSearch(ctx, s.query, &opts)
-	default:
-		return nil, fmt.Errorf("invalid search type: %s", s.searchType)
-	}
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to perform search: %w", err)
-	}
-
-	// Update cursor for next iteration
-	if response.Paging.Cursors != nil && response.Paging.Cursors.After != "" {
-		s.nextCursor = response.Paging.Cursors.After
-	} else if response.Paging.After != "" {
-		s.nextCursor = response.Paging.After
-	} else {
-		// No more pages
-		s.done = true
-	}
-
-	// Mark as done if no results returned
-	if len(response.Data) == 0 {
-		s.done = true
-	}
-
-	return response, nil
-}
-
-// HasNext returns true if there are more pages to fetch
-func (s *SearchIterator) HasNext() bool {
-	return !s.done
-}
-
-// Reset resets the iterator to start from the beginning
-func (s *SearchIterator) Reset() {
-	s.nextCursor = ""
-	s.done = false
-}
-
-// Collect fetches all remaining pages and returns them as a single slice
-func (s *SearchIterator) Collect(ctx context.Context) ([]Post, error) {
-	var allPosts []Post
-
-	for s.HasNext() {
-		response, err := s.Next(ctx)
-		if err != nil {
-			return nil, err
+	return &SearchIterator{NewIterator(func(ctx context.Context, cursor string) (*Page[Post], error) {
+		pageOpts := *opts
+		if cursor != "" {
+			pageOpts.After = cursor
 		}
 
-		if response != nil {
-			allPosts = append(allPosts, response.Data...)
+		switch searchType {
+		case "keyword":
+		case "tag":
+			pageOpts.SearchMode = SearchModeTag
+		default:
+			return nil, fmt.Errorf("invalid search type: %s", searchType)
 		}
-	}
 
-	return allPosts, nil
+		response, err := client.KeywordSearch(ctx, query, &pageOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to perform search: %w", err)
+		}
+		return response, nil
+	})}
 }
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func pagedFetcher(pages map[string]*Page[int], calls *[]string) PageFetcher[int] {
+	return func(_ context.Context, cursor string) (*Page[int], error) {
+		*calls = append(*calls, cursor)
+		return pages[cursor], nil
+	}
+}
+
+func testPages() map[string]*Page[int] {
+	return map[string]*Page[int]{
+		"": {
+			Data:   []int{1, 2},
+			Paging: Paging{Cursors: &PagingCursors{After: "a"}},
+		},
+		"a": {
+			Data:   []int{3, 4},
+			Paging: Paging{After: "b"}, // legacy top-level cursor
+		},
+		"b": {
+			Data: []int{5},
+		},
+	}
+}
+
+func TestIterator_All(t *testing.T) {
+	var calls []string
+	it := NewIterator(pagedFetcher(testPages(), &calls))
+
+	items, err := it.All(context.Background())
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+
+	if len(items) != 5 || items[0] != 1 || items[4] != 5 {
+		t.Errorf("unexpected items: %v", items)
+	}
+	if len(calls) != 3 || calls[1] != "a" || calls[2] != "b" {
+		t.Errorf("unexpected cursors: %v", calls)
+	}
+	if it.HasNext() {
+		t.Error("expected iterator to be exhausted")
+	}
+
+	page, err := it.Next(context.Background())
+	if page != nil || err != nil {
+		t.Errorf("expected nil page after exhaustion, got %v, %v", page, err)
+	}
+}
+
+func TestIterator_ResetRestarts(t *testing.T) {
+	var calls []string
+	it := NewIterator(pagedFetcher(testPages(), &calls))
+
+	if _, err := it.Next(context.Background()); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if it.Cursor() != "a" {
+		t.Errorf("expected cursor a, got %q", it.Cursor())
+	}
+
+	it.Reset()
+	if _, err := it.Next(context.Background()); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if calls[1] != "" {
+		t.Errorf("expected Reset to restart from the first page, got cursor %q", calls[1])
+	}
+}
+
+func TestIterator_ForEachStopsEarly(t *testing.T) {
+	var calls []string
+	it := NewIterator(pagedFetcher(testPages(), &calls))
+
+	var seen []int
+	err := it.ForEach(context.Background(), func(v int) error {
+		seen = append(seen, v)
+		if v == 3 {
+			return ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEach() error = %v", err)
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected 3 items before stopping, got %v", seen)
+	}
+	if len(calls) != 2 {
+		t.Errorf("expected 2 page fetches, got %d", len(calls))
+	}
+	if it.HasNext() {
+		t.Error("expected iterator to be done after ErrStopIteration")
+	}
+}
+
+func TestIterator_PropagatesErrors(t *testing.T) {
+	wantErr := errors.New("boom")
+	it := NewIterator(func(context.Context, string) (*Page[int], error) {
+		return nil, wantErr
+	})
+
+	if _, err := it.All(context.Background()); !errors.Is(err, wantErr) {
+		t.Errorf("expected %v, got %v", wantErr, err)
+	}
+
+	err := NewIterator(pagedFetcher(testPages(), &[]string{})).ForEach(context.Background(), func(int) error {
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("expected ForEach to return callback error, got %v", err)
+	}
+}
+
+func TestIterator_StopsOnEmptyPage(t *testing.T) {
+	calls := 0
+	it := NewIterator(func(context.Context, string) (*Page[int], error) {
+		calls++
+		return &Page[int]{Paging: Paging{After: "again"}}, nil
+	})
+
+	items, err := it.All(context.Background())
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(items) != 0 || calls != 1 {
+		t.Errorf("expected a single fetch and no items, got %d fetches and %v", calls, items)
+	}
+}
+
+func TestPostIterator_UsesClient(t *testing.T) {
+	requests := 0
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "refresh_access_token") {
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+			return
+		}
+		requests++
+		if r.URL.Query().Get("after") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"1"}],"paging":{"cursors":{"after":"next"}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"2"}],"paging":{}}`))
+	})
+	defer server.Close()
+
+	posts, err := NewPostIterator(client, "12345", nil).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if len(posts) != 2 || posts[1].ID != "2" {
+		t.Errorf("unexpected posts: %+v", posts)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
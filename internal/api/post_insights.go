@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"slices"
+)
+
+// defaultPostInsightMetrics are fetched when no metrics are named
+var defaultPostInsightMetrics = []PostInsightMetric{
+	PostInsightViews,
+	PostInsightLikes,
+	PostInsightReplies,
+	PostInsightReposts,
+}
+
+// PostInsights is the typed result of GetPostMetrics. Metrics that were not
+// requested are zero; Metrics lists the ones that were.
+type PostInsights struct {
+	PostID        PostID              `json:"post_id"`
+	Metrics       []PostInsightMetric `json:"metrics"`
+	Views         int                 `json:"views"`
+	Likes         int                 `json:"likes"`
+	Replies       int                 `json:"replies"`
+	Reposts       int                 `json:"reposts"`
+	Quotes        int                 `json:"quotes"`
+	Shares        int                 `json:"shares"`
+	LinkClicks    int                 `json:"link_clicks"`
+	ProfileClicks int                 `json:"profile_clicks"`
+}
+
+// Value returns the value of metric, and whether it was requested
+func (p *PostInsights) Value(metric PostInsightMetric) (int, bool) {
+	if field := p.field(metric); field != nil && slices.Contains(p.Metrics, metric) {
+		return *field, true
+	}
+	return 0, false
+}
+
+func (p *PostInsights) field(metric PostInsightMetric) *int {
+	switch metric {
+	case PostInsightViews:
+		return &p.Views
+	case PostInsightLikes:
+		return &p.Likes
+	case PostInsightReplies:
+		return &p.Replies
+	case PostInsightReposts:
+		return &p.Reposts
+	case PostInsightQuotes:
+		return &p.Quotes
+	case PostInsightShares:
+		return &p.Shares
+	case PostInsightLinkClicks:
+		return &p.LinkClicks
+	case PostInsightProfileClicks:
+		return &p.ProfileClicks
+	}
+	return nil
+}
+
+// ParsePostInsightMetrics checks metric names against the post insight
+// registry, returning them typed and without duplicates. No names means the
+// default metrics: views, likes, replies and reposts.
+func ParsePostInsightMetrics(names []string) ([]PostInsightMetric, error) {
+	if len(names) == 0 {
+		return slices.Clone(defaultPostInsightMetrics), nil
+	}
+	metrics := make([]PostInsightMetric, 0, len(names))
+	for _, name := range names {
+		metric := PostInsightMetric(name)
+		if _, ok := LookupPostInsightMetric(metric); !ok {
+			return nil, NewValidationError(400, "Invalid post insight metric",
+				"metric '"+name+"' is not supported for post insights", "metric")
+		}
+		if !slices.Contains(metrics, metric) {
+			metrics = append(metrics, metric)
+		}
+	}
+	return metrics, nil
+}
+
+// GetPostMetrics retrieves insights for a post as typed values. The metric
+// names are validated before any request is made; none means the defaults
+// of ParsePostInsightMetrics.
+func (c *Client) GetPostMetrics(ctx context.Context, postID PostID, metrics []string) (*PostInsights, error) {
+	parsed, err := ParsePostInsightMetrics(metrics)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(parsed))
+	for i, metric := range parsed {
+		names[i] = string(metric)
+	}
+
+	response, err := c.GetPostInsights(ctx, postID, names)
+	if err != nil {
+		return nil, err
+	}
+	return NewPostInsights(postID, parsed, response), nil
+}
+
+// NewPostInsights builds typed insights for metrics from a post insights
+// response, for callers that fetch it on their own, e.g. in a batch
+func NewPostInsights(postID PostID, metrics []PostInsightMetric, response *InsightsResponse) *PostInsights {
+	insights := &PostInsights{PostID: postID, Metrics: slices.Clone(metrics)}
+	if response == nil {
+		return insights
+	}
+	for _, insight := range response.Data {
+		field := insights.field(PostInsightMetric(insight.Name))
+		if field == nil {
+			continue
+		}
+		// Post insights are lifetime totals: the latest value is the count
+		switch {
+		case len(insight.Values) > 0:
+			*field = insight.Values[0].Value
+		case insight.TotalValue != nil:
+			*field = insight.TotalValue.Value
+		}
+	}
+	return insights
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestParsePostInsightMetrics(t *testing.T) {
+	got, err := ParsePostInsightMetrics(nil)
+	if err != nil || !slices.Equal(got, defaultPostInsightMetrics) {
+		t.Errorf("expected the default metrics, got %v, %v", got, err)
+	}
+
+	got, err = ParsePostInsightMetrics([]string{"shares", "views", "shares"})
+	if err != nil || !slices.Equal(got, []PostInsightMetric{PostInsightShares, PostInsightViews}) {
+		t.Errorf("expected shares and views once each, got %v, %v", got, err)
+	}
+
+	for _, names := range [][]string{{"views", "followers_count"}, {"VIEWS"}, {""}} {
+		_, err = ParsePostInsightMetrics(names)
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "metric" {
+			t.Errorf("%v: expected a metric validation error, got %v", names, err)
+		}
+	}
+}
+
+func TestGetPostMetrics(t *testing.T) {
+	var requested string
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Query().Get("metric")
+		createMockHandler(t, MockResponse{
+			StatusCode: http.StatusOK,
+			Body: map[string]any{"data": []map[string]any{
+				{"name": "views", "period": "lifetime", "values": []map[string]any{{"value": 1200}}},
+				{"name": "quotes", "period": "lifetime", "total_value": map[string]any{"value": 7}},
+				{"name": "unknown_metric", "period": "lifetime", "values": []map[string]any{{"value": 99}}},
+			}},
+		})(w, r)
+	})
+	defer server.Close()
+
+	insights, err := client.GetPostMetrics(context.Background(), "123", []string{"views", "quotes", "likes"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requested != "views,quotes,likes" {
+		t.Errorf("requested metrics %q", requested)
+	}
+	if insights.PostID != "123" || insights.Views != 1200 || insights.Quotes != 7 {
+		t.Errorf("unexpected insights: %+v", insights)
+	}
+	if v, ok := insights.Value(PostInsightLikes); v != 0 || !ok {
+		t.Errorf("expected likes requested and zero, got %d, %v", v, ok)
+	}
+	if _, ok := insights.Value(PostInsightShares); ok {
+		t.Error("expected shares reported as not requested")
+	}
+}
+
+func TestGetPostMetrics_ValidatesBeforeRequest(t *testing.T) {
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+	defer server.Close()
+
+	if _, err := client.GetPostMetrics(context.Background(), "123", []string{"clicks"}); err == nil {
+		t.Error("expected an account-only metric refused")
+	}
+}
@@ -6,156 +6,52 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
-	"time"
 )
 
 // CreateTextPost creates a new text post on Threads
 func (c *Client) CreateTextPost(ctx context.Context, content *TextPostContent) (*Post, error) {
-	// Validate content according to API limits
-	if err := c.ValidateTextPostContent(content); err != nil {
-		return nil, err
-	}
-
-	if strings.TrimSpace(content.Text) == "" {
-		return nil, NewValidationError(400, "Text content is required", ErrEmptyPostID, "text")
-	}
-
-	// Ensure we have a valid token
-	if err := c.EnsureValidToken(ctx); err != nil {
+	container, err := c.NewContainer(content)
+	if err != nil {
 		return nil, err
 	}
 
 	// Handle auto_publish_text flow differently
 	if content.AutoPublishText {
+		// Ensure we have a valid token
+		if err := c.EnsureValidToken(ctx); err != nil {
+			return nil, err
+		}
 		return c.createAndPublishTextPostDirectly(ctx, content)
 	}
 
-	// Standard container creation and publishing flow
-	containerID, err := c.createTextContainer(ctx, content)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create text container: %w", err)
-	}
-
-	// Wait for container to be ready
-	if errWait := c.waitForContainerReady(ctx, ContainerID(containerID), DefaultContainerPollMaxAttempts, DefaultContainerPollInterval); errWait != nil {
-		return nil, fmt.Errorf("container not ready for publishing: %w", errWait)
-	}
-
-	// Publish the container
-	post, errPublish := c.publishContainer(ctx, containerID)
-	if errPublish != nil {
-		return nil, fmt.Errorf("failed to publish text post: %w", errPublish)
-	}
-
-	return post, nil
+	return container.PublishWithWait(ctx, nil)
 }
 
 // CreateImagePost creates a new image post on Threads
 func (c *Client) CreateImagePost(ctx context.Context, content *ImagePostContent) (*Post, error) {
-	// Validate content according to API limits
-	if err := c.ValidateImagePostContent(content); err != nil {
-		return nil, err
-	}
-
-	if strings.TrimSpace(content.ImageURL) == "" {
-		return nil, NewValidationError(400, "Image URL is required", "Post must have an image URL", "image_url")
-	}
-
-	// Ensure we have a valid token
-	if err := c.EnsureValidToken(ctx); err != nil {
-		return nil, err
-	}
-
-	// Create container first
-	containerID, err := c.createImageContainer(ctx, content)
+	container, err := c.NewContainer(content)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create image container: %w", err)
-	}
-
-	// Wait for container to be ready
-	if errWait := c.waitForContainerReady(ctx, ContainerID(containerID), DefaultContainerPollMaxAttempts, DefaultContainerPollInterval); errWait != nil {
-		return nil, fmt.Errorf("container not ready for publishing: %w", errWait)
-	}
-
-	// Publish the container
-	post, errPublish := c.publishContainer(ctx, containerID)
-	if errPublish != nil {
-		return nil, fmt.Errorf("failed to publish image post: %w", errPublish)
+		return nil, err
 	}
-
-	return post, nil
+	return container.PublishWithWait(ctx, nil)
 }
 
 // CreateVideoPost creates a new video post on Threads
 func (c *Client) CreateVideoPost(ctx context.Context, content *VideoPostContent) (*Post, error) {
-	// Validate content according to API limits
-	if err := c.ValidateVideoPostContent(content); err != nil {
-		return nil, err
-	}
-
-	if strings.TrimSpace(content.VideoURL) == "" {
-		return nil, NewValidationError(400, "Video URL is required", "Post must have a video URL", "video_url")
-	}
-
-	// Ensure we have a valid token
-	if err := c.EnsureValidToken(ctx); err != nil {
-		return nil, err
-	}
-
-	// Create container first
-	containerID, err := c.createVideoContainer(ctx, content)
+	container, err := c.NewContainer(content)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create video container: %w", err)
-	}
-
-	// Wait for container to be ready
-	if errWait := c.waitForContainerReady(ctx, ContainerID(containerID), DefaultContainerPollMaxAttempts, DefaultContainerPollInterval); errWait != nil {
-		return nil, fmt.Errorf("container not ready for publishing: %w", errWait)
-	}
-
-	// Publish the container
-	post, errPublish := c.publishContainer(ctx, containerID)
-	if errPublish != nil {
-		return nil, fmt.Errorf("failed to publish video post: %w", errPublish)
+		return nil, err
 	}
-
-	return post, nil
+	return container.PublishWithWait(ctx, nil)
 }
 
 // CreateCarouselPost creates a new carousel post on Threads
 func (c *Client) CreateCarouselPost(ctx context.Context, content *CarouselPostContent) (*Post, error) {
-	// Validate content according to API limits
-	if err := c.ValidateCarouselPostContent(content); err != nil {
-		return nil, err
-	}
-
-	if len(content.Children) == 0 {
-		return nil, NewValidationError(400, "Children containers are required", "Carousel post must have at least one child container", "children")
-	}
-
-	// Ensure we have a valid token
-	if err := c.EnsureValidToken(ctx); err != nil {
-		return nil, err
-	}
-
-	// Create container first
-	containerID, err := c.createCarouselContainer(ctx, content)
+	container, err := c.NewContainer(content)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create carousel container: %w", err)
-	}
-
-	// Wait for container to be ready
-	if errWait := c.waitForContainerReady(ctx, ContainerID(containerID), DefaultContainerPollMaxAttempts, DefaultContainerPollInterval); errWait != nil {
-		return nil, fmt.Errorf("container not ready for publishing: %w", errWait)
-	}
-
-	// Publish the container
-	post, errPublish := c.publishContainer(ctx, containerID)
-	if errPublish != nil {
-		return nil, fmt.Errorf("failed to publish carousel post: %w", errPublish)
+		return nil, err
 	}
-
-	return post, nil
+	return container.PublishWithWait(ctx, nil)
 }
 
 // CreateQuotePost creates a new quote post on Threads
@@ -212,8 +108,8 @@ func (c *Client) RepostPost(ctx context.Context, postID PostID) (*Post, error) {
 	}
 
 	// Use the direct repost endpoint
-	path := fmt.Sprintf("/%s/repost", postID.String())
-	resp, err := c.httpClient.POST(path, nil, c.getAccessTokenSafe())
+	path := endpointRepost.path(postID.String())
+	resp, err := c.httpClient.POST(ctx, path, nil, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create repost: %w", err)
 	}
@@ -252,8 +148,8 @@ func (c *Client) UnrepostPost(ctx context.Context, repostID PostID) error {
 	}
 
 	// Use the unrepost endpoint
-	path := fmt.Sprintf("/%s/unrepost", repostID.String())
-	resp, err := c.httpClient.DELETE(path, c.getAccessTokenSafe())
+	path := endpointUnrepost.path(repostID.String())
+	resp, err := c.httpClient.DELETE(ctx, path, c.getAccessTokenSafe())
 	if err != nil {
 		return fmt.Errorf("failed to unrepost: %w", err)
 	}
@@ -272,8 +168,8 @@ func (c *Client) UnrepostPost(ctx context.Context, repostID PostID) error {
 	}
 
 	// Log successful unrepost if logger is available
-	if c.config.Logger != nil {
-		c.config.Logger.Info("Successfully removed repost", "repost_id", repostID.String())
+	if logger := c.currentConfig().Logger; logger != nil {
+		logger.Info("Successfully removed repost", "repost_id", repostID.String())
 	}
 
 	return nil
@@ -281,51 +177,55 @@ func (c *Client) UnrepostPost(ctx context.Context, repostID PostID) error {
 
 // CreateMediaContainer creates a media container for use in carousel posts
 func (c *Client) CreateMediaContainer(ctx context.Context, mediaType, mediaURL, altText string) (ContainerID, error) {
-	if mediaType == "" {
-		return "", NewValidationError(400, "Media type is required", "Must specify IMAGE or VIDEO", "media_type")
+	container, err := c.NewContainer(CarouselItem{MediaType: mediaType, URL: mediaURL, AltText: altText})
+	if err != nil {
+		return "", err
 	}
 
-	if mediaURL == "" {
-		return "", NewValidationError(400, "Media URL is required", "Must provide a valid media URL", "media_url")
+	if err := container.Create(ctx); err != nil {
+		return "", err
 	}
 
-	// Validate media URL
-	validator := NewValidator()
-	if err := validator.ValidateMediaURL(mediaURL, strings.ToLower(mediaType)); err != nil {
-		return "", err
+	return container.ID, nil
+}
+
+// carouselItemParams builds container parameters for a carousel child
+func carouselItemParams(item CarouselItem) (url.Values, error) {
+	if item.MediaType == "" {
+		return nil, NewValidationError(400, "Media type is required", "Must specify IMAGE or VIDEO", "media_type")
 	}
 
-	// Ensure we have a valid token
-	if err := c.EnsureValidToken(ctx); err != nil {
-		return "", err
+	if item.URL == "" {
+		return nil, NewValidationError(400, "Media URL is required", "Must provide a valid media URL", "media_url")
+	}
+
+	// Validate media URL
+	validator := NewValidator()
+	if err := validator.ValidateMediaURL(item.URL, strings.ToLower(item.MediaType)); err != nil {
+		return nil, err
 	}
 
 	// Build container using builder pattern
 	builder := NewContainerBuilder().
-		SetMediaType(strings.ToUpper(mediaType)).
+		SetMediaType(strings.ToUpper(item.MediaType)).
 		SetIsCarouselItem(true).
-		SetAltText(altText)
+		SetAltText(item.AltText)
 
 	// Set the appropriate URL parameter based on media type
-	switch strings.ToUpper(mediaType) {
+	switch strings.ToUpper(item.MediaType) {
 	case MediaTypeImage:
-		builder.SetImageURL(mediaURL)
+		builder.SetImageURL(item.URL)
 	case MediaTypeVideo:
-		builder.SetVideoURL(mediaURL)
+		builder.SetVideoURL(item.URL)
 	default:
-		return "", NewValidationError(400, "Invalid media type", "Media type must be IMAGE or VIDEO", "media_type")
+		return nil, NewValidationError(400, "Invalid media type", "Media type must be IMAGE or VIDEO", "media_type")
 	}
 
-	containerID, err := c.createContainer(ctx, builder.Build())
-	if err != nil {
-		return "", err
-	}
-
-	return ConvertToContainerID(containerID), nil
+	return builder.Build(), nil
 }
 
-// createTextContainer creates a container for text content
-func (c *Client) createTextContainer(ctx context.Context, content *TextPostContent) (string, error) {
+// textContainerParams builds container parameters for text content
+func textContainerParams(content *TextPostContent) url.Values {
 	builder := NewContainerBuilder().
 		SetMediaType(MediaTypeText).
 		SetText(content.Text).
@@ -346,11 +246,11 @@ func (c *Client) createTextContainer(ctx context.Context, content *TextPostConte
 		builder.SetQuotePostID(content.QuotedPostID)
 	}
 
-	return c.createContainer(ctx, builder.Build())
+	return builder.Build()
 }
 
-// createImageContainer creates a container for image content
-func (c *Client) createImageContainer(ctx context.Context, content *ImagePostContent) (string, error) {
+// imageContainerParams builds container parameters for image content
+func imageContainerParams(content *ImagePostContent) url.Values {
 	builder := NewContainerBuilder().
 		SetMediaType(MediaTypeImage).
 		SetImageURL(content.ImageURL).
@@ -369,11 +269,11 @@ func (c *Client) createImageContainer(ctx context.Context, content *ImagePostCon
 		builder.SetQuotePostID(content.QuotedPostID)
 	}
 
-	return c.createContainer(ctx, builder.Build())
+	return builder.Build()
 }
 
-// createVideoContainer creates a container for video content
-func (c *Client) createVideoContainer(ctx context.Context, content *VideoPostContent) (string, error) {
+// videoContainerParams builds container parameters for video content
+func videoContainerParams(content *VideoPostContent) url.Values {
 	builder := NewContainerBuilder().
 		SetMediaType(MediaTypeVideo).
 		SetVideoURL(content.VideoURL).
@@ -392,16 +292,11 @@ func (c *Client) createVideoContainer(ctx context.Context, content *VideoPostCon
 		builder.SetQuotePostID(content.QuotedPostID)
 	}
 
-	containerID, err := c.createContainer(ctx, builder.Build())
-	if err != nil {
-		return "", err
-	}
-
-	return containerID, nil
+	return builder.Build()
 }
 
-// createCarouselContainer creates a container for carousel content
-func (c *Client) createCarouselContainer(ctx context.Context, content *CarouselPostContent) (string, error) {
+// carouselContainerParams builds container parameters for carousel content
+func carouselContainerParams(content *CarouselPostContent) url.Values {
 	builder := NewContainerBuilder().
 		SetMediaType(MediaTypeCarousel).
 		SetText(content.Text).
@@ -419,26 +314,13 @@ func (c *Client) createCarouselContainer(ctx context.Context, content *CarouselP
 		builder.SetQuotePostID(content.QuotedPostID)
 	}
 
-	return c.createContainer(ctx, builder.Build())
+	return builder.Build()
 }
 
 // createAndPublishTextPostDirectly creates and publishes a text post directly when auto_publish_text is true
 func (c *Client) createAndPublishTextPostDirectly(ctx context.Context, content *TextPostContent) (*Post, error) {
-	builder := NewContainerBuilder().
-		SetMediaType(MediaTypeText).
-		SetText(content.Text).
-		SetAutoPublishText(true).
-		SetLinkAttachment(content.LinkAttachment).
-		SetPollAttachment(content.PollAttachment).
-		SetReplyControl(content.ReplyControl).
-		SetReplyTo(content.ReplyTo).
-		SetTopicTag(content.TopicTag).
-		SetAllowlistedCountryCodes(content.AllowlistedCountryCodes).
-		SetLocationID(content.LocationID).
-		SetTextEntities(content.TextEntities).
-		SetTextAttachment(content.TextAttachment).
-		SetGIFAttachment(content.GIFAttachment).
-		SetIsGhostPost(content.IsGhostPost)
+	params := textContainerParams(content)
+	params.Set("auto_publish_text", "true")
 
 	// Get user ID from token info
 	userID := c.getUserID()
@@ -447,8 +329,8 @@ func (c *Client) createAndPublishTextPostDirectly(ctx context.Context, content *
 	}
 
 	// Make API call to create and publish post directly
-	path := fmt.Sprintf("/%s/threads", userID)
-	resp, err := c.httpClient.POST(path, builder.Build(), c.getAccessTokenSafe())
+	path := endpointCreateContainer.path(userID)
+	resp, err := c.httpClient.POST(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -473,7 +355,7 @@ func (c *Client) createAndPublishTextPostDirectly(ctx context.Context, content *
 }
 
 // createContainer is a helper method to create containers with given parameters
-func (c *Client) createContainer(_ context.Context, params url.Values) (string, error) {
+func (c *Client) createContainer(ctx context.Context, params url.Values) (string, error) {
 	// Get user ID from token info
 	userID := c.getUserID()
 	if userID == "" {
@@ -481,8 +363,8 @@ func (c *Client) createContainer(_ context.Context, params url.Values) (string,
 	}
 
 	// Make API call to create container
-	path := fmt.Sprintf("/%s/threads", userID)
-	resp, err := c.httpClient.POST(path, params, c.getAccessTokenSafe())
+	path := endpointCreateContainer.path(userID)
+	resp, err := c.httpClient.POST(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return "", err
 	}
@@ -507,8 +389,9 @@ func (c *Client) createContainer(_ context.Context, params url.Values) (string,
 	return containerResp.ID, nil
 }
 
-// publishContainer publishes a created container
-func (c *Client) publishContainer(ctx context.Context, containerID string) (*Post, error) {
+// publishContainer publishes a created container, counting it against the
+// quota of kind
+func (c *Client) publishContainer(ctx context.Context, containerID string, kind publishKind) (*Post, error) {
 	if containerID == "" {
 		return nil, NewValidationError(400, ErrEmptyContainerID, "Cannot publish without container ID", "container_id")
 	}
@@ -524,9 +407,14 @@ func (c *Client) publishContainer(ctx context.Context, containerID string) (*Pos
 		"creation_id": {containerID},
 	}
 
+	// Other clients may have used up the quota
+	if err := c.checkPublishingQuota(ctx, kind); err != nil {
+		return nil, err
+	}
+
 	// Make API call to publish container
-	path := fmt.Sprintf("/%s/threads_publish", userID)
-	resp, err := c.httpClient.POST(path, params, c.getAccessTokenSafe())
+	path := endpointPublish.path(userID)
+	resp, err := c.httpClient.POST(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -547,6 +435,9 @@ func (c *Client) publishContainer(ctx context.Context, containerID string) (*Pos
 	if publishResp.ID == "" {
 		return nil, NewAPIError(resp.StatusCode, "Post ID not returned", "API response missing post ID", resp.RequestID)
 	}
+	if !c.scoped {
+		c.httpClient.countPublished(kind)
+	}
 
 	// Fetch the created post details
 	return c.GetPost(ctx, ConvertToPostID(publishResp.ID))
@@ -570,12 +461,12 @@ func (c *Client) GetContainerStatus(ctx context.Context, containerID ContainerID
 
 	// Build request parameters with container status fields
 	params := url.Values{
-		"fields": {ContainerStatusFields},
+		"fields": {endpointContainerStatus.Fields},
 	}
 
 	// Make API call to get container status
-	path := fmt.Sprintf("/%s", containerID.String())
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointContainerStatus.path(containerID.String())
+	resp, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get container status: %w", err)
 	}
@@ -601,39 +492,3 @@ func (c *Client) GetContainerStatus(ctx context.Context, containerID ContainerID
 
 	return &status, nil
 }
-
-// waitForContainerReady polls the container status until it's ready to be published
-// Returns an error if the container fails or times out
-//
-//nolint:unparam // maxAttempts always receives DefaultContainerPollMaxAttempts but keeping for API flexibility
-func (c *Client) waitForContainerReady(ctx context.Context, containerID ContainerID, maxAttempts int, pollInterval time.Duration) error {
-	for attempt := 0; attempt < maxAttempts; attempt++ {
-		status, err := c.GetContainerStatus(ctx, containerID)
-		if err != nil {
-			return fmt.Errorf("failed to check container status: %w", err)
-		}
-
-		switch status.Status {
-		case ContainerStatusFinished:
-			// Container is ready to be published
-			return nil
-		case ContainerStatusError:
-			if status.ErrorMessage != "" {
-				return fmt.Errorf("container processing failed: %s", status.ErrorMessage)
-			}
-			return fmt.Errorf("container processing failed with error status")
-		case ContainerStatusExpired:
-			return fmt.Errorf("container expired before it could be published")
-		case ContainerStatusInProgress, ContainerStatusPublished:
-			// Still processing or already published, wait and retry
-			time.Sleep(pollInterval)
-			continue
-		default:
-			// Unknown status, wait and retry
-			time.Sleep(pollInterval)
-			continue
-		}
-	}
-
-	return fmt.Errorf("timeout waiting for container to be ready after %d attempts", maxAttempts)
-}
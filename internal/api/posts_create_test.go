@@ -359,21 +359,6 @@ func TestGetContainerStatus_InvalidContainerID(t *testing.T) {
 	}
 }
 
-// TestWaitForContainerReady_Timeout tests that waitForContainerReady times out correctly
-func TestWaitForContainerReady_Timeout(t *testing.T) {
-	// This test would require mocking the GetContainerStatus method
-	// For now, we test the timeout calculation logic
-	maxAttempts := 3
-	pollInterval := 100 * time.Millisecond
-
-	expectedMinTime := time.Duration(maxAttempts-1) * pollInterval // We wait between attempts
-
-	// The timeout should be at least the expected minimum time
-	if expectedMinTime < 0 {
-		t.Error("expected positive minimum time")
-	}
-}
-
 // TestContainerStatusConstants tests that container status constants are defined correctly
 func TestContainerStatusConstants(t *testing.T) {
 	expectedStatuses := map[string]string{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"time"
 )
 
 // GetPost retrieves a specific post by ID with all available fields
@@ -19,12 +20,12 @@ func (c *Client) GetPost(ctx context.Context, postID PostID) (*Post, error) {
 
 	// Build query parameters with extended fields for comprehensive data
 	params := url.Values{
-		"fields": {PostExtendedFields},
+		"fields": {endpointPost.Fields},
 	}
 
 	// Make API call to get post
-	path := fmt.Sprintf("/%s", postID.String())
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointPost.path(postID.String())
+	resp, err := c.httpClient.cachedGET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +88,7 @@ func (c *Client) GetUserPostsWithOptions(ctx context.Context, userID UserID, opt
 
 	// Build query parameters with enhanced fields from API documentation
 	params := url.Values{
-		"fields": {PostExtendedFields},
+		"fields": {endpointUserThreads.Fields},
 	}
 
 	// Add pagination and filtering options if provided
@@ -110,8 +111,8 @@ func (c *Client) GetUserPostsWithOptions(ctx context.Context, userID UserID, opt
 	}
 
 	// Make API call to get user posts
-	path := fmt.Sprintf("/%s/threads", userID.String())
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointUserThreads.path(userID.String())
+	resp, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +159,7 @@ func (c *Client) GetUserMentions(ctx context.Context, userID UserID, opts *Pagin
 
 	// Build query parameters
 	params := url.Values{
-		"fields": {PostExtendedFields},
+		"fields": {endpointMentions.Fields},
 	}
 
 	// Add pagination options if provided
@@ -175,8 +176,8 @@ func (c *Client) GetUserMentions(ctx context.Context, userID UserID, opts *Pagin
 	}
 
 	// Make API call to get user mentions
-	path := fmt.Sprintf("/%s/mentions", userID.String())
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointMentions.path(userID.String())
+	resp, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -218,12 +219,12 @@ func (c *Client) GetPublishingLimits(ctx context.Context) (*PublishingLimits, er
 
 	// Build query parameters
 	params := url.Values{
-		"fields": {PublishingLimitFields},
+		"fields": {endpointPublishingLimit.Fields},
 	}
 
 	// Make API call
-	path := fmt.Sprintf("/%s/threads_publishing_limit", userID)
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointPublishingLimit.path(userID)
+	resp, err := c.httpClient.cachedGET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -245,7 +246,13 @@ func (c *Client) GetPublishingLimits(ctx context.Context) (*PublishingLimits, er
 		return nil, NewAPIError(resp.StatusCode, "No publishing limits data returned", "API response missing data", resp.RequestID)
 	}
 
-	return &limitsResp.Data[0], nil
+	limits := &limitsResp.Data[0]
+	limits.FetchedAt = time.Now()
+	if !c.scoped {
+		saved := *limits
+		c.httpClient.saveLimits(&saved)
+	}
+	return limits, nil
 }
 
 // GetUserGhostPosts retrieves ghost posts from a specific user.
@@ -268,7 +275,7 @@ func (c *Client) GetUserGhostPosts(ctx context.Context, userID UserID, opts *Pag
 
 	// Build query parameters with ghost post fields
 	params := url.Values{
-		"fields": {GhostPostFields},
+		"fields": {endpointGhostPosts.Fields},
 	}
 
 	// Add pagination options if provided
@@ -285,8 +292,8 @@ func (c *Client) GetUserGhostPosts(ctx context.Context, userID UserID, opts *Pag
 	}
 
 	// Make API call to get ghost posts
-	path := fmt.Sprintf("/%s/ghost_posts", userID.String())
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointGhostPosts.path(userID.String())
+	resp, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -46,8 +46,8 @@ func (c *Client) CreateReply(ctx context.Context, content *PostContent) (*Post,
 	}
 
 	// Wait recommended 10 seconds before publishing reply
-	if c.config.Logger != nil {
-		c.config.Logger.Info("Reply container created, waiting before publishing", "container_id", containerID)
+	if logger := c.currentConfig().Logger; logger != nil {
+		logger.Info("Reply container created, waiting before publishing", "container_id", containerID)
 	}
 
 	// Use context timeout or fixed delay
@@ -58,7 +58,7 @@ func (c *Client) CreateReply(ctx context.Context, content *PostContent) (*Post,
 	}
 
 	// Publish the container
-	post, err := c.publishContainer(ctx, containerID)
+	post, err := c.publishContainer(ctx, containerID, publishReply)
 	if err != nil {
 		return nil, fmt.Errorf("failed to publish reply: %w", err)
 	}
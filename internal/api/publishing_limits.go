@@ -0,0 +1,99 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// Quota is the usage of one publishing quota within its rolling window
+type Quota struct {
+	Used     int           `json:"used"`
+	Total    int           `json:"total"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Remaining returns how many more operations the quota allows, never negative
+func (q Quota) Remaining() int {
+	return max(q.Total-q.Used, 0)
+}
+
+// Allows reports whether n more operations fit in the quota
+func (q Quota) Allows(n int) bool {
+	return n <= q.Remaining()
+}
+
+// Duration returns the quota window as a time.Duration
+func (c QuotaConfig) Duration() time.Duration {
+	return time.Duration(c.QuotaDuration) * time.Second
+}
+
+func newQuota(used int, config QuotaConfig) Quota {
+	return Quota{Used: used, Total: config.QuotaTotal, Duration: config.Duration()}
+}
+
+// Posts returns the post publishing quota
+func (l *PublishingLimits) Posts() Quota {
+	return newQuota(l.QuotaUsage, l.Config)
+}
+
+// Replies returns the reply publishing quota
+func (l *PublishingLimits) Replies() Quota {
+	return newQuota(l.ReplyQuotaUsage, l.ReplyConfig)
+}
+
+// Deletes returns the post deletion quota
+func (l *PublishingLimits) Deletes() Quota {
+	return newQuota(l.DeleteQuotaUsage, l.DeleteConfig)
+}
+
+// LocationSearches returns the location search quota
+func (l *PublishingLimits) LocationSearches() Quota {
+	return newQuota(l.LocationSearchQuotaUsage, l.LocationSearchConfig)
+}
+
+// Remaining returns how many more posts can be published in the current window
+func (l *PublishingLimits) Remaining() int {
+	return l.Posts().Remaining()
+}
+
+// ResetsAt returns the latest time at which the current post usage will have
+// aged out of the rolling quota window. Quota frees up gradually before then
+// as older posts leave the window. It returns the zero time if FetchedAt or
+// the window duration is unknown.
+func (l *PublishingLimits) ResetsAt() time.Time {
+	return l.resetsAt(l.Config)
+}
+
+// resetsAt returns when usage of the quota of config will have aged out of
+// its window, or the zero time if that is unknown
+func (l *PublishingLimits) resetsAt(config QuotaConfig) time.Time {
+	if l.FetchedAt.IsZero() || config.QuotaDuration <= 0 {
+		return time.Time{}
+	}
+	return l.FetchedAt.Add(config.Duration())
+}
+
+// CheckPosts returns a RateLimitError if publishing n more posts would exceed
+// the quota. Use it as a pre-flight check before bulk publishing.
+func (l *PublishingLimits) CheckPosts(n int) error {
+	return checkQuota("posts", l.Posts(), n, l.ResetsAt())
+}
+
+// CheckReplies returns a RateLimitError if publishing n more replies would
+// exceed the reply quota.
+func (l *PublishingLimits) CheckReplies(n int) error {
+	return checkQuota("replies", l.Replies(), n, l.resetsAt(l.ReplyConfig))
+}
+
+func checkQuota(name string, q Quota, n int, resetsAt time.Time) error {
+	if q.Total == 0 || q.Allows(n) {
+		return nil
+	}
+
+	var retryAfter time.Duration
+	if !resetsAt.IsZero() {
+		retryAfter = time.Until(resetsAt)
+	}
+	return NewRateLimitError(429, "Publishing quota exceeded",
+		fmt.Sprintf("%d %s requested but only %d of %d remain", n, name, q.Remaining(), q.Total), retryAfter)
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPublishingLimits_Quotas(t *testing.T) {
+	fetched := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	limits := &PublishingLimits{
+		QuotaUsage:      240,
+		Config:          QuotaConfig{QuotaTotal: 250, QuotaDuration: 86400},
+		ReplyQuotaUsage: 1001,
+		ReplyConfig:     QuotaConfig{QuotaTotal: 1000, QuotaDuration: 86400},
+		FetchedAt:       fetched,
+	}
+
+	if got := limits.Remaining(); got != 10 {
+		t.Errorf("Remaining() = %d, want 10", got)
+	}
+	if got := limits.Replies().Remaining(); got != 0 {
+		t.Errorf("Replies().Remaining() = %d, want 0 (never negative)", got)
+	}
+	if got := limits.Posts().Duration; got != 24*time.Hour {
+		t.Errorf("Posts().Duration = %v, want 24h", got)
+	}
+	if got := limits.ResetsAt(); !got.Equal(fetched.Add(24 * time.Hour)) {
+		t.Errorf("ResetsAt() = %v, want %v", got, fetched.Add(24*time.Hour))
+	}
+	if limits.Deletes().Total != 0 {
+		t.Errorf("expected unreported delete quota to be zero")
+	}
+}
+
+func TestPublishingLimits_ResetsAtUnknown(t *testing.T) {
+	limits := &PublishingLimits{Config: QuotaConfig{QuotaTotal: 250, QuotaDuration: 86400}}
+	if !limits.ResetsAt().IsZero() {
+		t.Errorf("expected zero ResetsAt without FetchedAt")
+	}
+}
+
+func TestPublishingLimits_CheckPosts(t *testing.T) {
+	limits := &PublishingLimits{
+		QuotaUsage: 248,
+		Config:     QuotaConfig{QuotaTotal: 250, QuotaDuration: 86400},
+		FetchedAt:  time.Now(),
+	}
+
+	if err := limits.CheckPosts(2); err != nil {
+		t.Fatalf("CheckPosts(2) = %v, want nil", err)
+	}
+
+	err := limits.CheckPosts(3)
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("CheckPosts(3) = %v, want *RateLimitError", err)
+	}
+	if rateErr.RetryAfter <= 0 {
+		t.Errorf("expected positive RetryAfter, got %v", rateErr.RetryAfter)
+	}
+}
+
+func TestPublishingLimits_CheckRepliesUnknownQuota(t *testing.T) {
+	limits := &PublishingLimits{}
+	if err := limits.CheckReplies(100); err != nil {
+		t.Errorf("expected unknown quota to pass pre-flight, got %v", err)
+	}
+}
+
+func TestPublishingLimits_CheckRepliesUsesReplyWindow(t *testing.T) {
+	limits := &PublishingLimits{
+		ReplyQuotaUsage: 1000,
+		Config:          QuotaConfig{QuotaTotal: 250, QuotaDuration: 3600},
+		ReplyConfig:     QuotaConfig{QuotaTotal: 1000, QuotaDuration: 86400},
+		FetchedAt:       time.Now(),
+	}
+
+	var rateErr *RateLimitError
+	if err := limits.CheckReplies(1); !errors.As(err, &rateErr) {
+		t.Fatalf("CheckReplies(1) = %v, want *RateLimitError", err)
+	}
+	if rateErr.RetryAfter <= time.Hour {
+		t.Errorf("expected the retry after the reply window, got %v", rateErr.RetryAfter)
+	}
+}
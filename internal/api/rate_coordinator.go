@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"time"
+)
+
+// RateCoordinator shares rate-limit accounting between clients, including
+// clients in other processes, so that together they stay within the quota
+// of an account instead of each spending it as if alone. The ratecoord
+// package serves one over a local socket.
+type RateCoordinator interface {
+	// Acquire counts a request about to be sent, or returns how long to
+	// wait before asking again
+	Acquire(ctx context.Context) (time.Duration, error)
+	// Update reports the limits the API returned with a response
+	Update(info *RateLimitInfo)
+	// RateLimited reports a 429 response that lasts until reset
+	RateLimited(reset time.Time)
+}
+
+// coordinate waits until the coordinator lets a request through. A
+// coordinator that cannot be reached does not hold requests back; the
+// client's own rate limiter still applies.
+func (h *HTTPClient) coordinate(ctx context.Context) error {
+	if h.coordinator == nil {
+		return nil
+	}
+	for {
+		wait, err := h.coordinator.Acquire(ctx)
+		if err != nil {
+			if h.logger != nil {
+				h.logger.Debug("Rate limit coordinator unavailable", "error", err.Error())
+			}
+			return nil
+		}
+		if wait <= 0 {
+			return nil
+		}
+		if h.logger != nil {
+			h.logger.Info("Rate limit coordinator holding request", "wait_duration", wait.String())
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
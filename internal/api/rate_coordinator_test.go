@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeCoordinator holds the first request back for wait and records reports
+type fakeCoordinator struct {
+	mu       sync.Mutex
+	wait     time.Duration
+	err      error
+	acquired int
+	updates  []*RateLimitInfo
+	limited  []time.Time
+}
+
+func (c *fakeCoordinator) Acquire(context.Context) (time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.acquired++
+	wait := c.wait
+	c.wait = 0
+	return wait, c.err
+}
+
+func (c *fakeCoordinator) Update(info *RateLimitInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.updates = append(c.updates, info)
+}
+
+func (c *fakeCoordinator) RateLimited(reset time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.limited = append(c.limited, reset)
+}
+
+func TestHTTPClient_RateCoordinator(t *testing.T) {
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "200")
+		w.Header().Set("X-RateLimit-Remaining", "150")
+		if r.URL.Path == "/limited" {
+			w.Header().Set("X-RateLimit-Reset", "4102444800")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"message":"Too many calls","code":4}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+	defer server.Close()
+	coordinator := &fakeCoordinator{wait: 20 * time.Millisecond}
+	client.httpClient.coordinator = coordinator
+	client.httpClient.retryConfig.MaxRetries = 0
+	ctx := context.Background()
+
+	start := time.Now()
+	if _, err := client.httpClient.GET(ctx, "/me", nil, "token"); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) < 20*time.Millisecond || coordinator.acquired != 2 {
+		t.Errorf("expected the request held back and acquired again, got %d acquires", coordinator.acquired)
+	}
+	if len(coordinator.updates) != 1 || coordinator.updates[0].Remaining != 150 {
+		t.Errorf("expected the limits reported, got %+v", coordinator.updates)
+	}
+
+	if _, err := client.httpClient.GET(ctx, "/limited", nil, "token"); err == nil {
+		t.Fatal("expected a rate limit error")
+	}
+	if len(coordinator.limited) != 1 || coordinator.limited[0].Unix() != 4102444800 {
+		t.Errorf("expected the 429 reported with its reset, got %v", coordinator.limited)
+	}
+
+	// The client's own limiter would now wait for the reset too
+	client.httpClient.rateLimiter = nil
+
+	// An unreachable coordinator does not hold requests back
+	coordinator.err = errors.New("connection refused")
+	if _, err := client.httpClient.GET(ctx, "/me", nil, "token"); err != nil {
+		t.Errorf("expected the request sent without the coordinator, got %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	coordinator.err, coordinator.wait = nil, time.Hour
+	if _, err := client.httpClient.GET(cancelled, "/me", nil, "token"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a wait to end with the context, got %v", err)
+	}
+}
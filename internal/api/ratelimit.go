@@ -72,10 +72,11 @@ func (rl *RateLimiter) ShouldWait() bool {
 	return rl.rateLimited && time.Now().Before(rl.resetTime)
 }
 
-// Wait blocks until it's safe to make a request, only when actually rate limited
+// Wait blocks until it's safe to make a request, only when actually rate limited.
+// The lock is not held while waiting, so concurrent requests can still
+// update the limits, and each waiter can be cancelled.
 func (rl *RateLimiter) Wait(ctx context.Context) error {
 	rl.mu.Lock()
-	defer rl.mu.Unlock()
 
 	// Check if rate limit window has reset
 	if time.Now().After(rl.resetTime) {
@@ -83,12 +84,14 @@ func (rl *RateLimiter) Wait(ctx context.Context) error {
 		rl.resetTime = time.Now().Add(time.Hour) // Reset to 1 hour from now
 		rl.rateLimited = false                   // Clear rate limited flag
 		rl.logRateLimitReset()
+		rl.mu.Unlock()
 		return nil // No need to wait if window has reset
 	}
 
 	// Only wait if we've been explicitly rate limited
 	if !rl.rateLimited {
 		rl.lastRequestTime = time.Now()
+		rl.mu.Unlock()
 		return nil
 	}
 
@@ -104,15 +107,23 @@ func (rl *RateLimiter) Wait(ctx context.Context) error {
 	}
 
 	rl.logRateLimitWait(waitTime)
+	rl.mu.Unlock()
 
 	// Wait for either the context to be cancelled or the wait time to elapse
+	timer := time.NewTimer(waitTime)
+	defer timer.Stop()
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case <-time.After(waitTime):
-		// After waiting, clear the rate limited flag
-		rl.rateLimited = false
+	case <-timer.C:
+		// After waiting, clear the rate limited flag, unless another
+		// request was limited again meanwhile
+		rl.mu.Lock()
+		if !time.Now().Before(rl.resetTime) {
+			rl.rateLimited = false
+		}
 		rl.lastRequestTime = time.Now()
+		rl.mu.Unlock()
 		return nil
 	}
 }
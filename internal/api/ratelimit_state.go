@@ -0,0 +1,261 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// RateLimitState is what a client knows of an account's limits: the request
+// window of its RateLimiter and the publishing quotas it last fetched. A
+// RateLimitStore keeps it between processes, so that short-lived ones, such
+// as a cron job posting in a loop, share one budget.
+type RateLimitState struct {
+	Limit     int       `json:"limit,omitempty"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset,omitzero"`
+	// LimitedUntil is the end of a 429 response
+	LimitedUntil time.Time `json:"limited_until,omitzero"`
+	// Publishing are the limits last returned by GetPublishingLimits, with
+	// the posts published since counted in QuotaUsage
+	Publishing *PublishingLimits `json:"publishing,omitempty"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+// RateLimitStore keeps a RateLimitState between clients.
+type RateLimitStore interface {
+	// Load returns the saved state, or nil if there is none.
+	Load() (*RateLimitState, error)
+	// Save replaces the saved state.
+	Save(state *RateLimitState) error
+}
+
+var _ RateLimitStore = (*FileRateLimitStore)(nil)
+
+// FileRateLimitStore keeps the state as JSON in a file readable only by the
+// current user. Writes go through a temporary file and rename, so processes
+// reading it never see half a state.
+type FileRateLimitStore struct {
+	path string
+}
+
+// NewFileRateLimitStore creates a store backed by the file at path. The
+// parent directory is created on first Save if it does not exist.
+func NewFileRateLimitStore(path string) *FileRateLimitStore {
+	return &FileRateLimitStore{path: path}
+}
+
+// Path returns the file the state is kept in
+func (f *FileRateLimitStore) Path() string {
+	return f.path
+}
+
+// Load reads the state from the file. A missing file is no state.
+func (f *FileRateLimitStore) Load() (*RateLimitState, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate limit state: %w", err)
+	}
+	var state RateLimitState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode rate limit state %s: %w", f.path, err)
+	}
+	return &state, nil
+}
+
+// Save writes the state to the file
+func (f *FileRateLimitStore) Save(state *RateLimitState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode rate limit state: %w", err)
+	}
+	if err := statefile.Write(f.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save rate limit state: %w", err)
+	}
+	return nil
+}
+
+// State returns the limits the rate limiter knows, for a RateLimitStore
+func (rl *RateLimiter) State() RateLimitState {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	state := RateLimitState{Limit: rl.limit, Remaining: rl.remaining, Reset: rl.resetTime}
+	if rl.rateLimited && time.Now().Before(rl.resetTime) {
+		state.LimitedUntil = rl.resetTime
+	}
+	return state
+}
+
+// Restore takes over the limits of a saved state. The limits of a window
+// that has ended are left out.
+func (rl *RateLimiter) Restore(state *RateLimitState) {
+	if state == nil {
+		return
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	if state.Reset.After(now) {
+		if state.Limit > 0 {
+			rl.limit = state.Limit
+		}
+		rl.remaining = state.Remaining
+		rl.resetTime = state.Reset
+	}
+	if state.LimitedUntil.After(now) {
+		rl.rateLimited = true
+		if state.LimitedUntil.After(rl.resetTime) {
+			rl.resetTime = state.LimitedUntil
+		}
+	}
+}
+
+// limitState keeps the limits of a client in its RateLimitStore
+type limitState struct {
+	mu         sync.Mutex
+	store      RateLimitStore
+	publishing *PublishingLimits
+}
+
+// restoreLimits loads the saved state into the client's rate limiter. A
+// state that cannot be read is ignored; the API reports the limits again.
+func (h *HTTPClient) restoreLimits() {
+	if h.limits == nil {
+		return
+	}
+	state, err := h.limits.store.Load()
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Warn("Failed to load rate limit state", "error", err.Error())
+		}
+		return
+	}
+	if state == nil {
+		return
+	}
+	if h.rateLimiter != nil {
+		h.rateLimiter.Restore(state)
+	}
+	h.limits.mu.Lock()
+	h.limits.publishing = state.Publishing
+	h.limits.mu.Unlock()
+}
+
+// saveLimits saves the state of the client's rate limiter, with publishing
+// if it is not nil, or the publishing limits known so far
+func (h *HTTPClient) saveLimits(publishing *PublishingLimits) {
+	if h.limits == nil {
+		return
+	}
+	h.limits.mu.Lock()
+	defer h.limits.mu.Unlock()
+
+	if publishing != nil {
+		h.limits.publishing = publishing
+	}
+	var state RateLimitState
+	if h.rateLimiter != nil {
+		state = h.rateLimiter.State()
+	}
+	state.Publishing = h.limits.publishing
+	state.UpdatedAt = time.Now()
+	if err := h.limits.store.Save(&state); err != nil && h.logger != nil {
+		h.logger.Warn("Failed to save rate limit state", "error", err.Error())
+	}
+}
+
+// publishKind is the publishing quota a publish counts against
+type publishKind int
+
+const (
+	publishPost publishKind = iota
+	publishReply
+)
+
+// countPublished counts a published post or reply against the saved
+// publishing quota of its kind
+func (h *HTTPClient) countPublished(kind publishKind) {
+	if h.limits == nil {
+		return
+	}
+	h.limits.mu.Lock()
+	var publishing *PublishingLimits
+	if h.limits.publishing != nil {
+		counted := *h.limits.publishing
+		if kind == publishReply {
+			counted.ReplyQuotaUsage++
+		} else {
+			counted.QuotaUsage++
+		}
+		publishing = &counted
+	}
+	h.limits.mu.Unlock()
+	if publishing != nil {
+		h.saveLimits(publishing)
+	}
+}
+
+// savedPublishing returns the publishing limits known to the client, if
+// they are of the current quota window
+func (h *HTTPClient) savedPublishing() *PublishingLimits {
+	if h.limits == nil {
+		return nil
+	}
+	h.limits.mu.Lock()
+	defer h.limits.mu.Unlock()
+
+	limits := h.limits.publishing
+	if limits == nil || limits.Config.QuotaTotal <= 0 {
+		return nil
+	}
+	if window := time.Duration(limits.Config.QuotaDuration) * time.Second; time.Since(limits.FetchedAt) > window {
+		return nil
+	}
+	copied := *limits
+	return &copied
+}
+
+// checkPublishingQuota refuses to publish when the saved quota of kind,
+// which other processes also count against, is used up and the API
+// confirms it. A quota that cannot be checked does not stop publishing, and
+// scoped clients have no saved quota to check.
+func (c *Client) checkPublishingQuota(ctx context.Context, kind publishKind) error {
+	if c.scoped {
+		return nil
+	}
+	saved := c.httpClient.savedPublishing()
+	if saved == nil || saved.check(kind) == nil {
+		return nil
+	}
+	limits, err := c.GetPublishingLimits(ctx)
+	if err != nil {
+		return nil
+	}
+	err = limits.check(kind)
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		rateLimitErr.Bucket = RateLimitBucketPublish
+	}
+	return err
+}
+
+// check returns a RateLimitError if the quota of kind has no room for one
+// more publish
+func (l *PublishingLimits) check(kind publishKind) error {
+	if kind == publishReply {
+		return l.CheckReplies(1)
+	}
+	return l.CheckPosts(1)
+}
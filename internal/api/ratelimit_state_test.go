@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFileRateLimitStore(t *testing.T) {
+	store := NewFileRateLimitStore(filepath.Join(t.TempDir(), "ratelimit", "1.json"))
+	if state, err := store.Load(); err != nil || state != nil {
+		t.Fatalf("expected no state before the first save, got %+v, %v", state, err)
+	}
+
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	want := &RateLimitState{Limit: 200, Remaining: 12, Reset: reset, Publishing: &PublishingLimits{QuotaUsage: 3}}
+	if err := store.Save(want); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(store.Path()); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("expected a state readable by the user alone, got %v, %v", info, err)
+	}
+	got, err := store.Load()
+	if err != nil || got.Remaining != 12 || !got.Reset.Equal(reset) || got.Publishing.QuotaUsage != 3 {
+		t.Errorf("unexpected state %+v, %v", got, err)
+	}
+
+	if err := os.WriteFile(store.Path(), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load(); err == nil {
+		t.Error("expected a damaged state reported")
+	}
+}
+
+func TestRateLimiter_Restore(t *testing.T) {
+	rl := NewRateLimiter(&RateLimiterConfig{})
+	rl.Restore(&RateLimitState{Limit: 200, Remaining: 0, Reset: time.Now().Add(-time.Minute), LimitedUntil: time.Now().Add(-time.Minute)})
+	if status := rl.GetStatus(); status.Limit != 100 || status.Remaining != 100 || rl.IsRateLimited() {
+		t.Errorf("expected an ended window ignored, got %+v", status)
+	}
+
+	until := time.Now().Add(10 * time.Minute)
+	rl.Restore(&RateLimitState{Limit: 200, Remaining: 5, Reset: until, LimitedUntil: until})
+	if status := rl.GetStatus(); status.Limit != 200 || status.Remaining != 5 || !rl.IsRateLimited() {
+		t.Errorf("expected the saved window taken over, got %+v", status)
+	}
+	if state := rl.State(); !state.LimitedUntil.Equal(until) || state.Remaining != 5 {
+		t.Errorf("expected the state saved back, got %+v", state)
+	}
+}
+
+func TestClient_SharesRateLimitState(t *testing.T) {
+	store := NewFileRateLimitStore(filepath.Join(t.TempDir(), "1.json"))
+	reset := time.Now().Add(time.Hour).Unix()
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "200")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":{"message":"Too many calls","code":4}}`))
+	})
+	defer server.Close()
+	client.httpClient.limits = &limitState{store: store}
+	client.httpClient.retryConfig.MaxRetries = 0
+
+	if _, err := client.httpClient.GET(context.Background(), "/me", nil, "token"); err == nil {
+		t.Fatal("expected a rate limit error")
+	}
+
+	// The next process starts out rate limited
+	next, err := NewClient(&Config{ClientID: "id", ClientSecret: "secret", RateLimitStore: store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !next.IsRateLimited() || next.GetRateLimitStatus().ResetTime.Unix() != reset {
+		t.Errorf("expected the 429 shared, got %+v", next.GetRateLimitStatus())
+	}
+}
+
+func TestClient_PublishingQuotaShared(t *testing.T) {
+	var usage, published atomic.Int32
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/threads_publishing_limit"):
+			_, _ = w.Write([]byte(`{"data":[{"quota_usage":` + strconv.Itoa(int(usage.Load())) + `,"config":{"quota_total":250,"quota_duration":86400},"reply_quota_usage":0,"reply_config":{"quota_total":1000,"quota_duration":86400}}]}`))
+		case strings.HasSuffix(r.URL.Path, "/threads_publish"):
+			published.Add(1)
+			_, _ = w.Write([]byte(`{"id":"p1"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"p1"}`))
+		}
+	})
+	defer server.Close()
+	store := NewFileRateLimitStore(filepath.Join(t.TempDir(), "1.json"))
+	client.httpClient.limits = &limitState{store: store}
+	client.currentConfig().TokenRefreshWindow = -1
+	ctx := context.Background()
+
+	// Another process saved a quota one post short of used up
+	usage.Store(249)
+	if _, err := client.GetPublishingLimits(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.publishContainer(ctx, "c1", publishPost); err != nil {
+		t.Fatal(err)
+	}
+	state, err := store.Load()
+	if err != nil || state.Publishing == nil || state.Publishing.QuotaUsage != 250 {
+		t.Fatalf("expected the post counted in the saved quota, got %+v, %v", state, err)
+	}
+
+	// The saved count says used up; the API confirms it
+	usage.Store(250)
+	_, err = client.publishContainer(ctx, "c2", publishPost)
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.Bucket != RateLimitBucketPublish {
+		t.Fatalf("expected a publishing quota error, got %v", err)
+	}
+	if published.Load() != 1 {
+		t.Errorf("expected the second post not sent, got %d published", published.Load())
+	}
+
+	// Replies count against their own quota
+	if _, err := client.publishContainer(ctx, "r1", publishReply); err != nil || published.Load() != 2 {
+		t.Fatalf("expected the reply published with the post quota used up, got %v", err)
+	}
+	if state, err := store.Load(); err != nil || state.Publishing.QuotaUsage != 250 || state.Publishing.ReplyQuotaUsage != 1 {
+		t.Fatalf("expected the reply counted in the saved reply quota, got %+v, %v", state, err)
+	}
+
+	// The API has room again, so the saved count was too high
+	usage.Store(100)
+	if _, err := client.publishContainer(ctx, "c3", publishPost); err != nil || published.Load() != 3 {
+		t.Errorf("expected the post published after the API reported room, got %v", err)
+	}
+}
+
+func TestAsUser_KeepsOffSavedQuota(t *testing.T) {
+	var published atomic.Int32
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/threads_publishing_limit"):
+			usage := "250"
+			if r.Header.Get("Authorization") == "Bearer scoped-token" {
+				usage = "3"
+			}
+			_, _ = w.Write([]byte(`{"data":[{"quota_usage":` + usage + `,"config":{"quota_total":250,"quota_duration":86400}}]}`))
+		case strings.HasSuffix(r.URL.Path, "/threads_publish"):
+			published.Add(1)
+			_, _ = w.Write([]byte(`{"id":"p1"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"p1"}`))
+		}
+	})
+	defer server.Close()
+	store := NewFileRateLimitStore(filepath.Join(t.TempDir(), "1.json"))
+	client.httpClient.limits = &limitState{store: store}
+	ctx := context.Background()
+
+	// The parent account has used up its quota
+	if _, err := client.GetPublishingLimits(ctx); err != nil {
+		t.Fatal(err)
+	}
+	scoped, err := client.AsUser("2", "scoped-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scoped.currentConfig().TokenRefreshWindow = -1
+
+	if _, err := scoped.publishContainer(ctx, "c1", publishPost); err != nil || published.Load() != 1 {
+		t.Fatalf("expected the scoped client not held to the parent's quota, got %v", err)
+	}
+	if _, err := scoped.GetPublishingLimits(ctx); err != nil {
+		t.Fatal(err)
+	}
+	state, err := store.Load()
+	if err != nil || state.Publishing == nil || state.Publishing.QuotaUsage != 250 {
+		t.Errorf("expected the parent's saved quota untouched, got %+v, %v", state, err)
+	}
+}
@@ -8,9 +8,9 @@ import (
 )
 
 // buildRepliesParams builds query parameters for replies and conversation requests
-func buildRepliesParams(opts *RepliesOptions, maxLimit int, limitDescription string) (url.Values, error) {
+func buildRepliesParams(e *Endpoint, opts *RepliesOptions, maxLimit int, limitDescription string) (url.Values, error) {
 	params := url.Values{
-		"fields": {ReplyFields},
+		"fields": {e.Fields},
 	}
 
 	if opts != nil {
@@ -35,8 +35,8 @@ func buildRepliesParams(opts *RepliesOptions, maxLimit int, limitDescription str
 }
 
 // fetchRepliesData makes the API call and handles common error cases
-func (c *Client) fetchRepliesData(path string, params url.Values, postID PostID, dataType string) (*RepliesResponse, error) {
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+func (c *Client) fetchRepliesData(ctx context.Context, path string, params url.Values, postID PostID, dataType string) (*RepliesResponse, error) {
+	resp, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -75,14 +75,14 @@ func (c *Client) GetReplies(ctx context.Context, postID PostID, opts *RepliesOpt
 	}
 
 	// Build query parameters
-	params, err := buildRepliesParams(opts, 100, "replies per request")
+	params, err := buildRepliesParams(endpointReplies, opts, 100, "replies per request")
 	if err != nil {
 		return nil, err
 	}
 
 	// Make API call to get post replies
-	path := fmt.Sprintf("/%s/replies", postID.String())
-	return c.fetchRepliesData(path, params, postID, "post replies")
+	path := endpointReplies.path(postID.String())
+	return c.fetchRepliesData(ctx, path, params, postID, "post replies")
 }
 
 // GetConversation retrieves a flattened conversation thread for a specific post
@@ -97,14 +97,14 @@ func (c *Client) GetConversation(ctx context.Context, postID PostID, opts *Repli
 	}
 
 	// Build query parameters
-	params, err := buildRepliesParams(opts, 100, "posts per request")
+	params, err := buildRepliesParams(endpointConversation, opts, 100, "posts per request")
 	if err != nil {
 		return nil, err
 	}
 
 	// Make API call to get conversation
-	path := fmt.Sprintf("/%s/conversation", postID.String())
-	return c.fetchRepliesData(path, params, postID, "conversation")
+	path := endpointConversation.path(postID.String())
+	return c.fetchRepliesData(ctx, path, params, postID, "conversation")
 }
 
 // manageReplyVisibility handles hiding and unhiding replies
@@ -129,8 +129,8 @@ func (c *Client) manageReplyVisibility(ctx context.Context, replyID PostID, hide
 	}
 
 	// Make API call to manage reply visibility
-	path := fmt.Sprintf("/%s/manage_reply", replyID.String())
-	resp, err := c.httpClient.POST(path, params, c.getAccessTokenSafe())
+	path := endpointManageReply.path(replyID.String())
+	resp, err := c.httpClient.POST(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return err
 	}
@@ -156,15 +156,15 @@ func (c *Client) manageReplyVisibility(ctx context.Context, replyID PostID, hide
 	if len(resp.Body) > 0 {
 		if err := json.Unmarshal(resp.Body, &manageResp); err != nil {
 			// If we can't parse the response but got 200, assume success
-			if c.config.Logger != nil {
-				c.config.Logger.Warn(fmt.Sprintf("Could not parse %s reply response, but got 200 status", action), "reply_id", replyID.String())
+			if logger := c.currentConfig().Logger; logger != nil {
+				logger.Warn(fmt.Sprintf("Could not parse %s reply response, but got 200 status", action), "reply_id", replyID.String())
 			}
 		}
 	}
 
 	// Log successful action if logger is available
-	if c.config.Logger != nil {
-		c.config.Logger.Info(fmt.Sprintf("Successfully %sd reply", action), "reply_id", replyID.String())
+	if logger := c.currentConfig().Logger; logger != nil {
+		logger.Info(fmt.Sprintf("Successfully %sd reply", action), "reply_id", replyID.String())
 	}
 
 	return nil
@@ -192,7 +192,7 @@ func TestBuildRepliesParams(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			params, err := buildRepliesParams(tt.opts, tt.maxLimit, tt.limitDesc)
+			params, err := buildRepliesParams(endpointReplies, tt.opts, tt.maxLimit, tt.limitDesc)
 
 			if tt.shouldErr {
 				if err == nil {
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// DefaultResponseCacheTTL is how long a cached response is served without
+// asking the API, when NewResponseCache is given no TTL
+const DefaultResponseCacheTTL = time.Minute
+
+// ResponseCacheHeader is set to "hit" on responses served from the cache
+// without a request, and to "revalidated" on those the API confirmed with
+// 304 Not Modified
+const ResponseCacheHeader = "X-Threads-Cache"
+
+// ResponseCache keeps the responses of read endpoints, such as GetUser,
+// GetPost and GetPublishingLimits, in files under a directory. A response
+// younger than the TTL is served without a request; an older one is sent
+// back with its ETag or Last-Modified, and reused if the API answers 304
+// Not Modified. Entries are kept per access token, and those of a token
+// are dropped after any request that changes something with it, such as
+// publishing or deleting a post.
+//
+// Several clients and processes may share a directory.
+type ResponseCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// NewResponseCache returns a cache that keeps responses under dir and
+// serves them without a request for ttl. A zero ttl is
+// DefaultResponseCacheTTL; a negative one always revalidates.
+func NewResponseCache(dir string, ttl time.Duration) *ResponseCache {
+	if ttl == 0 {
+		ttl = DefaultResponseCacheTTL
+	}
+	return &ResponseCache{dir: dir, ttl: ttl}
+}
+
+// Dir returns the directory the cache keeps responses in
+func (c *ResponseCache) Dir() string {
+	return c.dir
+}
+
+// Clear removes every cached response
+func (c *ResponseCache) Clear() error {
+	return os.RemoveAll(c.dir)
+}
+
+// cachedResponse is a response kept by ResponseCache
+type cachedResponse struct {
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	StoredAt     time.Time `json:"stored_at"`
+	RequestID    string    `json:"request_id,omitempty"`
+	Body         []byte    `json:"body"`
+}
+
+// tokenDir is where the responses fetched with accessToken are kept. The
+// token is hashed so it is not written to disk.
+func (c *ResponseCache) tokenDir(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:8]))
+}
+
+func (c *ResponseCache) path(accessToken, rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return filepath.Join(c.tokenDir(accessToken), hex.EncodeToString(sum[:])+".json")
+}
+
+// load returns the response kept for rawURL, or nil. A damaged entry is a
+// miss.
+func (c *ResponseCache) load(accessToken, rawURL string) *cachedResponse {
+	data, err := os.ReadFile(c.path(accessToken, rawURL))
+	if err != nil {
+		return nil
+	}
+	var entry cachedResponse
+	if json.Unmarshal(data, &entry) != nil || entry.StoredAt.IsZero() {
+		return nil
+	}
+	return &entry
+}
+
+// fresh reports whether entry may be served without a request
+func (c *ResponseCache) fresh(entry *cachedResponse) bool {
+	return time.Since(entry.StoredAt) < c.ttl
+}
+
+// store keeps entry for rawURL, replacing the file atomically so readers
+// in other processes never see half of it
+func (c *ResponseCache) store(accessToken, rawURL string, entry *cachedResponse) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := statefile.Write(c.path(accessToken, rawURL), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write response cache file: %w", err)
+	}
+	return nil
+}
+
+// invalidate drops the responses fetched with accessToken
+func (c *ResponseCache) invalidate(accessToken string) error {
+	return os.RemoveAll(c.tokenDir(accessToken))
+}
+
+// response turns entry back into the Response of a successful GET
+func (entry *cachedResponse) response(state string) *Response {
+	header := http.Header{}
+	header.Set(ResponseCacheHeader, state)
+	if entry.ETag != "" {
+		header.Set("ETag", entry.ETag)
+	}
+	return &Response{
+		Response:   &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: header},
+		Body:       entry.Body,
+		RequestID:  entry.RequestID,
+		StatusCode: http.StatusOK,
+	}
+}
+
+// cachedGET performs a GET through the response cache, or a plain GET when
+// the client has none
+func (h *HTTPClient) cachedGET(ctx context.Context, path string, queryParams url.Values, accessToken string) (*Response, error) {
+	if h.cache == nil {
+		return h.GET(ctx, path, queryParams, accessToken)
+	}
+
+	key := h.baseURL + path + "?" + queryParams.Encode()
+	entry := h.cache.load(accessToken, key)
+	if entry != nil && h.cache.fresh(entry) {
+		return entry.response("hit"), nil
+	}
+
+	headers := map[string]string{}
+	if entry != nil && entry.ETag != "" {
+		headers["If-None-Match"] = entry.ETag
+	}
+	if entry != nil && entry.LastModified != "" {
+		headers["If-Modified-Since"] = entry.LastModified
+	}
+	resp, err := h.Do(&RequestOptions{
+		Method:      http.MethodGet,
+		Path:        path,
+		QueryParams: queryParams,
+		Headers:     headers,
+		Context:     ctx,
+	}, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		entry.StoredAt = time.Now()
+		h.storeResponse(accessToken, key, entry)
+		revalidated := entry.response("revalidated")
+		revalidated.Meta, revalidated.RateLimit, revalidated.Duration = resp.Meta, resp.RateLimit, resp.Duration
+		return revalidated, nil
+	case resp.StatusCode == http.StatusOK:
+		h.storeResponse(accessToken, key, &cachedResponse{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			StoredAt:     time.Now(),
+			RequestID:    resp.RequestID,
+			Body:         resp.Body,
+		})
+	}
+	return resp, nil
+}
+
+// storeResponse keeps a response; the response is still good if it cannot
+// be kept, so failures are only logged
+func (h *HTTPClient) storeResponse(accessToken, key string, entry *cachedResponse) {
+	if err := h.cache.store(accessToken, key, entry); err != nil && h.logger != nil {
+		h.logger.Warn("Failed to cache response", "error", err.Error())
+	}
+}
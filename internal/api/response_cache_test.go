@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// etagServer answers with the mock user and an ETag, or 304 Not Modified
+// to a request that already has it, recording the If-None-Match of each
+func etagServer(t *testing.T) (*Client, *[]string, *sync.Mutex) {
+	t.Helper()
+	var mu sync.Mutex
+	var seen []string
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method != http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(mockSuccessResponse())
+			return
+		}
+		seen = append(seen, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockUserResponse())
+	})
+	t.Cleanup(server.Close)
+	// Keep the test token from being refreshed against this server
+	client.currentConfig().TokenRefreshWindow = -1
+	return client, &seen, &mu
+}
+
+func TestResponseCache_ServesFreshResponses(t *testing.T) {
+	client, seen, mu := etagServer(t)
+	client.httpClient.cache = NewResponseCache(t.TempDir(), time.Hour)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		user, err := client.GetUser(ctx, "12345")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.Username != mockUserResponse()["username"] {
+			t.Fatalf("unexpected user %+v", user)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(*seen) != 1 {
+		t.Errorf("expected one request while fresh, got %d", len(*seen))
+	}
+}
+
+func TestResponseCache_RevalidatesWithETag(t *testing.T) {
+	client, seen, mu := etagServer(t)
+	client.httpClient.cache = NewResponseCache(t.TempDir(), -1)
+	ctx := context.Background()
+
+	if _, err := client.GetUser(ctx, "12345"); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.httpClient.cachedGET(ctx, endpointUser.path("12345"), url.Values{"fields": {endpointUser.Fields}}, "test-access-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get(ResponseCacheHeader) != "revalidated" || !strings.Contains(string(resp.Body), "testuser") {
+		t.Errorf("expected the cached body revalidated, got %d %v %s", resp.StatusCode, resp.Header, resp.Body)
+	}
+	user, err := client.GetUser(ctx, "12345")
+	if err != nil || user.ID != "12345" {
+		t.Fatalf("expected the user from a 304, got %+v, %v", user, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(*seen) != 3 || (*seen)[0] != "" || (*seen)[1] != `"v1"` || (*seen)[2] != `"v1"` {
+		t.Errorf("expected later requests to send the ETag, got %q", *seen)
+	}
+}
+
+func TestResponseCache_ChangesInvalidate(t *testing.T) {
+	client, seen, mu := etagServer(t)
+	dir := t.TempDir()
+	client.httpClient.cache = NewResponseCache(dir, time.Hour)
+	ctx := context.Background()
+
+	if _, err := client.GetUser(ctx, "12345"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.httpClient.POST(ctx, "/12345/threads_publish", url.Values{"creation_id": {"1"}}, "test-access-token"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetUser(ctx, "12345"); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(*seen) != 2 || (*seen)[1] != "" {
+		t.Errorf("expected a fresh request after the POST, got %q", *seen)
+	}
+}
+
+func TestResponseCache_Files(t *testing.T) {
+	client, _, _ := etagServer(t)
+	dir := t.TempDir()
+	client.httpClient.cache = NewResponseCache(dir, time.Hour)
+
+	if _, err := client.GetUser(context.Background(), "12345"); err != nil {
+		t.Fatal(err)
+	}
+	var files []string
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if len(files) != 1 {
+		t.Fatalf("expected one cached response, got %v", files)
+	}
+	data, _ := os.ReadFile(files[0])
+	if strings.Contains(files[0]+string(data), "test-access-token") {
+		t.Error("expected the access token kept out of the cache")
+	}
+	if !strings.Contains(string(data), `\"v1\"`) {
+		t.Errorf("expected the ETag stored, got %s", data)
+	}
+
+	// A damaged entry is a miss
+	if err := os.WriteFile(files[0], []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetUser(context.Background(), "12345"); err != nil {
+		t.Errorf("expected a damaged entry refetched, got %v", err)
+	}
+
+	if err := client.httpClient.cache.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("expected Clear to remove the directory, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ResponseMeta describes a single HTTP exchange with the Threads API.
+// It is available for every response, successful or not, through
+// ResponseMetaFromContext and for failed calls through ResponseMetaFromError.
+type ResponseMeta struct {
+	Method     string         `json:"method"`
+	Path       string         `json:"path"`
+	StatusCode int            `json:"status_code"`
+	RequestID  string         `json:"request_id,omitempty"`
+	Header     http.Header    `json:"-"`
+	RateLimit  *RateLimitInfo `json:"rate_limit,omitempty"`
+	Duration   time.Duration  `json:"duration"`
+	Attempt    int            `json:"attempt"`
+}
+
+// newResponseMeta builds metadata for resp. The query string is left out of
+// Path because it may contain credentials.
+func newResponseMeta(resp *Response, attempt int) *ResponseMeta {
+	meta := &ResponseMeta{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.RequestID,
+		RateLimit:  resp.RateLimit,
+		Duration:   resp.Duration,
+		Attempt:    attempt,
+	}
+	if resp.Response != nil {
+		meta.Header = resp.Header.Clone()
+		if resp.Request != nil {
+			meta.Method = resp.Request.Method
+			meta.Path = resp.Request.URL.Path
+		}
+	}
+	return meta
+}
+
+// responseMetaRecorder collects metadata for requests made with a context
+type responseMetaRecorder struct {
+	mu    sync.Mutex
+	metas []*ResponseMeta
+}
+
+type responseMetaKey struct{}
+
+// WithResponseMeta returns a context that records metadata for every API
+// response received while it is in use. Retrieve it with
+// ResponseMetaFromContext or ResponseMetasFromContext.
+func WithResponseMeta(ctx context.Context) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, &responseMetaRecorder{})
+}
+
+// ResponseMetaFromContext returns metadata for the most recent response
+// recorded in ctx. It returns false if ctx was not prepared with
+// WithResponseMeta or no response has been received yet.
+func ResponseMetaFromContext(ctx context.Context) (*ResponseMeta, bool) {
+	metas := ResponseMetasFromContext(ctx)
+	if len(metas) == 0 {
+		return nil, false
+	}
+	return metas[len(metas)-1], true
+}
+
+// ResponseMetasFromContext returns metadata for all responses recorded in
+// ctx, oldest first, including retried attempts.
+func ResponseMetasFromContext(ctx context.Context) []*ResponseMeta {
+	rec, ok := ctx.Value(responseMetaKey{}).(*responseMetaRecorder)
+	if !ok {
+		return nil
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return append([]*ResponseMeta(nil), rec.metas...)
+}
+
+// recordResponseMeta stores meta in ctx if it was prepared with WithResponseMeta
+func recordResponseMeta(ctx context.Context, meta *ResponseMeta) {
+	if ctx == nil {
+		return
+	}
+	rec, ok := ctx.Value(responseMetaKey{}).(*responseMetaRecorder)
+	if !ok {
+		return
+	}
+	rec.mu.Lock()
+	rec.metas = append(rec.metas, meta)
+	rec.mu.Unlock()
+}
+
+// ResponseMetaFromError returns the response metadata attached to an API
+// error, if the error was produced from an HTTP response.
+func ResponseMetaFromError(err error) (*ResponseMeta, bool) {
+	var carrier interface{ ResponseMeta() *ResponseMeta }
+	if !errors.As(err, &carrier) {
+		return nil, false
+	}
+	meta := carrier.ResponseMeta()
+	return meta, meta != nil
+}
+
+// attachResponseMeta records meta on err when err is one of the API error types
+func attachResponseMeta(err error, meta *ResponseMeta) error {
+	if err == nil || meta == nil {
+		return err
+	}
+	var carrier interface{ setResponseMeta(*ResponseMeta) }
+	if errors.As(err, &carrier) {
+		carrier.setResponseMeta(meta)
+	}
+	return err
+}
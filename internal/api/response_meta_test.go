@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestResponseMeta_RecordedInContext(t *testing.T) {
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Fb-Request-Id", "req-123")
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		if strings.Contains(r.URL.Path, "refresh_access_token") {
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"123","text":"hello"}`))
+	})
+	defer server.Close()
+
+	ctx := WithResponseMeta(context.Background())
+	if _, err := client.GetPost(ctx, "123"); err != nil {
+		t.Fatalf("GetPost failed: %v", err)
+	}
+
+	meta, ok := ResponseMetaFromContext(ctx)
+	if !ok {
+		t.Fatal("expected response metadata in context")
+	}
+	if meta.Method != http.MethodGet || meta.Path != "/123" {
+		t.Errorf("unexpected request: %s %s", meta.Method, meta.Path)
+	}
+	if meta.StatusCode != http.StatusOK || meta.RequestID != "req-123" || meta.Attempt != 1 {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+	if meta.RateLimit == nil || meta.RateLimit.Remaining != 99 {
+		t.Errorf("expected rate limit info, got %+v", meta.RateLimit)
+	}
+	if meta.Header.Get("X-Fb-Request-Id") != "req-123" {
+		t.Error("expected response headers to be captured")
+	}
+}
+
+func TestResponseMeta_FromError(t *testing.T) {
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Fb-Request-Id", "req-404")
+		if strings.Contains(r.URL.Path, "refresh_access_token") {
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Invalid post","code":100}}`))
+	})
+	defer server.Close()
+
+	_, err := client.GetPost(context.Background(), "123")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	meta, ok := ResponseMetaFromError(err)
+	if !ok {
+		t.Fatalf("expected response metadata on error %v", err)
+	}
+	if meta.StatusCode != http.StatusBadRequest || meta.RequestID != "req-404" {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+}
+
+func TestResponseMeta_NotRecordedWithoutContext(t *testing.T) {
+	ctx := context.Background()
+	recordResponseMeta(ctx, &ResponseMeta{StatusCode: 200})
+
+	if _, ok := ResponseMetaFromContext(ctx); ok {
+		t.Error("expected no metadata without WithResponseMeta")
+	}
+	if _, ok := ResponseMetaFromError(NewNetworkError(0, "timeout", "", true)); ok {
+		t.Error("expected no metadata on error not produced from a response")
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// IsRetryable reports whether the operation that returned err may succeed if
+// repeated. It applies the same rules the client uses for its own retries:
+// rate limits, server errors (5xx), and temporary network failures are
+// retryable; validation, authentication and other client errors are not. A
+// container that was still processing when a wait timed out is also
+// retryable. Context cancellation is never retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	// Rate limit errors are retry-able
+	if IsRateLimitError(err) {
+		return true
+	}
+
+	// Temporary network errors are retry-able
+	var netErr *NetworkError
+	if errors.As(err, &netErr) {
+		return netErr.Temporary
+	}
+
+	var containerErr *ContainerError
+	if errors.As(err, &containerErr) {
+		return containerErr.IsTimeout()
+	}
+
+	// Prefer the HTTP status when the error came from a response, since API
+	// error codes in the body do not follow HTTP semantics
+	if meta, ok := ResponseMetaFromError(err); ok && meta.StatusCode != 0 {
+		return isRetryableStatus(meta.StatusCode)
+	}
+
+	// Some API errors are retry-able (5xx status codes)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code >= 500 && apiErr.Code < 600
+	}
+
+	return false
+}
+
+// RetryAfter returns how long the server asked the caller to wait before
+// retrying, or zero if err carries no such hint. Callers should fall back to
+// their own backoff when it returns zero.
+func RetryAfter(err error) time.Duration {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		if rateLimitErr.RetryAfter > 0 {
+			return rateLimitErr.RetryAfter
+		}
+		if !rateLimitErr.ResetAt.IsZero() {
+			return max(time.Until(rateLimitErr.ResetAt), 0)
+		}
+	}
+
+	if meta, ok := ResponseMetaFromError(err); ok && meta.RateLimit != nil {
+		if meta.RateLimit.RetryAfter > 0 {
+			return meta.RateLimit.RetryAfter
+		}
+		if !meta.RateLimit.Reset.IsZero() {
+			return max(time.Until(meta.RateLimit.Reset), 0)
+		}
+	}
+
+	return 0
+}
+
+// isRetryableStatus reports whether an HTTP status code should trigger a retry
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case 429: // Too Many Requests
+		return true
+	case 500, 502, 503, 504: // Server errors
+		return true
+	default:
+		return false
+	}
+}
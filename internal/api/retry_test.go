@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIsRetryable(t *testing.T) {
+	withMeta := func(err error, status int) error {
+		return attachResponseMeta(err, &ResponseMeta{StatusCode: status})
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"rate limit", NewRateLimitError(429, "slow down", "", time.Second), true},
+		{"temporary network", NewNetworkError(0, "timeout", "", true), true},
+		{"permanent network", NewNetworkError(0, "dns", "", false), false},
+		{"server error", NewAPIError(503, "unavailable", "", ""), true},
+		{"client api error", NewAPIError(404, "not found", "", ""), false},
+		{"validation", NewValidationError(400, "bad", "", "text"), false},
+		{"authentication", NewAuthenticationError(401, "expired", ""), false},
+		{"body code on 500 response", withMeta(NewAPIError(1, "unknown", "", ""), 500), true},
+		{"body code on 400 response", withMeta(NewAPIError(2, "bad", "", ""), 404), false},
+		{"wrapped", fmt.Errorf("failed: %w", NewRateLimitError(429, "", "", 0)), true},
+		{"container timeout", NewContainerError("c1", ContainerStatusInProgress, "", ""), true},
+		{"container failed", NewContainerError("c1", ContainerStatusError, "", ""), false},
+		{"cancelled", fmt.Errorf("wait: %w", context.Canceled), false},
+		{"plain", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if got := RetryAfter(NewRateLimitError(429, "", "", 30*time.Second)); got != 30*time.Second {
+		t.Errorf("expected 30s from RateLimitError, got %v", got)
+	}
+
+	err := attachResponseMeta(NewAPIError(503, "", "", ""), &ResponseMeta{
+		StatusCode: 503,
+		RateLimit:  &RateLimitInfo{RetryAfter: 5 * time.Second},
+	})
+	if got := RetryAfter(fmt.Errorf("wrapped: %w", err)); got != 5*time.Second {
+		t.Errorf("expected 5s from response metadata, got %v", got)
+	}
+
+	if got := RetryAfter(errors.New("boom")); got != 0 {
+		t.Errorf("expected zero for plain errors, got %v", got)
+	}
+}
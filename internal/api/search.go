@@ -21,7 +21,7 @@ func (c *Client) KeywordSearch(ctx context.Context, query string, opts *SearchOp
 	// Build query parameters according to API documentation
 	params := url.Values{
 		"q":      {query},
-		"fields": {PostExtendedFields}, // Use PostExtendedFields for comprehensive search results
+		"fields": {endpointKeywordSearch.Fields},
 	}
 
 	// Add search options if provided
@@ -65,8 +65,8 @@ func (c *Client) KeywordSearch(ctx context.Context, query string, opts *SearchOp
 	}
 
 	// Make API call to keyword search endpoint
-	path := "/keyword_search"
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointKeywordSearch.path()
+	resp, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
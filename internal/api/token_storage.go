@@ -0,0 +1,186 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// TokenStorage interface for storing and retrieving tokens.
+// The client stores every token it obtains or refreshes, so an implementation
+// backed by durable storage keeps refreshed tokens across restarts.
+// The default MemoryTokenStorage loses tokens when the application terminates.
+type TokenStorage interface {
+	// Store saves a token to persistent storage.
+	// Should return an error if the token cannot be saved.
+	Store(token *TokenInfo) error
+
+	// Load retrieves a token from persistent storage.
+	// Should return an error if no token is found or cannot be loaded.
+	Load() (*TokenInfo, error)
+
+	// Delete removes a token from persistent storage.
+	// Should return an error if the token cannot be deleted.
+	Delete() error
+}
+
+// Compile-time checks for the bundled storage implementations
+var (
+	_ TokenStorage = (*MemoryTokenStorage)(nil)
+	_ TokenStorage = (*FileTokenStorage)(nil)
+	_ TokenStorage = (*CallbackTokenStorage)(nil)
+)
+
+// MemoryTokenStorage provides in-memory token storage (default).
+// The zero value is ready to use and safe for concurrent use.
+type MemoryTokenStorage struct {
+	mu    sync.RWMutex
+	token *TokenInfo
+}
+
+// NewMemoryTokenStorage creates an empty in-memory token storage
+func NewMemoryTokenStorage() *MemoryTokenStorage {
+	return &MemoryTokenStorage{}
+}
+
+// Store saves the token in memory
+func (m *MemoryTokenStorage) Store(token *TokenInfo) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.token = token
+	return nil
+}
+
+// Load retrieves the token from memory
+func (m *MemoryTokenStorage) Load() (*TokenInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.token == nil {
+		return nil, NewAuthenticationError(401, "No token stored", "Token not found in memory storage")
+	}
+	return m.token, nil
+}
+
+// Delete removes the token from memory
+func (m *MemoryTokenStorage) Delete() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.token = nil
+	return nil
+}
+
+// FileTokenStorage persists the token as JSON in a file readable only by the
+// current user. Writes go through a temporary file and rename so a crash never
+// leaves a truncated token behind.
+type FileTokenStorage struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileTokenStorage creates a token storage backed by the file at path.
+// The parent directory is created on first Store if it does not exist.
+func NewFileTokenStorage(path string) *FileTokenStorage {
+	return &FileTokenStorage{path: path}
+}
+
+// Path returns the file the token is stored in
+func (f *FileTokenStorage) Path() string {
+	return f.path
+}
+
+// Store writes the token to the file
+func (f *FileTokenStorage) Store(token *TokenInfo) error {
+	if token == nil {
+		return fmt.Errorf("token cannot be nil")
+	}
+
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode token: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := statefile.Write(f.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save token file: %w", err)
+	}
+	return nil
+}
+
+// Load reads the token from the file
+func (f *FileTokenStorage) Load() (*TokenInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, NewAuthenticationError(401, "No token stored", fmt.Sprintf("Token file %s does not exist", f.path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	var token TokenInfo
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to decode token file: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, NewAuthenticationError(401, "No token stored", fmt.Sprintf("Token file %s has no access token", f.path))
+	}
+	return &token, nil
+}
+
+// Delete removes the token file. Deleting a missing file is not an error.
+func (f *FileTokenStorage) Delete() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete token file: %w", err)
+	}
+	return nil
+}
+
+// CallbackTokenStorage adapts plain functions to the TokenStorage interface,
+// for applications that keep tokens in their own database or secret store.
+// A nil StoreFunc or DeleteFunc is a no-op; a nil LoadFunc reports that no
+// token is stored.
+type CallbackTokenStorage struct {
+	StoreFunc  func(token *TokenInfo) error
+	LoadFunc   func() (*TokenInfo, error)
+	DeleteFunc func() error
+}
+
+// NewCallbackTokenStorage creates a token storage that delegates to the given functions
+func NewCallbackTokenStorage(store func(*TokenInfo) error, load func() (*TokenInfo, error), del func() error) *CallbackTokenStorage {
+	return &CallbackTokenStorage{StoreFunc: store, LoadFunc: load, DeleteFunc: del}
+}
+
+// Store calls StoreFunc
+func (c *CallbackTokenStorage) Store(token *TokenInfo) error {
+	if c.StoreFunc == nil {
+		return nil
+	}
+	return c.StoreFunc(token)
+}
+
+// Load calls LoadFunc
+func (c *CallbackTokenStorage) Load() (*TokenInfo, error) {
+	if c.LoadFunc == nil {
+		return nil, NewAuthenticationError(401, "No token stored", "No load callback configured")
+	}
+	return c.LoadFunc()
+}
+
+// Delete calls DeleteFunc
+func (c *CallbackTokenStorage) Delete() error {
+	if c.DeleteFunc == nil {
+		return nil
+	}
+	return c.DeleteFunc()
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestFileTokenStorage_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "token.json")
+	storage := NewFileTokenStorage(path)
+
+	if _, err := storage.Load(); !IsAuthenticationError(err) {
+		t.Fatalf("expected authentication error for missing file, got %v", err)
+	}
+
+	token := &TokenInfo{
+		AccessToken: "abc",
+		TokenType:   "Bearer",
+		ExpiresAt:   time.Now().Add(time.Hour).Truncate(time.Second),
+		UserID:      "42",
+	}
+	if err := storage.Store(token); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("token file not created: %v", err)
+	}
+	// Windows has no permission bits to check
+	if perm := info.Mode().Perm(); perm != 0o600 && runtime.GOOS != "windows" {
+		t.Errorf("expected 0600 permissions, got %o", perm)
+	}
+
+	loaded, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.AccessToken != "abc" || loaded.UserID != "42" || !loaded.ExpiresAt.Equal(token.ExpiresAt) {
+		t.Errorf("unexpected token: %+v", loaded)
+	}
+
+	if err := storage.Delete(); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := storage.Delete(); err != nil {
+		t.Errorf("Delete of missing file should succeed, got %v", err)
+	}
+}
+
+func TestCallbackTokenStorage(t *testing.T) {
+	var stored *TokenInfo
+	storage := NewCallbackTokenStorage(
+		func(token *TokenInfo) error { stored = token; return nil },
+		func() (*TokenInfo, error) { return stored, nil },
+		nil,
+	)
+
+	if err := storage.Store(&TokenInfo{AccessToken: "abc"}); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	loaded, err := storage.Load()
+	if err != nil || loaded.AccessToken != "abc" {
+		t.Errorf("unexpected Load result: %+v, %v", loaded, err)
+	}
+	if err := storage.Delete(); err != nil {
+		t.Errorf("nil DeleteFunc should be a no-op, got %v", err)
+	}
+
+	empty := &CallbackTokenStorage{}
+	if _, err := empty.Load(); !IsAuthenticationError(err) {
+		t.Errorf("expected authentication error without LoadFunc, got %v", err)
+	}
+}
+
+func TestClient_PersistsTokenToStorage(t *testing.T) {
+	var stored []*TokenInfo
+	storage := &CallbackTokenStorage{
+		StoreFunc: func(token *TokenInfo) error {
+			stored = append(stored, token)
+			return nil
+		},
+	}
+
+	client, err := New("abc", WithTokenStorage(storage))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if len(stored) != 1 || stored[0].AccessToken != "abc" {
+		t.Fatalf("expected token to be stored on creation, got %+v", stored)
+	}
+
+	storage.StoreFunc = func(*TokenInfo) error { return errors.New("disk full") }
+	if err := client.SetTokenInfo(&TokenInfo{AccessToken: "def"}); err == nil {
+		t.Error("expected storage error to be returned")
+	}
+}
@@ -229,18 +229,12 @@ const (
 // PostsResponse represents a paginated response containing multiple posts.
 // Use the Paging field to navigate through large result sets.
 // This is returned by endpoints like GetUserPosts, SearchPosts, etc.
-type PostsResponse struct {
-	Data   []Post `json:"data"`
-	Paging Paging `json:"paging"`
-}
+type PostsResponse = Page[Post]
 
 // RepliesResponse represents a paginated response containing reply posts.
 // Use the Paging field to navigate through conversation threads.
 // This is returned by endpoints like GetReplies, GetConversation, etc.
-type RepliesResponse struct {
-	Data   []Post `json:"data"`
-	Paging Paging `json:"paging"`
-}
+type RepliesResponse = Page[Post]
 
 // InsightsResponse represents analytics and insights data for posts or user profiles.
 // Contains an array of Insight objects with various metrics like views, likes, replies.
@@ -271,6 +265,20 @@ type Value struct {
 // TotalValue represents an aggregated metric value
 type TotalValue struct {
 	Value int `json:"value"`
+	// Breakdowns splits the value by dimension, e.g. for follower_demographics
+	Breakdowns []InsightBreakdown `json:"breakdowns,omitempty"`
+}
+
+// InsightBreakdown is a metric value split by one or more dimensions
+type InsightBreakdown struct {
+	DimensionKeys []string                 `json:"dimension_keys"`
+	Results       []InsightBreakdownResult `json:"results"`
+}
+
+// InsightBreakdownResult is the value for one combination of dimension values
+type InsightBreakdownResult struct {
+	DimensionValues []string `json:"dimension_values"`
+	Value           int      `json:"value"`
 }
 
 // Paging represents pagination information for navigating through result sets.
@@ -360,6 +368,9 @@ type PublishingLimits struct {
 	DeleteConfig             QuotaConfig `json:"delete_config"`
 	LocationSearchQuotaUsage int         `json:"location_search_quota_usage"`
 	LocationSearchConfig     QuotaConfig `json:"location_search_config"`
+
+	// FetchedAt is when the limits were retrieved; ResetsAt is relative to it.
+	FetchedAt time.Time `json:"fetched_at,omitempty"`
 }
 
 // QuotaConfig represents quota configuration for a specific operation type.
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RateLimitBucket identifies which platform rate limit a request counted against
+type RateLimitBucket string
+
+const (
+	// RateLimitBucketApp is the app-wide limit shared by every user of the app
+	RateLimitBucketApp RateLimitBucket = "app"
+	// RateLimitBucketUser is the per-user (business use case) limit
+	RateLimitBucketUser RateLimitBucket = "user"
+	// RateLimitBucketPublish is the per-user content publishing limit
+	RateLimitBucketPublish RateLimitBucket = "publish"
+)
+
+// Platform error codes that identify the exhausted bucket when usage headers are missing
+const (
+	errorCodeAppRateLimit  = 4
+	errorCodeUserRateLimit = 17
+)
+
+// BucketUsage is the usage reported for one rate limit bucket. Usage values
+// are percentages of the bucket's allowance.
+type BucketUsage struct {
+	Bucket       RateLimitBucket `json:"bucket"`
+	CallCount    int             `json:"call_count"`
+	TotalCPUTime int             `json:"total_cputime"`
+	TotalTime    int             `json:"total_time"`
+	// RegainAccessIn is the platform's estimate of when access returns, zero if not reported
+	RegainAccessIn time.Duration `json:"regain_access_in,omitempty"`
+}
+
+// Percent returns the highest usage percentage across call count, CPU time and total time
+func (u BucketUsage) Percent() int {
+	return max(u.CallCount, u.TotalCPUTime, u.TotalTime)
+}
+
+// Exhausted reports whether the bucket has no allowance left
+func (u BucketUsage) Exhausted() bool {
+	return u.Percent() >= 100 || u.RegainAccessIn > 0
+}
+
+// usageHeader is the JSON shape shared by X-App-Usage and the entries of
+// X-Business-Use-Case-Usage
+type usageHeader struct {
+	Type                        string `json:"type"`
+	CallCount                   int    `json:"call_count"`
+	TotalCPUTime                int    `json:"total_cputime"`
+	TotalTime                   int    `json:"total_time"`
+	EstimatedTimeToRegainAccess int    `json:"estimated_time_to_regain_access"`
+}
+
+func (u usageHeader) toBucketUsage(bucket RateLimitBucket) BucketUsage {
+	return BucketUsage{
+		Bucket:         bucket,
+		CallCount:      u.CallCount,
+		TotalCPUTime:   u.TotalCPUTime,
+		TotalTime:      u.TotalTime,
+		RegainAccessIn: regainAccessIn(u.EstimatedTimeToRegainAccess),
+	}
+}
+
+// regainAccessIn converts the platform's estimate in minutes, ignoring
+// negative values and capping ones too large for a time.Duration
+func regainAccessIn(minutes int) time.Duration {
+	switch {
+	case minutes <= 0:
+		return 0
+	case int64(minutes) > math.MaxInt64/int64(time.Minute):
+		return math.MaxInt64
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// parseUsageHeaders extracts bucket usage from the X-App-Usage and
+// X-Business-Use-Case-Usage headers. Malformed headers are ignored.
+func parseUsageHeaders(headers http.Header) []BucketUsage {
+	var usages []BucketUsage
+
+	if raw := headers.Get("X-App-Usage"); raw != "" {
+		var app usageHeader
+		if err := json.Unmarshal([]byte(raw), &app); err == nil {
+			usages = append(usages, app.toBucketUsage(RateLimitBucketApp))
+		}
+	}
+
+	if raw := headers.Get("X-Business-Use-Case-Usage"); raw != "" {
+		// Keyed by business or user ID, each with one entry per use case type
+		var business map[string][]usageHeader
+		if err := json.Unmarshal([]byte(raw), &business); err == nil {
+			for _, entries := range business {
+				for _, entry := range entries {
+					bucket := RateLimitBucketUser
+					if strings.Contains(strings.ToLower(entry.Type), "publish") {
+						bucket = RateLimitBucketPublish
+					}
+					usages = append(usages, entry.toBucketUsage(bucket))
+				}
+			}
+		}
+	}
+
+	return usages
+}
+
+// exhaustedBucket picks the bucket most likely responsible for a 429. An
+// exhausted bucket wins over one that is merely busy, and among those the
+// one with the longest wait or highest usage wins. If the headers say
+// nothing, the platform error code is used.
+func exhaustedBucket(usages []BucketUsage, errorCode int) (BucketUsage, bool) {
+	var (
+		best  BucketUsage
+		found bool
+	)
+	for _, u := range usages {
+		if !found || usageOutranks(u, best) {
+			best, found = u, true
+		}
+	}
+	if found && (best.Percent() > 0 || best.RegainAccessIn > 0) {
+		return best, true
+	}
+
+	switch errorCode {
+	case errorCodeAppRateLimit:
+		return BucketUsage{Bucket: RateLimitBucketApp}, true
+	case errorCodeUserRateLimit:
+		return BucketUsage{Bucket: RateLimitBucketUser}, true
+	}
+	return BucketUsage{}, false
+}
+
+func usageOutranks(a, b BucketUsage) bool {
+	if a.Exhausted() != b.Exhausted() {
+		return a.Exhausted()
+	}
+	if a.RegainAccessIn != b.RegainAccessIn {
+		return a.RegainAccessIn > b.RegainAccessIn
+	}
+	return a.Percent() > b.Percent()
+}
+
+// rateLimitErrorFromResponse builds a RateLimitError for a 429 response. The
+// standard rate limit headers give the retry delay and reset time; the usage
+// headers add which bucket ran out and, for per-user buckets, the platform's
+// estimate of when access returns.
+func rateLimitErrorFromResponse(resp *Response, code int, message, details string) *RateLimitError {
+	var retryAfter time.Duration
+	var resetAt time.Time
+	if resp.RateLimit != nil {
+		retryAfter = resp.RateLimit.RetryAfter
+		resetAt = resp.RateLimit.Reset
+	}
+
+	var usages []BucketUsage
+	if resp.Response != nil {
+		usages = parseUsageHeaders(resp.Header)
+	}
+
+	var bucket RateLimitBucket
+	if usage, ok := exhaustedBucket(usages, code); ok {
+		bucket = usage.Bucket
+		if usage.RegainAccessIn > 0 {
+			if estimated := time.Now().Add(usage.RegainAccessIn); estimated.After(resetAt) {
+				resetAt = estimated
+			}
+			if retryAfter == 0 {
+				retryAfter = usage.RegainAccessIn
+			}
+		}
+	}
+
+	err := NewRateLimitError(code, message, details, retryAfter)
+	err.Bucket = bucket
+	err.ResetAt = resetAt
+	return err
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseUsageHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("X-App-Usage", `{"call_count":12,"total_cputime":3,"total_time":7}`)
+	headers.Set("X-Business-Use-Case-Usage", `{"12345":[{"type":"threads","call_count":100,"total_cputime":20,"total_time":40,"estimated_time_to_regain_access":15},{"type":"threads_publish","call_count":50,"total_cputime":0,"total_time":0,"estimated_time_to_regain_access":0}]}`)
+
+	usages := parseUsageHeaders(headers)
+	if len(usages) != 3 {
+		t.Fatalf("expected 3 bucket usages, got %d: %+v", len(usages), usages)
+	}
+
+	byBucket := make(map[RateLimitBucket]BucketUsage)
+	for _, u := range usages {
+		byBucket[u.Bucket] = u
+	}
+	if got := byBucket[RateLimitBucketApp].Percent(); got != 12 {
+		t.Errorf("expected app usage 12%%, got %d%%", got)
+	}
+	user := byBucket[RateLimitBucketUser]
+	if !user.Exhausted() || user.RegainAccessIn != 15*time.Minute {
+		t.Errorf("expected exhausted user bucket regaining access in 15m, got %+v", user)
+	}
+	if got := byBucket[RateLimitBucketPublish].Percent(); got != 50 {
+		t.Errorf("expected publish usage 50%%, got %d%%", got)
+	}
+}
+
+func TestParseUsageHeaders_IgnoresMalformed(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("X-App-Usage", "not json")
+	headers.Set("X-Business-Use-Case-Usage", `["wrong shape"]`)
+
+	if usages := parseUsageHeaders(headers); len(usages) != 0 {
+		t.Errorf("expected no usages from malformed headers, got %+v", usages)
+	}
+}
+
+func FuzzParseUsageHeaders(f *testing.F) {
+	f.Add(`{"call_count":12,"total_cputime":3,"total_time":7}`,
+		`{"12345":[{"type":"threads","call_count":100,"estimated_time_to_regain_access":15}]}`)
+	f.Add("not json", `["wrong shape"]`)
+	f.Add(`{"estimated_time_to_regain_access":9223372036854775807}`, `{"1":[{"estimated_time_to_regain_access":-5}]}`)
+
+	f.Fuzz(func(t *testing.T, app, business string) {
+		headers := http.Header{}
+		headers.Set("X-App-Usage", app)
+		headers.Set("X-Business-Use-Case-Usage", business)
+
+		for _, u := range parseUsageHeaders(headers) {
+			if u.RegainAccessIn < 0 {
+				t.Errorf("RegainAccessIn = %v, want non-negative", u.RegainAccessIn)
+			}
+		}
+	})
+}
+
+func TestExhaustedBucket(t *testing.T) {
+	tests := []struct {
+		name      string
+		usages    []BucketUsage
+		code      int
+		want      RateLimitBucket
+		wantFound bool
+	}{
+		{
+			name: "exhausted bucket wins over busier one",
+			usages: []BucketUsage{
+				{Bucket: RateLimitBucketApp, CallCount: 95},
+				{Bucket: RateLimitBucketPublish, CallCount: 40, RegainAccessIn: time.Hour},
+			},
+			want:      RateLimitBucketPublish,
+			wantFound: true,
+		},
+		{
+			name: "highest usage wins",
+			usages: []BucketUsage{
+				{Bucket: RateLimitBucketUser, TotalTime: 30},
+				{Bucket: RateLimitBucketApp, TotalCPUTime: 100},
+			},
+			want:      RateLimitBucketApp,
+			wantFound: true,
+		},
+		{"app error code", nil, errorCodeAppRateLimit, RateLimitBucketApp, true},
+		{"user error code", []BucketUsage{{Bucket: RateLimitBucketApp}}, errorCodeUserRateLimit, RateLimitBucketUser, true},
+		{"unknown", nil, 429, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := exhaustedBucket(tt.usages, tt.code)
+			if found != tt.wantFound || got.Bucket != tt.want {
+				t.Errorf("exhaustedBucket() = %q, %v; want %q, %v", got.Bucket, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestCreateErrorFromResponse_RateLimitUsage(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Business-Use-Case-Usage", `{"12345":[{"type":"threads","call_count":100,"total_cputime":10,"total_time":10,"estimated_time_to_regain_access":30}]}`)
+	resp := &Response{
+		Response:   &http.Response{StatusCode: 429, Header: header},
+		Body:       []byte(`{"error":{"message":"User request limit reached","type":"OAuthException","code":17}}`),
+		StatusCode: 429,
+	}
+
+	h := &HTTPClient{rateLimiter: NewRateLimiter(&RateLimiterConfig{})}
+	err := h.createErrorFromResponse(resp)
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("expected *RateLimitError, got %T", err)
+	}
+	if rateLimitErr.Bucket != RateLimitBucketUser {
+		t.Errorf("expected user bucket, got %q", rateLimitErr.Bucket)
+	}
+	if rateLimitErr.RetryAfter != 30*time.Minute {
+		t.Errorf("expected RetryAfter of 30m from the usage estimate, got %v", rateLimitErr.RetryAfter)
+	}
+	if until := time.Until(rateLimitErr.ResetAt); until < 29*time.Minute || until > 30*time.Minute {
+		t.Errorf("expected ResetAt about 30m from now, got %v", rateLimitErr.ResetAt)
+	}
+	if got := RetryAfter(err); got != 30*time.Minute {
+		t.Errorf("expected RetryAfter helper to return 30m, got %v", got)
+	}
+}
+
+func TestCreateErrorFromResponse_RateLimitErrorCodeOnly(t *testing.T) {
+	resp := &Response{
+		Response:   &http.Response{StatusCode: 429, Header: http.Header{}},
+		Body:       []byte(`{"error":{"message":"Application request limit reached","code":4}}`),
+		StatusCode: 429,
+		RateLimit:  &RateLimitInfo{RetryAfter: time.Minute},
+	}
+
+	err := (&HTTPClient{}).createErrorFromResponse(resp)
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("expected *RateLimitError, got %T", err)
+	}
+	if rateLimitErr.Bucket != RateLimitBucketApp {
+		t.Errorf("expected app bucket, got %q", rateLimitErr.Bucket)
+	}
+	if rateLimitErr.RetryAfter != time.Minute {
+		t.Errorf("expected Retry-After to be kept, got %v", rateLimitErr.RetryAfter)
+	}
+}
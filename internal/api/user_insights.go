@@ -0,0 +1,237 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// InsightsOptions selects the account insights GetUserInsights fetches
+type InsightsOptions struct {
+	// Metrics defaults to views, likes, replies and reposts
+	Metrics []AccountInsightMetric `json:"metrics,omitempty"`
+	// Period defaults to lifetime; day also returns day-by-day values
+	Period InsightPeriod `json:"period,omitempty"`
+	Since  *time.Time    `json:"since,omitempty"`
+	Until  *time.Time    `json:"until,omitempty"`
+	// Breakdowns of follower_demographics to fetch, one request each. They
+	// imply that metric, which in turn defaults to every breakdown.
+	Breakdowns []FollowerDemographicsBreakdown `json:"breakdowns,omitempty"`
+}
+
+// UserInsights is the typed result of GetUserInsights
+type UserInsights struct {
+	Period InsightPeriod `json:"period"`
+	Since  *time.Time    `json:"since,omitempty"`
+	Until  *time.Time    `json:"until,omitempty"`
+	// Metrics holds every requested metric but follower_demographics
+	Metrics []UserInsightMetric `json:"metrics"`
+	// Demographics holds one entry per requested breakdown
+	Demographics []FollowerDemographics `json:"demographics,omitempty"`
+}
+
+// UserInsightMetric is one account metric
+type UserInsightMetric struct {
+	Name  AccountInsightMetric `json:"name"`
+	Title string               `json:"title,omitempty"`
+	// Total is the value over the whole range
+	Total int `json:"total"`
+	// Daily holds one value per day, for metrics the API returns as a series
+	Daily []Value `json:"daily,omitempty"`
+}
+
+// FollowerDemographics is the follower count per value of one breakdown,
+// largest first
+type FollowerDemographics struct {
+	Breakdown FollowerDemographicsBreakdown `json:"breakdown"`
+	Values    []DemographicValue            `json:"values"`
+}
+
+// DemographicValue is the number of followers with one value of a breakdown,
+// e.g. a country code or an age range
+type DemographicValue struct {
+	Key   string `json:"key"`
+	Value int    `json:"value"`
+}
+
+// Metric returns the metric with name, or nil if it was not requested
+func (u *UserInsights) Metric(name AccountInsightMetric) *UserInsightMetric {
+	for i := range u.Metrics {
+		if u.Metrics[i].Name == name {
+			return &u.Metrics[i]
+		}
+	}
+	return nil
+}
+
+// Validate checks the options against the metric registry without making
+// a request: every metric must support the period and time range, every
+// breakdown must exist, and the range must start after MinInsightTimestamp
+// and before it ends.
+func (o *InsightsOptions) Validate() error {
+	_, err := o.Requests()
+	return err
+}
+
+// Requests validates the options and splits them into the account insights
+// calls that fetch them: one for the plain metrics and one per demographics
+// breakdown
+func (o *InsightsOptions) Requests() ([]*AccountInsightsOptions, error) {
+	if o == nil {
+		o = &InsightsOptions{}
+	}
+
+	period := cmp.Or(o.Period, InsightPeriodLifetime)
+	if !slices.Contains(allInsightPeriods, period) {
+		return nil, NewValidationError(400, "Invalid insight period",
+			fmt.Sprintf("period '%s' is not supported", period), "period")
+	}
+
+	for _, t := range []struct {
+		name string
+		at   *time.Time
+	}{{"since", o.Since}, {"until", o.Until}} {
+		if t.at != nil && t.at.Unix() < MinInsightTimestamp {
+			return nil, NewValidationError(400, fmt.Sprintf("Invalid %s timestamp", t.name),
+				fmt.Sprintf("%s timestamp must be >= %d", t.name, MinInsightTimestamp), t.name)
+		}
+	}
+	if o.Since != nil && o.Until != nil && o.Since.After(*o.Until) {
+		return nil, NewValidationError(400, "Invalid date range", "since date cannot be after until date", "since")
+	}
+	hasTimeRange := o.Since != nil || o.Until != nil
+
+	metrics := o.Metrics
+	if len(metrics) == 0 {
+		metrics = []AccountInsightMetric{AccountInsightViews, AccountInsightLikes, AccountInsightReplies, AccountInsightReposts}
+		if len(o.Breakdowns) > 0 {
+			metrics = []AccountInsightMetric{AccountInsightFollowerDemographics}
+		}
+	}
+	var plain []AccountInsightMetric
+	demographics := len(o.Breakdowns) > 0
+	for _, metric := range metrics {
+		if metric == AccountInsightFollowerDemographics {
+			demographics = true
+		} else if !slices.Contains(plain, metric) {
+			plain = append(plain, metric)
+		}
+	}
+
+	var requests []*AccountInsightsOptions
+	if len(plain) > 0 {
+		if err := ValidateAccountInsightRequest(plain, period, "", hasTimeRange); err != nil {
+			return nil, err
+		}
+		requests = append(requests, &AccountInsightsOptions{Metrics: plain, Period: period, Since: o.Since, Until: o.Until})
+	}
+	if demographics {
+		spec := accountInsightMetricSpecs[AccountInsightFollowerDemographics]
+		breakdowns := o.Breakdowns
+		if len(breakdowns) == 0 {
+			breakdowns = spec.Breakdowns
+		}
+		var seen []FollowerDemographicsBreakdown
+		for _, breakdown := range breakdowns {
+			if slices.Contains(seen, breakdown) {
+				continue
+			}
+			seen = append(seen, breakdown)
+			if !spec.SupportsBreakdown(breakdown) {
+				return nil, NewValidationError(400, "Invalid breakdown parameter",
+					fmt.Sprintf("breakdown '%s' is not supported. Valid values: country, city, age, gender", breakdown), "breakdown")
+			}
+			demo := []AccountInsightMetric{AccountInsightFollowerDemographics}
+			if err := ValidateAccountInsightRequest(demo, period, string(breakdown), hasTimeRange); err != nil {
+				return nil, err
+			}
+			requests = append(requests, &AccountInsightsOptions{Metrics: demo, Period: period, Breakdown: string(breakdown)})
+		}
+	}
+	return requests, nil
+}
+
+// GetUserInsights retrieves account insights as typed values, fetching each
+// requested follower demographics breakdown with its own request. The
+// options are validated before any request is made.
+func (c *Client) GetUserInsights(ctx context.Context, userID UserID, opts *InsightsOptions) (*UserInsights, error) {
+	if !userID.Valid() {
+		return nil, NewValidationError(400, ErrEmptyUserID, "userID cannot be empty", "userID")
+	}
+	requests, err := opts.Requests()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*InsightsResponse, len(requests))
+	for i, req := range requests {
+		if responses[i], err = c.GetAccountInsightsWithOptions(ctx, userID, req); err != nil {
+			return nil, err
+		}
+	}
+	return NewUserInsights(opts, responses...), nil
+}
+
+// NewUserInsights builds typed insights from the responses to the
+// Requests of opts, for callers that make them on their own
+func NewUserInsights(opts *InsightsOptions, responses ...*InsightsResponse) *UserInsights {
+	if opts == nil {
+		opts = &InsightsOptions{}
+	}
+	period := cmp.Or(opts.Period, InsightPeriodLifetime)
+	insights := &UserInsights{Period: period, Since: opts.Since, Until: opts.Until, Metrics: []UserInsightMetric{}}
+
+	for _, response := range responses {
+		if response == nil {
+			continue
+		}
+		for _, insight := range response.Data {
+			if insight.Name == string(AccountInsightFollowerDemographics) {
+				insights.Demographics = append(insights.Demographics, followerDemographics(insight)...)
+				continue
+			}
+			metric := UserInsightMetric{Name: AccountInsightMetric(insight.Name), Title: insight.Title, Daily: insight.Values}
+			if insight.TotalValue != nil {
+				metric.Total = insight.TotalValue.Value
+			} else {
+				for _, v := range insight.Values {
+					metric.Total += v.Value
+				}
+			}
+			// A lifetime series of one value is just the total
+			if period != InsightPeriodDay && len(metric.Daily) <= 1 {
+				metric.Daily = nil
+			}
+			insights.Metrics = append(insights.Metrics, metric)
+		}
+	}
+	return insights
+}
+
+// followerDemographics reads the breakdowns of a follower_demographics insight
+func followerDemographics(insight Insight) []FollowerDemographics {
+	if insight.TotalValue == nil {
+		return nil
+	}
+	var all []FollowerDemographics
+	for _, breakdown := range insight.TotalValue.Breakdowns {
+		if len(breakdown.DimensionKeys) == 0 {
+			continue
+		}
+		demo := FollowerDemographics{
+			Breakdown: FollowerDemographicsBreakdown(breakdown.DimensionKeys[0]),
+			Values:    make([]DemographicValue, 0, len(breakdown.Results)),
+		}
+		for _, result := range breakdown.Results {
+			if len(result.DimensionValues) == 0 {
+				continue
+			}
+			demo.Values = append(demo.Values, DemographicValue{Key: result.DimensionValues[0], Value: result.Value})
+		}
+		slices.SortStableFunc(demo.Values, func(a, b DemographicValue) int { return cmp.Compare(b.Value, a.Value) })
+		all = append(all, demo)
+	}
+	return all
+}
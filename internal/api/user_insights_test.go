@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInsightsOptions_Requests(t *testing.T) {
+	since := time.Unix(MinInsightTimestamp+86400, 0)
+	_, err := (&InsightsOptions{
+		Metrics:    []AccountInsightMetric{AccountInsightViews, AccountInsightFollowerDemographics},
+		Since:      &since,
+		Breakdowns: []FollowerDemographicsBreakdown{BreakdownAge, BreakdownAge, BreakdownCountry},
+	}).Requests()
+	if err == nil {
+		t.Fatal("expected demographics with a time range to be rejected")
+	}
+
+	requests, err := (&InsightsOptions{
+		Metrics:    []AccountInsightMetric{AccountInsightViews, AccountInsightFollowerDemographics},
+		Breakdowns: []FollowerDemographicsBreakdown{BreakdownAge, BreakdownAge, BreakdownCountry},
+	}).Requests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("expected one request for views and one per breakdown, got %d", len(requests))
+	}
+	if requests[0].Period != InsightPeriodLifetime || requests[0].Breakdown != "" || requests[1].Breakdown != "age" || requests[2].Breakdown != "country" {
+		t.Errorf("unexpected requests: %+v %+v %+v", requests[0], requests[1], requests[2])
+	}
+
+	// The metric alone fetches every breakdown
+	requests, err = (&InsightsOptions{Metrics: []AccountInsightMetric{AccountInsightFollowerDemographics}}).Requests()
+	if err != nil || len(requests) != 4 {
+		t.Errorf("expected four breakdowns, got %d, %v", len(requests), err)
+	}
+}
+
+func TestInsightsOptions_Validate(t *testing.T) {
+	early := time.Unix(MinInsightTimestamp-1, 0)
+	later := time.Unix(MinInsightTimestamp+3600, 0)
+	earlier := time.Unix(MinInsightTimestamp+60, 0)
+	tests := map[string]*InsightsOptions{
+		"period":          {Period: "week"},
+		"metric":          {Metrics: []AccountInsightMetric{"impressions"}},
+		"followers daily": {Metrics: []AccountInsightMetric{AccountInsightFollowersCount}, Period: InsightPeriodDay},
+		"breakdown":       {Breakdowns: []FollowerDemographicsBreakdown{"language"}},
+		"since too early": {Since: &early},
+		"reversed range":  {Since: &later, Until: &earlier},
+	}
+	for name, opts := range tests {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if err := (*InsightsOptions)(nil).Validate(); err != nil {
+		t.Errorf("expected the defaults to be valid, got %v", err)
+	}
+}
+
+func TestGetUserInsights(t *testing.T) {
+	client, server := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("breakdown") {
+		case "":
+			w.Write([]byte(`{"data": [
+				{"name": "views", "period": "day", "values": [{"value": 10, "end_time": "2025-06-01T07:00:00+0000"}, {"value": 5, "end_time": "2025-06-02T07:00:00+0000"}]},
+				{"name": "likes", "period": "day", "total_value": {"value": 3}}
+			]}`)) //nolint:errcheck,gosec // Test server
+		case "country":
+			w.Write([]byte(`{"data": [{"name": "follower_demographics", "period": "lifetime", "total_value": {"breakdowns": [
+				{"dimension_keys": ["country"], "results": [{"dimension_values": ["DE"], "value": 2}, {"dimension_values": ["US"], "value": 7}]}
+			]}}]}`)) //nolint:errcheck,gosec // Test server
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	})
+	defer server.Close()
+
+	// Demographics are lifetime only, so ask for them separately from the daily metrics
+	daily, err := client.GetUserInsights(context.Background(), "12345", &InsightsOptions{
+		Metrics: []AccountInsightMetric{AccountInsightViews, AccountInsightLikes},
+		Period:  InsightPeriodDay,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	views, likes := daily.Metric(AccountInsightViews), daily.Metric(AccountInsightLikes)
+	if views == nil || views.Total != 15 || len(views.Daily) != 2 {
+		t.Errorf("expected views summed from the series, got %+v", views)
+	}
+	if likes == nil || likes.Total != 3 || likes.Daily != nil {
+		t.Errorf("expected likes from the total, got %+v", likes)
+	}
+
+	demo, err := client.GetUserInsights(context.Background(), "12345", &InsightsOptions{
+		Breakdowns: []FollowerDemographicsBreakdown{BreakdownCountry},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(demo.Metrics) != 0 || len(demo.Demographics) != 1 {
+		t.Fatalf("expected only demographics, got %+v", demo)
+	}
+	countries := demo.Demographics[0]
+	if countries.Breakdown != BreakdownCountry || len(countries.Values) != 2 || countries.Values[0] != (DemographicValue{Key: "US", Value: 7}) {
+		t.Errorf("expected countries largest first, got %+v", countries)
+	}
+
+	if _, err := client.GetUserInsights(context.Background(), "", nil); err == nil || !strings.Contains(err.Error(), "userID") {
+		t.Errorf("expected an empty user ID to be rejected, got %v", err)
+	}
+}
@@ -20,12 +20,12 @@ func (c *Client) GetUser(ctx context.Context, userID UserID) (*User, error) {
 
 	// Build query parameters for user fields based on Threads API documentation
 	params := url.Values{
-		"fields": {UserProfileFields},
+		"fields": {endpointUser.Fields},
 	}
 
 	// Make API call to get user
-	path := fmt.Sprintf("/%s", userID.String())
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointUser.path(userID.String())
+	resp, err := c.httpClient.cachedGET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -128,8 +128,8 @@ func (c *Client) GetUserFields(ctx context.Context, userID UserID, fields []stri
 	}
 
 	// Make API call to get user
-	path := fmt.Sprintf("/%s", userID.String())
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointUser.path(userID.String())
+	resp, err := c.httpClient.cachedGET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -195,8 +195,8 @@ func (c *Client) LookupPublicProfile(ctx context.Context, username string) (*Pub
 	}
 
 	// Make API call to lookup public profile
-	path := "/profile_lookup"
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointProfileLookup.path()
+	resp, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -236,7 +236,7 @@ func (c *Client) GetPublicProfilePosts(ctx context.Context, username string, opt
 	// Build query parameters with enhanced fields from API documentation
 	params := url.Values{
 		"username": {username},
-		"fields":   {PostExtendedFields},
+		"fields":   {endpointProfilePosts.Fields},
 	}
 
 	// Add pagination and filtering options if provided
@@ -262,8 +262,8 @@ func (c *Client) GetPublicProfilePosts(ctx context.Context, username string, opt
 	}
 
 	// Make API call to get public profile posts
-	path := "/profile_posts"
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointProfilePosts.path()
+	resp, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -299,7 +299,7 @@ func (c *Client) GetUserReplies(ctx context.Context, userID UserID, opts *PostsO
 
 	// Build query parameters with reply-specific fields from API documentation
 	params := url.Values{
-		"fields": {ReplyFields},
+		"fields": {endpointUserReplies.Fields},
 	}
 
 	// Add pagination and filtering options if provided
@@ -325,8 +325,8 @@ func (c *Client) GetUserReplies(ctx context.Context, userID UserID, opts *PostsO
 	}
 
 	// Make API call to get user replies
-	path := fmt.Sprintf("/%s/replies", userID.String())
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	path := endpointUserReplies.path(userID.String())
+	resp, err := c.httpClient.GET(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/salmonumbrella/threads-cli/internal/httpx"
+)
+
+// WebhookSignatureHeader is the request header carrying the signature of a
+// webhook delivery
+const WebhookSignatureHeader = httpx.SignatureHeader
+
+// ErrInvalidWebhookSignature is returned by VerifyWebhookSignature for a
+// delivery that was not signed with the app secret
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// VerifyWebhookSignature checks that header, the X-Hub-Signature-256 value
+// of a delivery, is "sha256=" and the hex HMAC-SHA256 of body keyed with
+// appSecret. Verify the raw body before parsing it with ParseWebhookEvent.
+func VerifyWebhookSignature(body []byte, header, appSecret string) error {
+	if appSecret == "" {
+		return fmt.Errorf("%w: no app secret to verify with", ErrInvalidWebhookSignature)
+	}
+	if !strings.HasPrefix(header, "sha256=") {
+		return fmt.Errorf("%w: expected a sha256= signature", ErrInvalidWebhookSignature)
+	}
+	if !httpx.ValidSignature(appSecret, body, header) {
+		return ErrInvalidWebhookSignature
+	}
+	return nil
+}
+
+// WebhookEvent is a webhook delivery. Of Mention, Reply and Publish, the
+// one matching Field is set by ParseWebhookEvent; other fields, such as
+// deletes, leave all three nil and keep their value in Values.Value.
+type WebhookEvent struct {
+	AppID          string             `json:"app_id"`
+	Topic          string             `json:"topic"`
+	TargetID       string             `json:"target_id"`
+	Time           int64              `json:"time"`
+	SubscriptionID string             `json:"subscription_id"`
+	HasUIDField    bool               `json:"has_uid_field"`
+	Values         WebhookEventValues `json:"values"`
+
+	Mention *WebhookMention `json:"-"`
+	Reply   *WebhookReply   `json:"-"`
+	Publish *WebhookPublish `json:"-"`
+}
+
+// WebhookEventValues names the event of a delivery and holds its payload
+type WebhookEventValues struct {
+	Field WebhookEventType `json:"field"`
+	Value json.RawMessage  `json:"value"`
+}
+
+// WebhookMedia is the post a mentions, replies or publishes event is about
+type WebhookMedia struct {
+	ID        string `json:"id"`
+	Username  string `json:"username,omitempty"`
+	Text      string `json:"text,omitempty"`
+	MediaType string `json:"media_type,omitempty"`
+	MediaURL  string `json:"media_url,omitempty"`
+	Permalink string `json:"permalink,omitempty"`
+	Shortcode string `json:"shortcode,omitempty"`
+	Timestamp Time   `json:"timestamp"`
+}
+
+// Post returns the media as a Post, with the fields a delivery carries
+func (m WebhookMedia) Post() Post {
+	return Post{
+		ID:        m.ID,
+		Username:  m.Username,
+		Text:      m.Text,
+		MediaType: m.MediaType,
+		MediaURL:  m.MediaURL,
+		Permalink: m.Permalink,
+		Shortcode: m.Shortcode,
+		Timestamp: m.Timestamp,
+	}
+}
+
+// WebhookMention is a post that mentions the account
+type WebhookMention struct {
+	WebhookMedia
+}
+
+// WebhookReply is a reply to a post of the account
+type WebhookReply struct {
+	WebhookMedia
+	// RepliedTo is the post replied to, and RootPost the top of the thread
+	RepliedTo *WebhookPostRef `json:"replied_to,omitempty"`
+	RootPost  *WebhookPostRef `json:"root_post,omitempty"`
+}
+
+// Post returns the reply as a Post, linked to the post it replies to
+func (r WebhookReply) Post() Post {
+	post := r.WebhookMedia.Post()
+	post.IsReply = true
+	if r.RepliedTo != nil {
+		post.ReplyTo = r.RepliedTo.ID
+		post.RepliedTo = &Post{ID: r.RepliedTo.ID, Username: r.RepliedTo.Username}
+	}
+	if r.RootPost != nil {
+		post.RootPost = &Post{ID: r.RootPost.ID, Username: r.RootPost.Username}
+	}
+	return post
+}
+
+// WebhookPublish is a post the account published
+type WebhookPublish struct {
+	WebhookMedia
+}
+
+// WebhookPostRef points at a post in a replies event
+type WebhookPostRef struct {
+	ID       string `json:"id"`
+	OwnerID  string `json:"owner_id,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// ParseWebhookEvent decodes the body of a webhook delivery and its typed
+// payload. An event type it does not know is not an error.
+func ParseWebhookEvent(body []byte) (*WebhookEvent, error) {
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("invalid webhook event: %w", err)
+	}
+	if event.Values.Field == "" {
+		return nil, errors.New("invalid webhook event: no values.field")
+	}
+
+	var payload any
+	switch event.Values.Field {
+	case WebhookEventMentions:
+		event.Mention = &WebhookMention{}
+		payload = event.Mention
+	case WebhookEventReplies:
+		event.Reply = &WebhookReply{}
+		payload = event.Reply
+	case WebhookEventPublishes:
+		event.Publish = &WebhookPublish{}
+		payload = event.Publish
+	default:
+		return &event, nil
+	}
+	if len(event.Values.Value) == 0 {
+		return nil, fmt.Errorf("invalid %s event: no values.value", event.Values.Field)
+	}
+	if err := json.Unmarshal(event.Values.Value, payload); err != nil {
+		return nil, fmt.Errorf("invalid %s event: %w", event.Values.Field, err)
+	}
+	return &event, nil
+}
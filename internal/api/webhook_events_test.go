@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/httpx"
+)
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"values":{"field":"mentions"}}`)
+	header := httpx.Sign("app-secret", body)
+
+	if err := VerifyWebhookSignature(body, header, "app-secret"); err != nil {
+		t.Fatalf("expected a valid signature, got %v", err)
+	}
+	for name, tc := range map[string]struct {
+		body           []byte
+		header, secret string
+	}{
+		"wrong secret":  {body, header, "other"},
+		"changed body":  {[]byte(`{"values":{"field":"publishes"}}`), header, "app-secret"},
+		"sha1":          {body, "sha1=abc", "app-secret"},
+		"not hex":       {body, "sha256=zz", "app-secret"},
+		"missing":       {body, "", "app-secret"},
+		"no app secret": {body, header, ""},
+	} {
+		if err := VerifyWebhookSignature(tc.body, tc.header, tc.secret); !errors.Is(err, ErrInvalidWebhookSignature) {
+			t.Errorf("%s: expected ErrInvalidWebhookSignature, got %v", name, err)
+		}
+	}
+}
+
+func TestParseWebhookEvent(t *testing.T) {
+	event, err := ParseWebhookEvent([]byte(`{
+		"app_id": "123", "topic": "moderate", "target_id": "456", "time": 1723226877,
+		"subscription_id": "789", "has_uid_field": false,
+		"values": {"field": "replies", "value": {
+			"id": "1811", "username": "ana", "text": "Nice!", "media_type": "TEXT_POST",
+			"permalink": "https://www.threads.net/@ana/post/abc", "shortcode": "abc",
+			"timestamp": "2024-08-09T18:07:57+0000",
+			"replied_to": {"id": "1700"}, "root_post": {"id": "1600", "owner_id": "456", "username": "me"}
+		}}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Values.Field != WebhookEventReplies || event.Reply == nil || event.Mention != nil || event.Publish != nil {
+		t.Fatalf("expected only a reply, got %+v", event)
+	}
+	if event.TargetID != "456" || event.Time != 1723226877 {
+		t.Errorf("unexpected envelope: %+v", event)
+	}
+	post := event.Reply.Post()
+	if post.ID != "1811" || post.Username != "ana" || !post.IsReply || post.ReplyTo != "1700" || post.RootPost.Username != "me" {
+		t.Errorf("unexpected reply post: %+v", post)
+	}
+	if post.Timestamp.Unix() != 1723226877 {
+		t.Errorf("expected the timestamp parsed, got %v", post.Timestamp)
+	}
+
+	event, err = ParseWebhookEvent([]byte(`{"values":{"field":"mentions","value":{"id":"1","username":"bo","text":"hi @me","timestamp":"2024-08-09T18:07:57+0000"}}}`))
+	if err != nil || event.Mention == nil || event.Mention.Post().Text != "hi @me" {
+		t.Errorf("expected a mention, got %+v, %v", event, err)
+	}
+	event, err = ParseWebhookEvent([]byte(`{"values":{"field":"publishes","value":{"id":"2","timestamp":"2024-08-09T18:07:57+0000"}}}`))
+	if err != nil || event.Publish == nil || event.Publish.ID != "2" {
+		t.Errorf("expected a publish, got %+v, %v", event, err)
+	}
+
+	// Other fields keep their raw value
+	event, err = ParseWebhookEvent([]byte(`{"values":{"field":"deletes","value":{"id":"3"}}}`))
+	if err != nil || event.Mention != nil || event.Reply != nil || event.Publish != nil || string(event.Values.Value) != `{"id":"3"}` {
+		t.Errorf("expected an untyped delete, got %+v, %v", event, err)
+	}
+
+	for _, bad := range []string{`not json`, `{"values":{}}`, `{"values":{"field":"mentions"}}`, `{"values":{"field":"mentions","value":"x"}}`} {
+		if _, err := ParseWebhookEvent([]byte(bad)); err == nil {
+			t.Errorf("expected %s rejected", bad)
+		}
+	}
+}
+
+func FuzzParseWebhookEvent(f *testing.F) {
+	for _, seed := range []string{
+		`{"values":{"field":"mentions","value":{"id":"1","username":"ana","text":"@me hi"}}}`,
+		`{"values":{"field":"replies","value":{"id":"2","replied_to":{"id":"1"},"root_post":{"id":"1"}}}}`,
+		`{"values":{"field":"publishes","value":{"id":"3","timestamp":"2024-08-09T18:07:57+0000"}}}`,
+		`{"values":{"field":"delete","value":{"id":"4"}}}`,
+		`{"values":{"field":"replies"}}`,
+		`{"values":{"field":"mentions","value":"text"}}`,
+		`{"values":{}}`,
+		`[]`,
+		``,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		event, err := ParseWebhookEvent(body)
+		if err != nil {
+			if event != nil {
+				t.Errorf("ParseWebhookEvent(%q) returned an event with error %v", body, err)
+			}
+			return
+		}
+		if event.Values.Field == "" {
+			t.Errorf("ParseWebhookEvent(%q) accepted an event without a field", body)
+		}
+		set := map[WebhookEventType]bool{
+			WebhookEventMentions:  event.Mention != nil,
+			WebhookEventReplies:   event.Reply != nil,
+			WebhookEventPublishes: event.Publish != nil,
+		}
+		for field, ok := range set {
+			if ok != (field == event.Values.Field) {
+				t.Errorf("ParseWebhookEvent(%q): payload for %s set = %v with field %q", body, field, ok, event.Values.Field)
+			}
+		}
+	})
+}
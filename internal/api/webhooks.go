@@ -3,7 +3,6 @@ package api
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/url"
 )
 
@@ -13,6 +12,8 @@ type WebhookEventType string
 const (
 	// WebhookEventMentions triggers when someone mentions you in a post
 	WebhookEventMentions WebhookEventType = "mentions"
+	// WebhookEventReplies triggers when someone replies to your posts
+	WebhookEventReplies WebhookEventType = "replies"
 	// WebhookEventPublishes triggers when you publish a post
 	WebhookEventPublishes WebhookEventType = "publishes"
 	// WebhookEventDeletes triggers when a post is deleted
@@ -89,25 +90,23 @@ func (c *Client) SubscribeWebhook(ctx context.Context, opts *WebhookSubscribeOpt
 	}
 
 	// Get the app ID from the config (client ID is the app ID in Meta's API)
-	appID := c.config.ClientID
+	appID := c.currentConfig().ClientID
+	token := c.getAccessTokenSafe()
 
 	// Build form data for the POST request
 	formData := url.Values{}
 	formData.Set("object", "user")
 	formData.Set("callback_url", opts.CallbackURL)
 	formData.Set("fields", fields)
-	formData.Set("access_token", c.accessToken)
+	formData.Set("access_token", token)
 	if opts.VerifyToken != "" {
 		formData.Set("verify_token", opts.VerifyToken)
 	}
 
-	c.mu.RLock()
-	token := c.accessToken
-	c.mu.RUnlock()
-
 	// POST to /{app-id}/subscriptions
 	resp, err := c.httpClient.POST(
-		fmt.Sprintf("/v1.0/%s/subscriptions", appID),
+		ctx,
+		endpointSubscribe.path(appID),
 		formData,
 		token,
 	)
@@ -145,7 +144,7 @@ func (c *Client) SubscribeWebhook(ctx context.Context, opts *WebhookSubscribeOpt
 // ListWebhookSubscriptions retrieves all webhook subscriptions for the authenticated user's app.
 func (c *Client) ListWebhookSubscriptions(ctx context.Context) (*WebhookSubscriptionsResponse, error) {
 	// Get the app ID from the config
-	appID := c.config.ClientID
+	appID := c.currentConfig().ClientID
 
 	c.mu.RLock()
 	token := c.accessToken
@@ -156,7 +155,8 @@ func (c *Client) ListWebhookSubscriptions(ctx context.Context) (*WebhookSubscrip
 
 	// GET /{app-id}/subscriptions
 	resp, err := c.httpClient.GET(
-		fmt.Sprintf("/v1.0/%s/subscriptions", appID),
+		ctx,
+		endpointSubscriptions.path(appID),
 		params,
 		token,
 	)
@@ -186,7 +186,7 @@ func (c *Client) DeleteWebhookSubscription(ctx context.Context, subscriptionID s
 	}
 
 	// Get the app ID from the config
-	appID := c.config.ClientID
+	appID := c.currentConfig().ClientID
 
 	c.mu.RLock()
 	token := c.accessToken
@@ -199,7 +199,7 @@ func (c *Client) DeleteWebhookSubscription(ctx context.Context, subscriptionID s
 
 	resp, err := c.httpClient.Do(&RequestOptions{
 		Method:      "DELETE",
-		Path:        fmt.Sprintf("/v1.0/%s/subscriptions", appID),
+		Path:        endpointUnsubscribe.path(appID),
 		QueryParams: queryParams,
 	}, token)
 	if err != nil {
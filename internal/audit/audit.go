@@ -0,0 +1,86 @@
+// Package audit keeps an append-only log of the actions the CLI took on
+// behalf of the user, such as publishing, deleting, and changing credentials.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileName is the name of the audit log in the data directory.
+const FileName = "audit.jsonl"
+
+// Results of an audited action.
+const (
+	ResultOK    = "ok"
+	ResultError = "error"
+)
+
+// Entry is one audited action.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Account string    `json:"account,omitempty"`
+	// Command is the full command path, e.g. "threads posts delete"
+	Command string `json:"command"`
+	// Args are the positional arguments and set flags, with secrets redacted
+	Args   []string `json:"args,omitempty"`
+	Result string   `json:"result"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// Append adds e to the log at path as one JSON line. The file is only ever
+// opened for appending and is readable by the user alone.
+func Append(path string, e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600) //nolint:gosec // Path comes from the data directory
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Read returns the entries in the log at path recorded at or after since, in
+// the order they were written. A missing log has no entries.
+func Read(path string, since time.Time) ([]Entry, error) {
+	file, err := os.Open(path) //nolint:gosec // Path comes from the data directory
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() //nolint:errcheck // Read-only
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for n := 1; scanner.Scan(); n++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", path, n, err)
+		}
+		if !e.Time.Before(since) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
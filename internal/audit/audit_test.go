@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", FileName)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	entries := []Entry{
+		{Time: now.Add(-10 * 24 * time.Hour), Account: "work", Command: "threads posts create", Result: ResultOK},
+		{Time: now.Add(-time.Hour), Account: "work", Command: "threads posts delete", Args: []string{"123"}, Result: ResultOK},
+		{Time: now, Account: "personal", Command: "threads replies hide", Result: ResultError, Error: "boom"},
+	}
+	for _, e := range entries {
+		if err := Append(path, e); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Windows has no permission bits to check
+	if perm := info.Mode().Perm(); perm != 0o600 && runtime.GOOS != "windows" {
+		t.Errorf("log mode = %v, want 0600", perm)
+	}
+
+	got, err := Read(path, now.Add(-7*24*time.Hour))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(got) != 2 || got[0].Command != "threads posts delete" || got[1].Error != "boom" {
+		t.Errorf("unexpected entries: %+v", got)
+	}
+}
+
+func TestRead_Missing(t *testing.T) {
+	entries, err := Read(filepath.Join(t.TempDir(), FileName), time.Time{})
+	if err != nil || entries != nil {
+		t.Errorf("Read of a missing log = %v, %v; want nil, nil", entries, err)
+	}
+}
+
+func TestRead_Malformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("{\"command\":\"ok\"}\nnot json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(path, time.Time{}); err == nil {
+		t.Error("expected an error for a malformed line")
+	}
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// StartManual runs the OAuth flow without a browser or a callback server,
+// for machines reached over SSH: it writes the authorization URL to out, to
+// be opened in a browser anywhere, and reads from in the URL the browser was
+// redirected to, or the code in it. The redirect does not need to load.
+func (s *OAuthServer) StartManual(ctx context.Context, in io.Reader, out io.Writer) (*OAuthResult, error) {
+	fmt.Fprintf(out, "Open this URL in a browser on any machine and authorize the app:\n\n%s\n\n", s.buildAuthURL()) //nolint:errcheck // Best-effort output
+	fmt.Fprintf(out, "The browser is then sent to %s, which may fail to load.\n", s.redirectURI)                     //nolint:errcheck // Best-effort output
+	fmt.Fprint(out, "Paste the URL from its address bar, or the code in it: ")                                       //nolint:errcheck // Best-effort output
+
+	lines := make(chan string, 1)
+	errs := make(chan error, 1)
+	go func() {
+		line, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || strings.TrimSpace(line) == "") {
+			errs <- fmt.Errorf("no authorization code given: %w", err)
+			return
+		}
+		lines <- line
+	}()
+
+	var line string
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case err := <-errs:
+		return nil, err
+	case line = <-lines:
+	}
+
+	code, err := s.parseRedirect(line)
+	if err != nil {
+		return nil, err
+	}
+	return s.exchangeCodeForToken(ctx, code)
+}
+
+// parseRedirect returns the authorization code in input, the URL a browser
+// was redirected to or the code alone. A URL must carry the state of this
+// flow, like a request to the callback server.
+func (s *OAuthServer) parseRedirect(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("missing authorization code")
+	}
+	if !strings.Contains(input, "?") {
+		// Threads ends codes with #_, which is easily copied along
+		return strings.TrimSuffix(input, "#_"), nil
+	}
+
+	u, err := url.Parse(input)
+	if err != nil {
+		return "", fmt.Errorf("invalid redirect URL: %w", err)
+	}
+	query := u.Query()
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(s.csrfToken)) != 1 {
+		return "", fmt.Errorf("CSRF validation failed: the URL is not from this login")
+	}
+	if errCode := query.Get("error"); errCode != "" {
+		return "", fmt.Errorf("authorization denied: %s - %s", errCode, query.Get("error_description"))
+	}
+	code := query.Get("code")
+	if code == "" {
+		return "", fmt.Errorf("missing authorization code")
+	}
+	return strings.TrimSuffix(code, "#_"), nil
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseRedirect(t *testing.T) {
+	server := NewOAuthServer("client-id", "secret", "http://127.0.0.1:8585/callback", []string{"threads_basic"})
+	state := server.csrfToken
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{name: "redirect URL", input: "http://127.0.0.1:8585/callback?code=abc&state=" + state + "#_\n", want: "abc"},
+		{name: "code alone", input: "  abc#_\n", want: "abc"},
+		{name: "other login", input: "http://127.0.0.1:8585/callback?code=abc&state=other", wantErr: "CSRF"},
+		{name: "denied", input: "http://127.0.0.1:8585/callback?error=access_denied&error_description=Denied&state=" + state, wantErr: "authorization denied"},
+		{name: "no code", input: "http://127.0.0.1:8585/callback?state=" + state, wantErr: "missing authorization code"},
+		{name: "empty", input: "\n", wantErr: "missing authorization code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := server.parseRedirect(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %q, %v", tt.wantErr, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartManual_PrintsURLAndChecksInput(t *testing.T) {
+	server := NewOAuthServer("client-id", "secret", "http://127.0.0.1:8585/callback", []string{"threads_basic"})
+	var out bytes.Buffer
+
+	_, err := server.StartManual(context.Background(), strings.NewReader("http://127.0.0.1:8585/callback?code=abc&state=forged\n"), &out)
+	if err == nil || !strings.Contains(err.Error(), "CSRF") {
+		t.Errorf("expected a forged redirect refused, got %v", err)
+	}
+	if !strings.Contains(out.String(), server.buildAuthURL()) {
+		t.Errorf("expected the authorization URL printed, got %q", out.String())
+	}
+
+	if _, err := server.StartManual(context.Background(), strings.NewReader(""), &out); err == nil || !strings.Contains(err.Error(), "no authorization code") {
+		t.Errorf("expected an error without input, got %v", err)
+	}
+}
+
+func TestStartManual_ContextCancellation(t *testing.T) {
+	server := NewOAuthServer("client-id", "secret", "http://127.0.0.1:8585/callback", []string{"threads_basic"})
+	in, w := io.Pipe()
+	defer w.Close() //nolint:errcheck // Ends the read
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := server.StartManual(ctx, in, io.Discard); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the wait to end with the context, got %v", err)
+	}
+}
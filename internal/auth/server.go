@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"os/exec"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -20,6 +21,21 @@ import (
 	"github.com/salmonumbrella/threads-cli/internal/api"
 )
 
+// The callback server listens on DefaultCallbackHost and
+// DefaultCallbackPort unless told otherwise, and receives the redirect at
+// CallbackPath
+const (
+	DefaultCallbackHost = "127.0.0.1"
+	DefaultCallbackPort = 8585
+	CallbackPath        = "/callback"
+)
+
+// CallbackURI returns the redirect URI served by a callback server on host
+// and port
+func CallbackURI(host string, port int) string {
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(port)) + CallbackPath
+}
+
 // OAuthResult contains the result of OAuth authentication
 type OAuthResult struct {
 	AccessToken string
@@ -34,6 +50,8 @@ type OAuthServer struct {
 	clientSecret string
 	redirectURI  string
 	scopes       []string
+	callbackHost string
+	callbackPort int
 	result       chan *OAuthResult
 	errChan      chan error
 	shutdown     chan struct{}
@@ -61,9 +79,46 @@ func NewOAuthServer(clientID, clientSecret, redirectURI string, scopes []string)
 	}
 }
 
+// SetCallback sets the host and port the callback server is meant to
+// listen on, which Validate checks against the redirect URI
+func (s *OAuthServer) SetCallback(host string, port int) {
+	s.callbackHost = host
+	s.callbackPort = port
+}
+
+// Validate reports whether the callback server can receive the redirect to
+// the redirect URI, which must also be registered with the app: a plain
+// http URL with a port, at the host and port set by SetCallback if any, and
+// a path other than those of the pages the server shows.
+func (s *OAuthServer) Validate() error {
+	u, err := url.Parse(s.redirectURI)
+	if err != nil {
+		return fmt.Errorf("invalid redirect URI: %w", err)
+	}
+	if u.Scheme != "http" || u.Hostname() == "" {
+		return fmt.Errorf("invalid redirect URI %s: the callback server only serves http://HOST:PORT URLs", s.redirectURI)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil || port < 0 || port > 65535 {
+		return fmt.Errorf("invalid redirect URI %s: bad port %q", s.redirectURI, u.Port())
+	}
+	if u.Path == "" || u.Path == "/" || u.Path == "/success" {
+		return fmt.Errorf("invalid redirect URI %s: the callback needs its own path, such as %s", s.redirectURI, CallbackPath)
+	}
+	if s.callbackHost != "" && !strings.EqualFold(u.Hostname(), s.callbackHost) {
+		return fmt.Errorf("redirect URI %s does not match the callback host %s", s.redirectURI, s.callbackHost)
+	}
+	if s.callbackPort != 0 && port != s.callbackPort {
+		return fmt.Errorf("redirect URI %s does not match the callback port %d", s.redirectURI, s.callbackPort)
+	}
+	return nil
+}
+
 // Start starts the OAuth server and opens the browser
 func (s *OAuthServer) Start(ctx context.Context) (*OAuthResult, error) {
-	// Parse redirect URI to get port
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
 	u, err := url.Parse(s.redirectURI)
 	if err != nil {
 		return nil, fmt.Errorf("invalid redirect URI: %w", err)
@@ -76,18 +131,23 @@ func (s *OAuthServer) Start(ctx context.Context) (*OAuthResult, error) {
 	}
 	defer listener.Close() //nolint:errcheck // Best-effort cleanup
 
-	port := listener.Addr().(*net.TCPAddr).Port
-	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
-
 	// Update redirect URI with actual port if using dynamic port
-	if u.Port() == "0" || u.Port() == "" {
-		s.redirectURI = baseURL + u.Path
+	if u.Port() == "0" {
+		port := listener.Addr().(*net.TCPAddr).Port
+		s.redirectURI = "http://" + net.JoinHostPort(u.Hostname(), strconv.Itoa(port)) + u.Path
 	}
 
 	// Create HTTP server
 	mux := http.NewServeMux()
-	mux.HandleFunc("/", s.handleRoot)
-	mux.HandleFunc("/callback", s.handleCallback)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// The path comes from the redirect URI, so it is matched as is
+		// rather than as a pattern
+		if r.URL.Path == u.Path {
+			s.handleCallback(w, r)
+			return
+		}
+		s.handleRoot(w, r)
+	})
 	mux.HandleFunc("/success", s.handleSuccess)
 
 	server := &http.Server{
@@ -108,7 +168,7 @@ func (s *OAuthServer) Start(ctx context.Context) (*OAuthResult, error) {
 
 	// Open browser
 	go func() {
-		if err := openBrowser(authURL); err != nil {
+		if err := OpenBrowser(authURL); err != nil {
 			slog.Info("failed to open browser, please navigate manually", "url", authURL)
 			fmt.Printf("\nOpen this URL in your browser:\n%s\n\n", authURL)
 		}
@@ -187,7 +247,7 @@ func (s *OAuthServer) handleCallback(w http.ResponseWriter, r *http.Request) {
 
 	// Exchange code for token
 	go func() {
-		result, err := s.exchangeCodeForToken(code)
+		result, err := s.exchangeCodeForToken(context.Background(), code)
 		if err != nil {
 			s.errChan <- err
 			return
@@ -199,7 +259,7 @@ func (s *OAuthServer) handleCallback(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/success", http.StatusTemporaryRedirect)
 }
 
-func (s *OAuthServer) exchangeCodeForToken(code string) (*OAuthResult, error) {
+func (s *OAuthServer) exchangeCodeForToken(ctx context.Context, code string) (*OAuthResult, error) {
 	config := &api.Config{
 		ClientID:     s.clientID,
 		ClientSecret: s.clientSecret,
@@ -207,12 +267,12 @@ func (s *OAuthServer) exchangeCodeForToken(code string) (*OAuthResult, error) {
 		Scopes:       s.scopes,
 	}
 
-	client, err := api.NewClient(config)
+	client, err := api.NewClient(config) //nolint:staticcheck // New needs a token, which the code exchange has yet to get
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	// Exchange code for token
@@ -251,7 +311,8 @@ func (s *OAuthServer) handleSuccess(w http.ResponseWriter, r *http.Request) {
 	tmpl.Execute(w, nil)
 }
 
-func openBrowser(url string) error {
+// OpenBrowser opens url in the default browser.
+func OpenBrowser(url string) error {
 	var cmd *exec.Cmd
 
 	switch runtime.GOOS {
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -329,6 +330,94 @@ func TestStart_InvalidRedirectURI(t *testing.T) {
 	}
 }
 
+func TestCallbackURI(t *testing.T) {
+	if got := CallbackURI(DefaultCallbackHost, DefaultCallbackPort); got != "http://127.0.0.1:8585/callback" {
+		t.Errorf("unexpected default callback URI %q", got)
+	}
+	if got := CallbackURI("::1", 9000); got != "http://[::1]:9000/callback" {
+		t.Errorf("expected an IPv6 host bracketed, got %q", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		redirectURI string
+		host        string
+		port        int
+		wantErr     string
+	}{
+		{name: "default", redirectURI: "http://127.0.0.1:8585/callback"},
+		{name: "matching callback", redirectURI: "http://localhost:9000/oauth", host: "LOCALHOST", port: 9000},
+		{name: "https", redirectURI: "https://127.0.0.1:8585/callback", wantErr: "only serves http"},
+		{name: "no port", redirectURI: "http://127.0.0.1/callback", wantErr: "bad port"},
+		{name: "no path", redirectURI: "http://127.0.0.1:8585", wantErr: "its own path"},
+		{name: "success page", redirectURI: "http://127.0.0.1:8585/success", wantErr: "its own path"},
+		{name: "other host", redirectURI: "http://127.0.0.1:8585/callback", host: "0.0.0.0", wantErr: "callback host 0.0.0.0"},
+		{name: "other port", redirectURI: "http://127.0.0.1:8585/callback", port: 9000, wantErr: "callback port 9000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewOAuthServer("client-id", "secret", tt.redirectURI, []string{"basic"})
+			server.SetCallback(tt.host, tt.port)
+			err := server.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStart_ServesRedirectPath(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close() //nolint:errcheck,gosec // Only reserved a free port
+
+	redirectURI := fmt.Sprintf("http://127.0.0.1:%d/oauth/done", port)
+	server := NewOAuthServer("client-id", "secret", redirectURI, []string{"basic"})
+	server.SetCallback("127.0.0.1", port)
+
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := server.Start(context.Background())
+		errChan <- err
+	}()
+
+	var resp *http.Response
+	for range 50 {
+		resp, err = http.Get(redirectURI + "?state=wrong&code=abc") //nolint:gosec,noctx // Local test server
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close() //nolint:errcheck,gosec // Test response
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected the callback to check the state at the redirect path, got status %d", resp.StatusCode)
+	}
+
+	select {
+	case err := <-errChan:
+		if err == nil || !strings.Contains(err.Error(), "CSRF") {
+			t.Errorf("expected CSRF error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("timeout waiting for the server to stop")
+	}
+}
+
 func TestStart_ContextCancellation(t *testing.T) {
 	server := NewOAuthServer("client-id", "secret", "http://127.0.0.1:0/callback", []string{"basic"})
 
@@ -475,9 +564,9 @@ func TestOAuthResult_Fields(t *testing.T) {
 }
 
 func TestOpenBrowser_ValidURL(t *testing.T) {
-	// This test verifies openBrowser doesn't panic with a valid URL
+	// This test verifies OpenBrowser doesn't panic with a valid URL
 	// We don't actually want to open a browser, so we use a URL that won't cause issues
-	err := openBrowser("https://example.com")
+	err := OpenBrowser("https://example.com")
 	// On CI environments without a display, this may return an error
 	// We just verify it doesn't panic
 	_ = err
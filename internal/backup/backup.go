@@ -0,0 +1,214 @@
+// Package backup reads and writes archives of the local state of the CLI,
+// a gzip- or zstd-compressed tar file, optionally encrypted with a
+// passphrase.
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// maxFileSize bounds a file read from an archive, so a damaged or hostile
+// archive cannot exhaust memory
+const maxFileSize = 256 << 20
+
+// Encryption parameters. The key is derived from the passphrase with
+// PBKDF2-SHA256 and seals the archive with AES-256-GCM.
+const (
+	magic      = "THREADS-BACKUP\x00\x01"
+	saltSize   = 16
+	iterations = 600_000
+)
+
+// zstdMagic starts every zstd frame
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// ErrPassphrase is returned by Decrypt for a wrong passphrase or an
+// archive changed after it was encrypted.
+var ErrPassphrase = errors.New("wrong passphrase or damaged archive")
+
+// File is a file in an archive
+type File struct {
+	// Name is the slash-separated path of the file in the archive
+	Name    string
+	Mode    fs.FileMode
+	ModTime time.Time
+	Data    []byte
+}
+
+// Compression is how the tar stream of an archive is compressed
+type Compression int
+
+const (
+	Gzip Compression = iota
+	Zstd
+)
+
+// CompressionFor returns the compression of an archive named name: zstd
+// for names ending in .zst or .tzst, gzip otherwise.
+func CompressionFor(name string) Compression {
+	if strings.HasSuffix(name, ".zst") || strings.HasSuffix(name, ".tzst") {
+		return Zstd
+	}
+	return Gzip
+}
+
+// Write writes files to w as a tar archive compressed with c
+func Write(w io.Writer, files []File, c Compression) error {
+	var zw io.WriteCloser = gzip.NewWriter(w)
+	if c == Zstd {
+		enc, err := zstd.NewWriter(w)
+		if err != nil {
+			return err
+		}
+		zw = enc
+	}
+	tw := tar.NewWriter(zw)
+	for _, file := range files {
+		hdr := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     file.Name,
+			Mode:     int64(file.Mode.Perm()),
+			ModTime:  file.ModTime,
+			Size:     int64(len(file.Data)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(file.Data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// Read reads the regular files of a tar archive from r, telling gzip from
+// zstd compression by its magic number. Names that could escape the
+// directory the archive is restored to, such as absolute paths or paths
+// with "..", are refused.
+func Read(r io.Reader) ([]File, error) {
+	br := bufio.NewReader(r)
+	var zr io.Reader
+	if head, _ := br.Peek(len(zstdMagic)); bytes.Equal(head, zstdMagic) {
+		dec, err := zstd.NewReader(br, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("not a backup archive: %w", err)
+		}
+		defer dec.Close()
+		zr = dec
+	} else {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("not a backup archive: %w", err)
+		}
+		zr = gz
+	}
+	tr := tar.NewReader(zr)
+	var files []File
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("damaged backup archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if !fs.ValidPath(hdr.Name) || path.Clean(hdr.Name) != hdr.Name {
+			return nil, fmt.Errorf("backup archive holds an unsafe path: %s", hdr.Name)
+		}
+		if hdr.Size > maxFileSize {
+			return nil, fmt.Errorf("backup archive holds an oversized file: %s", hdr.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("damaged backup archive: %w", err)
+		}
+		files = append(files, File{Name: hdr.Name, Mode: fs.FileMode(hdr.Mode).Perm(), ModTime: hdr.ModTime, Data: data})
+	}
+}
+
+// IsEncrypted reports whether data was written by Encrypt
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(magic))
+}
+
+// Encrypt seals data with a key derived from passphrase
+func Encrypt(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(magic)+saltSize+len(nonce)+len(data)+aead.Overhead())
+	out = append(out, magic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	// The header is authenticated along with the data
+	return aead.Seal(out, nonce, data, out), nil
+}
+
+// Decrypt opens data sealed by Encrypt with passphrase
+func Decrypt(data []byte, passphrase string) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return nil, errors.New("not an encrypted backup")
+	}
+	if len(data) < len(magic)+saltSize {
+		return nil, ErrPassphrase
+	}
+	salt := data[len(magic) : len(magic)+saltSize]
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	headerSize := len(magic) + saltSize + aead.NonceSize()
+	if len(data) < headerSize {
+		return nil, ErrPassphrase
+	}
+	plain, err := aead.Open(nil, data[len(magic)+saltSize:headerSize], data[headerSize:], data[:headerSize])
+	if err != nil {
+		return nil, ErrPassphrase
+	}
+	return plain, nil
+}
+
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,105 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	modTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	files := []File{
+		{Name: "config/config.json", Mode: 0o600, ModTime: modTime, Data: []byte(`{"output":"json"}`)},
+		{Name: "data/queue.json", Mode: 0o644, ModTime: modTime, Data: []byte(`[]`)},
+	}
+
+	for _, c := range []Compression{Gzip, Zstd} {
+		var buf bytes.Buffer
+		if err := Write(&buf, files, c); err != nil {
+			t.Fatal(err)
+		}
+		if c == Zstd && !bytes.HasPrefix(buf.Bytes(), zstdMagic) {
+			t.Error("expected a zstd-compressed archive")
+		}
+		got, err := Read(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("expected 2 files, got %+v", got)
+		}
+		for i, file := range got {
+			want := files[i]
+			if file.Name != want.Name || file.Mode != want.Mode || !file.ModTime.Equal(want.ModTime) || string(file.Data) != string(want.Data) {
+				t.Errorf("compression %d, file %d: got %+v, want %+v", c, i, file, want)
+			}
+		}
+	}
+}
+
+func TestCompressionFor(t *testing.T) {
+	for name, want := range map[string]Compression{
+		"backup.tar.zst": Zstd,
+		"backup.tzst":    Zstd,
+		"backup.tar.gz":  Gzip,
+		"-":              Gzip,
+	} {
+		if got := CompressionFor(name); got != want {
+			t.Errorf("CompressionFor(%q) = %d, want %d", name, got, want)
+		}
+	}
+}
+
+func TestRead_RefusesUnsafePaths(t *testing.T) {
+	for _, name := range []string{"../etc/passwd", "/etc/passwd", "config/../../x", "config//x"} {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0o600}); err != nil {
+			t.Fatal(err)
+		}
+		tw.Close() //nolint:errcheck,gosec // In-memory
+		gz.Close() //nolint:errcheck,gosec // In-memory
+
+		if _, err := Read(&buf); err == nil || !strings.Contains(err.Error(), "unsafe path") {
+			t.Errorf("%s: expected an unsafe path refused, got %v", name, err)
+		}
+	}
+
+	if _, err := Read(strings.NewReader("plain text")); err == nil {
+		t.Error("expected a file that is not an archive refused")
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	data := []byte("archive")
+	sealed, err := Encrypt(data, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(sealed) || IsEncrypted(data) {
+		t.Error("expected only the sealed data recognized as encrypted")
+	}
+	if bytes.Contains(sealed, data) {
+		t.Error("expected the data hidden")
+	}
+
+	got, err := Decrypt(sealed, "correct horse")
+	if err != nil || string(got) != "archive" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if _, err := Decrypt(sealed, "wrong"); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("expected a wrong passphrase refused, got %v", err)
+	}
+	sealed[len(sealed)-1] ^= 1
+	if _, err := Decrypt(sealed, "correct horse"); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("expected a changed archive refused, got %v", err)
+	}
+	if _, err := Decrypt(sealed[:len(magic)+3], "correct horse"); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("expected a truncated archive refused, got %v", err)
+	}
+}
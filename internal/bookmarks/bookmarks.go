@@ -0,0 +1,97 @@
+// Package bookmarks keeps posts saved to read later, each with a snapshot
+// of the post taken when it was saved, so bookmarks survive the post being
+// deleted and can be searched offline.
+package bookmarks
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// FileName is the name of the bookmark store in the data directory.
+const FileName = "bookmarks.json"
+
+// schema and version label the bookmark store file
+const (
+	schema  = "bookmarks"
+	version = 1
+)
+
+// Bookmark is a saved post.
+type Bookmark struct {
+	ID      string    `json:"id"`
+	AddedAt time.Time `json:"added_at"`
+	// Post is the post as it was when last fetched
+	Post api.Post `json:"post"`
+}
+
+// Load returns the bookmarks in the store at path, oldest first. A missing
+// store has no bookmarks.
+func Load(path string) ([]Bookmark, error) {
+	var bookmarks []Bookmark
+	err := statefile.ReadJSON(path, schema, version, &bookmarks)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bookmarks, nil
+}
+
+// Save replaces the store at path with bookmarks. The store is replaced
+// atomically, so a crash leaves either the old or the new one, and is
+// readable by the user alone.
+func Save(path string, bookmarks []Bookmark) error {
+	if bookmarks == nil {
+		bookmarks = []Bookmark{}
+	}
+	return statefile.WriteJSON(path, schema, version, bookmarks)
+}
+
+// Put adds b to bookmarks, or replaces the bookmark with the same ID while
+// keeping when it was first added. It reports whether b was new.
+func Put(bookmarks []Bookmark, b Bookmark) ([]Bookmark, bool) {
+	i := slices.IndexFunc(bookmarks, func(existing Bookmark) bool { return existing.ID == b.ID })
+	if i < 0 {
+		return append(bookmarks, b), true
+	}
+	b.AddedAt = bookmarks[i].AddedAt
+	bookmarks[i] = b
+	return bookmarks, false
+}
+
+// Remove deletes the bookmark with id and reports whether there was one
+func Remove(bookmarks []Bookmark, id string) ([]Bookmark, bool) {
+	i := slices.IndexFunc(bookmarks, func(b Bookmark) bool { return b.ID == id })
+	if i < 0 {
+		return bookmarks, false
+	}
+	return slices.Delete(bookmarks, i, i+1), true
+}
+
+// Matches reports whether the snapshot of b contains query, ignoring case,
+// in its text, author, alt text or topic, or in the post it quotes or
+// reposts
+func (b *Bookmark) Matches(query string) bool {
+	query = strings.ToLower(query)
+	var match func(p *api.Post) bool
+	match = func(p *api.Post) bool {
+		if p == nil {
+			return false
+		}
+		for _, s := range []string{p.Text, p.Username, p.AltText, p.TopicTag} {
+			if strings.Contains(strings.ToLower(s), query) {
+				return true
+			}
+		}
+		return match(p.QuotedPost) || match(p.RepostedPost)
+	}
+	return match(&b.Post)
+}
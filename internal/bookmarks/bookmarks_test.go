@@ -0,0 +1,87 @@
+package bookmarks
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+func TestLoad_Missing(t *testing.T) {
+	saved, err := Load(filepath.Join(t.TempDir(), FileName))
+	if err != nil || saved != nil {
+		t.Errorf("expected no bookmarks, got %v, %v", saved, err)
+	}
+}
+
+func TestSave_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", FileName)
+	added := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	want := []Bookmark{{ID: "1", AddedAt: added, Post: api.Post{ID: "1", Text: "hello"}}}
+
+	if err := Save(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "1" || !got[0].AddedAt.Equal(added) || got[0].Post.Text != "hello" {
+		t.Errorf("unexpected bookmarks: %+v", got)
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0o600 {
+			t.Errorf("expected mode 0600, got %v", info.Mode().Perm())
+		}
+	}
+}
+
+func TestPut_KeepsAddedAt(t *testing.T) {
+	first := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	saved, added := Put(nil, Bookmark{ID: "1", AddedAt: first, Post: api.Post{Text: "old"}})
+	if !added {
+		t.Error("expected a new bookmark")
+	}
+
+	saved, added = Put(saved, Bookmark{ID: "1", AddedAt: first.Add(time.Hour), Post: api.Post{Text: "new"}})
+	if added || len(saved) != 1 {
+		t.Fatalf("expected the bookmark to be replaced, got %+v", saved)
+	}
+	if !saved[0].AddedAt.Equal(first) || saved[0].Post.Text != "new" {
+		t.Errorf("expected a new snapshot with the first added time, got %+v", saved[0])
+	}
+
+	saved, removed := Remove(saved, "1")
+	if !removed || len(saved) != 0 {
+		t.Errorf("expected the bookmark to be removed, got %+v", saved)
+	}
+	if _, removed := Remove(saved, "1"); removed {
+		t.Error("expected nothing to remove")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	b := Bookmark{Post: api.Post{
+		Text:       "Check this out",
+		Username:   "gopher",
+		QuotedPost: &api.Post{Text: "Generics in Go 1.18"},
+	}}
+	for query, want := range map[string]bool{
+		"CHECK":    true,
+		"gopher":   true,
+		"generics": true,
+		"rust":     false,
+	} {
+		if got := b.Matches(query); got != want {
+			t.Errorf("Matches(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+// Package card renders a post as a shareable image: avatar, name, handle,
+// text and timestamp, laid out like a post in the Threads app. The Go fonts
+// are embedded, so rendering looks the same on every machine.
+package card
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// DefaultWidth is the card width in pixels when Options.Width is zero.
+const DefaultWidth = 1080
+
+// Post is what a card shows.
+type Post struct {
+	Username string
+	// Name is the display name; the username is used when empty
+	Name string
+	Text string
+	Time time.Time
+	// Avatar is the profile picture; when nil, the initial of the username
+	// is drawn instead
+	Avatar image.Image
+}
+
+// Options control the look of a card.
+type Options struct {
+	// Width in pixels, DefaultWidth when zero. Everything else scales with it.
+	Width int
+	// Dark draws light text on a dark background
+	Dark bool
+}
+
+// theme holds the colors of a card
+type theme struct {
+	background, text, muted, avatar color.Color
+}
+
+var (
+	lightTheme = theme{
+		background: color.White,
+		text:       color.RGBA{0x10, 0x10, 0x10, 0xff},
+		muted:      color.RGBA{0x99, 0x99, 0x99, 0xff},
+		avatar:     color.RGBA{0x4a, 0x4a, 0x4a, 0xff},
+	}
+	darkTheme = theme{
+		background: color.RGBA{0x10, 0x10, 0x10, 0xff},
+		text:       color.RGBA{0xf3, 0xf5, 0xf7, 0xff},
+		muted:      color.RGBA{0x77, 0x77, 0x77, 0xff},
+		avatar:     color.RGBA{0x5c, 0x5c, 0x5c, 0xff},
+	}
+)
+
+// Layout in pixels at DefaultWidth
+const (
+	padding      = 64
+	avatarSize   = 96
+	avatarGap    = 24
+	initialSize  = 48
+	nameSize     = 36
+	handleSize   = 30
+	textSize     = 40
+	lineHeight   = 56
+	timeSize     = 28
+	sectionSpace = 40
+)
+
+var (
+	fontsOnce          sync.Once
+	regular, bold      *opentype.Font
+	errFontsUnparsable error
+)
+
+func loadFonts() error {
+	fontsOnce.Do(func() {
+		var err error
+		if regular, err = opentype.Parse(goregular.TTF); err != nil {
+			errFontsUnparsable = err
+			return
+		}
+		if bold, err = opentype.Parse(gobold.TTF); err != nil {
+			errFontsUnparsable = err
+		}
+	})
+	return errFontsUnparsable
+}
+
+func newFace(f *opentype.Font, size float64) (font.Face, error) {
+	return opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+}
+
+// Render draws the card for p.
+func Render(p Post, opts Options) (image.Image, error) {
+	if err := loadFonts(); err != nil {
+		return nil, fmt.Errorf("load fonts: %w", err)
+	}
+	width := opts.Width
+	if width == 0 {
+		width = DefaultWidth
+	}
+	scale := float64(width) / DefaultWidth
+	px := func(n int) int { return int(float64(n)*scale + 0.5) }
+
+	th := lightTheme
+	if opts.Dark {
+		th = darkTheme
+	}
+
+	faces := make(map[string]font.Face, 5)
+	defer func() {
+		for _, face := range faces {
+			face.Close() //nolint:errcheck,gosec // Nothing to release for opentype faces
+		}
+	}()
+	for _, spec := range []struct {
+		name string
+		font *opentype.Font
+		size int
+	}{
+		{"name", bold, nameSize},
+		{"initial", bold, initialSize},
+		{"handle", regular, handleSize},
+		{"text", regular, textSize},
+		{"time", regular, timeSize},
+	} {
+		face, err := newFace(spec.font, float64(px(spec.size)))
+		if err != nil {
+			return nil, fmt.Errorf("load fonts: %w", err)
+		}
+		faces[spec.name] = face
+	}
+
+	pad := px(padding)
+	lines := wrap(faces["text"], p.Text, width-2*pad)
+	textTop := pad + px(avatarSize) + px(sectionSpace)
+	timeTop := textTop + len(lines)*px(lineHeight) + px(sectionSpace)
+	if len(lines) == 0 {
+		timeTop = textTop
+	}
+	height := timeTop + px(timeSize) + pad
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(th.background), image.Point{}, draw.Src)
+
+	avatar := image.Rect(pad, pad, pad+px(avatarSize), pad+px(avatarSize))
+	drawAvatar(img, avatar, p, th, faces["initial"])
+
+	name := p.Name
+	if name == "" {
+		name = p.Username
+	}
+	headerX := avatar.Max.X + px(avatarGap)
+	drawText(img, faces["name"], th.text, headerX, pad+px(nameSize), name)
+	drawText(img, faces["handle"], th.muted, headerX, pad+px(nameSize)+px(lineHeight)-px(4), "@"+p.Username)
+
+	for i, line := range lines {
+		drawText(img, faces["text"], th.text, pad, textTop+i*px(lineHeight)+px(textSize), line)
+	}
+
+	if !p.Time.IsZero() {
+		drawText(img, faces["time"], th.muted, pad, timeTop+px(timeSize), p.Time.Format("3:04 PM · Jan 2, 2006"))
+	}
+	return img, nil
+}
+
+// WritePNG renders the card for p and writes it as PNG.
+func WritePNG(w io.Writer, p Post, opts Options) error {
+	img, err := Render(p, opts)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+func drawText(dst draw.Image, face font.Face, c color.Color, x, baseline int, s string) {
+	d := &font.Drawer{Dst: dst, Src: image.NewUniform(c), Face: face, Dot: fixed.P(x, baseline)}
+	d.DrawString(s)
+}
+
+// drawAvatar draws the profile picture, or the initial of the username on a
+// plain background, cropped to a circle
+func drawAvatar(dst *image.RGBA, r image.Rectangle, p Post, th theme, initialFace font.Face) {
+	tile := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	if p.Avatar != nil {
+		draw.CatmullRom.Scale(tile, tile.Bounds(), p.Avatar, squareCrop(p.Avatar.Bounds()), draw.Src, nil)
+	} else {
+		draw.Draw(tile, tile.Bounds(), image.NewUniform(th.avatar), image.Point{}, draw.Src)
+		initial := "?"
+		for _, r := range p.Username {
+			initial = string(unicode.ToUpper(r))
+			break
+		}
+		advance := font.MeasureString(initialFace, initial).Round()
+		metrics := initialFace.Metrics()
+		baseline := (r.Dy() + metrics.Ascent.Round() - metrics.Descent.Round()) / 2
+		drawText(tile, initialFace, color.White, (r.Dx()-advance)/2, baseline, initial)
+	}
+	draw.DrawMask(dst, r, tile, image.Point{}, circle{r.Dx()}, image.Point{}, draw.Over)
+}
+
+// squareCrop is the centered square of r, so avatars are not stretched
+func squareCrop(r image.Rectangle) image.Rectangle {
+	side := min(r.Dx(), r.Dy())
+	x := r.Min.X + (r.Dx()-side)/2
+	y := r.Min.Y + (r.Dy()-side)/2
+	return image.Rect(x, y, x+side, y+side)
+}
+
+// circle is an alpha mask of a circle with the given diameter, with
+// antialiased edges
+type circle struct {
+	diameter int
+}
+
+func (c circle) ColorModel() color.Model { return color.AlphaModel }
+
+func (c circle) Bounds() image.Rectangle { return image.Rect(0, 0, c.diameter, c.diameter) }
+
+func (c circle) At(x, y int) color.Color {
+	radius := float64(c.diameter) / 2
+	dx := float64(x) + 0.5 - radius
+	dy := float64(y) + 0.5 - radius
+	// Distance inside the edge, clamped to one pixel of antialiasing
+	inside := radius - math.Hypot(dx, dy)
+	switch {
+	case inside >= 1:
+		return color.Opaque
+	case inside <= 0:
+		return color.Transparent
+	}
+	return color.Alpha{A: uint8(inside * 0xff)}
+}
+
+// wrap breaks text into lines no wider than width, keeping the line breaks
+// of the text and splitting words that are wider than a line. Invalid UTF-8
+// is drawn as U+FFFD.
+func wrap(face font.Face, text string, width int) []string {
+	text = strings.ToValidUTF8(text, "\uFFFD")
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	if text == "" {
+		return nil
+	}
+	fits := func(s string) bool { return font.MeasureString(face, s).Ceil() <= width }
+
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if fits(candidate) {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = ""
+			for _, r := range word {
+				if !fits(line+string(r)) && line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				line += string(r)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,165 @@
+package card
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/image/font"
+)
+
+func TestRender_Size(t *testing.T) {
+	post := Post{
+		Username: "someone",
+		Text:     "Hello from the terminal",
+		Time:     time.Date(2025, 7, 1, 14, 0, 0, 0, time.UTC),
+	}
+
+	short, err := Render(post, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if short.Bounds().Dx() != DefaultWidth {
+		t.Errorf("width = %d, want %d", short.Bounds().Dx(), DefaultWidth)
+	}
+
+	post.Text = strings.Repeat("A longer post that needs to wrap. ", 12)
+	long, err := Render(post, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if long.Bounds().Dy() <= short.Bounds().Dy() {
+		t.Errorf("long text height %d should exceed short text height %d", long.Bounds().Dy(), short.Bounds().Dy())
+	}
+
+	half, err := Render(post, Options{Width: DefaultWidth / 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if half.Bounds().Dx() != DefaultWidth/2 {
+		t.Errorf("width = %d, want %d", half.Bounds().Dx(), DefaultWidth/2)
+	}
+}
+
+func TestRender_Themes(t *testing.T) {
+	post := Post{Username: "someone", Text: "Hi"}
+	for _, tt := range []struct {
+		dark bool
+		want color.Color
+	}{
+		{false, lightTheme.background},
+		{true, darkTheme.background},
+	} {
+		img, err := Render(post, Options{Dark: tt.dark})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sameColor(img.At(1, 1), tt.want) {
+			t.Errorf("dark=%v: background %v, want %v", tt.dark, img.At(1, 1), tt.want)
+		}
+	}
+}
+
+func TestRender_AvatarCroppedToCircle(t *testing.T) {
+	avatar := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	for y := range 100 {
+		for x := range 200 {
+			avatar.Set(x, y, color.RGBA{0xff, 0, 0, 0xff})
+		}
+	}
+	img, err := Render(Post{Username: "someone", Avatar: avatar}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	center := padding + avatarSize/2
+	if r, g, b, _ := img.At(center, center).RGBA(); r>>8 != 0xff || g != 0 || b != 0 {
+		t.Errorf("avatar center = %v, want red", img.At(center, center))
+	}
+	// The corner of the avatar square is outside the circle
+	if !sameColor(img.At(padding+1, padding+1), lightTheme.background) {
+		t.Errorf("avatar corner = %v, want background", img.At(padding+1, padding+1))
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePNG(&buf, Post{Username: "someone", Text: "Hi"}, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := png.Decode(&buf); err != nil {
+		t.Fatalf("not a PNG: %v", err)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	if err := loadFonts(); err != nil {
+		t.Fatal(err)
+	}
+	face, err := newFace(regular, textSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	width := 400
+
+	lines := wrap(face, "first paragraph\n\nthird "+strings.Repeat("x", 80), width)
+	if lines[0] != "first paragraph" || lines[1] != "" || !strings.HasPrefix(lines[2], "third") {
+		t.Errorf("unexpected lines: %q", lines)
+	}
+	if len(lines) < 4 {
+		t.Errorf("expected the long word to be split, got %q", lines)
+	}
+	for _, line := range lines {
+		if w := font.MeasureString(face, line).Ceil(); w > width {
+			t.Errorf("line %q is %dpx wide, max %d", line, w, width)
+		}
+	}
+
+	if got := wrap(face, "  \n ", width); got != nil {
+		t.Errorf("blank text: got %q", got)
+	}
+}
+
+func FuzzWrap(f *testing.F) {
+	f.Add("first paragraph\n\nthird "+strings.Repeat("x", 80), 400)
+	f.Add("emoji 🧵🧵🧵 and CJK 日本語のテキスト", 120)
+	f.Add("\r\n\t\u00a0", 1)
+	f.Add("\xff\xfe invalid", 50)
+	if err := loadFonts(); err != nil {
+		f.Fatal(err)
+	}
+	face, err := newFace(regular, textSize)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Fuzz(func(t *testing.T, text string, width int) {
+		if width < 1 || width > 4096 || len(text) > 2000 {
+			return
+		}
+		lines := wrap(face, text, width)
+
+		// No text is lost: the lines hold the words of the text in order
+		want := strings.Join(strings.Fields(strings.ToValidUTF8(text, "\uFFFD")), "")
+		got := strings.Join(strings.Fields(strings.Join(lines, " ")), "")
+		if got != want {
+			t.Errorf("wrap(%q) lost text: %q", text, lines)
+		}
+		for _, line := range lines {
+			if w := font.MeasureString(face, line).Ceil(); w > width && utf8.RuneCountInString(line) > 1 {
+				t.Errorf("line %q is %dpx wide, max %d", line, w, width)
+			}
+		}
+	})
+}
+
+func sameColor(a, b color.Color) bool {
+	r1, g1, b1, a1 := a.RGBA()
+	r2, g2, b2, a2 := b.RGBA()
+	return r1 == r2 && g1 == g2 && b1 == b2 && a1 == a2
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/timeparse"
+)
+
+type authLabelOptions struct {
+	Note     string
+	Color    string
+	Require  []string
+	Forbid   []string
+	Timezone string
+	Clear    bool
+}
+
+func newAuthLabelCmd(f *Factory) *cobra.Command {
+	opts := &authLabelOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "label [account]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Attach a note, color, and posting rules to an account",
+		Long: `Attach a note and a color to a stored account. The label is shown in
+'threads auth list' and in confirmation prompts, so it is harder to act on
+the wrong account. Without flags, the current label is shown.
+
+--require and --forbid set content rules checked before anything is posted
+from the account: every post must contain each --require text and none of
+the --forbid texts (case-sensitive). Pass an empty value to remove a rule.
+
+--timezone sets the zone for dates and times typed without one, such as
+--since "yesterday 9am", when acting as the account.
+
+Colors: ` + strings.Join(config.AccountColors, ", ") + `
+
+Examples:
+  threads auth label work --color blue --note "Brand account"
+  threads auth label work --require "RELEASE:"
+  threads auth label personal --color green --forbid "RELEASE:"
+  threads auth label work --timezone Europe/Berlin
+  threads auth label work --clear`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAuthLabel(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Note, "note", "", "Short note, e.g. \"Brand account\"")
+	cmd.Flags().StringVar(&opts.Color, "color", "", "Label color: "+strings.Join(config.AccountColors, ", "))
+	cmd.Flags().StringArrayVar(&opts.Require, "require", nil, "Text every post must contain (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.Forbid, "forbid", nil, "Text no post may contain (repeatable)")
+	cmd.Flags().StringVar(&opts.Timezone, "timezone", "", "Timezone for dates and times, e.g. Europe/Berlin")
+	cmd.Flags().BoolVar(&opts.Clear, "clear", false, "Remove the label and rules")
+	for _, name := range []string{"note", "color", "require", "forbid", "timezone"} {
+		cmd.MarkFlagsMutuallyExclusive("clear", name)
+	}
+	return cmd
+}
+
+func runAuthLabel(cmd *cobra.Command, f *Factory, name string, opts *authLabelOptions) error {
+	ctx := cmd.Context()
+
+	if opts.Color != "" && !slices.Contains(config.AccountColors, opts.Color) {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid color: %s", opts.Color),
+			Suggestion: "Valid colors: " + strings.Join(config.AccountColors, ", "),
+		}
+	}
+
+	if opts.Timezone != "" {
+		if _, ok := timeparse.Zone(opts.Timezone); !ok {
+			return &UserFriendlyError{
+				Message:    fmt.Sprintf("Unknown timezone: %s", opts.Timezone),
+				Suggestion: "Use an IANA name such as Europe/Berlin or America/New_York",
+			}
+		}
+	}
+
+	store, err := f.Store()
+	if err != nil {
+		return FormatError(err)
+	}
+	if _, err := store.Get(name); err != nil {
+		return FormatError(err)
+	}
+
+	cfg, err := config.LoadFile(config.ConfigPath())
+	if err != nil {
+		return err
+	}
+
+	settings := cfg.ForAccount(name)
+	flags := cmd.Flags()
+	changed := opts.Clear
+	for _, name := range []string{"note", "color", "require", "forbid", "timezone"} {
+		changed = changed || flags.Changed(name)
+	}
+	switch {
+	case opts.Clear:
+		settings = config.AccountSettings{}
+	default:
+		if flags.Changed("note") {
+			settings.Note = opts.Note
+		}
+		if flags.Changed("color") {
+			settings.Color = opts.Color
+		}
+		if flags.Changed("require") {
+			settings.Require = opts.Require
+		}
+		if flags.Changed("forbid") {
+			settings.Forbid = opts.Forbid
+		}
+		if flags.Changed("timezone") {
+			settings.Timezone = opts.Timezone
+		}
+	}
+
+	if changed {
+		cfg.SetAccountSettings(name, settings)
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+		f.Config = cfg
+		settings = cfg.ForAccount(name)
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
+			"name":     name,
+			"note":     settings.Note,
+			"color":    settings.Color,
+			"require":  settings.Require,
+			"forbid":   settings.Forbid,
+			"timezone": settings.Timezone,
+		})
+	}
+
+	switch {
+	case settings.IsZero():
+		fmt.Fprintf(io.Out, "Account %q has no label\n", name) //nolint:errcheck // Best-effort output
+		return nil
+	case changed:
+		f.UI(ctx).Success("Labeled %s", accountTag(ctx, f, name))
+	default:
+		fmt.Fprintln(io.Out, accountTag(ctx, f, name)) //nolint:errcheck // Best-effort output
+	}
+	for _, want := range settings.Require {
+		fmt.Fprintf(io.Out, "  Requires: %q\n", want) //nolint:errcheck // Best-effort output
+	}
+	for _, bad := range settings.Forbid {
+		fmt.Fprintf(io.Out, "  Forbids:  %q\n", bad) //nolint:errcheck // Best-effort output
+	}
+	if settings.Timezone != "" {
+		fmt.Fprintf(io.Out, "  Timezone: %s\n", settings.Timezone) //nolint:errcheck // Best-effort output
+	}
+	return nil
+}
+
+// accountTag describes an account with its label, e.g. "work (Brand account)",
+// in the label's color
+func accountTag(ctx context.Context, f *Factory, name string) string {
+	settings := f.Config.ForAccount(name)
+	tag := name
+	if settings.Note != "" {
+		tag += " (" + settings.Note + ")"
+	}
+	return colorAccountLabel(ctx, f, settings, tag)
+}
+
+// colorAccountLabel paints s in the account's label color, if any
+func colorAccountLabel(ctx context.Context, f *Factory, settings config.AccountSettings, s string) string {
+	p := f.UI(ctx)
+	if color := p.NamedColor(settings.Color); color != nil {
+		return p.Colorize(s, color)
+	}
+	return s
+}
+
+// accountListLabel is the LABEL column of 'auth list': a colored dot and the note
+func accountListLabel(ctx context.Context, f *Factory, name string) string {
+	settings := f.Config.ForAccount(name)
+	var parts []string
+	if settings.Color != "" {
+		parts = append(parts, colorAccountLabel(ctx, f, settings, "●"))
+	}
+	if settings.Note != "" {
+		parts = append(parts, settings.Note)
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
+
+// checkAccountRules refuses text that breaks the content rules of the account
+// about to post it; see 'threads auth label --require/--forbid'
+func checkAccountRules(f *Factory, text string) error {
+	name := f.currentAccountName()
+	violations := f.Config.ForAccount(name).CheckText(text)
+	if len(violations) == 0 {
+		return nil
+	}
+	return &UserFriendlyError{
+		Message:    fmt.Sprintf("Post text breaks the rules of account %q: it %s", name, strings.Join(violations, " and ")),
+		Suggestion: fmt.Sprintf("Check that you are posting from the right account (--account), or change the rules with 'threads auth label %s'", name),
+	}
+}
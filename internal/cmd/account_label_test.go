@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+func useTempConfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv("THREADS_CONFIG", path)
+	return path
+}
+
+func TestAuthLabel_SavesNoteAndColor(t *testing.T) {
+	path := useTempConfig(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newAuthLabelCmd(f)
+	cmd.SetArgs([]string{"test-user", "--color", "blue", "--note", "Brand account"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := config.AccountSettings{Note: "Brand account", Color: "blue"}
+	if got := cfg.ForAccount("test-user"); !reflect.DeepEqual(got, want) {
+		t.Errorf("saved settings = %+v, want %+v", got, want)
+	}
+	if got := f.Config.ForAccount("test-user"); !reflect.DeepEqual(got, want) {
+		t.Errorf("factory settings = %+v, want %+v", got, want)
+	}
+	if out := io.Out.(*bytes.Buffer).String(); !strings.Contains(out, "test-user (Brand account)") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestAuthLabel_Clear(t *testing.T) {
+	path := useTempConfig(t)
+	if err := os.WriteFile(path, []byte(`{"accounts":{"test-user":{"note":"Brand","color":"red"}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newAuthLabelCmd(f)
+	cmd.SetArgs([]string{"test-user", "--clear"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Accounts) != 0 {
+		t.Errorf("expected no account settings, got %+v", cfg.Accounts)
+	}
+}
+
+func TestAuthLabel_InvalidColor(t *testing.T) {
+	useTempConfig(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newAuthLabelCmd(f)
+	cmd.SetArgs([]string{"test-user", "--color", "purple"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "Invalid color: purple") {
+		t.Fatalf("expected invalid color error, got %v", err)
+	}
+}
+
+func TestAuthList_ShowsLabel(t *testing.T) {
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	f.Config.SetAccountSettings("test-user", config.AccountSettings{Note: "Brand account", Color: "blue"})
+
+	cmd := newAuthListCmd(f)
+	cmd.SetArgs([]string{})
+	ctx := outfmt.WithFormat(iocontext.WithIO(context.Background(), io), "json")
+	cmd.SetContext(ctx)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := io.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, `"note": "Brand account"`) || !strings.Contains(out, `"color": "blue"`) {
+		t.Errorf("expected label in output, got %s", out)
+	}
+}
+
+func TestAccountPromptLabel(t *testing.T) {
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	ctx := iocontext.WithIO(context.Background(), io)
+
+	if got := f.accountPromptLabel(ctx); got != "" {
+		t.Errorf("unlabeled account: got %q, want empty", got)
+	}
+
+	f.Config.SetAccountSettings("test-user", config.AccountSettings{Note: "Brand account"})
+	if got := f.accountPromptLabel(ctx); got != "[test-user · Brand account]" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestPostsCreate_AccountRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings config.AccountSettings
+		text     string
+		wantErr  string
+	}{
+		{"required text present", config.AccountSettings{Require: []string{"RELEASE:"}}, "RELEASE: v1.2.0", ""},
+		{"required text missing", config.AccountSettings{Require: []string{"RELEASE:"}}, "lunch", `must contain "RELEASE:"`},
+		{"forbidden text", config.AccountSettings{Forbid: []string{"RELEASE:"}}, "RELEASE: v1.2.0", `must not contain "RELEASE:"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posted string
+			server := newCreatePostServer(t, &posted)
+			f, io := newIntegrationTestFactory(t, server.URL)
+			f.Config.SetAccountSettings("test-user", tt.settings)
+
+			cmd := newPostsCreateCmd(f)
+			cmd.SetArgs([]string{"--text", tt.text})
+			cmd.SetContext(iocontext.WithIO(context.Background(), io))
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := cmd.Execute()
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if posted != tt.text {
+					t.Errorf("posted %q, want %q", posted, tt.text)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if posted != "" {
+				t.Errorf("expected nothing to be posted, got %q", posted)
+			}
+		})
+	}
+}
+
+func TestAuthLabel_SetsRules(t *testing.T) {
+	path := useTempConfig(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newAuthLabelCmd(f)
+	cmd.SetArgs([]string{"test-user", "--require", "RELEASE:", "--forbid", "lol"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := config.AccountSettings{Require: []string{"RELEASE:"}, Forbid: []string{"lol"}}
+	if got := cfg.ForAccount("test-user"); !reflect.DeepEqual(got, want) {
+		t.Errorf("saved settings = %+v, want %+v", got, want)
+	}
+	if out := io.Out.(*bytes.Buffer).String(); !strings.Contains(out, `Requires: "RELEASE:"`) {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+// annotatedPost is a reply or mention with what the configured classifier
+// and translator made of it, printed by 'replies list' and 'users mentions'
+type annotatedPost struct {
+	*api.Post
+	Labels []string `json:"labels,omitempty"`
+	// Translation is the text translated to the --translate language
+	Translation string `json:"translation,omitempty"`
+}
+
+// annotateOptions selects what annotatePosts does to each post
+type annotateOptions struct {
+	// Classify labels posts with the configured classifier
+	Classify bool
+	// Labels keeps only posts with any of these labels; empty keeps all
+	Labels []string
+	// Translate is the language to translate posts to; empty leaves them
+	Translate string
+}
+
+// annotatePosts labels and translates the posts of page as opts asks
+func annotatePosts(ctx context.Context, f *Factory, page *api.Page[api.Post], opts annotateOptions) (*api.Page[annotatedPost], error) {
+	if opts.Classify && f.Config.Classifier == "" {
+		return nil, &UserFriendlyError{
+			Message:    "No reply classifier configured",
+			Suggestion: "Set a command or URL with 'threads config set classifier ./classify-reply.sh'",
+		}
+	}
+	if opts.Translate != "" {
+		if err := validateLanguage(opts.Translate); err != nil {
+			return nil, err
+		}
+		if f.Config.Translator == "" {
+			return nil, &UserFriendlyError{
+				Message:    "No translator configured",
+				Suggestion: "Set a command or URL with 'threads config set translator ./translate.sh'",
+			}
+		}
+	}
+
+	annotated := &api.Page[annotatedPost]{Data: []annotatedPost{}, Paging: page.Paging}
+	for i := range page.Data {
+		post := annotatedPost{Post: &page.Data[i]}
+		if opts.Classify {
+			labels, err := classifyReply(ctx, f.Config.Classifier, post.Post)
+			if err != nil {
+				return nil, WrapError(fmt.Sprintf("failed to classify post %s", post.ID), err)
+			}
+			post.Labels = labels
+		}
+		if len(opts.Labels) > 0 && !slices.ContainsFunc(opts.Labels, func(label string) bool {
+			return slices.Contains(post.Labels, strings.ToLower(label))
+		}) {
+			continue
+		}
+		// Translated after filtering, so left-out posts cost no calls
+		if opts.Translate != "" {
+			translation, err := translateText(ctx, f.Config.Translator, post.Text, opts.Translate)
+			if err != nil {
+				return nil, WrapError(fmt.Sprintf("failed to translate post %s", post.ID), err)
+			}
+			post.Translation = translation
+		}
+		annotated.Data = append(annotated.Data, post)
+	}
+	return annotated, nil
+}
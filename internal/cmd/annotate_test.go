@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+func TestAnnotatePosts_FiltersByLabel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reply api.Post
+		json.NewDecoder(r.Body).Decode(&reply) //nolint:errcheck,gosec // Empty text is no label
+		w.Write([]byte(reply.Text))            //nolint:errcheck,gosec // Test server
+	}))
+	defer server.Close()
+
+	f := newTestFactory(t)
+	f.Config.Classifier = server.URL
+	page := &api.RepliesResponse{Data: []api.Post{{ID: "1", Text: "question"}, {ID: "2", Text: "positive"}, {ID: "3"}}}
+
+	labeled, err := annotatePosts(context.Background(), f, page, annotateOptions{Classify: true, Labels: []string{"Question", "spam"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labeled.Data) != 1 || labeled.Data[0].ID != "1" || !slices.Equal(labeled.Data[0].Labels, []string{"question"}) {
+		t.Errorf("unexpected replies: %+v", labeled.Data)
+	}
+
+	f.Config.Classifier = ""
+	if _, err := annotatePosts(context.Background(), f, page, annotateOptions{Classify: true}); err == nil {
+		t.Error("expected an error without a classifier")
+	}
+	if labeled, err := annotatePosts(context.Background(), f, page, annotateOptions{}); err != nil || len(labeled.Data) != 3 {
+		t.Errorf("expected replies unlabeled without --classify, got %v, %v", labeled, err)
+	}
+}
+
+func TestAnnotatePosts_Translates(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var req struct{ Text, Target string }
+		json.NewDecoder(r.Body).Decode(&req)                                              //nolint:errcheck,gosec // Checked through the answer
+		json.NewEncoder(w).Encode(map[string]string{"text": req.Target + ":" + req.Text}) //nolint:errcheck,gosec // Test server
+	}))
+	defer server.Close()
+
+	f := newTestFactory(t)
+	page := &api.PostsResponse{Data: []api.Post{{ID: "1", Text: "hola"}, {ID: "2"}}}
+	if _, err := annotatePosts(context.Background(), f, page, annotateOptions{Translate: "en"}); err == nil {
+		t.Error("expected an error without a translator")
+	}
+
+	f.Config.Translator = server.URL
+	if _, err := annotatePosts(context.Background(), f, page, annotateOptions{Translate: "not a language"}); err == nil {
+		t.Error("expected an invalid language to be an error")
+	}
+
+	translated, err := annotatePosts(context.Background(), f, page, annotateOptions{Translate: "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if translated.Data[0].Text != "hola" || translated.Data[0].Translation != "en:hola" {
+		t.Errorf("expected the original and its translation, got %+v", translated.Data[0])
+	}
+	// Posts without text are not sent
+	if translated.Data[1].Translation != "" || calls != 1 {
+		t.Errorf("expected one translator call, got %d: %+v", calls, translated.Data[1])
+	}
+}
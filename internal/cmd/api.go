@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// NewAPICmd builds the api command group.
+func NewAPICmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Inspect the Threads Graph API surface the CLI uses",
+	}
+
+	cmd.AddCommand(newAPIDescribeCmd())
+
+	return cmd
+}
+
+func newAPIDescribeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe [command]",
+		Short: "List the Graph API endpoints the client calls",
+		Long: `List every Graph API endpoint the client knows: method and path, the
+parameters it sends, the fields it requests by default, the permission the
+token needs, the publishing quotas it counts against and the commands that
+call it. Commands marked optional still run without the endpoint's scope.
+
+With a command, lists only the endpoints that command calls, which tells you
+the scopes it needs before you run it.`,
+		Example: `  threads api describe
+  threads api describe posts get
+  threads api describe -o json | jq -r '.[].scope' | sort -u`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			endpoints := api.Endpoints()
+
+			if len(args) > 0 {
+				name := strings.Join(args, " ")
+				if target, rest, err := cmd.Root().Find(args); err == nil && len(rest) == 0 {
+					name = strings.TrimPrefix(target.CommandPath(), cmd.Root().Name()+" ")
+				}
+				endpoints = slices.DeleteFunc(endpoints, func(e api.Endpoint) bool {
+					return !slices.Contains(e.Commands, name)
+				})
+				if len(endpoints) == 0 {
+					return &UserFriendlyError{
+						Message:    fmt.Sprintf("No API endpoints are called by %q", name),
+						Suggestion: "Run 'threads api describe' to list every endpoint with its commands",
+					}
+				}
+			}
+
+			io := iocontext.GetIO(ctx)
+			if outfmt.IsJSON(ctx) {
+				return outfmt.WriteJSONContext(ctx, io.Out, endpoints)
+			}
+
+			for i, e := range endpoints {
+				if i > 0 {
+					fmt.Fprintln(io.Out) //nolint:errcheck // Best-effort output
+				}
+				fmt.Fprintf(io.Out, "%s %s\n", e.Method, e.Path) //nolint:errcheck // Best-effort output
+				fmt.Fprintf(io.Out, "  %s\n", e.Summary)         //nolint:errcheck // Best-effort output
+				if len(e.Params) > 0 {
+					fmt.Fprintf(io.Out, "  Params:   %s\n", strings.Join(e.Params, ", ")) //nolint:errcheck // Best-effort output
+				}
+				if e.Fields != "" {
+					fmt.Fprintf(io.Out, "  Fields:   %s\n", e.Fields) //nolint:errcheck // Best-effort output
+				}
+				if e.Scope != "" {
+					fmt.Fprintf(io.Out, "  Scope:    %s\n", e.Scope) //nolint:errcheck // Best-effort output
+				}
+				if len(e.Quotas) > 0 {
+					fmt.Fprintf(io.Out, "  Quotas:   %s\n", strings.Join(e.Quotas, ", ")) //nolint:errcheck // Best-effort output
+				}
+				if len(e.Commands) > 0 {
+					commands := make([]string, len(e.Commands))
+					for i, command := range e.Commands {
+						commands[i] = command
+						if slices.Contains(e.Optional, command) {
+							commands[i] += " (optional)"
+						}
+					}
+					fmt.Fprintf(io.Out, "  Commands: %s\n", strings.Join(commands, ", ")) //nolint:errcheck // Best-effort output
+				}
+			}
+			return nil
+		},
+	}
+}
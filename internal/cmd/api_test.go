@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+func TestAPIEndpoints_NameRealCommands(t *testing.T) {
+	root := NewRootCmd(newTestFactory(t))
+	for _, e := range api.Endpoints() {
+		for _, name := range e.Commands {
+			target, rest, err := root.Find(strings.Fields(name))
+			if err != nil || len(rest) != 0 || target.CommandPath() != "threads "+name {
+				t.Errorf("%s %s: no command %q", e.Method, e.Path, name)
+			}
+		}
+	}
+}
+
+func TestAPIDescribe_FiltersByCommand(t *testing.T) {
+	f := newTestFactory(t)
+	var out bytes.Buffer
+	f.IO.Out = &out
+
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"api", "describe", "insights", "post", "-o", "json"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var endpoints []api.Endpoint
+	if err := json.Unmarshal(out.Bytes(), &endpoints); err != nil {
+		t.Fatalf("expected JSON, got %v:\n%s", err, out.String())
+	}
+	// The post's insights, and the user's posts and a batch of their insights
+	// for a --since report
+	if len(endpoints) != 3 || endpoints[0].Path != "/{user-id}/threads" || endpoints[1].Path != "/{media-id}/insights" || endpoints[1].Scope != "threads_manage_insights" || endpoints[2].Path != "/" {
+		t.Errorf("unexpected endpoints: %+v", endpoints)
+	}
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/salmonumbrella/threads-cli/internal/audit"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// auditAnnotation marks a command whose runs are recorded in the audit log.
+// Its value is auditRecord, or auditRecordRedactArgs when the positional
+// arguments are secret (such as a token).
+const auditAnnotation = "audit"
+
+const (
+	auditRecord           = "record"
+	auditRecordRedactArgs = "record-redact-args"
+)
+
+// auditRedacted replaces secret values in audit entries
+const auditRedacted = "[REDACTED]"
+
+// auditPath is the audit log location. It is replaced in tests.
+var auditPath = func() string {
+	return filepath.Join(config.DataDir(), audit.FileName)
+}
+
+// secretFlagPattern matches flags whose values are never written to the log
+var secretFlagPattern = regexp.MustCompile(`(?i)token|secret|password`)
+
+// recordAudit appends a run of an audited command to the audit log. Failing
+// to write the log is reported but does not fail the command.
+func recordAudit(f *Factory, errOut io.Writer, cmd *cobra.Command, runErr error) {
+	mode := cmd.Annotations[auditAnnotation]
+	if mode == "" {
+		return
+	}
+
+	entry := audit.Entry{
+		Time:    time.Now().UTC(),
+		Account: f.currentAccountName(),
+		Command: cmd.CommandPath(),
+		Args:    auditArgs(cmd, mode == auditRecordRedactArgs),
+		Result:  audit.ResultOK,
+	}
+	if runErr != nil {
+		entry.Result = audit.ResultError
+		entry.Error = runErr.Error()
+		var ufe *UserFriendlyError
+		if errors.As(FormatError(runErr), &ufe) {
+			entry.Error = ufe.Message
+		}
+	}
+
+	if err := audit.Append(auditPath(), entry); err != nil {
+		fmt.Fprintf(errOut, "warning: failed to write audit log: %v\n", err) //nolint:errcheck // Best-effort output to stderr
+	}
+}
+
+// auditArgs lists the positional arguments and the flags set on cmd, with
+// secret values redacted
+func auditArgs(cmd *cobra.Command, redactArgs bool) []string {
+	var args []string
+	for _, arg := range cmd.Flags().Args() {
+		if redactArgs {
+			arg = auditRedacted
+		}
+		args = append(args, arg)
+	}
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		value := flag.Value.String()
+		if secretFlagPattern.MatchString(flag.Name) {
+			value = auditRedacted
+		}
+		args = append(args, "--"+flag.Name+"="+value)
+	})
+	return args
+}
+
+// auditEntryView is the output model of an audit log entry
+type auditEntryView struct {
+	entry *audit.Entry
+}
+
+func (v auditEntryView) viewFields() []viewField {
+	return []viewField{
+		{Key: "time", Value: v.entry.Time, Text: v.entry.Time.Local().Format("2006-01-02 15:04:05"), Type: outfmt.ColumnDate},
+		{Key: "account", Value: v.entry.Account},
+		{Key: "command", Value: strings.TrimPrefix(v.entry.Command, "threads ")},
+		{Key: "args", Value: v.entry.Args},
+		{Key: "result", Value: v.entry.Result, Text: strings.ToUpper(v.entry.Result), Type: outfmt.ColumnStatus},
+		{Key: "error", Value: v.entry.Error},
+	}
+}
+
+// NewAuditCmd builds the audit command group.
+func NewAuditCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Review what the CLI changed",
+		Long: `Every command that changes something - publishing, deleting, hiding
+replies, and adding or removing credentials - is recorded in an append-only
+audit log in the data directory, with the time, account, arguments (secrets
+redacted), and result. Use it to review what scripts and automation did.`,
+	}
+
+	cmd.AddCommand(newAuditListCmd(f))
+	return cmd
+}
+
+func newAuditListCmd(f *Factory) *cobra.Command {
+	var since string
+	var utc bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List audited actions",
+		Long: `List audited actions, oldest first.
+
+--since takes a duration such as 7d or 12h, a date (2024-01-15), or a
+time such as "yesterday 9am".
+
+Examples:
+  threads audit list --since 7d
+  threads audit list --since 2024-01-15 -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			var from time.Time
+			if since != "" {
+				t, err := parseTimeFlag(f, utc, "since", since)
+				if err != nil {
+					return err
+				}
+				from = t
+			}
+
+			entries, err := audit.Read(auditPath(), from)
+			if err != nil {
+				return WrapError("failed to read audit log", err)
+			}
+			views := make([]auditEntryView, len(entries))
+			for i := range entries {
+				views[i] = auditEntryView{entry: &entries[i]}
+			}
+			return writeViewList(ctx, views, nil, "No audited actions")
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only show actions since a duration ago (7d, 12h) or a date or time")
+	addUTCFlag(cmd, &utc)
+	return cmd
+}
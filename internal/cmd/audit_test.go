@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/audit"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+// useTempAuditLog points the audit log at a temporary file
+func useTempAuditLog(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), audit.FileName)
+	orig := auditPath
+	auditPath = func() string { return path }
+	t.Cleanup(func() { auditPath = orig })
+	return path
+}
+
+func TestExecute_RecordsAuditedCommands(t *testing.T) {
+	path := useTempAuditLog(t)
+
+	var hidden []api.PostID
+	f, io := newMockAPITestFactory(t, &mockAPI{
+		hideReplies: func(ctx context.Context, replyIDs []api.PostID) error {
+			hidden = replyIDs
+			return nil
+		},
+	})
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"replies", "hide", "r1", "r2"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := ExecuteCommand(cmd, f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hidden) != 2 {
+		t.Fatalf("expected 2 replies hidden, got %v", hidden)
+	}
+
+	// Read-only commands are not recorded
+	cmd = NewRootCmd(f)
+	cmd.SetArgs([]string{"config", "path"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := ExecuteCommand(cmd, f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := audit.Read(path, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %+v", entries)
+	}
+	e := entries[0]
+	if e.Command != "threads replies hide" || e.Account != "test-user" || e.Result != audit.ResultOK {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if strings.Join(e.Args, " ") != "r1 r2" {
+		t.Errorf("args = %v", e.Args)
+	}
+}
+
+func TestExecute_RecordsFailures(t *testing.T) {
+	path := useTempAuditLog(t)
+
+	f, io := newMockAPITestFactory(t, &mockAPI{
+		hideReplies: func(ctx context.Context, replyIDs []api.PostID) error {
+			return errors.New("permission denied")
+		},
+	})
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"replies", "hide", "r1", "r2"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := ExecuteCommand(cmd, f); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	entries, err := audit.Read(path, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Result != audit.ResultError || !strings.Contains(entries[0].Error, "permission denied") {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestAuditArgs_RedactsSecrets(t *testing.T) {
+	cmd := &cobra.Command{Use: "token", RunE: func(*cobra.Command, []string) error { return nil }}
+	cmd.Flags().String("client-secret", "", "")
+	cmd.Flags().String("name", "", "")
+	if err := cmd.ParseFlags([]string{"EAAtoken", "--client-secret", "s3cret", "--name", "work"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := strings.Join(auditArgs(cmd, true), " ")
+	if strings.Contains(got, "EAAtoken") || strings.Contains(got, "s3cret") {
+		t.Errorf("secrets leaked into audit args: %s", got)
+	}
+	if want := "[REDACTED] --client-secret=[REDACTED] --name=work"; got != want {
+		t.Errorf("auditArgs = %q, want %q", got, want)
+	}
+}
+
+func TestAuditList_Since(t *testing.T) {
+	path := useTempAuditLog(t)
+	now := time.Now().UTC()
+	for _, e := range []audit.Entry{
+		{Time: now.AddDate(0, 0, -10), Command: "threads posts create", Result: audit.ResultOK},
+		{Time: now.Add(-time.Hour), Command: "threads posts delete", Args: []string{"123"}, Result: audit.ResultOK},
+	} {
+		if err := audit.Append(path, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f := newTestFactory(t)
+	var out bytes.Buffer
+	f.IO.Out = &out
+	cmd := newAuditListCmd(f)
+	cmd.SetArgs([]string{"--since", "7d"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := out.String()
+	if !strings.Contains(got, "posts delete") || strings.Contains(got, "posts create") {
+		t.Errorf("unexpected output:\n%s", got)
+	}
+}
@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -35,8 +38,12 @@ func NewAuthCmd(f *Factory) *cobra.Command {
 	cmd.AddCommand(newAuthTokenCmd(f))
 	cmd.AddCommand(newAuthRefreshCmd(f))
 	cmd.AddCommand(newAuthStatusCmd(f))
+	cmd.AddCommand(newAuthDebugCmd(f))
 	cmd.AddCommand(newAuthListCmd(f))
 	cmd.AddCommand(newAuthRemoveCmd(f))
+	cmd.AddCommand(newAuthLabelCmd(f))
+	cmd.AddCommand(newAuthSwitchCmd(f))
+	cmd.AddCommand(newAuthCurrentCmd(f))
 
 	return cmd
 }
@@ -47,6 +54,9 @@ type authLoginOptions struct {
 	ClientSecret string
 	RedirectURI  string
 	Scopes       []string
+	NoBrowser    bool
+	CallbackHost string
+	CallbackPort int
 }
 
 func newAuthLoginCmd(f *Factory) *cobra.Command {
@@ -56,12 +66,27 @@ func newAuthLoginCmd(f *Factory) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "login",
-		Short: "Authenticate with Threads via browser",
+		Use:         "login",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Authenticate with Threads via browser",
 		Long: `Opens a browser to authenticate with Threads using OAuth 2.0.
 
 After authentication, your credentials are securely stored in the system keychain.
-Tokens are automatically converted to long-lived tokens (60 days).`,
+Tokens are automatically converted to long-lived tokens (60 days).
+
+On machines without a browser, such as over SSH, --no-browser prints the
+authorization URL to open on any other machine instead, then reads the URL the
+browser was redirected to, or the code in it, from stdin. The redirect does
+not need to load, so nothing has to listen on the redirect URI.
+
+Otherwise a local server receives the redirect, on 127.0.0.1:8585 unless
+--callback-host and --callback-port (or the callback_host and callback_port
+config keys) say otherwise. The redirect URI, built from them unless given,
+must be listed in the Valid OAuth Redirect URIs of the Meta app, and is
+checked against them before the server starts.`,
+		Example: `  threads auth login
+  threads auth login --name work --no-browser
+  threads auth login --callback-port 9000 --redirect-uri http://localhost:9000/callback`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runAuthLogin(cmd, f, opts)
 		},
@@ -72,6 +97,9 @@ Tokens are automatically converted to long-lived tokens (60 days).`,
 	cmd.Flags().StringVar(&opts.ClientSecret, "client-secret", "", "Meta App Client Secret (or THREADS_CLIENT_SECRET)")
 	cmd.Flags().StringVar(&opts.RedirectURI, "redirect-uri", "", "OAuth Redirect URI (or THREADS_REDIRECT_URI)")
 	cmd.Flags().StringSliceVar(&opts.Scopes, "scopes", opts.Scopes, "OAuth scopes to request")
+	cmd.Flags().BoolVar(&opts.NoBrowser, "no-browser", false, "Print the authorization URL and read the redirect from stdin instead of opening a browser")
+	cmd.Flags().StringVar(&opts.CallbackHost, "callback-host", "", "Host the OAuth callback server listens on (default 127.0.0.1, or config callback_host)")
+	cmd.Flags().IntVar(&opts.CallbackPort, "callback-port", 0, "Port the OAuth callback server listens on (default 8585, or config callback_port)")
 
 	return cmd
 }
@@ -97,8 +125,32 @@ func runAuthLogin(cmd *cobra.Command, f *Factory, opts *authLoginOptions) error
 		}
 	}
 
+	callbackHost, callbackPort := opts.CallbackHost, opts.CallbackPort
+	if f.Config != nil {
+		callbackHost = fallback(callbackHost, f.Config.CallbackHost)
+		callbackPort = cmp.Or(callbackPort, f.Config.CallbackPort)
+	}
+	if callbackPort < 0 || callbackPort > 65535 {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid callback port: %d", callbackPort),
+			Suggestion: "Use a number from 1 to 65535",
+		}
+	}
 	if redirectURI == "" {
-		redirectURI = "http://127.0.0.1:8585/callback"
+		redirectURI = auth.CallbackURI(fallback(callbackHost, auth.DefaultCallbackHost), cmp.Or(callbackPort, auth.DefaultCallbackPort))
+	}
+
+	server := auth.NewOAuthServer(clientID, clientSecret, redirectURI, opts.Scopes)
+	server.SetCallback(callbackHost, callbackPort)
+	// Without a browser nothing listens, so any registered redirect will do
+	if !opts.NoBrowser {
+		if err := server.Validate(); err != nil {
+			return &UserFriendlyError{
+				Message:    "Cannot receive the OAuth redirect: " + err.Error(),
+				Suggestion: "Use a redirect URI listed in the Valid OAuth Redirect URIs of your Meta app that matches --callback-host and --callback-port, or use --no-browser",
+				Cause:      err,
+			}
+		}
 	}
 
 	store, err := f.Store()
@@ -109,10 +161,15 @@ func runAuthLogin(cmd *cobra.Command, f *Factory, opts *authLoginOptions) error
 	ctx := cmd.Context()
 	p := f.UI(ctx)
 	p.Info("Starting authentication flow...")
-	p.Info("Opening browser for Threads authorization...")
 
-	server := auth.NewOAuthServer(clientID, clientSecret, redirectURI, opts.Scopes)
-	result, err := server.Start(ctx)
+	var result *auth.OAuthResult
+	if opts.NoBrowser {
+		io := iocontext.GetIO(ctx)
+		result, err = server.StartManual(ctx, io.In, io.ErrOut)
+	} else {
+		p.Info("Opening browser for Threads authorization...")
+		result, err = server.Start(ctx)
+	}
 	if err != nil {
 		return WrapError("authentication failed", err)
 	}
@@ -127,17 +184,22 @@ func runAuthLogin(cmd *cobra.Command, f *Factory, opts *authLoginOptions) error
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
 		RedirectURI:  redirectURI,
+		Scopes:       opts.Scopes,
 	}
 
 	if err := store.Set(opts.Name, creds); err != nil {
 		return WrapError("failed to store credentials", err)
 	}
+	selected := selectAccountIfNone(opts.Name)
 
 	p.Success("Authentication successful!")
 	io := iocontext.GetIO(ctx)
 	fmt.Fprintf(io.Out, "  Account:  %s\n", opts.Name)                                                                                  //nolint:errcheck // Best-effort output
 	fmt.Fprintf(io.Out, "  User:     @%s\n", result.Username)                                                                           //nolint:errcheck // Best-effort output
 	fmt.Fprintf(io.Out, "  Expires:  %s (%.0f days)\n", result.ExpiresAt.Format("2006-01-02"), time.Until(result.ExpiresAt).Hours()/24) //nolint:errcheck // Best-effort output
+	if selected {
+		p.Info("%s is now the active account; change it with 'threads auth switch'", opts.Name)
+	}
 
 	return nil
 }
@@ -154,8 +216,9 @@ func newAuthTokenCmd(f *Factory) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "token [access-token]",
-		Short: "Authenticate with an existing access token",
+		Use:         "token [access-token]",
+		Annotations: map[string]string{auditAnnotation: auditRecordRedactArgs},
+		Short:       "Authenticate with an existing access token",
 		Long: `Use an existing access token to authenticate.
 
 You can provide the token as an argument or via THREADS_ACCESS_TOKEN environment variable.
@@ -200,7 +263,9 @@ func runAuthToken(cmd *cobra.Command, f *Factory, opts *authTokenOptions, args [
 	cfg := &api.Config{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
+		BaseURL:      apiBaseURL(),
 		Debug:        f.Debug,
+		HTTPTrace:    f.httpTrace,
 	}
 	if f.Debug {
 		cfg.Logger = f.logger()
@@ -244,11 +309,13 @@ func runAuthToken(cmd *cobra.Command, f *Factory, opts *authTokenOptions, args [
 		CreatedAt:    time.Now(),
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
+		Scopes:       debugInfo.Data.Scopes,
 	}
 
 	if err := store.Set(opts.Name, creds); err != nil {
 		return WrapError("failed to store credentials", err)
 	}
+	selected := selectAccountIfNone(opts.Name)
 
 	p := f.UI(ctx)
 	p.Success("Token stored successfully!")
@@ -256,15 +323,19 @@ func runAuthToken(cmd *cobra.Command, f *Factory, opts *authTokenOptions, args [
 	fmt.Fprintf(io.Out, "  Account:  %s\n", opts.Name)                                                                    //nolint:errcheck // Best-effort output
 	fmt.Fprintf(io.Out, "  User:     @%s\n", user.Username)                                                               //nolint:errcheck // Best-effort output
 	fmt.Fprintf(io.Out, "  Expires:  %s (%.0f days)\n", expiresAt.Format("2006-01-02"), time.Until(expiresAt).Hours()/24) //nolint:errcheck // Best-effort output
+	if selected {
+		p.Info("%s is now the active account; change it with 'threads auth switch'", opts.Name)
+	}
 
 	return nil
 }
 
 func newAuthRefreshCmd(f *Factory) *cobra.Command {
 	return &cobra.Command{
-		Use:   "refresh",
-		Short: "Refresh the access token",
-		Long:  `Refresh the current access token before it expires.`,
+		Use:         "refresh",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Refresh the access token",
+		Long:        `Refresh the current access token before it expires.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runAuthRefresh(cmd, f)
 		},
@@ -277,19 +348,9 @@ func runAuthRefresh(cmd *cobra.Command, f *Factory) error {
 		return FormatError(err)
 	}
 
-	account := f.Account
-	if account == "" {
-		accounts, listErr := store.List()
-		if listErr != nil {
-			return FormatError(listErr)
-		}
-		if len(accounts) == 0 {
-			return &UserFriendlyError{
-				Message:    "No Threads account configured",
-				Suggestion: "Run 'threads auth login' to authenticate with your Threads account",
-			}
-		}
-		account = accounts[0]
+	account, err := f.resolveAccount()
+	if err != nil {
+		return err
 	}
 
 	creds, err := store.Get(account)
@@ -304,32 +365,18 @@ func runAuthRefresh(cmd *cobra.Command, f *Factory) error {
 		}
 	}
 
-	cfg := &api.Config{
-		ClientID:     creds.ClientID,
-		ClientSecret: creds.ClientSecret,
-		Debug:        f.Debug,
-	}
-	if f.Debug {
-		cfg.Logger = f.logger()
-	}
-
-	client, err := f.NewClient(creds.AccessToken, cfg)
+	client, err := f.NewClient(creds.AccessToken, f.apiConfig(store, account, creds))
 	if err != nil {
 		return WrapError("failed to create client", err)
 	}
 
+	// The client persists the refreshed token through its OnTokenRefresh hook
 	ctx := cmd.Context()
 	if err := client.RefreshToken(ctx); err != nil {
 		return WrapError("failed to refresh token", err)
 	}
 
-	tokenInfo := client.GetTokenInfo()
-	creds.AccessToken = tokenInfo.AccessToken
-	creds.ExpiresAt = tokenInfo.ExpiresAt
-
-	if err := store.Set(account, *creds); err != nil {
-		return WrapError("failed to update stored credentials", err)
-	}
+	creds.ExpiresAt = client.GetTokenInfo().ExpiresAt
 
 	p := f.UI(ctx)
 	p.Success("Token refreshed successfully!")
@@ -351,26 +398,32 @@ func newAuthStatusCmd(f *Factory) *cobra.Command {
 	}
 }
 
+// authStatus is the JSON output of 'auth status'
+type authStatus struct {
+	Account         string    `json:"account"`
+	DaysUntilExpiry float64   `json:"days_until_expiry"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	IsExpired       bool      `json:"is_expired"`
+	UserID          string    `json:"user_id"`
+	Username        string    `json:"username"`
+}
+
 func runAuthStatus(cmd *cobra.Command, f *Factory) error {
 	store, err := f.Store()
 	if err != nil {
 		return FormatError(err)
 	}
 
-	account := f.Account
-	if account == "" {
-		accounts, listErr := store.List()
-		if listErr != nil {
-			return FormatError(listErr)
-		}
-		if len(accounts) == 0 {
-			p := f.UI(cmd.Context())
-			p.Warning("No account configured")
-			io := iocontext.GetIO(cmd.Context())
-			fmt.Fprintln(io.Out, "\nRun 'threads auth login' to authenticate.") //nolint:errcheck // Best-effort output
-			return nil
-		}
-		account = accounts[0]
+	account, err := f.resolveAccount()
+	if errors.Is(err, errNoAccount) {
+		p := f.UI(cmd.Context())
+		p.Warning("No account configured")
+		io := iocontext.GetIO(cmd.Context())
+		fmt.Fprintln(io.Out, "\nRun 'threads auth login' to authenticate.") //nolint:errcheck // Best-effort output
+		return nil
+	}
+	if err != nil {
+		return err
 	}
 
 	creds, err := store.Get(account)
@@ -382,14 +435,14 @@ func runAuthStatus(cmd *cobra.Command, f *Factory) error {
 	io := iocontext.GetIO(ctx)
 
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSONTo(io.Out, map[string]any{
-			"account":           account,
-			"user_id":           creds.UserID,
-			"username":          creds.Username,
-			"expires_at":        creds.ExpiresAt,
-			"is_expired":        creds.IsExpired(),
-			"days_until_expiry": creds.DaysUntilExpiry(),
-		}, outfmt.GetQuery(ctx))
+		return outfmt.WriteJSONContext(ctx, io.Out, authStatus{
+			Account:         account,
+			UserID:          creds.UserID,
+			Username:        creds.Username,
+			ExpiresAt:       creds.ExpiresAt,
+			IsExpired:       creds.IsExpired(),
+			DaysUntilExpiry: creds.DaysUntilExpiry(),
+		})
 	}
 
 	p := f.UI(ctx)
@@ -416,6 +469,72 @@ func runAuthStatus(cmd *cobra.Command, f *Factory) error {
 	return nil
 }
 
+func newAuthDebugCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "debug [access-token]",
+		Short: "Inspect an access token",
+		Long: `Show validity, owner, scopes, and expiry of an access token.
+
+Without an argument the active account's token is inspected. With the app
+auth mode (threads config set auth_mode app) any user's token can be inspected
+using only the app credentials in THREADS_CLIENT_ID and THREADS_CLIENT_SECRET.`,
+		Example: `  threads auth debug
+  THREADS_AUTH_MODE=app threads auth debug TOKEN`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var token string
+			if len(args) > 0 {
+				token = args[0]
+			}
+			return runAuthDebug(cmd, f, token)
+		},
+	}
+}
+
+func runAuthDebug(cmd *cobra.Command, f *Factory, token string) error {
+	ctx := cmd.Context()
+
+	client, err := f.Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	if token == "" && client.IsAppToken() {
+		return &UserFriendlyError{
+			Message:    "An access token is required in app auth mode",
+			Suggestion: "Pass the token to inspect: threads auth debug TOKEN",
+		}
+	}
+
+	resp, err := client.DebugToken(ctx, token)
+	if err != nil {
+		return WrapError("failed to debug token", err)
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, resp.Data)
+	}
+
+	p := f.UI(ctx)
+	valid := p.Colorize("valid", p.Green)
+	if !resp.Data.IsValid {
+		valid = p.Colorize("invalid", p.Red)
+	}
+
+	fmt.Fprintf(io.Out, "Status:   %s\n", valid)                                //nolint:errcheck // Best-effort output
+	fmt.Fprintf(io.Out, "User ID:  %s\n", resp.Data.UserID)                     //nolint:errcheck // Best-effort output
+	fmt.Fprintf(io.Out, "Scopes:   %s\n", strings.Join(resp.Data.Scopes, ", ")) //nolint:errcheck // Best-effort output
+	if resp.Data.ExpiresAt > 0 {
+		expiresAt := time.Unix(resp.Data.ExpiresAt, 0)
+		fmt.Fprintf(io.Out, "Expires:  %s (%s)\n", expiresAt.Format("2006-01-02 15:04"), ui.FormatDuration(time.Until(expiresAt).Hours()/24)) //nolint:errcheck // Best-effort output
+	} else {
+		fmt.Fprintln(io.Out, "Expires:  never") //nolint:errcheck // Best-effort output
+	}
+
+	return nil
+}
+
 func newAuthListCmd(f *Factory) *cobra.Command {
 	return &cobra.Command{
 		Use:   "list",
@@ -452,25 +571,25 @@ func runAuthList(cmd *cobra.Command, f *Factory) error {
 		for _, name := range accounts {
 			creds, _ := store.Get(name) //nolint:errcheck // handled via nil check
 			if creds != nil {
+				settings := f.Config.ForAccount(name)
 				result = append(result, map[string]any{
 					"name":       name,
 					"username":   creds.Username,
 					"user_id":    creds.UserID,
 					"expires_at": creds.ExpiresAt,
 					"is_expired": creds.IsExpired(),
+					"note":       settings.Note,
+					"color":      settings.Color,
 				})
 			}
 		}
-		return outfmt.WriteJSONTo(io.Out, result, outfmt.GetQuery(ctx))
+		return outfmt.WriteJSONContext(ctx, io.Out, result)
 	}
 
 	fmtr := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
-	fmtr.Header("ACCOUNT", "USERNAME", "EXPIRES", "STATUS")
+	fmtr.Header("ACCOUNT", "USERNAME", "EXPIRES", "STATUS", "LABEL")
 
-	currentAccount := f.Account
-	if currentAccount == "" && len(accounts) > 0 {
-		currentAccount = accounts[0]
-	}
+	currentAccount, _ := f.resolveAccount() //nolint:errcheck // No account is marked when none is selected
 
 	for _, name := range accounts {
 		creds, _ := store.Get(name) //nolint:errcheck // handled via nil check
@@ -495,7 +614,7 @@ func runAuthList(cmd *cobra.Command, f *Factory) error {
 			expires = creds.ExpiresAt.Format("2006-01-02")
 		}
 
-		fmtr.Row(displayName, "@"+creds.Username, expires, status)
+		fmtr.Row(displayName, "@"+creds.Username, expires, status, accountListLabel(ctx, f, name))
 	}
 	fmtr.Flush()
 
@@ -504,9 +623,10 @@ func runAuthList(cmd *cobra.Command, f *Factory) error {
 
 func newAuthRemoveCmd(f *Factory) *cobra.Command {
 	return &cobra.Command{
-		Use:   "remove [account]",
-		Short: "Remove a stored account",
-		Args:  cobra.ExactArgs(1),
+		Use:         "remove [account]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Remove a stored account",
+		Args:        cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runAuthRemove(cmd, f, args[0])
 		},
@@ -523,7 +643,11 @@ func runAuthRemove(cmd *cobra.Command, f *Factory, name string) error {
 		return FormatError(err)
 	}
 
-	if !f.Confirm(cmd.Context(), fmt.Sprintf("Remove account %q?", name)) {
+	confirmed, err := f.Confirm(cmd.Context(), fmt.Sprintf("Remove account %q?", name))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		io := iocontext.GetIO(cmd.Context())
 		fmt.Fprintln(io.Out, "Cancelled.") //nolint:errcheck // Best-effort output
 		return nil
@@ -535,5 +659,10 @@ func runAuthRemove(cmd *cobra.Command, f *Factory, name string) error {
 
 	p := f.UI(cmd.Context())
 	p.Success("Account %q removed", name)
+	if unselected, err := unselectAccount(name); err != nil {
+		p.Warning("Could not clear the selected account: %v", err)
+	} else if unselected {
+		p.Info("No account is selected now; choose one with 'threads auth switch'")
+	}
 	return nil
 }
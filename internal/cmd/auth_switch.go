@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
+)
+
+// Where the account commands act on was chosen, as reported by 'auth current'
+const (
+	accountSourceFlag   = "flag"
+	accountSourceEnv    = "env"
+	accountSourceConfig = "config"
+	accountSourceOnly   = "only"
+)
+
+func newAuthSwitchCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:         "switch [account]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Choose the account commands act on",
+		Long: `Record the account commands act on when --account and THREADS_ACCOUNT are
+not given, as the account key of the config file. Without an argument, pick
+from the stored accounts.
+
+With several accounts stored and none chosen, commands stop rather than
+guess which one to use.`,
+		Example: `  threads auth switch work
+  threads auth switch`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var name string
+			if len(args) == 1 {
+				name = args[0]
+			}
+			return runAuthSwitch(cmd, f, name)
+		},
+	}
+}
+
+func runAuthSwitch(cmd *cobra.Command, f *Factory, name string) error {
+	ctx := cmd.Context()
+	store, err := f.Store()
+	if err != nil {
+		return FormatError(err)
+	}
+	accounts, err := store.List()
+	if err != nil {
+		return FormatError(err)
+	}
+	if len(accounts) == 0 {
+		return errNoAccount
+	}
+
+	cfg, err := config.LoadFile(config.ConfigPath())
+	if err != nil {
+		return err
+	}
+	previous := cfg.Account
+
+	if name == "" {
+		name, err = pickAccount(cmd, store, accounts, previous)
+		if err != nil || name == "" {
+			return err
+		}
+	}
+	if !slices.Contains(accounts, name) {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("No account named %q", name),
+			Suggestion: "Stored accounts: " + strings.Join(accounts, ", "),
+		}
+	}
+
+	if name != previous {
+		cfg.Account = name
+		if err := config.Save(cfg); err != nil {
+			return WrapError("failed to save config", err)
+		}
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
+			"account":  name,
+			"previous": previous,
+		})
+	}
+
+	p := f.UI(ctx)
+	p.Success("Switched to account %q", name)
+	if env := os.Getenv("THREADS_ACCOUNT"); env != "" && env != name {
+		p.Warning("THREADS_ACCOUNT=%s still takes precedence where it is set", env)
+	}
+	return nil
+}
+
+// pickAccount asks which account to switch to, returning "" if the user
+// answers nothing
+func pickAccount(cmd *cobra.Command, store secrets.Store, accounts []string, current string) (string, error) {
+	ctx := cmd.Context()
+	io := iocontext.GetIO(ctx)
+	if !isTerminalReader(io.In) || outfmt.GetStrict(ctx) {
+		return "", &UserFriendlyError{
+			Message:    "Name the account to switch to",
+			Suggestion: "Run 'threads auth switch NAME' with one of: " + strings.Join(accounts, ", "),
+		}
+	}
+
+	width := 0
+	for _, name := range accounts {
+		width = max(width, len(name))
+	}
+	for i, name := range accounts {
+		user := ""
+		if creds, err := store.Get(name); err == nil {
+			user = "@" + creds.Username
+		}
+		marker := ""
+		if name == current {
+			marker = " *"
+		}
+		fmt.Fprintf(io.Out, "  %d) %-*s  %s%s\n", i+1, width, name, user, marker) //nolint:errcheck // Best-effort output
+	}
+	fmt.Fprintf(io.Out, "Switch to [1-%d]: ", len(accounts)) //nolint:errcheck // Best-effort output
+
+	var answer string
+	//nolint:errcheck,gosec // Scanln error is fine - an empty answer cancels
+	fmt.Fscanln(io.In, &answer)
+	if answer == "" {
+		fmt.Fprintln(io.Out, "Cancelled.") //nolint:errcheck // Best-effort output
+		return "", nil
+	}
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(accounts) {
+		return accounts[n-1], nil
+	}
+	return answer, nil
+}
+
+func newAuthCurrentCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "current",
+		Short: "Show the account commands act on",
+		Long: `Show the account commands act on and how it was chosen: the --account flag,
+THREADS_ACCOUNT, the config file (see 'threads auth switch'), or being the
+only stored account.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAuthCurrent(cmd, f)
+		},
+	}
+}
+
+func runAuthCurrent(cmd *cobra.Command, f *Factory) error {
+	ctx := cmd.Context()
+	account, err := f.resolveAccount()
+	if err != nil {
+		return err
+	}
+
+	source := accountSourceOnly
+	switch {
+	case cmd.Flags().Changed("account"):
+		source = accountSourceFlag
+	case os.Getenv("THREADS_ACCOUNT") != "":
+		source = accountSourceEnv
+	case f.Account != "":
+		source = accountSourceConfig
+	}
+
+	store, err := f.Store()
+	if err != nil {
+		return FormatError(err)
+	}
+	creds, err := store.Get(account)
+	if err != nil {
+		return FormatError(err)
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
+			"account":  account,
+			"username": creds.Username,
+			"user_id":  creds.UserID,
+			"source":   source,
+		})
+	}
+
+	fmt.Fprintf(io.Out, "  Account:  %s\n", account)                       //nolint:errcheck // Best-effort output
+	fmt.Fprintf(io.Out, "  User:     @%s\n", creds.Username)               //nolint:errcheck // Best-effort output
+	fmt.Fprintf(io.Out, "  Source:   %s\n", describeAccountSource(source)) //nolint:errcheck // Best-effort output
+	return nil
+}
+
+func describeAccountSource(source string) string {
+	switch source {
+	case accountSourceFlag:
+		return "--account flag"
+	case accountSourceEnv:
+		return "THREADS_ACCOUNT"
+	case accountSourceConfig:
+		return "config (threads auth switch)"
+	default:
+		return "only stored account"
+	}
+}
+
+// selectAccountIfNone records name as the account commands act on unless
+// one is already recorded, reporting whether it did. Logins use it so the
+// first account needs no 'threads auth switch'.
+func selectAccountIfNone(name string) bool {
+	cfg, err := config.LoadFile(config.ConfigPath())
+	if err != nil || cfg.Account != "" {
+		return false
+	}
+	cfg.Account = name
+	return config.Save(cfg) == nil
+}
+
+// unselectAccount forgets name as the account commands act on if it is the
+// recorded one, reporting whether it was
+func unselectAccount(name string) (bool, error) {
+	cfg, err := config.LoadFile(config.ConfigPath())
+	if err != nil || cfg.Account != name {
+		return false, err
+	}
+	cfg.Account = ""
+	return true, config.Save(cfg)
+}
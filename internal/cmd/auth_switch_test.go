@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
+)
+
+// newAccountsTestFactory returns a factory whose store holds names, with
+// the account selection loaded from the config file as the root command does
+func newAccountsTestFactory(t *testing.T, names ...string) (*Factory, *iocontext.IO) {
+	t.Helper()
+	t.Setenv("THREADS_ACCOUNT", "")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	io := &iocontext.IO{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}, In: &bytes.Buffer{}}
+	f, err := NewFactory(context.Background(), FactoryOptions{
+		IO:     io,
+		Config: cfg,
+		Store: func() (secrets.Store, error) {
+			return &accountsStore{names: names}, nil
+		},
+		NewClient: func(string, *api.Config) (api.API, error) {
+			return &mockAPI{}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create factory: %v", err)
+	}
+	f.Account = cfg.Account
+	return f, io
+}
+
+func TestResolveAccount(t *testing.T) {
+	useTempConfig(t)
+
+	f, _ := newAccountsTestFactory(t)
+	if _, err := f.resolveAccount(); !errors.Is(err, errNoAccount) {
+		t.Errorf("expected errNoAccount with no accounts, got %v", err)
+	}
+
+	f, _ = newAccountsTestFactory(t, "only")
+	if account, err := f.resolveAccount(); err != nil || account != "only" {
+		t.Errorf("expected the only account, got %q, %v", account, err)
+	}
+
+	f, _ = newAccountsTestFactory(t, "personal", "work")
+	_, err := f.resolveAccount()
+	var ufe *UserFriendlyError
+	if !errors.As(err, &ufe) || !strings.Contains(ufe.Suggestion, "threads auth switch") {
+		t.Errorf("expected several unselected accounts refused, got %v", err)
+	}
+
+	f.Account = "work"
+	if account, err := f.resolveAccount(); err != nil || account != "work" {
+		t.Errorf("expected the selected account, got %q, %v", account, err)
+	}
+}
+
+func TestAuthSwitch_RecordsAccount(t *testing.T) {
+	path := useTempConfig(t)
+	f, io := newAccountsTestFactory(t, "personal", "work")
+
+	cmd := newAuthSwitchCmd(f)
+	cmd.SetArgs([]string{"work"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Account != "work" {
+		t.Errorf("expected work recorded, got %q", cfg.Account)
+	}
+
+	f, _ = newAccountsTestFactory(t, "personal", "work")
+	if account, err := f.resolveAccount(); err != nil || account != "work" {
+		t.Errorf("expected later commands to use work, got %q, %v", account, err)
+	}
+}
+
+func TestAuthSwitch_Errors(t *testing.T) {
+	useTempConfig(t)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "unknown account", args: []string{"brand"}, wantErr: `No account named "brand"`},
+		{name: "no argument without a terminal", args: nil, wantErr: "Name the account"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, io := newAccountsTestFactory(t, "personal", "work")
+			cmd := newAuthSwitchCmd(f)
+			cmd.SetArgs(tt.args)
+			cmd.SetContext(iocontext.WithIO(context.Background(), io))
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAuthCurrent_JSON(t *testing.T) {
+	path := useTempConfig(t)
+	if err := os.WriteFile(path, []byte(`{"account":"work"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, io := newAccountsTestFactory(t, "personal", "work")
+
+	cmd := newAuthCurrentCmd(f)
+	cmd.SetContext(outfmt.WithFormat(iocontext.WithIO(context.Background(), io), "json"))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got map[string]string
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got["account"] != "work" || got["source"] != accountSourceConfig || got["username"] != "testuser" {
+		t.Errorf("unexpected output: %v", got)
+	}
+}
+
+func TestAuthRemove_ClearsSelection(t *testing.T) {
+	path := useTempConfig(t)
+	if err := os.WriteFile(path, []byte(`{"account":"test-user"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newAuthRemoveCmd(f)
+	cmd.SetArgs([]string{"test-user"})
+	cmd.SetContext(outfmt.WithYes(iocontext.WithIO(context.Background(), io), true))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Account != "" {
+		t.Errorf("expected the selection cleared, got %q", cfg.Account)
+	}
+}
@@ -1,6 +1,16 @@
 package cmd
 
-import "testing"
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
+)
 
 func TestAuthCmd_Structure(t *testing.T) {
 	f := newTestFactory(t)
@@ -19,8 +29,12 @@ func TestAuthCmd_Structure(t *testing.T) {
 		"token":   true,
 		"refresh": true,
 		"status":  true,
+		"debug":   true,
 		"list":    true,
 		"remove":  true,
+		"label":   true,
+		"switch":  true,
+		"current": true,
 	}
 
 	for _, sub := range cmd.Commands() {
@@ -49,6 +63,8 @@ func TestAuthLoginCmd_Flags(t *testing.T) {
 		{"client-secret", ""},
 		{"redirect-uri", ""},
 		{"scopes", ""},
+		{"callback-host", ""},
+		{"callback-port", ""},
 	}
 
 	for _, flag := range flags {
@@ -68,6 +84,38 @@ func TestAuthLoginCmd_Flags(t *testing.T) {
 	}
 }
 
+func TestAuthLogin_CallbackMustMatchRedirectURI(t *testing.T) {
+	t.Setenv("THREADS_CLIENT_ID", "client-id")
+	t.Setenv("THREADS_CLIENT_SECRET", "client-secret")
+	t.Setenv("THREADS_REDIRECT_URI", "http://127.0.0.1:8585/callback")
+
+	tests := []struct {
+		name    string
+		port    int
+		args    []string
+		wantErr string
+	}{
+		{name: "config port", port: 9000, wantErr: "callback port 9000"},
+		{name: "flag host", args: []string{"--callback-host", "0.0.0.0"}, wantErr: "callback host 0.0.0.0"},
+		{name: "flag over config", port: 9000, args: []string{"--callback-port", "9001"}, wantErr: "callback port 9001"},
+		{name: "bad port", args: []string{"--callback-port", "70000"}, wantErr: "Invalid callback port"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestFactory(t)
+			f.Config.CallbackPort = tt.port
+			cmd := newAuthLoginCmd(f)
+			cmd.SetArgs(tt.args)
+			cmd.SetContext(context.Background())
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestAuthTokenCmd_Structure(t *testing.T) {
 	f := newTestFactory(t)
 	cmd := newAuthTokenCmd(f)
@@ -152,3 +200,101 @@ func TestAuthRemoveCmd_Structure(t *testing.T) {
 		t.Error("expected RunE to be set")
 	}
 }
+
+// recordingStore captures credentials written by refresh hooks
+type recordingStore struct {
+	mockCredentialsStore
+	saved map[string]secrets.Credentials
+}
+
+func (r *recordingStore) Set(name string, creds secrets.Credentials) error {
+	r.saved[name] = creds
+	return nil
+}
+
+func TestFactoryAPIConfig_ResponseCache(t *testing.T) {
+	dir := useTempCacheDir(t)
+	f := newTestFactory(t)
+	creds := testCredentials()
+
+	cfg := f.apiConfig(&recordingStore{}, "test-user", creds)
+	if cfg.ResponseCache == nil || cfg.ResponseCache.Dir() != filepath.Join(dir, "http") {
+		t.Fatalf("expected responses cached in the http category, got %+v", cfg.ResponseCache)
+	}
+
+	f.NoCache = true
+	if cfg := f.apiConfig(&recordingStore{}, "test-user", creds); cfg.ResponseCache != nil {
+		t.Error("expected no response cache with --no-cache")
+	}
+}
+
+func TestFactoryAPIConfig_RateLimitStore(t *testing.T) {
+	dir := useTempCacheDir(t)
+	f := newTestFactory(t)
+
+	cfg := f.apiConfig(&recordingStore{}, "test-user", testCredentials())
+	store, ok := cfg.RateLimitStore.(*api.FileRateLimitStore)
+	if !ok || store.Path() != filepath.Join(dir, "ratelimit", testCredentials().UserID+".json") {
+		t.Fatalf("expected rate limits kept by user ID in the ratelimit category, got %+v", cfg.RateLimitStore)
+	}
+
+	// Without a user ID, the account name keys the state
+	creds := testCredentials()
+	creds.UserID = ""
+	cfg = f.apiConfig(&recordingStore{}, "work/team", creds)
+	if store := cfg.RateLimitStore.(*api.FileRateLimitStore); store.Path() != filepath.Join(dir, "ratelimit", "work%2Fteam.json") {
+		t.Errorf("expected the account name escaped, got %s", store.Path())
+	}
+}
+
+func TestFactoryAPIConfig_PersistsRefreshedToken(t *testing.T) {
+	f := newTestFactory(t)
+	store := &recordingStore{saved: map[string]secrets.Credentials{}}
+	creds := testCredentials()
+
+	cfg := f.apiConfig(store, "test-user", creds)
+	if cfg.TokenRefreshWindow != tokenRefreshWindow {
+		t.Errorf("expected refresh window %s, got %s", tokenRefreshWindow, cfg.TokenRefreshWindow)
+	}
+
+	expiresAt := time.Now().Add(60 * 24 * time.Hour)
+	if err := cfg.OnTokenRefresh(&api.TokenInfo{AccessToken: "new-token", ExpiresAt: expiresAt}); err != nil {
+		t.Fatalf("OnTokenRefresh failed: %v", err)
+	}
+
+	saved, ok := store.saved["test-user"]
+	if !ok {
+		t.Fatal("expected refreshed credentials to be stored")
+	}
+	if saved.AccessToken != "new-token" || !saved.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("unexpected stored credentials: %+v", saved)
+	}
+	if saved.ClientSecret != creds.ClientSecret || saved.UserID != creds.UserID {
+		t.Error("expected other credential fields to be preserved")
+	}
+}
+
+func TestFactoryClient_AppAuthMode(t *testing.T) {
+	t.Setenv("THREADS_CLIENT_ID", "app-id")
+	t.Setenv("THREADS_CLIENT_SECRET", "app-secret")
+
+	var gotToken string
+	f := newTestFactory(t)
+	f.Config.AuthMode = config.AuthModeApp
+	f.NewClient = func(accessToken string, cfg *api.Config) (api.API, error) {
+		gotToken = accessToken
+		return &mockAPI{}, nil
+	}
+
+	if _, err := f.Client(context.Background()); err != nil {
+		t.Fatalf("Client failed: %v", err)
+	}
+	if gotToken != "app-id|app-secret" {
+		t.Errorf("expected app access token, got %q", gotToken)
+	}
+
+	t.Setenv("THREADS_CLIENT_SECRET", "")
+	if _, err := f.Client(context.Background()); err == nil {
+		t.Error("expected error without app credentials")
+	}
+}
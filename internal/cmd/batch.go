@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// Batch item outcomes
+const (
+	batchSucceeded = "succeeded"
+	batchFailed    = "failed"
+	batchSkipped   = "skipped"
+)
+
+// batchItem is the outcome of one item of a batch command
+type batchItem struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// batchResult records the outcome of every item of a batch command, so all
+// of them report partial failure the same way.
+type batchResult struct {
+	// Action is the verb of the summary, e.g. "hide"
+	Action    string      `json:"action"`
+	Succeeded int         `json:"succeeded"`
+	Failed    int         `json:"failed"`
+	Skipped   int         `json:"skipped"`
+	Items     []batchItem `json:"items"`
+	// Stopped tells why the batch ended before every item was processed
+	Stopped string `json:"stopped,omitempty"`
+
+	// noun names the items in messages, e.g. "replies"
+	noun string
+	// retry tells how to retry the failed items
+	retry string
+}
+
+func newBatchResult(action, noun string) *batchResult {
+	return &batchResult{
+		Action: action,
+		Items:  []batchItem{},
+		noun:   noun,
+		retry:  "Each failed item is listed with its reason; re-run with just those to retry",
+	}
+}
+
+func (r *batchResult) succeed(id string) {
+	r.Succeeded++
+	r.Items = append(r.Items, batchItem{ID: id, Status: batchSucceeded})
+}
+
+func (r *batchResult) fail(id string, err error) {
+	r.Failed++
+	r.Items = append(r.Items, batchItem{ID: id, Status: batchFailed, Reason: err.Error()})
+}
+
+func (r *batchResult) skip(id, reason string) {
+	r.Skipped++
+	r.Items = append(r.Items, batchItem{ID: id, Status: batchSkipped, Reason: reason})
+}
+
+// batchFailure is the cause of the error returned when items of a batch
+// failed; ExitCode maps it to ExitPartial.
+type batchFailure struct {
+	failed, total int
+}
+
+func (e *batchFailure) Error() string {
+	return fmt.Sprintf("%d of %d batch items failed", e.failed, e.total)
+}
+
+// writeBatchResult prints the items that did not succeed with a summary line,
+// or the whole result as JSON, and returns an error when any item failed so
+// the exit code shows it.
+func writeBatchResult(ctx context.Context, r *batchResult) error {
+	io := iocontext.GetIO(ctx)
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSONContext(ctx, io.Out, r); err != nil {
+			return err
+		}
+	} else {
+		// Successes are only counted, so long batches stay readable
+		var rows [][]string
+		for _, item := range r.Items {
+			if item.Status != batchSucceeded {
+				rows = append(rows, []string{item.ID, strings.ToUpper(item.Status), item.Reason})
+			}
+		}
+		if len(rows) > 0 {
+			out := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
+			if err := out.Table([]string{"ID", "STATUS", "REASON"}, rows, []outfmt.ColumnType{
+				outfmt.ColumnID, outfmt.ColumnStatus, outfmt.ColumnPlain,
+			}); err != nil {
+				return err
+			}
+			fmt.Fprintln(io.Out) //nolint:errcheck // Best-effort output
+		}
+		fmt.Fprintf(io.Out, "%d succeeded, %d failed, %d skipped\n", r.Succeeded, r.Failed, r.Skipped) //nolint:errcheck // Best-effort output
+	}
+
+	if r.Failed > 0 {
+		total := len(r.Items)
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Failed to %s %d of %d %s", r.Action, r.Failed, total, r.noun),
+			Suggestion: r.retry,
+			Cause:      &batchFailure{failed: r.Failed, total: total},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/bookmarks"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// bookmarksPath is the bookmark store location. It is replaced in tests.
+var bookmarksPath = func() string {
+	return filepath.Join(config.DataDir(), bookmarks.FileName)
+}
+
+// bookmarkExportFormats are the formats of 'bookmarks export'
+var bookmarkExportFormats = []string{"jsonl", "markdown"}
+
+// bookmarkView is the output model of a bookmark
+type bookmarkView struct {
+	b *bookmarks.Bookmark
+}
+
+func (v bookmarkView) viewFields() []viewField {
+	p := &v.b.Post
+	posted := viewField{Key: "posted", Value: p.Timestamp.Time, Text: "-", Type: outfmt.ColumnDate}
+	if !p.Timestamp.IsZero() {
+		posted.Text = p.Timestamp.Local().Format("2006-01-02 15:04")
+	}
+	return []viewField{
+		{Key: "id", Value: v.b.ID, Type: outfmt.ColumnID},
+		{Key: "username", Value: p.Username, Text: "@" + p.Username},
+		{Key: "text", Value: p.Text, Text: fallback(truncateText(strings.ReplaceAll(p.Text, "\n", " "), 50), "-")},
+		posted,
+		{Key: "added_at", Value: v.b.AddedAt, Text: v.b.AddedAt.Local().Format("2006-01-02 15:04"), Type: outfmt.ColumnDate},
+		{Key: "permalink", Value: p.Permalink},
+	}
+}
+
+// NewBookmarksCmd builds the bookmarks command group.
+func NewBookmarksCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bookmarks",
+		Short: "Save posts to read later",
+		Long: `Save posts to read later. Each bookmark keeps a snapshot of the post taken
+when it was added, in the data directory, so it can be listed, searched and
+exported without the API, even after the post is deleted.`,
+	}
+
+	cmd.AddCommand(newBookmarksAddCmd(f))
+	cmd.AddCommand(newBookmarksListCmd(f))
+	cmd.AddCommand(newBookmarksExportCmd(f))
+	cmd.AddCommand(newBookmarksRemoveCmd(f))
+
+	return cmd
+}
+
+func newBookmarksAddCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add [post-id|url]",
+		Short: "Bookmark a post",
+		Long: `Bookmark a post by ID or by its threads.net URL, saving a snapshot of it.
+Adding a post again refreshes the snapshot.
+
+A URL is looked up among the author's recent posts, which needs the
+threads_profile_discovery scope; use the post ID for older posts.
+
+Examples:
+  threads bookmarks add 12345678901234567
+  threads bookmarks add https://www.threads.net/@someone/post/C8abcDEFghi`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBookmarksAdd(cmd, f, args[0])
+		},
+	}
+}
+
+func runBookmarksAdd(cmd *cobra.Command, f *Factory, ref string) error {
+	ctx := cmd.Context()
+
+	client, err := f.Client(ctx)
+	if err != nil {
+		return err
+	}
+	post, err := resolvePost(ctx, client, ref)
+	if err != nil {
+		return err
+	}
+
+	path := bookmarksPath()
+	saved, err := bookmarks.Load(path)
+	if err != nil {
+		return WrapError("failed to read bookmarks", err)
+	}
+	saved, added := bookmarks.Put(saved, bookmarks.Bookmark{ID: post.ID, AddedAt: time.Now().UTC(), Post: *post})
+	if err := bookmarks.Save(path, saved); err != nil {
+		return WrapError("failed to save bookmarks", err)
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{"id": post.ID, "added": added})
+	}
+	if added {
+		f.UI(ctx).Success("Bookmarked %s by @%s", post.ID, post.Username)
+	} else {
+		f.UI(ctx).Success("Refreshed the snapshot of bookmark %s", post.ID)
+	}
+	return nil
+}
+
+func newBookmarksListCmd(f *Factory) *cobra.Command {
+	var search string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List bookmarks",
+		Long: `List bookmarks, oldest first, from their saved snapshots. No API requests
+are made.
+
+--search keeps bookmarks whose text, author, alt text or topic contains the
+given words, ignoring case; the post a bookmark quotes or reposts is searched
+too.
+
+Examples:
+  threads bookmarks list
+  threads bookmarks list --search "go generics"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			saved, err := bookmarks.Load(bookmarksPath())
+			if err != nil {
+				return WrapError("failed to read bookmarks", err)
+			}
+			views := []bookmarkView{}
+			for i := range saved {
+				if search == "" || saved[i].Matches(search) {
+					views = append(views, bookmarkView{b: &saved[i]})
+				}
+			}
+			return writeViewList(cmd.Context(), views, nil, "No bookmarks found")
+		},
+	}
+
+	cmd.Flags().StringVar(&search, "search", "", "Only list bookmarks containing this text")
+	return cmd
+}
+
+func newBookmarksExportCmd(f *Factory) *cobra.Command {
+	var format string
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export bookmarks with their snapshots",
+		Long: `Write every bookmark with its full post snapshot, as JSON lines (one
+bookmark per line) or as a Markdown reading list.
+
+Examples:
+  threads bookmarks export > bookmarks.jsonl
+  threads bookmarks export --format markdown --out reading-list.md`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBookmarksExport(cmd, f, format, out)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "jsonl", "Export format: "+strings.Join(bookmarkExportFormats, ", "))
+	cmd.Flags().StringVar(&out, "out", "", "File to write (default: stdout)")
+	return cmd
+}
+
+func runBookmarksExport(cmd *cobra.Command, f *Factory, format, out string) error {
+	ctx := cmd.Context()
+	var write func(w io.Writer, saved []bookmarks.Bookmark) error
+	switch format {
+	case "jsonl":
+		write = writeBookmarksJSONL
+	case "markdown":
+		write = writeBookmarksMarkdown
+	default:
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --format: %s", format),
+			Suggestion: "Use one of: " + strings.Join(bookmarkExportFormats, ", "),
+		}
+	}
+
+	saved, err := bookmarks.Load(bookmarksPath())
+	if err != nil {
+		return WrapError("failed to read bookmarks", err)
+	}
+
+	if out == "" {
+		return write(iocontext.GetIO(ctx).Out, saved)
+	}
+	file, err := os.Create(out) //nolint:gosec // Path is chosen by the user
+	if err != nil {
+		return WrapError("failed to create export file", err)
+	}
+	if err := write(file, saved); err != nil {
+		file.Close() //nolint:errcheck,gosec // Already failing
+		return WrapError("failed to write export file", err)
+	}
+	if err := file.Close(); err != nil {
+		return WrapError("failed to write export file", err)
+	}
+	f.UI(ctx).Success("Exported %s to %s", pluralize(len(saved), "bookmark", "bookmarks"), out)
+	return nil
+}
+
+func writeBookmarksJSONL(w io.Writer, saved []bookmarks.Bookmark) error {
+	enc := json.NewEncoder(w)
+	for _, b := range saved {
+		if err := enc.Encode(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeBookmarksMarkdown(w io.Writer, saved []bookmarks.Bookmark) error {
+	if _, err := fmt.Fprintln(w, "# Bookmarks"); err != nil {
+		return err
+	}
+	for _, b := range saved {
+		p := &b.Post
+		heading := "@" + p.Username
+		if !p.Timestamp.IsZero() {
+			heading += ", " + p.Timestamp.UTC().Format("2006-01-02 15:04 UTC")
+		}
+		if _, err := fmt.Fprintf(w, "\n## %s\n\n", heading); err != nil {
+			return err
+		}
+		if p.Text != "" {
+			if _, err := fmt.Fprintf(w, "%s\n\n", indent(p.Text, "> ")); err != nil {
+				return err
+			}
+		}
+		link := "Post " + b.ID
+		if p.Permalink != "" {
+			link = fmt.Sprintf("[Post %s](%s)", b.ID, p.Permalink)
+		}
+		if _, err := fmt.Fprintf(w, "%s, bookmarked %s\n", link, b.AddedAt.UTC().Format("2006-01-02")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newBookmarksRemoveCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove [post-id]",
+		Short: "Remove a bookmark and its snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			path := bookmarksPath()
+			saved, err := bookmarks.Load(path)
+			if err != nil {
+				return WrapError("failed to read bookmarks", err)
+			}
+			saved, removed := bookmarks.Remove(saved, args[0])
+			if !removed {
+				return &UserFriendlyError{
+					Message:    fmt.Sprintf("No bookmark for post %s", args[0]),
+					Suggestion: "List bookmarks with 'threads bookmarks list'",
+				}
+			}
+			if err := bookmarks.Save(path, saved); err != nil {
+				return WrapError("failed to save bookmarks", err)
+			}
+			if outfmt.IsJSON(ctx) {
+				return outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, map[string]any{"id": args[0], "removed": true})
+			}
+			f.UI(ctx).Success("Removed bookmark %s", args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/bookmarks"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// useTempBookmarks points the bookmark store at a temporary file
+func useTempBookmarks(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), bookmarks.FileName)
+	orig := bookmarksPath
+	bookmarksPath = func() string { return path }
+	t.Cleanup(func() { bookmarksPath = orig })
+	return path
+}
+
+func runBookmarksCmd(t *testing.T, f *Factory, io *iocontext.IO, ctx context.Context, args ...string) error {
+	t.Helper()
+	cmd := NewBookmarksCmd(f)
+	cmd.SetArgs(args)
+	cmd.SetContext(iocontext.WithIO(ctx, io))
+	return cmd.Execute()
+}
+
+func TestBookmarksAdd_ByIDAndURL(t *testing.T) {
+	path := useTempBookmarks(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{
+		getPost: func(_ context.Context, id api.PostID) (*api.Post, error) {
+			return &api.Post{ID: string(id), Username: "me", Text: "saved by ID"}, nil
+		},
+		getProfilePosts: func(_ context.Context, username string, _ *api.PostsOptions) (*api.PostsResponse, error) {
+			if username != "someone" {
+				t.Errorf("expected @someone's posts, got %q", username)
+			}
+			return &api.PostsResponse{Data: []api.Post{
+				{ID: "2", Shortcode: "Other"},
+				{ID: "3", Shortcode: "C8abc", Text: "saved by URL"},
+			}}, nil
+		},
+	})
+
+	ctx := context.Background()
+	if err := runBookmarksCmd(t, f, io, ctx, "add", "1"); err != nil {
+		t.Fatalf("add by ID failed: %v", err)
+	}
+	if err := runBookmarksCmd(t, f, io, ctx, "add", "https://www.threads.com/@someone/post/C8abc?xmt=1"); err != nil {
+		t.Fatalf("add by URL failed: %v", err)
+	}
+	err := runBookmarksCmd(t, f, io, ctx, "add", "https://www.threads.net/@someone/post/Missing")
+	if err == nil || !strings.Contains(err.Error(), "not among @someone's") {
+		t.Errorf("expected an unknown shortcode to be an error, got %v", err)
+	}
+
+	saved, err := bookmarks.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 2 || saved[0].Post.Text != "saved by ID" || saved[1].ID != "3" {
+		t.Errorf("unexpected bookmarks: %+v", saved)
+	}
+}
+
+func TestBookmarksList_SearchesOffline(t *testing.T) {
+	path := useTempBookmarks(t)
+	if err := bookmarks.Save(path, []bookmarks.Bookmark{
+		{ID: "1", Post: api.Post{ID: "1", Text: "Go generics explained"}},
+		{ID: "2", Post: api.Post{ID: "2", Text: "Sourdough starter"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// No client: listing needs no API
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	if err := runBookmarksCmd(t, f, io, outfmt.WithFormat(context.Background(), "json"), "list", "--search", "GENERICS"); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	var result struct {
+		Data []map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(result.Data) != 1 || result.Data[0]["id"] != "1" {
+		t.Errorf("unexpected bookmarks: %v", result.Data)
+	}
+}
+
+func TestBookmarksExport(t *testing.T) {
+	path := useTempBookmarks(t)
+	if err := bookmarks.Save(path, []bookmarks.Bookmark{
+		{ID: "1", Post: api.Post{ID: "1", Username: "gopher", Text: "line one\nline two", Permalink: "https://www.threads.net/@gopher/post/A"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	if err := runBookmarksCmd(t, f, io, context.Background(), "export"); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	var b bookmarks.Bookmark
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &b); err != nil || b.Post.Username != "gopher" {
+		t.Errorf("expected one JSON line with the snapshot, got %+v, %v", b, err)
+	}
+
+	out := filepath.Join(t.TempDir(), "reading-list.md")
+	if err := runBookmarksCmd(t, f, io, context.Background(), "export", "--format", "markdown", "--out", out); err != nil {
+		t.Fatalf("markdown export failed: %v", err)
+	}
+	data, err := os.ReadFile(out) //nolint:gosec // Test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"## @gopher", "> line one\n> line two", "[Post 1](https://www.threads.net/@gopher/post/A)"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected the reading list to contain %q, got:\n%s", want, data)
+		}
+	}
+
+	if err := runBookmarksCmd(t, f, io, context.Background(), "export", "--format", "csv"); err == nil {
+		t.Error("expected an unknown format to be an error")
+	}
+}
+
+func TestBookmarksRemove(t *testing.T) {
+	path := useTempBookmarks(t)
+	if err := bookmarks.Save(path, []bookmarks.Bookmark{{ID: "1"}}); err != nil {
+		t.Fatal(err)
+	}
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	if err := runBookmarksCmd(t, f, io, context.Background(), "remove", "1"); err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+	if saved, _ := bookmarks.Load(path); len(saved) != 0 {
+		t.Errorf("expected no bookmarks, got %+v", saved)
+	}
+	if err := runBookmarksCmd(t, f, io, context.Background(), "remove", "1"); err == nil {
+		t.Error("expected removing a missing bookmark to be an error")
+	}
+}
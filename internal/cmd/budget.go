@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/media"
+)
+
+// errBudgetSpent stops a long-running command at the end of the last unit of
+// work that fit in its budget
+var errBudgetSpent = errors.New("run budget spent")
+
+// budgetOptions caps what one run of an unattended command may spend, so a
+// cron job cannot use up the rate limit or fill the disk.
+type budgetOptions struct {
+	MaxAPICalls      int
+	MaxDownloadBytes string
+}
+
+func addBudgetFlags(cmd *cobra.Command, opts *budgetOptions) {
+	cmd.Flags().IntVar(&opts.MaxAPICalls, "max-api-calls", 0, "Stop cleanly after this many API requests (0 for no limit)")
+	cmd.Flags().StringVar(&opts.MaxDownloadBytes, "max-download-bytes", "", "Stop cleanly after downloading this much media, e.g. 500MB or 2GB")
+}
+
+// runBudget tracks one run's spending against its caps
+type runBudget struct {
+	calls    *api.CallBudget
+	maxCalls int
+	maxBytes int64
+	bytes    int64
+}
+
+// start validates the caps and returns a context whose API requests count
+// against them
+func (o *budgetOptions) start(ctx context.Context) (context.Context, *runBudget, error) {
+	if o.MaxAPICalls < 0 {
+		return nil, nil, &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --max-api-calls: %d", o.MaxAPICalls),
+			Suggestion: "Use a positive number, or 0 for no limit",
+		}
+	}
+	b := &runBudget{calls: api.NewCallBudget(o.MaxAPICalls), maxCalls: o.MaxAPICalls}
+	if o.MaxDownloadBytes != "" {
+		limit, err := media.ParseSize(o.MaxDownloadBytes)
+		if err != nil {
+			return nil, nil, &UserFriendlyError{
+				Message:    fmt.Sprintf("Invalid --max-download-bytes: %s", o.MaxDownloadBytes),
+				Suggestion: "Use a size such as 500MB or 2GB",
+			}
+		}
+		b.maxBytes = limit
+	}
+	return api.WithCallBudget(ctx, b.calls), b, nil
+}
+
+// downloaded counts n bytes of media fetched
+func (b *runBudget) downloaded(n int64) {
+	b.bytes += n
+}
+
+// spent explains which cap stopped the run, or returns "" while both have room
+func (b *runBudget) spent() string {
+	switch {
+	case b.calls.Exhausted() || (b.maxCalls > 0 && b.calls.Remaining() == 0):
+		return fmt.Sprintf("--max-api-calls %d reached", b.maxCalls)
+	case b.maxBytes > 0 && b.bytes >= b.maxBytes:
+		return fmt.Sprintf("--max-download-bytes %s reached (%s downloaded)", media.FormatSize(b.maxBytes), media.FormatSize(b.bytes))
+	}
+	return ""
+}
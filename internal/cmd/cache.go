@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/media"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/ui"
+)
+
+// defaultCacheMaxSize is the size 'cache prune' trims the media cache to
+const defaultCacheMaxSize = "1GB"
+
+// cacheDir is the root of all cached state. It is replaced in tests.
+var cacheDir = config.CacheDir
+
+// cacheCategory is one kind of local state kept under the cache directory
+type cacheCategory struct {
+	Name string
+	// Subdir is the category's directory under cacheDir
+	Subdir string
+	// Contents describes what the category holds
+	Contents string
+}
+
+func (c cacheCategory) Dir() string {
+	return filepath.Join(cacheDir(), c.Subdir)
+}
+
+// cacheCategories lists everything 'cache info' and 'cache clear' manage
+var cacheCategories = []cacheCategory{
+	{Name: "http", Subdir: "http", Contents: "Cached API responses"},
+	{Name: "seen", Subdir: "seen", Contents: "IDs already handled by watch and daemon modes"},
+	{Name: "events", Subdir: "events", Contents: "Webhook events received by 'webhooks serve'"},
+	{Name: "media", Subdir: mediaCacheSubdir, Contents: "Media saved with --download-media"},
+	{Name: "insights", Subdir: "insights", Contents: "Follower counts kept by 'insights rollup' to report growth"},
+	{Name: "ratelimit", Subdir: "ratelimit", Contents: "Rate limits and publishing quota shared by successive commands"},
+}
+
+func cacheCategoryNames() []string {
+	names := make([]string, len(cacheCategories))
+	for i, c := range cacheCategories {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// cacheUsage is the disk usage of a cache category
+type cacheUsage struct {
+	category cacheCategory
+	files    int
+	bytes    int64
+	oldest   time.Time
+	newest   time.Time
+}
+
+// measureCache walks a category's directory; a missing directory is empty
+func measureCache(c cacheCategory) (*cacheUsage, error) {
+	usage := &cacheUsage{category: c}
+	err := filepath.WalkDir(c.Dir(), func(_ string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		usage.files++
+		usage.bytes += info.Size()
+		if mod := info.ModTime(); usage.oldest.IsZero() || mod.Before(usage.oldest) {
+			usage.oldest = mod
+		}
+		if mod := info.ModTime(); mod.After(usage.newest) {
+			usage.newest = mod
+		}
+		return nil
+	})
+	return usage, err
+}
+
+func (u *cacheUsage) viewFields() []viewField {
+	age := func(t time.Time) viewField {
+		if t.IsZero() {
+			return viewField{Value: nil}
+		}
+		return viewField{Value: t.UTC(), Text: ui.FormatRelativeTime(t)}
+	}
+	oldest, newest := age(u.oldest), age(u.newest)
+	oldest.Key, newest.Key = "oldest", "newest"
+
+	return []viewField{
+		{Key: "category", Value: u.category.Name},
+		{Key: "files", Value: u.files},
+		{Key: "bytes", Value: u.bytes, Text: media.FormatSize(u.bytes)},
+		oldest,
+		newest,
+		{Key: "path", Value: u.category.Dir()},
+	}
+}
+
+// NewCacheCmd builds the cache command group.
+func NewCacheCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local cache",
+		Long: `Manage the state threads keeps in the local cache directory:
+
+  http   Cached API responses
+  seen   IDs already handled by watch and daemon modes
+  events Webhook events received by 'webhooks serve'
+  media  Media saved with --download-media
+
+Media is stored once per file content, so repeated archive runs skip files
+that haven't changed. Download directories hold links to the cached files.`,
+	}
+
+	cmd.AddCommand(newCacheInfoCmd(f))
+	cmd.AddCommand(newCacheClearCmd(f))
+	cmd.AddCommand(newCachePruneCmd(f))
+
+	return cmd
+}
+
+func newCacheInfoCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "info",
+		Short: "Show the size and age of each cache category",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			usages := make([]*cacheUsage, 0, len(cacheCategories))
+			for _, c := range cacheCategories {
+				usage, err := measureCache(c)
+				if err != nil {
+					return WrapError(fmt.Sprintf("failed to read %s cache", c.Name), err)
+				}
+				usages = append(usages, usage)
+			}
+			return writeViewList(ctx, usages, nil, "No cache categories")
+		},
+	}
+}
+
+func newCacheClearCmd(f *Factory) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "clear [category...]",
+		Short: "Delete cached state by category",
+		Long: `Delete everything in the given cache categories (` + strings.Join(cacheCategoryNames(), ", ") + `),
+or in all of them with --all. Requires confirmation unless --yes is set.
+
+Clearing "seen" makes watch and daemon modes handle old items again.
+
+Examples:
+  threads cache clear http
+  threads cache clear media seen --yes
+  threads cache clear --all`,
+		ValidArgs: cacheCategoryNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCacheClear(cmd, f, args, all)
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Clear every category")
+	return cmd
+}
+
+func runCacheClear(cmd *cobra.Command, f *Factory, names []string, all bool) error {
+	ctx := cmd.Context()
+
+	var selected []cacheCategory
+	switch {
+	case all && len(names) > 0:
+		return &UserFriendlyError{Message: "Cannot combine --all with category names"}
+	case all:
+		selected = cacheCategories
+	case len(names) == 0:
+		return &UserFriendlyError{
+			Message:    "No cache category given",
+			Suggestion: "Name one or more of: " + strings.Join(cacheCategoryNames(), ", ") + ", or use --all",
+		}
+	}
+	for _, name := range names {
+		found := false
+		for _, c := range cacheCategories {
+			if c.Name == name {
+				selected = append(selected, c)
+				found = true
+			}
+		}
+		if !found {
+			return &UserFriendlyError{
+				Message:    fmt.Sprintf("Unknown cache category: %s", name),
+				Suggestion: "Valid categories: " + strings.Join(cacheCategoryNames(), ", "),
+			}
+		}
+	}
+
+	labels := make([]string, len(selected))
+	for i, c := range selected {
+		labels[i] = c.Name
+	}
+	confirmed, err := f.Confirm(ctx, fmt.Sprintf("Clear the %s cache?", strings.Join(labels, ", ")))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Fprintln(iocontext.GetIO(ctx).Out, "Cancelled.") //nolint:errcheck // Best-effort output
+		return nil
+	}
+
+	type cleared struct {
+		Category string `json:"category"`
+		Files    int    `json:"files"`
+		Bytes    int64  `json:"bytes"`
+	}
+	var results []cleared
+	for _, c := range selected {
+		usage, err := measureCache(c)
+		if err != nil {
+			return WrapError(fmt.Sprintf("failed to read %s cache", c.Name), err)
+		}
+		if err := os.RemoveAll(c.Dir()); err != nil {
+			return WrapError(fmt.Sprintf("failed to clear %s cache", c.Name), err)
+		}
+		results = append(results, cleared{Category: c.Name, Files: usage.files, Bytes: usage.bytes})
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{"cleared": results})
+	}
+	for _, r := range results {
+		f.UI(ctx).Success("Cleared %s cache: %d files (%s)", r.Category, r.Files, media.FormatSize(r.Bytes))
+	}
+	return nil
+}
+
+func newCachePruneCmd(f *Factory) *cobra.Command {
+	var maxSize string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove least recently used media from the cache",
+		Long: `Remove the least recently used media from the cache until it fits in
+--max-size. Files already exported to a download directory are kept there;
+a later run downloads pruned media again.
+
+Examples:
+  threads cache prune
+  threads cache prune --max-size 200MB
+  threads cache prune --max-size 0 --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCachePrune(cmd, f, maxSize, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVar(&maxSize, "max-size", defaultCacheMaxSize, "Largest size to keep, e.g. 500MB or 2GB")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be removed without deleting")
+	return cmd
+}
+
+func runCachePrune(cmd *cobra.Command, f *Factory, maxSize string, dryRun bool) error {
+	ctx := cmd.Context()
+
+	limit, err := media.ParseSize(maxSize)
+	if err != nil {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --max-size: %s", maxSize),
+			Suggestion: "Use a size such as 500MB or 2GB",
+		}
+	}
+
+	cache, err := media.OpenCache(mediaCacheDir())
+	if err != nil {
+		return WrapError("failed to open media cache", err)
+	}
+	result, err := cache.Prune(limit, dryRun)
+	if err != nil {
+		return WrapError("failed to prune media cache", err)
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
+			"dir":             cache.Dir(),
+			"dry_run":         dryRun,
+			"removed":         result.Removed,
+			"freed_bytes":     result.FreedBytes,
+			"remaining":       result.Remaining,
+			"remaining_bytes": result.RemainingBytes,
+		})
+	}
+
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+	f.UI(ctx).Success("%s %d files (%s) from %s", verb, result.Removed, media.FormatSize(result.FreedBytes), cache.Dir())
+	fmt.Fprintf(io.Out, "  %d files (%s) remain\n", result.Remaining, media.FormatSize(result.RemainingBytes)) //nolint:errcheck // Best-effort output
+	return nil
+}
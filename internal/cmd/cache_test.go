@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// useTempCacheDir points the cache directory at a temporary directory
+func useTempCacheDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	orig := cacheDir
+	cacheDir = func() string { return dir }
+	t.Cleanup(func() { cacheDir = orig })
+	return dir
+}
+
+func TestCachePrune_EmptyCache(t *testing.T) {
+	dir := useTempCacheDir(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newCachePruneCmd(f)
+	cmd.SetArgs([]string{"--max-size", "0"})
+	ctx := outfmt.WithFormat(iocontext.WithIO(context.Background(), io), "json")
+	cmd.SetContext(ctx)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result["removed"] != float64(0) || result["dir"] != filepath.Join(dir, "media") {
+		t.Errorf("unexpected result: %v", result)
+	}
+}
+
+func TestCachePrune_InvalidSize(t *testing.T) {
+	useTempCacheDir(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newCachePruneCmd(f)
+	cmd.SetArgs([]string{"--max-size", "lots"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "Invalid --max-size") {
+		t.Fatalf("expected invalid size error, got %v", err)
+	}
+}
+
+func TestCacheInfo_ReportsCategories(t *testing.T) {
+	dir := useTempCacheDir(t)
+	if err := os.MkdirAll(filepath.Join(dir, "seen"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "seen", "mentions.json"), []byte("[1,2,3]"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newCacheInfoCmd(f)
+	cmd.SetArgs(nil)
+	cmd.SetContext(outfmt.WithFormat(iocontext.WithIO(context.Background(), io), "json"))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	var result struct {
+		Data []map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(result.Data) != len(cacheCategories) {
+		t.Fatalf("expected %d categories, got %d", len(cacheCategories), len(result.Data))
+	}
+	for _, c := range result.Data {
+		switch c["category"] {
+		case "seen":
+			if c["files"] != float64(1) || c["bytes"] != float64(7) || c["oldest"] == nil {
+				t.Errorf("unexpected seen usage: %v", c)
+			}
+		default:
+			if c["files"] != float64(0) || c["oldest"] != nil {
+				t.Errorf("expected empty category: %v", c)
+			}
+		}
+	}
+}
+
+func TestCacheClear_Categories(t *testing.T) {
+	dir := useTempCacheDir(t)
+	for _, sub := range []string{"http", "seen"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, sub, "state"), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newCacheClearCmd(f)
+	cmd.SetArgs([]string{"http"})
+	cmd.SetContext(outfmt.WithYes(iocontext.WithIO(context.Background(), io), true))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "http")); !os.IsNotExist(err) {
+		t.Errorf("http cache not cleared: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "seen", "state")); err != nil {
+		t.Errorf("seen cache should be kept: %v", err)
+	}
+	if out := io.Out.(*bytes.Buffer).String(); !strings.Contains(out, "Cleared http cache: 1 files") {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestCacheClear_RequiresCategory(t *testing.T) {
+	useTempCacheDir(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	for _, args := range [][]string{nil, {"nope"}, {"http", "--all"}} {
+		cmd := newCacheClearCmd(f)
+		cmd.SetArgs(args)
+		cmd.SetContext(outfmt.WithYes(iocontext.WithIO(context.Background(), io), true))
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("expected error for args %v", args)
+		}
+	}
+}
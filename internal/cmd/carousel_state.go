@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// carouselStateDir holds the progress of carousels being created, so a
+// failed one can be resumed. It is replaced in tests.
+var carouselStateDir = func() string {
+	return filepath.Join(config.DataDir(), "carousels")
+}
+
+// carouselState is the progress of one carousel, saved after each child
+type carouselState struct {
+	Account string             `json:"account,omitempty"`
+	Items   []api.CarouselItem `json:"items"`
+	api.CarouselProgress
+	Updated time.Time `json:"updated"`
+}
+
+// carouselStatePath returns the state file of the carousel of items posted
+// as account. Running the same command again finds the same file.
+func carouselStatePath(account string, items []api.CarouselItem) string {
+	key, _ := json.Marshal(struct { //nolint:errcheck // Strings only
+		Account string
+		Items   []api.CarouselItem
+	}{account, items})
+	sum := sha256.Sum256(key)
+	return filepath.Join(carouselStateDir(), hex.EncodeToString(sum[:8])+".json")
+}
+
+// readCarouselState loads the state at path; nil means there is none
+func readCarouselState(path string) (*carouselState, error) {
+	var state carouselState
+	err := statefile.ReadJSON(path, "carousel", 1, &state)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid carousel progress: %s", path),
+			Suggestion: "Drop --resume to start the carousel over",
+			Cause:      err,
+		}
+	}
+	return &state, nil
+}
+
+// writeCarouselState replaces the state at path atomically
+func writeCarouselState(path string, state *carouselState) error {
+	state.Updated = time.Now().UTC()
+	if err := statefile.WriteJSON(path, "carousel", 1, state); err != nil {
+		return WrapError("failed to save the carousel progress", err)
+	}
+	return nil
+}
+
+// removeCarouselState deletes the state at path once the carousel is posted
+func removeCarouselState(path string) error {
+	if err := statefile.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove the carousel progress: %w", err)
+	}
+	return nil
+}
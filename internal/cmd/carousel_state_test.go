@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+func TestPostsCarousel_Resume(t *testing.T) {
+	dir := t.TempDir()
+	orig := carouselStateDir
+	carouselStateDir = func() string { return dir }
+	t.Cleanup(func() { carouselStateDir = orig })
+
+	// The third image fails until fixed; children are "c-" and the file name
+	var fixed atomic.Bool
+	var created atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "refresh_access_token"):
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/threads"):
+			if r.FormValue("media_type") == "CAROUSEL" {
+				_, _ = w.Write([]byte(`{"id":"c-carousel"}`))
+				return
+			}
+			name := strings.TrimSuffix(path.Base(r.FormValue("image_url")), ".jpg")
+			if name == "3" && !fixed.Load() {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":{"message":"Invalid image","type":"OAuthException","code":100}}`))
+				return
+			}
+			created.Add(1)
+			_, _ = w.Write([]byte(`{"id":"c-` + name + `"}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/threads_publish"):
+			_, _ = w.Write([]byte(`{"id":"p1"}`))
+		case strings.HasPrefix(r.URL.Path, "/c-"):
+			_, _ = w.Write([]byte(`{"id":"` + strings.TrimPrefix(r.URL.Path, "/") + `","status":"FINISHED"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"p1","permalink":"https://www.threads.net/t/p1"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	f, io := newIntegrationTestFactory(t, server.URL)
+
+	run := func(args ...string) error {
+		cmd := newPostsCarouselCmd(f)
+		cmd.SetArgs(append([]string{"--items", "https://example.com/1.jpg,https://example.com/2.jpg,https://example.com/3.jpg"}, args...))
+		cmd.SetContext(iocontext.WithIO(context.Background(), io))
+		return cmd.Execute()
+	}
+
+	if err := run("--resume"); err == nil || !strings.Contains(err.Error(), "No failed carousel") {
+		t.Fatalf("expected nothing to resume, got %v", err)
+	}
+	err := run()
+	if err == nil || !strings.Contains(FormatError(err).Error(), "after 2 of 3 items") {
+		t.Fatalf("expected the third item to fail, got %v", err)
+	}
+	if states, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(states) != 1 {
+		t.Fatalf("expected the progress saved, got %d files", len(states))
+	}
+
+	fixed.Store(true)
+	if err := run("--resume"); err != nil {
+		t.Fatal(err)
+	}
+	if created.Load() != 3 {
+		t.Errorf("expected each item created once, got %d", created.Load())
+	}
+	if !strings.Contains(io.Out.(*bytes.Buffer).String(), "Reused 2 items") {
+		t.Errorf("expected the reuse reported, got %q", io.Out.(*bytes.Buffer).String())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected the progress removed after posting, got %d files", len(entries))
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/events"
+)
+
+// labelPattern is what a label may look like after lowercasing
+var labelPattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// classifyReply returns the labels the classifier in the 'classifier'
+// config key gives reply, such as positive, negative, question or spam. A
+// URL is sent the reply as a JSON POST body; anything else runs in the shell
+// with the reply as JSON on stdin. Either answers with {"labels": [...]} or
+// with labels separated by commas or whitespace.
+func classifyReply(ctx context.Context, classifier string, reply *api.Post) ([]string, error) {
+	body, err := json.Marshal(reply)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := callExternal(ctx, classifier, body)
+	if err != nil {
+		return nil, err
+	}
+	return parseLabels(out)
+}
+
+// parseLabels reads a classifier's answer as lowercase, unique labels
+func parseLabels(out []byte) ([]string, error) {
+	var words []string
+	if trimmed := bytes.TrimSpace(out); len(trimmed) > 0 && trimmed[0] == '{' {
+		var answer struct {
+			Labels []string `json:"labels"`
+		}
+		if err := json.Unmarshal(trimmed, &answer); err != nil {
+			return nil, fmt.Errorf("invalid classifier answer: %w", err)
+		}
+		words = answer.Labels
+	} else {
+		words = strings.FieldsFunc(string(out), func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+		})
+	}
+
+	labels := []string{}
+	for _, word := range words {
+		label := strings.ToLower(strings.TrimSpace(word))
+		if !labelPattern.MatchString(label) {
+			return nil, fmt.Errorf("invalid label %q: use letters, digits, - and _", word)
+		}
+		if !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	return labels, nil
+}
+
+// labelReplyEvent tags a new reply with the labels of the configured
+// classifier before it is published, so hooks can act on them. A failing
+// classifier is a warning and the reply goes out unlabeled.
+func (f *Factory) labelReplyEvent(ctx context.Context, e events.Event) (events.Event, error) {
+	reply, ok := e.(events.NewReply)
+	if !ok || f.Config.Classifier == "" {
+		return e, nil
+	}
+	labels, err := classifyReply(ctx, f.Config.Classifier, &reply.Reply)
+	if err != nil {
+		return e, warn(ctx, &UserFriendlyError{
+			Message:    fmt.Sprintf("Could not classify reply %s: %v", reply.Reply.ID, err),
+			Suggestion: "Check the command with 'threads config get classifier'",
+			Cause:      err,
+		})
+	}
+	reply.Labels = labels
+	return reply, nil
+}
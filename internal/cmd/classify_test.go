@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/events"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+func TestParseLabels(t *testing.T) {
+	tests := []struct {
+		out  string
+		want []string
+	}{
+		{`{"labels": ["Question", "spam"]}`, []string{"question", "spam"}},
+		{"positive, question\nPOSITIVE\n", []string{"positive", "question"}},
+		{"", []string{}},
+	}
+	for _, tt := range tests {
+		got, err := parseLabels([]byte(tt.out))
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("parseLabels(%q) = %v, %v; want %v", tt.out, got, err, tt.want)
+		}
+	}
+
+	for _, out := range []string{`{"labels": "spam"}`, "not/a label"} {
+		if _, err := parseLabels([]byte(out)); err == nil {
+			t.Errorf("parseLabels(%q): expected an error", out)
+		}
+	}
+}
+
+func TestClassifyReply_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("classifier commands in tests use sh")
+	}
+	ctx := iocontext.WithIO(context.Background(), newTestFactory(t).IO)
+
+	// The reply arrives as JSON on stdin
+	classifier := `grep -q '"text":"why?"' && echo question || echo positive`
+	labels, err := classifyReply(ctx, classifier, &api.Post{ID: "1", Text: "why?"})
+	if err != nil || !slices.Equal(labels, []string{"question"}) {
+		t.Errorf("got %v, %v", labels, err)
+	}
+
+	if _, err := classifyReply(ctx, "exit 3", &api.Post{ID: "1"}); err == nil {
+		t.Error("expected a failing command to be an error")
+	}
+}
+
+func TestClassifyReply_HTTP(t *testing.T) {
+	var received api.Post
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &received)        //nolint:errcheck,gosec // Checked through received
+		w.Write([]byte(`{"labels":["spam"]}`)) //nolint:errcheck,gosec // Test server
+	}))
+	defer server.Close()
+
+	labels, err := classifyReply(context.Background(), server.URL, &api.Post{ID: "r1", Text: "buy now"})
+	if err != nil || !slices.Equal(labels, []string{"spam"}) {
+		t.Errorf("got %v, %v", labels, err)
+	}
+	if received.ID != "r1" || received.Text != "buy now" {
+		t.Errorf("expected the reply to be posted, got %+v", received)
+	}
+}
+
+func TestLabelReplyEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("negative")) //nolint:errcheck,gosec // Test server
+	}))
+	defer server.Close()
+
+	f := newTestFactory(t)
+	ctx := iocontext.WithIO(context.Background(), f.IO)
+	mention := events.NewMention{Post: api.Post{ID: "1"}}
+	if e, _ := f.labelReplyEvent(ctx, mention); e.Type() != events.TypeNewMention {
+		t.Errorf("expected mentions to pass through, got %+v", e)
+	}
+
+	f.Config.Classifier = server.URL
+	e, err := f.labelReplyEvent(ctx, events.NewReply{Reply: api.Post{ID: "2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply := e.(events.NewReply); !slices.Equal(reply.Labels, []string{"negative"}) {
+		t.Errorf("unexpected labels: %v", reply.Labels)
+	}
+	if values := hookValues(e); values["labels"] != "negative" {
+		t.Errorf("expected hooks to see the labels, got %q", values["labels"])
+	}
+}
@@ -2,12 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/salmonumbrella/threads-cli/internal/auth"
 	"github.com/salmonumbrella/threads-cli/internal/config"
 	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/notify"
 	"github.com/salmonumbrella/threads-cli/internal/outfmt"
 )
 
@@ -24,6 +28,8 @@ func NewConfigCmd(f *Factory) *cobra.Command {
 	cmd.AddCommand(newConfigGetCmd())
 	cmd.AddCommand(newConfigSetCmd(f))
 	cmd.AddCommand(newConfigUnsetCmd(f))
+	cmd.AddCommand(newConfigLintCmd())
+	cmd.AddCommand(newConfigEnvCmd())
 
 	return cmd
 }
@@ -52,13 +58,26 @@ func newConfigListCmd() *cobra.Command {
 
 			io := iocontext.GetIO(cmd.Context())
 			if outfmt.IsJSON(cmd.Context()) {
-				return outfmt.WriteJSONTo(io.Out, configToMap(cfg), outfmt.GetQuery(cmd.Context()))
+				return outfmt.WriteJSONContext(cmd.Context(), io.Out, configToMap(cfg))
 			}
 
-			fmt.Fprintf(io.Out, "Account: %s\n", fallback(cfg.Account, "(none)")) //nolint:errcheck // Best-effort output
-			fmt.Fprintf(io.Out, "Output:  %s\n", fallback(cfg.Output, "text"))    //nolint:errcheck // Best-effort output
-			fmt.Fprintf(io.Out, "Color:   %s\n", fallback(cfg.Color, "auto"))     //nolint:errcheck // Best-effort output
-			fmt.Fprintf(io.Out, "Debug:   %v\n", cfg.Debug)                       //nolint:errcheck // Best-effort output
+			fmt.Fprintf(io.Out, "Account:   %s\n", fallback(cfg.Account, "(none)"))             //nolint:errcheck // Best-effort output
+			fmt.Fprintf(io.Out, "Output:    %s\n", fallback(cfg.Output, "text"))                //nolint:errcheck // Best-effort output
+			fmt.Fprintf(io.Out, "Color:     %s\n", fallback(cfg.Color, "auto"))                 //nolint:errcheck // Best-effort output
+			fmt.Fprintf(io.Out, "Debug:     %v\n", cfg.Debug)                                   //nolint:errcheck // Best-effort output
+			fmt.Fprintf(io.Out, "Footer:    %v\n", cfg.Footer)                                  //nolint:errcheck // Best-effort output
+			fmt.Fprintf(io.Out, "Strict:    %v\n", cfg.Strict)                                  //nolint:errcheck // Best-effort output
+			fmt.Fprintf(io.Out, "Auth mode: %s\n", fallback(cfg.AuthMode, config.AuthModeUser)) //nolint:errcheck // Best-effort output
+			for _, event := range sortedHookEvents(cfg.Hooks) {
+				fmt.Fprintf(io.Out, "Hook %s: %s\n", event, cfg.Hooks[event]) //nolint:errcheck // Best-effort output
+			}
+			for _, event := range sortedHookEvents(cfg.Notify) {
+				value := cfg.Notify[event]
+				if !strings.HasPrefix(event, "email.") {
+					value = describeNotify(value)
+				}
+				fmt.Fprintf(io.Out, "Notify %s: %s\n", event, value) //nolint:errcheck // Best-effort output
+			}
 			return nil
 		},
 	}
@@ -81,13 +100,13 @@ func newConfigGetCmd() *cobra.Command {
 			if !ok {
 				return &UserFriendlyError{
 					Message:    fmt.Sprintf("Unknown config key: %s", key),
-					Suggestion: "Valid keys: account, output, color, debug, path",
+					Suggestion: "Valid keys: " + strings.Join(config.Keys(), ", ") + ", path",
 				}
 			}
 
 			io := iocontext.GetIO(cmd.Context())
 			if outfmt.IsJSON(cmd.Context()) {
-				return outfmt.WriteJSONTo(io.Out, map[string]any{key: value}, outfmt.GetQuery(cmd.Context()))
+				return outfmt.WriteJSONContext(cmd.Context(), io.Out, map[string]any{key: value})
 			}
 
 			fmt.Fprintln(io.Out, value) //nolint:errcheck // Best-effort output
@@ -105,6 +124,19 @@ func newConfigSetCmd(f *Factory) *cobra.Command {
 			key := strings.ToLower(args[0])
 			value := args[1]
 
+			if key == smtpPasswordKey {
+				if value == "" {
+					return &UserFriendlyError{
+						Message:    "The SMTP password cannot be empty",
+						Suggestion: "Remove it with 'threads config unset " + smtpPasswordKey + "'",
+					}
+				}
+				if err := setSMTPPassword(cmd.Context(), f, value); err != nil {
+					return err
+				}
+				return writeSecretUpdated(cmd, key, "Stored "+key+" in the credential store")
+			}
+
 			cfg, err := config.LoadFile(config.ConfigPath())
 			if err != nil {
 				return err
@@ -122,10 +154,10 @@ func newConfigSetCmd(f *Factory) *cobra.Command {
 
 			io := iocontext.GetIO(cmd.Context())
 			if outfmt.IsJSON(cmd.Context()) {
-				return outfmt.WriteJSONTo(io.Out, map[string]any{
+				return outfmt.WriteJSONContext(cmd.Context(), io.Out, map[string]any{
 					"success": true,
 					"config":  configToMap(cfg),
-				}, outfmt.GetQuery(cmd.Context()))
+				})
 			}
 
 			fmt.Fprintf(io.Out, "Updated %s\n", key) //nolint:errcheck // Best-effort output
@@ -142,6 +174,13 @@ func newConfigUnsetCmd(f *Factory) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			key := strings.ToLower(args[0])
 
+			if key == smtpPasswordKey {
+				if err := setSMTPPassword(cmd.Context(), f, ""); err != nil {
+					return WrapError("failed to remove the SMTP password", err)
+				}
+				return writeSecretUpdated(cmd, key, "Removed "+key+" from the credential store")
+			}
+
 			cfg, err := config.LoadFile(config.ConfigPath())
 			if err != nil {
 				return err
@@ -159,10 +198,10 @@ func newConfigUnsetCmd(f *Factory) *cobra.Command {
 
 			io := iocontext.GetIO(cmd.Context())
 			if outfmt.IsJSON(cmd.Context()) {
-				return outfmt.WriteJSONTo(io.Out, map[string]any{
+				return outfmt.WriteJSONContext(cmd.Context(), io.Out, map[string]any{
 					"success": true,
 					"config":  configToMap(cfg),
-				}, outfmt.GetQuery(cmd.Context()))
+				})
 			}
 
 			fmt.Fprintf(io.Out, "Unset %s\n", key) //nolint:errcheck // Best-effort output
@@ -171,17 +210,167 @@ func newConfigUnsetCmd(f *Factory) *cobra.Command {
 	}
 }
 
+func newConfigLintCmd() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check the config file for problems",
+		Long: `Check the config file against the config schema.
+
+Reports unknown keys, values of the wrong type, invalid values for keys
+with a fixed set of choices, and deprecated keys with migration hints.
+Exits with an error if any problem other than a deprecation is found.`,
+		Example: `  threads config lint
+  threads config lint --file ./config.json -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if path == "" {
+				path = config.ConfigPath()
+			}
+
+			issues, err := config.LintFile(path)
+			if err != nil {
+				return WrapError("failed to read config file", err)
+			}
+
+			io := iocontext.GetIO(cmd.Context())
+			if outfmt.IsJSON(cmd.Context()) {
+				if issues == nil {
+					issues = []config.Issue{}
+				}
+				if err := outfmt.WriteJSONContext(cmd.Context(), io.Out, map[string]any{
+					"path":   path,
+					"valid":  !config.HasErrors(issues),
+					"issues": issues,
+				}); err != nil {
+					return err
+				}
+			} else {
+				for _, issue := range issues {
+					fmt.Fprintln(io.Out, issue.String()) //nolint:errcheck // Best-effort output
+				}
+				if len(issues) == 0 {
+					fmt.Fprintf(io.Out, "No problems found in %s\n", path) //nolint:errcheck // Best-effort output
+				}
+			}
+
+			if config.HasErrors(issues) {
+				return &UserFriendlyError{
+					Message:    fmt.Sprintf("Config file %s has problems", path),
+					Suggestion: "Fix the reported keys with 'threads config set' or 'threads config unset', or edit the file directly",
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "file", "", "Config file to check (default: the active config file)")
+	return cmd
+}
+
+func newConfigEnvCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "env",
+		Short: "Show the effective configuration and where each value comes from",
+		Long: `Show the effective value of every config key and its source.
+
+Values are resolved in order of precedence: command-line flag, environment
+variable, config file, then built-in default. Every key can be set through
+the environment variable shown in the ENV column.`,
+		Example: `  threads config env
+  THREADS_OUTPUT=json threads config env
+  threads config env --color never -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings, err := config.Resolve(config.ConfigPath())
+			if err != nil {
+				return err
+			}
+
+			// Global flags win over everything else
+			for i, setting := range settings {
+				flag := cmd.Flags().Lookup(strings.ReplaceAll(setting.Key, "_", "-"))
+				if flag == nil || !flag.Changed {
+					continue
+				}
+				settings[i].Source = config.SourceFlag
+				settings[i].Value = flag.Value.String()
+				if flag.Value.Type() == "bool" {
+					settings[i].Value = flag.Value.String() == "true"
+				}
+			}
+
+			ctx := cmd.Context()
+			io := iocontext.GetIO(ctx)
+			if outfmt.IsJSON(ctx) {
+				return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
+					"path":     config.ConfigPath(),
+					"settings": settings,
+				})
+			}
+
+			fmt.Fprintf(io.Out, "Config file: %s\n\n", config.ConfigPath()) //nolint:errcheck // Best-effort output
+			fmtr := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
+			fmtr.Header("KEY", "VALUE", "SOURCE", "ENV")
+			for _, setting := range settings {
+				value := fmt.Sprint(setting.Value)
+				if value == "" {
+					value = "(none)"
+				}
+				fmtr.Row(setting.Key, value, setting.Source, setting.EnvVar)
+			}
+			fmtr.Flush()
+			return nil
+		},
+	}
+}
+
+// smtpPasswordKey is set and unset like a config key but kept in the
+// credential store
+const smtpPasswordKey = "notify.email.password"
+
+// writeSecretUpdated reports a change to the secret at key, set through
+// config set or unset, which leaves the config file as it was
+func writeSecretUpdated(cmd *cobra.Command, key, message string) error {
+	io := iocontext.GetIO(cmd.Context())
+	if outfmt.IsJSON(cmd.Context()) {
+		return outfmt.WriteJSONContext(cmd.Context(), io.Out, map[string]any{"success": true, "key": key})
+	}
+	fmt.Fprintln(io.Out, message) //nolint:errcheck // Best-effort output
+	return nil
+}
+
 func configToMap(cfg *config.Config) map[string]any {
 	return map[string]any{
-		"account": cfg.Account,
-		"output":  cfg.Output,
-		"color":   cfg.Color,
-		"debug":   cfg.Debug,
-		"path":    config.ConfigPath(),
+		"account":       cfg.Account,
+		"output":        cfg.Output,
+		"color":         cfg.Color,
+		"debug":         cfg.Debug,
+		"footer":        cfg.Footer,
+		"strict":        cfg.Strict,
+		"auth_mode":     fallback(cfg.AuthMode, config.AuthModeUser),
+		"callback_host": fallback(cfg.CallbackHost, auth.DefaultCallbackHost),
+		"callback_port": callbackPort(cfg),
+		"signature":     cfg.Signature,
+		"shortener":     cfg.Shortener,
+		"classifier":    cfg.Classifier,
+		"translator":    cfg.Translator,
+		"hooks":         cfg.Hooks,
+		"notify":        cfg.Notify,
+		"path":          config.ConfigPath(),
 	}
 }
 
 func configValue(cfg *config.Config, key string) (any, bool) {
+	if event, ok := strings.CutPrefix(key, "hooks."); ok {
+		return cfg.Hooks[event], slices.Contains(config.HookEvents, event)
+	}
+	if event, ok := strings.CutPrefix(key, "notify."); ok {
+		name, isEmail := strings.CutPrefix(event, "email.")
+		return cfg.Notify[event], slices.Contains(config.HookEvents, event) || (isEmail && slices.Contains(notify.EmailSettings, name))
+	}
+
 	switch key {
 	case "account":
 		return cfg.Account, true
@@ -191,6 +380,28 @@ func configValue(cfg *config.Config, key string) (any, bool) {
 		return cfg.Color, true
 	case "debug":
 		return cfg.Debug, true
+	case "footer":
+		return cfg.Footer, true
+	case "strict":
+		return cfg.Strict, true
+	case "auth_mode":
+		return fallback(cfg.AuthMode, config.AuthModeUser), true
+	case "callback_host":
+		return fallback(cfg.CallbackHost, auth.DefaultCallbackHost), true
+	case "callback_port":
+		return callbackPort(cfg), true
+	case "signature":
+		return cfg.Signature, true
+	case "shortener":
+		return cfg.Shortener, true
+	case "classifier":
+		return cfg.Classifier, true
+	case "translator":
+		return cfg.Translator, true
+	case "hooks":
+		return cfg.Hooks, true
+	case "notify":
+		return cfg.Notify, true
 	case "path":
 		return config.ConfigPath(), true
 	default:
@@ -199,24 +410,28 @@ func configValue(cfg *config.Config, key string) (any, bool) {
 }
 
 func applyConfigValue(cfg *config.Config, key, value string) error {
+	if event, ok := strings.CutPrefix(key, "hooks."); ok {
+		return setHook(cfg, event, value)
+	}
+	if event, ok := strings.CutPrefix(key, "notify."); ok {
+		return setNotify(cfg, event, value)
+	}
+
+	if field, ok := config.LookupField(key); ok && len(field.Enum) > 0 {
+		if err := field.ValidateValue(value); err != nil {
+			return &UserFriendlyError{
+				Message:    fmt.Sprintf("Invalid %s value: %s", key, value),
+				Suggestion: "Valid values: " + strings.Join(field.Enum, ", "),
+			}
+		}
+	}
+
 	switch key {
 	case "account":
 		cfg.Account = value
 	case "output":
-		if value != "" && value != "text" && value != "json" {
-			return &UserFriendlyError{
-				Message:    fmt.Sprintf("Invalid output value: %s", value),
-				Suggestion: "Valid values: text, json",
-			}
-		}
 		cfg.Output = value
 	case "color":
-		if value != "" && value != "auto" && value != "always" && value != "never" {
-			return &UserFriendlyError{
-				Message:    fmt.Sprintf("Invalid color value: %s", value),
-				Suggestion: "Valid values: auto, always, never",
-			}
-		}
 		cfg.Color = value
 	case "debug":
 		if value == "" {
@@ -228,10 +443,68 @@ func applyConfigValue(cfg *config.Config, key, value string) error {
 			return err
 		}
 		cfg.Debug = parsed
+	case "footer":
+		if value == "" {
+			cfg.Footer = false
+			return nil
+		}
+		parsed, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		cfg.Footer = parsed
+	case "strict":
+		if value == "" {
+			cfg.Strict = false
+			return nil
+		}
+		parsed, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		cfg.Strict = parsed
+	case "auth_mode":
+		cfg.AuthMode = value
+	case "callback_host":
+		cfg.CallbackHost = value
+	case "callback_port":
+		if value == "" {
+			cfg.CallbackPort = 0
+			return nil
+		}
+		port, err := parsePort(value)
+		if err != nil {
+			return err
+		}
+		cfg.CallbackPort = port
+	case "signature":
+		cfg.Signature = value
+	case "shortener":
+		cfg.Shortener = value
+	case "classifier":
+		cfg.Classifier = value
+	case "translator":
+		cfg.Translator = value
+	case "hooks":
+		if value != "" {
+			return &UserFriendlyError{
+				Message:    "Hooks are set one event at a time",
+				Suggestion: "Use 'threads config set hooks.<event> <command>', with events " + strings.Join(config.HookEvents, ", "),
+			}
+		}
+		cfg.Hooks = nil
+	case "notify":
+		if value != "" {
+			return &UserFriendlyError{
+				Message:    "Notifications are set one event at a time",
+				Suggestion: "Use 'threads config set notify.<event> <url>', with events " + strings.Join(config.HookEvents, ", "),
+			}
+		}
+		cfg.Notify = nil
 	default:
 		return &UserFriendlyError{
 			Message:    fmt.Sprintf("Unknown config key: %s", key),
-			Suggestion: "Valid keys: account, output, color, debug",
+			Suggestion: "Valid keys: " + strings.Join(config.Keys(), ", "),
 		}
 	}
 	return nil
@@ -251,6 +524,25 @@ func parseBool(value string) (bool, error) {
 	}
 }
 
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid port: %s", value),
+			Suggestion: "Use a number from 1 to 65535",
+		}
+	}
+	return port, nil
+}
+
+// callbackPort returns the port auth login listens on for the OAuth redirect
+func callbackPort(cfg *config.Config) int {
+	if cfg.CallbackPort == 0 {
+		return auth.DefaultCallbackPort
+	}
+	return cfg.CallbackPort
+}
+
 func fallback(value, def string) string {
 	if value == "" {
 		return def
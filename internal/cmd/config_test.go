@@ -1,6 +1,16 @@
 package cmd
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
 
 func TestConfigCmd_Structure(t *testing.T) {
 	f := newTestFactory(t)
@@ -25,6 +35,8 @@ func TestConfigCmd_Subcommands(t *testing.T) {
 		"get":   true,
 		"set":   true,
 		"unset": true,
+		"lint":  true,
+		"env":   true,
 	}
 
 	for _, sub := range cmd.Commands() {
@@ -39,3 +51,172 @@ func TestConfigCmd_Subcommands(t *testing.T) {
 		t.Errorf("missing subcommand: %s", name)
 	}
 }
+
+func TestApplyConfigValue_AuthMode(t *testing.T) {
+	cfg := config.Default()
+
+	if err := applyConfigValue(cfg, "auth_mode", "app"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AuthMode != config.AuthModeApp {
+		t.Errorf("expected auth_mode=app, got %q", cfg.AuthMode)
+	}
+
+	if err := applyConfigValue(cfg, "auth_mode", "robot"); err == nil {
+		t.Error("expected error for invalid auth_mode")
+	}
+
+	if err := applyConfigValue(cfg, "auth_mode", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value, _ := configValue(cfg, "auth_mode"); value != config.AuthModeUser {
+		t.Errorf("expected unset auth_mode to read as user, got %v", value)
+	}
+}
+
+func TestApplyConfigValue_Signature(t *testing.T) {
+	cfg := config.Default()
+
+	if err := applyConfigValue(cfg, "signature", "posted via threads-cli {version}"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value, _ := configValue(cfg, "signature"); value != "posted via threads-cli {version}" {
+		t.Errorf("expected the signature set, got %v", value)
+	}
+
+	if err := applyConfigValue(cfg, "signature", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Signature != "" {
+		t.Errorf("expected the signature unset, got %q", cfg.Signature)
+	}
+}
+
+func TestConfigSet_Shortener(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("THREADS_CONFIG", filepath.Join(dir, "config.json"))
+	f := newTestFactory(t)
+
+	endpoint := "https://is.gd/create.php?format=simple&url={url}"
+	cmd := NewConfigCmd(f)
+	cmd.SetArgs([]string{"set", "shortener", endpoint})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := configValue(cfg, "shortener"); value != endpoint {
+		t.Errorf("expected the shortener saved, got %v", value)
+	}
+}
+
+func TestApplyConfigValue_CallbackPort(t *testing.T) {
+	cfg := config.Default()
+
+	if err := applyConfigValue(cfg, "callback_port", "9000"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.CallbackPort != 9000 {
+		t.Errorf("expected callback_port=9000, got %d", cfg.CallbackPort)
+	}
+
+	for _, value := range []string{"http", "0", "65536"} {
+		if err := applyConfigValue(cfg, "callback_port", value); err == nil {
+			t.Errorf("expected error for callback_port %q", value)
+		}
+	}
+
+	if err := applyConfigValue(cfg, "callback_port", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value, _ := configValue(cfg, "callback_port"); value != 8585 {
+		t.Errorf("expected unset callback_port to read as 8585, got %v", value)
+	}
+}
+
+func TestConfigLint_ReportsIssues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"output":"xml","colour":"never"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	cmd := newConfigLintCmd()
+	cmd.SetArgs([]string{"--file", path})
+	cmd.SetContext(iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &bytes.Buffer{}}))
+
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected an error for an invalid config")
+	}
+	got := out.String()
+	for _, want := range []string{`error: colour: unknown key (did you mean "color"?)`, `error: output: invalid value "xml" (valid values: text, json, ids, csv, tsv)`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestConfigLint_Clean(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"output":"json"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	cmd := newConfigLintCmd()
+	cmd.SetArgs([]string{"--file", path})
+	cmd.SetContext(iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &bytes.Buffer{}}))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "No problems found") {
+		t.Errorf("unexpected output: %s", out.String())
+	}
+}
+
+func TestConfigEnv_ShowsSources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"version":1,"account":"personal"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("THREADS_CONFIG", path)
+	t.Setenv("THREADS_ACCOUNT", "")
+	t.Setenv("THREADS_COLOR", "")
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("THREADS_OUTPUT", "")
+	t.Setenv("THREADS_AUTH_MODE", "app")
+
+	f := newTestFactory(t)
+	var out bytes.Buffer
+	f.IO.Out = &out
+
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"config", "env", "--color", "never"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := out.String()
+	for _, want := range [][]string{
+		{"account", "personal", "file"},
+		{"color", "never", "flag"},
+		{"auth_mode", "app", "env", "THREADS_AUTH_MODE"},
+		{"output", "text", "default", "THREADS_OUTPUT"},
+	} {
+		found := false
+		for _, line := range strings.Split(got, "\n") {
+			if strings.HasPrefix(strings.Join(strings.Fields(line), " "), strings.Join(want, " ")) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected a row starting with %v, got:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/demo"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+type demoOptions struct {
+	Seed int64
+	Addr string
+}
+
+// demoServer describes a running demo server for --output json
+type demoServer struct {
+	Account string `json:"account"`
+	Posts   int    `json:"posts"`
+	Replies int    `json:"replies"`
+	Seed    int64  `json:"seed"`
+	URL     string `json:"url"`
+	Users   int    `json:"users"`
+}
+
+// NewDemoCmd builds the demo command.
+func NewDemoCmd(f *Factory) *cobra.Command {
+	opts := &demoOptions{Seed: 42, Addr: "127.0.0.1:8686"}
+
+	cmd := &cobra.Command{
+		Use:   "demo",
+		Short: "Serve generated demo data as a local Threads API",
+		Long: `Generate a made-up account with followers, posts and conversations, and
+serve it as a read-only Threads API, so screenshots, talks and tests never
+show a real account.
+
+The same --seed always generates the same users, posts and replies; only
+timestamps move, counting back from when the server starts. Point the CLI at
+the server with THREADS_BASE_URL and store any token for it with
+'threads auth token', which needs some app credentials; the server accepts
+any. Writes, such as creating posts, are refused.`,
+		Example: `  threads demo --seed 42
+
+  # In another shell
+  export THREADS_BASE_URL=http://127.0.0.1:8686
+  THREADS_CLIENT_ID=demo THREADS_CLIENT_SECRET=demo threads auth token demo --name demo
+  threads --account demo posts list
+  threads auth remove demo`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			listener, err := net.Listen("tcp", opts.Addr)
+			if err != nil {
+				return &UserFriendlyError{
+					Message:    fmt.Sprintf("Cannot listen on %s", opts.Addr),
+					Suggestion: "Choose another address with --addr",
+					Cause:      err,
+				}
+			}
+			return runDemo(cmd.Context(), listener, opts.Seed)
+		},
+	}
+
+	cmd.Flags().Int64Var(&opts.Seed, "seed", opts.Seed, "Seed of the generated data")
+	cmd.Flags().StringVar(&opts.Addr, "addr", opts.Addr, "Address to listen on")
+	return cmd
+}
+
+// runDemo serves the data for seed on listener until ctx is done
+func runDemo(ctx context.Context, listener net.Listener, seed int64) error {
+	defer listener.Close() //nolint:errcheck // Also closed by Shutdown
+
+	data := demo.Generate(seed, time.Now())
+	server := &http.Server{Handler: data.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	info := demoServer{
+		Account: data.Account().Username,
+		Posts:   len(data.Posts),
+		Replies: len(data.Replies),
+		Seed:    seed,
+		URL:     "http://" + listener.Addr().String(),
+		Users:   len(data.Users),
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSONContext(ctx, io.Out, info); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(io.ErrOut, "Serving @%s, %d users, %d posts and %d replies for seed %d at %s (Ctrl+C to stop)\n", //nolint:errcheck // Best-effort output to stderr
+			info.Account, info.Users, info.Posts, info.Replies, seed, info.URL)
+		fmt.Fprintf(io.ErrOut, "Use it from another shell:\n  export THREADS_BASE_URL=%s\n  THREADS_CLIENT_ID=demo THREADS_CLIENT_SECRET=demo threads auth token demo --name demo\n  threads --account demo posts list\n", info.URL) //nolint:errcheck // Best-effort output to stderr
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx) //nolint:errcheck,gosec // Exiting anyway
+	}()
+
+	err := server.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		<-done
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
+)
+
+func TestDemo_ServesTheCLI(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var started bytes.Buffer
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = outfmt.NewContext(iocontext.WithIO(ctx, &iocontext.IO{Out: &started, ErrOut: &bytes.Buffer{}}), outfmt.JSON)
+	served := make(chan error, 1)
+	go func() { served <- runDemo(ctx, listener, 42) }()
+	defer func() {
+		cancel()
+		if err := <-served; err != nil {
+			t.Errorf("unexpected serve error: %v", err)
+		}
+	}()
+
+	t.Setenv("THREADS_BASE_URL", "http://"+listener.Addr().String())
+	f := newTestFactory(t)
+	f.Store = func() (secrets.Store, error) {
+		return &mockCredentialsStore{creds: testCredentials()}, nil
+	}
+	var out bytes.Buffer
+	f.IO.Out = &out
+
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"me", "-o", "json"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var info demoServer
+	deadline := time.Now().Add(time.Second)
+	for json.Unmarshal(started.Bytes(), &info) != nil && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if info.Seed != 42 || info.Account == "" || !strings.Contains(out.String(), `"username": "`+info.Account+`"`) {
+		t.Errorf("expected the demo account %+v, got:\n%s", info, out.String())
+	}
+}
@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/queue"
+	"github.com/salmonumbrella/threads-cli/internal/series"
+)
+
+// draftsBundleVersion is the format of a drafts bundle; import rejects
+// newer ones
+const draftsBundleVersion = 1
+
+// draftsBundle is the unpublished work of one person, for another to take
+// over. It holds no account names or credentials: drafts are published as
+// whoever imports them.
+type draftsBundle struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	// Drafts are the pending posts of the queue
+	Drafts []queue.Item `json:"drafts"`
+	// Series carries the templates and counters of numbered series
+	Series *series.State `json:"series"`
+}
+
+// NewDraftsCmd builds the drafts command group.
+func NewDraftsCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drafts",
+		Short: "Hand unpublished work to someone else",
+		Long: `Drafts are the posts waiting in the queue, with the series templates they
+use. Bundle them to hand work between members of a team, each of whom
+publishes with their own account.`,
+	}
+
+	bundle := &cobra.Command{
+		Use:   "bundle",
+		Short: "Export or import a bundle of drafts",
+	}
+	bundle.AddCommand(newDraftsBundleExportCmd(f))
+	bundle.AddCommand(newDraftsBundleImportCmd(f))
+	cmd.AddCommand(bundle)
+
+	return cmd
+}
+
+type draftsBundleExportOptions struct {
+	Out  string
+	Move bool
+}
+
+func newDraftsBundleExportCmd(f *Factory) *cobra.Command {
+	opts := &draftsBundleExportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export [id]...",
+		Short: "Write the pending drafts and series to a bundle",
+		Long: `Write the pending posts of the queue, or those with the given IDs, and every
+series with its template and counter to a JSON bundle for 'threads drafts
+bundle import'.
+
+The bundle has no tokens or account names; the person importing it
+publishes the drafts with their own account. With --move the exported
+drafts are removed from this queue, so they are not published twice.`,
+		Example: `  threads drafts bundle export --out handoff.json
+  threads drafts bundle export 3f9a1c2b7d4e --move --out handoff.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDraftsBundleExport(cmd, f, args, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Out, "out", "", "Write to a file instead of standard output")
+	cmd.Flags().BoolVar(&opts.Move, "move", false, "Remove the exported drafts from this queue")
+	return cmd
+}
+
+func runDraftsBundleExport(cmd *cobra.Command, f *Factory, ids []string, opts *draftsBundleExportOptions) error {
+	ctx := cmd.Context()
+
+	state, err := series.Load(seriesPath())
+	if err != nil {
+		return WrapError("failed to read the series", err)
+	}
+	bundle := draftsBundle{Version: draftsBundleVersion, ExportedAt: time.Now().UTC(), Drafts: []queue.Item{}, Series: state}
+
+	// The drafts are chosen, and with --move removed, under the queue lock so
+	// a runner cannot publish one in between
+	write := func(items []queue.Item) ([]queue.Item, error) {
+		for _, id := range ids {
+			i := slices.IndexFunc(items, func(item queue.Item) bool { return item.ID == id })
+			switch {
+			case i < 0:
+				return nil, &UserFriendlyError{
+					Message:    fmt.Sprintf("No queued post with ID %s", id),
+					Suggestion: "Run 'threads queue list' to see queued posts",
+				}
+			case items[i].Status != queue.StatusPending:
+				return nil, &UserFriendlyError{
+					Message:    fmt.Sprintf("Queued post %s is %s, not a draft", id, items[i].Status),
+					Suggestion: "Only pending posts can be exported",
+				}
+			}
+		}
+		exported := func(item queue.Item) bool {
+			return item.Status == queue.StatusPending && (len(ids) == 0 || slices.Contains(ids, item.ID))
+		}
+		for _, item := range items {
+			if exported(item) {
+				item.Account, item.Attempts, item.ClaimedAt, item.Error = "", 0, nil, ""
+				bundle.Drafts = append(bundle.Drafts, item)
+			}
+		}
+		if err := writeDraftsBundle(ctx, opts.Out, &bundle); err != nil {
+			return nil, err
+		}
+		if opts.Move {
+			return slices.DeleteFunc(items, exported), nil
+		}
+		return items, nil
+	}
+	if opts.Move {
+		err = queue.Update(queuePath(), write)
+	} else {
+		var items []queue.Item
+		if items, err = queue.Load(queuePath()); err == nil {
+			_, err = write(items)
+		}
+	}
+	if err != nil {
+		return FormatError(err)
+	}
+
+	if opts.Out == "" {
+		return nil
+	}
+	p := f.UI(ctx)
+	p.Success("Exported %s and %s to %s", pluralize(len(bundle.Drafts), "draft", "drafts"), pluralize(len(state.Series), "series", "series"), opts.Out)
+	if opts.Move && len(bundle.Drafts) > 0 {
+		p.Info("Removed the exported drafts from this queue")
+	}
+	return nil
+}
+
+// writeDraftsBundle writes bundle to the file out, or to standard output
+func writeDraftsBundle(ctx context.Context, out string, bundle *draftsBundle) error {
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if out == "" {
+		_, err := iocontext.GetIO(ctx).Out.Write(data)
+		return err
+	}
+	if err := os.WriteFile(out, data, 0o600); err != nil {
+		return WrapError("failed to write "+out, err)
+	}
+	return nil
+}
+
+func newDraftsBundleImportCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:         "import [file]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Add the drafts and series of a bundle",
+		Long: `Add the drafts of a bundle written by 'threads drafts bundle export' to the
+queue, to be published with the current account, and merge its series; -
+reads standard input.
+
+Drafts already in the queue are skipped, so a bundle can be imported again.
+Series are merged as by 'threads series import'. Drafts whose time has
+passed are published by the next 'threads queue run'.`,
+		Example: `  threads drafts bundle import handoff.json
+  threads drafts bundle import handoff.json --account brand`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDraftsBundleImport(cmd, f, args[0])
+		},
+	}
+}
+
+func runDraftsBundleImport(cmd *cobra.Command, f *Factory, name string) error {
+	ctx := cmd.Context()
+
+	var r io.Reader = iocontext.GetIO(ctx).In
+	if name != "-" {
+		file, err := os.Open(name) //nolint:gosec // Path is chosen by the user
+		if err != nil {
+			return WrapError("failed to open "+name, err)
+		}
+		defer file.Close() //nolint:errcheck // Read-only
+		r = file
+	}
+	var bundle draftsBundle
+	if err := json.NewDecoder(r).Decode(&bundle); err != nil || bundle.Version == 0 {
+		if err == nil {
+			err = errors.New("no version")
+		}
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("%s is not a drafts bundle: %v", name, err),
+			Suggestion: "Create one with 'threads drafts bundle export'",
+			Cause:      err,
+		}
+	}
+	if bundle.Version > draftsBundleVersion {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("%s is a version %d bundle; this CLI reads version %d", name, bundle.Version, draftsBundleVersion),
+			Suggestion: "Upgrade threads to import it",
+		}
+	}
+
+	// overdue are added drafts whose time has passed
+	added, skipped, overdue := []string{}, []string{}, []string{}
+	var merged series.MergeResult
+	account := f.currentAccountName()
+	now := time.Now()
+	err := queue.Update(queuePath(), func(items []queue.Item) ([]queue.Item, error) {
+		for _, draft := range bundle.Drafts {
+			if draft.ID == "" || slices.ContainsFunc(items, func(item queue.Item) bool { return item.ID == draft.ID }) {
+				skipped = append(skipped, draft.ID)
+				continue
+			}
+			draft.Account, draft.Status = account, queue.StatusPending
+			draft.Attempts, draft.ClaimedAt, draft.Error = 0, nil, ""
+			items = append(items, draft)
+			added = append(added, draft.ID)
+			if !draft.At.After(now) {
+				overdue = append(overdue, draft.ID)
+			}
+		}
+		return items, nil
+	})
+	if err != nil {
+		return WrapError("failed to save the post queue", err)
+	}
+	if bundle.Series != nil {
+		err = series.Update(seriesPath(), func(state *series.State) error {
+			merged = state.Merge(bundle.Series)
+			return nil
+		})
+		if err != nil {
+			return WrapError("failed to save the series", err)
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, map[string]any{
+			"added":   added,
+			"skipped": skipped,
+			"overdue": overdue,
+			"series": map[string]any{
+				"added":     nonNil(merged.Added),
+				"updated":   nonNil(merged.Updated),
+				"conflicts": nonNil(merged.Conflicts),
+			},
+		})
+	}
+	p := f.UI(ctx)
+	p.Success("Added %s to the queue", pluralize(len(added), "draft", "drafts"))
+	if len(skipped) > 0 {
+		p.Info("Skipped %s already in the queue", pluralize(len(skipped), "draft", "drafts"))
+	}
+	for _, name := range merged.Added {
+		p.Success("Added series %s", name)
+	}
+	for _, name := range merged.Updated {
+		p.Success("Updated series %s", name)
+	}
+	for _, conflict := range merged.Conflicts {
+		p.Warning("%s", conflict)
+	}
+	if len(overdue) > 0 {
+		p.Warning("%s already due; the next 'threads queue run' publishes them", pluralize(len(overdue), "draft is", "drafts are"))
+	}
+	return nil
+}
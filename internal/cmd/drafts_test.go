@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/queue"
+	"github.com/salmonumbrella/threads-cli/internal/series"
+)
+
+func runDraftsCmd(t *testing.T, f *Factory, io *iocontext.IO, args ...string) error {
+	t.Helper()
+	cmd := NewDraftsCmd(f)
+	cmd.SetArgs(append([]string{"bundle"}, args...))
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	return cmd.Execute()
+}
+
+func TestDraftsBundle_ExportImport(t *testing.T) {
+	queued := useTempQueue(t)
+	seriesFile := useTempSeries(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	now := time.Now().UTC()
+	err := queue.Save(queued, []queue.Item{
+		{ID: "a", TextPost: &api.TextPostContent{Text: "later"}, At: now.Add(time.Hour), Account: "alice", Status: queue.StatusPending},
+		{ID: "b", TextPost: &api.TextPostContent{Text: "overdue"}, At: now.Add(-time.Hour), Account: "alice", Status: queue.StatusPending, Attempts: 2, Error: "rate limited"},
+		{ID: "c", TextPost: &api.TextPostContent{Text: "done"}, At: now.Add(-time.Hour), Account: "alice", Status: queue.StatusPublished},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = series.Save(seriesFile, &series.State{Series: []*series.Series{{Name: "TIL", Template: "TIL #{{.n}}: {{.text}}", Next: 5}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle := filepath.Join(t.TempDir(), "handoff.json")
+	if err := runDraftsCmd(t, f, io, "export", "--move", "--out", bundle); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(bundle) //nolint:gosec // Test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "alice") || strings.Contains(string(data), "rate limited") {
+		t.Errorf("expected no account or attempt state in the bundle:\n%s", data)
+	}
+	var exported draftsBundle
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatal(err)
+	}
+	if len(exported.Drafts) != 2 || exported.Series == nil || len(exported.Series.Series) != 1 {
+		t.Fatalf("expected the two pending drafts and the series, got %+v", exported)
+	}
+	items, err := queue.Load(queued)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].ID != "c" {
+		t.Fatalf("expected --move to leave the published post, got %+v", items)
+	}
+
+	// Another person imports the bundle, twice
+	if err := os.Remove(seriesFile); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if err := runDraftsCmd(t, f, io, "import", bundle); err != nil {
+			t.Fatal(err)
+		}
+	}
+	items, err = queue.Load(queued)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 || items[1].Account != "test-user" || items[2].Attempts != 0 || items[2].Status != queue.StatusPending {
+		t.Fatalf("expected the drafts queued once for the importing account, got %+v", items)
+	}
+	state, err := series.Load(seriesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := state.Get("TIL"); s == nil || s.Next != 5 {
+		t.Errorf("expected the series imported, got %+v", state.Series)
+	}
+	out := io.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, "Skipped 2 drafts already in the queue") || !strings.Contains(out, "1 draft is already due") {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestDraftsBundle_ExportRejectsNonDrafts(t *testing.T) {
+	queued := useTempQueue(t)
+	useTempSeries(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	err := queue.Save(queued, []queue.Item{{ID: "c", At: time.Now(), Status: queue.StatusPublished}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runDraftsCmd(t, f, io, "export", "c"); err == nil || !strings.Contains(err.Error(), "not a draft") {
+		t.Errorf("expected a published post to be refused, got %v", err)
+	}
+	if err := runDraftsCmd(t, f, io, "export", "x"); err == nil || !strings.Contains(err.Error(), "No queued post with ID x") {
+		t.Errorf("expected an unknown ID to be refused, got %v", err)
+	}
+}
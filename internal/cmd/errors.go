@@ -5,10 +5,38 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
 )
 
+// Process exit codes
+const (
+	ExitOK    = 0
+	ExitError = 1
+	// ExitPartial means some items of a batch command failed
+	ExitPartial = 3
+	// ExitQuery means the --jq expression could not be parsed, compiled or run
+	ExitQuery = 4
+)
+
+// ExitCode returns the process exit code for an error returned by Execute.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var queryErr *outfmt.QueryError
+	if errors.As(err, &queryErr) {
+		return ExitQuery
+	}
+	var batchErr *batchFailure
+	if errors.As(err, &batchErr) {
+		return ExitPartial
+	}
+	return ExitError
+}
+
 // UserFriendlyError wraps an error with a user-friendly message and optional suggestion.
 type UserFriendlyError struct {
 	Message    string
@@ -65,6 +93,30 @@ func FormatError(err error) error {
 		return formatNetworkError(networkErr)
 	}
 
+	// Check for container lifecycle errors
+	var containerErr *api.ContainerError
+	if errors.As(err, &containerErr) {
+		return formatContainerError(containerErr)
+	}
+
+	// Check for jq query errors
+	var queryErr *outfmt.QueryError
+	if errors.As(err, &queryErr) {
+		return &UserFriendlyError{
+			Message:    queryErr.Error(),
+			Suggestion: fmt.Sprintf("Check the --jq expression %q; the syntax follows jq (https://jqlang.org/manual/)", queryErr.Query),
+			Cause:      err,
+		}
+	}
+
+	if errors.Is(err, outfmt.ErrNoIDs) {
+		return &UserFriendlyError{
+			Message:    "This command's output has no IDs to print",
+			Suggestion: "Drop --id-only / --output ids, or use --output json",
+			Cause:      err,
+		}
+	}
+
 	// Check for API errors
 	var apiErr *api.APIError
 	if errors.As(err, &apiErr) {
@@ -120,6 +172,10 @@ func formatRateLimitError(err *api.RateLimitError) *UserFriendlyError {
 		suggestion = "Wait a few minutes before retrying. Run 'threads ratelimit status' to check your current limits"
 	}
 
+	if detail := rateLimitDetail(err); detail != "" {
+		suggestion = detail + ". " + suggestion
+	}
+
 	return &UserFriendlyError{
 		Message:    msg,
 		Suggestion: suggestion,
@@ -127,6 +183,27 @@ func formatRateLimitError(err *api.RateLimitError) *UserFriendlyError {
 	}
 }
 
+// rateLimitDetail describes which limit was exhausted and when it resets
+func rateLimitDetail(err *api.RateLimitError) string {
+	var parts []string
+	switch err.Bucket {
+	case api.RateLimitBucketApp:
+		parts = append(parts, "The app-wide rate limit is exhausted (shared by all users of this app)")
+	case api.RateLimitBucketUser:
+		parts = append(parts, "Your account's rate limit is exhausted")
+	case api.RateLimitBucketPublish:
+		parts = append(parts, "Your publishing limit is exhausted")
+	}
+	if !err.ResetAt.IsZero() {
+		reset := "estimated reset at " + err.ResetAt.Local().Format(time.RFC3339)
+		if len(parts) == 0 {
+			reset = "Estimated reset at " + err.ResetAt.Local().Format(time.RFC3339)
+		}
+		parts = append(parts, reset)
+	}
+	return strings.Join(parts, ", ")
+}
+
 func formatValidationError(err *api.ValidationError) *UserFriendlyError {
 	msg := "Invalid input"
 	suggestion := ""
@@ -200,6 +277,35 @@ func formatNetworkError(err *api.NetworkError) *UserFriendlyError {
 	}
 }
 
+func formatContainerError(err *api.ContainerError) *UserFriendlyError {
+	var msg string
+	var suggestion string
+
+	switch err.Status {
+	case api.ContainerStatusError:
+		msg = fmt.Sprintf("Media processing failed: %s", err.Details)
+		suggestion = "Check that the media URL is accessible and the format is supported (JPEG, PNG for images; MP4 for videos)"
+
+	case api.ContainerStatusExpired:
+		msg = "Media container expired before publishing"
+		suggestion = "Re-upload the media and publish immediately after container creation"
+
+	case api.ContainerStatusPublished:
+		msg = "Media container has already been published"
+		suggestion = "Create a new post instead of republishing the same container"
+
+	default:
+		msg = "Timeout waiting for media processing"
+		suggestion = "Media processing is taking too long. Try using a smaller file or increase timeout with --timeout"
+	}
+
+	return &UserFriendlyError{
+		Message:    msg,
+		Suggestion: suggestion,
+		Cause:      err,
+	}
+}
+
 func formatAPIError(err *api.APIError) *UserFriendlyError {
 	var msg string
 	var suggestion string
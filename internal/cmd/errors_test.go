@@ -2,11 +2,13 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
 )
 
 func TestUserFriendlyError_Error(t *testing.T) {
@@ -130,6 +132,24 @@ func TestFormatError_RateLimitError(t *testing.T) {
 	}
 }
 
+func TestFormatError_RateLimitError_BucketAndReset(t *testing.T) {
+	err := api.NewRateLimitError(17, "User request limit reached", "", 30*time.Minute)
+	err.Bucket = api.RateLimitBucketPublish
+	err.ResetAt = time.Date(2030, 1, 2, 15, 4, 0, 0, time.UTC)
+
+	ufErr, ok := FormatError(err).(*UserFriendlyError)
+	if !ok {
+		t.Fatalf("FormatError() did not return *UserFriendlyError")
+	}
+	if !strings.Contains(ufErr.Suggestion, "publishing limit") {
+		t.Errorf("Suggestion = %q, want it to name the publishing limit", ufErr.Suggestion)
+	}
+	wantReset := err.ResetAt.Local().Format(time.RFC3339)
+	if !strings.Contains(ufErr.Suggestion, wantReset) {
+		t.Errorf("Suggestion = %q, want it to contain reset time %s", ufErr.Suggestion, wantReset)
+	}
+}
+
 func TestFormatError_ValidationError(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -553,3 +573,27 @@ func TestFormatError_GenericErrors_AllCases(t *testing.T) {
 		})
 	}
 }
+
+func TestExitCode(t *testing.T) {
+	queryErr := &outfmt.QueryError{Query: ".[", Err: errors.New("invalid jq query")}
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"generic", errors.New("boom"), ExitError},
+		{"query", queryErr, ExitQuery},
+		{"wrapped query", fmt.Errorf("output: %w", queryErr), ExitQuery},
+		{"formatted query", FormatError(queryErr), ExitQuery},
+		{"partial batch", &UserFriendlyError{Message: "Failed to hide 1 of 2 replies", Cause: &batchFailure{failed: 1, total: 2}}, ExitPartial},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
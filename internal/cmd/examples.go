@@ -0,0 +1,467 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// example is a runnable invocation; {name} in Args is replaced by the
+// parameter of that name
+type example struct {
+	Name    string
+	Topic   string
+	Summary string
+	Args    []string
+	Params  []exampleParam
+	// Writes is set when the example publishes or changes something, so it
+	// asks before running
+	Writes bool
+}
+
+type exampleParam struct {
+	Name string
+	// Default is used when the parameter is not set; without one it is required
+	Default string
+	Help    string
+}
+
+var examples = []example{
+	{
+		Name:    "post-text",
+		Topic:   "posts",
+		Summary: "Publish a text post",
+		Args:    []string{"posts", "create", "--text", "{text}"},
+		Params:  []exampleParam{{Name: "text", Default: "Hello from the command line", Help: "Post text"}},
+		Writes:  true,
+	},
+	{
+		Name:    "post-poll",
+		Topic:   "posts",
+		Summary: "Publish a post with a poll of 2-4 options",
+		Args:    []string{"posts", "create", "--text", "{question}", "--poll", "{options}"},
+		Params: []exampleParam{
+			{Name: "question", Default: "Which editor do you use?", Help: "Post text"},
+			{Name: "options", Default: "Vim,Emacs,VS Code", Help: "Comma-separated poll options"},
+		},
+		Writes: true,
+	},
+	{
+		Name:    "post-image",
+		Topic:   "posts",
+		Summary: "Publish an image with alt text",
+		Args:    []string{"posts", "create", "--text", "{text}", "--image", "{url}", "--alt-text", "{alt}"},
+		Params: []exampleParam{
+			{Name: "url", Help: "Public URL of a JPEG or PNG (see: threads help guides media)"},
+			{Name: "text", Default: "New photo", Help: "Post text"},
+			{Name: "alt", Default: "A photo", Help: "Alt text describing the image"},
+		},
+		Writes: true,
+	},
+	{
+		Name:    "carousel",
+		Topic:   "posts",
+		Summary: "Publish a carousel of 2-20 images or videos",
+		Args:    []string{"posts", "carousel", "--items", "{items}", "--text", "{caption}"},
+		Params: []exampleParam{
+			{Name: "items", Help: "Comma-separated public media URLs"},
+			{Name: "caption", Default: "Photo dump", Help: "Caption text"},
+		},
+		Writes: true,
+	},
+	{
+		Name:    "reply",
+		Topic:   "posts",
+		Summary: "Reply to a post",
+		Args:    []string{"posts", "create", "--reply-to", "{post_id}", "--text", "{text}"},
+		Params: []exampleParam{
+			{Name: "post_id", Help: "ID of the post to reply to"},
+			{Name: "text", Default: "Thanks for sharing!", Help: "Reply text"},
+		},
+		Writes: true,
+	},
+	{
+		Name:    "search-export",
+		Topic:   "search",
+		Summary: "Export recent posts matching a keyword as JSON",
+		Args:    []string{"search", "{query}", "--type", "recent", "--since", "{since}", "--limit", "{limit}", "--output", "json"},
+		Params: []exampleParam{
+			{Name: "query", Default: "golang", Help: "Keyword to search for"},
+			{Name: "since", Default: "7d", Help: "Oldest post to include (7d, 2025-06-01)"},
+			{Name: "limit", Default: "50", Help: "Maximum results"},
+		},
+	},
+	{
+		Name:    "search-tag",
+		Topic:   "search",
+		Summary: "Find the top posts with a topic tag",
+		Args:    []string{"search", "{tag}", "--mode", "tag"},
+		Params:  []exampleParam{{Name: "tag", Default: "coffee", Help: "Topic tag, without #"}},
+	},
+	{
+		Name:    "post-insights",
+		Topic:   "insights",
+		Summary: "Show views, likes, replies, reposts and quotes of a post",
+		Args:    []string{"insights", "post", "{post_id}", "--metrics", "views,likes,replies,reposts,quotes"},
+		Params:  []exampleParam{{Name: "post_id", Help: "ID of one of your posts"}},
+	},
+	{
+		Name:    "recent-posts",
+		Topic:   "insights",
+		Summary: "List your posts from the last week",
+		Args:    []string{"posts", "list", "--since", "{since}"},
+		Params:  []exampleParam{{Name: "since", Default: "7d", Help: "Oldest post to include"}},
+	},
+	{
+		Name:    "backup-media",
+		Topic:   "archive",
+		Summary: "Download the images and videos of all your posts",
+		Args:    []string{"posts", "list", "--all", "--download-media", "{dir}"},
+		Params:  []exampleParam{{Name: "dir", Default: "./threads-media", Help: "Directory to save media to"}},
+		Writes:  true,
+	},
+	{
+		Name:    "webhook-test",
+		Topic:   "webhooks",
+		Summary: "Send a signed test mention to a local webhook handler",
+		Args:    []string{"webhooks", "send-test", "--type", "mentions", "--to", "{url}"},
+		Params:  []exampleParam{{Name: "url", Default: "http://localhost:8080/webhooks/threads", Help: "URL of your handler"}},
+		Writes:  true,
+	},
+}
+
+func findExample(name string) (example, bool) {
+	for _, ex := range examples {
+		if ex.Name == name {
+			return ex, true
+		}
+	}
+	return example{}, false
+}
+
+func exampleTopics() []string {
+	var topics []string
+	for _, ex := range examples {
+		if !slices.Contains(topics, ex.Topic) {
+			topics = append(topics, ex.Topic)
+		}
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+func exampleNames() []string {
+	names := make([]string, len(examples))
+	for i, ex := range examples {
+		names[i] = ex.Name
+	}
+	return names
+}
+
+// resolve returns the arguments with parameters replaced by values, falling
+// back to defaults
+func (ex example) resolve(values map[string]string) ([]string, error) {
+	for name := range values {
+		if !slices.ContainsFunc(ex.Params, func(p exampleParam) bool { return p.Name == name }) {
+			return nil, &UserFriendlyError{
+				Message:    fmt.Sprintf("Example %s has no parameter %q", ex.Name, name),
+				Suggestion: "Parameters: " + ex.paramNames(),
+			}
+		}
+	}
+
+	pairs := make([]string, 0, 2*len(ex.Params))
+	for _, p := range ex.Params {
+		value, ok := values[p.Name]
+		if !ok {
+			value = p.Default
+		}
+		if value == "" {
+			return nil, &UserFriendlyError{
+				Message:    fmt.Sprintf("Example %s needs a value for %s: %s", ex.Name, p.Name, p.Help),
+				Suggestion: fmt.Sprintf("Add --set %s=VALUE", p.Name),
+			}
+		}
+		pairs = append(pairs, "{"+p.Name+"}", value)
+	}
+	replacer := strings.NewReplacer(pairs...)
+
+	args := make([]string, len(ex.Args))
+	for i, arg := range ex.Args {
+		args[i] = replacer.Replace(arg)
+	}
+	return args, nil
+}
+
+func (ex example) paramNames() string {
+	names := make([]string, len(ex.Params))
+	for i, p := range ex.Params {
+		names[i] = p.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// usage renders the example with defaults filled in and <name> for the
+// parameters that must be set
+func (ex example) usage() string {
+	parts := []string{"threads"}
+	for _, arg := range ex.Args {
+		required := false
+		for _, p := range ex.Params {
+			placeholder := "{" + p.Name + "}"
+			if p.Default == "" && strings.Contains(arg, placeholder) {
+				required = true
+				arg = strings.ReplaceAll(arg, placeholder, "<"+p.Name+">")
+			} else {
+				arg = strings.ReplaceAll(arg, placeholder, p.Default)
+			}
+		}
+		if !required {
+			arg = shellQuote(arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// commandLine renders args as a shell command
+func commandLine(args []string) string {
+	parts := []string{"threads"}
+	for _, arg := range args {
+		parts = append(parts, shellQuote(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+// NewExamplesCmd builds the examples command.
+func NewExamplesCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "examples [topic]",
+		Short: "List example invocations you can try",
+		Long: `List example invocations by topic. Each example has parameters with
+defaults, and 'threads examples run' fills them in and runs it, or with
+--dry-run only checks and explains it, which is a safe way to learn.`,
+		Example: `  threads examples
+  threads examples posts
+  threads examples run post-poll --dry-run
+  threads examples run post-poll --set question="Tabs or spaces?" --set options="Tabs,Spaces"`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: exampleTopics(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExamplesList(cmd, args)
+		},
+	}
+
+	cmd.AddCommand(newExamplesRunCmd(f))
+
+	return cmd
+}
+
+func runExamplesList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	io := iocontext.GetIO(ctx)
+
+	var selected []example
+	for _, ex := range examples {
+		if len(args) == 0 || ex.Topic == args[0] {
+			selected = append(selected, ex)
+		}
+	}
+	if len(selected) == 0 {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("No examples on %q", args[0]),
+			Suggestion: "Topics: " + strings.Join(exampleTopics(), ", "),
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		type paramJSON struct {
+			Name    string `json:"name"`
+			Default string `json:"default,omitempty"`
+			Help    string `json:"help"`
+		}
+		type exampleJSON struct {
+			Name    string      `json:"name"`
+			Topic   string      `json:"topic"`
+			Summary string      `json:"summary"`
+			Command string      `json:"command"`
+			Params  []paramJSON `json:"params"`
+			Writes  bool        `json:"writes"`
+		}
+		out := make([]exampleJSON, len(selected))
+		for i, ex := range selected {
+			params := make([]paramJSON, len(ex.Params))
+			for j, p := range ex.Params {
+				params[j] = paramJSON(p)
+			}
+			out[i] = exampleJSON{ex.Name, ex.Topic, ex.Summary, ex.usage(), params, ex.Writes}
+		}
+		return outfmt.WriteJSONContext(ctx, io.Out, out)
+	}
+
+	topic := ""
+	for _, ex := range selected {
+		if ex.Topic != topic {
+			if topic != "" {
+				fmt.Fprintln(io.Out) //nolint:errcheck // Best-effort output
+			}
+			topic = ex.Topic
+			fmt.Fprintf(io.Out, "%s\n", strings.ToUpper(topic)) //nolint:errcheck // Best-effort output
+		}
+		fmt.Fprintf(io.Out, "  %-15s %s\n", ex.Name, ex.Summary) //nolint:errcheck // Best-effort output
+		fmt.Fprintf(io.Out, "  %-15s %s\n", "", ex.usage())      //nolint:errcheck // Best-effort output
+	}
+	fmt.Fprintln(io.Out, "\nTry one safely with: threads examples run <name> --dry-run") //nolint:errcheck // Best-effort output
+	return nil
+}
+
+func newExamplesRunCmd(f *Factory) *cobra.Command {
+	var (
+		sets   []string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "run <name>",
+		Short: "Run an example",
+		Long: `Run an example with its parameters filled in. --set overrides a default
+and is required for parameters without one.
+
+With --dry-run the command is checked like a real run, so unknown flags or
+missing arguments show up, and the resolved flags are printed, but nothing is
+sent. Examples that publish or change something ask for confirmation first.`,
+		Example: `  threads examples run search-export --dry-run
+  threads examples run search-export --set query=coffee --set since=1d
+  threads examples run reply --set post_id=18012345678901234 --dry-run`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: exampleNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ex, ok := findExample(args[0])
+			if !ok {
+				return &UserFriendlyError{
+					Message:    fmt.Sprintf("Unknown example: %s", args[0]),
+					Suggestion: "List the examples with: threads examples",
+				}
+			}
+			values, err := parseTemplateVars(sets)
+			if err != nil {
+				return err
+			}
+			exArgs, err := ex.resolve(values)
+			if err != nil {
+				return err
+			}
+			exArgs = append(exArgs, inheritedFlagArgs(cmd)...)
+			if dryRun {
+				return runExampleDry(cmd, f, ex, exArgs)
+			}
+			return runExample(cmd, f, ex, exArgs)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&sets, "set", nil, "Set a parameter (name=value, repeatable)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Check the example and show what it would run without running it")
+	return cmd
+}
+
+// runExampleDry parses the example's command line the way a real run would
+// and reports the command and its flags
+func runExampleDry(cmd *cobra.Command, f *Factory, ex example, args []string) error {
+	ctx := cmd.Context()
+	io := iocontext.GetIO(ctx)
+
+	target, rest, err := NewRootCmd(f).Find(args)
+	if err == nil {
+		err = target.ParseFlags(rest)
+	}
+	if err == nil {
+		err = target.ValidateArgs(target.Flags().Args())
+	}
+	if err == nil {
+		err = target.ValidateRequiredFlags()
+	}
+	if err == nil {
+		err = target.ValidateFlagGroups()
+	}
+	if err != nil {
+		return WrapError(fmt.Sprintf("example %s does not parse", ex.Name), err)
+	}
+	if err := checkPolicy(target); err != nil {
+		return err
+	}
+
+	flags := map[string]string{}
+	target.Flags().Visit(func(flag *pflag.Flag) {
+		flags[flag.Name] = flag.Value.String()
+	})
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
+			"name":    ex.Name,
+			"command": commandLine(args),
+			"args":    target.Flags().Args(),
+			"flags":   flags,
+			"writes":  ex.Writes,
+			"ran":     false,
+		})
+	}
+
+	fmt.Fprintf(io.Out, "Would run:\n  %s\n\n", commandLine(args))      //nolint:errcheck // Best-effort output
+	fmt.Fprintf(io.Out, "%s: %s\n", target.CommandPath(), target.Short) //nolint:errcheck // Best-effort output
+	if positional := target.Flags().Args(); len(positional) > 0 {
+		fmt.Fprintf(io.Out, "  arguments  %s\n", strings.Join(positional, " ")) //nolint:errcheck // Best-effort output
+	}
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(io.Out, "  --%-16s %s\n", name, flags[name]) //nolint:errcheck // Best-effort output
+	}
+	if ex.Writes {
+		fmt.Fprintln(io.Out, "\nRunning it changes something on your account or disk.") //nolint:errcheck // Best-effort output
+	}
+	fmt.Fprintf(io.Out, "\nRun it with: threads examples run %s\n", ex.Name) //nolint:errcheck // Best-effort output
+	return nil
+}
+
+// inheritedFlagArgs returns the global flags given to cmd, such as
+// --account, so the example runs with them too
+func inheritedFlagArgs(cmd *cobra.Command) []string {
+	var args []string
+	cmd.InheritedFlags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Changed {
+			args = append(args, "--"+flag.Name+"="+flag.Value.String())
+		}
+	})
+	return args
+}
+
+// runExample runs the example's command line as a separate invocation
+func runExample(cmd *cobra.Command, f *Factory, ex example, args []string) error {
+	ctx := cmd.Context()
+	if ex.Writes {
+		confirmed, err := f.Confirm(ctx, fmt.Sprintf("Run %s?", commandLine(args)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(iocontext.GetIO(ctx).Out, "Cancelled.") //nolint:errcheck // Best-effort output
+			return nil
+		}
+	}
+
+	root := NewRootCmd(f)
+	root.SetArgs(args)
+	root.SetOut(cmd.OutOrStdout())
+	root.SetErr(cmd.ErrOrStderr())
+	return root.ExecuteContext(ctx)
+}
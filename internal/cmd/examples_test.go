@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+// runExamplesCmd runs threads with args on f and returns stdout
+func runExamplesCmd(t *testing.T, f *Factory, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewRootCmd(f)
+	cmd.SetArgs(args)
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+	err := ExecuteCommand(cmd, f)
+	return f.IO.Out.(*bytes.Buffer).String(), err
+}
+
+// Every example must parse against the real command tree, so renamed flags
+// cannot leave broken examples behind
+func TestExamples_DryRunAll(t *testing.T) {
+	for _, ex := range examples {
+		t.Run(ex.Name, func(t *testing.T) {
+			args := []string{"examples", "run", ex.Name, "--dry-run"}
+			for _, p := range ex.Params {
+				if p.Default == "" {
+					args = append(args, "--set", p.Name+"=18012345678901234")
+				}
+			}
+			out, err := runExamplesCmd(t, newTestFactory(t), args...)
+			if err != nil {
+				t.Fatalf("dry run failed: %v", err)
+			}
+			if !strings.Contains(out, "Would run:") {
+				t.Errorf("unexpected output:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestExamples_List(t *testing.T) {
+	out, err := runExamplesCmd(t, newTestFactory(t), "examples")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"POSTS", "SEARCH", "post-poll", "--poll 'Vim,Emacs,VS Code'", "--reply-to <post_id>"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in the list:\n%s", s, out)
+		}
+	}
+}
+
+func TestExamples_ListTopicJSON(t *testing.T) {
+	out, err := runExamplesCmd(t, newTestFactory(t), "examples", "search", "-o", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var list []struct {
+		Name   string `json:"name"`
+		Topic  string `json:"topic"`
+		Writes bool   `json:"writes"`
+	}
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected the 2 search examples, got %+v", list)
+	}
+	for _, ex := range list {
+		if ex.Topic != "search" || ex.Writes {
+			t.Errorf("unexpected example: %+v", ex)
+		}
+	}
+}
+
+func TestExamples_UnknownTopic(t *testing.T) {
+	_, err := runExamplesCmd(t, newTestFactory(t), "examples", "nope")
+	var ufe *UserFriendlyError
+	if !errors.As(err, &ufe) || !strings.Contains(ufe.Suggestion, "posts") {
+		t.Errorf("expected an error listing the topics, got %v", err)
+	}
+}
+
+func TestExamplesRun_DryRunSet(t *testing.T) {
+	out, err := runExamplesCmd(t, newTestFactory(t),
+		"examples", "run", "post-poll", "--dry-run", "--set", "question=Tabs or spaces?", "--set", "options=Tabs,Spaces")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"threads posts create --text 'Tabs or spaces?' --poll Tabs,Spaces",
+		"--poll             Tabs,Spaces",
+		"changes something",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in:\n%s", s, out)
+		}
+	}
+}
+
+func TestExamplesRun_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unknown example", []string{"nope"}, "Unknown example"},
+		{"missing parameter", []string{"reply", "--dry-run"}, "needs a value for post_id"},
+		{"unknown parameter", []string{"post-text", "--set", "colour=red"}, `no parameter "colour"`},
+		{"bad set", []string{"post-text", "--set", "novalue"}, "Invalid --var"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runExamplesCmd(t, newTestFactory(t), append([]string{"examples", "run"}, tt.args...)...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestExamplesRun_ReadOnly(t *testing.T) {
+	server := newFixtureServer(t)
+	f, io := newIntegrationTestFactory(t, server.URL)
+
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"examples", "run", "recent-posts", "--set", "since=2024-01-01", "-o", "json"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := ExecuteCommand(cmd, f); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	var result struct {
+		Posts []struct {
+			ID string `json:"id"`
+		} `json:"posts"`
+	}
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatalf("expected the JSON of posts list, got %v:\n%s", err, io.Out)
+	}
+	if len(result.Posts) == 0 {
+		t.Error("expected posts from the fixtures")
+	}
+}
+
+func TestExamplesRun_WriteNeedsConfirmation(t *testing.T) {
+	// Any request would fail the test: declining must not reach the API
+	server := newFixtureServer(t)
+	f, io := newIntegrationTestFactory(t, server.URL)
+
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"examples", "run", "post-text"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := ExecuteCommand(cmd, f); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if out := io.Out.(*bytes.Buffer).String(); !strings.Contains(out, "Cancelled.") {
+		t.Errorf("expected the run to be cancelled without a terminal, got %q", out)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+// externalTimeout bounds one call of a configured command or endpoint, such
+// as the reply classifier, so a slow one cannot stall the webhook server or
+// a listing
+const externalTimeout = 10 * time.Second
+
+// externalClient calls commands configured as a URL
+var externalClient = &http.Client{Timeout: externalTimeout}
+
+// maxExternalOutput bounds the response read from a command or endpoint
+const maxExternalOutput = 64 << 10
+
+// callExternal sends body to spec: an http(s) URL gets it as a JSON POST
+// body, anything else runs in the shell like a hook with it on stdin. It
+// returns the response body or what the command printed.
+func callExternal(ctx context.Context, spec string, body []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, externalTimeout)
+	defer cancel()
+
+	if strings.HasPrefix(spec, "http://") || strings.HasPrefix(spec, "https://") {
+		return postExternal(ctx, spec, body)
+	}
+	return runExternal(ctx, spec, body)
+}
+
+func postExternal(ctx context.Context, endpoint string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := externalClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // Read-only body
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", endpoint, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxExternalOutput))
+}
+
+// runExternal runs command in the shell; its stderr goes to ours
+func runExternal(ctx context.Context, command string, body []byte) ([]byte, error) {
+	cmd := hookShell(ctx, command)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stderr = iocontext.GetIO(ctx).ErrOut
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	if out.Len() > maxExternalOutput {
+		return nil, fmt.Errorf("the command printed more than %d bytes", maxExternalOutput)
+	}
+	return out.Bytes(), nil
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/term"
 
 	"github.com/salmonumbrella/threads-cli/internal/api"
 	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/events"
 	"github.com/salmonumbrella/threads-cli/internal/iocontext"
 	"github.com/salmonumbrella/threads-cli/internal/outfmt"
 	"github.com/salmonumbrella/threads-cli/internal/secrets"
@@ -18,16 +22,36 @@ import (
 
 // Factory provides shared dependencies and helpers for commands.
 type Factory struct {
-	IO         *iocontext.IO
-	Config     *config.Config
-	Store      func() (secrets.Store, error)
-	NewClient  func(accessToken string, cfg *api.Config) (*api.Client, error)
-	Output     outfmt.Format
-	ColorMode  outfmt.ColorMode
-	Debug      bool
-	Account    string
+	IO        *iocontext.IO
+	Config    *config.Config
+	Store     func() (secrets.Store, error)
+	NewClient func(accessToken string, cfg *api.Config) (api.API, error)
+	Output    outfmt.Format
+	ColorMode outfmt.ColorMode
+	Debug     bool
+	Account   string
+	// Strict is set by --strict: warnings fail the command and nothing prompts
+	Strict bool
+	// NoCache is set by --no-cache: clients neither read nor update the
+	// response cache
+	NoCache bool
+
 	debugLog   api.Logger
 	loggerOnce sync.Once
+	// storeMu serializes the token updates of concurrent clients
+	storeMu sync.Mutex
+
+	// Events carries what commands observe to the handlers subscribed to
+	// it, starting with the configured hooks and notifiers
+	Events *events.Bus
+
+	// command is the path of the running command without the root, set
+	// before it runs so Client can check the token's scopes for it
+	command string
+
+	// httpTrace is set by --debug-http-file and closed by ExecuteCommand
+	httpTrace     *api.HTTPTrace
+	httpTraceFile *os.File
 }
 
 // FactoryOptions allows overriding factory dependencies (mainly for tests).
@@ -35,7 +59,7 @@ type FactoryOptions struct {
 	IO        *iocontext.IO
 	Config    *config.Config
 	Store     func() (secrets.Store, error)
-	NewClient func(accessToken string, cfg *api.Config) (*api.Client, error)
+	NewClient func(accessToken string, cfg *api.Config) (api.API, error)
 }
 
 // NewFactory creates a new Factory with defaults.
@@ -54,44 +78,81 @@ func NewFactory(ctx context.Context, opts FactoryOptions) (*Factory, error) {
 		cfg = loaded
 	}
 
-	store := opts.Store
-	if store == nil {
-		store = func() (secrets.Store, error) {
-			return secrets.OpenDefault()
-		}
-	}
-
 	newClient := opts.NewClient
 	if newClient == nil {
-		newClient = api.NewClientWithToken
+		newClient = newAPIClient
 	}
 
-	return &Factory{
+	f := &Factory{
 		IO:        io,
 		Config:    cfg,
-		Store:     store,
+		Store:     opts.Store,
 		NewClient: newClient,
 		Output:    outfmt.ParseFormat(cfg.Output),
 		ColorMode: outfmt.ParseColorMode(cfg.Color),
 		Debug:     cfg.Debug,
 		Account:   cfg.Account,
-	}, nil
+		Strict:    cfg.Strict,
+		Events:    &events.Bus{},
+	}
+	f.Events.Subscribe(f.runHook)
+	f.Events.Subscribe(f.runNotifiers)
+	if f.Store == nil {
+		f.Store = func() (secrets.Store, error) {
+			if f.Strict {
+				return secrets.OpenDefaultNoPrompt()
+			}
+			return secrets.OpenDefault()
+		}
+	}
+	return f, nil
 }
 
 // UI returns a configured UI printer.
 func (f *Factory) UI(ctx context.Context) *ui.Printer {
 	io := iocontext.GetIO(ctx)
+	if outfmt.IsDelimited(ctx) {
+		// Stdout is kept for the rows, so messages go to stderr
+		io = &iocontext.IO{Out: io.ErrOut, ErrOut: io.ErrOut, In: io.In}
+	}
 	color := outfmt.GetColorMode(ctx)
 	return ui.New(io, color)
 }
 
+// newAPIClient is the default Factory.NewClient, backed by a real *api.Client.
+// App access tokens skip token validation, which only applies to user tokens.
+func newAPIClient(accessToken string, cfg *api.Config) (api.API, error) {
+	var client *api.Client
+	var err error
+	if api.IsAppAccessToken(accessToken) {
+		client, err = api.NewAppClient(cfg.ClientID, cfg.ClientSecret, api.WithLogger(cfg.Logger), api.WithDebug(cfg.Debug), api.WithHTTPTrace(cfg.HTTPTrace))
+	} else {
+		client, err = api.NewClientWithToken(accessToken, cfg) //nolint:staticcheck // Validates the stored token and learns its expiry
+	}
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
 // Client returns a Threads client for the active account.
-func (f *Factory) Client(ctx context.Context) (*api.Client, error) {
+// Commands depend on the api.API interface so tests can inject mocks.
+func (f *Factory) Client(ctx context.Context) (api.API, error) {
+	if f.Config != nil && f.Config.AuthMode == config.AuthModeApp {
+		return f.appClient()
+	}
+
 	account, err := f.resolveAccount()
 	if err != nil {
 		return nil, err
 	}
+	return f.clientFor(ctx, account)
+}
 
+// clientFor returns a Threads client for a stored account. Each client has
+// its own rate limiter, so commands covering several accounts can use them
+// side by side.
+func (f *Factory) clientFor(ctx context.Context, account string) (api.API, error) {
 	store, err := f.Store()
 	if err != nil {
 		return nil, FormatError(err)
@@ -109,24 +170,115 @@ func (f *Factory) Client(ctx context.Context) (*api.Client, error) {
 		}
 	}
 
+	if err := checkScopes(f.command, creds.Scopes); err != nil {
+		return nil, err
+	}
+
+	client, err := f.NewClient(creds.AccessToken, f.apiConfig(store, account, creds))
+	if err != nil {
+		return nil, WrapError("failed to create API client", err)
+	}
+
+	// The client refreshes an expiring token on its first request and only
+	// logs when the new token cannot be saved; strict mode refreshes up front
+	// so that failure stops the command instead
+	if outfmt.GetStrict(ctx) && creds.IsExpiringSoon(tokenRefreshWindow) {
+		if err := client.RefreshToken(ctx); err != nil {
+			return nil, &UserFriendlyError{
+				Message:    fmt.Sprintf("Your access token expires on %s and could not be renewed", creds.ExpiresAt.Format("2006-01-02")),
+				Suggestion: "Run 'threads auth refresh', or 'threads auth login' to re-authenticate",
+				Cause:      err,
+			}
+		}
+	}
+
+	return client, nil
+}
+
+// appClient returns a client authenticated with an app access token, for the
+// "app" auth mode. App credentials come from the environment so monitoring
+// tools need neither a login nor a keychain.
+func (f *Factory) appClient() (api.API, error) {
+	clientID := os.Getenv("THREADS_CLIENT_ID")
+	clientSecret := os.Getenv("THREADS_CLIENT_SECRET")
+	if clientID == "" || clientSecret == "" {
+		return nil, &UserFriendlyError{
+			Message:    "App auth mode requires app credentials",
+			Suggestion: "Set THREADS_CLIENT_ID and THREADS_CLIENT_SECRET, or run 'threads config unset auth_mode' to use your account token",
+		}
+	}
+
 	cfg := &api.Config{
-		ClientID:     creds.ClientID,
-		ClientSecret: creds.ClientSecret,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		BaseURL:      apiBaseURL(),
 		Debug:        f.Debug,
+		HTTPTrace:    f.httpTrace,
 	}
-
 	if f.Debug {
 		cfg.Logger = f.logger()
 	}
 
-	client, err := f.NewClient(creds.AccessToken, cfg)
+	client, err := f.NewClient(api.AppAccessToken(clientID, clientSecret), cfg)
 	if err != nil {
 		return nil, WrapError("failed to create API client", err)
 	}
-
 	return client, nil
 }
 
+// apiBaseURL returns the API URL set with THREADS_BASE_URL, such as a
+// 'threads demo' server, or "" for the real API
+func apiBaseURL() string {
+	return os.Getenv("THREADS_BASE_URL")
+}
+
+// tokenRefreshWindow is how long before expiry commands refresh the stored token.
+// Long-lived tokens last 60 days, so a week leaves room for infrequent use.
+const tokenRefreshWindow = 7 * 24 * time.Hour
+
+// apiConfig builds the client configuration for an account. Tokens the client
+// refreshes are written back to the credential store.
+func (f *Factory) apiConfig(store secrets.Store, account string, creds *secrets.Credentials) *api.Config {
+	cfg := &api.Config{
+		ClientID:           creds.ClientID,
+		ClientSecret:       creds.ClientSecret,
+		BaseURL:            apiBaseURL(),
+		Debug:              f.Debug,
+		HTTPTrace:          f.httpTrace,
+		TokenRefreshWindow: tokenRefreshWindow,
+		OnTokenRefresh: func(token *api.TokenInfo) error {
+			// Clients of several accounts may refresh at the same time
+			f.storeMu.Lock()
+			defer f.storeMu.Unlock()
+			updated := *creds
+			updated.AccessToken = token.AccessToken
+			updated.ExpiresAt = token.ExpiresAt
+			return store.Set(account, updated)
+		},
+	}
+
+	cfg.RateCoordinator = rateCoordinator(account, creds)
+	cfg.RateLimitStore = rateLimitStore(account, creds)
+	if !f.NoCache {
+		cfg.ResponseCache = api.NewResponseCache(filepath.Join(cacheDir(), "http"), 0)
+	}
+	if f.Debug {
+		cfg.Logger = f.logger()
+	}
+
+	return cfg
+}
+
+// errNoAccount is returned by resolveAccount when no account is stored
+var errNoAccount = &UserFriendlyError{
+	Message:    "No Threads account configured",
+	Suggestion: "Run 'threads auth login' to authenticate with your Threads account",
+}
+
+// resolveAccount returns the account commands act on: the one named with
+// --account or THREADS_ACCOUNT, else the one chosen with 'threads auth
+// switch', else the only stored account. With several stored and none
+// chosen there is no safe guess, so it fails.
 func (f *Factory) resolveAccount() (string, error) {
 	if f.Account != "" {
 		return f.Account, nil
@@ -142,14 +294,45 @@ func (f *Factory) resolveAccount() (string, error) {
 		return "", FormatError(err)
 	}
 
-	if len(accounts) == 0 {
+	switch len(accounts) {
+	case 0:
+		return "", errNoAccount
+	case 1:
+		return accounts[0], nil
+	default:
 		return "", &UserFriendlyError{
-			Message:    "No Threads account configured",
-			Suggestion: "Run 'threads auth login' to authenticate with your Threads account",
+			Message:    fmt.Sprintf("%d accounts are stored and none is selected", len(accounts)),
+			Suggestion: "Run 'threads auth switch' to choose one, or pass --account with one of: " + strings.Join(accounts, ", "),
 		}
 	}
+}
+
+// activeCredentials returns the stored credentials of the account commands
+// act on, for commands that need more than an API client
+func (f *Factory) activeCredentials() (*secrets.Credentials, error) {
+	account, err := f.resolveAccount()
+	if err != nil {
+		return nil, err
+	}
+	store, err := f.Store()
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	creds, err := store.Get(account)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	return creds, nil
+}
 
-	return accounts[0], nil
+// currentAccountName returns the account commands act on, or "" if none is
+// configured
+func (f *Factory) currentAccountName() string {
+	name, err := f.resolveAccount()
+	if err != nil {
+		return ""
+	}
+	return name
 }
 
 func (f *Factory) logger() api.Logger {
@@ -159,25 +342,74 @@ func (f *Factory) logger() api.Logger {
 	return f.debugLog
 }
 
+// openHTTPTrace starts recording API traffic to path, appending to any
+// existing log. The file is created with owner-only permissions because it
+// holds full request and response bodies.
+func (f *Factory) openHTTPTrace(path string, maxBodyBytes int) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Cannot open HTTP debug log: %s", path),
+			Suggestion: "Check that the directory exists and is writable",
+			Cause:      err,
+		}
+	}
+	f.closeHTTPTrace()
+	f.httpTraceFile = file
+	f.httpTrace = api.NewHTTPTrace(file, maxBodyBytes)
+	return nil
+}
+
+// closeHTTPTrace stops recording API traffic
+func (f *Factory) closeHTTPTrace() {
+	if f.httpTraceFile != nil {
+		f.httpTraceFile.Close() //nolint:errcheck // Best-effort close of a debug log
+	}
+	f.httpTraceFile = nil
+	f.httpTrace = nil
+}
+
 // Confirm prompts for confirmation unless --yes is set.
-// Returns false when stdin is not a TTY.
-func (f *Factory) Confirm(ctx context.Context, prompt string) bool {
+// Returns false when stdin is not a TTY, and an error with --strict, which
+// never prompts.
+func (f *Factory) Confirm(ctx context.Context, prompt string) (bool, error) {
 	if outfmt.GetYes(ctx) {
-		return true
+		return true, nil
+	}
+	if outfmt.GetStrict(ctx) {
+		return false, errStrictPrompt
 	}
 
 	io := iocontext.GetIO(ctx)
 	if !isTerminalReader(io.In) {
 		fmt.Fprintln(io.ErrOut, "error: cannot prompt for confirmation (stdin is not a terminal)")   //nolint:errcheck // Best-effort output
 		fmt.Fprintln(io.ErrOut, "hint: use --yes (-y) to skip confirmation in non-interactive mode") //nolint:errcheck // Best-effort output
-		return false
+		return false, nil
 	}
 
+	if label := f.accountPromptLabel(ctx); label != "" {
+		prompt = label + " " + prompt
+	}
 	fmt.Fprintf(io.Out, "%s [y/N]: ", prompt) //nolint:errcheck // Best-effort output
 	var response string
 	//nolint:errcheck,gosec // Scanln error is fine - empty response means "no"
 	fmt.Fscanln(io.In, &response)
-	return response == "y" || response == "Y" || response == "yes"
+	return response == "y" || response == "Y" || response == "yes", nil
+}
+
+// accountPromptLabel returns "[account · note]" in the account's color when
+// the current account is labeled, so prompts show which account acts
+func (f *Factory) accountPromptLabel(ctx context.Context) string {
+	name := f.currentAccountName()
+	settings := f.Config.ForAccount(name)
+	if name == "" || settings.IsZero() {
+		return ""
+	}
+	label := name
+	if settings.Note != "" {
+		label += " · " + settings.Note
+	}
+	return colorAccountLabel(ctx, f, settings, "["+label+"]")
 }
 
 func isTerminalReader(r any) bool {
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/fixtures"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// fixturesAPIURL is where fixtures are recorded from. It is replaced in tests.
+var fixturesAPIURL = api.BaseAPIURL
+
+// fixtureEndpoints are the responses 'fixtures record' captures, in order:
+// the post endpoints use the newest post of the account
+var fixtureEndpoints = []fixtures.Endpoint{
+	{Name: "me", Path: "/me", Params: url.Values{"fields": {api.UserProfileFields}}},
+	{Name: "user_posts", Path: "/me/threads", Params: url.Values{"fields": {api.PostExtendedFields}, "limit": {"3"}}},
+	{Name: "post", Path: "/" + fixtures.PostIDVar, Params: url.Values{"fields": {api.PostExtendedFields}}},
+	{Name: "replies", Path: "/" + fixtures.PostIDVar + "/replies", Params: url.Values{"fields": {api.ReplyFields}}},
+	{Name: "post_insights", Path: "/" + fixtures.PostIDVar + "/insights", Params: url.Values{"metric": {"views,likes,replies,reposts,quotes"}}},
+	{Name: "publishing_limit", Path: "/me/threads_publishing_limit", Params: url.Values{"fields": {api.PublishingLimitFields}}},
+}
+
+// NewFixturesCmd builds the fixtures command group, used to maintain the
+// API responses the test suite runs against.
+func NewFixturesCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "fixtures",
+		Short:  "Maintain the API fixtures of the test suite",
+		Hidden: true,
+	}
+
+	cmd.AddCommand(newFixturesRecordCmd(f))
+
+	return cmd
+}
+
+func newFixturesRecordCmd(f *Factory) *cobra.Command {
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "Record sanitized API responses into testdata",
+		Long: `Fetch a fixed set of responses from the real API with the active account,
+sanitize them and write them to the fixtures testdata directory.
+
+Sanitizing scrambles every ID, username, shortcode and paging cursor
+consistently across the recording, replaces display names, points media
+URLs at example.com and redacts tokens. Review the diff before committing:
+free text such as post captions is kept as posted, so record with a test
+account.
+
+Run it from the repository root:
+  threads fixtures record`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFixturesRecord(cmd, f, out)
+		},
+	}
+
+	cmd.Flags().StringVar(&out, "out", filepath.FromSlash(fixtures.Dir), "Directory to write fixtures to")
+	return cmd
+}
+
+func runFixturesRecord(cmd *cobra.Command, f *Factory, out string) error {
+	ctx := cmd.Context()
+
+	creds, err := f.activeCredentials()
+	if err != nil {
+		return err
+	}
+
+	cfg := api.NewConfig()
+	cfg.BaseURL = fixturesAPIURL
+	cfg.HTTPTrace = f.httpTrace
+	client := api.NewHTTPClient(cfg, nil)
+	fetch := func(ctx context.Context, path string, params url.Values) ([]byte, error) {
+		resp, err := client.GET(ctx, path, params, creds.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Body, nil
+	}
+
+	written, err := fixtures.Record(ctx, out, fixtureEndpoints, fetch, fixtures.NewScrambler())
+	if err != nil {
+		return WrapError("failed to record fixtures", err)
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
+			"dir":   out,
+			"files": written,
+		})
+	}
+	if len(written) < len(fixtureEndpoints) {
+		f.UI(ctx).Warning("The account has no posts, so post fixtures were not recorded")
+	}
+	f.UI(ctx).Success("Recorded %d fixtures to %s", len(written), out)
+	for _, file := range written {
+		f.UI(ctx).Info("  %s", strings.TrimPrefix(file, out+string(filepath.Separator)))
+	}
+	return nil
+}
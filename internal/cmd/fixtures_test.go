@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/fixtures"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// IDs in the recorded fixtures
+const (
+	// fixturePostID is the newest post in the user_posts fixture
+	fixturePostID = "18063927415508136"
+	// fixtureUserID is the account of the me fixture
+	fixtureUserID = "17841452903716482"
+)
+
+// newFixtureServer serves the recorded fixtures at the paths they were
+// recorded from
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	routes := map[string]string{
+		"/me":                             "me",
+		"/me/threads":                     "user_posts",
+		"/me/threads_publishing_limit":    "publishing_limit",
+		"/" + fixturePostID:               "post",
+		"/" + fixturePostID + "/replies":  "replies",
+		"/" + fixturePostID + "/insights": "post_insights",
+		// Conversations have the shape of replies
+		"/" + fixturePostID + "/conversation": "replies",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "refresh_access_token") {
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+			return
+		}
+		// The client addresses the account by ID rather than /me
+		path := r.URL.Path
+		for _, id := range []string{testCredentials().UserID, fixtureUserID} {
+			if rest, ok := strings.CutPrefix(path, "/"+id); ok {
+				path = "/me" + rest
+			}
+		}
+		name, ok := routes[path]
+		if !ok {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(fixtures.Get(name))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFixturesRecord(t *testing.T) {
+	server := newFixtureServer(t)
+	orig := fixturesAPIURL
+	fixturesAPIURL = server.URL
+	t.Cleanup(func() { fixturesAPIURL = orig })
+
+	f, io := newIntegrationTestFactory(t, server.URL)
+	dir := t.TempDir()
+
+	cmd := newFixturesRecordCmd(f)
+	cmd.SetArgs([]string{"--out", dir})
+	cmd.SetContext(outfmt.WithFormat(iocontext.WithIO(context.Background(), io), "text"))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("record failed: %v", err)
+	}
+
+	for _, ep := range fixtureEndpoints {
+		data, err := os.ReadFile(filepath.Join(dir, ep.Name+".json"))
+		if err != nil {
+			t.Errorf("fixture %s not written: %v", ep.Name, err)
+			continue
+		}
+		if bytes.Contains(data, []byte(fixturePostID)) {
+			t.Errorf("fixture %s still contains the original post ID", ep.Name)
+		}
+	}
+	if out := io.Out.(*bytes.Buffer).String(); !strings.Contains(out, "Recorded 6 fixtures") {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestFixturesCmd_Hidden(t *testing.T) {
+	f := newTestFactory(t)
+	if cmd := NewFixturesCmd(f); !cmd.Hidden {
+		t.Error("fixtures is a maintenance command and should be hidden")
+	}
+}
+
+func TestPostsGet_Fixture(t *testing.T) {
+	server := newFixtureServer(t)
+	f, io := newIntegrationTestFactory(t, server.URL)
+
+	cmd := newPostsGetCmd(f)
+	cmd.SetArgs([]string{fixturePostID})
+	cmd.SetContext(outfmt.WithFormat(iocontext.WithIO(context.Background(), io), "text"))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	output := io.Out.(*bytes.Buffer).String()
+	for _, want := range []string{fixturePostID, "@user_k3v9q2md", "Shipping the new release today"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q, got: %s", want, output)
+		}
+	}
+}
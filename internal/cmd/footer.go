@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// writeListFooter prints a paging summary to stderr after text list output
+// when --footer (or the footer config key) is set. JSON output carries its
+// own paging fields, so the footer is never printed there.
+func writeListFooter(cmd *cobra.Command, args []string, shown int, nextCursor string) {
+	ctx := cmd.Context()
+	if !outfmt.GetFooter(ctx) || outfmt.IsJSON(ctx) {
+		return
+	}
+
+	io := iocontext.GetIO(ctx)
+	noun := "results"
+	if shown == 1 {
+		noun = "result"
+	}
+
+	if nextCursor == "" {
+		fmt.Fprintf(io.ErrOut, "\nShowing %d %s (last page)\n", shown, noun) //nolint:errcheck // Best-effort output to stderr
+		return
+	}
+	fmt.Fprintf(io.ErrOut, "\nShowing %d %s, next cursor: %s\n", shown, noun, nextCursor) //nolint:errcheck // Best-effort output to stderr
+	fmt.Fprintf(io.ErrOut, "Next page: %s\n", nextPageCommand(cmd, args, nextCursor))     //nolint:errcheck // Best-effort output to stderr
+}
+
+// nextPageCommand rebuilds the invocation of cmd with --cursor set to cursor,
+// keeping every other flag the user passed
+func nextPageCommand(cmd *cobra.Command, args []string, cursor string) string {
+	parts := []string{cmd.CommandPath()}
+	for _, arg := range args {
+		parts = append(parts, shellQuote(arg))
+	}
+
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if flag.Name == "cursor" {
+			return
+		}
+		if sv, ok := flag.Value.(pflag.SliceValue); ok {
+			for _, v := range sv.GetSlice() {
+				parts = append(parts, "--"+flag.Name, shellQuote(v))
+			}
+			return
+		}
+		if flag.Value.Type() == "bool" {
+			if flag.Value.String() == "true" {
+				parts = append(parts, "--"+flag.Name)
+			} else {
+				parts = append(parts, "--"+flag.Name+"=false")
+			}
+			return
+		}
+		parts = append(parts, "--"+flag.Name, shellQuote(flag.Value.String()))
+	})
+
+	parts = append(parts, "--cursor", shellQuote(cursor))
+	return strings.Join(parts, " ")
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9@%_+=:,./-]+$`)
+
+// shellQuote quotes s for POSIX shells when it contains special characters
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
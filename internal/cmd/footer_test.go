@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// newFooterTestCmd returns a parsed "threads search" command with a footer context
+func newFooterTestCmd(t *testing.T, ctx context.Context, argv ...string) (*cobra.Command, *bytes.Buffer) {
+	t.Helper()
+
+	root := &cobra.Command{Use: "threads"}
+	root.PersistentFlags().StringP("account", "a", "", "")
+	cmd := &cobra.Command{Use: "search", Run: func(*cobra.Command, []string) {}}
+	cmd.Flags().Int("limit", 25, "")
+	cmd.Flags().String("cursor", "", "")
+	cmd.Flags().Bool("all", false, "")
+	cmd.Flags().StringSlice("event", nil, "")
+	root.AddCommand(cmd)
+
+	if err := cmd.ParseFlags(argv); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+
+	var errOut bytes.Buffer
+	ctx = iocontext.WithIO(ctx, &iocontext.IO{Out: &bytes.Buffer{}, ErrOut: &errOut})
+	cmd.SetContext(ctx)
+	return cmd, &errOut
+}
+
+func TestNextPageCommand(t *testing.T) {
+	cmd, _ := newFooterTestCmd(t, context.Background(),
+		"--limit", "10", "--cursor", "old", "--all", "--event", "mentions", "--event", "deletes", "-a", "work")
+
+	got := nextPageCommand(cmd, []string{"hello world"}, "QVFI=")
+	want := "threads search 'hello world' --account work --all --event mentions --event deletes --limit 10 --cursor QVFI="
+	if got != want {
+		t.Errorf("nextPageCommand() =\n  %s\nwant\n  %s", got, want)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"simple":      "simple",
+		"a b":         "'a b'",
+		"it's":        `'it'\''s'`,
+		"":            "''",
+		"user@x.com":  "user@x.com",
+		"$HOME":       "'$HOME'",
+		"key=value,1": "key=value,1",
+	}
+	for in, want := range tests {
+		if got := shellQuote(in); got != want {
+			t.Errorf("shellQuote(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWriteListFooter(t *testing.T) {
+	ctx := outfmt.WithFooter(context.Background(), true)
+	cmd, errOut := newFooterTestCmd(t, ctx, "--limit", "25")
+
+	writeListFooter(cmd, []string{"go"}, 25, "NEXT")
+
+	out := errOut.String()
+	if !strings.Contains(out, "Showing 25 results, next cursor: NEXT") {
+		t.Errorf("missing summary:\n%s", out)
+	}
+	if !strings.Contains(out, "Next page: threads search go --limit 25 --cursor NEXT") {
+		t.Errorf("missing next page command:\n%s", out)
+	}
+}
+
+func TestWriteListFooter_LastPage(t *testing.T) {
+	ctx := outfmt.WithFooter(context.Background(), true)
+	cmd, errOut := newFooterTestCmd(t, ctx)
+
+	writeListFooter(cmd, nil, 1, "")
+
+	if out := errOut.String(); !strings.Contains(out, "Showing 1 result (last page)") {
+		t.Errorf("unexpected footer:\n%s", out)
+	}
+}
+
+func TestWriteListFooter_Suppressed(t *testing.T) {
+	tests := map[string]context.Context{
+		"not enabled": context.Background(),
+		"json":        outfmt.NewContext(outfmt.WithFooter(context.Background(), true), outfmt.JSON),
+	}
+	for name, ctx := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmd, errOut := newFooterTestCmd(t, ctx)
+			writeListFooter(cmd, nil, 25, "NEXT")
+			if errOut.Len() != 0 {
+				t.Errorf("expected no footer, got:\n%s", errOut.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+// Golden tests run commands end to end against the recorded fixtures and
+// compare what they print with testdata/golden, so changes to column order,
+// truncation or colors show up in review. After an intended change, rewrite
+// the files and check the diff:
+//
+//	go test ./internal/cmd -run Golden -update
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenScrubbers replace output that differs between runs
+var goldenScrubbers = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`\((?:now|in [^)]*|[^()]* ago)\)`), "(<relative time>)"},
+	{regexp.MustCompile(`resets by \S+`), "resets by <time>"},
+	{regexp.MustCompile(`"fetched_at": "[^"]*"`), `"fetched_at": "<time>"`},
+}
+
+// goldenFormats are the output flags each golden case is run with
+var goldenFormats = []struct {
+	name string
+	args []string
+}{
+	{"text", nil},
+	{"json", []string{"--output", "json"}},
+	{"ids", []string{"--output", "ids"}},
+	{"csv", []string{"--output", "csv"}},
+	{"tsv", []string{"--output", "tsv"}},
+	{"color", []string{"--color", "always"}},
+}
+
+func TestGolden(t *testing.T) {
+	// Pin what decides colors, so --color always renders the same everywhere
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("COLORTERM", "truecolor")
+
+	server := newFixtureServer(t)
+
+	cases := []struct {
+		name    string
+		args    []string
+		formats []string
+	}{
+		{"me", []string{"me"}, []string{"text", "json"}},
+		{"posts_list", []string{"posts", "list"}, []string{"text", "json", "ids", "csv", "tsv", "color"}},
+		{"posts_get", []string{"posts", "get", fixturePostID}, []string{"text", "json", "color"}},
+		{"replies_list", []string{"replies", "list", fixturePostID}, []string{"text", "json", "ids", "csv", "tsv", "color"}},
+		{"insights_post", []string{"insights", "post", fixturePostID}, []string{"text", "json", "color"}},
+		{"ratelimit_publishing", []string{"ratelimit", "publishing"}, []string{"text", "json"}},
+	}
+
+	for _, tc := range cases {
+		for _, format := range goldenFormats {
+			if !slices.Contains(tc.formats, format.name) {
+				continue
+			}
+			name := tc.name + "." + format.name
+			t.Run(name, func(t *testing.T) {
+				f, io := newIntegrationTestFactory(t, server.URL)
+				cmd := NewRootCmd(f)
+				cmd.SetArgs(append(append([]string{}, tc.args...), format.args...))
+				cmd.SetContext(iocontext.WithIO(context.Background(), io))
+				if err := ExecuteCommand(cmd, f); err != nil {
+					t.Fatalf("command failed: %v", err)
+				}
+
+				out := io.Out.(*bytes.Buffer).Bytes()
+				if format.name != "color" && bytes.Contains(out, []byte("\x1b[")) {
+					t.Errorf("color codes leaked into piped output:\n%q", out)
+				}
+				assertGolden(t, name, out)
+			})
+		}
+	}
+}
+
+// assertGolden compares got, scrubbed, with testdata/golden/<name>.golden,
+// or rewrites that file when -update is given
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	for _, s := range goldenScrubbers {
+		got = s.pattern.ReplaceAll(got, []byte(s.replacement))
+	}
+	path := filepath.Join("testdata", "golden", name+".golden")
+
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil { //nolint:gosec // testdata is checked in
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path) //nolint:gosec // Path built from the test case name
+	if err != nil {
+		t.Fatalf("missing golden file; run go test ./internal/cmd -run Golden -update: %v", err)
+	}
+	// Checkouts with CRLF line endings still compare equal
+	want = bytes.ReplaceAll(want, []byte("\r\n"), []byte("\n"))
+	if bytes.Equal(got, want) {
+		return
+	}
+
+	gotLines := strings.Split(string(got), "\n")
+	wantLines := strings.Split(string(want), "\n")
+	for i := 0; i < max(len(gotLines), len(wantLines)); i++ {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			t.Errorf("output differs from %s at line %d:\n got: %q\nwant: %q\n\nfull output:\n%s", path, i+1, g, w, got)
+			return
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/guides"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// newHelpCmd replaces cobra's help command, so the guides can hang off it
+func newHelpCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "help [command]",
+		Short: "Help about any command",
+		Long: `Help provides help for any command in the application.
+Simply type threads help [path to command] for full details.
+
+Long-form guides on setup and workflows: threads help guides`,
+		Args: cobra.ArbitraryArgs,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			target, _, err := cmd.Root().Find(args)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			var names []string
+			for _, sub := range target.Commands() {
+				if (sub.IsAvailableCommand() || sub == cmd) && strings.HasPrefix(sub.Name(), toComplete) {
+					names = append(names, sub.Name())
+				}
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, _, err := cmd.Root().Find(args)
+			if target == nil || err != nil {
+				cmd.Printf("Unknown help topic %#q\n", args)
+				return cmd.Root().Usage()
+			}
+			target.InitDefaultHelpFlag()
+			return target.Help()
+		},
+	}
+
+	cmd.AddCommand(newHelpGuidesCmd(f))
+
+	return cmd
+}
+
+func newHelpGuidesCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "guides [topic]",
+		Short: "Read the guides on setup and workflows",
+		Long: `Read long-form guides on setting up and running the CLI. The guides are
+built into the binary, so they work offline and match the installed version.
+
+Without a topic, lists the guides. A guide is shown in $PAGER (less by
+default) when the output is a terminal; set PAGER=cat to print it instead.`,
+		Example: `  threads help guides
+  threads help guides auth
+  threads help guides webhooks > webhooks.md`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: guides.Names(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			io := iocontext.GetIO(ctx)
+
+			if len(args) == 0 {
+				list := guides.List()
+				if outfmt.IsJSON(ctx) {
+					return outfmt.WriteJSONContext(ctx, io.Out, list)
+				}
+				rows := make([][]string, len(list))
+				for i, g := range list {
+					rows[i] = []string{g.Name, g.Title}
+				}
+				out := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
+				if err := out.Table([]string{"TOPIC", "TITLE"}, rows, []outfmt.ColumnType{outfmt.ColumnID, outfmt.ColumnPlain}); err != nil {
+					return err
+				}
+				fmt.Fprintln(io.Out, "\nRead one with: threads help guides <topic>") //nolint:errcheck // Best-effort output
+				return nil
+			}
+
+			content, err := guides.Read(args[0])
+			if err != nil {
+				return &UserFriendlyError{
+					Message:    fmt.Sprintf("No guide on %q", args[0]),
+					Suggestion: "Topics: " + strings.Join(guides.Names(), ", "),
+					Cause:      err,
+				}
+			}
+			if outfmt.IsJSON(ctx) {
+				return outfmt.WriteJSONContext(ctx, io.Out, map[string]string{
+					"name":    args[0],
+					"content": content,
+				})
+			}
+			return page(ctx, content)
+		},
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+// stubPager makes stdout a terminal and records what the pager is given
+func stubPager(t *testing.T, err error) (pager, text *string) {
+	t.Helper()
+	origTerminal, origPager := stdoutIsTerminal, runPager
+	t.Cleanup(func() { stdoutIsTerminal, runPager = origTerminal, origPager })
+
+	pager, text = new(string), new(string)
+	stdoutIsTerminal = func(context.Context) bool { return true }
+	runPager = func(_ context.Context, p, s string) error {
+		*pager, *text = p, s
+		return err
+	}
+	return pager, text
+}
+
+func runHelp(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	f := newTestFactory(t)
+	cmd := NewRootCmd(f)
+	cmd.SetArgs(args)
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+	err := ExecuteCommand(cmd, f)
+	return f.IO.Out.(*bytes.Buffer).String(), err
+}
+
+func TestHelpGuides_List(t *testing.T) {
+	out, err := runHelp(t, "help", "guides")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, topic := range []string{"auth", "media", "scheduling", "webhooks", "Authentication setup"} {
+		if !strings.Contains(out, topic) {
+			t.Errorf("expected %q in the list:\n%s", topic, out)
+		}
+	}
+}
+
+func TestHelpGuides_ListJSON(t *testing.T) {
+	out, err := runHelp(t, "help", "guides", "-o", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var list []struct{ Name, Title, Summary string }
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(list) != 4 || list[0].Name != "auth" || list[0].Summary == "" {
+		t.Errorf("unexpected list: %+v", list)
+	}
+}
+
+func TestHelpGuides_Pager(t *testing.T) {
+	t.Setenv("PAGER", "less -S")
+	pager, text := stubPager(t, nil)
+
+	out, err := runHelp(t, "help", "guides", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *pager != "less -S" {
+		t.Errorf("pager = %q, want $PAGER", *pager)
+	}
+	if !strings.HasPrefix(*text, "# Authentication setup") {
+		t.Errorf("pager got %.40q", *text)
+	}
+	if out != "" {
+		t.Errorf("expected nothing written around the pager, got %q", out)
+	}
+}
+
+func TestHelpGuides_NoPager(t *testing.T) {
+	tests := []struct {
+		name     string
+		pager    string
+		terminal bool
+		pagerErr error
+	}{
+		{"not a terminal", "less", false, nil},
+		{"PAGER=cat", "cat", true, nil},
+		{"PAGER empty", "", true, nil},
+		{"pager fails", "missing-pager", true, errors.New("not found")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PAGER", tt.pager)
+			stubPager(t, tt.pagerErr)
+			stdoutIsTerminal = func(context.Context) bool { return tt.terminal }
+
+			out, err := runHelp(t, "help", "guides", "webhooks")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(out, "# Webhooks\n") {
+				t.Errorf("expected the guide on stdout, got %.40q", out)
+			}
+		})
+	}
+}
+
+func TestHelpGuides_UnknownTopic(t *testing.T) {
+	_, err := runHelp(t, "help", "guides", "nope")
+	var ufe *UserFriendlyError
+	if !errors.As(err, &ufe) {
+		t.Fatalf("expected a UserFriendlyError, got %v", err)
+	}
+	if !strings.Contains(ufe.Suggestion, "auth, media, scheduling, webhooks") {
+		t.Errorf("suggestion should list the topics: %q", ufe.Suggestion)
+	}
+}
+
+func TestHelp_Command(t *testing.T) {
+	out, err := runHelp(t, "help", "posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "threads posts [command]") {
+		t.Errorf("expected the posts help, got:\n%s", out)
+	}
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/events"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+// hookTimeout bounds a hook command, so a hung script cannot stall the
+// command that published the event
+const hookTimeout = time.Minute
+
+// hookEnvPrefix starts the environment variables of hook commands. It differs
+// from the config prefix so event values never change the settings of a
+// threads command run by the hook.
+const hookEnvPrefix = "THREADS_EVENT_"
+
+// hookFields are the values a hook can use, as {{.name}} in its command and
+// as THREADS_EVENT_<NAME> in its environment. Fields an event does not have
+// are empty.
+var hookFields = []string{
+	"type", "source", "id", "username", "text", "permalink", "timestamp", "media_type",
+	"labels", "account", "expires_at", "error", "json",
+}
+
+// runHook runs the command configured under hooks.<type> for e. Hooks come
+// from the config loaded when the command started, so a long-running command
+// needs a restart to pick up 'threads config set'. A failing hook is a
+// warning: the event has already happened.
+func (f *Factory) runHook(ctx context.Context, e events.Event) error {
+	command := f.Config.Hooks[string(e.Type())]
+	if strings.TrimSpace(command) == "" {
+		return nil
+	}
+
+	line, err := renderHook(command)
+	if err == nil {
+		err = execHook(ctx, line, hookValues(e))
+	}
+	if err != nil {
+		return warn(ctx, &UserFriendlyError{
+			Message:    fmt.Sprintf("The %s hook failed: %v", e.Type(), err),
+			Suggestion: fmt.Sprintf("Check the command with 'threads config get hooks.%s'", e.Type()),
+			Cause:      err,
+		})
+	}
+	return nil
+}
+
+// renderHook replaces the {{.name}} placeholders of command with references
+// to the matching environment variables. The shell expands them as single
+// words, so post text from other people can never run as part of the command.
+func renderHook(command string) (string, error) {
+	tmpl, err := template.New("hook").Option("missingkey=error").Parse(command)
+	if err != nil {
+		return "", err
+	}
+	refs := make(map[string]string, len(hookFields))
+	for _, name := range hookFields {
+		refs[name] = hookEnvRef(hookEnvPrefix + strings.ToUpper(name))
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, refs); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// execHook runs line in the shell with values added to the environment.
+// Output goes to stderr, keeping the command's own output parseable.
+func execHook(ctx context.Context, line string, values map[string]string) error {
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+
+	cmd := hookShell(ctx, line)
+	cmd.Env = os.Environ()
+	for _, name := range hookFields {
+		cmd.Env = append(cmd.Env, hookEnvPrefix+strings.ToUpper(name)+"="+values[name])
+	}
+	io := iocontext.GetIO(ctx)
+	cmd.Stdout, cmd.Stderr = io.ErrOut, io.ErrOut
+	return cmd.Run()
+}
+
+// hookShell and hookEnvRef are the platform's shell and its quoted variable
+// reference. cmd.exe does not protect quotes inside expanded values, so on
+// Windows hooks should read text from the environment rather than their
+// command line.
+func hookShell(ctx context.Context, line string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", line) //nolint:gosec // The hook is configured by the user
+	}
+	return exec.CommandContext(ctx, "sh", "-c", line) //nolint:gosec // The hook is configured by the user
+}
+
+func hookEnvRef(name string) string {
+	if runtime.GOOS == "windows" {
+		return `"%` + name + `%"`
+	}
+	return `"$` + name + `"`
+}
+
+// hookValues flattens e into the values of hookFields
+func hookValues(e events.Event) map[string]string {
+	values := map[string]string{"type": string(e.Type())}
+	if data, err := json.Marshal(e); err == nil {
+		values["json"] = string(data)
+	}
+
+	var post *api.Post
+	switch e := e.(type) {
+	case events.NewMention:
+		post, values["source"] = &e.Post, string(e.Source)
+	case events.NewReply:
+		post, values["source"] = &e.Reply, string(e.Source)
+		values["labels"] = strings.Join(e.Labels, ",")
+	case events.PostPublished:
+		post, values["source"] = &e.Post, string(e.Source)
+	case events.PublishFailed:
+		values["source"], values["text"] = string(e.Source), e.Text
+		values["id"], values["account"], values["error"] = e.QueueID, e.Account, e.Error
+	case events.TokenExpiring:
+		values["account"] = e.Account
+		values["expires_at"] = e.ExpiresAt.Format(time.RFC3339)
+	case events.TokenRefreshed:
+		values["account"] = e.Account
+		values["expires_at"] = e.ExpiresAt.Format(time.RFC3339)
+	case events.TokenRefreshFailed:
+		values["account"], values["error"] = e.Account, e.Error
+		values["expires_at"] = e.ExpiresAt.Format(time.RFC3339)
+	}
+	if post != nil {
+		values["id"] = post.ID
+		values["username"] = post.Username
+		values["text"] = post.Text
+		values["permalink"] = post.Permalink
+		values["media_type"] = post.MediaType
+		if !post.Timestamp.IsZero() {
+			values["timestamp"] = post.Timestamp.Format(time.RFC3339)
+		}
+	}
+	return values
+}
+
+// setHook sets the hook for event, or removes it when command is empty
+func setHook(cfg *config.Config, event, command string) error {
+	if !slices.Contains(config.HookEvents, event) {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Unknown hook event: %s", event),
+			Suggestion: "Valid events: " + strings.Join(config.HookEvents, ", "),
+		}
+	}
+	if command == "" {
+		delete(cfg.Hooks, event)
+		return nil
+	}
+	if _, err := renderHook(command); err != nil {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid hook command: %v", err),
+			Suggestion: "Placeholders look like {{.id}}; available: " + strings.Join(hookFields, ", "),
+			Cause:      err,
+		}
+	}
+	if cfg.Hooks == nil {
+		cfg.Hooks = map[string]string{}
+	}
+	cfg.Hooks[event] = command
+	return nil
+}
+
+// sortedHookEvents returns the events with a hook, in name order
+func sortedHookEvents(hooks map[string]string) []string {
+	names := make([]string, 0, len(hooks))
+	for name := range hooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
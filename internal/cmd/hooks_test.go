@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/events"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+func TestHooks_RunWithEventValues(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in tests use sh")
+	}
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	f.Config.Hooks = map[string]string{
+		"post_published": `printf '%s|%s|%s\n' {{.id}} {{.text}} "$THREADS_EVENT_PERMALINK" > out`,
+	}
+
+	post := api.Post{ID: "42", Text: `it's $(touch pwned) "fine"`, Permalink: "https://threads.net/p/42"}
+	ctx := iocontext.WithIO(context.Background(), io)
+	if err := f.Events.Publish(ctx, events.PostPublished{Post: post, Source: events.SourceCommand}); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "out")) //nolint:gosec // Test file
+	if err != nil {
+		t.Fatalf("expected the hook to run: %v\n%s", err, io.ErrOut.(*bytes.Buffer).String())
+	}
+	if want := "42|" + post.Text + "|" + post.Permalink + "\n"; string(data) != want {
+		t.Errorf("hook wrote %q, want %q", data, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pwned")); !os.IsNotExist(err) {
+		t.Error("expected post text never to run as part of the command")
+	}
+
+	// Events without a hook run nothing
+	if err := f.Events.Publish(ctx, events.NewMention{Post: post}); err != nil {
+		t.Errorf("expected no error without a hook, got %v", err)
+	}
+}
+
+func TestHooks_FailureWarns(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in tests use sh")
+	}
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	f.Config.Hooks = map[string]string{"new_mention": "exit 3"}
+
+	ctx := iocontext.WithIO(context.Background(), io)
+	if err := f.Events.Publish(ctx, events.NewMention{}); err != nil {
+		t.Fatalf("expected a failing hook to only warn, got %v", err)
+	}
+	if !strings.Contains(io.ErrOut.(*bytes.Buffer).String(), "warning: The new_mention hook failed: exit status 3") {
+		t.Errorf("expected a warning, got:\n%s", io.ErrOut.(*bytes.Buffer).String())
+	}
+}
+
+func TestApplyConfigValue_Hooks(t *testing.T) {
+	cfg := config.Default()
+	if err := applyConfigValue(cfg, "hooks.post_published", "./notify.sh {{.id}} {{.permalink}}"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Hooks["post_published"] != "./notify.sh {{.id}} {{.permalink}}" {
+		t.Errorf("unexpected hooks: %v", cfg.Hooks)
+	}
+	if value, ok := configValue(cfg, "hooks.post_published"); !ok || value != cfg.Hooks["post_published"] {
+		t.Errorf("configValue = %v, %v", value, ok)
+	}
+
+	if err := applyConfigValue(cfg, "hooks.on_like", "x"); err == nil || !strings.Contains(err.Error(), "Unknown hook event") {
+		t.Errorf("expected an unknown event error, got %v", err)
+	}
+	if err := applyConfigValue(cfg, "hooks.new_reply", "./notify.sh {{.likes}}"); err == nil || !strings.Contains(err.Error(), "Invalid hook command") {
+		t.Errorf("expected an invalid placeholder error, got %v", err)
+	}
+	if err := applyConfigValue(cfg, "hooks", "x"); err == nil {
+		t.Error("expected setting all hooks at once to fail")
+	}
+
+	if err := applyConfigValue(cfg, "hooks.post_published", ""); err != nil || len(cfg.Hooks) != 0 {
+		t.Errorf("expected the hook to be unset, got %v, %v", cfg.Hooks, err)
+	}
+}
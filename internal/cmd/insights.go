@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -21,14 +24,22 @@ func NewInsightsCmd(f *Factory) *cobra.Command {
 
 	cmd.AddCommand(newInsightsPostCmd(f))
 	cmd.AddCommand(newInsightsAccountCmd(f))
+	cmd.AddCommand(newInsightsReachCmd(f))
+	cmd.AddCommand(newInsightsCommunityCmd(f))
+	cmd.AddCommand(newInsightsRollupCmd(f))
 
 	return cmd
 }
 
 type insightsPostOptions struct {
 	Metrics []string
+	Range   dateRangeOptions
 }
 
+// maxRangeInsightPosts bounds the posts 'insights post --since' reports on,
+// as each costs a request
+const maxRangeInsightPosts = 200
+
 func newInsightsPostCmd(f *Factory) *cobra.Command {
 	opts := &insightsPostOptions{
 		Metrics: []string{"views", "likes", "replies", "reposts"},
@@ -36,9 +47,13 @@ func newInsightsPostCmd(f *Factory) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "post [post-id]",
-		Short: "Get insights for a post",
+		Short: "Get insights for a post, or for your posts from a period",
 		Long: `Get analytics insights for a specific post.
 
+Without a post ID, --since and --until select your posts published in that
+period and list their insights, one row per post, newest first. Post
+insights are lifetime totals: the range picks the posts, not the activity.
+
 Available metrics: views, likes, replies, reposts, quotes, shares, link_clicks, profile_clicks
 
 Click metrics:
@@ -49,19 +64,55 @@ Examples:
   threads insights post 12345678901234567
   threads insights post 12345678901234567 --metrics views,likes,replies
   threads insights post 12345678901234567 --metrics link_clicks,profile_clicks
-  threads insights post 12345678901234567 --output json`,
-		Args: cobra.ExactArgs(1),
+  threads insights post 12345678901234567 --output json
+  threads insights post --since 30d --metrics views,shares
+  threads insights post --since 2025-06-01 --until 2025-07-01 --output csv`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runInsightsPost(cmd, f, opts, args[0])
+			return runInsightsPost(cmd, f, opts, args)
 		},
 	}
 
 	cmd.Flags().StringSliceVar(&opts.Metrics, "metrics", opts.Metrics, "Metrics to retrieve (comma-separated)")
+	cmd.Flags().StringVar(&opts.Range.Since, "since", "", "Without a post ID, report on posts published after a date or time (2025-06-01, 7d)")
+	cmd.Flags().StringVar(&opts.Range.Until, "until", "", "Without a post ID, report on posts published before a date or time (2025-06-30, yesterday)")
+	addUTCFlag(cmd, &opts.Range.UTC)
 	return cmd
 }
 
-func runInsightsPost(cmd *cobra.Command, f *Factory, opts *insightsPostOptions, postID string) error {
+func runInsightsPost(cmd *cobra.Command, f *Factory, opts *insightsPostOptions, args []string) error {
 	ctx := cmd.Context()
+
+	// Reject unknown metrics before making any API calls
+	metrics, err := api.ParsePostInsightMetrics(opts.Metrics)
+	if err != nil {
+		var validationErr *api.ValidationError
+		if errors.As(err, &validationErr) {
+			return &UserFriendlyError{
+				Message:    validationErr.Details,
+				Suggestion: "Run 'threads insights post --help' for supported metrics",
+				Cause:      err,
+			}
+		}
+		return err
+	}
+	ranged := opts.Range.Since != "" || opts.Range.Until != ""
+	switch {
+	case len(args) == 1 && ranged:
+		return &UserFriendlyError{
+			Message:    "--since and --until cannot be combined with a post ID",
+			Suggestion: "Post insights are lifetime totals; the range selects which of your posts to report on",
+		}
+	case len(args) == 0 && !ranged:
+		return &UserFriendlyError{
+			Message:    "Name a post, or a period to report on",
+			Suggestion: "threads insights post POST_ID, or threads insights post --since 30d",
+		}
+	case len(args) == 0:
+		return runInsightsPostRange(cmd, f, opts, metrics)
+	}
+	postID := args[0]
+
 	client, err := f.Client(ctx)
 	if err != nil {
 		return err
@@ -74,7 +125,7 @@ func runInsightsPost(cmd *cobra.Command, f *Factory, opts *insightsPostOptions,
 
 	io := iocontext.GetIO(ctx)
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSONTo(io.Out, insights, outfmt.GetQuery(ctx))
+		return outfmt.WriteJSONContext(ctx, io.Out, insights)
 	}
 
 	p := f.UI(ctx)
@@ -90,23 +141,110 @@ func runInsightsPost(cmd *cobra.Command, f *Factory, opts *insightsPostOptions,
 	fmtr.Header("METRIC", "VALUE", "PERIOD")
 
 	for _, insight := range insights.Data {
-		value := 0
-		if len(insight.Values) > 0 {
-			value = insight.Values[0].Value
-		} else if insight.TotalValue != nil {
-			value = insight.TotalValue.Value
-		}
-		fmtr.Row(insight.Name, value, insight.Period)
+		fmtr.Row(insight.Name, insightValue(insight), insight.Period)
 	}
 	fmtr.Flush()
 
 	return nil
 }
 
+// postInsightsView is the output model of a post's insights in a period
+// report
+type postInsightsView struct {
+	post     *api.Post
+	insights *api.PostInsights
+}
+
+func (v postInsightsView) viewFields() []viewField {
+	fields := []viewField{
+		{Key: "id", Value: v.post.ID, Type: outfmt.ColumnID},
+		{Key: "posted", Value: v.post.Timestamp.Time, Text: v.post.Timestamp.Local().Format("2006-01-02 15:04"), Type: outfmt.ColumnDate},
+		{Key: "text", Value: v.post.Text, Text: fallback(truncateText(strings.ReplaceAll(v.post.Text, "\n", " "), 40), "-")},
+	}
+	for _, metric := range v.insights.Metrics {
+		value, _ := v.insights.Value(metric)
+		fields = append(fields, viewField{Key: string(metric), Value: value, Type: outfmt.ColumnAmount})
+	}
+	return fields
+}
+
+// runInsightsPostRange lists the insights of each of the user's posts
+// published in the --since/--until range
+func runInsightsPostRange(cmd *cobra.Command, f *Factory, opts *insightsPostOptions, metrics []api.PostInsightMetric) error {
+	ctx := cmd.Context()
+
+	since, until, err := opts.Range.unixRange(f)
+	if err != nil {
+		return err
+	}
+	client, err := f.Client(ctx)
+	if err != nil {
+		return err
+	}
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return WrapError("failed to get user info", err)
+	}
+
+	names := make([]string, len(metrics))
+	for i, metric := range metrics {
+		names[i] = string(metric)
+	}
+	pager := api.NewPager(func(ctx context.Context, cursor string) (*api.PostsResponse, error) {
+		return client.GetUserPostsWithOptions(ctx, api.UserID(me.ID), &api.PostsOptions{
+			Limit: 100,
+			After: cursor,
+			Since: since,
+			Until: until,
+		})
+	})
+	posts := []api.Post{}
+	for pager.Next(ctx) {
+		if len(posts) == maxRangeInsightPosts {
+			f.UI(ctx).Warning("Only the %d most recent posts of the period are shown; narrow it with --since and --until", maxRangeInsightPosts)
+			break
+		}
+		posts = append(posts, pager.Item())
+	}
+	if err := pager.Err(); err != nil {
+		return WrapError("failed to list posts", err)
+	}
+	if len(posts) == 0 {
+		return writeViewList(ctx, []postInsightsView{}, nil, "No posts in that period")
+	}
+
+	// One batch of requests rather than a request per post
+	ids := make([]api.PostID, len(posts))
+	for i, post := range posts {
+		ids[i] = api.PostID(post.ID)
+	}
+	responses, err := client.GetPostsInsights(ctx, ids, names)
+	if err != nil {
+		return WrapError("failed to get post insights", err)
+	}
+	views := make([]postInsightsView, len(posts))
+	for i := range posts {
+		views[i] = postInsightsView{post: &posts[i], insights: api.NewPostInsights(ids[i], metrics, responses[ids[i]])}
+	}
+	return writeViewList(ctx, views, nil, "No posts in that period")
+}
+
+// insightValue returns the latest value of a metric, falling back to its total
+func insightValue(insight api.Insight) int {
+	if len(insight.Values) > 0 {
+		return insight.Values[0].Value
+	}
+	if insight.TotalValue != nil {
+		return insight.TotalValue.Value
+	}
+	return 0
+}
+
 type insightsAccountOptions struct {
-	Metrics   []string
-	Period    string
-	Breakdown string
+	Metrics    []string
+	Period     string
+	Breakdowns []string
+	Range      dateRangeOptions
 }
 
 func newInsightsAccountCmd(f *Factory) *cobra.Command {
@@ -124,8 +262,13 @@ Available metrics: views, likes, replies, reposts, quotes, clicks, followers_cou
 
 Metric details:
   clicks - Total clicks across all posts (combined link and profile clicks)
+  followers_count, follower_demographics - Only support --period lifetime and no date range
+
+With --period day, metrics the API returns day by day are listed per day.
+--since and --until limit the range; insights start on 2024-04-13.
 
-Breakdown options (for follower_demographics metric):
+Breakdown options (for follower_demographics metric, comma-separated; each
+is one request, and all four are fetched when none is given):
   country - Breakdown by country
   city    - Breakdown by city
   age     - Breakdown by age group
@@ -135,9 +278,10 @@ Examples:
   threads insights account
   threads insights account --metrics views,followers_count
   threads insights account --metrics clicks
-  threads insights account --period day
+  threads insights account --period day --since 7d
+  threads insights account --since 2025-06-01 --until 2025-07-01
   threads insights account --metrics follower_demographics --breakdown country
-  threads insights account --metrics follower_demographics --breakdown age
+  threads insights account --breakdown age,gender
   threads insights account --output json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runInsightsAccount(cmd, f, opts)
@@ -146,7 +290,10 @@ Examples:
 
 	cmd.Flags().StringSliceVar(&opts.Metrics, "metrics", opts.Metrics, "Metrics to retrieve (comma-separated)")
 	cmd.Flags().StringVar(&opts.Period, "period", opts.Period, "Time period: day, lifetime")
-	cmd.Flags().StringVar(&opts.Breakdown, "breakdown", "", "Breakdown for follower_demographics: country, city, age, gender")
+	cmd.Flags().StringSliceVar(&opts.Breakdowns, "breakdown", nil, "Breakdowns for follower_demographics: country, city, age, gender")
+	cmd.Flags().StringVar(&opts.Range.Since, "since", "", "Only count activity after a date or time (2025-06-01, 7d)")
+	cmd.Flags().StringVar(&opts.Range.Until, "until", "", "Only count activity before a date or time (2025-06-30, yesterday)")
+	addUTCFlag(cmd, &opts.Range.UTC)
 
 	return cmd
 }
@@ -158,19 +305,42 @@ func runInsightsAccount(cmd *cobra.Command, f *Factory, opts *insightsAccountOpt
 		return err
 	}
 
-	if opts.Breakdown != "" {
-		validBreakdowns := map[string]bool{
-			"country": true,
-			"city":    true,
-			"age":     true,
-			"gender":  true,
-		}
-		if !validBreakdowns[opts.Breakdown] {
+	optsReq := &api.InsightsOptions{Period: api.InsightPeriod(opts.Period)}
+	for _, m := range opts.Metrics {
+		optsReq.Metrics = append(optsReq.Metrics, api.AccountInsightMetric(m))
+	}
+	for _, b := range opts.Breakdowns {
+		optsReq.Breakdowns = append(optsReq.Breakdowns, api.FollowerDemographicsBreakdown(b))
+	}
+	// --breakdown alone asks for the demographics only
+	if len(opts.Breakdowns) > 0 && !cmd.Flags().Changed("metrics") {
+		optsReq.Metrics = nil
+	}
+	since, until, err := opts.Range.unixRange(f)
+	if err != nil {
+		return err
+	}
+	if since > 0 {
+		t := time.Unix(since, 0)
+		optsReq.Since = &t
+	}
+	if until > 0 {
+		t := time.Unix(until, 0)
+		optsReq.Until = &t
+	}
+
+	// Reject unsupported metric combinations before making any API calls
+	requests, err := optsReq.Requests()
+	if err != nil {
+		var validationErr *api.ValidationError
+		if errors.As(err, &validationErr) {
 			return &UserFriendlyError{
-				Message:    fmt.Sprintf("Invalid breakdown value: %s", opts.Breakdown),
-				Suggestion: "Valid breakdown values are: country, city, age, gender",
+				Message:    validationErr.Details,
+				Suggestion: "Run 'threads insights account --help' for supported metrics, periods and breakdowns",
+				Cause:      err,
 			}
 		}
+		return err
 	}
 
 	user, err := client.GetMe(ctx)
@@ -178,73 +348,82 @@ func runInsightsAccount(cmd *cobra.Command, f *Factory, opts *insightsAccountOpt
 		return WrapError("failed to get user info", err)
 	}
 
-	optsReq := &api.AccountInsightsOptions{
-		Breakdown: opts.Breakdown,
-	}
-
-	for _, m := range opts.Metrics {
-		optsReq.Metrics = append(optsReq.Metrics, api.AccountInsightMetric(m))
-	}
-
-	if opts.Period != "" {
-		optsReq.Period = api.InsightPeriod(opts.Period)
-	}
-
-	insights, err := client.GetAccountInsightsWithOptions(ctx, api.UserID(user.ID), optsReq)
-	if err != nil {
-		return WrapError("failed to get account insights", err)
+	// Every breakdown is its own request; JSON output merges their data
+	insights := &api.InsightsResponse{Data: []api.Insight{}}
+	responses := make([]*api.InsightsResponse, len(requests))
+	for i, req := range requests {
+		response, err := client.GetAccountInsightsWithOptions(ctx, api.UserID(user.ID), req)
+		if err != nil {
+			return WrapError("failed to get account insights", err)
+		}
+		responses[i] = response
+		insights.Data = append(insights.Data, response.Data...)
 	}
 
 	io := iocontext.GetIO(ctx)
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSONTo(io.Out, insights, outfmt.GetQuery(ctx))
+		return outfmt.WriteJSONContext(ctx, io.Out, insights)
 	}
 
 	p := f.UI(ctx)
 	p.Success("Account Insights for @%s", user.Username)
 	fmt.Fprintln(io.Out) //nolint:errcheck // Best-effort output
 
-	if len(insights.Data) == 0 {
+	typed := api.NewUserInsights(optsReq, responses...)
+	if len(typed.Metrics) == 0 && len(typed.Demographics) == 0 {
 		p.Info("No insights data available")
 		return nil
 	}
 
-	hasBreakdownData := false
-	for _, insight := range insights.Data {
-		if insight.Name == "follower_demographics" && len(insight.Values) > 0 {
-			hasBreakdownData = true
-			break
+	fmtr := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
+	if len(typed.Metrics) > 0 {
+		fmtr.Header("METRIC", "VALUE", "PERIOD")
+		for _, m := range typed.Metrics {
+			fmtr.Row(string(m.Name), m.Total, string(typed.Period))
 		}
+		fmtr.Flush()
 	}
 
-	if hasBreakdownData && opts.Breakdown != "" {
-		fmtr := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
-		fmtr.Header(strings.ToUpper(opts.Breakdown), "PERCENTAGE")
-
-		for _, insight := range insights.Data {
-			if insight.Name == "follower_demographics" {
-				for _, v := range insight.Values {
-					fmtr.Row(v.EndTime, fmt.Sprintf("%d%%", v.Value))
-				}
+	if typed.Period == api.InsightPeriodDay {
+		for _, m := range typed.Metrics {
+			if len(m.Daily) == 0 {
+				continue
 			}
+			fmt.Fprintf(io.Out, "\n%s by day:\n", m.Name) //nolint:errcheck // Best-effort output
+			fmtr.Header("DATE", "VALUE")
+			for _, v := range m.Daily {
+				fmtr.Row(insightDate(v.EndTime), v.Value)
+			}
+			fmtr.Flush()
 		}
-		fmtr.Flush()
-		return nil
 	}
 
-	fmtr := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
-	fmtr.Header("METRIC", "VALUE", "PERIOD")
-
-	for _, insight := range insights.Data {
-		value := 0
-		if len(insight.Values) > 0 {
-			value = insight.Values[0].Value
-		} else if insight.TotalValue != nil {
-			value = insight.TotalValue.Value
+	for i, demo := range typed.Demographics {
+		if i > 0 || len(typed.Metrics) > 0 {
+			fmt.Fprintln(io.Out) //nolint:errcheck // Best-effort output
+		}
+		total := 0
+		for _, v := range demo.Values {
+			total += v.Value
+		}
+		fmtr.Header(strings.ToUpper(string(demo.Breakdown)), "FOLLOWERS", "SHARE")
+		for _, v := range demo.Values {
+			share := "-"
+			if total > 0 {
+				share = fmt.Sprintf("%.1f%%", float64(v.Value)*100/float64(total))
+			}
+			fmtr.Row(v.Key, v.Value, share)
 		}
-		fmtr.Row(insight.Name, value, insight.Period)
+		fmtr.Flush()
 	}
-	fmtr.Flush()
 
 	return nil
 }
+
+// insightDate shortens an insight value's end_time to its date
+func insightDate(endTime string) string {
+	if t, err := time.Parse("2006-01-02T15:04:05-0700", endTime); err == nil {
+		return t.Format("2006-01-02")
+	}
+	return endTime
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// communityMaxPosts bounds the posts whose conversations are read, as each
+// costs at least one request
+const communityMaxPosts = 200
+
+// communityMaxScanned bounds the replies and the mentions read, each, so a
+// viral post does not page through its whole conversation
+const communityMaxScanned = 5000
+
+// communityMember is what one user did with the account's posts in a period
+type communityMember struct {
+	Username        string    `json:"username"`
+	Replies         int       `json:"replies"`
+	Quotes          int       `json:"quotes"`
+	LastInteraction time.Time `json:"last_interaction"`
+}
+
+func (m *communityMember) total() int {
+	return m.Replies + m.Quotes
+}
+
+// communityMemberView is the output model of a leaderboard row
+type communityMemberView struct {
+	rank int
+	m    *communityMember
+}
+
+func (v communityMemberView) viewFields() []viewField {
+	return []viewField{
+		{Key: "rank", Value: v.rank, Type: outfmt.ColumnAmount},
+		{Key: "username", Value: v.m.Username, Text: "@" + v.m.Username},
+		{Key: "replies", Value: v.m.Replies, Type: outfmt.ColumnAmount},
+		{Key: "quotes", Value: v.m.Quotes, Type: outfmt.ColumnAmount},
+		{Key: "total", Value: v.m.total(), Type: outfmt.ColumnAmount},
+		{Key: "last_interaction", Value: v.m.LastInteraction, Text: v.m.LastInteraction.Local().Format("2006-01-02"), Type: outfmt.ColumnDate},
+	}
+}
+
+type insightsCommunityOptions struct {
+	Range dateRangeOptions
+	Limit int
+}
+
+func newInsightsCommunityCmd(f *Factory) *cobra.Command {
+	opts := &insightsCommunityOptions{Range: dateRangeOptions{Since: "90d"}, Limit: 25}
+
+	cmd := &cobra.Command{
+		Use:   "community",
+		Short: "Rank the users who reply to and quote you most",
+		Long: `Rank the users who interact with your posts most, by their replies at any
+depth under your posts and their quotes of your posts, with the date of
+each user's last interaction.
+
+Replies are read from the conversations of your posts published in the
+period, and quotes from your mentions; only interactions dated in the period
+count. Each post costs a request or more, so long periods take a while: at
+most your ` + fmt.Sprint(communityMaxPosts) + ` most recent posts of the period are read.
+
+Examples:
+  threads insights community
+  threads insights community --since 30d --limit 10
+  threads insights community --since 2025-01-01 --until 2025-04-01 --output csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInsightsCommunity(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Range.Since, "since", opts.Range.Since, "Count interactions after a date or time (2025-06-01, 30d)")
+	cmd.Flags().StringVar(&opts.Range.Until, "until", "", "Count interactions before a date or time (2025-06-30, yesterday)")
+	addUTCFlag(cmd, &opts.Range.UTC)
+	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Users to list (0 for all)")
+	return cmd
+}
+
+func runInsightsCommunity(cmd *cobra.Command, f *Factory, opts *insightsCommunityOptions) error {
+	ctx := cmd.Context()
+
+	if opts.Limit < 0 {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --limit: %d", opts.Limit),
+			Suggestion: "Use 0 to list every user",
+		}
+	}
+	since, until, err := opts.Range.unixRange(f)
+	if err != nil {
+		return err
+	}
+
+	client, err := f.Client(ctx)
+	if err != nil {
+		return err
+	}
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return WrapError("failed to get user info", err)
+	}
+
+	posts := api.NewPager(func(ctx context.Context, cursor string) (*api.PostsResponse, error) {
+		return client.GetUserPostsWithOptions(ctx, api.UserID(me.ID), &api.PostsOptions{
+			Limit: 100,
+			After: cursor,
+			Since: since,
+			Until: until,
+		})
+	})
+	mine := map[string]bool{}
+	var replies []api.Post
+	for posts.Next(ctx) {
+		if len(mine) == communityMaxPosts {
+			f.UI(ctx).Warning("Only replies to your %d most recent posts of the period are counted", communityMaxPosts)
+			break
+		}
+		post := posts.Item()
+		mine[post.ID] = true
+		if len(replies) < communityMaxScanned {
+			conversation, err := scanConversation(ctx, client, post.ID, communityMaxScanned-len(replies))
+			if err != nil {
+				return err
+			}
+			replies = append(replies, conversation...)
+		}
+	}
+	if err := posts.Err(); err != nil {
+		return WrapError("failed to list posts", err)
+	}
+	if len(replies) == communityMaxScanned {
+		f.UI(ctx).Warning("Stopped after %d replies; narrow the period to count them all", communityMaxScanned)
+	}
+
+	// Mentions come newest first, so the period ends the scan
+	mentions := api.NewPager(func(ctx context.Context, cursor string) (*api.PostsResponse, error) {
+		return client.GetUserMentions(ctx, api.UserID(me.ID), &api.PaginationOptions{Limit: 100, After: cursor})
+	})
+	var quotes []api.Post
+	for scanned := 0; scanned < communityMaxScanned && mentions.Next(ctx); scanned++ {
+		mention := mentions.Item()
+		if since > 0 && mention.Timestamp.Unix() < since {
+			break
+		}
+		if q := mention.QuotedPost; q != nil && (mine[q.ID] || strings.EqualFold(q.Username, me.Username)) {
+			quotes = append(quotes, mention)
+		}
+	}
+	if err := mentions.Err(); err != nil {
+		return WrapError("failed to list mentions", err)
+	}
+
+	members := rankCommunity(me.Username, inPeriod(replies, since, until), inPeriod(quotes, since, until))
+	if opts.Limit > 0 && len(members) > opts.Limit {
+		members = members[:opts.Limit]
+	}
+	views := make([]communityMemberView, len(members))
+	for i, m := range members {
+		views[i] = communityMemberView{rank: i + 1, m: m}
+	}
+	return writeViewList(ctx, views, nil, "No replies or quotes in that period")
+}
+
+// scanConversation returns up to limit replies, at any depth, under a post
+func scanConversation(ctx context.Context, client api.API, postID string, limit int) ([]api.Post, error) {
+	pager := api.NewPager(func(ctx context.Context, cursor string) (*api.RepliesResponse, error) {
+		return client.GetConversation(ctx, api.PostID(postID), &api.RepliesOptions{Limit: 100, After: cursor})
+	})
+	var replies []api.Post
+	for len(replies) < limit && pager.Next(ctx) {
+		replies = append(replies, pager.Item())
+	}
+	if err := pager.Err(); err != nil {
+		return nil, WrapError(fmt.Sprintf("failed to get the conversation of post %s", postID), err)
+	}
+	return replies, nil
+}
+
+// inPeriod returns the posts dated in the unix range; 0 leaves a side open
+func inPeriod(posts []api.Post, since, until int64) []api.Post {
+	return slices.DeleteFunc(posts, func(p api.Post) bool {
+		t := p.Timestamp.Unix()
+		return (since > 0 && t < since) || (until > 0 && t >= until)
+	})
+}
+
+// rankCommunity counts the replies and quotes of each user other than me,
+// most interactions first, then most recent first
+func rankCommunity(me string, replies, quotes []api.Post) []*communityMember {
+	byUser := map[string]*communityMember{}
+	count := func(p *api.Post, reply bool) {
+		if p.Username == "" || strings.EqualFold(p.Username, me) {
+			return
+		}
+		m := byUser[p.Username]
+		if m == nil {
+			m = &communityMember{Username: p.Username}
+			byUser[p.Username] = m
+		}
+		if reply {
+			m.Replies++
+		} else {
+			m.Quotes++
+		}
+		if p.Timestamp.After(m.LastInteraction) {
+			m.LastInteraction = p.Timestamp.Time
+		}
+	}
+	for i := range replies {
+		count(&replies[i], true)
+	}
+	for i := range quotes {
+		count(&quotes[i], false)
+	}
+
+	members := make([]*communityMember, 0, len(byUser))
+	for _, m := range byUser {
+		members = append(members, m)
+	}
+	slices.SortFunc(members, func(a, b *communityMember) int {
+		return cmp.Or(
+			cmp.Compare(b.total(), a.total()),
+			b.LastInteraction.Compare(a.LastInteraction),
+			cmp.Compare(a.Username, b.Username),
+		)
+	})
+	return members
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+func TestRankCommunity(t *testing.T) {
+	at := func(day int) api.Time {
+		return api.Time{Time: time.Date(2025, 7, day, 12, 0, 0, 0, time.UTC)}
+	}
+	replies := []api.Post{
+		{Username: "bob", Timestamp: at(1)},
+		{Username: "bob", Timestamp: at(3)},
+		{Username: "carol", Timestamp: at(2)},
+		{Username: "Me", Timestamp: at(4)},
+	}
+	quotes := []api.Post{
+		{Username: "carol", Timestamp: at(5)},
+		{Username: "dave", Timestamp: at(6)},
+	}
+
+	members := rankCommunity("me", replies, quotes)
+	var got []string
+	for _, m := range members {
+		got = append(got, m.Username)
+	}
+	// bob and carol tie on two; carol interacted last
+	if strings.Join(got, ",") != "carol,bob,dave" {
+		t.Fatalf("unexpected ranking: %v", got)
+	}
+	if c := members[0]; c.Replies != 1 || c.Quotes != 1 || !c.LastInteraction.Equal(at(5).Time) {
+		t.Errorf("unexpected counts for carol: %+v", c)
+	}
+}
+
+func TestInsightsCommunity_CSV(t *testing.T) {
+	now := time.Now()
+	recent := api.Time{Time: now.Add(-24 * time.Hour)}
+	old := api.Time{Time: now.AddDate(0, 0, -200)}
+	mock := &mockAPI{
+		getMe: func(context.Context) (*api.User, error) {
+			return &api.User{ID: "1", Username: "me"}, nil
+		},
+		getUserPosts: func(_ context.Context, _ api.UserID, opts *api.PostsOptions) (*api.PostsResponse, error) {
+			if opts.Since == 0 {
+				t.Error("expected the default period sent")
+			}
+			return &api.PostsResponse{Data: []api.Post{{ID: "10", Username: "me"}}}, nil
+		},
+		getConversation: func(context.Context, api.PostID, *api.RepliesOptions) (*api.RepliesResponse, error) {
+			return &api.RepliesResponse{Data: []api.Post{
+				{ID: "11", Username: "bob", Timestamp: recent},
+				{ID: "12", Username: "me", Timestamp: recent},
+				{ID: "13", Username: "bob", Timestamp: recent},
+			}}, nil
+		},
+		getMentions: func(context.Context, api.UserID, *api.PaginationOptions) (*api.PostsResponse, error) {
+			return &api.PostsResponse{Data: []api.Post{
+				{ID: "20", Username: "carol", Timestamp: recent, IsQuotePost: true, QuotedPost: &api.Post{ID: "10"}},
+				{ID: "21", Username: "dave", Timestamp: recent},
+				{ID: "22", Username: "erin", Timestamp: old, QuotedPost: &api.Post{ID: "10"}},
+			}}, nil
+		},
+	}
+	f, io := newMockAPITestFactory(t, mock)
+
+	cmd := newInsightsCommunityCmd(f)
+	cmd.SetArgs([]string{})
+	cmd.SetContext(outfmt.WithFormat(iocontext.WithIO(context.Background(), io), "csv"))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(io.Out.(*bytes.Buffer).String()), "\n")
+	day := recent.Local().Format("2006-01-02")
+	want := []string{
+		"RANK,USERNAME,REPLIES,QUOTES,TOTAL,LAST INTERACTION",
+		"1,@bob,2,0,2," + day,
+		"2,@carol,0,1,1," + day,
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+}
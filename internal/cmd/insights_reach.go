@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// reachMetrics are the post metrics compared against the baseline
+var reachMetrics = []string{"views", "likes", "replies", "reposts", "quotes"}
+
+// reachOutlierZ is how many standard deviations from the baseline average
+// make a value an outlier
+const reachOutlierZ = 2
+
+// reachMinBaseline is the fewest baseline posts outliers are flagged with
+const reachMinBaseline = 3
+
+// reachReport compares a post's metrics with the account's recent posts
+type reachReport struct {
+	PostID        string        `json:"post_id"`
+	Followers     int           `json:"followers"`
+	BaselinePosts int           `json:"baseline_posts"`
+	Metrics       []reachMetric `json:"metrics"`
+}
+
+// reachMetric is one metric of a post against its baseline
+type reachMetric struct {
+	Metric string `json:"metric"`
+	Value  int    `json:"value"`
+	// PerFollower is Value divided by the follower count
+	PerFollower float64 `json:"per_follower"`
+	// Average and StdDev are over the baseline posts
+	Average float64 `json:"average"`
+	StdDev  float64 `json:"std_dev"`
+	// Ratio is Value divided by Average, 0 without an average
+	Ratio float64 `json:"ratio"`
+	// Outlier is "high" or "low" when Value is reachOutlierZ standard
+	// deviations or more from Average
+	Outlier string `json:"outlier,omitempty"`
+}
+
+type insightsReachOptions struct {
+	Baseline int
+}
+
+func newInsightsReachCmd(f *Factory) *cobra.Command {
+	opts := &insightsReachOptions{Baseline: 20}
+
+	cmd := &cobra.Command{
+		Use:   "reach [post-id]",
+		Short: "Compare a post's reach with your followers and recent posts",
+		Long: `Put a post's views, likes, replies, reposts and quotes in context: each is
+shown per follower and against its average over your most recent posts, and
+flagged as an outlier when it is two standard deviations or more away from
+that average.
+
+The baseline is fetched on every run: one request for the recent posts and
+one for the insights of each, so a larger --baseline costs more requests.
+Reposts have no insights of their own and are left out.
+
+Examples:
+  threads insights reach 12345678901234567
+  threads insights reach 12345678901234567 --baseline 50
+  threads insights reach 12345678901234567 --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInsightsReach(cmd, f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Baseline, "baseline", opts.Baseline, fmt.Sprintf("Recent posts to compare with (max %d)", archiveMaxPageSize))
+	return cmd
+}
+
+func runInsightsReach(cmd *cobra.Command, f *Factory, opts *insightsReachOptions, postID string) error {
+	ctx := cmd.Context()
+
+	if opts.Baseline < 1 || opts.Baseline > archiveMaxPageSize {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --baseline: %d", opts.Baseline),
+			Suggestion: fmt.Sprintf("Use 1 to %d", archiveMaxPageSize),
+		}
+	}
+
+	client, err := f.Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	target, err := postMetrics(ctx, client, postID)
+	if err != nil {
+		return WrapError("failed to get post insights", err)
+	}
+
+	user, err := client.GetMe(ctx)
+	if err != nil {
+		return WrapError("failed to get user info", err)
+	}
+	followers, err := client.GetAccountInsightsWithOptions(ctx, api.UserID(user.ID), &api.AccountInsightsOptions{
+		Metrics: []api.AccountInsightMetric{api.AccountInsightFollowersCount},
+		Period:  api.InsightPeriodLifetime,
+	})
+	if err != nil {
+		return WrapError("failed to get follower count", err)
+	}
+	followerCount := 0
+	for _, insight := range followers.Data {
+		if insight.Name == string(api.AccountInsightFollowersCount) {
+			followerCount = insightValue(insight)
+		}
+	}
+
+	// One extra in case the post itself is among the recent ones
+	recent, err := client.GetUserPostsWithOptions(ctx, api.UserID(user.ID), &api.PostsOptions{Limit: opts.Baseline + 1})
+	if err != nil {
+		return WrapError("failed to list recent posts", err)
+	}
+	var ids []api.PostID
+	for _, post := range recent.Data {
+		if len(ids) == opts.Baseline {
+			break
+		}
+		if post.ID == postID || post.RepostedPost != nil {
+			continue
+		}
+		ids = append(ids, api.PostID(post.ID))
+	}
+	var baseline []map[string]int
+	if len(ids) > 0 {
+		responses, err := client.GetPostsInsights(ctx, ids, reachMetrics)
+		if err != nil {
+			return WrapError("failed to get insights of recent posts", err)
+		}
+		for _, id := range ids {
+			baseline = append(baseline, metricsByName(responses[id]))
+		}
+	}
+
+	report := compareReach(postID, target, baseline, followerCount)
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, report)
+	}
+
+	p := f.UI(ctx)
+	p.Success("Reach of %s against %d followers and %d recent posts", postID, report.Followers, report.BaselinePosts)
+	fmt.Fprintln(io.Out) //nolint:errcheck // Best-effort output
+
+	fmtr := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
+	fmtr.Header("METRIC", "VALUE", "PER FOLLOWER", "AVERAGE", "VS AVERAGE", "OUTLIER")
+	for _, m := range report.Metrics {
+		perFollower, ratio := "-", "-"
+		if report.Followers > 0 {
+			perFollower = fmt.Sprintf("%.1f%%", m.PerFollower*100)
+		}
+		if m.Average > 0 {
+			ratio = fmt.Sprintf("%.1fx", m.Ratio)
+		}
+		outlier := "-"
+		switch m.Outlier {
+		case "high":
+			outlier = "above usual"
+		case "low":
+			outlier = "below usual"
+		}
+		fmtr.Row(m.Metric, m.Value, perFollower, fmt.Sprintf("%.1f", m.Average), ratio, outlier)
+	}
+	fmtr.Flush()
+
+	if report.BaselinePosts < reachMinBaseline {
+		p.Warning("Outliers need at least %d recent posts to compare with", reachMinBaseline)
+	}
+	return nil
+}
+
+// postMetrics returns the reach metrics of a post by name
+func postMetrics(ctx context.Context, client api.API, postID string) (map[string]int, error) {
+	insights, err := client.GetPostInsights(ctx, api.PostID(postID), reachMetrics)
+	if err != nil {
+		return nil, err
+	}
+	return metricsByName(insights), nil
+}
+
+// metricsByName returns the latest value of each metric of insights by name
+func metricsByName(insights *api.InsightsResponse) map[string]int {
+	metrics := map[string]int{}
+	if insights == nil {
+		return metrics
+	}
+	for _, insight := range insights.Data {
+		metrics[insight.Name] = insightValue(insight)
+	}
+	return metrics
+}
+
+// compareReach compares target with the mean and population standard
+// deviation of each metric over baseline
+func compareReach(postID string, target map[string]int, baseline []map[string]int, followers int) *reachReport {
+	report := &reachReport{PostID: postID, Followers: followers, BaselinePosts: len(baseline), Metrics: []reachMetric{}}
+	for _, name := range reachMetrics {
+		m := reachMetric{Metric: name, Value: target[name]}
+		if followers > 0 {
+			m.PerFollower = float64(m.Value) / float64(followers)
+		}
+		if n := float64(len(baseline)); n > 0 {
+			var sum, squares float64
+			for _, post := range baseline {
+				sum += float64(post[name])
+			}
+			m.Average = sum / n
+			for _, post := range baseline {
+				d := float64(post[name]) - m.Average
+				squares += d * d
+			}
+			m.StdDev = math.Sqrt(squares / n)
+		}
+		if m.Average > 0 {
+			m.Ratio = float64(m.Value) / m.Average
+		}
+		if len(baseline) >= reachMinBaseline && m.StdDev > 0 {
+			switch z := (float64(m.Value) - m.Average) / m.StdDev; {
+			case z >= reachOutlierZ:
+				m.Outlier = "high"
+			case z <= -reachOutlierZ:
+				m.Outlier = "low"
+			}
+		}
+		report.Metrics = append(report.Metrics, m)
+	}
+	return report
+}
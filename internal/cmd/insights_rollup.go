@@ -0,0 +1,409 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/notify"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// rollupDefaultRange is the range of a rollup without date flags
+const rollupDefaultRange = 30 * 24 * time.Hour
+
+// rollupPageSize is how many posts each request of a rollup counts
+const rollupPageSize = 100
+
+// rollupMetrics are the account metrics a rollup adds up over its range
+var rollupMetrics = []api.AccountInsightMetric{
+	api.AccountInsightViews, api.AccountInsightLikes, api.AccountInsightReplies,
+	api.AccountInsightReposts, api.AccountInsightQuotes,
+}
+
+// rollupReport is the combined report of 'insights rollup'
+type rollupReport struct {
+	Since    time.Time       `json:"since"`
+	Until    time.Time       `json:"until"`
+	Accounts []rollupAccount `json:"accounts"`
+	// Totals add up the accounts that succeeded
+	Totals rollupCounts `json:"totals"`
+}
+
+// rollupCounts are the figures of one account, or their totals
+type rollupCounts struct {
+	Posts     int `json:"posts"`
+	Views     int `json:"views"`
+	Likes     int `json:"likes"`
+	Replies   int `json:"replies"`
+	Reposts   int `json:"reposts"`
+	Quotes    int `json:"quotes"`
+	Followers int `json:"followers"`
+	// FollowerGrowth is the change in followers since the previous rollup;
+	// the API only reports the current count
+	FollowerGrowth *int `json:"follower_growth,omitempty"`
+}
+
+func (c *rollupCounts) add(other rollupCounts) {
+	c.Posts += other.Posts
+	c.Views += other.Views
+	c.Likes += other.Likes
+	c.Replies += other.Replies
+	c.Reposts += other.Reposts
+	c.Quotes += other.Quotes
+	c.Followers += other.Followers
+	if other.FollowerGrowth != nil {
+		growth := *other.FollowerGrowth
+		if c.FollowerGrowth != nil {
+			growth += *c.FollowerGrowth
+		}
+		c.FollowerGrowth = &growth
+	}
+}
+
+// rollupAccount is one account of a rollup
+type rollupAccount struct {
+	Account  string `json:"account"`
+	Username string `json:"username,omitempty"`
+	rollupCounts
+	// GrowthSince is when the follower count FollowerGrowth compares with
+	// was taken
+	GrowthSince *time.Time `json:"growth_since,omitempty"`
+	Error       string     `json:"error,omitempty"`
+
+	userID string
+}
+
+// followerSnapshot is a follower count kept for the next rollup
+type followerSnapshot struct {
+	Followers int       `json:"followers"`
+	At        time.Time `json:"at"`
+}
+
+type insightsRollupOptions struct {
+	Accounts []string
+	Range    dateRangeOptions
+	// Email lists the addresses the report is emailed to
+	Email []string
+}
+
+func newInsightsRollupCmd(f *Factory) *cobra.Command {
+	opts := &insightsRollupOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rollup",
+		Short: "Combine the insights of several accounts",
+		Long: `Report posts, reach and growth for several stored accounts side by side,
+with totals. Without --accounts every stored account is included, and
+without a date range the last 30 days are.
+
+Each account is fetched concurrently with its own client and rate limiter:
+one request for the profile, one per 100 posts in the range and two for
+insights. An account that fails is reported with its error and left out
+of the totals.
+
+GROWTH is the change in followers since the previous rollup of the account,
+as the API only reports the current follower count; the counts are kept in
+the "insights" cache.
+
+--email also sends the report as plain text through the SMTP server set
+under notify.email, so a weekly cron job can mail it out.
+
+Examples:
+  threads insights rollup
+  threads insights rollup --accounts brand-a,brand-b,brand-c
+  threads insights rollup --month 2025-06 --output csv
+  threads insights rollup --since 7d --email team@example.com`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInsightsRollup(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Accounts, "accounts", nil, "Accounts to include (default: all stored accounts)")
+	cmd.Flags().StringVar(&opts.Range.Since, "since", "", "Only count activity after a date or time (default: 30d)")
+	cmd.Flags().StringVar(&opts.Range.Until, "until", "", "Only count activity before a date or time (2025-06-30, yesterday)")
+	cmd.Flags().StringVar(&opts.Range.Month, "month", "", "Only count activity in a calendar month (2025-06)")
+	cmd.Flags().StringSliceVar(&opts.Email, "email", nil, "Also email the report to these addresses (see notify.email in 'threads config')")
+	addUTCFlag(cmd, &opts.Range.UTC)
+	cmd.MarkFlagsMutuallyExclusive("month", "since")
+	cmd.MarkFlagsMutuallyExclusive("month", "until")
+
+	return cmd
+}
+
+func runInsightsRollup(cmd *cobra.Command, f *Factory, opts *insightsRollupOptions) error {
+	ctx := cmd.Context()
+
+	since, until, err := opts.Range.unixRange(f)
+	if err != nil {
+		return err
+	}
+	// Email settings are checked before the accounts are fetched
+	if len(opts.Email) > 0 {
+		for _, address := range opts.Email {
+			if _, err := mail.ParseAddress(address); err != nil {
+				return &UserFriendlyError{Message: fmt.Sprintf("Invalid --email address: %s", address), Cause: err}
+			}
+		}
+		if _, err := f.smtpServer(); err != nil {
+			return err
+		}
+	}
+	now := time.Now()
+	report := &rollupReport{Since: now.Add(-rollupDefaultRange), Until: now, Accounts: []rollupAccount{}}
+	if since > 0 {
+		report.Since = time.Unix(since, 0)
+	}
+	if until > 0 {
+		report.Until = time.Unix(until, 0)
+	}
+	if report.Since.Unix() < api.MinInsightTimestamp {
+		report.Since = time.Unix(api.MinInsightTimestamp, 0)
+	}
+
+	accounts := opts.Accounts
+	if len(accounts) == 0 {
+		store, err := f.Store()
+		if err != nil {
+			return FormatError(err)
+		}
+		if accounts, err = store.List(); err != nil {
+			return WrapError("failed to list accounts", err)
+		}
+		if len(accounts) == 0 {
+			return &UserFriendlyError{
+				Message:    "No Threads account configured",
+				Suggestion: "Run 'threads auth login' to authenticate with your Threads account",
+			}
+		}
+	}
+
+	// Clients are made one at a time, as the credential store may prompt
+	clients := make([]api.API, len(accounts))
+	for i, account := range accounts {
+		if clients[i], err = f.clientFor(ctx, account); err != nil {
+			return WrapError(fmt.Sprintf("failed to create a client for account %s", account), err)
+		}
+	}
+
+	report.Accounts = make([]rollupAccount, len(accounts))
+	var wg sync.WaitGroup
+	for i, account := range accounts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Accounts[i] = rollupFetch(ctx, clients[i], account, report.Since, report.Until)
+		}()
+	}
+	wg.Wait()
+
+	var failed []error
+	for i := range report.Accounts {
+		a := &report.Accounts[i]
+		if a.Error != "" {
+			failed = append(failed, fmt.Errorf("account %s: %s", a.Account, a.Error))
+			continue
+		}
+		if err := rollupGrowth(a, now); err != nil {
+			return err
+		}
+		report.Totals.add(a.rollupCounts)
+	}
+	if len(failed) == len(accounts) {
+		return WrapError("failed to get insights of every account", errors.Join(failed...))
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSONContext(ctx, io.Out, report); err != nil {
+			return err
+		}
+		return emailRollup(ctx, f, opts.Email, report, failed)
+	}
+
+	p := f.UI(ctx)
+	if !outfmt.IsDelimited(ctx) {
+		p.Success("Insights of %s from %s to %s", pluralize(len(accounts), "account", "accounts"),
+			report.Since.Local().Format("2006-01-02"), report.Until.Local().Format("2006-01-02"))
+		fmt.Fprintln(io.Out) //nolint:errcheck // Best-effort output
+	}
+
+	fmtr := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
+	fmtr.Header("ACCOUNT", "USERNAME", "POSTS", "VIEWS", "LIKES", "REPLIES", "REPOSTS", "QUOTES", "FOLLOWERS", "GROWTH")
+	for _, a := range report.Accounts {
+		if a.Error != "" {
+			fmtr.Row(a.Account, "-", "-", "-", "-", "-", "-", "-", "-", "-")
+			continue
+		}
+		fmtr.Row(a.Account, "@"+a.Username, a.Posts, a.Views, a.Likes, a.Replies, a.Reposts, a.Quotes, a.Followers, rollupGrowthText(a.FollowerGrowth))
+	}
+	t := report.Totals
+	fmtr.Row("TOTAL", "", t.Posts, t.Views, t.Likes, t.Replies, t.Reposts, t.Quotes, t.Followers, rollupGrowthText(t.FollowerGrowth))
+	fmtr.Flush()
+
+	for _, err := range failed {
+		p.Warning("%v", err)
+	}
+	if len(opts.Email) > 0 {
+		if err := emailRollup(ctx, f, opts.Email, report, failed); err != nil {
+			return err
+		}
+		p.Success("Emailed the report to %s", strings.Join(opts.Email, ", "))
+	}
+	return nil
+}
+
+// emailRollup sends report as a plain-text table to addresses, if any
+func emailRollup(ctx context.Context, f *Factory, addresses []string, report *rollupReport, failed []error) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+	m := notify.Message{
+		Title: fmt.Sprintf("Threads insights from %s to %s", report.Since.Local().Format("2006-01-02"), report.Until.Local().Format("2006-01-02")),
+		Text:  rollupText(report, failed),
+		Data:  report,
+	}
+	if err := f.email(ctx, addresses, m); err != nil {
+		return WrapError("failed to email the report", err)
+	}
+	return nil
+}
+
+// rollupText lays report out as the table 'insights rollup' prints, for
+// an email
+func rollupText(report *rollupReport, failed []error) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ACCOUNT\tUSERNAME\tPOSTS\tVIEWS\tLIKES\tREPLIES\tREPOSTS\tQUOTES\tFOLLOWERS\tGROWTH") //nolint:errcheck // Writes to a strings.Builder
+	for _, a := range report.Accounts {
+		if a.Error != "" {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\t-\t-\t-\t-\t-\n", a.Account) //nolint:errcheck // Writes to a strings.Builder
+			continue
+		}
+		fmt.Fprintf(w, "%s\t@%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%s\n", a.Account, a.Username, //nolint:errcheck // Writes to a strings.Builder
+			a.Posts, a.Views, a.Likes, a.Replies, a.Reposts, a.Quotes, a.Followers, rollupGrowthText(a.FollowerGrowth))
+	}
+	t := report.Totals
+	fmt.Fprintf(w, "TOTAL\t\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%s\n", //nolint:errcheck // Writes to a strings.Builder
+		t.Posts, t.Views, t.Likes, t.Replies, t.Reposts, t.Quotes, t.Followers, rollupGrowthText(t.FollowerGrowth))
+	w.Flush() //nolint:errcheck,gosec // Writes to a strings.Builder
+
+	for _, err := range failed {
+		fmt.Fprintf(&b, "\nWarning: %v", err)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// rollupFetch gets the figures of one account between since and until.
+// Failures are reported in the result so the other accounts still count.
+func rollupFetch(ctx context.Context, client api.API, account string, since, until time.Time) rollupAccount {
+	result := rollupAccount{Account: account}
+	fail := func(what string, err error) rollupAccount {
+		result.Error = fmt.Sprintf("%s: %s", what, FormatError(err).Error())
+		return result
+	}
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return fail("failed to get user info", err)
+	}
+	result.Username, result.userID = me.Username, me.ID
+
+	posts := api.NewIterator(func(ctx context.Context, cursor string) (*api.PostsResponse, error) {
+		return client.GetUserPostsWithOptions(ctx, api.UserID(me.ID), &api.PostsOptions{
+			Limit: rollupPageSize, After: cursor, Since: since.Unix(), Until: until.Unix(),
+		})
+	})
+	for posts.HasNext() {
+		page, err := posts.Next(ctx)
+		if err != nil {
+			return fail("failed to count posts", err)
+		}
+		if page != nil {
+			result.Posts += len(page.Data)
+		}
+	}
+
+	activity, err := client.GetUserInsights(ctx, api.UserID(me.ID), &api.InsightsOptions{
+		Metrics: rollupMetrics, Since: &since, Until: &until,
+	})
+	if err != nil {
+		return fail("failed to get insights", err)
+	}
+	values := map[api.AccountInsightMetric]*int{
+		api.AccountInsightViews:   &result.Views,
+		api.AccountInsightLikes:   &result.Likes,
+		api.AccountInsightReplies: &result.Replies,
+		api.AccountInsightReposts: &result.Reposts,
+		api.AccountInsightQuotes:  &result.Quotes,
+	}
+	for name, value := range values {
+		if metric := activity.Metric(name); metric != nil {
+			*value = metric.Total
+		}
+	}
+
+	// The follower count takes no date range, so it is its own request
+	followers, err := client.GetUserInsights(ctx, api.UserID(me.ID), &api.InsightsOptions{
+		Metrics: []api.AccountInsightMetric{api.AccountInsightFollowersCount},
+	})
+	if err != nil {
+		return fail("failed to get follower count", err)
+	}
+	if metric := followers.Metric(api.AccountInsightFollowersCount); metric != nil {
+		result.Followers = metric.Total
+	}
+	return result
+}
+
+// rollupSnapshotPath is where the follower count of a user is kept between
+// rollups
+func rollupSnapshotPath(userID string) string {
+	return filepath.Join(cacheDir(), "insights", "followers-"+userID+".json")
+}
+
+// rollupGrowth compares the follower count of a with the one kept by the
+// previous rollup, then keeps the new one
+func rollupGrowth(a *rollupAccount, now time.Time) error {
+	path := rollupSnapshotPath(a.userID)
+	var previous followerSnapshot
+	err := statefile.ReadJSON(path, "followers", 1, &previous)
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	// A damaged snapshot only means no growth this time
+	case errors.As(err, &syntaxErr):
+	case err != nil:
+		return WrapError("failed to read the previous follower count", err)
+	case !previous.At.IsZero():
+		growth := a.Followers - previous.Followers
+		a.FollowerGrowth, a.GrowthSince = &growth, &previous.At
+	}
+
+	if err := statefile.WriteJSON(path, "followers", 1, followerSnapshot{Followers: a.Followers, At: now.UTC()}); err != nil {
+		return WrapError("failed to save the follower count", err)
+	}
+	return nil
+}
+
+// rollupGrowthText formats a follower change with its sign
+func rollupGrowthText(growth *int) string {
+	if growth == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%+d", *growth)
+}
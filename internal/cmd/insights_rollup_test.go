@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
+)
+
+// accountsStore holds credentials for several accounts, each with the
+// account name as its token
+type accountsStore struct {
+	mockCredentialsStore
+	names []string
+}
+
+func (s *accountsStore) Get(name string) (*secrets.Credentials, error) {
+	creds := testCredentials()
+	creds.Name, creds.AccessToken = name, name
+	return creds, nil
+}
+
+func (s *accountsStore) List() ([]string, error) { return s.names, nil }
+
+// rollupMock is an account with followers followers and views views in
+// every range, and posts posts on one page
+func rollupMock(id string, posts, views, followers int) *mockAPI {
+	return &mockAPI{
+		getMe: func(context.Context) (*api.User, error) {
+			return &api.User{ID: id, Username: "user" + id}, nil
+		},
+		getUserPosts: func(_ context.Context, _ api.UserID, opts *api.PostsOptions) (*api.PostsResponse, error) {
+			if opts.Since == 0 || opts.Until == 0 {
+				return nil, errors.New("expected a date range")
+			}
+			return &api.PostsResponse{Data: make([]api.Post, posts)}, nil
+		},
+		getAccountInsights: func(_ context.Context, _ api.UserID, opts *api.AccountInsightsOptions) (*api.InsightsResponse, error) {
+			var data []api.Insight
+			for _, metric := range opts.Metrics {
+				value := views
+				if metric == api.AccountInsightFollowersCount {
+					value = followers
+				} else if metric != api.AccountInsightViews {
+					value = 1
+				}
+				data = append(data, api.Insight{Name: string(metric), TotalValue: &api.TotalValue{Value: value}})
+			}
+			return &api.InsightsResponse{Data: data}, nil
+		},
+	}
+}
+
+func TestInsightsRollup_CombinesAccounts(t *testing.T) {
+	useTempCacheDir(t)
+	mocks := map[string]*mockAPI{
+		"brand-a": rollupMock("1", 3, 100, 50),
+		"brand-b": rollupMock("2", 2, 40, 10),
+		"broken": {getMe: func(context.Context) (*api.User, error) {
+			return nil, errors.New("token revoked")
+		}},
+	}
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	f.Store = func() (secrets.Store, error) {
+		return &accountsStore{names: []string{"brand-a", "brand-b", "broken"}}, nil
+	}
+	f.NewClient = func(token string, _ *api.Config) (api.API, error) { return mocks[token], nil }
+
+	run := func() *rollupReport {
+		t.Helper()
+		io.Out.(*bytes.Buffer).Reset()
+		cmd := newInsightsRollupCmd(f)
+		cmd.SetArgs([]string{"--since", "7d"})
+		cmd.SetContext(outfmt.NewContext(iocontext.WithIO(context.Background(), io), outfmt.JSON))
+		if err := cmd.Execute(); err != nil {
+			t.Fatal(err)
+		}
+		var report rollupReport
+		if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &report); err != nil {
+			t.Fatal(err)
+		}
+		return &report
+	}
+
+	report := run()
+	if len(report.Accounts) != 3 || report.Accounts[0].Username != "user1" || report.Accounts[1].Posts != 2 {
+		t.Fatalf("unexpected accounts: %+v", report.Accounts)
+	}
+	if !strings.Contains(report.Accounts[2].Error, "token revoked") {
+		t.Errorf("expected the broken account's error, got %+v", report.Accounts[2])
+	}
+	totals := report.Totals
+	if totals.Posts != 5 || totals.Views != 140 || totals.Likes != 2 || totals.Followers != 60 || totals.FollowerGrowth != nil {
+		t.Errorf("unexpected totals: %+v", totals)
+	}
+
+	// The second rollup compares with the follower counts of the first
+	mocks["brand-a"] = rollupMock("1", 3, 100, 55)
+	report = run()
+	if g := report.Accounts[0].FollowerGrowth; g == nil || *g != 5 || report.Accounts[0].GrowthSince == nil {
+		t.Errorf("expected growth of 5, got %+v", report.Accounts[0])
+	}
+	if g := report.Totals.FollowerGrowth; g == nil || *g != 5 {
+		t.Errorf("expected total growth of 5, got %v", g)
+	}
+}
+
+func TestInsightsRollup_AllAccountsFail(t *testing.T) {
+	useTempCacheDir(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{getMe: func(context.Context) (*api.User, error) {
+		return nil, errors.New("down")
+	}})
+
+	cmd := newInsightsRollupCmd(f)
+	cmd.SetArgs([]string{"--accounts", "test-user"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "every account") {
+		t.Errorf("expected an error when no account succeeds, got %v", err)
+	}
+}
+
+func TestInsightsRollup_Email(t *testing.T) {
+	useTempCacheDir(t)
+	sent := captureEmail(t)
+	f, io := newMockAPITestFactory(t, rollupMock("1", 3, 100, 50))
+	store := &mockCredentialsStore{creds: testCredentials(), secrets: map[string]string{smtpPasswordSecret: "hunter2"}}
+	f.Store = func() (secrets.Store, error) { return store, nil }
+
+	run := func() error {
+		cmd := newInsightsRollupCmd(f)
+		cmd.SetArgs([]string{"--accounts", "test-user", "--since", "7d", "--email", "team@example.com"})
+		cmd.SetContext(iocontext.WithIO(context.Background(), io))
+		return cmd.Execute()
+	}
+
+	if err := run(); err == nil || !strings.Contains(err.Error(), "No SMTP server") {
+		t.Fatalf("expected the missing server reported before fetching, got %v", err)
+	}
+	f.Config.Notify = smtpConfig(nil)
+	if err := run(); err != nil {
+		t.Fatal(err)
+	}
+	if len(*sent) != 1 || (*sent)[0].To[0] != "team@example.com" || !strings.HasPrefix((*sent)[0].Title, "Threads insights from ") {
+		t.Fatalf("expected the report emailed, got %+v", *sent)
+	}
+	lines := strings.Split((*sent)[0].Text, "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "test-user") || !strings.Contains(lines[2], "TOTAL") || !strings.Contains(lines[2], "100") {
+		t.Errorf("expected the table as text, got:\n%s", (*sent)[0].Text)
+	}
+	if !strings.Contains(io.Out.(*bytes.Buffer).String(), "Emailed the report to team@example.com") {
+		t.Errorf("expected the email reported, got %q", io.Out.(*bytes.Buffer).String())
+	}
+}
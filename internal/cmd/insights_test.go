@@ -1,6 +1,18 @@
 package cmd
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
 
 func TestInsightsCmd_Structure(t *testing.T) {
 	f := newTestFactory(t)
@@ -20,8 +32,11 @@ func TestInsightsCmd_Subcommands(t *testing.T) {
 	cmd := NewInsightsCmd(f)
 
 	expectedSubs := map[string]bool{
-		"post":    true,
-		"account": true,
+		"post":      true,
+		"account":   true,
+		"reach":     true,
+		"community": true,
+		"rollup":    true,
 	}
 
 	for _, sub := range cmd.Commands() {
@@ -36,3 +51,239 @@ func TestInsightsCmd_Subcommands(t *testing.T) {
 		t.Errorf("missing subcommand: %s", name)
 	}
 }
+
+func TestInsightsAccount_RejectsUnsupportedPeriodBeforeAPICall(t *testing.T) {
+	mock := &mockAPI{
+		getMe: func(context.Context) (*api.User, error) {
+			t.Fatal("GetMe should not be called for an invalid request")
+			return nil, nil
+		},
+	}
+
+	f, io := newMockAPITestFactory(t, mock)
+
+	cmd := newInsightsAccountCmd(f)
+	cmd.SetArgs([]string{"--metrics", "followers_count", "--period", "day"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	err := cmd.Execute()
+	var ufErr *UserFriendlyError
+	if !errors.As(err, &ufErr) {
+		t.Fatalf("expected UserFriendlyError, got %T: %v", err, err)
+	}
+	if !strings.Contains(ufErr.Message, "followers_count") {
+		t.Errorf("expected message to name the metric, got %q", ufErr.Message)
+	}
+}
+
+func TestInsightsAccount_Breakdowns(t *testing.T) {
+	var breakdowns []string
+	mock := &mockAPI{
+		getMe: func(context.Context) (*api.User, error) {
+			return &api.User{ID: "1", Username: "me"}, nil
+		},
+		getAccountInsights: func(_ context.Context, _ api.UserID, opts *api.AccountInsightsOptions) (*api.InsightsResponse, error) {
+			breakdowns = append(breakdowns, opts.Breakdown)
+			return &api.InsightsResponse{Data: []api.Insight{{
+				Name: "follower_demographics",
+				TotalValue: &api.TotalValue{Breakdowns: []api.InsightBreakdown{{
+					DimensionKeys: []string{opts.Breakdown},
+					Results: []api.InsightBreakdownResult{
+						{DimensionValues: []string{opts.Breakdown + "-a"}, Value: 1},
+						{DimensionValues: []string{opts.Breakdown + "-b"}, Value: 3},
+					},
+				}}},
+			}}}, nil
+		},
+	}
+	f, io := newMockAPITestFactory(t, mock)
+
+	cmd := newInsightsAccountCmd(f)
+	cmd.SetArgs([]string{"--breakdown", "age,gender"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	// --breakdown alone leaves out the default metrics
+	if strings.Join(breakdowns, ",") != "age,gender" {
+		t.Errorf("expected one request per breakdown, got %q", breakdowns)
+	}
+	out := io.Out.(*bytes.Buffer).String()
+	for _, want := range []string{"AGE", "GENDER", "age-b", "75.0%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "age-b") > strings.Index(out, "age-a") {
+		t.Errorf("expected the largest value first, got:\n%s", out)
+	}
+}
+
+func TestInsightsPost_Range(t *testing.T) {
+	var since int64
+	batches := 0
+	mock := &mockAPI{
+		getMe: func(context.Context) (*api.User, error) {
+			return &api.User{ID: "1", Username: "me"}, nil
+		},
+		getUserPosts: func(_ context.Context, _ api.UserID, opts *api.PostsOptions) (*api.PostsResponse, error) {
+			since = opts.Since
+			return &api.PostsResponse{Data: []api.Post{{ID: "11", Text: "first"}, {ID: "12", Text: "second"}}}, nil
+		},
+		getPostsInsights: func(_ context.Context, postIDs []api.PostID, metrics []string) (map[api.PostID]*api.InsightsResponse, error) {
+			batches++
+			if strings.Join(metrics, ",") != "views,shares" {
+				t.Errorf("unexpected metrics %v", metrics)
+			}
+			responses := map[api.PostID]*api.InsightsResponse{}
+			for _, postID := range postIDs {
+				views := 100
+				if postID == "12" {
+					views = 250
+				}
+				responses[postID] = &api.InsightsResponse{Data: []api.Insight{
+					{Name: "views", Values: []api.Value{{Value: views}}},
+					{Name: "shares", Values: []api.Value{{Value: 3}}},
+				}}
+			}
+			return responses, nil
+		},
+	}
+	f, io := newMockAPITestFactory(t, mock)
+
+	cmd := newInsightsPostCmd(f)
+	cmd.SetArgs([]string{"--since", "2025-06-01", "--metrics", "views,shares", "--utc"})
+	cmd.SetContext(outfmt.WithFormat(iocontext.WithIO(context.Background(), io), "json"))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	if since != 1748736000 {
+		t.Errorf("expected posts since 2025-06-01 UTC, got %d", since)
+	}
+	if batches != 1 {
+		t.Errorf("expected the insights in one batch call, got %d", batches)
+	}
+	var result struct {
+		Data []map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(result.Data) != 2 || result.Data[1]["id"] != "12" || result.Data[1]["views"] != float64(250) || result.Data[1]["shares"] != float64(3) {
+		t.Errorf("unexpected report: %v", result.Data)
+	}
+	if _, ok := result.Data[0]["likes"]; ok {
+		t.Errorf("expected only the requested metrics, got %v", result.Data[0])
+	}
+}
+
+func TestInsightsPost_ArgumentErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "no post or period", args: nil, wantErr: "Name a post"},
+		{name: "post and period", args: []string{"123", "--since", "7d"}, wantErr: "cannot be combined"},
+		{name: "account metric", args: []string{"123", "--metrics", "followers_count"}, wantErr: "followers_count"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, io := newMockAPITestFactory(t, &mockAPI{
+				getPostInsights: func(context.Context, api.PostID, []string) (*api.InsightsResponse, error) {
+					t.Error("unexpected request")
+					return nil, errNotMocked
+				},
+			})
+			cmd := newInsightsPostCmd(f)
+			cmd.SetArgs(tt.args)
+			cmd.SetContext(iocontext.WithIO(context.Background(), io))
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCompareReach(t *testing.T) {
+	baseline := []map[string]int{
+		{"views": 90, "likes": 10},
+		{"views": 100, "likes": 10},
+		{"views": 110, "likes": 10},
+	}
+	report := compareReach("1", map[string]int{"views": 400, "likes": 10}, baseline, 200)
+
+	views, likes := report.Metrics[0], report.Metrics[1]
+	if views.Metric != "views" || views.Average != 100 || views.Ratio != 4 || views.PerFollower != 2 || views.Outlier != "high" {
+		t.Errorf("unexpected views: %+v", views)
+	}
+	// No spread in the baseline, so nothing is an outlier
+	if likes.Ratio != 1 || likes.StdDev != 0 || likes.Outlier != "" {
+		t.Errorf("unexpected likes: %+v", likes)
+	}
+
+	if report := compareReach("1", map[string]int{"views": 0}, baseline, 0); report.Metrics[0].Outlier != "low" || report.Metrics[0].PerFollower != 0 {
+		t.Errorf("expected a low outlier without a per-follower rate, got %+v", report.Metrics[0])
+	}
+	if report := compareReach("1", map[string]int{"views": 400}, baseline[:2], 200); report.Metrics[0].Outlier != "" {
+		t.Errorf("expected no outliers with %d baseline posts, got %+v", 2, report.Metrics[0])
+	}
+}
+
+func TestInsightsReach_ExcludesPostAndReposts(t *testing.T) {
+	views := map[string]int{"target": 500, "a": 100, "b": 120, "c": 80}
+	var fetched []string
+	mock := &mockAPI{
+		getMe: func(context.Context) (*api.User, error) {
+			return &api.User{ID: "me"}, nil
+		},
+		getAccountInsights: func(_ context.Context, _ api.UserID, opts *api.AccountInsightsOptions) (*api.InsightsResponse, error) {
+			return &api.InsightsResponse{Data: []api.Insight{{Name: "followers_count", TotalValue: &api.TotalValue{Value: 1000}}}}, nil
+		},
+		getUserPosts: func(_ context.Context, _ api.UserID, opts *api.PostsOptions) (*api.PostsResponse, error) {
+			if opts.Limit != 4 {
+				t.Errorf("expected one post more than the baseline, got limit %d", opts.Limit)
+			}
+			return &api.PostsResponse{Data: []api.Post{
+				{ID: "target"}, {ID: "a"}, {ID: "repost", RepostedPost: &api.Post{ID: "x"}}, {ID: "b"}, {ID: "c"},
+			}}, nil
+		},
+		getPostInsights: func(_ context.Context, postID api.PostID, _ []string) (*api.InsightsResponse, error) {
+			fetched = append(fetched, string(postID))
+			return &api.InsightsResponse{Data: []api.Insight{{Name: "views", Values: []api.Value{{Value: views[string(postID)]}}}}}, nil
+		},
+		getPostsInsights: func(_ context.Context, postIDs []api.PostID, _ []string) (map[api.PostID]*api.InsightsResponse, error) {
+			fetched = append(fetched, fmt.Sprint(postIDs))
+			responses := map[api.PostID]*api.InsightsResponse{}
+			for _, postID := range postIDs {
+				responses[postID] = &api.InsightsResponse{Data: []api.Insight{{Name: "views", Values: []api.Value{{Value: views[string(postID)]}}}}}
+			}
+			return responses, nil
+		},
+	}
+	f, io := newMockAPITestFactory(t, mock)
+
+	cmd := newInsightsReachCmd(f)
+	cmd.SetArgs([]string{"target", "--baseline", "3"})
+	cmd.SetContext(outfmt.WithFormat(iocontext.WithIO(context.Background(), io), "json"))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	// The post on its own, then the baseline in one batch
+	if strings.Join(fetched, ",") != "target,[a b c]" {
+		t.Errorf("unexpected insights requests: %v", fetched)
+	}
+	var report reachReport
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if report.Followers != 1000 || report.BaselinePosts != 3 || report.Metrics[0].Average != 100 || report.Metrics[0].Outlier != "high" {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
@@ -30,11 +30,11 @@ type ListConfig[T any] struct {
 	EmptyMessage string
 
 	// Fetch function - called with cursor and limit
-	Fetch func(ctx context.Context, client *api.Client, cursor string, limit int) (ListResult[T], error)
+	Fetch func(ctx context.Context, client api.API, cursor string, limit int) (ListResult[T], error)
 }
 
 // NewListCommand creates a new list command using the provided configuration
-func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*api.Client, error)) *cobra.Command {
+func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (api.API, error)) *cobra.Command {
 	var limit int
 	var cursor string
 
@@ -71,7 +71,7 @@ func NewListCommand[T any](cfg ListConfig[T], getClient func(context.Context) (*
 
 			// Handle JSON output mode
 			if outfmt.IsJSON(ctx) {
-				return outputListJSON(io, result, cursor, outfmt.GetQuery(ctx))
+				return outputListJSON(ctx, io, result, cursor)
 			}
 
 			// Handle empty results in text mode
@@ -118,7 +118,7 @@ type listJSONOutput struct {
 // outputListJSON outputs the list result as JSON
 //
 //nolint:unparam // requestCursor reserved for future pagination features
-func outputListJSON[T any](io *iocontext.IO, result ListResult[T], _ string, query string) error {
+func outputListJSON[T any](ctx context.Context, io *iocontext.IO, result ListResult[T], _ string) error {
 	output := listJSONOutput{
 		Items:   result.Items,
 		HasMore: result.HasMore,
@@ -130,5 +130,5 @@ func outputListJSON[T any](io *iocontext.IO, result ListResult[T], _ string, que
 		output.Items = []T{}
 	}
 
-	return outfmt.WriteJSONTo(io.Out, output, query)
+	return outfmt.WriteJSONContext(ctx, io.Out, output)
 }
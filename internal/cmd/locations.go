@@ -4,8 +4,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/salmonumbrella/threads-cli/internal/api"
-	"github.com/salmonumbrella/threads-cli/internal/iocontext"
-	"github.com/salmonumbrella/threads-cli/internal/outfmt"
 )
 
 // NewLocationsCmd builds the locations command group.
@@ -59,29 +57,11 @@ func newLocationsSearchCmd(f *Factory) *cobra.Command {
 				return WrapError("location search failed", err)
 			}
 
-			io := iocontext.GetIO(ctx)
-			out := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
-
-			if outfmt.IsJSON(ctx) {
-				return out.Output(result)
-			}
-
-			if len(result.Data) == 0 {
-				out.Empty("No locations found")
-				return nil
+			views := make([]locationView, len(result.Data))
+			for i := range result.Data {
+				views[i] = locationView{loc: &result.Data[i]}
 			}
-
-			headers := []string{"ID", "NAME", "ADDRESS"}
-			rows := make([][]string, len(result.Data))
-			for i, loc := range result.Data {
-				rows[i] = []string{
-					loc.ID,
-					loc.Name,
-					loc.Address,
-				}
-			}
-
-			return out.Table(headers, rows, nil)
+			return writeViewList(ctx, views, nil, "No locations found")
 		},
 	}
 
@@ -110,9 +90,7 @@ func newLocationsGetCmd(f *Factory) *cobra.Command {
 				return WrapError("failed to get location", err)
 			}
 
-			io := iocontext.GetIO(ctx)
-			out := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
-			return out.Output(location)
+			return writeViewDetail(ctx, locationView{loc: location})
 		},
 	}
 	return cmd
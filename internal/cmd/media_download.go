@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/media"
+)
+
+// mediaDownloadRetries is how often a failed media download is retried
+const mediaDownloadRetries = 3
+
+// mediaCacheSubdir is where downloaded media is stored, content-addressed,
+// under the cache directory
+const mediaCacheSubdir = "media"
+
+func mediaCacheDir() string {
+	return filepath.Join(cacheDir(), mediaCacheSubdir)
+}
+
+// newMediaDownloader is replaced in tests
+var newMediaDownloader = func() (*media.Downloader, error) {
+	cache, err := media.OpenCache(mediaCacheDir())
+	if err != nil {
+		return nil, err
+	}
+	return media.NewDownloader(nil, mediaDownloadRetries).WithCache(cache), nil
+}
+
+// downloadPostMedia downloads the images and videos of posts into dir and
+// updates the manifest there. Files are kept in the media cache, so media
+// already fetched by an earlier run is linked instead of downloaded again.
+// The summary goes to stderr so JSON output on stdout stays parseable.
+// Individual failures are recorded in the manifest and reported as one error
+// after the others have been fetched.
+func downloadPostMedia(ctx context.Context, client api.API, dir string, posts []api.Post) error {
+	items, skipped := collectMedia(ctx, client, posts)
+
+	downloader, err := newMediaDownloader()
+	if err != nil {
+		return WrapError("failed to open media cache", err)
+	}
+	manifest, err := downloader.Download(ctx, dir, items)
+	if err != nil {
+		return WrapError("failed to download media", err)
+	}
+
+	io := iocontext.GetIO(ctx)
+	manifestPath := filepath.Join(dir, media.ManifestName)
+	failed := manifest.Failed()
+	fmt.Fprintf(io.ErrOut, "Downloaded media for %d posts to %s (%d files, %d failed)\n", len(posts), dir, len(items)-len(failed), len(failed)) //nolint:errcheck // Best-effort output to stderr
+
+	if len(failed) > 0 {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("%d media files failed to download", len(failed)),
+			Suggestion: fmt.Sprintf("See %s for details; re-run the command to retry, files already downloaded are skipped", manifestPath),
+		}
+	}
+	if skipped > 0 {
+		return warn(ctx, &UserFriendlyError{
+			Message:    fmt.Sprintf("%d carousel items could not be loaded and were not downloaded", skipped),
+			Suggestion: "Re-run the command to retry",
+		})
+	}
+	return nil
+}
+
+// collectMedia lists the media files attached to posts. The API only returns
+// the IDs of carousel items, so each is fetched for its URL; items that
+// cannot be fetched are skipped and counted.
+func collectMedia(ctx context.Context, client api.API, posts []api.Post) (items []media.Item, skipped int) {
+	for i := range posts {
+		post := &posts[i]
+		if url := fallback(post.MediaURL, post.GifURL); url != "" {
+			items = append(items, media.Item{PostID: post.ID, MediaType: post.MediaType, URL: url, Name: post.ID})
+		}
+		if post.Children == nil {
+			continue
+		}
+		for n, child := range post.Children.Data {
+			full, err := client.GetPost(ctx, api.PostID(child.ID))
+			if err != nil {
+				skipped++
+				continue
+			}
+			if full.MediaURL == "" {
+				continue
+			}
+			items = append(items, media.Item{
+				PostID:    post.ID,
+				MediaType: full.MediaType,
+				URL:       full.MediaURL,
+				Name:      fmt.Sprintf("%s-%d", post.ID, n+1),
+			})
+		}
+	}
+	return items, skipped
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/media"
+)
+
+func TestPostsGet_DownloadMedia(t *testing.T) {
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.jpg" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write([]byte("bytes of " + r.URL.Path)) //nolint:errcheck,gosec // Test server
+	}))
+	defer cdn.Close()
+
+	mock := newPostDetailMock()
+	detailPost := mock.getPost
+	mock.getPost = func(ctx context.Context, postID api.PostID) (*api.Post, error) {
+		switch postID {
+		case "1":
+			return &api.Post{ID: "1", MediaType: "IMAGE", MediaURL: cdn.URL + "/one.jpg"}, nil
+		case "2":
+			return &api.Post{ID: "2", MediaType: "IMAGE", MediaURL: cdn.URL + "/two.jpg"}, nil
+		}
+		return detailPost(ctx, postID)
+	}
+	useTempCacheDir(t)
+	f, io := newMockAPITestFactory(t, mock)
+	dir := filepath.Join(t.TempDir(), "out")
+
+	cmd := newPostsGetCmd(f)
+	cmd.SetArgs([]string{"999", "--download-media", dir})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	for _, name := range []string{"999-1.jpg", "999-2.jpg"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s: %v", name, err)
+		}
+	}
+	m, err := media.ReadManifest(dir)
+	if err != nil {
+		t.Fatalf("ReadManifest: %v", err)
+	}
+	if len(m.Entries) != 2 || m.Entries[0].PostID != "999" || m.Entries[0].SHA256 == "" {
+		t.Errorf("unexpected manifest: %+v", m.Entries)
+	}
+	if errOut := io.ErrOut.(*bytes.Buffer).String(); !strings.Contains(errOut, "(2 files, 0 failed)") {
+		t.Errorf("missing summary on stderr: %q", errOut)
+	}
+
+	// A failed download is reported once the others are done
+	mock.getPost = func(_ context.Context, postID api.PostID) (*api.Post, error) {
+		return &api.Post{ID: string(postID), MediaType: "IMAGE", MediaURL: cdn.URL + "/missing.jpg"}, nil
+	}
+	orig := newMediaDownloader
+	newMediaDownloader = func() (*media.Downloader, error) { return media.NewDownloader(nil, 0), nil }
+	t.Cleanup(func() { newMediaDownloader = orig })
+
+	cmd = newPostsGetCmd(f)
+	cmd.SetArgs([]string{"42", "--download-media", dir})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	err = cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "1 media files failed to download") {
+		t.Fatalf("expected download failure, got %v", err)
+	}
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/mute"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// mutesPath is the mute lists location. It is replaced in tests.
+var mutesPath = func() string {
+	return filepath.Join(config.ConfigDir(), mute.FileName)
+}
+
+// muteView is the output model of a mute list entry
+type muteView struct {
+	Kind  string
+	Value string
+}
+
+func (v muteView) viewFields() []viewField {
+	text := v.Value
+	if v.Kind == "user" {
+		text = "@" + v.Value
+	}
+	return []viewField{
+		{Key: "type", Value: v.Kind, Type: outfmt.ColumnStatus},
+		{Key: "value", Value: v.Value, Text: text},
+	}
+}
+
+// NewMuteCmd builds the mute command group.
+func NewMuteCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mute",
+		Short: "Hide users and keywords from the CLI's views",
+		Long: `Keep local lists of muted users and keywords. Posts by a muted user, or whose
+text contains a muted keyword as a whole word, are left out of watch,
+'users mentions', 'replies list' and search; --show-muted on those commands
+shows them anyway. A post quoting or reposting a muted post is muted too.
+
+Muting is local to this machine and independent of muting on Threads:
+nothing is sent to the API, and hooks still see muted posts.
+
+An argument starting with @ is a user; anything else is a keyword.`,
+	}
+
+	cmd.AddCommand(newMuteAddCmd(f))
+	cmd.AddCommand(newMuteRemoveCmd(f))
+	cmd.AddCommand(newMuteListCmd(f))
+
+	return cmd
+}
+
+func newMuteAddCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add [@user|keyword]...",
+		Short: "Mute users or keywords",
+		Example: `  threads mute add @someone
+  threads mute add spoilers "season finale"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateMutes(cmd, f, args, true)
+		},
+	}
+}
+
+func newMuteRemoveCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove [@user|keyword]...",
+		Short:   "Unmute users or keywords",
+		Example: `  threads mute remove @someone spoilers`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateMutes(cmd, f, args, false)
+		},
+	}
+}
+
+// updateMutes adds args to the mute lists, or removes them, and saves the
+// lists if anything changed
+func updateMutes(cmd *cobra.Command, f *Factory, args []string, add bool) error {
+	ctx := cmd.Context()
+
+	var changed, unchanged []string
+	for _, arg := range args {
+		if mute.NormalizeUser(arg) == "" || mute.NormalizeKeyword(arg) == "" {
+			return &UserFriendlyError{
+				Message:    fmt.Sprintf("Nothing to mute in %q", arg),
+				Suggestion: "Give a @username or a keyword",
+			}
+		}
+	}
+
+	path := mutesPath()
+	lists, err := mute.Load(path)
+	if err != nil {
+		return WrapError("failed to read mute lists", err)
+	}
+	for _, arg := range args {
+		var ok bool
+		name := mute.NormalizeKeyword(arg)
+		if strings.HasPrefix(arg, "@") {
+			name = "@" + mute.NormalizeUser(arg)
+			if add {
+				ok = lists.AddUser(arg)
+			} else {
+				ok = lists.RemoveUser(arg)
+			}
+		} else if add {
+			ok = lists.AddKeyword(arg)
+		} else {
+			ok = lists.RemoveKeyword(arg)
+		}
+		if ok {
+			changed = append(changed, name)
+		} else {
+			unchanged = append(unchanged, name)
+		}
+	}
+	if len(changed) > 0 {
+		if err := mute.Save(path, lists); err != nil {
+			return WrapError("failed to save mute lists", err)
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		key := "removed"
+		if add {
+			key = "added"
+		}
+		return outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, map[string]any{key: append([]string{}, changed...)})
+	}
+	p := f.UI(ctx)
+	for _, name := range changed {
+		if add {
+			p.Success("Muted %s", name)
+		} else {
+			p.Success("Unmuted %s", name)
+		}
+	}
+	for _, name := range unchanged {
+		if add {
+			p.Info("%s is already muted", name)
+		} else {
+			p.Warning("%s is not muted", name)
+		}
+	}
+	return nil
+}
+
+func newMuteListCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List muted users and keywords",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lists, err := mute.Load(mutesPath())
+			if err != nil {
+				return WrapError("failed to read mute lists", err)
+			}
+			views := []muteView{}
+			for _, user := range lists.Users {
+				views = append(views, muteView{Kind: "user", Value: user})
+			}
+			for _, keyword := range lists.Keywords {
+				views = append(views, muteView{Kind: "keyword", Value: keyword})
+			}
+			return writeViewList(cmd.Context(), views, nil, "Nothing is muted")
+		},
+	}
+}
+
+// addShowMutedFlag adds the --show-muted flag of the commands that hide
+// muted posts
+func addShowMutedFlag(cmd *cobra.Command, show *bool) {
+	cmd.Flags().BoolVar(show, "show-muted", false, "Include posts hidden by 'threads mute'")
+}
+
+// loadMutes returns the mute lists, or nil when show is set
+func loadMutes(show bool) (*mute.Lists, error) {
+	if show {
+		return nil, nil
+	}
+	lists, err := mute.Load(mutesPath())
+	if err != nil {
+		return nil, WrapError("failed to read mute lists", err)
+	}
+	return lists, nil
+}
+
+// hideMuted drops the posts of page the mute lists match, unless show is
+// set, and says on stderr how many were hidden
+func hideMuted(ctx context.Context, page *api.Page[api.Post], show bool) error {
+	lists, err := loadMutes(show)
+	if err != nil || lists.Empty() {
+		return err
+	}
+	kept := page.Data[:0]
+	for i := range page.Data {
+		if lists.Match(&page.Data[i]) == "" {
+			kept = append(kept, page.Data[i])
+		}
+	}
+	hidden := len(page.Data) - len(kept)
+	page.Data = kept
+	if hidden > 0 && !outfmt.IsJSON(ctx) {
+		fmt.Fprintf(iocontext.GetIO(ctx).ErrOut, "%s hidden, --show-muted to include them\n", pluralize(hidden, "muted post", "muted posts")) //nolint:errcheck // Best-effort output to stderr
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/mute"
+)
+
+// useTempMutes points the mute lists at a temporary file
+func useTempMutes(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), mute.FileName)
+	orig := mutesPath
+	mutesPath = func() string { return path }
+	t.Cleanup(func() { mutesPath = orig })
+	return path
+}
+
+func TestMuteCmd_AddListRemove(t *testing.T) {
+	path := useTempMutes(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	ctx := iocontext.WithIO(context.Background(), io)
+
+	run := func(args ...string) error {
+		cmd := NewMuteCmd(f)
+		cmd.SetArgs(args)
+		cmd.SetContext(ctx)
+		return cmd.Execute()
+	}
+
+	if err := run("add", "@Someone", "Spoilers", "spoilers"); err != nil {
+		t.Fatal(err)
+	}
+	lists, err := mute.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(lists.Users, []string{"someone"}) || !slices.Equal(lists.Keywords, []string{"spoilers"}) {
+		t.Errorf("unexpected lists: %+v", lists)
+	}
+
+	io.Out.(*bytes.Buffer).Reset()
+	if err := run("list"); err != nil {
+		t.Fatal(err)
+	}
+	if out := io.Out.(*bytes.Buffer).String(); !strings.Contains(out, "@someone") || !strings.Contains(out, "spoilers") {
+		t.Errorf("expected both entries listed, got %q", out)
+	}
+
+	if err := run("remove", "@someone"); err != nil {
+		t.Fatal(err)
+	}
+	if lists, _ := mute.Load(path); len(lists.Users) != 0 || len(lists.Keywords) != 1 {
+		t.Errorf("expected only the user removed, got %+v", lists)
+	}
+	if err := run("add", "@"); err == nil {
+		t.Error("expected an empty username to be an error")
+	}
+}
+
+func TestUsersMentions_HidesMuted(t *testing.T) {
+	path := useTempMutes(t)
+	if err := mute.Save(path, &mute.Lists{Users: []string{"spammer"}, Keywords: []string{"crypto"}}); err != nil {
+		t.Fatal(err)
+	}
+	f, io := newMockAPITestFactory(t, &mockAPI{
+		getMe: func(context.Context) (*api.User, error) { return &api.User{ID: "me"}, nil },
+		getMentions: func(context.Context, api.UserID, *api.PaginationOptions) (*api.PostsResponse, error) {
+			return &api.PostsResponse{Data: []api.Post{
+				{ID: "1", Username: "spammer", Text: "hello"},
+				{ID: "2", Username: "friend", Text: "Buy CRYPTO now"},
+				{ID: "3", Username: "friend", Text: "see you tomorrow"},
+			}}, nil
+		},
+	})
+
+	run := func(args ...string) string {
+		t.Helper()
+		io.Out.(*bytes.Buffer).Reset()
+		io.ErrOut.(*bytes.Buffer).Reset()
+		cmd := newUsersMentionsCmd(f)
+		cmd.SetArgs(args)
+		cmd.SetContext(iocontext.WithIO(context.Background(), io))
+		if err := cmd.Execute(); err != nil {
+			t.Fatal(err)
+		}
+		return io.Out.(*bytes.Buffer).String()
+	}
+
+	out := run()
+	if strings.Contains(out, "spammer") || strings.Contains(out, "CRYPTO") || !strings.Contains(out, "see you tomorrow") {
+		t.Errorf("expected muted mentions hidden, got %q", out)
+	}
+	if errOut := io.ErrOut.(*bytes.Buffer).String(); !strings.Contains(errOut, "2 muted posts hidden") {
+		t.Errorf("expected a note of the hidden posts, got %q", errOut)
+	}
+
+	if out := run("--show-muted"); !strings.Contains(out, "spammer") || !strings.Contains(out, "CRYPTO") {
+		t.Errorf("expected --show-muted to show everything, got %q", out)
+	}
+}
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/notes"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// notesPath is the note store location. It is replaced in tests.
+var notesPath = func() string {
+	return filepath.Join(config.DataDir(), notes.FileName)
+}
+
+// noteView is the output model of a note
+type noteView struct {
+	n *notes.Note
+}
+
+func (v noteView) viewFields() []viewField {
+	target := v.n.Target
+	if v.n.Kind == notes.User {
+		target = "@" + target
+	}
+	return []viewField{
+		{Key: "kind", Value: v.n.Kind, Type: outfmt.ColumnStatus},
+		{Key: "target", Value: v.n.Target, Text: target, Type: outfmt.ColumnID},
+		{Key: "text", Value: v.n.Text, Text: truncateText(strings.ReplaceAll(v.n.Text, "\n", " / "), 60)},
+		{Key: "updated_at", Value: v.n.UpdatedAt, Text: v.n.UpdatedAt.Local().Format("2006-01-02 15:04"), Type: outfmt.ColumnDate},
+	}
+}
+
+// NewNotesCmd builds the notes command group.
+func NewNotesCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notes",
+		Short: "Keep private notes on users and posts",
+		Long: `Keep private notes on users and posts. Notes are stored in the data
+directory on this machine only and never sent to Threads.
+
+Table output of replies, conversations, mentions, search results and your
+posts gains a NOTE column when a listed post or its author has a note.`,
+	}
+
+	cmd.AddCommand(newNotesAddCmd(f))
+	cmd.AddCommand(newNotesListCmd(f))
+	cmd.AddCommand(newNotesRemoveCmd(f))
+
+	return cmd
+}
+
+func newNotesAddCmd(f *Factory) *cobra.Command {
+	var replace bool
+
+	cmd := &cobra.Command{
+		Use:   "add [user|post] [target] [text]",
+		Short: "Add a note on a user or a post",
+		Long: `Add a note on a user, by username, or on a post, by ID or threads.net URL.
+Adding to a target that already has a note appends a line to it; --replace
+overwrites it instead.
+
+A URL is looked up among the author's recent posts, which needs the
+threads_profile_discovery scope; a post ID needs no API request.
+
+Examples:
+  threads notes add user @someone "met at conf"
+  threads notes add post 12345678901234567 "use for the case study"
+  threads notes add user someone "prefers DMs" --replace`,
+		Args: cobra.MinimumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runNotesAdd(cmd, f, args[0], args[1], strings.Join(args[2:], " "), replace)
+		},
+	}
+
+	cmd.Flags().BoolVar(&replace, "replace", false, "Replace the existing note instead of appending to it")
+	return cmd
+}
+
+func runNotesAdd(cmd *cobra.Command, f *Factory, kindName, target, text string, replace bool) error {
+	ctx := cmd.Context()
+
+	kind, err := parseNoteKind(kindName)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(text) == "" {
+		return &UserFriendlyError{Message: "The note is empty"}
+	}
+	if kind == notes.Post && postURLPattern.MatchString(target) {
+		client, err := f.Client(ctx)
+		if err != nil {
+			return err
+		}
+		post, err := resolvePost(ctx, client, target)
+		if err != nil {
+			return err
+		}
+		target = post.ID
+	}
+	target = notes.NormalizeTarget(kind, target)
+	if target == "" {
+		return &UserFriendlyError{Message: fmt.Sprintf("Name the %s to add the note on", kind)}
+	}
+
+	path := notesPath()
+	saved, err := notes.Load(path)
+	if err != nil {
+		return WrapError("failed to read notes", err)
+	}
+	if replace {
+		saved, _ = notes.Remove(saved, kind, target)
+	}
+	now := time.Now().UTC()
+	saved, added := notes.Add(saved, notes.Note{Kind: kind, Target: target, Text: text, AddedAt: now, UpdatedAt: now})
+	if err := notes.Save(path, saved); err != nil {
+		return WrapError("failed to save notes", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, map[string]any{"kind": kind, "target": target, "added": added})
+	}
+	if added {
+		f.UI(ctx).Success("Added a note on %s", describeNoteTarget(kind, target))
+	} else {
+		f.UI(ctx).Success("Appended to the note on %s", describeNoteTarget(kind, target))
+	}
+	return nil
+}
+
+func newNotesListCmd(f *Factory) *cobra.Command {
+	var kindName string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List notes",
+		Long: `List notes, oldest first. No API requests are made.
+
+Examples:
+  threads notes list
+  threads notes list --kind user`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var kind notes.Kind
+			if kindName != "" {
+				var err error
+				if kind, err = parseNoteKind(kindName); err != nil {
+					return err
+				}
+			}
+			saved, err := notes.Load(notesPath())
+			if err != nil {
+				return WrapError("failed to read notes", err)
+			}
+			views := []noteView{}
+			for i := range saved {
+				if kind == "" || saved[i].Kind == kind {
+					views = append(views, noteView{n: &saved[i]})
+				}
+			}
+			return writeViewList(cmd.Context(), views, nil, "No notes found")
+		},
+	}
+
+	cmd.Flags().StringVar(&kindName, "kind", "", "Only list notes on users or on posts: user or post")
+	return cmd
+}
+
+func newNotesRemoveCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove [user|post] [target]",
+		Short: "Remove the note on a user or a post",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			kind, err := parseNoteKind(args[0])
+			if err != nil {
+				return err
+			}
+			target := notes.NormalizeTarget(kind, args[1])
+
+			path := notesPath()
+			saved, err := notes.Load(path)
+			if err != nil {
+				return WrapError("failed to read notes", err)
+			}
+			saved, removed := notes.Remove(saved, kind, target)
+			if !removed {
+				return &UserFriendlyError{
+					Message:    fmt.Sprintf("No note on %s", describeNoteTarget(kind, target)),
+					Suggestion: "List notes with 'threads notes list'",
+				}
+			}
+			if err := notes.Save(path, saved); err != nil {
+				return WrapError("failed to save notes", err)
+			}
+			if outfmt.IsJSON(ctx) {
+				return outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, map[string]any{"kind": kind, "target": target, "removed": true})
+			}
+			f.UI(ctx).Success("Removed the note on %s", describeNoteTarget(kind, target))
+			return nil
+		},
+	}
+}
+
+func parseNoteKind(name string) (notes.Kind, error) {
+	kind, err := notes.ParseKind(name)
+	if err != nil {
+		return "", &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid note kind: %s", name),
+			Suggestion: "Notes are on a user or a post, e.g. 'threads notes add user @someone \"met at conf\"'",
+		}
+	}
+	return kind, nil
+}
+
+func describeNoteTarget(kind notes.Kind, target string) string {
+	if kind == notes.User {
+		return "@" + target
+	}
+	return "post " + target
+}
+
+// postNotes returns the note cell of each of n listed posts, for a NOTE
+// column, or nil when none of them or their authors has a note. Notes are
+// extra to a listing, so a store that cannot be read is only warned about.
+func postNotes(ctx context.Context, f *Factory, n int, post func(i int) *api.Post) []string {
+	saved, err := notes.Load(notesPath())
+	if err != nil {
+		f.UI(ctx).Warning("Notes not shown: %v", err)
+		return nil
+	}
+	if len(saved) == 0 {
+		return nil
+	}
+	ix := notes.NewIndex(saved)
+	cells := make([]string, n)
+	noted := false
+	for i := range n {
+		p := post(i)
+		note := ix.Post(p.ID, p.Username)
+		if note != "" {
+			noted = true
+		}
+		cells[i] = fallback(truncateText(strings.ReplaceAll(note, "\n", " / "), 40), "-")
+	}
+	if !noted {
+		return nil
+	}
+	return cells
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/notes"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// useTempNotes points the note store at a temporary file
+func useTempNotes(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), notes.FileName)
+	orig := notesPath
+	notesPath = func() string { return path }
+	t.Cleanup(func() { notesPath = orig })
+	return path
+}
+
+func runNotesCmd(t *testing.T, f *Factory, io *iocontext.IO, ctx context.Context, args ...string) error {
+	t.Helper()
+	cmd := NewNotesCmd(f)
+	cmd.SetArgs(args)
+	cmd.SetContext(iocontext.WithIO(ctx, io))
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return cmd.Execute()
+}
+
+func TestNotes_AddListRemove(t *testing.T) {
+	path := useTempNotes(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	ctx := context.Background()
+
+	for _, args := range [][]string{
+		{"add", "user", "@Someone", "met", "at", "conf"},
+		{"add", "user", "someone", "prefers DMs"},
+		{"add", "post", "123", "case study"},
+	} {
+		if err := runNotesCmd(t, f, io, ctx, args...); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+	}
+	saved, err := notes.Load(path)
+	if err != nil || len(saved) != 2 || saved[0].Text != "met at conf\nprefers DMs" {
+		t.Fatalf("unexpected notes: %+v, %v", saved, err)
+	}
+
+	io.Out.(*bytes.Buffer).Reset()
+	if err := runNotesCmd(t, f, io, outfmt.WithFormat(ctx, "json"), "list", "--kind", "post"); err != nil {
+		t.Fatal(err)
+	}
+	var listed struct {
+		Data []map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &listed); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(listed.Data) != 1 || listed.Data[0]["target"] != "123" {
+		t.Errorf("expected only the post note, got %v", listed.Data)
+	}
+
+	if err := runNotesCmd(t, f, io, ctx, "remove", "user", "@someone"); err != nil {
+		t.Fatal(err)
+	}
+	err = runNotesCmd(t, f, io, ctx, "remove", "user", "someone")
+	if err == nil || !strings.Contains(err.Error(), "No note on @someone") {
+		t.Errorf("expected removing twice to fail, got %v", err)
+	}
+}
+
+func TestNotes_AddErrors(t *testing.T) {
+	useTempNotes(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	err := runNotesCmd(t, f, io, context.Background(), "add", "topic", "golang", "text")
+	if err == nil || !strings.Contains(err.Error(), "Invalid note kind") {
+		t.Errorf("expected an unknown kind refused, got %v", err)
+	}
+	err = runNotesCmd(t, f, io, context.Background(), "add", "user", "someone", " ")
+	if err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("expected an empty note refused, got %v", err)
+	}
+}
+
+func TestNotes_ShownInReplies(t *testing.T) {
+	path := useTempNotes(t)
+	saved, _ := notes.Add(nil, notes.Note{Kind: notes.User, Target: "bob", Text: "met at conf"})
+	if err := notes.Save(path, saved); err != nil {
+		t.Fatal(err)
+	}
+	f, io := newMockAPITestFactory(t, &mockAPI{
+		getConversation: func(context.Context, api.PostID, *api.RepliesOptions) (*api.RepliesResponse, error) {
+			return &api.RepliesResponse{Data: []api.Post{
+				{ID: "2", Username: "bob", Text: "Nice"},
+				{ID: "3", Username: "carol", Text: "Agreed"},
+			}}, nil
+		},
+	})
+
+	cmd := NewRepliesCmd(f)
+	cmd.SetArgs([]string{"conversation", "1"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	out := io.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, "NOTE") || !strings.Contains(out, "met at conf") {
+		t.Errorf("expected bob's note in a NOTE column:\n%s", out)
+	}
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/events"
+	"github.com/salmonumbrella/threads-cli/internal/notify"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
+)
+
+// notifyClient sends notifications. Its timeout keeps a slow chat service
+// from stalling the command that published the event; it is replaced in
+// tests.
+var notifyClient = &http.Client{Timeout: 10 * time.Second}
+
+// sendEmail sends email notifications; it is replaced in tests
+var sendEmail = notify.SendEmail
+
+// smtpPasswordSecret names the SMTP password in the credential store
+const smtpPasswordSecret = "smtp"
+
+// email sends m to addresses through the server configured under
+// notify.email
+func (f *Factory) email(ctx context.Context, addresses []string, m notify.Message) error {
+	server, err := f.smtpServer()
+	if err != nil {
+		return err
+	}
+	return sendEmail(ctx, server, addresses, m)
+}
+
+// smtpServer reads the notify.email settings, with the password from the
+// credential store when a username is set
+func (f *Factory) smtpServer() (notify.SMTP, error) {
+	settings := f.Config.Notify
+	server := notify.SMTP{
+		Host:     settings["email.host"],
+		Username: settings["email.username"],
+		From:     settings["email.from"],
+	}
+	if server.Host == "" || server.From == "" {
+		return server, &UserFriendlyError{
+			Message:    "No SMTP server configured for email",
+			Suggestion: "Set one with 'threads config set notify.email.host smtp.example.com' and 'threads config set notify.email.from bot@example.com'",
+		}
+	}
+	if port := settings["email.port"]; port != "" {
+		n, err := strconv.Atoi(port)
+		if err != nil {
+			return server, fmt.Errorf("invalid notify.email.port %q", port)
+		}
+		server.Port = n
+	}
+	if server.Username == "" {
+		return server, nil
+	}
+
+	store, err := f.Store()
+	if err != nil {
+		return server, err
+	}
+	server.Password, err = store.GetSecret(smtpPasswordSecret)
+	if errors.Is(err, secrets.ErrSecretNotFound) {
+		return server, &UserFriendlyError{
+			Message:    fmt.Sprintf("No SMTP password stored for %s", server.Username),
+			Suggestion: "Store it with 'threads config set notify.email.password -', which reads it from stdin",
+		}
+	}
+	return server, err
+}
+
+// runNotifiers tells the targets configured under notify.<type> about e. Like
+// hooks, the config is read for every event and a failure is a warning.
+func (f *Factory) runNotifiers(ctx context.Context, e events.Event) error {
+	value := f.Config.Notify[string(e.Type())]
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	targets, err := notify.ParseTargets(value)
+	if err == nil {
+		message := notifyMessage(e)
+		var errs []error
+		var addresses []string
+		for _, target := range targets {
+			if target.Kind == notify.KindEmail {
+				addresses = append(addresses, target.Address())
+				continue
+			}
+			errs = append(errs, notify.Send(ctx, notifyClient, target, message))
+		}
+		if len(addresses) > 0 {
+			errs = append(errs, f.email(ctx, addresses, message))
+		}
+		err = errors.Join(errs...)
+	}
+	if err != nil {
+		return warn(ctx, &UserFriendlyError{
+			Message:    fmt.Sprintf("The %s notification failed: %v", e.Type(), err),
+			Suggestion: fmt.Sprintf("Check the URLs with 'threads config get notify.%s'", e.Type()),
+			Cause:      err,
+		})
+	}
+	return nil
+}
+
+// notifyMessage says what happened in e
+func notifyMessage(e events.Event) notify.Message {
+	m := notify.Message{Event: string(e.Type()), Data: e}
+	switch e := e.(type) {
+	case events.NewMention:
+		m.Title = fmt.Sprintf("@%s mentioned you", e.Post.Username)
+		m.Text, m.Link = e.Post.Text, e.Post.Permalink
+	case events.NewReply:
+		m.Title = fmt.Sprintf("@%s replied", e.Reply.Username)
+		if len(e.Labels) > 0 {
+			m.Title += " (" + strings.Join(e.Labels, ", ") + ")"
+		}
+		m.Text, m.Link = e.Reply.Text, e.Reply.Permalink
+	case events.PostPublished:
+		m.Title = "Published a post"
+		if e.Source == events.SourceQueue {
+			m.Title = "Published a queued post"
+		}
+		m.Text, m.Link = e.Post.Text, e.Post.Permalink
+	case events.PublishFailed:
+		m.Title = "Failed to publish a post"
+		if e.QueueID != "" {
+			m.Title = "Failed to publish queued post " + e.QueueID
+		}
+		m.Text = e.Error
+		if e.Text != "" {
+			m.Text += "\n\n" + e.Text
+		}
+	case events.TokenExpiring:
+		m.Title = fmt.Sprintf("The token of %s expires %s", e.Account, e.ExpiresAt.Local().Format("2006-01-02 15:04 MST"))
+		m.Text = "Renew it with 'threads auth refresh'"
+	case events.TokenRefreshed:
+		m.Title = fmt.Sprintf("Renewed the token of %s", e.Account)
+		m.Text = "It now expires " + e.ExpiresAt.Local().Format("2006-01-02 15:04 MST")
+	case events.TokenRefreshFailed:
+		m.Title = fmt.Sprintf("Failed to renew the token of %s, which expires %s", e.Account, e.ExpiresAt.Local().Format("2006-01-02 15:04 MST"))
+		m.Text = e.Error + "\n\nRenew it with 'threads auth refresh', or 'threads auth login' to re-authenticate"
+	default:
+		m.Title = string(e.Type())
+	}
+	return m
+}
+
+// describeNotify lists the targets of value without the secret paths of
+// their URLs
+func describeNotify(value string) string {
+	targets, err := notify.ParseTargets(value)
+	if err != nil {
+		return "(invalid: " + err.Error() + ")"
+	}
+	names := make([]string, len(targets))
+	for i, target := range targets {
+		names[i] = target.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// setNotify sets the targets told of event, or removes them when value is
+// empty. Keys starting with "email." are the SMTP settings instead.
+func setNotify(cfg *config.Config, event, value string) error {
+	if name, ok := strings.CutPrefix(event, "email."); ok {
+		return setNotifyEmail(cfg, name, value)
+	}
+	if !slices.Contains(config.HookEvents, event) {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Unknown notify event: %s", event),
+			Suggestion: "Valid events: " + strings.Join(config.HookEvents, ", "),
+		}
+	}
+	if value == "" {
+		delete(cfg.Notify, event)
+		return nil
+	}
+	if _, err := notify.ParseTargets(value); err != nil {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid notify target: %v", err),
+			Suggestion: "Give Slack or Discord webhook URLs, http(s) endpoints or mailto: addresses, separated by commas; prefix one with slack: or discord: to force its format",
+			Cause:      err,
+		}
+	}
+	if cfg.Notify == nil {
+		cfg.Notify = map[string]string{}
+	}
+	cfg.Notify[event] = value
+	return nil
+}
+
+// setNotifyEmail sets the SMTP setting name, or removes it when value is
+// empty. The password is not kept in the config; see setSMTPPassword.
+func setNotifyEmail(cfg *config.Config, name, value string) error {
+	if !slices.Contains(notify.EmailSettings, name) {
+		suggestion := "Valid settings: notify.email." + strings.Join(notify.EmailSettings, ", notify.email.") + ", notify.email.password"
+		if name == "password" {
+			suggestion = "The password is kept in the credential store; set it with 'threads config set notify.email.password -'"
+		}
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Unknown email setting: %s", name),
+			Suggestion: suggestion,
+		}
+	}
+	key := "email." + name
+	if value == "" {
+		delete(cfg.Notify, key)
+		return nil
+	}
+	if err := notify.CheckEmailSetting(name, value); err != nil {
+		return &UserFriendlyError{
+			Message: fmt.Sprintf("Invalid notify.%s: %v", key, err),
+			Cause:   err,
+		}
+	}
+	if cfg.Notify == nil {
+		cfg.Notify = map[string]string{}
+	}
+	cfg.Notify[key] = value
+	return nil
+}
+
+// setSMTPPassword stores the SMTP password in the credential store, reading
+// it from stdin when value is "-", or removes it when value is empty
+func setSMTPPassword(ctx context.Context, f *Factory, value string) error {
+	store, err := f.Store()
+	if err != nil {
+		return FormatError(err)
+	}
+	if value == "" {
+		return store.DeleteSecret(smtpPasswordSecret)
+	}
+	if value == "-" {
+		if value, err = readStdinText(ctx); err != nil {
+			return err
+		}
+		if value == "" {
+			return &UserFriendlyError{Message: "No password on stdin"}
+		}
+	}
+	if err := store.SetSecret(smtpPasswordSecret, value); err != nil {
+		return WrapError("failed to store the SMTP password", err)
+	}
+	return nil
+}
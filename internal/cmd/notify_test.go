@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/events"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/notify"
+	"github.com/salmonumbrella/threads-cli/internal/queue"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
+)
+
+// notifyServer records the JSON bodies posted to it
+func notifyServer(t *testing.T, status int) (*httptest.Server, *[]map[string]any) {
+	t.Helper()
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid JSON: %v", err)
+		}
+		bodies = append(bodies, body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &bodies
+}
+
+// sentEmail is a call of sendEmail
+type sentEmail struct {
+	Server notify.SMTP
+	To     []string
+	notify.Message
+}
+
+// captureEmail records emails instead of sending them
+func captureEmail(t *testing.T) *[]sentEmail {
+	t.Helper()
+	var sent []sentEmail
+	orig := sendEmail
+	sendEmail = func(_ context.Context, server notify.SMTP, to []string, m notify.Message) error {
+		sent = append(sent, sentEmail{server, to, m})
+		return nil
+	}
+	t.Cleanup(func() { sendEmail = orig })
+	return &sent
+}
+
+// smtpConfig is the notify config of an SMTP server needing a login
+func smtpConfig(targets map[string]string) map[string]string {
+	cfg := map[string]string{"email.host": "smtp.example.com", "email.port": "465", "email.from": "bot@example.com", "email.username": "bot"}
+	for event, value := range targets {
+		cfg[event] = value
+	}
+	return cfg
+}
+
+func TestNotifiers_SendConfiguredEvents(t *testing.T) {
+	server, bodies := notifyServer(t, http.StatusOK)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	f.Config.Notify = map[string]string{
+		"new_mention":    "slack:" + server.URL + "/slack",
+		"token_expiring": server.URL + "/generic",
+	}
+
+	ctx := iocontext.WithIO(context.Background(), io)
+	post := api.Post{ID: "1", Username: "ana", Text: "hi @me", Permalink: "https://www.threads.net/t/1"}
+	for _, e := range []events.Event{
+		events.NewMention{Post: post, Source: events.SourcePoll},
+		events.TokenExpiring{Account: "me", ExpiresAt: time.Now().Add(48 * time.Hour)},
+		events.NewReply{Reply: post},
+	} {
+		if err := f.Events.Publish(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(*bodies) != 2 {
+		t.Fatalf("expected two notifications, got %v", *bodies)
+	}
+	if text := (*bodies)[0]["text"]; text != "*@ana mentioned you*\nhi @me\nhttps://www.threads.net/t/1" {
+		t.Errorf("unexpected Slack message: %v", text)
+	}
+	if (*bodies)[1]["event"] != "token_expiring" || !strings.Contains((*bodies)[1]["title"].(string), "The token of me expires") {
+		t.Errorf("unexpected HTTP payload: %v", (*bodies)[1])
+	}
+}
+
+func TestNotifiers_FailureWarns(t *testing.T) {
+	server, _ := notifyServer(t, http.StatusNotFound)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	f.Config.Notify = map[string]string{"post_published": server.URL + "/secret"}
+
+	ctx := iocontext.WithIO(context.Background(), io)
+	if err := f.Events.Publish(ctx, events.PostPublished{}); err != nil {
+		t.Fatalf("expected a failing notification to only warn, got %v", err)
+	}
+	stderr := io.ErrOut.(*bytes.Buffer).String()
+	if !strings.Contains(stderr, "warning: The post_published notification failed") || strings.Contains(stderr, "/secret") {
+		t.Errorf("expected a warning without the URL path, got:\n%s", stderr)
+	}
+}
+
+func TestNotifiers_Email(t *testing.T) {
+	sent := captureEmail(t)
+	server, bodies := notifyServer(t, http.StatusOK)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	store := &mockCredentialsStore{creds: testCredentials(), secrets: map[string]string{smtpPasswordSecret: "hunter2"}}
+	f.Store = func() (secrets.Store, error) { return store, nil }
+	f.Config.Notify = smtpConfig(map[string]string{"publish_failed": "mailto:ops@example.com," + server.URL + ",mailto:ana@example.com"})
+
+	ctx := iocontext.WithIO(context.Background(), io)
+	if err := f.Events.Publish(ctx, events.PublishFailed{QueueID: "q1", Error: "Invalid parameter"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*bodies) != 1 {
+		t.Errorf("expected the HTTP target notified too, got %v", *bodies)
+	}
+	if len(*sent) != 1 {
+		t.Fatalf("expected one email, got %+v", *sent)
+	}
+	email := (*sent)[0]
+	want := notify.SMTP{Host: "smtp.example.com", Port: 465, Username: "bot", Password: "hunter2", From: "bot@example.com"}
+	if email.Server != want || strings.Join(email.To, ",") != "ops@example.com,ana@example.com" {
+		t.Errorf("unexpected email: %+v", email)
+	}
+	if email.Title != "Failed to publish queued post q1" || email.Text != "Invalid parameter" {
+		t.Errorf("unexpected message: %+v", email.Message)
+	}
+
+	// Without the password the notification only warns
+	delete(store.secrets, smtpPasswordSecret)
+	if err := f.Events.Publish(ctx, events.TokenExpiring{Account: "me"}); err != nil {
+		t.Fatal(err)
+	}
+	f.Config.Notify["token_expiring"] = "mailto:ops@example.com"
+	if err := f.Events.Publish(ctx, events.TokenExpiring{Account: "me"}); err != nil {
+		t.Fatal(err)
+	}
+	if stderr := io.ErrOut.(*bytes.Buffer).String(); !strings.Contains(stderr, "No SMTP password stored for bot") {
+		t.Errorf("expected a missing password warning, got:\n%s", stderr)
+	}
+	if len(*sent) != 1 {
+		t.Errorf("expected no email without a password, got %+v", *sent)
+	}
+}
+
+func TestConfigSet_SMTPPassword(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv("THREADS_CONFIG", path)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	store := &mockCredentialsStore{creds: testCredentials()}
+	f.Store = func() (secrets.Store, error) { return store, nil }
+
+	run := func(stdin string, args ...string) error {
+		io.In = strings.NewReader(stdin)
+		cmd := NewConfigCmd(f)
+		cmd.SetArgs(args)
+		cmd.SetContext(iocontext.WithIO(context.Background(), io))
+		return cmd.Execute()
+	}
+	if err := run("", "set", "notify.email.username", "bot"); err != nil {
+		t.Fatal(err)
+	}
+	if err := run("hunter2\n", "set", "notify.email.password", "-"); err != nil {
+		t.Fatal(err)
+	}
+	if store.secrets[smtpPasswordSecret] != "hunter2" {
+		t.Errorf("expected the password stored, got %v", store.secrets)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "hunter2") || strings.Contains(string(data), "password") {
+		t.Errorf("expected no password in the config file:\n%s", data)
+	}
+
+	if err := run("", "unset", "notify.email.password"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.secrets[smtpPasswordSecret]; ok {
+		t.Error("expected the password removed")
+	}
+}
+
+func TestQueueRun_NotifiesFailures(t *testing.T) {
+	path := useTempQueue(t)
+	notified, bodies := notifyServer(t, http.StatusOK)
+	api400 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Invalid parameter","type":"OAuthException","code":100}}`))
+	}))
+	t.Cleanup(api400.Close)
+	f, io := newIntegrationTestFactory(t, api400.URL)
+	f.Config.Notify = map[string]string{"publish_failed": notified.URL}
+
+	// The last attempt fails, so the post is given up
+	err := queue.Save(path, []queue.Item{
+		{ID: "q1", TextPost: &api.TextPostContent{Text: "doomed"}, At: time.Now().Add(-time.Minute), Status: queue.StatusPending, Attempts: queue.MaxAttempts - 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runQueue(iocontext.WithIO(context.Background(), io), f, time.Now(), &queueRunOptions{}, false); err != nil {
+		t.Fatal(err)
+	}
+	if len(*bodies) != 1 || (*bodies)[0]["title"] != "Failed to publish queued post q1" || !strings.Contains((*bodies)[0]["text"].(string), "doomed") {
+		t.Errorf("expected the failure notified, got %v", *bodies)
+	}
+}
+
+func TestApplyConfigValue_Notify(t *testing.T) {
+	cfg := config.Default()
+	if err := applyConfigValue(cfg, "notify.publish_failed", "https://hooks.slack.com/services/T/B/x"); err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := configValue(cfg, "notify.publish_failed"); !ok || value != cfg.Notify["publish_failed"] {
+		t.Errorf("expected the URL back, got %v", value)
+	}
+	if got := describeNotify(cfg.Notify["publish_failed"]); got != "slack https://hooks.slack.com" {
+		t.Errorf("expected the URL described without its secret, got %q", got)
+	}
+	if err := applyConfigValue(cfg, "notify.on_like", "https://example.com"); err == nil || !strings.Contains(err.Error(), "Unknown notify event") {
+		t.Errorf("expected an unknown event to fail, got %v", err)
+	}
+	if err := applyConfigValue(cfg, "notify.new_reply", "example.com"); err == nil || !strings.Contains(err.Error(), "Invalid notify target") {
+		t.Errorf("expected a bad URL to fail, got %v", err)
+	}
+	if err := applyConfigValue(cfg, "notify.publish_failed", ""); err != nil || len(cfg.Notify) != 0 {
+		t.Errorf("expected the target removed, got %v %v", err, cfg.Notify)
+	}
+
+	if err := applyConfigValue(cfg, "notify.email.port", "465"); err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := configValue(cfg, "notify.email.port"); !ok || value != "465" {
+		t.Errorf("expected the port back, got %v", value)
+	}
+	if err := applyConfigValue(cfg, "notify.email.port", "smtp"); err == nil || !strings.Contains(err.Error(), "Invalid notify.email.port") {
+		t.Errorf("expected a bad port to fail, got %v", err)
+	}
+	if err := applyConfigValue(cfg, "notify.email.password", "x"); err == nil || !strings.Contains(FormatError(err).Error(), "credential store") {
+		t.Errorf("expected the password kept out of the config, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+// stdoutIsTerminal and runPager are replaced in tests
+var (
+	stdoutIsTerminal = func(ctx context.Context) bool {
+		return isTerminalReader(iocontext.GetIO(ctx).Out)
+	}
+	runPager = func(ctx context.Context, pager, text string) error {
+		args := strings.Fields(pager)
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec // The pager is chosen by the user
+		io := iocontext.GetIO(ctx)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = strings.NewReader(text), io.Out, io.ErrOut
+		// Like git: quit when the text fits on one screen and keep colors
+		if _, ok := os.LookupEnv("LESS"); !ok {
+			cmd.Env = append(os.Environ(), "LESS=FRX")
+		}
+		return cmd.Run()
+	}
+)
+
+// pagerCommand returns $PAGER, or less (more on Windows) when it is unset.
+// PAGER set to an empty value or cat turns paging off.
+func pagerCommand() string {
+	pager, ok := os.LookupEnv("PAGER")
+	if !ok {
+		if runtime.GOOS == "windows" {
+			return "more"
+		}
+		return "less"
+	}
+	if pager = strings.TrimSpace(pager); pager == "cat" {
+		return ""
+	}
+	return pager
+}
+
+// page shows long text through the pager when stdout is a terminal and
+// writes it directly otherwise, or when the pager cannot be started
+func page(ctx context.Context, text string) error {
+	io := iocontext.GetIO(ctx)
+	if pager := pagerCommand(); pager != "" && stdoutIsTerminal(ctx) {
+		if err := runPager(ctx, pager, text); err == nil {
+			return nil
+		}
+	}
+	_, err := fmt.Fprint(io.Out, text)
+	return err
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/policy"
+)
+
+// policyPath is the machine policy location. It is replaced in tests.
+var policyPath = policy.DefaultPath
+
+// checkPolicy refuses to run cmd when the machine policy denies it. Help and
+// shell completion always run.
+func checkPolicy(cmd *cobra.Command) error {
+	switch cmd.Name() {
+	case "help", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return nil
+	}
+
+	p, err := policy.Load(policyPath())
+	if err != nil {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Cannot read the command policy for this machine: %v", err),
+			Suggestion: "Ask the machine's administrator to fix the policy file",
+			Cause:      err,
+		}
+	}
+
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	err = p.Check(command)
+	var denied *policy.DeniedError
+	if errors.As(err, &denied) {
+		reason := fmt.Sprintf("it is not in the allow list of %s", denied.Path)
+		if denied.Rule != "" {
+			reason = fmt.Sprintf("it is denied by rule %q in %s", denied.Rule, denied.Path)
+		}
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Policy violation: 'threads %s' is not allowed on this machine", command),
+			Suggestion: fmt.Sprintf("The machine's administrator restricted this command (%s)", reason),
+			Cause:      err,
+		}
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+// usePolicy installs a machine policy with the given JSON content
+func usePolicy(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	orig := policyPath
+	policyPath = func() string { return path }
+	t.Cleanup(func() { policyPath = orig })
+}
+
+func TestPolicy_DeniesCommand(t *testing.T) {
+	usePolicy(t, `{"deny":["* delete"]}`)
+	useTempAuditLog(t)
+
+	f := newTestFactory(t)
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"posts", "delete", "123", "--yes"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+
+	err := ExecuteCommand(cmd, f)
+	if err == nil {
+		t.Fatal("expected a policy violation")
+	}
+	for _, want := range []string{"Policy violation: 'threads posts delete'", `rule "* delete"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
+
+func TestPolicy_AllowList(t *testing.T) {
+	usePolicy(t, `{"allow":["config"]}`)
+
+	f := newTestFactory(t)
+	var out bytes.Buffer
+	f.IO.Out = &out
+
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"config", "path"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+	if err := ExecuteCommand(cmd, f); err != nil {
+		t.Fatalf("config path should be allowed: %v", err)
+	}
+
+	cmd = NewRootCmd(f)
+	cmd.SetArgs([]string{"cache", "info"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+	err := ExecuteCommand(cmd, f)
+	if err == nil || !strings.Contains(err.Error(), "not in the allow list") {
+		t.Errorf("expected cache info to be denied, got %v", err)
+	}
+
+	// Help is always available
+	cmd = NewRootCmd(f)
+	cmd.SetArgs([]string{"help", "cache"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+	if err := ExecuteCommand(cmd, f); err != nil {
+		t.Errorf("help should be allowed: %v", err)
+	}
+}
+
+func TestPolicy_InvalidFileFailsClosed(t *testing.T) {
+	usePolicy(t, `{"deny":`)
+
+	f := newTestFactory(t)
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"config", "path"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+	err := ExecuteCommand(cmd, f)
+	if err == nil || !strings.Contains(err.Error(), "Cannot read the command policy") {
+		t.Errorf("expected an invalid policy to block commands, got %v", err)
+	}
+}
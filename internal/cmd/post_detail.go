@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/auth"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/ui"
+)
+
+// postDetailMetrics are the insights shown by 'posts get', in display order
+var postDetailMetrics = []api.PostInsightMetric{
+	api.PostInsightViews,
+	api.PostInsightLikes,
+	api.PostInsightReplies,
+	api.PostInsightReposts,
+	api.PostInsightQuotes,
+	api.PostInsightShares,
+}
+
+// openBrowser is replaced in tests
+var openBrowser = auth.OpenBrowser
+
+// conversationSampleSize caps the replies fetched for conversation stats
+const conversationSampleSize = 100
+
+// Quote chain depths for 'posts get': the first quoted post is always shown
+// as a preview; --expand quotes follows the chain further.
+const (
+	defaultQuoteDepth = 1
+	expandQuoteDepth  = 3
+	maxQuoteDepth     = 10
+)
+
+// postExpansions are the values accepted by --expand
+var postExpansions = []string{"quotes"}
+
+// postGetOptions holds the flags of 'posts get'
+type postGetOptions struct {
+	Open          bool
+	Expand        []string
+	ExpandDepth   int
+	DownloadMedia string
+}
+
+// quoteDepth returns how many quoted posts to fetch, validating the flags
+func (o *postGetOptions) quoteDepth() (int, error) {
+	depth := defaultQuoteDepth
+	for _, e := range o.Expand {
+		if !slices.Contains(postExpansions, e) {
+			return 0, &UserFriendlyError{
+				Message:    fmt.Sprintf("Invalid --expand value: %s", e),
+				Suggestion: "Valid values: " + strings.Join(postExpansions, ", "),
+			}
+		}
+		depth = o.ExpandDepth
+	}
+	if depth < 1 || depth > maxQuoteDepth {
+		return 0, &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --expand-depth: %d", depth),
+			Suggestion: fmt.Sprintf("Use a depth between 1 and %d", maxQuoteDepth),
+		}
+	}
+	return depth, nil
+}
+
+// postDetail is everything 'posts get' shows about a post. Metrics and
+// conversation stats are best-effort: insights need the
+// threads_manage_insights scope and only cover your own posts, so a failure
+// is recorded instead of failing the command.
+type postDetail struct {
+	*api.Post
+	Metrics           map[string]int     `json:"metrics,omitempty"`
+	MetricsError      string             `json:"metrics_error,omitempty"`
+	Conversation      *conversationStats `json:"conversation,omitempty"`
+	ConversationError string             `json:"conversation_error,omitempty"`
+}
+
+// conversationStats summarises the replies under a post
+type conversationStats struct {
+	Replies      int  `json:"replies"`
+	Participants int  `json:"participants"`
+	Hidden       int  `json:"hidden"`
+	Truncated    bool `json:"truncated"`
+}
+
+func runPostsGet(cmd *cobra.Command, f *Factory, postID string, opts *postGetOptions) error {
+	ctx := cmd.Context()
+	depth, err := opts.quoteDepth()
+	if err != nil {
+		return err
+	}
+
+	client, err := f.Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	detail, err := loadPostDetail(ctx, client, api.PostID(postID), depth)
+	if err != nil {
+		return WrapError("failed to get post", err)
+	}
+
+	if opts.Open {
+		if detail.Permalink == "" {
+			return &UserFriendlyError{Message: "Post has no permalink to open"}
+		}
+		if err := openBrowser(detail.Permalink); err != nil {
+			f.UI(ctx).Warning("Could not open browser: %v", err)
+		}
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSONContext(ctx, io.Out, detail); err != nil {
+			return err
+		}
+	} else {
+		printPostDetail(ctx, f, detail)
+	}
+
+	if opts.DownloadMedia != "" {
+		return downloadPostMedia(ctx, client, opts.DownloadMedia, []api.Post{*detail.Post})
+	}
+	return nil
+}
+
+// loadPostDetail fetches a post along with quoteDepth levels of quoted
+// posts, metrics and conversation stats. Only the post itself is required.
+func loadPostDetail(ctx context.Context, client api.API, postID api.PostID, quoteDepth int) (*postDetail, error) {
+	post, err := client.GetPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	detail := &postDetail{Post: post}
+
+	expandQuotes(ctx, client, post, quoteDepth)
+
+	metrics := make([]string, len(postDetailMetrics))
+	for i, m := range postDetailMetrics {
+		metrics[i] = string(m)
+	}
+	if insights, err := client.GetPostInsights(ctx, postID, metrics); err != nil {
+		detail.MetricsError = shortErrorMessage(err)
+	} else {
+		detail.Metrics = make(map[string]int, len(insights.Data))
+		for _, insight := range insights.Data {
+			detail.Metrics[insight.Name] = insightValue(insight)
+		}
+	}
+
+	if replies, err := client.GetConversation(ctx, postID, &api.RepliesOptions{Limit: conversationSampleSize}); err != nil {
+		detail.ConversationError = shortErrorMessage(err)
+	} else {
+		detail.Conversation = summarizeConversation(replies)
+	}
+
+	return detail, ctx.Err()
+}
+
+// expandQuotes replaces the quoted-post references in the chain starting at
+// post with the full posts, up to depth levels. The API only returns the ID
+// of a quoted post. Expansion stops at the first post that cannot be fetched
+// and on cycles, leaving the reference in place.
+func expandQuotes(ctx context.Context, client api.API, post *api.Post, depth int) {
+	seen := map[string]bool{post.ID: true}
+	for cur := post; depth > 0; depth-- {
+		ref := cur.QuotedPost
+		if ref == nil || ref.ID == "" || seen[ref.ID] {
+			return
+		}
+		seen[ref.ID] = true
+
+		full, err := client.GetPost(ctx, api.PostID(ref.ID))
+		if err != nil {
+			return
+		}
+		cur.QuotedPost = full
+		cur = full
+	}
+}
+
+func summarizeConversation(replies *api.RepliesResponse) *conversationStats {
+	stats := &conversationStats{
+		Replies:   len(replies.Data),
+		Truncated: replies.NextCursor() != "",
+	}
+	people := make(map[string]bool)
+	for _, reply := range replies.Data {
+		if reply.Username != "" {
+			people[reply.Username] = true
+		}
+		if reply.HideStatus == "HIDDEN" {
+			stats.Hidden++
+		}
+	}
+	stats.Participants = len(people)
+	return stats
+}
+
+// shortErrorMessage returns the one-line message of err, without suggestions
+func shortErrorMessage(err error) string {
+	var ufErr *UserFriendlyError
+	if formatted := FormatError(err); errors.As(formatted, &ufErr) {
+		return ufErr.Message
+	}
+	return err.Error()
+}
+
+func printPostDetail(ctx context.Context, f *Factory, d *postDetail) {
+	io := iocontext.GetIO(ctx)
+	p := f.UI(ctx)
+
+	header := "@" + d.Username
+	if !d.Timestamp.IsZero() {
+		header += p.Dim(fmt.Sprintf("  %s (%s)", d.Timestamp.Format("2006-01-02 15:04"), ui.FormatRelativeTime(d.Timestamp.Time)))
+	}
+	fmt.Fprintln(io.Out, header) //nolint:errcheck // Best-effort output
+	if d.Text != "" {
+		fmt.Fprintf(io.Out, "\n%s\n", indent(d.Text, "  ")) //nolint:errcheck // Best-effort output
+	}
+	fmt.Fprintln(io.Out) //nolint:errcheck // Best-effort output
+
+	w := tabwriter.NewWriter(io.Out, 0, 0, 2, ' ', 0)
+	row := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(w, "  %s:\t%s\n", label, value) //nolint:errcheck // Best-effort output
+		}
+	}
+	row("ID", d.ID)
+	row("Type", postTypeLabel(d.Post))
+	row("Media", fallback(d.MediaURL, d.GifURL))
+	row("Thumbnail", d.ThumbnailURL)
+	row("Alt text", d.AltText)
+	row("Link", d.LinkAttachmentURL)
+	if d.TopicTag != "" {
+		row("Topic", "#"+d.TopicTag)
+	}
+	row("Poll", formatPoll(d.PollAttachment))
+	row("Who can reply", d.ReplyAudience)
+	if d.IsReply {
+		row("Reply to", d.ReplyTo)
+	}
+	row("Hidden", d.HideStatus)
+	row("Permalink", d.Permalink)
+	w.Flush() //nolint:errcheck,gosec // Best-effort flush
+
+	if d.QuotedPost != nil {
+		fmt.Fprintf(io.Out, "\n%s\n", p.Bold("Quoted post")) //nolint:errcheck // Best-effort output
+		for level, q := 0, d.QuotedPost; q != nil; level, q = level+1, q.QuotedPost {
+			prefix := "  "
+			if level > 0 {
+				prefix += strings.Repeat("  ", level-1) + "↳ "
+			}
+			fmt.Fprintf(io.Out, "%s%s\n", prefix, quotedPostLine(q)) //nolint:errcheck // Best-effort output
+		}
+	}
+
+	fmt.Fprintf(io.Out, "\n%s\n", p.Bold("Metrics")) //nolint:errcheck // Best-effort output
+	if d.Metrics == nil {
+		fmt.Fprintf(io.Out, "  %s\n", p.Dim("unavailable: "+d.MetricsError)) //nolint:errcheck // Best-effort output
+	} else {
+		var parts []string
+		for _, m := range postDetailMetrics {
+			if value, ok := d.Metrics[string(m)]; ok {
+				parts = append(parts, fmt.Sprintf("%s %d", m, value))
+			}
+		}
+		fmt.Fprintf(io.Out, "  %s\n", strings.Join(parts, "  ")) //nolint:errcheck // Best-effort output
+	}
+
+	fmt.Fprintf(io.Out, "\n%s\n", p.Bold("Conversation")) //nolint:errcheck // Best-effort output
+	if c := d.Conversation; c == nil {
+		fmt.Fprintf(io.Out, "  %s\n", p.Dim("unavailable: "+d.ConversationError)) //nolint:errcheck // Best-effort output
+	} else {
+		replies := fmt.Sprintf("%d", c.Replies)
+		if c.Truncated {
+			replies += "+"
+		}
+		fmt.Fprintf(io.Out, "  %s replies from %d people, %d hidden\n", replies, c.Participants, c.Hidden) //nolint:errcheck // Best-effort output
+	}
+}
+
+// quotedPostLine summarises a quoted post; an unexpanded reference shows only its ID
+func quotedPostLine(q *api.Post) string {
+	if q.Username == "" && q.Text == "" {
+		return q.ID + " (not expanded)"
+	}
+	line := "@" + q.Username
+	if q.Text != "" {
+		line += ": " + truncateText(strings.ReplaceAll(q.Text, "\n", " "), 80)
+	}
+	return line
+}
+
+// postTypeLabel describes the media type, including carousel size
+func postTypeLabel(post *api.Post) string {
+	label := post.MediaType
+	if post.Children != nil && len(post.Children.Data) > 0 {
+		label += fmt.Sprintf(" (%d items)", len(post.Children.Data))
+	}
+	if post.IsQuotePost {
+		label += ", quote"
+	}
+	return label
+}
+
+func formatPoll(poll *api.PollResult) string {
+	if poll == nil {
+		return ""
+	}
+	options := []struct {
+		text    string
+		percent float64
+	}{
+		{poll.OptionA, poll.OptionAVotesPercentage},
+		{poll.OptionB, poll.OptionBVotesPercentage},
+		{poll.OptionC, poll.OptionCVotesPercentage},
+		{poll.OptionD, poll.OptionDVotesPercentage},
+	}
+	var parts []string
+	for _, o := range options {
+		if o.text != "" {
+			parts = append(parts, fmt.Sprintf("%s %.0f%%", o.text, o.percent))
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+func indent(text, prefix string) string {
+	return prefix + strings.ReplaceAll(text, "\n", "\n"+prefix)
+}
+
+func truncateText(text string, maxLen int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLen {
+		return text
+	}
+	return string(runes[:maxLen-3]) + "..."
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+func newPostDetailMock() *mockAPI {
+	return &mockAPI{
+		getPost: func(_ context.Context, postID api.PostID) (*api.Post, error) {
+			if postID == "555" {
+				return &api.Post{ID: "555", Username: "quoted", Text: "the original"}, nil
+			}
+			return &api.Post{
+				ID:            string(postID),
+				Username:      "mockuser",
+				Text:          "hello\nworld",
+				MediaType:     "CAROUSEL_ALBUM",
+				Children:      &api.ChildrenData{Data: []api.ChildPost{{ID: "1"}, {ID: "2"}}},
+				Permalink:     "https://www.threads.net/@mockuser/post/abc",
+				ReplyAudience: "EVERYONE",
+				IsQuotePost:   true,
+				QuotedPost:    &api.Post{ID: "555"},
+			}, nil
+		},
+		getPostInsights: func(context.Context, api.PostID, []string) (*api.InsightsResponse, error) {
+			return &api.InsightsResponse{Data: []api.Insight{
+				{Name: "views", Values: []api.Value{{Value: 120}}},
+				{Name: "likes", TotalValue: &api.TotalValue{Value: 4}},
+			}}, nil
+		},
+		getConversation: func(context.Context, api.PostID, *api.RepliesOptions) (*api.RepliesResponse, error) {
+			return &api.RepliesResponse{
+				Data: []api.Post{
+					{ID: "r1", Username: "a"},
+					{ID: "r2", Username: "b", HideStatus: "HIDDEN"},
+					{ID: "r3", Username: "a"},
+				},
+				Paging: api.Paging{Cursors: &api.PagingCursors{After: "more"}},
+			}, nil
+		},
+	}
+}
+
+func TestPostsGet_DetailText(t *testing.T) {
+	f, io := newMockAPITestFactory(t, newPostDetailMock())
+
+	cmd := newPostsGetCmd(f)
+	cmd.SetArgs([]string{"999"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	output := io.Out.(*bytes.Buffer).String()
+	for _, want := range []string{
+		"@mockuser",
+		"  hello\n  world",
+		"CAROUSEL_ALBUM (2 items), quote",
+		"EVERYONE",
+		"https://www.threads.net/@mockuser/post/abc",
+		"@quoted: the original",
+		"views 120  likes 4",
+		"3+ replies from 2 people, 1 hidden",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestPostsGet_DetailMetricsUnavailable(t *testing.T) {
+	mock := newPostDetailMock()
+	mock.getPostInsights = nil
+	f, io := newMockAPITestFactory(t, mock)
+
+	cmd := newPostsGetCmd(f)
+	cmd.SetArgs([]string{"999"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("metrics failures should not fail the command: %v", err)
+	}
+	if output := io.Out.(*bytes.Buffer).String(); !strings.Contains(output, "unavailable: not mocked") {
+		t.Errorf("expected metrics to be reported unavailable:\n%s", output)
+	}
+}
+
+func TestPostsGet_DetailJSON(t *testing.T) {
+	f, io := newMockAPITestFactory(t, newPostDetailMock())
+
+	cmd := newPostsGetCmd(f)
+	cmd.SetArgs([]string{"999"})
+	cmd.SetContext(outfmt.NewContext(iocontext.WithIO(context.Background(), io), outfmt.JSON))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got["id"] != "999" || got["reply_audience"] != "EVERYONE" {
+		t.Errorf("expected post fields at the top level, got %v", got)
+	}
+	if metrics, _ := got["metrics"].(map[string]any); metrics["views"] != float64(120) {
+		t.Errorf("expected metrics.views=120, got %v", got["metrics"])
+	}
+	if conv, _ := got["conversation"].(map[string]any); conv["participants"] != float64(2) || conv["truncated"] != true {
+		t.Errorf("unexpected conversation stats %v", got["conversation"])
+	}
+	if quoted, _ := got["quoted_post"].(map[string]any); quoted["text"] != "the original" {
+		t.Errorf("expected the quoted post to be expanded, got %v", got["quoted_post"])
+	}
+}
+
+func TestPostsGet_Open(t *testing.T) {
+	var opened string
+	orig := openBrowser
+	openBrowser = func(url string) error {
+		opened = url
+		return nil
+	}
+	t.Cleanup(func() { openBrowser = orig })
+
+	f, io := newMockAPITestFactory(t, newPostDetailMock())
+
+	cmd := newPostsGetCmd(f)
+	cmd.SetArgs([]string{"999", "--open"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if opened != "https://www.threads.net/@mockuser/post/abc" {
+		t.Errorf("expected the permalink to be opened, got %q", opened)
+	}
+}
+
+// quoteChainMock serves posts 1 -> 2 -> 3 -> 4, each quoting the next
+func quoteChainMock(calls *[]api.PostID) *mockAPI {
+	return &mockAPI{
+		getPost: func(_ context.Context, postID api.PostID) (*api.Post, error) {
+			*calls = append(*calls, postID)
+			post := &api.Post{ID: string(postID), Username: "user" + string(postID), Text: "post " + string(postID)}
+			if postID < "4" {
+				post.QuotedPost = &api.Post{ID: string(postID[0] + 1)}
+			}
+			return post, nil
+		},
+	}
+}
+
+func TestExpandQuotes_DepthLimited(t *testing.T) {
+	var calls []api.PostID
+	client := quoteChainMock(&calls)
+
+	post, _ := client.GetPost(context.Background(), "1")
+	expandQuotes(context.Background(), client, post, 2)
+
+	if post.QuotedPost.Text != "post 2" || post.QuotedPost.QuotedPost.Text != "post 3" {
+		t.Fatalf("expected two levels to be expanded, got %+v", post.QuotedPost)
+	}
+	if ref := post.QuotedPost.QuotedPost.QuotedPost; ref == nil || ref.ID != "4" || ref.Text != "" {
+		t.Errorf("expected post 4 to stay a reference, got %+v", ref)
+	}
+	if len(calls) != 3 {
+		t.Errorf("expected 3 GetPost calls, got %v", calls)
+	}
+}
+
+func TestExpandQuotes_StopsOnCycle(t *testing.T) {
+	calls := 0
+	client := &mockAPI{
+		getPost: func(_ context.Context, postID api.PostID) (*api.Post, error) {
+			calls++
+			next := "b"
+			if postID == "b" {
+				next = "a"
+			}
+			return &api.Post{ID: string(postID), Text: "x", QuotedPost: &api.Post{ID: next}}, nil
+		},
+	}
+
+	post := &api.Post{ID: "a", QuotedPost: &api.Post{ID: "b"}}
+	expandQuotes(context.Background(), client, post, 10)
+
+	if calls != 1 {
+		t.Errorf("expected the cycle back to a to stop expansion after 1 call, got %d", calls)
+	}
+}
+
+func TestPostsGet_ExpandQuotes(t *testing.T) {
+	var calls []api.PostID
+	f, io := newMockAPITestFactory(t, quoteChainMock(&calls))
+
+	cmd := newPostsGetCmd(f)
+	cmd.SetArgs([]string{"1", "--expand", "quotes"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	output := io.Out.(*bytes.Buffer).String()
+	for _, want := range []string{"  @user2: post 2", "  ↳ @user3: post 3", "    ↳ @user4: post 4"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestPostsGet_QuotePreviewOnlyByDefault(t *testing.T) {
+	var calls []api.PostID
+	f, io := newMockAPITestFactory(t, quoteChainMock(&calls))
+
+	cmd := newPostsGetCmd(f)
+	cmd.SetArgs([]string{"1"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	output := io.Out.(*bytes.Buffer).String()
+	if !strings.Contains(output, "@user2: post 2") || !strings.Contains(output, "↳ 3 (not expanded)") {
+		t.Errorf("expected a one-level preview:\n%s", output)
+	}
+}
+
+func TestPostsGet_ExpandValidation(t *testing.T) {
+	tests := map[string][]string{
+		"unknown expansion": {"1", "--expand", "replies"},
+		"depth too large":   {"1", "--expand", "quotes", "--expand-depth", "11"},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls []api.PostID
+			f, io := newMockAPITestFactory(t, quoteChainMock(&calls))
+
+			cmd := newPostsGetCmd(f)
+			cmd.SetArgs(args)
+			cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+			if err := cmd.Execute(); err == nil {
+				t.Error("expected a validation error")
+			}
+			if len(calls) != 0 {
+				t.Errorf("expected no API calls, got %v", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+// maxStdinTextBytes bounds what --stdin reads; posts are far shorter
+const maxStdinTextBytes = 1 << 20
+
+// readStdinText reads post text from standard input, dropping the trailing
+// newline that echo and most pipelines add. Windows line endings become
+// plain newlines, so text piped from PowerShell posts without stray \r.
+func readStdinText(ctx context.Context) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(iocontext.GetIO(ctx).In, maxStdinTextBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read text from stdin: %w", err)
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	return strings.TrimRight(text, "\r\n"), nil
+}
+
+// parseTemplateVars parses repeated --var key=value flags
+func parseTemplateVars(vars []string) (map[string]string, error) {
+	values := make(map[string]string, len(vars))
+	for _, v := range vars {
+		key, value, ok := strings.Cut(v, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, &UserFriendlyError{
+				Message:    fmt.Sprintf("Invalid --var: %s", v),
+				Suggestion: "Use --var name=value, e.g. --var version=1.2.3",
+			}
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// renderPostText substitutes {{.name}} placeholders in text with --var
+// values. Without vars the text is returned unchanged, so literal braces in
+// ordinary posts are never interpreted. A placeholder without a value is an
+// error rather than being posted as "<no value>".
+func renderPostText(text string, vars []string) (string, error) {
+	if len(vars) == 0 {
+		return text, nil
+	}
+	values, err := parseTemplateVars(vars)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New("text").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid template in post text: %v", err),
+			Suggestion: "Placeholders look like {{.name}}; check for unbalanced braces",
+			Cause:      err,
+		}
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, values); err != nil {
+		return "", &UserFriendlyError{
+			Message:    fmt.Sprintf("Post text uses a variable that was not set: %v", err),
+			Suggestion: "Pass every placeholder with --var name=value",
+			Cause:      err,
+		}
+	}
+	return out.String(), nil
+}
+
+// stdinIsTerminal and runEditor are replaced in tests
+var (
+	stdinIsTerminal = func(ctx context.Context) bool {
+		return isTerminalReader(iocontext.GetIO(ctx).In)
+	}
+	runEditor = func(ctx context.Context, editor, path string) error {
+		args := strings.Fields(editor)
+		cmd := exec.CommandContext(ctx, args[0], append(args[1:], path)...) //nolint:gosec // The editor is chosen by the user
+		io := iocontext.GetIO(ctx)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = io.In, io.Out, io.ErrOut
+		return cmd.Run()
+	}
+)
+
+// editorCommand returns the user's editor: $VISUAL, then $EDITOR, then vi
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+// postEditorContext is shown as comments in the editor template
+type postEditorContext struct {
+	Account string
+	ReplyTo string
+}
+
+// editPostText opens the user's editor on a temporary file, like git commit,
+// and returns the saved text without comment lines. Empty text aborts.
+func editPostText(ctx context.Context, info postEditorContext) (string, error) {
+	file, err := os.CreateTemp("", "threads-post-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name()) //nolint:errcheck // Best-effort cleanup
+
+	_, err = file.WriteString(postEditorTemplate(info))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	editor := editorCommand()
+	if err := runEditor(ctx, editor, file.Name()); err != nil {
+		return "", &UserFriendlyError{
+			Message:    fmt.Sprintf("Editor %q failed: %v", editor, err),
+			Suggestion: "Set $VISUAL or $EDITOR to your editor, or pass the text with --text or --stdin",
+			Cause:      err,
+		}
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	text := stripEditorComments(string(data))
+	if text == "" {
+		return "", &UserFriendlyError{Message: "Aborting post due to empty text"}
+	}
+	return text, nil
+}
+
+func postEditorTemplate(info postEditorContext) string {
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString("# Write your post above. Lines starting with '#' are ignored;\n")
+	b.WriteString("# an empty post aborts.\n")
+	b.WriteString("#\n")
+	if info.Account != "" {
+		fmt.Fprintf(&b, "# Account:  %s\n", info.Account)
+	}
+	if info.ReplyTo != "" {
+		fmt.Fprintf(&b, "# Reply to: %s\n", info.ReplyTo)
+	}
+	fmt.Fprintf(&b, "# Limit:    %d characters\n", api.MaxTextLength)
+	return b.String()
+}
+
+// stripEditorComments drops '#' lines and surrounding blank lines
+func stripEditorComments(text string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// signatureOptions are the per-command overrides of the configured signature
+type signatureOptions struct {
+	Signature   string
+	NoSignature bool
+}
+
+func addSignatureFlags(cmd *cobra.Command, opts *signatureOptions) {
+	cmd.Flags().StringVar(&opts.Signature, "signature", "", "Footer to append instead of the configured signature")
+	cmd.Flags().BoolVar(&opts.NoSignature, "no-signature", false, "Do not append the configured signature")
+	cmd.MarkFlagsMutuallyExclusive("signature", "no-signature")
+}
+
+// applySignature appends the configured signature, or the --signature
+// override, to text after a blank line. {version} in the signature is replaced
+// by the CLI version. The signature counts against the text limit, so text
+// that only fits without it is an error rather than a surprise from the API.
+func applySignature(cmd *cobra.Command, f *Factory, opts *signatureOptions, text string) (string, error) {
+	signature := f.Config.Signature
+	switch {
+	case opts.NoSignature:
+		signature = ""
+	case cmd.Flags().Changed("signature"):
+		signature = opts.Signature
+	}
+	signature = strings.TrimSpace(strings.ReplaceAll(signature, "{version}", Version))
+	if signature == "" {
+		return text, nil
+	}
+
+	signed := signature
+	if text != "" {
+		signed = text + "\n\n" + signature
+	}
+	if len(signed) > api.MaxTextLength {
+		return "", &UserFriendlyError{
+			Message:    fmt.Sprintf("Text is %d characters with the signature, over the %d character limit", len(signed), api.MaxTextLength),
+			Suggestion: "Shorten the text, or skip the signature with --no-signature",
+		}
+	}
+	return signed, nil
+}
@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+func TestRenderPostText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		vars []string
+		want string
+	}{
+		{"no vars keeps braces", "literal {{.x}}", nil, "literal {{.x}}"},
+		{"substitutes", "deployed {{.version}} to {{.env}}", []string{"version=1.2.3", "env=prod"}, "deployed 1.2.3 to prod"},
+		{"value with equals", "{{.q}}", []string{"q=a=b"}, "a=b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderPostText(tt.text, tt.vars)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderPostText_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		vars []string
+		want string
+	}{
+		{"missing var", "deployed {{.version}}", []string{"env=prod"}, "variable that was not set"},
+		{"bad var", "x", []string{"noequals"}, "Invalid --var"},
+		{"bad template", "{{.version", []string{"version=1"}, "Invalid template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := renderPostText(tt.text, tt.vars)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// newCreatePostServer serves the container and publish flow of a text post
+// and records the posted text
+func newCreatePostServer(t *testing.T, posted *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "refresh_access_token"):
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/threads"):
+			_ = r.ParseForm()
+			*posted = r.Form.Get("text")
+			_, _ = w.Write([]byte(`{"id":"c1"}`))
+		case strings.HasSuffix(r.URL.Path, "/c1"):
+			_, _ = w.Write([]byte(`{"id":"c1","status":"FINISHED"}`))
+		case strings.Contains(r.URL.Path, "threads_publish"):
+			_, _ = w.Write([]byte(`{"id":"p1"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"p1","text":"posted"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPostsCreate_StdinWithVars(t *testing.T) {
+	var posted string
+	server := newCreatePostServer(t, &posted)
+
+	f, io := newIntegrationTestFactory(t, server.URL)
+	io.In = strings.NewReader("deployed {{.version}}\n")
+
+	cmd := newPostsCreateCmd(f)
+	cmd.SetArgs([]string{"--stdin", "--var", "version=1.2.3", "--reply-control", "everyone"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if posted != "deployed 1.2.3" {
+		t.Errorf("posted text = %q, want %q", posted, "deployed 1.2.3")
+	}
+	if out := io.Out.(*bytes.Buffer).String(); !strings.Contains(out, "p1") {
+		t.Errorf("expected post ID in output, got %q", out)
+	}
+}
+
+func TestReadStdinText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"unix", "line one\nline two\n", "line one\nline two"},
+		{"windows", "line one\r\nline two\r\n", "line one\nline two"},
+		{"no newline", "hello", "hello"},
+		{"blank lines kept", "a\r\n\r\nb\r\n", "a\n\nb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := iocontext.WithIO(context.Background(), &iocontext.IO{In: strings.NewReader(tt.input)})
+			got, err := readStdinText(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("readStdinText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostsCreate_StdinAndTextConflict(t *testing.T) {
+	f := newTestFactory(t)
+
+	cmd := newPostsCreateCmd(f)
+	cmd.SetArgs([]string{"--stdin", "--text", "hi"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected --stdin and --text to be mutually exclusive")
+	}
+}
+
+// useFakeEditor makes stdin look like a terminal and replaces the editor with
+// edit, which receives the temp file's template and returns the saved content
+func useFakeEditor(t *testing.T, edit func(template string) string) {
+	t.Helper()
+	origTerminal, origEditor := stdinIsTerminal, runEditor
+	stdinIsTerminal = func(context.Context) bool { return true }
+	runEditor = func(_ context.Context, _, path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, []byte(edit(string(data))), 0o600)
+	}
+	t.Cleanup(func() { stdinIsTerminal, runEditor = origTerminal, origEditor })
+}
+
+func TestPostsCreate_Editor(t *testing.T) {
+	var template string
+	useFakeEditor(t, func(tmpl string) string {
+		template = tmpl
+		return "Hello from the editor\n" + tmpl
+	})
+	var posted string
+	server := newCreatePostServer(t, &posted)
+
+	f, io := newIntegrationTestFactory(t, server.URL)
+	cmd := newPostsCreateCmd(f)
+	cmd.SetArgs([]string{"--reply-to", "777"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if posted != "Hello from the editor" {
+		t.Errorf("posted text = %q", posted)
+	}
+	for _, want := range []string{"# Account:  test-user", "# Reply to: 777", "# Limit:    500 characters"} {
+		if !strings.Contains(template, want) {
+			t.Errorf("template missing %q:\n%s", want, template)
+		}
+	}
+}
+
+func TestPostsCreate_EditorEmptyAborts(t *testing.T) {
+	useFakeEditor(t, func(tmpl string) string { return tmpl })
+	f := newTestFactory(t)
+
+	cmd := newPostsCreateCmd(f)
+	cmd.SetArgs(nil)
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+
+	err := cmd.Execute()
+	var ufErr *UserFriendlyError
+	if !errors.As(err, &ufErr) || ufErr.Message != "Aborting post due to empty text" {
+		t.Errorf("expected empty text abort, got %v", err)
+	}
+}
+
+func TestPostsCreate_EditorTooLong(t *testing.T) {
+	useFakeEditor(t, func(string) string { return strings.Repeat("a", 501) })
+	f := newTestFactory(t)
+
+	cmd := newPostsCreateCmd(f)
+	cmd.SetArgs(nil)
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "500 characters") {
+		t.Errorf("expected a length error, got %v", err)
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	if got := editorCommand(); got != "vi" {
+		t.Errorf("default editor = %q", got)
+	}
+	t.Setenv("EDITOR", "nano")
+	if got := editorCommand(); got != "nano" {
+		t.Errorf("EDITOR = %q", got)
+	}
+	t.Setenv("VISUAL", "code --wait")
+	if got := editorCommand(); got != "code --wait" {
+		t.Errorf("VISUAL should win, got %q", got)
+	}
+}
+
+func TestApplySignature(t *testing.T) {
+	origVersion := Version
+	Version = "1.2.3"
+	t.Cleanup(func() { Version = origVersion })
+
+	tests := []struct {
+		name       string
+		configured string
+		args       []string
+		text       string
+		want       string
+		wantErr    bool
+	}{
+		{name: "no signature", text: "hi", want: "hi"},
+		{name: "configured", configured: "posted via threads-cli v{version}", text: "hi", want: "hi\n\nposted via threads-cli v1.2.3"},
+		{name: "override", configured: "default sig", args: []string{"--signature", "— Ana"}, text: "hi", want: "hi\n\n— Ana"},
+		{name: "disabled", configured: "default sig", args: []string{"--no-signature"}, text: "hi", want: "hi"},
+		{name: "empty text", configured: "sig", text: "", want: "sig"},
+		{name: "too long with signature", configured: "sig", text: strings.Repeat("a", 499), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestFactory(t)
+			f.Config.Signature = tt.configured
+
+			var opts signatureOptions
+			cmd := &cobra.Command{Use: "test"}
+			addSignatureFlags(cmd, &opts)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := applySignature(cmd, f, &opts, tt.text)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "with the signature") {
+					t.Fatalf("expected a length error, got %q, %v", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostsCreate_AppendsSignature(t *testing.T) {
+	var posted string
+	server := newCreatePostServer(t, &posted)
+
+	f, io := newIntegrationTestFactory(t, server.URL)
+	f.Config.Signature = "#automated"
+
+	cmd := newPostsCreateCmd(f)
+	cmd.SetArgs([]string{"--text", "deploy finished"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if want := "deploy finished\n\n#automated"; posted != want {
+		t.Errorf("posted text = %q, want %q", posted, want)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/events"
 	"github.com/salmonumbrella/threads-cli/internal/iocontext"
 	"github.com/salmonumbrella/threads-cli/internal/outfmt"
 	"github.com/salmonumbrella/threads-cli/internal/ui"
@@ -18,6 +20,10 @@ const (
 	// containerPollingInterval is the time between status checks when waiting
 	// for media container processing to complete.
 	containerPollingInterval = 2 * time.Second
+
+	// defaultContainerTimeoutSecs is how long commands wait for media
+	// container processing unless --timeout is given.
+	defaultContainerTimeoutSecs = 300
 )
 
 // NewPostsCmd builds the posts command group.
@@ -37,6 +43,8 @@ func NewPostsCmd(f *Factory) *cobra.Command {
 	cmd.AddCommand(newPostsRepostCmd(f))
 	cmd.AddCommand(newPostsUnrepostCmd(f))
 	cmd.AddCommand(newPostsGhostListCmd(f))
+	cmd.AddCommand(newPostsQRCmd(f))
+	cmd.AddCommand(newPostsArchiveCmd(f))
 
 	return cmd
 }
@@ -53,14 +61,23 @@ type postsCreateOptions struct {
 	Location     string
 	ReplyControl string
 	GIF          string
+	TimeoutSecs  int
+	Stdin        bool
+	Vars         []string
+	Schedule     string
+	UTC          bool
+	signatureOptions
 }
 
 func newPostsCreateCmd(f *Factory) *cobra.Command {
-	opts := &postsCreateOptions{}
+	opts := &postsCreateOptions{
+		TimeoutSecs: defaultContainerTimeoutSecs,
+	}
 
 	cmd := &cobra.Command{
-		Use:   "create",
-		Short: "Create a new post",
+		Use:         "create",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Create a new post",
 		Long: `Create a new post on Threads.
 
 Supports text, image, and video posts. For carousel posts, use 'threads posts carousel'.
@@ -91,7 +108,17 @@ Examples:
   threads posts create --text "Followers only discussion" --reply-control accounts_you_follow
 
   # Create a post with a GIF
-  threads posts create --text "This is hilarious" --gif TENOR_GIF_ID`,
+  threads posts create --text "This is hilarious" --gif TENOR_GIF_ID
+
+  # Write the text in $VISUAL or $EDITOR (when --text is omitted in a terminal)
+  threads posts create
+
+  # Read the text from a pipeline and fill in {{.name}} placeholders
+  echo "deployed {{.version}}" | threads posts create --stdin --var version=1.2.3
+
+  # Publish later from the local queue (see 'threads queue')
+  threads posts create --text "Good morning!" --schedule "2025-07-01 09:00"
+  threads posts create --text "Launch day" --schedule "tomorrow 9am" --utc`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPostsCreate(cmd, f, opts)
 		},
@@ -108,6 +135,13 @@ Examples:
 	cmd.Flags().StringVar(&opts.Location, "location", "", "Attach a location ID to the post (use 'threads locations search' to find IDs)")
 	cmd.Flags().StringVar(&opts.ReplyControl, "reply-control", "", "Control who can reply: everyone, accounts_you_follow, mentioned_only")
 	cmd.Flags().StringVar(&opts.GIF, "gif", "", "Attach a GIF using a Tenor GIF ID (text-only posts)")
+	cmd.Flags().IntVar(&opts.TimeoutSecs, "timeout", defaultContainerTimeoutSecs, "Timeout in seconds for container processing")
+	cmd.Flags().BoolVar(&opts.Stdin, "stdin", false, "Read the post text from standard input")
+	cmd.Flags().StringArrayVar(&opts.Vars, "var", nil, "Replace {{.name}} in the text with value (name=value, repeatable)")
+	cmd.Flags().StringVar(&opts.Schedule, "schedule", "", "Queue the post to be published at a time (\"2025-07-01 09:00\", \"tomorrow 9am\", 2h)")
+	addUTCFlag(cmd, &opts.UTC)
+	cmd.MarkFlagsMutuallyExclusive("text", "stdin")
+	addSignatureFlags(cmd, &opts.signatureOptions)
 
 	return cmd
 }
@@ -115,6 +149,43 @@ Examples:
 func runPostsCreate(cmd *cobra.Command, f *Factory, opts *postsCreateOptions) error {
 	ctx := cmd.Context()
 
+	// The schedule is checked before the text is written in the editor
+	var scheduleAt time.Time
+	var scheduleLoc *time.Location
+	if opts.Schedule != "" {
+		var err error
+		if scheduleAt, scheduleLoc, err = parseScheduleFlag(f, opts.UTC, "schedule", opts.Schedule); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case opts.Stdin:
+		text, err := readStdinText(ctx)
+		if err != nil {
+			return err
+		}
+		opts.Text = text
+	case opts.Text == "" && opts.ImageURL == "" && opts.VideoURL == "" && stdinIsTerminal(ctx) && !outfmt.GetStrict(ctx):
+		text, err := editPostText(ctx, postEditorContext{Account: f.currentAccountName(), ReplyTo: opts.ReplyTo})
+		if err != nil {
+			return err
+		}
+		validator := api.NewValidator()
+		if err := validator.ValidateTextLength(text, "Text"); err != nil {
+			return FormatError(err)
+		}
+		if err := validator.ValidateLinkCount(text, ""); err != nil {
+			return FormatError(err)
+		}
+		opts.Text = text
+	}
+	text, err := renderPostText(opts.Text, opts.Vars)
+	if err != nil {
+		return err
+	}
+	opts.Text = text
+
 	hasImage := opts.ImageURL != ""
 	hasVideo := opts.VideoURL != ""
 	hasText := opts.Text != ""
@@ -156,6 +227,14 @@ func runPostsCreate(cmd *cobra.Command, f *Factory, opts *postsCreateOptions) er
 		}
 	}
 
+	opts.Text, err = applySignature(cmd, f, &opts.signatureOptions, opts.Text)
+	if err != nil {
+		return err
+	}
+	if err := checkAccountRules(f, opts.Text); err != nil {
+		return err
+	}
+
 	var replyControl api.ReplyControl
 	if opts.ReplyControl != "" {
 		switch opts.ReplyControl {
@@ -203,16 +282,20 @@ func runPostsCreate(cmd *cobra.Command, f *Factory, opts *postsCreateOptions) er
 		}
 	}
 
-	client, err := f.Client(ctx)
-	if err != nil {
-		return err
+	if hasImage || hasVideo {
+		missing := 0
+		if opts.AltText == "" {
+			missing = 1
+		}
+		if err := checkAltText(ctx, missing, 1); err != nil {
+			return err
+		}
 	}
 
-	var post *api.Post
-
+	var content any
 	switch {
 	case hasImage:
-		content := &api.ImagePostContent{
+		content = &api.ImagePostContent{
 			Text:         opts.Text,
 			ImageURL:     opts.ImageURL,
 			AltText:      opts.AltText,
@@ -221,9 +304,8 @@ func runPostsCreate(cmd *cobra.Command, f *Factory, opts *postsCreateOptions) er
 			TopicTag:     opts.Topic,
 			LocationID:   opts.Location,
 		}
-		post, err = client.CreateImagePost(ctx, content)
 	case hasVideo:
-		content := &api.VideoPostContent{
+		content = &api.VideoPostContent{
 			Text:         opts.Text,
 			VideoURL:     opts.VideoURL,
 			AltText:      opts.AltText,
@@ -232,9 +314,8 @@ func runPostsCreate(cmd *cobra.Command, f *Factory, opts *postsCreateOptions) er
 			TopicTag:     opts.Topic,
 			LocationID:   opts.Location,
 		}
-		post, err = client.CreateVideoPost(ctx, content)
 	default:
-		content := &api.TextPostContent{
+		textContent := &api.TextPostContent{
 			Text:           opts.Text,
 			ReplyTo:        opts.ReplyTo,
 			ReplyControl:   replyControl,
@@ -244,21 +325,39 @@ func runPostsCreate(cmd *cobra.Command, f *Factory, opts *postsCreateOptions) er
 			IsGhostPost:    opts.Ghost,
 		}
 		if hasGIF {
-			content.GIFAttachment = &api.GIFAttachment{
+			textContent.GIFAttachment = &api.GIFAttachment{
 				GIFID:    opts.GIF,
 				Provider: api.GIFProviderTenor,
 			}
 		}
-		post, err = client.CreateTextPost(ctx, content)
+		content = textContent
+	}
+
+	if opts.Schedule != "" {
+		return schedulePost(ctx, f, content, scheduleAt, scheduleLoc)
+	}
+
+	client, err := f.Client(ctx)
+	if err != nil {
+		return err
+	}
+	if opts.ReplyTo == "" {
+		if err := checkDuplicate(ctx, f, client, opts.Text); err != nil {
+			return err
+		}
 	}
 
+	post, err := publishContent(ctx, client, content, opts.TimeoutSecs)
 	if err != nil {
 		return WrapError("failed to create post", err)
 	}
 
 	io := iocontext.GetIO(ctx)
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSONTo(io.Out, post, outfmt.GetQuery(ctx))
+		if err := outfmt.WriteJSONContext(ctx, io.Out, post); err != nil {
+			return err
+		}
+		return announcePublished(ctx, f, post)
 	}
 
 	p := f.UI(ctx)
@@ -277,66 +376,56 @@ func runPostsCreate(cmd *cobra.Command, f *Factory, opts *postsCreateOptions) er
 		fmt.Fprintf(io.Out, "  Text:      %s\n", text) //nolint:errcheck // Best-effort output
 	}
 
-	return nil
+	return announcePublished(ctx, f, post)
 }
 
 func newPostsGetCmd(f *Factory) *cobra.Command {
+	opts := &postGetOptions{ExpandDepth: expandQuoteDepth}
+
 	cmd := &cobra.Command{
 		Use:   "get [post-id]",
-		Short: "Get a single post by ID",
-		Long: `Retrieve a single post by its ID.
-
-Example:
-  threads posts get 12345678901234567`,
-		Args: cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPostsGet(cmd, f, args[0])
-		},
-	}
-	return cmd
-}
-
-func runPostsGet(cmd *cobra.Command, f *Factory, postID string) error {
-	ctx := cmd.Context()
-	client, err := f.Client(ctx)
-	if err != nil {
-		return err
-	}
+		Short: "Show a post with media, metrics and conversation stats",
+		Long: `Show a single post in detail: text, media, reply settings, the quoted post,
+metrics and conversation stats.
 
-	post, err := client.GetPost(ctx, api.PostID(postID))
-	if err != nil {
-		return WrapError("failed to get post", err)
-	}
+Metrics need the threads_manage_insights scope and are only available for
+your own posts; when they cannot be fetched the rest of the post is still
+shown. JSON output includes every post field plus "metrics" and
+"conversation".
 
-	io := iocontext.GetIO(ctx)
-	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSONTo(io.Out, post, outfmt.GetQuery(ctx))
-	}
+The post a quote post quotes is always shown. With --expand quotes, the chain
+of quoted posts is followed up to --expand-depth levels, and each level is
+nested under "quoted_post" in JSON output.
 
-	fmt.Fprintf(io.Out, "ID:        %s\n", post.ID)                                      //nolint:errcheck // Best-effort output
-	fmt.Fprintf(io.Out, "Username:  @%s\n", post.Username)                               //nolint:errcheck // Best-effort output
-	fmt.Fprintf(io.Out, "Type:      %s\n", post.MediaType)                               //nolint:errcheck // Best-effort output
-	fmt.Fprintf(io.Out, "Permalink: %s\n", post.Permalink)                               //nolint:errcheck // Best-effort output
-	fmt.Fprintf(io.Out, "Timestamp: %s\n", post.Timestamp.Format("2006-01-02 15:04:05")) //nolint:errcheck // Best-effort output
+With --download-media, the post's images and videos (including carousel
+items) are saved to the given directory along with a manifest.json of
+checksums. Failed downloads are retried and recorded in the manifest.
 
-	if post.Text != "" {
-		fmt.Fprintf(io.Out, "Text:      %s\n", post.Text) //nolint:errcheck // Best-effort output
-	}
-	if post.MediaURL != "" {
-		fmt.Fprintf(io.Out, "Media URL: %s\n", post.MediaURL) //nolint:errcheck // Best-effort output
-	}
-	if post.IsReply {
-		fmt.Fprintf(io.Out, "Reply to:  %s\n", post.ReplyTo) //nolint:errcheck // Best-effort output
-	}
-	if post.IsQuotePost {
-		fmt.Fprintln(io.Out, "Quote:     yes") //nolint:errcheck // Best-effort output
+Examples:
+  threads posts get 12345678901234567
+  threads posts get 12345678901234567 --open
+  threads posts get 12345678901234567 --expand quotes --expand-depth 5
+  threads posts get 12345678901234567 --download-media ./out
+  threads posts get 12345678901234567 --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPostsGet(cmd, f, args[0], opts)
+		},
 	}
 
-	return nil
+	cmd.Flags().BoolVar(&opts.Open, "open", false, "Open the post in the browser")
+	cmd.Flags().StringSliceVar(&opts.Expand, "expand", nil, "Fetch related posts inline: quotes")
+	cmd.Flags().IntVar(&opts.ExpandDepth, "expand-depth", opts.ExpandDepth, fmt.Sprintf("Levels of quoted posts to fetch with --expand quotes (max %d)", maxQuoteDepth))
+	cmd.Flags().StringVar(&opts.DownloadMedia, "download-media", "", "Download the post's images and videos to this directory")
+	return cmd
 }
 
 func newPostsListCmd(f *Factory) *cobra.Command {
 	var limit int
+	var all bool
+	var cursor string
+	var downloadMedia string
+	var dateRange dateRangeOptions
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -350,20 +439,39 @@ Examples:
   # List with pagination
   threads posts list --limit 10
 
+  # Fetch every page
+  threads posts list --all
+
+  # Posts from the last week, or from June 2025
+  threads posts list --since 7d
+  threads posts list --all --month 2025-06
+
+  # Back up every post with its images and videos
+  threads posts list --all --output json --download-media ./media > posts.json
+
   # Output as JSON
   threads posts list --output json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPostsList(cmd, f, limit)
+			return runPostsList(cmd, f, limit, all, cursor, downloadMedia, &dateRange)
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of results")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all pages (--limit sets the page size)")
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Pagination cursor for the next page")
+	cmd.Flags().StringVar(&downloadMedia, "download-media", "", "Download the listed posts' images and videos to this directory")
+	addDateRangeFlags(cmd, &dateRange)
 	return cmd
 }
 
-func runPostsList(cmd *cobra.Command, f *Factory, limit int) error {
+func runPostsList(cmd *cobra.Command, f *Factory, limit int, all bool, cursor, downloadMedia string, dateRange *dateRangeOptions) error {
 	ctx := cmd.Context()
 
+	since, until, err := dateRange.unixRange(f)
+	if err != nil {
+		return err
+	}
+
 	client, err := f.Client(ctx)
 	if err != nil {
 		return err
@@ -374,27 +482,56 @@ func runPostsList(cmd *cobra.Command, f *Factory, limit int) error {
 		return WrapError("failed to get user info", err)
 	}
 
-	opts := &api.PaginationOptions{}
+	opts := &api.PostsOptions{After: cursor, Since: since, Until: until}
 	if limit > 0 {
 		opts.Limit = limit
 	}
 
-	postsResp, err := client.GetUserPosts(ctx, api.UserID(me.ID), opts)
-	if err != nil {
-		return WrapError("failed to list posts", err)
+	var postsResp *api.PostsResponse
+	if all {
+		it := api.NewIterator(func(ctx context.Context, cursor string) (*api.PostsResponse, error) {
+			pageOpts := *opts
+			pageOpts.After = cursor
+			return client.GetUserPostsWithOptions(ctx, api.UserID(me.ID), &pageOpts)
+		})
+		allPosts, errAll := it.All(ctx)
+		if errAll != nil {
+			return WrapError("failed to list posts", errAll)
+		}
+		postsResp = &api.PostsResponse{Data: allPosts}
+	} else {
+		postsResp, err = client.GetUserPostsWithOptions(ctx, api.UserID(me.ID), opts)
+		if err != nil {
+			return WrapError("failed to list posts", err)
+		}
 	}
 
 	posts := postsResp.Data
-	if limit > 0 && len(posts) > limit {
+	if !all && limit > 0 && len(posts) > limit {
 		posts = posts[:limit]
 	}
 
+	if err := writePostsList(cmd, f, posts, postsResp); err != nil {
+		return err
+	}
+
+	if downloadMedia != "" {
+		return downloadPostMedia(ctx, client, downloadMedia, posts)
+	}
+	return nil
+}
+
+// postsList is the JSON output of 'posts list' and 'posts ghost-list'
+type postsList struct {
+	Paging api.Paging `json:"paging"`
+	Posts  []api.Post `json:"posts"`
+}
+
+func writePostsList(cmd *cobra.Command, f *Factory, posts []api.Post, postsResp *api.PostsResponse) error {
+	ctx := cmd.Context()
 	io := iocontext.GetIO(ctx)
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSONTo(io.Out, map[string]any{
-			"posts":  posts,
-			"paging": postsResp.Paging,
-		}, outfmt.GetQuery(ctx))
+		return outfmt.WriteJSONContext(ctx, io.Out, postsList{Paging: postsResp.Paging, Posts: posts})
 	}
 
 	if len(posts) == 0 {
@@ -403,30 +540,41 @@ func runPostsList(cmd *cobra.Command, f *Factory, limit int) error {
 	}
 
 	fmtr := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
-	fmtr.Header("ID", "TYPE", "TEXT", "TIMESTAMP")
+	headers := []string{"ID", "TYPE", "TEXT", "TIMESTAMP"}
+	noted := postNotes(ctx, f, len(posts), func(i int) *api.Post { return &posts[i] })
+	if noted != nil {
+		headers = append(headers, "NOTE")
+	}
+	fmtr.Header(headers...)
 
-	for _, post := range posts {
+	for i, post := range posts {
 		text := strings.ReplaceAll(post.Text, "\n", " ")
 		if len(text) > 40 {
 			text = text[:40] + "..."
 		}
 
-		fmtr.Row(
+		row := []any{
 			post.ID,
 			post.MediaType,
 			text,
 			post.Timestamp.Format("2006-01-02 15:04"),
-		)
+		}
+		if noted != nil {
+			row = append(row, noted[i])
+		}
+		fmtr.Row(row...)
 	}
 	fmtr.Flush()
 
+	writeListFooter(cmd, nil, len(posts), postsResp.NextCursor())
 	return nil
 }
 
 func newPostsDeleteCmd(f *Factory) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "delete [post-id]",
-		Short: "Delete a post",
+		Use:         "delete [post-id]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Delete a post",
 		Long: `Delete a post by its ID.
 
 Requires confirmation unless --yes flag is provided.
@@ -468,7 +616,11 @@ func runPostsDelete(cmd *cobra.Command, f *Factory, postID string) error {
 		}
 		fmt.Fprintln(io.Out) //nolint:errcheck // Best-effort output
 
-		if !f.Confirm(ctx, "Delete this post?") {
+		confirmed, err := f.Confirm(ctx, "Delete this post?")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
 			fmt.Fprintln(io.Out, "Cancelled.") //nolint:errcheck // Best-effort output
 			return nil
 		}
@@ -488,6 +640,8 @@ type postsCarouselOptions struct {
 	AltTexts    []string
 	ReplyTo     string
 	TimeoutSecs int
+	Resume      bool
+	signatureOptions
 }
 
 func newPostsCarouselCmd(f *Factory) *cobra.Command {
@@ -496,17 +650,26 @@ func newPostsCarouselCmd(f *Factory) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "carousel",
-		Short: "Create a carousel post with multiple images/videos",
+		Use:         "carousel",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Create a carousel post with multiple images/videos",
 		Long: `Create a carousel post with 2-20 media items.
 
 Each item should be a URL to an image or video. Alt text can be provided
-for accessibility using --alt-text (one per item, in order).`,
+for accessibility using --alt-text (one per item, in order).
+
+The container made for each item is saved in the data directory as soon as
+it is ready. If the carousel fails midway, run the same command with
+--resume to reuse those containers instead of uploading every item again.
+Containers expire after 24 hours; expired ones are made again.`,
 		Example: `  # Create carousel with 3 images
   threads posts carousel --items url1,url2,url3
 
   # With caption and alt text
-  threads posts carousel --items url1,url2 --text "My photos" --alt-text "First" --alt-text "Second"`,
+  threads posts carousel --items url1,url2 --text "My photos" --alt-text "First" --alt-text "Second"
+
+  # Continue after a failure, reusing the items already uploaded
+  threads posts carousel --items url1,url2,url3 --resume`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPostsCarousel(cmd, f, opts)
 		},
@@ -517,6 +680,8 @@ for accessibility using --alt-text (one per item, in order).`,
 	cmd.Flags().StringSliceVar(&opts.AltTexts, "alt-text", nil, "Alt text for each item (in order)")
 	cmd.Flags().StringVar(&opts.ReplyTo, "reply-to", "", "Post ID to reply to")
 	cmd.Flags().IntVar(&opts.TimeoutSecs, "timeout", 300, "Timeout in seconds for container processing")
+	cmd.Flags().BoolVar(&opts.Resume, "resume", false, "Reuse the items uploaded by a failed run of the same carousel")
+	addSignatureFlags(cmd, &opts.signatureOptions)
 	//nolint:errcheck,gosec // MarkFlagRequired cannot fail for a flag that exists
 	cmd.MarkFlagRequired("items")
 
@@ -524,61 +689,110 @@ for accessibility using --alt-text (one per item, in order).`,
 }
 
 func runPostsCarousel(cmd *cobra.Command, f *Factory, opts *postsCarouselOptions) error {
-	if len(opts.Items) < 2 {
-		return &UserFriendlyError{
-			Message:    "Carousel requires at least 2 items",
-			Suggestion: "Add more items with --items or use 'threads posts create' for a single media post",
+	items := make([]api.CarouselItem, len(opts.Items))
+	for i, itemURL := range opts.Items {
+		items[i] = api.CarouselItem{MediaType: detectMediaType(itemURL), URL: itemURL}
+		if i < len(opts.AltTexts) {
+			items[i].AltText = opts.AltTexts[i]
 		}
 	}
-	if len(opts.Items) > 20 {
-		return &UserFriendlyError{
-			Message:    "Carousel supports maximum 20 items",
-			Suggestion: "Reduce the number of items to 20 or fewer",
+
+	// Check every item up front so no media is uploaded for a carousel that cannot be published
+	if err := api.ValidateCarouselPlan(items); err != nil {
+		var planErr *api.CarouselPlanError
+		if errors.As(err, &planErr) {
+			return carouselPlanError(planErr)
 		}
+		return err
 	}
-
-	ctx := cmd.Context()
-	client, err := f.Client(ctx)
+	text, err := applySignature(cmd, f, &opts.signatureOptions, opts.Text)
 	if err != nil {
 		return err
 	}
+	opts.Text = text
+	if err := checkAccountRules(f, opts.Text); err != nil {
+		return err
+	}
 
-	var containerIDs []string
-	for i, itemURL := range opts.Items {
-		var altText string
-		if i < len(opts.AltTexts) {
-			altText = opts.AltTexts[i]
+	ctx := cmd.Context()
+	missing := 0
+	for _, item := range items {
+		if item.AltText == "" {
+			missing++
 		}
+	}
+	if err := checkAltText(ctx, missing, len(items)); err != nil {
+		return err
+	}
 
-		mediaType := detectMediaType(itemURL)
-		containerID, errContainer := client.CreateMediaContainer(ctx, mediaType, itemURL, altText)
-		if errContainer != nil {
-			return WrapError(fmt.Sprintf("failed to create container for item %d", i+1), errContainer)
+	client, err := f.Client(ctx)
+	if err != nil {
+		return err
+	}
+	if opts.ReplyTo == "" {
+		if err := checkDuplicate(ctx, f, client, opts.Text); err != nil {
+			return err
 		}
+	}
 
-		if errWait := waitForContainer(ctx, client, containerID, opts.TimeoutSecs); errWait != nil {
-			return WrapError(fmt.Sprintf("container %d not ready", i+1), errWait)
+	// Progress is kept per account and items, so the same command finds it
+	statePath := carouselStatePath(f.currentAccountName(), items)
+	state, err := readCarouselState(statePath)
+	if err != nil {
+		return err
+	}
+	switch {
+	case state == nil && opts.Resume:
+		return &UserFriendlyError{
+			Message:    "No failed carousel with these items to resume",
+			Suggestion: "Drop --resume to start one",
 		}
-
-		containerIDs = append(containerIDs, string(containerID))
+	case state != nil && !opts.Resume && state.Done() > 0:
+		f.UI(ctx).Info("Uploading all items again; add --resume to reuse the %d uploaded by an earlier run", state.Done())
+		state = nil
 	}
+	if state == nil {
+		state = &carouselState{Account: f.currentAccountName(), Items: items}
+	}
+	resumed := state.Done()
 
 	content := &api.CarouselPostContent{
-		Text:     opts.Text,
-		Children: containerIDs,
+		Text: opts.Text,
 	}
 	if opts.ReplyTo != "" {
 		content.ReplyTo = opts.ReplyTo
 	}
 
-	post, err := client.CreateCarouselPost(ctx, content)
+	post, err := client.CreateCarousel(ctx, content, items, &api.CarouselOptions{
+		Wait:     containerWaitOptions(opts.TimeoutSecs),
+		Progress: &state.CarouselProgress,
+		OnChild: func(int, *api.CarouselProgress) error {
+			return writeCarouselState(statePath, state)
+		},
+	})
 	if err != nil {
+		if done := state.Done(); done > 0 {
+			return &UserFriendlyError{
+				Message:    fmt.Sprintf("Failed to create carousel post after %d of %d items: %v", done, len(items), FormatError(err)),
+				Suggestion: "Run the same command with --resume to reuse the items already uploaded",
+				Cause:      err,
+			}
+		}
 		return WrapError("failed to create carousel post", err)
 	}
+	if err := removeCarouselState(statePath); err != nil {
+		fmt.Fprintf(iocontext.GetIO(ctx).ErrOut, "warning: %v\n", err) //nolint:errcheck // Best-effort output to stderr
+	}
+	if resumed > 0 {
+		f.UI(ctx).Info("Reused %s from an earlier run", pluralize(resumed, "item", "items"))
+	}
 
 	io := iocontext.GetIO(ctx)
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSONTo(io.Out, post, outfmt.GetQuery(ctx))
+		if err := outfmt.WriteJSONContext(ctx, io.Out, post); err != nil {
+			return err
+		}
+		return announcePublished(ctx, f, post)
 	}
 
 	f.UI(ctx).Success("Carousel post created successfully!")
@@ -591,21 +805,23 @@ func runPostsCarousel(cmd *cobra.Command, f *Factory, opts *postsCarouselOptions
 		}
 		fmt.Fprintf(io.Out, "  Text:      %s\n", text) //nolint:errcheck // Best-effort output
 	}
-	fmt.Fprintf(io.Out, "  Items:     %d\n", len(containerIDs)) //nolint:errcheck // Best-effort output
+	fmt.Fprintf(io.Out, "  Items:     %d\n", len(items)) //nolint:errcheck // Best-effort output
 
-	return nil
+	return announcePublished(ctx, f, post)
 }
 
 func newPostsQuoteCmd(f *Factory) *cobra.Command {
 	var text string
 	var imageURL string
 	var videoURL string
+	var signature signatureOptions
 
 	cmd := &cobra.Command{
-		Use:   "quote [post-id]",
-		Short: "Create a quote post",
-		Long:  "Quote an existing post with optional text, image, or video.",
-		Args:  cobra.ExactArgs(1),
+		Use:         "quote [post-id]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Create a quote post",
+		Long:        "Quote an existing post with optional text, image, or video.",
+		Args:        cobra.ExactArgs(1),
 		Example: `  # Quote with text
   threads posts quote 12345 --text "Great point!"
 
@@ -615,53 +831,72 @@ func newPostsQuoteCmd(f *Factory) *cobra.Command {
 			quotedPostID := args[0]
 			ctx := cmd.Context()
 
+			body, err := applySignature(cmd, f, &signature, text)
+			if err != nil {
+				return err
+			}
+			if err := checkAccountRules(f, body); err != nil {
+				return err
+			}
+
 			client, err := f.Client(ctx)
 			if err != nil {
 				return err
 			}
+			if err := checkDuplicate(ctx, f, client, body); err != nil {
+				return err
+			}
 
-			var content interface{}
+			var content any
 			switch {
 			case videoURL != "":
 				content = &api.VideoPostContent{
-					VideoURL: videoURL,
-					Text:     text,
+					VideoURL:     videoURL,
+					Text:         body,
+					QuotedPostID: quotedPostID,
 				}
 			case imageURL != "":
 				content = &api.ImagePostContent{
-					ImageURL: imageURL,
-					Text:     text,
+					ImageURL:     imageURL,
+					Text:         body,
+					QuotedPostID: quotedPostID,
 				}
 			default:
 				content = &api.TextPostContent{
-					Text: text,
+					Text:         body,
+					QuotedPostID: quotedPostID,
 				}
 			}
 
-			post, err := client.CreateQuotePost(ctx, content, quotedPostID)
+			post, err := publishContent(ctx, client, content, defaultContainerTimeoutSecs)
 			if err != nil {
 				return WrapError("failed to create quote post", err)
 			}
 
 			io := iocontext.GetIO(ctx)
 			out := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
-			return out.Output(post)
+			if err := out.Output(post); err != nil {
+				return err
+			}
+			return announcePublished(ctx, f, post)
 		},
 	}
 
 	cmd.Flags().StringVar(&text, "text", "", "Quote text")
 	cmd.Flags().StringVar(&imageURL, "image", "", "Image URL to include")
 	cmd.Flags().StringVar(&videoURL, "video", "", "Video URL to include")
+	addSignatureFlags(cmd, &signature)
 
 	return cmd
 }
 
 func newPostsRepostCmd(f *Factory) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "repost [post-id]",
-		Short:   "Repost an existing post",
-		Args:    cobra.ExactArgs(1),
-		Example: `  threads posts repost 12345`,
+		Use:         "repost [post-id]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Repost an existing post",
+		Args:        cobra.ExactArgs(1),
+		Example:     `  threads posts repost 12345`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			postID := args[0]
 			ctx := cmd.Context()
@@ -686,8 +921,9 @@ func newPostsRepostCmd(f *Factory) *cobra.Command {
 
 func newPostsUnrepostCmd(f *Factory) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "unrepost [repost-id]",
-		Short: "Remove a repost",
+		Use:         "unrepost [repost-id]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Remove a repost",
 		Long: `Remove a repost by its ID.
 
 This undoes a repost action. Note that you need the repost ID, not the original post ID.
@@ -712,7 +948,11 @@ Requires confirmation unless --yes flag is provided.`,
 			io := iocontext.GetIO(ctx)
 			if !outfmt.GetYes(ctx) {
 				fmt.Fprintf(io.Out, "Repost to remove: %s\n\n", repostID) //nolint:errcheck // Best-effort output
-				if !f.Confirm(ctx, "Remove this repost?") {
+				confirmed, err := f.Confirm(ctx, "Remove this repost?")
+				if err != nil {
+					return err
+				}
+				if !confirmed {
 					fmt.Fprintln(io.Out, "Cancelled.") //nolint:errcheck // Best-effort output
 					return nil
 				}
@@ -731,6 +971,7 @@ Requires confirmation unless --yes flag is provided.`,
 
 func newPostsGhostListCmd(f *Factory) *cobra.Command {
 	var limit int
+	var cursor string
 
 	cmd := &cobra.Command{
 		Use:   "ghost-list",
@@ -750,15 +991,16 @@ Examples:
   # Output as JSON
   threads posts ghost-list --output json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPostsGhostList(cmd, f, limit)
+			return runPostsGhostList(cmd, f, limit, cursor)
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of results")
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Pagination cursor for the next page")
 	return cmd
 }
 
-func runPostsGhostList(cmd *cobra.Command, f *Factory, limit int) error {
+func runPostsGhostList(cmd *cobra.Command, f *Factory, limit int, cursor string) error {
 	ctx := cmd.Context()
 
 	client, err := f.Client(ctx)
@@ -771,7 +1013,7 @@ func runPostsGhostList(cmd *cobra.Command, f *Factory, limit int) error {
 		return WrapError("failed to get user info", err)
 	}
 
-	opts := &api.PaginationOptions{}
+	opts := &api.PaginationOptions{After: cursor}
 	if limit > 0 {
 		opts.Limit = limit
 	}
@@ -788,10 +1030,7 @@ func runPostsGhostList(cmd *cobra.Command, f *Factory, limit int) error {
 
 	io := iocontext.GetIO(ctx)
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSONTo(io.Out, map[string]any{
-			"posts":  posts,
-			"paging": postsResp.Paging,
-		}, outfmt.GetQuery(ctx))
+		return outfmt.WriteJSONContext(ctx, io.Out, postsList{Paging: postsResp.Paging, Posts: posts})
 	}
 
 	if len(posts) == 0 {
@@ -829,6 +1068,7 @@ func runPostsGhostList(cmd *cobra.Command, f *Factory, limit int) error {
 	}
 	fmtr.Flush()
 
+	writeListFooter(cmd, nil, len(posts), postsResp.NextCursor())
 	return nil
 }
 
@@ -847,62 +1087,39 @@ func detectMediaType(rawURL string) string {
 	return "IMAGE"
 }
 
-// waitForContainer polls container status until ready or timeout
-func waitForContainer(ctx context.Context, client *api.Client, containerID api.ContainerID, timeoutSecs int) error {
-	status, err := client.GetContainerStatus(ctx, containerID)
-	if err != nil {
-		return FormatError(err)
+// carouselPlanError lists every carousel violation in a single user-facing error
+func carouselPlanError(err *api.CarouselPlanError) *UserFriendlyError {
+	lines := make([]string, len(err.Violations))
+	for i, v := range err.Violations {
+		lines[i] = "  - " + v.String()
 	}
-	switch status.Status {
-	case "FINISHED":
-		return nil
-	case "ERROR":
-		return &UserFriendlyError{
-			Message:    fmt.Sprintf("Media processing failed: %s", status.ErrorMessage),
-			Suggestion: "Check that the media URL is accessible and the format is supported (JPEG, PNG for images; MP4 for videos)",
-		}
-	case "EXPIRED":
-		return &UserFriendlyError{
-			Message:    "Media container expired before publishing",
-			Suggestion: "Re-upload the media and publish immediately after container creation",
-		}
+	return &UserFriendlyError{
+		Message:    "Carousel cannot be published:\n" + strings.Join(lines, "\n"),
+		Suggestion: "Carousels need 2-20 http(s) image or video URLs with alt text of at most 1000 characters",
+		Cause:      err,
 	}
+}
 
-	timeout := time.After(time.Duration(timeoutSecs) * time.Second)
-	ticker := time.NewTicker(containerPollingInterval)
-	defer ticker.Stop()
+// containerWaitOptions returns the polling settings shared by all posting commands
+func containerWaitOptions(timeoutSecs int) *api.WaitOptions {
+	return &api.WaitOptions{
+		PollInterval: containerPollingInterval,
+		Timeout:      time.Duration(timeoutSecs) * time.Second,
+	}
+}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return &UserFriendlyError{
-				Message:    "Operation cancelled",
-				Suggestion: "Try again if this was unintentional",
-			}
-		case <-timeout:
-			return &UserFriendlyError{
-				Message:    "Timeout waiting for media processing",
-				Suggestion: "Media processing is taking too long. Try using a smaller file or increase timeout with --timeout",
-			}
-		case <-ticker.C:
-			status, err := client.GetContainerStatus(ctx, containerID)
-			if err != nil {
-				return FormatError(err)
-			}
-			switch status.Status {
-			case "FINISHED":
-				return nil
-			case "ERROR":
-				return &UserFriendlyError{
-					Message:    fmt.Sprintf("Media processing failed: %s", status.ErrorMessage),
-					Suggestion: "Check that the media URL is accessible and the format is supported",
-				}
-			case "EXPIRED":
-				return &UserFriendlyError{
-					Message:    "Media container expired before publishing",
-					Suggestion: "Re-upload the media and publish immediately after container creation",
-				}
-			}
-		}
+// publishContent creates a container for content, waits for it to finish
+// processing and publishes it
+func publishContent(ctx context.Context, client api.PostCreator, content any, timeoutSecs int) (*api.Post, error) {
+	container, err := client.NewContainer(content)
+	if err != nil {
+		return nil, err
 	}
+	return container.PublishWithWait(ctx, containerWaitOptions(timeoutSecs))
+}
+
+// announcePublished tells the subscribers of f.Events, such as the
+// post_published hook, about a post the command published
+func announcePublished(ctx context.Context, f *Factory, post *api.Post) error {
+	return f.Events.Publish(ctx, events.PostPublished{Post: *post, Source: events.SourceCommand})
 }
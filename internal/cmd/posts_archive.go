@@ -0,0 +1,449 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/media"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/redact"
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// Files of an archive directory
+const (
+	archivePostsFile      = "posts.jsonl"
+	archiveCheckpointFile = "checkpoint.json"
+	archiveMediaDir       = "media"
+)
+
+// archiveMaxPageSize is the most posts the API returns per page
+const archiveMaxPageSize = 100
+
+// archiveCheckpoint is rewritten after every page, so an interrupted archive
+// continues from the last page written instead of the beginning. The date
+// range is stored resolved, so relative dates such as "7d" mean the same on
+// resume.
+type archiveCheckpoint struct {
+	UserID     string    `json:"user_id"`
+	Since      int64     `json:"since,omitempty"`
+	Until      int64     `json:"until,omitempty"`
+	Media      bool      `json:"media"`
+	Redact     bool      `json:"redact,omitempty"`
+	RedactKey  string    `json:"redact_key,omitempty"`
+	Cursor     string    `json:"cursor,omitempty"`
+	LastPostID string    `json:"last_post_id,omitempty"`
+	Posts      int       `json:"posts"`
+	Pages      int       `json:"pages"`
+	MediaFiles int       `json:"media_files"`
+	MediaBytes int64     `json:"media_bytes"`
+	Complete   bool      `json:"complete"`
+	Started    time.Time `json:"started"`
+	Updated    time.Time `json:"updated"`
+}
+
+type postsArchiveOptions struct {
+	Resume    bool
+	Media     bool
+	Redact    bool
+	PageSize  int
+	DateRange dateRangeOptions
+	Budget    budgetOptions
+}
+
+func newPostsArchiveCmd(f *Factory) *cobra.Command {
+	opts := &postsArchiveOptions{PageSize: archiveMaxPageSize}
+
+	cmd := &cobra.Command{
+		Use:   "archive [dir]",
+		Short: "Archive every post to a directory, resumably",
+		Long: `Archive your posts to a directory, one JSON object per line in posts.jsonl,
+with their images and videos in media/ when --media is given.
+
+After every page a checkpoint (cursor, last post ID, posts written and media
+bytes downloaded) is saved to checkpoint.json. If the run is interrupted,
+run the same command with --resume to continue where it stopped; the date
+range and --media setting of the original run are kept.
+
+--redact writes posts safe to share for analysis or bug reports: usernames,
+including @mentions, become pseudonyms such as user_3f2a9c01b2d4, and URLs,
+email addresses, permalinks and media URLs are removed. Share posts.jsonl
+only; checkpoint.json holds the key that keeps pseudonyms the same across
+resumed runs.
+
+--max-api-calls and --max-download-bytes cap a single run for unattended
+jobs. When a cap is reached the archive stops after the last complete page,
+saves its checkpoint and exits successfully; the next --resume continues
+from there.`,
+		Example: `  # Archive all posts with their media
+  threads posts archive ./archive --media
+
+  # Continue after an interruption
+  threads posts archive ./archive --resume
+
+  # A dataset safe to attach to a bug report
+  threads posts archive ./shareable --redact
+
+  # Only 2024
+  threads posts archive ./archive-2024 --since 2024-01-01 --until 2025-01-01
+
+  # Nightly job: at most 500 requests and 1GB of media per run
+  threads posts archive ./archive --resume --max-api-calls 500 --max-download-bytes 1GB`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPostsArchive(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Resume, "resume", false, "Continue an interrupted archive from its checkpoint")
+	cmd.Flags().BoolVar(&opts.Media, "media", false, "Download images and videos to the media directory")
+	cmd.Flags().BoolVar(&opts.Redact, "redact", false, "Replace usernames with pseudonyms and strip URLs and emails")
+	cmd.Flags().IntVar(&opts.PageSize, "page-size", opts.PageSize, fmt.Sprintf("Posts fetched per request (max %d)", archiveMaxPageSize))
+	addDateRangeFlags(cmd, &opts.DateRange)
+	addBudgetFlags(cmd, &opts.Budget)
+	cmd.MarkFlagsMutuallyExclusive("resume", "since")
+	cmd.MarkFlagsMutuallyExclusive("resume", "until")
+	cmd.MarkFlagsMutuallyExclusive("resume", "month")
+	cmd.MarkFlagsMutuallyExclusive("resume", "media")
+	cmd.MarkFlagsMutuallyExclusive("resume", "redact")
+	cmd.MarkFlagsMutuallyExclusive("redact", "media")
+	return cmd
+}
+
+func runPostsArchive(cmd *cobra.Command, f *Factory, dir string, opts *postsArchiveOptions) error {
+	ctx := cmd.Context()
+
+	if opts.PageSize < 1 || opts.PageSize > archiveMaxPageSize {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --page-size: %d", opts.PageSize),
+			Suggestion: fmt.Sprintf("Use 1 to %d", archiveMaxPageSize),
+		}
+	}
+
+	ctx, budget, err := opts.Budget.start(ctx)
+	if err != nil {
+		return err
+	}
+
+	checkpoint, err := readArchiveCheckpoint(dir)
+	if err != nil {
+		return err
+	}
+	switch {
+	case checkpoint != nil && !opts.Resume && checkpoint.Complete:
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("%s already holds a complete archive", dir),
+			Suggestion: "Choose another directory",
+		}
+	case checkpoint != nil && !opts.Resume:
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("%s holds an unfinished archive (%d posts)", dir, checkpoint.Posts),
+			Suggestion: "Add --resume to continue it, or choose another directory",
+		}
+	case checkpoint == nil && opts.Resume:
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("No archive to resume in %s", dir),
+			Suggestion: "Drop --resume to start one",
+		}
+	}
+
+	client, err := f.Client(ctx)
+	if err != nil {
+		return err
+	}
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return WrapError("failed to get user info", err)
+	}
+
+	if checkpoint == nil {
+		since, until, err := opts.DateRange.unixRange(f)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return WrapError("failed to create archive directory", err)
+		}
+		now := time.Now().UTC()
+		checkpoint = &archiveCheckpoint{UserID: me.ID, Since: since, Until: until, Media: opts.Media, Started: now, Updated: now}
+		if opts.Redact {
+			checkpoint.Redact = true
+			checkpoint.RedactKey = hex.EncodeToString(redact.NewKey())
+		}
+		if err := writeArchiveCheckpoint(dir, checkpoint); err != nil {
+			return err
+		}
+	} else if checkpoint.UserID != me.ID {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("The archive in %s belongs to another account", dir),
+			Suggestion: "Resume it with the --account it was started with",
+		}
+	}
+
+	result := newBatchResult("archive", "posts")
+	result.retry = fmt.Sprintf("Retry their media with 'threads posts get POST_ID --download-media %s'", filepath.Join(dir, archiveMediaDir))
+	if !checkpoint.Complete {
+		err := archivePosts(ctx, client, dir, checkpoint, opts.PageSize, budget, result)
+		if errors.Is(err, errBudgetSpent) {
+			result.Stopped = budget.spent()
+		} else if err != nil {
+			return err
+		}
+	}
+
+	io := iocontext.GetIO(ctx)
+	if !outfmt.IsJSON(ctx) {
+		summary := fmt.Sprintf("Archived %d posts to %s", checkpoint.Posts, dir)
+		if checkpoint.Redact {
+			summary = fmt.Sprintf("Archived %d redacted posts to %s", checkpoint.Posts, dir)
+		}
+		if checkpoint.Media {
+			summary += fmt.Sprintf(" (%d media files, %s)", checkpoint.MediaFiles, media.FormatSize(checkpoint.MediaBytes))
+		}
+		if result.Stopped != "" {
+			f.UI(ctx).Warning("Stopped: %s. %s so far; run the same command with --resume to continue", result.Stopped, summary)
+		} else {
+			f.UI(ctx).Success("%s", summary)
+		}
+		fmt.Fprintln(io.Out) //nolint:errcheck // Best-effort output
+	}
+	return writeBatchResult(ctx, result)
+}
+
+// archivePosts fetches the pages after the checkpoint's cursor, appending
+// each to the posts file before the checkpoint moves past it. It returns
+// errBudgetSpent when budget runs out; a page cut short by it is not
+// checkpointed, so it is fetched again on resume.
+func archivePosts(ctx context.Context, client api.API, dir string, checkpoint *archiveCheckpoint, pageSize int, budget *runBudget, result *batchResult) error {
+	// Lines written after the last checkpoint belong to a page that is
+	// fetched again
+	postsPath := filepath.Join(dir, archivePostsFile)
+	if err := truncateLines(postsPath, checkpoint.Posts); err != nil {
+		return WrapError("failed to prepare "+archivePostsFile, err)
+	}
+	file, err := os.OpenFile(postsPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600) //nolint:gosec // Path is chosen by the user
+	if err != nil {
+		return WrapError("failed to open "+archivePostsFile, err)
+	}
+	defer file.Close() //nolint:errcheck // Closed after the last sync
+
+	var redactor *redact.Redactor
+	if checkpoint.Redact {
+		key, err := hex.DecodeString(checkpoint.RedactKey)
+		if err != nil || len(key) == 0 {
+			return &UserFriendlyError{
+				Message:    fmt.Sprintf("Invalid redaction key in %s", filepath.Join(dir, archiveCheckpointFile)),
+				Suggestion: "Start the archive over in another directory",
+				Cause:      err,
+			}
+		}
+		redactor = redact.New(key)
+	}
+
+	var downloader *media.Downloader
+	if checkpoint.Media {
+		if downloader, err = newMediaDownloader(); err != nil {
+			return WrapError("failed to open media cache", err)
+		}
+	}
+
+	for {
+		if budget.spent() != "" {
+			return errBudgetSpent
+		}
+		page, err := client.GetUserPostsWithOptions(ctx, api.UserID(checkpoint.UserID), &api.PostsOptions{
+			Limit: pageSize,
+			After: checkpoint.Cursor,
+			Since: checkpoint.Since,
+			Until: checkpoint.Until,
+		})
+		if errors.Is(err, api.ErrCallBudgetExhausted) {
+			return errBudgetSpent
+		}
+		if err != nil {
+			return archiveInterrupted(checkpoint, err)
+		}
+
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		for i := range page.Data {
+			if redactor != nil {
+				redactor.Post(&page.Data[i])
+			}
+			if err := enc.Encode(&page.Data[i]); err != nil {
+				return err
+			}
+		}
+		if _, err := file.Write(buf.Bytes()); err != nil {
+			return WrapError("failed to write "+archivePostsFile, err)
+		}
+		if err := file.Sync(); err != nil {
+			return WrapError("failed to write "+archivePostsFile, err)
+		}
+
+		failed := map[string]string{}
+		skipped := map[string]bool{}
+		if downloader != nil {
+			if err := archiveMedia(ctx, client, downloader, dir, page.Data, checkpoint, budget, failed, skipped); err != nil {
+				if errors.Is(err, errBudgetSpent) {
+					return err
+				}
+				return archiveInterrupted(checkpoint, err)
+			}
+			// Carousel items refused by the budget would otherwise count as skipped
+			if budget.calls.Exhausted() {
+				return errBudgetSpent
+			}
+		}
+		for _, post := range page.Data {
+			switch {
+			case failed[post.ID] != "":
+				result.fail(post.ID, errors.New(failed[post.ID]))
+			case skipped[post.ID]:
+				result.skip(post.ID, "carousel items could not be loaded")
+			default:
+				result.succeed(post.ID)
+			}
+		}
+
+		checkpoint.Posts += len(page.Data)
+		checkpoint.Pages++
+		if n := len(page.Data); n > 0 {
+			checkpoint.LastPostID = page.Data[n-1].ID
+		}
+		// Like api.Iterator, stop at a page without a cursor or without posts
+		checkpoint.Cursor = page.NextCursor()
+		checkpoint.Complete = checkpoint.Cursor == "" || len(page.Data) == 0
+		checkpoint.Updated = time.Now().UTC()
+		if err := writeArchiveCheckpoint(dir, checkpoint); err != nil {
+			return err
+		}
+		if checkpoint.Complete {
+			return nil
+		}
+	}
+}
+
+// archiveMedia downloads the media of a page, recording per post the first
+// failure and whether carousel items were skipped. The download cap is
+// checked before each post, so a run can exceed it by one post's media.
+func archiveMedia(ctx context.Context, client api.API, downloader *media.Downloader, dir string, posts []api.Post, checkpoint *archiveCheckpoint, budget *runBudget, failed map[string]string, skipped map[string]bool) error {
+	for _, post := range posts {
+		if budget.spent() != "" {
+			return errBudgetSpent
+		}
+		items, n := collectMedia(ctx, client, []api.Post{post})
+		if n > 0 {
+			skipped[post.ID] = true
+		}
+		if len(items) == 0 {
+			continue
+		}
+		started := time.Now().UTC()
+		manifest, err := downloader.Download(ctx, filepath.Join(dir, archiveMediaDir), items)
+		if err != nil {
+			return err
+		}
+		for _, entry := range manifest.Entries {
+			if entry.PostID != post.ID {
+				continue
+			}
+			if entry.Error != "" && failed[post.ID] == "" {
+				failed[post.ID] = entry.Error
+			}
+			// Files kept from an earlier run were not fetched again
+			if entry.Error == "" && !entry.Fetched.Before(started) {
+				budget.downloaded(entry.Bytes)
+			}
+		}
+	}
+
+	// Totals come from the manifest, which also covers earlier runs
+	manifest, err := media.ReadManifest(filepath.Join(dir, archiveMediaDir))
+	if err != nil {
+		return err
+	}
+	checkpoint.MediaFiles, checkpoint.MediaBytes = 0, 0
+	for _, entry := range manifest.Entries {
+		if entry.Error == "" {
+			checkpoint.MediaFiles++
+			checkpoint.MediaBytes += entry.Bytes
+		}
+	}
+	return nil
+}
+
+// archiveInterrupted explains how to continue after a failed page
+func archiveInterrupted(checkpoint *archiveCheckpoint, err error) error {
+	return &UserFriendlyError{
+		Message:    fmt.Sprintf("Archive interrupted after %d posts", checkpoint.Posts),
+		Suggestion: "Run the same command with --resume to continue from the last checkpoint",
+		Cause:      err,
+	}
+}
+
+// readArchiveCheckpoint loads the checkpoint in dir; nil means there is none
+func readArchiveCheckpoint(dir string) (*archiveCheckpoint, error) {
+	var checkpoint archiveCheckpoint
+	err := statefile.ReadJSON(filepath.Join(dir, archiveCheckpointFile), "archive-checkpoint", 1, &checkpoint)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid archive checkpoint: %s", filepath.Join(dir, archiveCheckpointFile)),
+			Suggestion: "Remove it to start the archive over",
+			Cause:      err,
+		}
+	}
+	return &checkpoint, nil
+}
+
+// writeArchiveCheckpoint replaces the checkpoint atomically, so a crash
+// leaves either the previous or the new one
+func writeArchiveCheckpoint(dir string, checkpoint *archiveCheckpoint) error {
+	if err := statefile.WriteJSON(filepath.Join(dir, archiveCheckpointFile), "archive-checkpoint", 1, checkpoint); err != nil {
+		return WrapError("failed to write the archive checkpoint", err)
+	}
+	return nil
+}
+
+// truncateLines cuts path after its first n lines; a missing file is fine
+// when n is 0
+func truncateLines(path string, n int) error {
+	file, err := os.OpenFile(path, os.O_RDWR, 0) //nolint:gosec // Path is chosen by the user
+	if errors.Is(err, os.ErrNotExist) && n == 0 {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close() //nolint:errcheck // Truncate is the only write
+
+	var offset int64
+	reader := bufio.NewReader(file)
+	for range n {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s has fewer than the %d posts in the checkpoint", filepath.Base(path), n)
+		}
+		if err != nil {
+			return err
+		}
+		offset += int64(len(line))
+	}
+	return file.Truncate(offset)
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+// archivePages serves two pages of posts; failSecond makes the request for
+// the second page fail, as if the run was interrupted
+func archivePages(cursors *[]string, failSecond *bool) *mockAPI {
+	return &mockAPI{
+		getMe: func(context.Context) (*api.User, error) {
+			return &api.User{ID: "me"}, nil
+		},
+		getUserPosts: func(_ context.Context, _ api.UserID, opts *api.PostsOptions) (*api.PostsResponse, error) {
+			*cursors = append(*cursors, opts.After)
+			if opts.After == "" {
+				return &api.PostsResponse{
+					Data:   []api.Post{{ID: "1", Text: "one"}, {ID: "2", Text: "two"}},
+					Paging: api.Paging{Cursors: &api.PagingCursors{After: "page2"}},
+				}, nil
+			}
+			if *failSecond {
+				return nil, errors.New("connection reset")
+			}
+			return &api.PostsResponse{Data: []api.Post{{ID: "3", Text: "three"}}}, nil
+		},
+	}
+}
+
+func runArchive(t *testing.T, mock *mockAPI, args ...string) error {
+	t.Helper()
+	f, io := newMockAPITestFactory(t, mock)
+	cmd := newPostsArchiveCmd(f)
+	cmd.SetArgs(args)
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	return cmd.Execute()
+}
+
+func TestPostsArchive_Resume(t *testing.T) {
+	dir := t.TempDir()
+	var cursors []string
+	failSecond := true
+	mock := archivePages(&cursors, &failSecond)
+
+	err := runArchive(t, mock, dir)
+	if err == nil || !strings.Contains(err.Error(), "interrupted after 2 posts") {
+		t.Fatalf("expected the archive to be interrupted, got %v", err)
+	}
+	checkpoint, err := readArchiveCheckpoint(dir)
+	if err != nil || checkpoint == nil {
+		t.Fatalf("expected a checkpoint, got %v", err)
+	}
+	if checkpoint.Cursor != "page2" || checkpoint.LastPostID != "2" || checkpoint.Posts != 2 || checkpoint.Complete {
+		t.Errorf("unexpected checkpoint: %+v", checkpoint)
+	}
+
+	if err := runArchive(t, mock, dir); err == nil || !strings.Contains(err.Error(), "--resume") {
+		t.Errorf("expected starting over an unfinished archive to be refused, got %v", err)
+	}
+
+	failSecond = false
+	cursors = nil
+	if err := runArchive(t, mock, dir, "--resume"); err != nil {
+		t.Fatalf("resume failed: %v", err)
+	}
+	if len(cursors) != 1 || cursors[0] != "page2" {
+		t.Errorf("expected resume to fetch only the page after the checkpoint, got cursors %q", cursors)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, archivePostsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[2], `"id":"3"`) {
+		t.Errorf("expected 3 archived posts, got:\n%s", data)
+	}
+	checkpoint, _ = readArchiveCheckpoint(dir)
+	if !checkpoint.Complete || checkpoint.Posts != 3 || checkpoint.Pages != 2 {
+		t.Errorf("expected a complete checkpoint, got %+v", checkpoint)
+	}
+}
+
+func TestPostsArchive_ResumeWithoutCheckpoint(t *testing.T) {
+	err := runArchive(t, &mockAPI{}, t.TempDir(), "--resume")
+	if err == nil || !strings.Contains(err.Error(), "No archive to resume") {
+		t.Errorf("expected an error, got %v", err)
+	}
+}
+
+func TestPostsArchive_Redact(t *testing.T) {
+	dir := t.TempDir()
+	var cursors []string
+	failSecond := true
+	mock := archivePages(&cursors, &failSecond)
+	getUserPosts := mock.getUserPosts
+	mock.getUserPosts = func(ctx context.Context, id api.UserID, opts *api.PostsOptions) (*api.PostsResponse, error) {
+		resp, err := getUserPosts(ctx, id, opts)
+		if resp != nil {
+			for i := range resp.Data {
+				resp.Data[i].Username = "alice"
+				resp.Data[i].Text += " @bob https://example.com bob@example.com"
+				resp.Data[i].Permalink = "https://www.threads.net/@alice/post/" + resp.Data[i].ID
+			}
+		}
+		return resp, err
+	}
+
+	if err := runArchive(t, mock, dir, "--redact"); err == nil {
+		t.Fatal("expected the archive to be interrupted")
+	}
+	failSecond = false
+	if err := runArchive(t, mock, dir, "--resume"); err != nil {
+		t.Fatalf("resume failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, archivePostsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leaked := range []string{"alice", "bob", "example.com", "threads.net"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("expected %q to be redacted, got:\n%s", leaked, data)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 archived posts, got:\n%s", data)
+	}
+	var first, last api.Post
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &last); err != nil {
+		t.Fatal(err)
+	}
+	if first.Username == "" || first.Username != last.Username {
+		t.Errorf("expected one pseudonym across the resumed run, got %q and %q", first.Username, last.Username)
+	}
+}
+
+func TestTruncateLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), archivePostsFile)
+	if err := os.WriteFile(path, []byte("{\"id\":\"1\"}\n{\"id\":\"2\"}\n{\"id\":"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := truncateLines(path, 1); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "{\"id\":\"1\"}\n" {
+		t.Errorf("unexpected content after truncate: %q", data)
+	}
+
+	if err := truncateLines(path, 5); err == nil {
+		t.Error("expected an error for a file shorter than the checkpoint")
+	}
+	if err := truncateLines(filepath.Join(t.TempDir(), "missing"), 0); err != nil {
+		t.Errorf("expected a missing file to be fine for 0 lines, got %v", err)
+	}
+}
+
+func TestPostsArchive_MaxAPICalls(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "refresh_access_token"):
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+		case strings.HasSuffix(r.URL.Path, "/threads"):
+			after := r.URL.Query().Get("after")
+			pages = append(pages, after)
+			if after == "" {
+				_, _ = w.Write([]byte(`{"data":[{"id":"1"},{"id":"2"}],"paging":{"cursors":{"after":"page2"}}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":"3"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"12345","username":"me"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	f, io := newIntegrationTestFactory(t, server.URL)
+	dir := t.TempDir()
+
+	run := func(args ...string) error {
+		cmd := newPostsArchiveCmd(f)
+		cmd.SetArgs(append([]string{dir}, args...))
+		cmd.SetContext(iocontext.WithIO(context.Background(), io))
+		return cmd.Execute()
+	}
+
+	// The token refresh, the profile and the first page
+	if err := run("--max-api-calls", "3"); err != nil {
+		t.Fatalf("expected a clean stop at the cap, got %v", err)
+	}
+	if out := io.Out.(*bytes.Buffer).String(); !strings.Contains(out, "--max-api-calls 3 reached") {
+		t.Errorf("expected the cap to be reported, got %q", out)
+	}
+	checkpoint, _ := readArchiveCheckpoint(dir)
+	if checkpoint == nil || checkpoint.Complete || checkpoint.Posts != 2 || checkpoint.Cursor != "page2" {
+		t.Fatalf("expected a checkpoint after the first page, got %+v", checkpoint)
+	}
+
+	if err := run("--resume"); err != nil {
+		t.Fatalf("resume failed: %v", err)
+	}
+	if len(pages) != 2 || pages[1] != "page2" {
+		t.Errorf("expected resume to continue at the saved cursor, got %q", pages)
+	}
+	if checkpoint, _ = readArchiveCheckpoint(dir); !checkpoint.Complete || checkpoint.Posts != 3 {
+		t.Errorf("expected a complete archive, got %+v", checkpoint)
+	}
+}
+
+func TestRunBudget_Spent(t *testing.T) {
+	opts := &budgetOptions{MaxDownloadBytes: "1KB"}
+	_, budget, err := opts.start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	budget.downloaded(512)
+	if got := budget.spent(); got != "" {
+		t.Errorf("expected room left, got %q", got)
+	}
+	budget.downloaded(600)
+	if got := budget.spent(); !strings.Contains(got, "--max-download-bytes") {
+		t.Errorf("expected the download cap to be spent, got %q", got)
+	}
+
+	for _, bad := range []budgetOptions{{MaxAPICalls: -1}, {MaxDownloadBytes: "lots"}} {
+		if _, _, err := bad.start(context.Background()); err == nil {
+			t.Errorf("expected %+v to be rejected", bad)
+		}
+	}
+}
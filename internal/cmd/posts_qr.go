@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/qr"
+)
+
+// shortenerClient calls the configured URL shortener
+var shortenerClient = &http.Client{Timeout: 10 * time.Second}
+
+// maxShortURLLength bounds the shortener response read as the short URL
+const maxShortURLLength = 2048
+
+type postsQROptions struct {
+	PNG    string
+	Scale  int
+	Invert bool
+	Short  bool
+}
+
+func newPostsQRCmd(f *Factory) *cobra.Command {
+	opts := &postsQROptions{Scale: 8}
+
+	cmd := &cobra.Command{
+		Use:   "qr [post-id|url]",
+		Short: "Show a QR code for a post's permalink",
+		Long: `Render the permalink of a post as a QR code, for slides and print.
+
+The code is drawn in the terminal by default. Filled cells are the light
+modules, which scans on dark terminals; use --invert on light backgrounds.
+--png writes an image instead, black on white, --scale pixels per module.
+
+--short shortens the link first with the endpoint in the 'shortener' config
+key. {url} in it is replaced by the escaped permalink, and the response body
+must be the short URL:
+
+  threads config set shortener "https://is.gd/create.php?format=simple&url={url}"
+
+Examples:
+  threads posts qr 12345
+  threads posts qr https://www.threads.net/@someone/post/C8abcDEFghi --invert
+  threads posts qr 12345 --short --png qr.png`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPostsQR(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.PNG, "png", "", "Write a PNG image to this file")
+	cmd.Flags().IntVar(&opts.Scale, "scale", opts.Scale, "Pixels per module for --png")
+	cmd.Flags().BoolVar(&opts.Invert, "invert", false, "Draw dark modules filled, for light backgrounds")
+	cmd.Flags().BoolVar(&opts.Short, "short", false, "Shorten the link with the configured shortener")
+	return cmd
+}
+
+func runPostsQR(cmd *cobra.Command, f *Factory, target string, opts *postsQROptions) error {
+	ctx := cmd.Context()
+
+	if opts.Scale < 1 || opts.Scale > 64 {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --scale: %d", opts.Scale),
+			Suggestion: "Use a scale between 1 and 64",
+		}
+	}
+
+	permalink, err := postPermalink(ctx, f, target)
+	if err != nil {
+		return err
+	}
+
+	link := permalink
+	if opts.Short {
+		link, err = shortenURL(ctx, f.Config.Shortener, permalink)
+		if err != nil {
+			return err
+		}
+	}
+
+	code, err := qr.Encode(link)
+	if err != nil {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("URL is too long for a QR code: %d characters", len(link)),
+			Suggestion: "Use --short to shorten it first",
+			Cause:      err,
+		}
+	}
+
+	if opts.PNG != "" {
+		var buf bytes.Buffer
+		if err := code.WritePNG(&buf, opts.Scale); err != nil {
+			return WrapError("failed to render QR code", err)
+		}
+		if err := os.WriteFile(opts.PNG, buf.Bytes(), 0o644); err != nil { //nolint:gosec // An image the user asked for
+			return WrapError("failed to write QR code", err)
+		}
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		result := map[string]any{
+			"url":       link,
+			"permalink": permalink,
+			"version":   code.Version,
+		}
+		if opts.PNG != "" {
+			result["png"] = opts.PNG
+		}
+		return outfmt.WriteJSONContext(ctx, io.Out, result)
+	}
+
+	if opts.PNG != "" {
+		f.UI(ctx).Success("Wrote QR code for %s to %s", link, opts.PNG)
+		return nil
+	}
+	fmt.Fprint(io.Out, code.Text(opts.Invert)) //nolint:errcheck // Best-effort output
+	fmt.Fprintln(io.Out, link)                 //nolint:errcheck // Best-effort output
+	return nil
+}
+
+// postPermalink returns target itself when it is a URL, otherwise the
+// permalink of the post with that ID
+func postPermalink(ctx context.Context, f *Factory, target string) (string, error) {
+	if strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "http://") {
+		return target, nil
+	}
+
+	client, err := f.Client(ctx)
+	if err != nil {
+		return "", err
+	}
+	post, err := client.GetPost(ctx, api.PostID(target))
+	if err != nil {
+		return "", WrapError("failed to get post", err)
+	}
+	if post.Permalink == "" {
+		return "", &UserFriendlyError{Message: fmt.Sprintf("Post %s has no permalink", target)}
+	}
+	return post.Permalink, nil
+}
+
+// shortenURL shortens long with the shortener endpoint template; see the
+// 'shortener' config key
+func shortenURL(ctx context.Context, endpoint, long string) (string, error) {
+	if endpoint == "" {
+		return "", &UserFriendlyError{
+			Message:    "No URL shortener configured",
+			Suggestion: `Set one with 'threads config set shortener "https://is.gd/create.php?format=simple&url={url}"'`,
+		}
+	}
+	if !strings.Contains(endpoint, "{url}") {
+		return "", &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid shortener: %s", endpoint),
+			Suggestion: "The shortener must contain {url} where the link goes",
+		}
+	}
+
+	reqURL := strings.ReplaceAll(endpoint, "{url}", url.QueryEscape(long))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return "", WrapError("invalid shortener URL", err)
+	}
+	resp, err := shortenerClient.Do(req)
+	if err != nil {
+		return "", WrapError("failed to shorten URL", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // Response already read
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxShortURLLength+1))
+	if err != nil {
+		return "", WrapError("failed to shorten URL", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", &UserFriendlyError{
+			Message:    fmt.Sprintf("URL shortener returned %s", resp.Status),
+			Suggestion: "Check the 'shortener' config key",
+		}
+	}
+
+	short := strings.TrimSpace(string(body))
+	parsed, err := url.Parse(short)
+	if len(body) > maxShortURLLength || err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return "", &UserFriendlyError{
+			Message:    "URL shortener did not return a URL",
+			Suggestion: "The shortener must answer with the short URL as plain text",
+		}
+	}
+	return short, nil
+}
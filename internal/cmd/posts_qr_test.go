@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+const testPermalink = "https://www.threads.net/@test/post/C8abcDEFghi"
+
+func runQRCmd(t *testing.T, f *Factory, io *iocontext.IO, format string, args ...string) error {
+	t.Helper()
+	cmd := newPostsQRCmd(f)
+	cmd.SetArgs(args)
+	cmd.SetContext(outfmt.WithFormat(iocontext.WithIO(context.Background(), io), format))
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return cmd.Execute()
+}
+
+func TestPostsQR_PostID(t *testing.T) {
+	f, io := newMockAPITestFactory(t, &mockAPI{
+		getPost: func(_ context.Context, id api.PostID) (*api.Post, error) {
+			return &api.Post{ID: string(id), Permalink: testPermalink}, nil
+		},
+	})
+
+	if err := runQRCmd(t, f, io, "text", "12345"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := io.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, "▀") || !strings.HasSuffix(out, testPermalink+"\n") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestPostsQR_URLNeedsNoAPI(t *testing.T) {
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	if err := runQRCmd(t, f, io, "json", testPermalink); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := io.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, `"url": "`+testPermalink+`"`) || !strings.Contains(out, `"version": 4`) {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestPostsQR_PNG(t *testing.T) {
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	path := filepath.Join(t.TempDir(), "qr.png")
+
+	if err := runQRCmd(t, f, io, "text", testPermalink, "--png", path, "--scale", "2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close() //nolint:errcheck // Test cleanup
+	img, err := png.Decode(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Version 4 is 33 modules, plus a quiet zone of 4 on each side
+	if got := img.Bounds().Dx(); got != (33+8)*2 {
+		t.Errorf("image width = %d", got)
+	}
+}
+
+func TestPostsQR_Short(t *testing.T) {
+	var gotURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL.Query().Get("url")
+		w.Write([]byte("https://sho.rt/abc\n")) //nolint:errcheck // Test server
+	}))
+	defer server.Close()
+
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	f.Config.Shortener = server.URL + "/create?format=simple&url={url}"
+
+	if err := runQRCmd(t, f, io, "text", testPermalink, "--short"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotURL != testPermalink {
+		t.Errorf("shortener got url %q", gotURL)
+	}
+	if out := io.Out.(*bytes.Buffer).String(); !strings.HasSuffix(out, "https://sho.rt/abc\n") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestShortenURL_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			http.Error(w, "nope", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("Error: invalid URL")) //nolint:errcheck // Test server
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		endpoint string
+		wantErr  string
+	}{
+		{"not configured", "", "No URL shortener configured"},
+		{"missing placeholder", server.URL + "/create", "Invalid shortener"},
+		{"http error", server.URL + "/fail?url={url}", "URL shortener returned 400"},
+		{"not a url", server.URL + "/create?url={url}", "did not return a URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := shortenURL(context.Background(), tt.endpoint, testPermalink)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
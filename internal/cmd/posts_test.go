@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/salmonumbrella/threads-cli/internal/api"
 	"github.com/salmonumbrella/threads-cli/internal/iocontext"
 	"github.com/salmonumbrella/threads-cli/internal/outfmt"
 )
@@ -41,6 +43,8 @@ func TestPostsCmd_Subcommands(t *testing.T) {
 		"repost":     true,
 		"unrepost":   true,
 		"ghost-list": true,
+		"qr":         true,
+		"archive":    true,
 	}
 
 	for _, sub := range cmd.Commands() {
@@ -75,6 +79,7 @@ func TestPostsCreateCmd_Flags(t *testing.T) {
 		{"location", ""},
 		{"reply-control", ""},
 		{"gif", ""},
+		{"schedule", ""},
 	}
 
 	for _, f := range flags {
@@ -119,6 +124,53 @@ func TestPostsListCmd_Structure(t *testing.T) {
 	}
 }
 
+func TestPostsList_AllFollowsCursors(t *testing.T) {
+	pages := map[string]*api.PostsResponse{
+		"": {
+			Data:   []api.Post{{ID: "1", MediaType: "TEXT"}, {ID: "2", MediaType: "TEXT"}},
+			Paging: api.Paging{Cursors: &api.PagingCursors{After: "c1"}},
+		},
+		"c1": {
+			Data: []api.Post{{ID: "3", MediaType: "TEXT"}},
+		},
+	}
+	var cursors []string
+	mock := &mockAPI{
+		getMe: func(context.Context) (*api.User, error) {
+			return &api.User{ID: "12345"}, nil
+		},
+		getUserPosts: func(_ context.Context, _ api.UserID, opts *api.PostsOptions) (*api.PostsResponse, error) {
+			cursors = append(cursors, opts.After)
+			return pages[opts.After], nil
+		},
+	}
+
+	f, io := newMockAPITestFactory(t, mock)
+
+	cmd := newPostsListCmd(f)
+	cmd.SetArgs([]string{"--all", "--limit", "2"})
+	ctx := iocontext.WithIO(context.Background(), io)
+	cmd.SetContext(outfmt.NewContext(ctx, outfmt.JSON))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	if strings.Join(cursors, ",") != ",c1" {
+		t.Errorf("expected cursors [\"\" c1], got %q", cursors)
+	}
+
+	var out struct {
+		Posts []api.Post `json:"posts"`
+	}
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if len(out.Posts) != 3 {
+		t.Errorf("expected 3 posts across pages, got %d", len(out.Posts))
+	}
+}
+
 func TestPostsDeleteCmd_Structure(t *testing.T) {
 	f := newTestFactory(t)
 	cmd := newPostsDeleteCmd(f)
@@ -288,6 +340,61 @@ func TestPostsCarouselCmd_HasExample(t *testing.T) {
 	}
 }
 
+func TestPostsCarousel_PreflightReportsAllItems(t *testing.T) {
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newPostsCarouselCmd(f)
+	cmd.SetArgs([]string{"--items", "ftp://example.com/a.jpg,https://example.com/b.png,not-a-url"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	// The mock has no CreateMediaContainer, so reaching the API would panic
+	err := cmd.Execute()
+	var ufErr *UserFriendlyError
+	if !errors.As(err, &ufErr) {
+		t.Fatalf("expected UserFriendlyError, got %T: %v", err, err)
+	}
+	for _, want := range []string{"item 1:", "item 3:"} {
+		if !strings.Contains(ufErr.Message, want) {
+			t.Errorf("expected message to contain %q, got %q", want, ufErr.Message)
+		}
+	}
+	if strings.Contains(ufErr.Message, "item 2:") {
+		t.Errorf("valid item reported as a violation: %q", ufErr.Message)
+	}
+}
+
+func TestPostsCreate_ContainerErrorIsFormatted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "refresh_access_token"):
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/threads"):
+			_, _ = w.Write([]byte(`{"id":"c1"}`))
+		case strings.HasSuffix(r.URL.Path, "/c1"):
+			_, _ = w.Write([]byte(`{"id":"c1","status":"ERROR","error_message":"unsupported codec"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	f, io := newIntegrationTestFactory(t, server.URL)
+
+	cmd := newPostsCreateCmd(f)
+	cmd.SetArgs([]string{"--video", "https://example.com/v.mp4"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	err := cmd.Execute()
+	var ufErr *UserFriendlyError
+	if !errors.As(err, &ufErr) {
+		t.Fatalf("expected UserFriendlyError, got %T: %v", err, err)
+	}
+	if !strings.Contains(ufErr.Message, "Media processing failed: unsupported codec") {
+		t.Errorf("unexpected message: %q", ufErr.Message)
+	}
+}
+
 func TestDetectMediaType_Image(t *testing.T) {
 	tests := []struct {
 		url      string
@@ -439,6 +546,34 @@ func TestPostsGet_Success(t *testing.T) {
 	}
 }
 
+func TestPostsGet_WithMockAPI(t *testing.T) {
+	var requested api.PostID
+	mock := &mockAPI{
+		getPost: func(_ context.Context, postID api.PostID) (*api.Post, error) {
+			requested = postID
+			return &api.Post{ID: string(postID), Username: "mockuser", MediaType: "TEXT"}, nil
+		},
+	}
+
+	f, io := newMockAPITestFactory(t, mock)
+
+	cmd := newPostsGetCmd(f)
+	cmd.SetArgs([]string{"999"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	if requested != "999" {
+		t.Errorf("expected GetPost to be called with 999, got %q", requested)
+	}
+	output := io.Out.(*bytes.Buffer).String()
+	if !strings.Contains(output, "@mockuser") {
+		t.Errorf("output missing username, got: %s", output)
+	}
+}
+
 func TestPostsGet_NotFound(t *testing.T) {
 	// Create mock server that returns 404
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,358 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/media"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
+)
+
+// configDir and keyringFileDir locate local state outside the cache. They
+// are replaced in tests.
+var (
+	configDir      = config.ConfigDir
+	keyringFileDir = secrets.FileDir
+)
+
+// localData is one kind of state the CLI keeps on this machine
+type localData struct {
+	Name     string
+	Contents string
+	Location string
+	// Paths are the files and directories holding it
+	Paths []string
+	// Accounts are the stored accounts, for credentials only
+	Accounts []string
+	// Kept says why purge-local leaves it in place; empty means it is removed
+	Kept string
+
+	files int
+	bytes int64
+}
+
+func (d *localData) entries() string {
+	if d.Name == "credentials" {
+		return pluralize(len(d.Accounts), "account", "accounts")
+	}
+	return pluralize(d.files, "file", "files")
+}
+
+func (d *localData) empty() bool {
+	return d.files == 0 && len(d.Accounts) == 0
+}
+
+func (d *localData) viewFields() []viewField {
+	entries := len(d.Accounts)
+	if d.Name != "credentials" {
+		entries = d.files
+	}
+	purged := viewField{Key: "purged", Value: d.Kept == ""}
+	if d.Kept != "" {
+		purged.Text = "no, " + d.Kept
+	}
+	return []viewField{
+		{Key: "item", Value: d.Name},
+		{Key: "contents", Value: d.Contents},
+		{Key: "location", Value: d.Location},
+		{Key: "entries", Value: entries, Text: d.entries()},
+		{Key: "bytes", Value: d.bytes, Text: media.FormatSize(d.bytes)},
+		{Key: "accounts", Value: d.Accounts},
+		purged,
+	}
+}
+
+func pluralize(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
+
+// collectLocalData lists everything the CLI stores on this machine, with
+// the accounts in store and the size on disk of each item. Files inside the
+// paths of another item, such as the audit log in the config directory on
+// macOS, count only for that item.
+func collectLocalData(store secrets.Store) ([]*localData, error) {
+	accounts, err := store.List()
+	if err != nil {
+		return nil, WrapError("failed to list accounts", err)
+	}
+	if accounts == nil {
+		accounts = []string{}
+	}
+
+	cfgPaths := []string{configDir()}
+	if path := config.ConfigPath(); !isWithin(path, configDir()) {
+		cfgPaths = append(cfgPaths, path)
+	}
+
+	items := []*localData{
+		{
+			Name:     "credentials",
+			Contents: "Access tokens, app secrets, user IDs and granted scopes of each account, and the SMTP password of email notifications",
+			Location: "system keyring, or encrypted files in " + keyringFileDir(),
+			Paths:    []string{keyringFileDir()},
+			Accounts: accounts,
+		},
+		{
+			Name:     "config",
+			Contents: "Settings, account labels, mute lists and backups from config upgrades",
+			Location: strings.Join(cfgPaths, ", "),
+			Paths:    cfgPaths,
+		},
+		{
+			Name:     "audit",
+			Contents: "Log of commands that changed posts, replies, webhooks or credentials, with secrets redacted",
+			Location: auditPath(),
+			Paths:    []string{auditPath()},
+		},
+		{
+			Name:     "bookmarks",
+			Contents: "Posts saved with 'bookmarks add' and a snapshot of each",
+			Location: bookmarksPath(),
+			Paths:    []string{bookmarksPath()},
+		},
+		{
+			Name:     "notes",
+			Contents: "Private notes on users and posts added with 'notes add'",
+			Location: notesPath(),
+			Paths:    []string{notesPath()},
+		},
+		{
+			Name:     "queue",
+			Contents: "Posts waiting to be published, with their text and due time",
+			Location: queuePath(),
+			Paths:    []string{queuePath()},
+		},
+		{
+			Name:     "series",
+			Contents: "Counters, templates and post IDs of numbered series",
+			Location: seriesPath(),
+			Paths:    []string{seriesPath()},
+		},
+		{
+			Name:     "carousels",
+			Contents: "Media URLs and container IDs of carousels that failed midway, for --resume",
+			Location: carouselStateDir(),
+			Paths:    []string{carouselStateDir()},
+		},
+	}
+	for _, c := range cacheCategories {
+		items = append(items, &localData{
+			Name:     "cache " + c.Name,
+			Contents: c.Contents,
+			Location: c.Dir(),
+			Paths:    []string{c.Dir()},
+		})
+	}
+	items = append(items, &localData{
+		Name:     "policy",
+		Contents: "Commands allowed or denied on this machine",
+		Location: policyPath(),
+		Paths:    []string{policyPath()},
+		Kept:     "managed by the machine's administrator",
+	})
+
+	for _, item := range items {
+		var others []string
+		for _, other := range items {
+			if other != item {
+				others = append(others, other.Paths...)
+			}
+		}
+		for _, path := range item.Paths {
+			if err := item.measure(path, others); err != nil {
+				return nil, WrapError(fmt.Sprintf("failed to read %s", path), err)
+			}
+		}
+	}
+	return items, nil
+}
+
+// measure adds the files under path to the item's totals, leaving out the
+// paths in skip
+func (d *localData) measure(path string, skip []string) error {
+	return filepath.WalkDir(path, func(p string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, s := range skip {
+			if p == s && p != path {
+				if entry.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		d.files++
+		d.bytes += info.Size()
+		return nil
+	})
+}
+
+// isWithin reports whether path is dir or inside it
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// NewPrivacyCmd builds the privacy command group.
+func NewPrivacyCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "privacy",
+		Short: "Show or remove what threads stores on this machine",
+		Long: `Show or remove the data threads keeps on this machine: credentials,
+configuration, the audit log, bookmarks, notes and the cache.
+
+Archives and download directories are written where you choose and are not
+tracked, so neither command covers them. Nothing here changes your account
+or posts on Threads.`,
+	}
+
+	cmd.AddCommand(newPrivacyReportCmd(f))
+	cmd.AddCommand(newPrivacyPurgeLocalCmd(f))
+
+	return cmd
+}
+
+func newPrivacyReportCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "report",
+		Short: "List what threads stores where",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := f.Store()
+			if err != nil {
+				return FormatError(err)
+			}
+			items, err := collectLocalData(store)
+			if err != nil {
+				return err
+			}
+			return writeViewList(cmd.Context(), items, nil, "No local data")
+		},
+	}
+}
+
+func newPrivacyPurgeLocalCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "purge-local",
+		Short: "Remove all local data: credentials, config, audit log, bookmarks, notes and cache",
+		Long: `Remove every account's credentials, the configuration, the audit log,
+bookmarks, notes and all cached data from this machine, after listing each
+item for confirmation.
+Requires confirmation unless --yes is set.
+
+The command policy belongs to the machine's administrator and is kept.`,
+		Example: `  threads privacy report
+  threads privacy purge-local`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPrivacyPurgeLocal(cmd, f)
+		},
+	}
+}
+
+func runPrivacyPurgeLocal(cmd *cobra.Command, f *Factory) error {
+	ctx := cmd.Context()
+	io := iocontext.GetIO(ctx)
+
+	store, err := f.Store()
+	if err != nil {
+		return FormatError(err)
+	}
+	items, err := collectLocalData(store)
+	if err != nil {
+		return err
+	}
+
+	var selected []*localData
+	for _, item := range items {
+		if item.Kept == "" && !item.empty() {
+			selected = append(selected, item)
+		}
+	}
+	if len(selected) == 0 {
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{"removed": []any{}})
+		}
+		f.UI(ctx).Success("No local data to remove")
+		return nil
+	}
+
+	if !outfmt.IsJSON(ctx) {
+		fmt.Fprintln(io.ErrOut, "This removes:") //nolint:errcheck // Best-effort output to stderr
+		for _, item := range selected {
+			detail := item.entries()
+			if len(item.Accounts) > 0 {
+				detail += " (" + strings.Join(item.Accounts, ", ") + ")"
+			}
+			if item.bytes > 0 {
+				detail += ", " + media.FormatSize(item.bytes)
+			}
+			fmt.Fprintf(io.ErrOut, "  %-13s %s in %s\n", item.Name, detail, item.Location) //nolint:errcheck // Best-effort output to stderr
+		}
+	}
+	confirmed, err := f.Confirm(ctx, "Remove all local threads data?")
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Fprintln(io.Out, "Cancelled.") //nolint:errcheck // Best-effort output
+		return nil
+	}
+
+	type removed struct {
+		Item     string   `json:"item"`
+		Files    int      `json:"files"`
+		Bytes    int64    `json:"bytes"`
+		Accounts []string `json:"accounts,omitempty"`
+	}
+	var results []removed
+	for _, item := range selected {
+		// Credentials may live in the system keyring rather than in files
+		for _, account := range item.Accounts {
+			if err := store.Delete(account); err != nil {
+				return WrapError(fmt.Sprintf("failed to remove account %q", account), err)
+			}
+		}
+		for _, path := range item.Paths {
+			if err := os.RemoveAll(path); err != nil {
+				return WrapError(fmt.Sprintf("failed to remove %s", path), err)
+			}
+		}
+		results = append(results, removed{Item: item.Name, Files: item.files, Bytes: item.bytes, Accounts: item.Accounts})
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{"removed": results})
+	}
+	for _, r := range results {
+		if r.Item == "credentials" {
+			f.UI(ctx).Success("Removed credentials of %s", pluralize(len(r.Accounts), "account", "accounts"))
+			continue
+		}
+		f.UI(ctx).Success("Removed %s: %s (%s)", r.Item, pluralize(r.Files, "file", "files"), media.FormatSize(r.Bytes))
+	}
+	return nil
+}
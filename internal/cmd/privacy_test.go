@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
+)
+
+// useTempLocalData points every local data location at a temporary
+// directory and fills the config, file keyring, audit log, seen cache and
+// policy with one file each
+func useTempLocalData(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	cfg := filepath.Join(root, "config")
+	locations := map[string]*func() string{
+		cfg:                                   &configDir,
+		filepath.Join(cfg, "keyring"):         &keyringFileDir,
+		filepath.Join(root, "cache"):          &cacheDir,
+		filepath.Join(root, "audit.jsonl"):    &auditPath,
+		filepath.Join(root, "bookmarks.json"): &bookmarksPath,
+		filepath.Join(root, "notes.json"):     &notesPath,
+		filepath.Join(root, "queue.json"):     &queuePath,
+		filepath.Join(root, "series.json"):    &seriesPath,
+		filepath.Join(root, "carousels"):      &carouselStateDir,
+		filepath.Join(root, "policy.json"):    &policyPath,
+	}
+	for path, location := range locations {
+		orig := *location
+		*location = func() string { return path }
+		t.Cleanup(func() { *location = orig })
+	}
+	t.Setenv("THREADS_CONFIG", filepath.Join(cfg, "config.json"))
+
+	for _, path := range []string{
+		filepath.Join(cfg, "config.json"),
+		filepath.Join(cfg, "keyring", "account:test-user"),
+		filepath.Join(root, "cache", "seen", "mentions.json"),
+		filepath.Join(root, "audit.jsonl"),
+		filepath.Join(root, "policy.json"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("{}"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// deletingStore records the accounts removed from it
+type deletingStore struct {
+	mockCredentialsStore
+	deleted []string
+}
+
+func (d *deletingStore) Delete(name string) error {
+	d.deleted = append(d.deleted, name)
+	return nil
+}
+
+func TestPrivacyReport_ListsLocalData(t *testing.T) {
+	useTempLocalData(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newPrivacyReportCmd(f)
+	cmd.SetArgs(nil)
+	cmd.SetContext(outfmt.WithFormat(iocontext.WithIO(context.Background(), io), "json"))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	var result struct {
+		Data []map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	items := map[string]map[string]any{}
+	for _, item := range result.Data {
+		items[item["item"].(string)] = item
+	}
+	if len(items) != 9+len(cacheCategories) {
+		t.Errorf("unexpected items: %v", result.Data)
+	}
+	// The file keyring inside the config directory counts only for credentials
+	for name, entries := range map[string]float64{"credentials": 1, "config": 1, "audit": 1, "bookmarks": 0, "notes": 0, "queue": 0, "series": 0, "carousels": 0, "cache seen": 1, "cache media": 0, "policy": 1} {
+		if items[name]["entries"] != entries {
+			t.Errorf("%s: expected %v entries, got %v", name, entries, items[name])
+		}
+	}
+	if items["policy"]["purged"] != false || items["audit"]["purged"] != true {
+		t.Errorf("expected only the policy to be kept: %v", result.Data)
+	}
+}
+
+func TestPrivacyPurgeLocal_RemovesAll(t *testing.T) {
+	root := useTempLocalData(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	store := &deletingStore{}
+	f.Store = func() (secrets.Store, error) { return store, nil }
+
+	cmd := newPrivacyPurgeLocalCmd(f)
+	cmd.SetArgs(nil)
+	cmd.SetContext(outfmt.WithYes(iocontext.WithIO(context.Background(), io), true))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	if len(store.deleted) != 1 || store.deleted[0] != "test-user" {
+		t.Errorf("expected the account to be removed, got %v", store.deleted)
+	}
+	for _, path := range []string{"config", "audit.jsonl", filepath.Join("cache", "seen")} {
+		if _, err := os.Stat(filepath.Join(root, path)); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed, got %v", path, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "policy.json")); err != nil {
+		t.Errorf("expected the policy to be kept, got %v", err)
+	}
+
+	listing := io.ErrOut.(*bytes.Buffer).String()
+	for _, want := range []string{"credentials", "1 account (test-user)", "cache seen", "audit"} {
+		if !strings.Contains(listing, want) {
+			t.Errorf("expected the itemized list to contain %q, got:\n%s", want, listing)
+		}
+	}
+	if strings.Contains(listing, "policy") {
+		t.Errorf("expected the policy not to be listed, got:\n%s", listing)
+	}
+}
+
+func TestPrivacyPurgeLocal_NotConfirmed(t *testing.T) {
+	root := useTempLocalData(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	store := &deletingStore{}
+	f.Store = func() (secrets.Store, error) { return store, nil }
+
+	cmd := newPrivacyPurgeLocalCmd(f)
+	cmd.SetArgs(nil)
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	if len(store.deleted) != 0 {
+		t.Errorf("expected no account to be removed, got %v", store.deleted)
+	}
+	if _, err := os.Stat(filepath.Join(root, "audit.jsonl")); err != nil {
+		t.Errorf("expected the audit log to be kept, got %v", err)
+	}
+}
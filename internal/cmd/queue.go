@@ -0,0 +1,602 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/events"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/queue"
+)
+
+const (
+	// defaultQueueInterval is how often the queue daemon checks for due posts
+	defaultQueueInterval = time.Minute
+	// queueStaleAfter is how long a post may stay claimed by a runner before
+	// the runner is taken to have stopped midway; it leaves room for the
+	// container timeout and the client's retries
+	queueStaleAfter = 30 * time.Minute
+)
+
+// queuePath is the post queue location. It is replaced in tests.
+var queuePath = func() string {
+	return filepath.Join(config.DataDir(), queue.FileName)
+}
+
+// queueSleep waits between runs of the queue daemon. It is replaced in
+// tests.
+var queueSleep = watchSleep
+
+// queueItemView is the output model of a queued post
+type queueItemView struct {
+	Item queue.Item
+}
+
+func (v queueItemView) viewFields() []viewField {
+	item := v.Item
+	result := item.Permalink
+	if item.Status != queue.StatusPublished {
+		result = item.Error
+	}
+	return []viewField{
+		{Key: "id", Value: item.ID, Type: outfmt.ColumnID},
+		{Key: "due", Value: item.At, Text: queueDue(item), Type: outfmt.ColumnDate},
+		{Key: "status", Value: item.Status, Type: outfmt.ColumnStatus},
+		{Key: "account", Value: item.Account, Text: fallback(item.Account, "-")},
+		{Key: "approved", Value: item.Approval != nil, Text: queueApproved(item)},
+		{Key: "text", Value: item.Text(), Text: truncateText(strings.ReplaceAll(item.Text(), "\n", " "), 40)},
+		{Key: "post_id", Value: item.PostID},
+		{Key: "result", Value: result, Text: truncateText(result, 50)},
+	}
+}
+
+// queueDue formats when item is due in the zone it was scheduled in
+func queueDue(item queue.Item) string {
+	return item.At.In(item.Location(time.Local)).Format("2006-01-02 15:04 MST")
+}
+
+// queueApproved says whether item was approved, and whether it changed
+// since
+func queueApproved(item queue.Item) string {
+	switch {
+	case item.Approval == nil:
+		return "-"
+	case item.CheckApproval() != nil:
+		return "modified"
+	}
+	return "yes"
+}
+
+// enqueuePosts adds items to the post queue
+func enqueuePosts(items ...queue.Item) error {
+	err := queue.Update(queuePath(), func(queued []queue.Item) ([]queue.Item, error) {
+		return append(queued, items...), nil
+	})
+	if err != nil {
+		return WrapError("failed to save the post queue", err)
+	}
+	return nil
+}
+
+// schedulePost queues content to be published at at, shown in loc, and
+// says so
+func schedulePost(ctx context.Context, f *Factory, content any, at time.Time, loc *time.Location) error {
+	item, err := scheduleContent(f, content, at, loc)
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, item)
+	}
+	p := f.UI(ctx)
+	p.Success("Post scheduled for %s as %s", queueDue(*item), item.ID)
+	p.Info("It is published by 'threads queue daemon', or 'threads queue run' from cron")
+	return nil
+}
+
+// scheduleContent adds content to the post queue as the current account
+func scheduleContent(f *Factory, content any, at time.Time, loc *time.Location) (*queue.Item, error) {
+	item := queue.Item{
+		ID:        queue.NewID(),
+		At:        at.UTC(),
+		Zone:      loc.String(),
+		CreatedAt: time.Now().UTC(),
+		Account:   f.currentAccountName(),
+		Status:    queue.StatusPending,
+	}
+	switch c := content.(type) {
+	case *api.TextPostContent:
+		item.TextPost = c
+	case *api.ImagePostContent:
+		item.ImagePost = c
+	case *api.VideoPostContent:
+		item.VideoPost = c
+	default:
+		return nil, fmt.Errorf("cannot schedule %T", content)
+	}
+	if err := enqueuePosts(item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// NewQueueCmd builds the queue command group.
+func NewQueueCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Manage posts scheduled for later",
+		Long: `List, cancel and publish the posts waiting in the local queue.
+
+Posts are added with 'threads posts create --schedule' and 'threads recycle
+queue', and kept in the data directory until they are published. Nothing is
+sent to Threads until 'threads queue run' or 'threads queue daemon' finds
+them due; a post that fell due while neither was running is published on
+the next run.
+
+Times are stored as absolute instants, so changing the machine's timezone
+or crossing a daylight saving change does not move a post. Each post is
+shown in the timezone it was scheduled in.
+
+Posts approved with 'threads queue approve' are only published as approved:
+one whose content changed after its approval fails instead, unless run or
+daemon is given --allow-modified.`,
+	}
+
+	cmd.AddCommand(newQueueListCmd(f))
+	cmd.AddCommand(newQueueApproveCmd(f))
+	cmd.AddCommand(newQueueCancelCmd(f))
+	cmd.AddCommand(newQueueRunCmd(f))
+	cmd.AddCommand(newQueueDaemonCmd(f))
+
+	return cmd
+}
+
+func newQueueListCmd(f *Factory) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List queued posts",
+		Long: `List the posts in the queue, soonest first. Published posts are left out
+unless --all is set.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			items, err := queue.Load(queuePath())
+			if err != nil {
+				return WrapError("failed to read the post queue", err)
+			}
+			views := []queueItemView{}
+			for _, item := range items {
+				if all || item.Status != queue.StatusPublished {
+					views = append(views, queueItemView{Item: item})
+				}
+			}
+			slices.SortStableFunc(views, func(a, b queueItemView) int { return a.Item.At.Compare(b.Item.At) })
+			return writeViewList(cmd.Context(), views, nil, "No queued posts")
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Include published posts")
+	return cmd
+}
+
+func newQueueCancelCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:         "cancel [id]...",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Remove posts from the queue",
+		Long: `Remove pending or failed posts from the queue. A post being published or
+already published cannot be cancelled; delete it with 'threads posts delete'.`,
+		Example: `  threads queue cancel 3f9a1c2b7d4e`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			err := queue.Update(queuePath(), func(items []queue.Item) ([]queue.Item, error) {
+				for _, id := range args {
+					i := slices.IndexFunc(items, func(item queue.Item) bool { return item.ID == id })
+					switch {
+					case i < 0:
+						return nil, &UserFriendlyError{
+							Message:    fmt.Sprintf("No queued post with ID %s", id),
+							Suggestion: "Run 'threads queue list' to see queued posts",
+						}
+					case items[i].Status == queue.StatusPublishing, items[i].Status == queue.StatusPublished:
+						return nil, &UserFriendlyError{
+							Message:    fmt.Sprintf("Queued post %s is %s and cannot be cancelled", id, items[i].Status),
+							Suggestion: "Delete the published post with 'threads posts delete'",
+						}
+					}
+				}
+				return slices.DeleteFunc(items, func(item queue.Item) bool { return slices.Contains(args, item.ID) }), nil
+			})
+			if err != nil {
+				return FormatError(err)
+			}
+
+			if outfmt.IsJSON(ctx) {
+				return outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, map[string]any{"cancelled": args})
+			}
+			p := f.UI(ctx)
+			for _, id := range args {
+				p.Success("Cancelled queued post %s", id)
+			}
+			return nil
+		},
+	}
+}
+
+func newQueueApproveCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:         "approve [id]...",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Sign off on the content of queued posts",
+		Long: `Record that the current content of pending or failed posts is approved for
+publishing. The approval keeps a hash of the text, media and options of the
+post, and 'threads queue run' and 'threads queue daemon' check it before
+publishing: a post changed after its approval, by hand or in an imported
+bundle, fails instead of being published.
+
+Approving a failed post puts it back in the queue. Approving a post again
+replaces its approval, so review the changes of a modified post first.`,
+		Example: `  threads queue list
+  threads queue approve 3f9a1c2b7d4e`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			now := time.Now()
+			var approved []queue.Item
+			err := queue.Update(queuePath(), func(items []queue.Item) ([]queue.Item, error) {
+				for _, id := range args {
+					i := slices.IndexFunc(items, func(item queue.Item) bool { return item.ID == id })
+					switch {
+					case i < 0:
+						return nil, &UserFriendlyError{
+							Message:    fmt.Sprintf("No queued post with ID %s", id),
+							Suggestion: "Run 'threads queue list' to see queued posts",
+						}
+					case items[i].Status == queue.StatusPublishing, items[i].Status == queue.StatusPublished:
+						return nil, &UserFriendlyError{
+							Message:    fmt.Sprintf("Queued post %s is %s and cannot be approved", id, items[i].Status),
+							Suggestion: "Only pending and failed posts can be approved",
+						}
+					}
+					item := &items[i]
+					item.Approve(now)
+					if item.Status == queue.StatusFailed {
+						item.Status, item.Attempts, item.Error = queue.StatusPending, 0, ""
+					}
+					approved = append(approved, *item)
+				}
+				return items, nil
+			})
+			if err != nil {
+				return FormatError(err)
+			}
+
+			if outfmt.IsJSON(ctx) {
+				return outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, map[string]any{"data": approved})
+			}
+			p := f.UI(ctx)
+			for _, item := range approved {
+				p.Success("Approved queued post %s, due %s", item.ID, queueDue(item))
+			}
+			return nil
+		},
+	}
+}
+
+// queueRunOptions are the flags shared by queue run and queue daemon
+type queueRunOptions struct {
+	AllowModified bool
+}
+
+func (o *queueRunOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.AllowModified, "allow-modified", false, "Publish approved posts even if their content changed since the approval")
+}
+
+func newQueueRunCmd(f *Factory) *cobra.Command {
+	opts := &queueRunOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "run",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Publish the queued posts that are due",
+		Long: `Publish every queued post that is due, then exit. Run it from cron or a
+systemd timer, or use 'threads queue daemon' to keep checking.
+
+A post that fails with an error that may pass, such as a rate limit, stays
+pending and is tried again on the next run, up to 5 times; other failures
+mark it failed. So does a post whose content changed after it was approved,
+unless --allow-modified is set.`,
+		Example: `  # Check every 5 minutes from cron
+  */5 * * * * threads queue run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			done, err := runQueue(ctx, f, time.Now(), opts, false)
+			if err != nil {
+				return err
+			}
+			if len(done) == 0 && !outfmt.IsJSON(ctx) {
+				f.UI(ctx).Info("No queued posts are due")
+				return nil
+			}
+			return writeBatchResult(ctx, queueRunResult(done))
+		},
+	}
+
+	opts.addFlags(cmd)
+	return cmd
+}
+
+type queueDaemonOptions struct {
+	queueRunOptions
+	Interval time.Duration
+}
+
+func newQueueDaemonCmd(f *Factory) *cobra.Command {
+	opts := &queueDaemonOptions{Interval: defaultQueueInterval}
+
+	cmd := &cobra.Command{
+		Use:         "daemon",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Publish queued posts as they fall due",
+		Long: `Check the queue every --interval, and at the time of the next post if it is
+sooner, and publish the posts that are due, until interrupted.
+
+Several daemons and 'queue run' may share one queue: each post is claimed
+before it is published, so it is published once. A post claimed by a daemon
+that was killed while publishing it is marked failed after 30 minutes
+rather than published twice, since it may have been posted.
+
+The tokens of the accounts with queued posts are renewed a week before they
+expire, even while the queue is idle; hooks and notifiers configured for
+token_refreshed and token_refresh_failed hear about it.
+
+With --output json each handled post is printed as one JSON object per line.`,
+		Example: `  threads queue daemon
+  threads queue daemon --interval 5m`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQueueDaemon(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.Interval, "interval", opts.Interval, "Longest wait between checks of the queue")
+	opts.addFlags(cmd)
+	return cmd
+}
+
+func runQueueDaemon(cmd *cobra.Command, f *Factory, opts *queueDaemonOptions) error {
+	ctx := cmd.Context()
+
+	if opts.Interval < time.Second {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --interval: %s", opts.Interval),
+			Suggestion: "Use an interval of at least 1s",
+		}
+	}
+
+	io := iocontext.GetIO(ctx)
+	if !outfmt.IsJSON(ctx) {
+		fmt.Fprintln(io.ErrOut, "Publishing queued posts as they fall due (Ctrl+C to stop)") //nolint:errcheck // Best-effort output to stderr
+	}
+
+	keepers := newTokenKeepers(f)
+	for {
+		// A failing run, such as a locked queue, is tried again later
+		if _, err := runQueue(ctx, f, time.Now(), &opts.queueRunOptions, true); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(io.ErrOut, "warning: %v\n", FormatError(err)) //nolint:errcheck // Best-effort output to stderr
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		wait := opts.Interval
+		// Tokens are renewed while the queue is idle, so they are valid
+		// when the next post falls due
+		accounts := []string{""}
+		items, err := queue.Load(queuePath())
+		if err == nil {
+			for _, item := range items {
+				if item.Status != queue.StatusPending {
+					continue
+				}
+				if until := time.Until(item.At); until > 0 && until < wait {
+					wait = until
+				}
+				if !slices.Contains(accounts, item.Account) {
+					accounts = append(accounts, item.Account)
+				}
+			}
+		}
+		if err := keepers.check(ctx, accounts, time.Now()); err != nil {
+			return err
+		}
+		if err := queueSleep(ctx, wait); err != nil {
+			return nil
+		}
+	}
+}
+
+// runQueue publishes the queued posts due at now and returns them with
+// their new status. Each result is printed as it comes in; with --output
+// json it is printed as a JSON object per line if jsonLines is set, and
+// left to the caller otherwise.
+func runQueue(ctx context.Context, f *Factory, now time.Time, opts *queueRunOptions, jsonLines bool) ([]queue.Item, error) {
+	path := queuePath()
+	claimed, err := queue.Claim(path, now, queueStaleAfter)
+	if err != nil {
+		return nil, WrapError("failed to read the post queue", err)
+	}
+
+	clients := map[string]api.API{}
+	quotas := map[string]*api.PublishingLimits{}
+	done := make([]queue.Item, 0, len(claimed))
+	for _, item := range claimed {
+		if ctx.Err() != nil {
+			break
+		}
+		var post *api.Post
+		publishErr := checkQueuedApproval(item, opts.AllowModified)
+		if publishErr == nil {
+			post, publishErr = publishQueued(ctx, f, clients, quotas, item)
+		}
+		err := queue.Update(path, func(items []queue.Item) ([]queue.Item, error) {
+			i := slices.IndexFunc(items, func(other queue.Item) bool { return other.ID == item.ID })
+			if i < 0 {
+				// Removed by hand while it was being published
+				return items, nil
+			}
+			finishQueued(&items[i], post, publishErr, time.Now())
+			item = items[i]
+			return items, nil
+		})
+		if err != nil {
+			return done, WrapError("failed to save the post queue", err)
+		}
+		done = append(done, item)
+		if err := reportQueued(ctx, f, item, jsonLines); err != nil {
+			return done, err
+		}
+		var e events.Event
+		switch {
+		case post != nil:
+			e = events.PostPublished{Post: *post, Source: events.SourceQueue}
+		case item.Status == queue.StatusFailed:
+			e = events.PublishFailed{Text: item.Text(), Error: item.Error, Source: events.SourceQueue, QueueID: item.ID, Account: item.Account}
+		}
+		if e != nil {
+			if err := f.Events.Publish(ctx, e); err != nil {
+				return done, err
+			}
+		}
+	}
+	return done, nil
+}
+
+// queueRunResult reports a run as a batch: posts put back in the queue to
+// be tried again are skipped, not failed
+func queueRunResult(done []queue.Item) *batchResult {
+	result := newBatchResult("publish", "queued posts")
+	result.retry = "Run 'threads queue list' to see why"
+	for _, item := range done {
+		switch item.Status {
+		case queue.StatusPublished:
+			result.succeed(item.ID)
+		case queue.StatusFailed:
+			result.fail(item.ID, errors.New(item.Error))
+		default:
+			result.skip(item.ID, "tried again on the next run: "+item.Error)
+		}
+	}
+	return result
+}
+
+// checkQueuedApproval refuses an approved item whose content changed since,
+// unless allowModified is set
+func checkQueuedApproval(item queue.Item, allowModified bool) error {
+	err := item.CheckApproval()
+	if err == nil || allowModified {
+		return nil
+	}
+	return &UserFriendlyError{
+		Message:    fmt.Sprintf("Queued post %s was %v", item.ID, err),
+		Suggestion: fmt.Sprintf("Review it and approve it again with 'threads queue approve %s', or publish it anyway with --allow-modified", item.ID),
+		Cause:      err,
+	}
+}
+
+// publishQueued publishes item as its account, reusing the clients and
+// publishing limits of earlier items of the run. Posts past the quota are
+// refused without a request, so they stay in the queue until it frees up.
+func publishQueued(ctx context.Context, f *Factory, clients map[string]api.API, quotas map[string]*api.PublishingLimits, item queue.Item) (*api.Post, error) {
+	account := item.Account
+	if account == "" {
+		var err error
+		if account, err = f.resolveAccount(); err != nil {
+			return nil, err
+		}
+	}
+	client, ok := clients[account]
+	if !ok {
+		var err error
+		if client, err = f.clientFor(ctx, account); err != nil {
+			return nil, err
+		}
+		clients[account] = client
+	}
+	limits, ok := quotas[account]
+	if !ok {
+		// A quota that cannot be fetched does not stop publishing
+		limits, _ = client.GetPublishingLimits(ctx) //nolint:errcheck // See above
+		quotas[account] = limits
+	}
+	// Replies count against their own quota
+	reply := item.IsReply()
+	if limits != nil {
+		check := limits.CheckPosts
+		if reply {
+			check = limits.CheckReplies
+		}
+		if err := check(1); err != nil {
+			return nil, err
+		}
+	}
+	post, err := publishContent(ctx, client, item.Content(), defaultContainerTimeoutSecs)
+	if err == nil && limits != nil {
+		if reply {
+			limits.ReplyQuotaUsage++
+		} else {
+			limits.QuotaUsage++
+		}
+	}
+	return post, err
+}
+
+// finishQueued records the outcome of publishing item. A retryable error
+// puts it back in the queue until it runs out of attempts.
+func finishQueued(item *queue.Item, post *api.Post, err error, now time.Time) {
+	item.ClaimedAt = nil
+	switch {
+	case err == nil:
+		at := now.UTC()
+		item.Status, item.Error, item.PublishedAt = queue.StatusPublished, "", &at
+		item.PostID, item.Permalink = post.ID, post.Permalink
+	case api.IsRetryable(err) && item.Attempts < queue.MaxAttempts:
+		item.Status, item.Error = queue.StatusPending, FormatError(err).Error()
+	default:
+		item.Status, item.Error = queue.StatusFailed, FormatError(err).Error()
+	}
+}
+
+// reportQueued prints the outcome of a queued post
+func reportQueued(ctx context.Context, f *Factory, item queue.Item, jsonLines bool) error {
+	if outfmt.IsJSON(ctx) {
+		if !jsonLines {
+			return nil
+		}
+		return outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, item)
+	}
+	p := f.UI(ctx)
+	switch item.Status {
+	case queue.StatusPublished:
+		p.Success("Published queued post %s: %s", item.ID, item.Permalink)
+	case queue.StatusPending:
+		p.Warning("Queued post %s failed and will be tried again: %s", item.ID, item.Error)
+	default:
+		p.Error("Queued post %s failed: %s", item.ID, item.Error)
+	}
+	return nil
+}
@@ -0,0 +1,418 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/queue"
+)
+
+// useTempQueue points the post queue at a temporary file and returns its
+// path
+func useTempQueue(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), queue.FileName)
+	orig := queuePath
+	queuePath = func() string { return path }
+	t.Cleanup(func() { queuePath = orig })
+	return path
+}
+
+// publishServer is a Threads API that publishes text posts as "p-" and
+// the text, counting the posts it published
+func publishServer(t *testing.T, published *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "refresh_access_token"):
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/threads"):
+			_, _ = w.Write([]byte(`{"id":"c-` + r.FormValue("text") + `"}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/threads_publish"):
+			published.Add(1)
+			_, _ = w.Write([]byte(`{"id":"p-` + strings.TrimPrefix(r.FormValue("creation_id"), "c-") + `"}`))
+		case strings.Contains(r.URL.Path, "/c-"):
+			id := strings.TrimPrefix(r.URL.Path, "/")
+			_, _ = w.Write([]byte(`{"id":"` + id + `","status":"FINISHED"}`))
+		default:
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			_, _ = w.Write([]byte(`{"id":"` + id + `","permalink":"https://www.threads.net/t/` + id + `"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPostsCreate_Schedule(t *testing.T) {
+	path := useTempQueue(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newPostsCreateCmd(f)
+	cmd.SetArgs([]string{"--text", "Good morning", "--schedule", "2h", "--utc"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := queue.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Text() != "Good morning" || items[0].Status != queue.StatusPending || items[0].Zone != "UTC" || items[0].Account != "test-user" {
+		t.Fatalf("unexpected queue: %+v", items)
+	}
+	if ahead := time.Until(items[0].At); ahead < 2*time.Hour-time.Minute || ahead > 2*time.Hour {
+		t.Errorf("expected the post due in 2h, got %v", ahead)
+	}
+
+	cmd = newPostsCreateCmd(f)
+	cmd.SetArgs([]string{"--text", "Too late", "--schedule", "2025-01-01 09:00"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "in the past") {
+		t.Errorf("expected a time in the past to fail, got %v", err)
+	}
+}
+
+func TestQueueRun_PublishesDuePosts(t *testing.T) {
+	path := useTempQueue(t)
+	var published atomic.Int32
+	f, io := newIntegrationTestFactory(t, publishServer(t, &published).URL)
+
+	now := time.Now().UTC()
+	err := queue.Save(path, []queue.Item{
+		{ID: "due", TextPost: &api.TextPostContent{Text: "due"}, At: now.Add(-time.Minute), Status: queue.StatusPending},
+		{ID: "later", TextPost: &api.TextPostContent{Text: "later"}, At: now.Add(time.Hour), Status: queue.StatusPending},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newQueueRunCmd(f)
+	cmd.SetArgs(nil)
+	cmd.SetContext(outfmt.NewContext(iocontext.WithIO(context.Background(), io), outfmt.JSON))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	var result batchResult
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Succeeded != 1 || len(result.Items) != 1 || result.Items[0].ID != "due" {
+		t.Fatalf("expected the due post published, got %+v", result)
+	}
+
+	// A second run finds nothing due
+	io.Out.(*bytes.Buffer).Reset()
+	cmd = newQueueRunCmd(f)
+	cmd.SetArgs(nil)
+	cmd.SetContext(outfmt.NewContext(iocontext.WithIO(context.Background(), io), outfmt.JSON))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if published.Load() != 1 {
+		t.Errorf("expected one post published, got %d", published.Load())
+	}
+	items, err := queue.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items[0].Permalink == "" || items[0].PublishedAt == nil || items[1].Status != queue.StatusPending {
+		t.Errorf("unexpected queue: %+v", items)
+	}
+}
+
+func TestQueueRun_ReportsFailuresInJSON(t *testing.T) {
+	path := useTempQueue(t)
+	api400 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Invalid parameter","type":"OAuthException","code":100}}`))
+	}))
+	t.Cleanup(api400.Close)
+	f, io := newIntegrationTestFactory(t, api400.URL)
+
+	err := queue.Save(path, []queue.Item{
+		{ID: "q1", TextPost: &api.TextPostContent{Text: "doomed"}, At: time.Now().Add(-time.Minute), Status: queue.StatusPending, Attempts: queue.MaxAttempts - 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newQueueRunCmd(f)
+	cmd.SetArgs(nil)
+	cmd.SetContext(outfmt.NewContext(iocontext.WithIO(context.Background(), io), outfmt.JSON))
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err = cmd.Execute()
+	if code := ExitCode(err); code != ExitPartial {
+		t.Errorf("expected exit code %d for a failed post, got %d (%v)", ExitPartial, code, err)
+	}
+	var result batchResult
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Failed != 1 || result.Items[0].ID != "q1" || result.Items[0].Reason == "" {
+		t.Errorf("expected the failure reported, got %+v", result)
+	}
+}
+
+func TestQueueRun_StopsAtPublishingQuota(t *testing.T) {
+	path := useTempQueue(t)
+	var published atomic.Int32
+	server := publishServer(t, &published)
+	limited := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/threads_publishing_limit") {
+			_, _ = w.Write([]byte(`{"data":[{"quota_usage":249,"config":{"quota_total":250,"quota_duration":86400}}]}`))
+			return
+		}
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(limited.Close)
+	f, io := newIntegrationTestFactory(t, limited.URL)
+
+	now := time.Now().UTC()
+	err := queue.Save(path, []queue.Item{
+		{ID: "first", TextPost: &api.TextPostContent{Text: "first"}, At: now.Add(-2 * time.Minute), Status: queue.StatusPending},
+		{ID: "second", TextPost: &api.TextPostContent{Text: "second"}, At: now.Add(-time.Minute), Status: queue.StatusPending},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newQueueRunCmd(f)
+	cmd.SetArgs(nil)
+	cmd.SetContext(outfmt.NewContext(iocontext.WithIO(context.Background(), io), outfmt.JSON))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if published.Load() != 1 {
+		t.Errorf("expected one post published within the quota, got %d", published.Load())
+	}
+	items, err := queue.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items[0].Status != queue.StatusPublished || items[1].Status != queue.StatusPending || !strings.Contains(items[1].Error, "Rate limit") {
+		t.Errorf("expected the post past the quota kept in the queue, got %+v", items)
+	}
+}
+
+func TestQueueRun_ChecksRepliesAgainstReplyQuota(t *testing.T) {
+	path := useTempQueue(t)
+	var published atomic.Int32
+	server := publishServer(t, &published)
+	limited := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/threads_publishing_limit") {
+			_, _ = w.Write([]byte(`{"data":[{"quota_usage":0,"config":{"quota_total":250,"quota_duration":86400},"reply_quota_usage":1000,"reply_config":{"quota_total":1000,"quota_duration":86400}}]}`))
+			return
+		}
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(limited.Close)
+	f, io := newIntegrationTestFactory(t, limited.URL)
+
+	now := time.Now().UTC()
+	err := queue.Save(path, []queue.Item{
+		{ID: "reply", TextPost: &api.TextPostContent{Text: "reply", ReplyTo: "parent"}, At: now.Add(-2 * time.Minute), Status: queue.StatusPending},
+		{ID: "post", TextPost: &api.TextPostContent{Text: "post"}, At: now.Add(-time.Minute), Status: queue.StatusPending},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newQueueRunCmd(f)
+	cmd.SetArgs(nil)
+	cmd.SetContext(outfmt.NewContext(iocontext.WithIO(context.Background(), io), outfmt.JSON))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	items, err := queue.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items[0].Status != queue.StatusPending || !strings.Contains(items[0].Error, "Rate limit") {
+		t.Errorf("expected the reply past the reply quota kept in the queue, got %+v", items[0])
+	}
+	if items[1].Status != queue.StatusPublished {
+		t.Errorf("expected the post published within the post quota, got %+v", items[1])
+	}
+}
+
+func TestQueueDaemon_PublishesWhenDue(t *testing.T) {
+	path := useTempQueue(t)
+	var published atomic.Int32
+	f, io := newIntegrationTestFactory(t, publishServer(t, &published).URL)
+
+	err := queue.Save(path, []queue.Item{
+		{ID: "soon", TextPost: &api.TextPostContent{Text: "soon"}, At: time.Now().Add(200 * time.Millisecond), Status: queue.StatusPending},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(iocontext.WithIO(context.Background(), io))
+	defer cancel()
+	var waits []time.Duration
+	orig := queueSleep
+	queueSleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		if published.Load() > 0 || len(waits) > 5 {
+			cancel()
+			return ctx.Err()
+		}
+		return orig(ctx, d)
+	}
+	t.Cleanup(func() { queueSleep = orig })
+
+	cmd := newQueueDaemonCmd(f)
+	cmd.SetArgs(nil)
+	cmd.SetContext(ctx)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if published.Load() != 1 || len(waits) < 2 || waits[0] > time.Second {
+		t.Errorf("expected the daemon to wake for the post, got %d published after waits %v", published.Load(), waits)
+	}
+	if !strings.Contains(io.Out.(*bytes.Buffer).String(), "Published queued post soon") {
+		t.Errorf("expected the post reported, got %q", io.Out.(*bytes.Buffer).String())
+	}
+}
+
+func TestQueueCancel(t *testing.T) {
+	path := useTempQueue(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	err := queue.Save(path, []queue.Item{
+		{ID: "a", At: time.Now().Add(time.Hour), Status: queue.StatusPending},
+		{ID: "b", At: time.Now().Add(-time.Hour), Status: queue.StatusPublished},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"a", "b"}, "published and cannot be cancelled"},
+		{[]string{"c"}, "No queued post with ID c"},
+		{[]string{"a"}, ""},
+	} {
+		cmd := newQueueCancelCmd(f)
+		cmd.SetArgs(tt.args)
+		cmd.SetContext(iocontext.WithIO(context.Background(), io))
+		err := cmd.Execute()
+		if tt.err == "" && err != nil || tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("cancel %v: expected %q, got %v", tt.args, tt.err, err)
+		}
+	}
+	items, err := queue.Load(path)
+	if err != nil || len(items) != 1 || items[0].ID != "b" {
+		t.Errorf("expected only the published post left, got %+v, %v", items, err)
+	}
+}
+
+func TestQueueApprove_RefusesModifiedPosts(t *testing.T) {
+	path := useTempQueue(t)
+	var published atomic.Int32
+	f, io := newIntegrationTestFactory(t, publishServer(t, &published).URL)
+	ctx := iocontext.WithIO(context.Background(), io)
+
+	due := time.Now().Add(-time.Minute).UTC()
+	err := queue.Save(path, []queue.Item{
+		{ID: "kept", TextPost: &api.TextPostContent{Text: "kept"}, At: due, Status: queue.StatusPending},
+		{ID: "edited", TextPost: &api.TextPostContent{Text: "edited"}, At: due, Status: queue.StatusPending},
+		{ID: "done", At: due, Status: queue.StatusPublished},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newQueueApproveCmd(f)
+	cmd.SetArgs([]string{"done"})
+	cmd.SetContext(ctx)
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "cannot be approved") {
+		t.Errorf("expected a published post refused, got %v", err)
+	}
+	cmd = newQueueApproveCmd(f)
+	cmd.SetArgs([]string{"kept", "edited"})
+	cmd.SetContext(ctx)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Someone changes a post after the approval
+	err = queue.Update(path, func(items []queue.Item) ([]queue.Item, error) {
+		items[1].TextPost.Text = "edited after review"
+		return items, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done, err := runQueue(ctx, f, time.Now(), &queueRunOptions{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(done) != 2 || done[0].Status != queue.StatusPublished || done[1].Status != queue.StatusFailed || !strings.Contains(done[1].Error, "modified since it was approved") {
+		t.Fatalf("expected only the unchanged post published, got %+v", done)
+	}
+	if published.Load() != 1 {
+		t.Errorf("expected one post published, got %d", published.Load())
+	}
+
+	// Approving the failed post again puts it back, and --allow-modified
+	// skips the check
+	cmd = newQueueApproveCmd(f)
+	cmd.SetArgs([]string{"edited"})
+	cmd.SetContext(ctx)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	items, _ := queue.Load(path)
+	if items[1].Status != queue.StatusPending || items[1].Error != "" || items[1].CheckApproval() != nil {
+		t.Fatalf("expected the post approved again, got %+v", items[1])
+	}
+	err = queue.Update(path, func(items []queue.Item) ([]queue.Item, error) {
+		items[1].TextPost.Text = "edited twice"
+		return items, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done, err = runQueue(ctx, f, time.Now(), &queueRunOptions{AllowModified: true}, false)
+	if err != nil || len(done) != 1 || done[0].Status != queue.StatusPublished {
+		t.Errorf("expected the modified post published with --allow-modified, got %+v, %v", done, err)
+	}
+}
+
+func TestFinishQueued_RetriesRetryableErrors(t *testing.T) {
+	item := &queue.Item{Status: queue.StatusPublishing, Attempts: 1}
+	finishQueued(item, nil, api.NewRateLimitError(429, "rate limited", "", time.Minute), time.Now())
+	if item.Status != queue.StatusPending || item.Error == "" {
+		t.Errorf("expected a rate limited post to stay pending, got %+v", item)
+	}
+
+	item.Attempts = queue.MaxAttempts
+	finishQueued(item, nil, api.NewRateLimitError(429, "rate limited", "", time.Minute), time.Now())
+	if item.Status != queue.StatusFailed {
+		t.Errorf("expected a post out of attempts to fail, got %+v", item)
+	}
+
+	item = &queue.Item{Status: queue.StatusPublishing, Attempts: 1}
+	finishQueued(item, nil, api.NewValidationError(400, "bad text", "", "text"), time.Now())
+	if item.Status != queue.StatusFailed {
+		t.Errorf("expected a validation error to fail the post, got %+v", item)
+	}
+}
@@ -2,12 +2,17 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/salmonumbrella/threads-cli/internal/api"
 	"github.com/salmonumbrella/threads-cli/internal/iocontext"
 	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
 )
 
 // NewRateLimitCmd builds the ratelimit command group.
@@ -20,10 +25,36 @@ func NewRateLimitCmd(f *Factory) *cobra.Command {
 
 	cmd.AddCommand(newRateLimitStatusCmd(f))
 	cmd.AddCommand(newRateLimitPublishingCmd(f))
+	cmd.AddCommand(newRateLimitServeCmd(f))
 
 	return cmd
 }
 
+// rateLimitKey names the quota of the account of creds. The quota is the
+// user's, whichever name the account is stored under.
+func rateLimitKey(account string, creds *secrets.Credentials) string {
+	if creds.UserID != "" {
+		return creds.UserID
+	}
+	return url.PathEscape(account)
+}
+
+// rateLimitStore keeps the rate limits of the account of creds in the cache
+// directory, for the commands run after this one
+func rateLimitStore(account string, creds *secrets.Credentials) *api.FileRateLimitStore {
+	return api.NewFileRateLimitStore(filepath.Join(cacheDir(), "ratelimit", rateLimitKey(account, creds)+".json"))
+}
+
+// rateLimitStatus is the JSON output of 'ratelimit status'
+type rateLimitStatus struct {
+	IsLimited bool      `json:"is_limited"`
+	Limit     int       `json:"limit"`
+	NearLimit bool      `json:"near_limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+	ResetIn   string    `json:"reset_in"`
+}
+
 func newRateLimitStatusCmd(f *Factory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "status",
@@ -41,14 +72,14 @@ func newRateLimitStatusCmd(f *Factory) *cobra.Command {
 
 			io := iocontext.GetIO(ctx)
 			if outfmt.IsJSON(ctx) {
-				return outfmt.WriteJSONTo(io.Out, map[string]interface{}{
-					"is_limited": isLimited,
-					"remaining":  status.Remaining,
-					"limit":      status.Limit,
-					"reset_at":   status.ResetTime,
-					"reset_in":   status.ResetIn.String(),
-					"near_limit": nearLimit,
-				}, outfmt.GetQuery(ctx))
+				return outfmt.WriteJSONContext(ctx, io.Out, rateLimitStatus{
+					IsLimited: isLimited,
+					Remaining: status.Remaining,
+					Limit:     status.Limit,
+					ResetAt:   status.ResetTime,
+					ResetIn:   status.ResetIn.String(),
+					NearLimit: nearLimit,
+				})
 			}
 
 			// Text output
@@ -85,13 +116,27 @@ func newRateLimitPublishingCmd(f *Factory) *cobra.Command {
 
 			io := iocontext.GetIO(ctx)
 			if outfmt.IsJSON(ctx) {
-				return outfmt.WriteJSONTo(io.Out, limits, outfmt.GetQuery(ctx))
+				return outfmt.WriteJSONContext(ctx, io.Out, limits)
 			}
 
 			// Text output
-			fmt.Fprintf(io.Out, "%v\n", limits) //nolint:errcheck // Best-effort output
+			writeQuota(io.Out, "Posts", limits.Posts())
+			writeQuota(io.Out, "Replies", limits.Replies())
+			writeQuota(io.Out, "Deletes", limits.Deletes())
+			writeQuota(io.Out, "Location searches", limits.LocationSearches())
+			if resetsAt := limits.ResetsAt(); !resetsAt.IsZero() {
+				fmt.Fprintf(io.Out, "Post quota fully resets by %s\n", resetsAt.Format(time.RFC3339)) //nolint:errcheck // Best-effort output
+			}
 			return nil
 		},
 	}
 	return cmd
 }
+
+// writeQuota prints one publishing quota line, skipping quotas the API did not report
+func writeQuota(w io.Writer, name string, q api.Quota) {
+	if q.Total == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%s: %d/%d used, %d remaining (per %s)\n", name, q.Used, q.Total, q.Remaining(), q.Duration) //nolint:errcheck // Best-effort output
+}
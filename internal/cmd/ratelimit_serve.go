@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/ratecoord"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
+)
+
+// rateCoordinatorSocket is where 'ratelimit serve' listens and clients
+// look for it
+func rateCoordinatorSocket() string {
+	return filepath.Join(cacheDir(), "ratelimit.sock")
+}
+
+func newRateLimitServeCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Share rate-limit accounting between commands on this machine",
+		Long: `Run a coordinator that every other threads command on this machine reports
+its API calls to, so that cron jobs, daemons and commands run by hand share
+one account's quota instead of each spending it as if alone. Once the quota
+reported by the API is spent, or the API answers 429, commands wait for the
+window to reset rather than failing one after another.
+
+Commands find the coordinator on a socket in the cache directory and carry on
+without it when it is not running. Run it under launchd, systemd or a
+terminal multiplexer to keep it up.`,
+		Example: `  threads ratelimit serve`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRateLimitServe(cmd.Context(), rateCoordinatorSocket())
+		},
+	}
+}
+
+// runRateLimitServe coordinates on the socket at path until ctx is done
+func runRateLimitServe(ctx context.Context, path string) error {
+	ln, err := ratecoord.Listen(path)
+	if err != nil {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Cannot listen on %s", path),
+			Suggestion: "Stop the coordinator already running, or check the permissions of the cache directory",
+			Cause:      err,
+		}
+	}
+
+	io := iocontext.GetIO(ctx)
+	if !outfmt.IsJSON(ctx) {
+		fmt.Fprintf(io.ErrOut, "Coordinating rate limits on %s (Ctrl+C to stop)\n", path) //nolint:errcheck // Best-effort output to stderr
+	}
+	if err := ratecoord.NewServer().Serve(ctx, ln); err != nil {
+		return WrapError("rate limit coordinator failed", err)
+	}
+	return nil
+}
+
+// rateCoordinator returns a client of the running coordinator for the
+// account of creds, or nil when none is running
+func rateCoordinator(account string, creds *secrets.Credentials) api.RateCoordinator {
+	path := rateCoordinatorSocket()
+	if info, err := os.Stat(path); err != nil || info.Mode().Type() != fs.ModeSocket {
+		return nil
+	}
+	return ratecoord.NewClient(path, rateLimitKey(account, creds))
+}
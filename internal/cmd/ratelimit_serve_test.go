@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+func TestRateLimitServe(t *testing.T) {
+	useTempCacheDir(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	creds := testCredentials()
+	if f.apiConfig(&recordingStore{}, "test-user", creds).RateCoordinator != nil {
+		t.Fatal("expected no coordinator while none is running")
+	}
+
+	ctx, cancel := context.WithCancel(iocontext.WithIO(context.Background(), io))
+	done := make(chan error, 1)
+	go func() { done <- runRateLimitServe(ctx, rateCoordinatorSocket()) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for f.apiConfig(&recordingStore{}, "test-user", creds).RateCoordinator == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("coordinator did not start")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	coordinator := f.apiConfig(&recordingStore{}, "test-user", creds).RateCoordinator
+	if wait, err := coordinator.Acquire(ctx); err != nil || wait != 0 {
+		t.Errorf("expected a request let through, got %s, %v", wait, err)
+	}
+
+	if err := runRateLimitServe(context.Background(), rateCoordinatorSocket()); err == nil {
+		t.Error("expected a second coordinator refused")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(rateCoordinatorSocket()); !os.IsNotExist(err) {
+		t.Errorf("expected the socket removed on exit, got %v", err)
+	}
+}
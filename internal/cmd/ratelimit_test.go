@@ -1,6 +1,15 @@
 package cmd
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
 
 func TestRateLimitCmd_Structure(t *testing.T) {
 	f := newTestFactory(t)
@@ -16,8 +25,8 @@ func TestRateLimitCmd_Structure(t *testing.T) {
 	}
 
 	subcommands := cmd.Commands()
-	if len(subcommands) != 2 {
-		t.Errorf("expected 2 subcommands, got %d", len(subcommands))
+	if len(subcommands) != 3 {
+		t.Errorf("expected 3 subcommands, got %d", len(subcommands))
 	}
 }
 
@@ -38,3 +47,42 @@ func TestRateLimitPublishingCmd_Structure(t *testing.T) {
 		t.Errorf("expected Use=publishing, got %s", cmd.Use)
 	}
 }
+
+func TestRateLimitPublishingCmd_TextOutput(t *testing.T) {
+	fetched := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock := &mockAPI{
+		getLimits: func(context.Context) (*api.PublishingLimits, error) {
+			return &api.PublishingLimits{
+				QuotaUsage:      40,
+				Config:          api.QuotaConfig{QuotaTotal: 250, QuotaDuration: 86400},
+				ReplyQuotaUsage: 5,
+				ReplyConfig:     api.QuotaConfig{QuotaTotal: 1000, QuotaDuration: 86400},
+				FetchedAt:       fetched,
+			}, nil
+		},
+	}
+
+	f, io := newMockAPITestFactory(t, mock)
+
+	cmd := newRateLimitPublishingCmd(f)
+	cmd.SetArgs([]string{})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := io.Out.(*bytes.Buffer).String()
+	for _, want := range []string{
+		"Posts: 40/250 used, 210 remaining (per 24h0m0s)",
+		"Replies: 5/1000 used, 995 remaining",
+		"2026-01-03T03:04:05Z",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Deletes") {
+		t.Errorf("expected unreported quotas to be omitted, got:\n%s", out)
+	}
+}
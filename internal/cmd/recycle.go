@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/queue"
+)
+
+// recycleRankings are the values of 'recycle suggest --rank': engagement
+// adds up likes, replies, reposts and quotes; the rest are single metrics
+var recycleRankings = []string{"engagement", "views", "likes", "replies", "reposts", "quotes"}
+
+// recycleSuggestion is an archived post worth posting again
+type recycleSuggestion struct {
+	Post    *api.Post
+	Metrics map[string]int
+	Score   int
+}
+
+func (s recycleSuggestion) viewFields() []viewField {
+	p := s.Post
+	return []viewField{
+		{Key: "id", Value: p.ID, Type: outfmt.ColumnID},
+		{Key: "posted", Value: p.Timestamp.Time, Text: p.Timestamp.Local().Format("2006-01-02"), Type: outfmt.ColumnDate},
+		{Key: "score", Value: s.Score},
+		{Key: "views", Value: s.Metrics["views"]},
+		{Key: "likes", Value: s.Metrics["likes"]},
+		{Key: "replies", Value: s.Metrics["replies"]},
+		{Key: "text", Value: p.Text, Text: truncateText(strings.ReplaceAll(p.Text, "\n", " "), 50)},
+		{Key: "permalink", Value: p.Permalink},
+	}
+}
+
+// NewRecycleCmd builds the recycle command group.
+func NewRecycleCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recycle",
+		Short: "Bring back your best older posts",
+		Long: `Find posts in a 'threads posts archive' directory that did well and have not
+been posted again, and queue them to be posted again, as they were or
+rephrased through a template.`,
+	}
+
+	cmd.AddCommand(newRecycleSuggestCmd(f))
+	cmd.AddCommand(newRecycleQueueCmd(f))
+
+	return cmd
+}
+
+type recycleSuggestOptions struct {
+	OlderThan int
+	Scan      int
+	Limit     int
+	Rank      string
+}
+
+func newRecycleSuggestCmd(f *Factory) *cobra.Command {
+	opts := &recycleSuggestOptions{OlderThan: 6, Scan: 50, Limit: 10, Rank: "engagement"}
+
+	cmd := &cobra.Command{
+		Use:   "suggest [archive-dir]",
+		Short: "Rank archived posts worth posting again",
+		Long: `Rank the posts of an archive made with 'threads posts archive' that are older
+than --older-than months and were never brought back: not reposted, not
+posted again with the same text, and not queued by 'recycle queue' before.
+Replies, reposts and posts without text are left out.
+
+The archive holds no metrics, so the insights of the --scan most recent
+candidates are fetched, one request each, and ranked by --rank.
+
+Examples:
+  threads posts archive ./archive --resume
+  threads recycle suggest ./archive
+  threads recycle suggest ./archive --older-than 12 --rank views --limit 5`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRecycleSuggest(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.OlderThan, "older-than", opts.OlderThan, "Only posts older than this many months")
+	cmd.Flags().IntVar(&opts.Scan, "scan", opts.Scan, "Candidates to fetch insights for, most recent first")
+	cmd.Flags().IntVar(&opts.Limit, "limit", opts.Limit, "Suggestions to show")
+	cmd.Flags().StringVar(&opts.Rank, "rank", opts.Rank, "Rank by: "+strings.Join(recycleRankings, ", "))
+	return cmd
+}
+
+func runRecycleSuggest(cmd *cobra.Command, f *Factory, dir string, opts *recycleSuggestOptions) error {
+	ctx := cmd.Context()
+
+	if !slices.Contains(recycleRankings, opts.Rank) {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --rank: %s", opts.Rank),
+			Suggestion: "Use one of: " + strings.Join(recycleRankings, ", "),
+		}
+	}
+	if opts.OlderThan < 0 || opts.Scan < 1 || opts.Limit < 1 {
+		return &UserFriendlyError{
+			Message:    "Invalid --older-than, --scan or --limit",
+			Suggestion: "Use a --older-than of 0 or more and a --scan and --limit of at least 1",
+		}
+	}
+
+	posts, err := readArchivePosts(dir)
+	if err != nil {
+		return err
+	}
+	queued, err := queue.Load(queuePath())
+	if err != nil {
+		return WrapError("failed to read the post queue", err)
+	}
+	candidates := recycleCandidates(posts, queued, time.Now().AddDate(0, -opts.OlderThan, 0))
+	if len(candidates) > opts.Scan {
+		candidates = candidates[:opts.Scan]
+	}
+
+	var suggestions []recycleSuggestion
+	if len(candidates) > 0 {
+		client, err := f.Client(ctx)
+		if err != nil {
+			return err
+		}
+		for _, post := range candidates {
+			metrics, err := postMetrics(ctx, client, post.ID)
+			if err != nil {
+				return WrapError(fmt.Sprintf("failed to get insights of post %s", post.ID), err)
+			}
+			suggestions = append(suggestions, recycleSuggestion{Post: post, Metrics: metrics, Score: recycleScore(metrics, opts.Rank)})
+		}
+	}
+	slices.SortStableFunc(suggestions, func(a, b recycleSuggestion) int { return cmp.Compare(b.Score, a.Score) })
+	if len(suggestions) > opts.Limit {
+		suggestions = suggestions[:opts.Limit]
+	}
+	return writeViewList(ctx, suggestions, nil, "No posts to recycle")
+}
+
+// readArchivePosts reads the posts.jsonl of an archive directory
+func readArchivePosts(dir string) ([]api.Post, error) {
+	path := filepath.Join(dir, archivePostsFile)
+	file, err := os.Open(path) //nolint:gosec // Path is chosen by the user
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, &UserFriendlyError{
+			Message:    fmt.Sprintf("No archive in %s", dir),
+			Suggestion: fmt.Sprintf("Create one with 'threads posts archive %s'", dir),
+		}
+	}
+	if err != nil {
+		return nil, WrapError("failed to open "+archivePostsFile, err)
+	}
+	defer file.Close() //nolint:errcheck // Read-only
+
+	var posts []api.Post
+	dec := json.NewDecoder(file)
+	for {
+		var post api.Post
+		if err := dec.Decode(&post); errors.Is(err, io.EOF) {
+			return posts, nil
+		} else if err != nil {
+			return nil, WrapError("failed to read "+path, err)
+		}
+		posts = append(posts, post)
+	}
+}
+
+// recycleCandidates returns the posts made before cutoff that were never
+// brought back, most recent first. A post was brought back if it was
+// reposted, if a later post has the same text or if it was queued before.
+func recycleCandidates(posts []api.Post, queued []queue.Item, cutoff time.Time) []*api.Post {
+	reused := map[string]bool{}
+	for _, item := range queued {
+		reused[item.RecycledFrom] = true
+	}
+	// The newest post with each text; older ones with the same text were
+	// posted again
+	newest := map[string]time.Time{}
+	for _, p := range posts {
+		if p.RepostedPost != nil {
+			reused[p.RepostedPost.ID] = true
+		}
+		key := recycleTextKey(p.Text)
+		if p.Timestamp.After(newest[key]) {
+			newest[key] = p.Timestamp.Time
+		}
+	}
+
+	var candidates []*api.Post
+	for i := range posts {
+		p := &posts[i]
+		switch {
+		case p.IsReply, p.RepostedPost != nil, p.MediaType == "REPOST_FACADE", strings.TrimSpace(p.Text) == "", p.GhostPostStatus != "":
+		case !p.Timestamp.Before(cutoff), reused[p.ID]:
+		case p.Timestamp.Before(newest[recycleTextKey(p.Text)]):
+		default:
+			candidates = append(candidates, p)
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b *api.Post) int { return b.Timestamp.Compare(a.Timestamp.Time) })
+	return candidates
+}
+
+// recycleTextKey is what makes two post texts the same: case and spacing
+// are ignored
+func recycleTextKey(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// recycleScore is the value of metrics --rank sorts by
+func recycleScore(metrics map[string]int, rank string) int {
+	if rank == "engagement" {
+		return metrics["likes"] + metrics["replies"] + metrics["reposts"] + metrics["quotes"]
+	}
+	return metrics[rank]
+}
+
+type recycleQueueOptions struct {
+	At       string
+	Every    time.Duration
+	Template string
+	UTC      bool
+}
+
+func newRecycleQueueCmd(f *Factory) *cobra.Command {
+	opts := &recycleQueueOptions{Every: 24 * time.Hour, Template: "{{.Text}}"}
+
+	cmd := &cobra.Command{
+		Use:   "queue [archive-dir] [post-id]...",
+		Short: "Queue archived posts to be posted again",
+		Long: `Add posts of an archive to the local post queue, the first at --at and each
+next one --every later. 'threads queue daemon' or 'threads queue run'
+publishes them when they are due; 'threads queue list' shows them.
+
+--template rephrases each post. It is a Go template with the fields .Text,
+.Permalink, .Username and .Date (the original post's, as 2006-01-02);
+the default posts the text as it was.
+
+Examples:
+  threads recycle queue ./archive 12345678901234567 --at "tomorrow 9am"
+  threads recycle queue ./archive 111 222 333 --at "tomorrow 9am" --every 48h
+  threads recycle queue ./archive 111 --at "2025-09-01 09:00" --template "From the archive ({{.Date}}): {{.Text}}"`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRecycleQueue(cmd, f, args[0], args[1:], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.At, "at", "", "When to post the first one (\"2025-09-01 09:00\", \"tomorrow 9am\", 2h)")
+	cmd.Flags().DurationVar(&opts.Every, "every", opts.Every, "Time between queued posts")
+	cmd.Flags().StringVar(&opts.Template, "template", opts.Template, "Template for the new text")
+	addUTCFlag(cmd, &opts.UTC)
+	_ = cmd.MarkFlagRequired("at")
+	return cmd
+}
+
+func runRecycleQueue(cmd *cobra.Command, f *Factory, dir string, ids []string, opts *recycleQueueOptions) error {
+	ctx := cmd.Context()
+
+	at, loc, err := parseScheduleFlag(f, opts.UTC, "at", opts.At)
+	if err != nil {
+		return err
+	}
+	if opts.Every < 0 {
+		return &UserFriendlyError{Message: "Invalid --every: " + opts.Every.String(), Suggestion: "Use a positive duration, e.g. 24h"}
+	}
+	tmpl, err := template.New("recycle").Option("missingkey=error").Parse(opts.Template)
+	if err != nil {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --template: %v", err),
+			Suggestion: "Use fields such as {{.Text}} and {{.Date}}",
+			Cause:      err,
+		}
+	}
+
+	posts, err := readArchivePosts(dir)
+	if err != nil {
+		return err
+	}
+	// Every post is checked before anything is queued
+	var added []queue.Item
+	now := time.Now().UTC()
+	for i, id := range ids {
+		j := slices.IndexFunc(posts, func(p api.Post) bool { return p.ID == id })
+		if j < 0 {
+			return &UserFriendlyError{
+				Message:    fmt.Sprintf("Post %s is not in the archive in %s", id, dir),
+				Suggestion: fmt.Sprintf("Find posts with 'threads recycle suggest %s'", dir),
+			}
+		}
+		p := posts[j]
+		var text strings.Builder
+		if err := tmpl.Execute(&text, map[string]string{
+			"Text": p.Text, "Permalink": p.Permalink, "Username": p.Username, "Date": p.Timestamp.Format("2006-01-02"),
+		}); err != nil {
+			return &UserFriendlyError{Message: fmt.Sprintf("Invalid --template: %v", err), Suggestion: "Use fields such as {{.Text}} and {{.Date}}", Cause: err}
+		}
+		if text.Len() > api.MaxTextLength {
+			return &UserFriendlyError{
+				Message:    fmt.Sprintf("Post %s is %d characters with --template, over the %d character limit", id, text.Len(), api.MaxTextLength),
+				Suggestion: "Use a shorter --template",
+			}
+		}
+		added = append(added, queue.Item{
+			ID:           queue.NewID(),
+			TextPost:     &api.TextPostContent{Text: text.String()},
+			At:           at.Add(time.Duration(i) * opts.Every).UTC(),
+			Zone:         loc.String(),
+			CreatedAt:    now,
+			Account:      f.currentAccountName(),
+			RecycledFrom: id,
+			Status:       queue.StatusPending,
+		})
+	}
+
+	if err := enqueuePosts(added...); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, map[string]any{"data": added})
+	}
+	p := f.UI(ctx)
+	for _, item := range added {
+		p.Success("Queued post %s again for %s as %s", item.RecycledFrom, queueDue(item), item.ID)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/queue"
+)
+
+// writeTestArchive writes posts as the posts.jsonl of a new archive
+// directory
+func writeTestArchive(t *testing.T, posts ...api.Post) string {
+	t.Helper()
+	dir := t.TempDir()
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	for _, p := range posts {
+		if err := enc.Encode(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, archivePostsFile), b.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func monthsAgo(n int) api.Time {
+	return api.Time{Time: time.Now().AddDate(0, -n, 0)}
+}
+
+func TestRecycleSuggest_RanksOldPostsNotBroughtBack(t *testing.T) {
+	useTempQueue(t)
+	dir := writeTestArchive(t,
+		api.Post{ID: "1", Text: "evergreen", Timestamp: monthsAgo(10)},
+		api.Post{ID: "2", Text: "popular", Timestamp: monthsAgo(8)},
+		api.Post{ID: "3", Text: "too recent", Timestamp: monthsAgo(1)},
+		api.Post{ID: "4", Text: "a reply", Timestamp: monthsAgo(9), IsReply: true},
+		api.Post{ID: "5", Text: "Posted  twice", Timestamp: monthsAgo(12)},
+		api.Post{ID: "6", Text: "posted twice", Timestamp: monthsAgo(7)},
+		api.Post{ID: "7", Text: "reposted", Timestamp: monthsAgo(11)},
+		api.Post{ID: "8", Timestamp: monthsAgo(2), RepostedPost: &api.Post{ID: "7"}},
+	)
+	var fetched []string
+	f, io := newMockAPITestFactory(t, &mockAPI{
+		getPostInsights: func(_ context.Context, postID api.PostID, _ []string) (*api.InsightsResponse, error) {
+			fetched = append(fetched, string(postID))
+			likes := map[api.PostID]int{"1": 5, "2": 40, "6": 10}[postID]
+			return &api.InsightsResponse{Data: []api.Insight{
+				{Name: "likes", TotalValue: &api.TotalValue{Value: likes}},
+				{Name: "views", TotalValue: &api.TotalValue{Value: 1000 - likes}},
+			}}, nil
+		},
+	})
+
+	cmd := newRecycleSuggestCmd(f)
+	cmd.SetArgs([]string{dir})
+	cmd.SetContext(outfmt.NewContext(iocontext.WithIO(context.Background(), io), outfmt.JSON))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	var result struct {
+		Data []map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	var ids []string
+	for _, item := range result.Data {
+		ids = append(ids, item["id"].(string))
+	}
+	if strings.Join(ids, ",") != "2,6,1" {
+		t.Errorf("expected posts 2, 6 and 1 by engagement, got %v", result.Data)
+	}
+	if strings.Join(fetched, ",") != "6,2,1" {
+		t.Errorf("expected insights of the candidates, most recent first, got %v", fetched)
+	}
+}
+
+func TestRecycleQueue_AddsSpacedItems(t *testing.T) {
+	path := useTempQueue(t)
+	dir := writeTestArchive(t,
+		api.Post{ID: "1", Text: "evergreen", Timestamp: api.Time{Time: time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)}},
+		api.Post{ID: "2", Text: "popular", Timestamp: monthsAgo(8)},
+	)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newRecycleQueueCmd(f)
+	cmd.SetArgs([]string{dir, "1", "2", "--at", "in 2h", "--every", "48h", "--template", "From {{.Date}}: {{.Text}}"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := queue.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].Text() != "From 2024-03-05: evergreen" || items[1].RecycledFrom != "2" {
+		t.Fatalf("unexpected queue: %+v", items)
+	}
+	if d := items[1].At.Sub(items[0].At); d != 48*time.Hour {
+		t.Errorf("expected items 48h apart, got %v", d)
+	}
+
+	// Queued posts are no longer suggested, and unknown posts are not queued
+	cmd = newRecycleQueueCmd(f)
+	cmd.SetArgs([]string{dir, "3", "--at", "in 2h"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "not in the archive") {
+		t.Errorf("expected an unknown post to fail, got %v", err)
+	}
+	posts, err := readArchivePosts(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := recycleCandidates(posts, items, time.Now()); len(got) != 0 {
+		t.Errorf("expected no candidates after queueing, got %+v", got)
+	}
+}
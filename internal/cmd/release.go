@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/packaging"
+)
+
+// releaseProject is what the packages describe
+var releaseProject = packaging.Project{
+	Name:        "threads",
+	Description: "Interact with Meta Threads from the command line",
+	Homepage:    "https://github.com/salmonumbrella/threads-cli",
+	License:     "MIT",
+	Maintainer:  "salmonumbrella",
+	Repo:        "salmonumbrella/threads-cli",
+}
+
+// NewReleaseCmd builds the release command group, used by the release
+// workflow after GoReleaser has built a tag.
+func NewReleaseCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "release",
+		Short:  "Release tooling",
+		Hidden: true,
+	}
+
+	cmd.AddCommand(newReleasePackagesCmd(f))
+
+	return cmd
+}
+
+func newReleasePackagesCmd(f *Factory) *cobra.Command {
+	var dist, out, maintainer string
+
+	cmd := &cobra.Command{
+		Use:   "packages",
+		Short: "Generate package manager manifests for a release",
+		Long: `Generate a Homebrew formula, a Scoop manifest and nfpm configs for deb and
+rpm packages from the metadata GoReleaser writes to its dist directory.
+
+Download URLs point at the GitHub release of the tag, with the archive
+checksums GoReleaser computed. Platforms the release has no build for are
+left out. Run it from the repository root after 'goreleaser release':
+  threads release packages
+  nfpm package --config dist/packaging/nfpm/threads_amd64.yaml --packager deb`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project := releaseProject
+			project.Maintainer = maintainer
+			return runReleasePackages(cmd, f, project, dist, out)
+		},
+	}
+
+	cmd.Flags().StringVar(&dist, "dist", "dist", "GoReleaser dist directory")
+	cmd.Flags().StringVar(&out, "out", filepath.Join("dist", "packaging"), "Directory to write manifests to")
+	cmd.Flags().StringVar(&maintainer, "maintainer", releaseProject.Maintainer, "Maintainer of the deb and rpm packages, \"Name <email>\"")
+	return cmd
+}
+
+func runReleasePackages(cmd *cobra.Command, f *Factory, project packaging.Project, dist, out string) error {
+	ctx := cmd.Context()
+
+	release, err := packaging.Load(dist)
+	if err != nil {
+		return WrapError("failed to read the GoReleaser build", err)
+	}
+	written, err := packaging.Generate(project, release, out)
+	if err != nil {
+		return WrapError("failed to generate package manifests", err)
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
+			"tag":   release.Tag,
+			"dir":   out,
+			"files": written,
+		})
+	}
+	f.UI(ctx).Success("Generated %d package manifests for %s in %s", len(written), release.Tag, out)
+	for _, file := range written {
+		f.UI(ctx).Info("  %s", filepath.FromSlash(file))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+// writeReleaseDist writes a GoReleaser dist with one macOS archive
+func writeReleaseDist(t *testing.T) string {
+	t.Helper()
+	dist := filepath.Join(t.TempDir(), "dist")
+	if err := os.MkdirAll(dist, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"metadata.json":  `{"tag":"v1.2.3","version":"1.2.3"}`,
+		"artifacts.json": `[{"name":"threads_1.2.3_darwin_arm64.tar.gz","path":"dist/threads_1.2.3_darwin_arm64.tar.gz","goos":"darwin","goarch":"arm64","type":"Archive","extra":{"Checksum":"sha256:0123456789abcdef"}}]`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dist, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dist
+}
+
+func TestReleasePackages(t *testing.T) {
+	dist := writeReleaseDist(t)
+	out := filepath.Join(t.TempDir(), "packaging")
+
+	f := newTestFactory(t)
+	io := f.IO
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"release", "packages", "--dist", dist, "--out", out})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := ExecuteCommand(cmd, f); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	if got := io.Out.(*bytes.Buffer).String(); !strings.Contains(got, "Generated 1 package manifests for v1.2.3") {
+		t.Errorf("unexpected output:\n%s", got)
+	}
+	formula, err := os.ReadFile(filepath.Join(out, "homebrew", "threads.rb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(formula), `sha256 "0123456789abcdef"`) {
+		t.Errorf("formula is missing the checksum:\n%s", formula)
+	}
+}
+
+func TestReleasePackages_JSON(t *testing.T) {
+	dist := writeReleaseDist(t)
+	out := t.TempDir()
+
+	f := newTestFactory(t)
+	io := f.IO
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"release", "packages", "--dist", dist, "--out", out, "--output", "json"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := ExecuteCommand(cmd, f); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	var result struct {
+		Tag   string   `json:"tag"`
+		Files []string `json:"files"`
+	}
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Tag != "v1.2.3" || len(result.Files) != 1 || result.Files[0] != "homebrew/threads.rb" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestReleasePackages_MissingDist(t *testing.T) {
+	f := newTestFactory(t)
+	io := f.IO
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"release", "packages", "--dist", filepath.Join(t.TempDir(), "dist")})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := ExecuteCommand(cmd, f); err == nil {
+		t.Fatal("expected an error without a GoReleaser build")
+	}
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+func renderTestPost(_ context.Context, id api.PostID) (*api.Post, error) {
+	return &api.Post{
+		ID:        string(id),
+		Username:  "someone",
+		Text:      "Hello from the terminal",
+		Timestamp: api.Time{Time: time.Date(2025, 7, 1, 14, 0, 0, 0, time.UTC)},
+	}, nil
+}
+
+func TestRenderCard_WritesPNG(t *testing.T) {
+	avatar := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	avatar.Set(0, 0, color.Black)
+	var pictureFetched bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pictureFetched = true
+		png.Encode(w, avatar) //nolint:errcheck // Test server
+	}))
+	defer server.Close()
+
+	f, io := newMockAPITestFactory(t, &mockAPI{
+		getPost: renderTestPost,
+		getMe: func(context.Context) (*api.User, error) {
+			return &api.User{Username: "someone", Name: "Some One", ProfilePicURL: server.URL + "/me.png"}, nil
+		},
+	})
+	path := filepath.Join(t.TempDir(), "card.png")
+
+	cmd := newRenderCardCmd(f)
+	cmd.SetArgs([]string{"12345", "--out", path, "--width", "540"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !pictureFetched {
+		t.Error("expected the profile picture to be fetched")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close() //nolint:errcheck // Test cleanup
+	img, err := png.Decode(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dx() != 540 {
+		t.Errorf("width = %d, want 540", img.Bounds().Dx())
+	}
+	if out := io.Out.(*bytes.Buffer).String(); strings.Contains(out, "Could not load") {
+		t.Errorf("unexpected warning: %s", out)
+	}
+}
+
+func TestRenderCard_ProfileUnavailable(t *testing.T) {
+	f, io := newMockAPITestFactory(t, &mockAPI{
+		getPost: renderTestPost,
+		getMe: func(context.Context) (*api.User, error) {
+			return &api.User{Username: "test-user"}, nil
+		},
+	})
+	path := filepath.Join(t.TempDir(), "card.png")
+
+	cmd := newRenderCardCmd(f)
+	cmd.SetArgs([]string{"12345", "--out", path})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("card not written: %v", err)
+	}
+	if out := io.Out.(*bytes.Buffer).String(); !strings.Contains(out, "Could not load the profile of @someone") {
+		t.Errorf("expected a warning, got %q", out)
+	}
+}
+
+func TestRenderCard_InvalidWidth(t *testing.T) {
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	cmd := newRenderCardCmd(f)
+	cmd.SetArgs([]string{"12345", "--out", filepath.Join(t.TempDir(), "card.png"), "--width", "10"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "Invalid --width") {
+		t.Fatalf("expected width error, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -29,13 +30,35 @@ func NewRepliesCmd(f *Factory) *cobra.Command {
 
 func newRepliesListCmd(f *Factory) *cobra.Command {
 	var limit int
+	var cursor string
+	var classify bool
+	var labels []string
+	var translate string
+	var showMuted bool
 
 	cmd := &cobra.Command{
 		Use:   "list [post-id]",
 		Short: "List replies to a post",
 		Long: `List all replies to a specific post.
 
-Results are paginated and can be filtered with --limit.`,
+Results are paginated and can be filtered with --limit.
+
+--classify tags each reply with labels from the classifier in the
+'classifier' config key, such as positive, negative, question or spam;
+--label keeps only the replies with any of the given labels.
+
+--translate shows each reply next to its translation by the command or URL
+in the 'translator' config key.
+
+Replies from users or with keywords muted with 'threads mute' are hidden
+unless --show-muted is set.
+
+Examples:
+  threads config set classifier ./classify-reply.sh
+  threads replies list 12345678901234567 --classify
+  threads replies list 12345678901234567 --label question,spam
+  threads config set translator ./translate.sh
+  threads replies list 12345678901234567 --translate en`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			postID := args[0]
@@ -46,19 +69,28 @@ Results are paginated and can be filtered with --limit.`,
 				return err
 			}
 
-			opts := &api.RepliesOptions{}
+			opts := &api.RepliesOptions{After: cursor}
 			if limit > 0 {
 				opts.Limit = limit
 			}
 
-			replies, err := client.GetReplies(ctx, api.PostID(postID), opts)
+			page, err := client.GetReplies(ctx, api.PostID(postID), opts)
 			if err != nil {
 				return WrapError("failed to get replies", err)
 			}
+			// Hidden before annotating, so muted replies cost no calls
+			if err := hideMuted(ctx, page, showMuted); err != nil {
+				return err
+			}
+			classify = classify || len(labels) > 0
+			replies, err := annotatePosts(ctx, f, page, annotateOptions{Classify: classify, Labels: labels, Translate: translate})
+			if err != nil {
+				return err
+			}
 
 			io := iocontext.GetIO(ctx)
 			if outfmt.IsJSON(ctx) {
-				return outfmt.WriteJSONTo(io.Out, replies, outfmt.GetQuery(ctx))
+				return outfmt.WriteJSONContext(ctx, io.Out, replies)
 			}
 
 			if len(replies.Data) == 0 {
@@ -67,6 +99,25 @@ Results are paginated and can be filtered with --limit.`,
 			}
 
 			headers := []string{"ID", "FROM", "TEXT", "DATE"}
+			colTypes := []outfmt.ColumnType{
+				outfmt.ColumnID,
+				outfmt.ColumnPlain,
+				outfmt.ColumnPlain,
+				outfmt.ColumnDate,
+			}
+			if classify {
+				headers = append(headers, "LABELS")
+				colTypes = append(colTypes, outfmt.ColumnPlain)
+			}
+			if translate != "" {
+				headers = append(headers, "TRANSLATION")
+				colTypes = append(colTypes, outfmt.ColumnPlain)
+			}
+			noted := postNotes(ctx, f, len(replies.Data), func(i int) *api.Post { return replies.Data[i].Post })
+			if noted != nil {
+				headers = append(headers, "NOTE")
+				colTypes = append(colTypes, outfmt.ColumnPlain)
+			}
 			rows := make([][]string, len(replies.Data))
 			for i, reply := range replies.Data {
 				text := strings.ReplaceAll(reply.Text, "\n", " ")
@@ -79,28 +130,44 @@ Results are paginated and can be filtered with --limit.`,
 					text,
 					reply.Timestamp.Format("2006-01-02 15:04"),
 				}
+				if classify {
+					rows[i] = append(rows[i], fallback(strings.Join(reply.Labels, ", "), "-"))
+				}
+				if translate != "" {
+					rows[i] = append(rows[i], fallback(truncateText(strings.ReplaceAll(reply.Translation, "\n", " "), 50), "-"))
+				}
+				if noted != nil {
+					rows[i] = append(rows[i], noted[i])
+				}
 			}
 
 			out := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
-			return out.Table(headers, rows, []outfmt.ColumnType{
-				outfmt.ColumnID,
-				outfmt.ColumnPlain,
-				outfmt.ColumnPlain,
-				outfmt.ColumnDate,
-			})
+			if err := out.Table(headers, rows, colTypes); err != nil {
+				return err
+			}
+
+			writeListFooter(cmd, args, len(replies.Data), replies.NextCursor())
+			return nil
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of replies to return")
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Pagination cursor for the next page")
+	cmd.Flags().BoolVar(&classify, "classify", false, "Label replies with the configured classifier")
+	cmd.Flags().StringSliceVar(&labels, "label", nil, "Only show replies with any of these labels (implies --classify)")
+	cmd.Flags().StringVar(&translate, "translate", "", "Also show replies translated to this language, e.g. en (uses the configured translator)")
+	addShowMutedFlag(cmd, &showMuted)
 	return cmd
 }
 
 func newRepliesCreateCmd(f *Factory) *cobra.Command {
 	var text string
+	var signature signatureOptions
 
 	cmd := &cobra.Command{
-		Use:   "create [post-id]",
-		Short: "Reply to a post",
+		Use:         "create [post-id]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Reply to a post",
 		Long: `Create a reply to a specific post.
 
 Provide the text of your reply with the --text flag.`,
@@ -109,13 +176,21 @@ Provide the text of your reply with the --text flag.`,
 			postID := args[0]
 			ctx := cmd.Context()
 
+			body, err := applySignature(cmd, f, &signature, text)
+			if err != nil {
+				return err
+			}
+			if err := checkAccountRules(f, body); err != nil {
+				return err
+			}
+
 			client, err := f.Client(ctx)
 			if err != nil {
 				return err
 			}
 
 			content := &api.PostContent{
-				Text: text,
+				Text: body,
 			}
 			reply, err := client.ReplyToPost(ctx, api.PostID(postID), content)
 			if err != nil {
@@ -124,7 +199,7 @@ Provide the text of your reply with the --text flag.`,
 
 			io := iocontext.GetIO(ctx)
 			if outfmt.IsJSON(ctx) {
-				return outfmt.WriteJSONTo(io.Out, reply, outfmt.GetQuery(ctx))
+				return outfmt.WriteJSONContext(ctx, io.Out, reply)
 			}
 
 			f.UI(ctx).Success("Reply created successfully!")
@@ -133,6 +208,7 @@ Provide the text of your reply with the --text flag.`,
 	}
 
 	cmd.Flags().StringVarP(&text, "text", "t", "", "Text content for the reply (required)")
+	addSignatureFlags(cmd, &signature)
 	//nolint:errcheck,gosec // MarkFlagRequired cannot fail for a flag that exists
 	cmd.MarkFlagRequired("text")
 	return cmd
@@ -140,28 +216,20 @@ Provide the text of your reply with the --text flag.`,
 
 func newRepliesHideCmd(f *Factory) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "hide [reply-id]",
-		Short: "Hide a reply",
-		Long: `Hide a reply from public view.
+		Use:         "hide [reply-id...]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Hide one or more replies",
+		Long: `Hide replies from public view.
 
 Hidden replies are not visible to other users but can be unhidden later.
-You can only hide replies on posts that you own.`,
-		Args: cobra.ExactArgs(1),
+You can only hide replies on posts that you own. When several reply IDs are
+given they are hidden with a single batch request and the outcome of each is
+listed; the command exits with status 3 if any of them failed.`,
+		Example: `  threads replies hide 12345
+  threads replies hide 12345 67890`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			replyID := args[0]
-			ctx := cmd.Context()
-
-			client, err := f.Client(ctx)
-			if err != nil {
-				return err
-			}
-
-			if err := client.HideReply(ctx, api.PostID(replyID)); err != nil {
-				return WrapError("failed to hide reply", err)
-			}
-
-			f.UI(ctx).Success("Reply %s hidden", replyID)
-			return nil
+			return runRepliesVisibility(cmd, f, args, true)
 		},
 	}
 	return cmd
@@ -169,32 +237,88 @@ You can only hide replies on posts that you own.`,
 
 func newRepliesUnhideCmd(f *Factory) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "unhide [reply-id]",
-		Short: "Unhide a reply",
-		Long:  `Unhide a previously hidden reply, making it visible again.`,
-		Args:  cobra.ExactArgs(1),
+		Use:         "unhide [reply-id...]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Unhide one or more replies",
+		Long: `Unhide previously hidden replies, making them visible again.
+
+When several reply IDs are given they are unhidden with a single batch request
+and the outcome of each is listed; the command exits with status 3 if any of
+them failed.`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			replyID := args[0]
-			ctx := cmd.Context()
+			return runRepliesVisibility(cmd, f, args, false)
+		},
+	}
+	return cmd
+}
 
-			client, err := f.Client(ctx)
-			if err != nil {
-				return err
-			}
+func runRepliesVisibility(cmd *cobra.Command, f *Factory, replyIDs []string, hide bool) error {
+	ctx := cmd.Context()
 
-			if err := client.UnhideReply(ctx, api.PostID(replyID)); err != nil {
-				return WrapError("failed to unhide reply", err)
-			}
+	action := "unhide"
+	past := "unhidden"
+	if hide {
+		action = "hide"
+		past = "hidden"
+	}
 
-			f.UI(ctx).Success("Reply %s unhidden", replyID)
-			return nil
-		},
+	client, err := f.Client(ctx)
+	if err != nil {
+		return err
 	}
-	return cmd
+
+	if len(replyIDs) == 1 {
+		if hide {
+			err = client.HideReply(ctx, api.PostID(replyIDs[0]))
+		} else {
+			err = client.UnhideReply(ctx, api.PostID(replyIDs[0]))
+		}
+		if err != nil {
+			return WrapError("failed to "+action+" reply", err)
+		}
+		f.UI(ctx).Success("Reply %s %s", replyIDs[0], past)
+		return nil
+	}
+
+	var ids []api.PostID
+	seen := map[string]bool{}
+	for _, id := range replyIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, api.PostID(id))
+		}
+	}
+
+	if hide {
+		err = client.HideReplies(ctx, ids)
+	} else {
+		err = client.UnhideReplies(ctx, ids)
+	}
+
+	var batchErr *api.BatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return WrapError("failed to "+action+" replies", err)
+	}
+	result := newBatchResult(action, "replies")
+	done := map[string]bool{}
+	for _, id := range replyIDs {
+		switch {
+		case done[id]:
+			result.skip(id, "listed more than once")
+		case batchErr != nil && batchErr.Errors[id] != nil:
+			result.fail(id, batchErr.Errors[id])
+		default:
+			result.succeed(id)
+		}
+		done[id] = true
+	}
+	return writeBatchResult(ctx, result)
 }
 
 func newRepliesConversationCmd(f *Factory) *cobra.Command {
 	var limit int
+	var cursor string
 
 	cmd := &cobra.Command{
 		Use:   "conversation [post-id]",
@@ -212,7 +336,7 @@ Returns all replies in the conversation in a flattened format.`,
 				return err
 			}
 
-			opts := &api.RepliesOptions{}
+			opts := &api.RepliesOptions{After: cursor}
 			if limit > 0 {
 				opts.Limit = limit
 			}
@@ -224,7 +348,7 @@ Returns all replies in the conversation in a flattened format.`,
 
 			io := iocontext.GetIO(ctx)
 			if outfmt.IsJSON(ctx) {
-				return outfmt.WriteJSONTo(io.Out, result, outfmt.GetQuery(ctx))
+				return outfmt.WriteJSONContext(ctx, io.Out, result)
 			}
 
 			if len(result.Data) == 0 {
@@ -233,6 +357,17 @@ Returns all replies in the conversation in a flattened format.`,
 			}
 
 			headers := []string{"ID", "FROM", "TEXT", "DATE"}
+			colTypes := []outfmt.ColumnType{
+				outfmt.ColumnID,
+				outfmt.ColumnPlain,
+				outfmt.ColumnPlain,
+				outfmt.ColumnDate,
+			}
+			noted := postNotes(ctx, f, len(result.Data), func(i int) *api.Post { return &result.Data[i] })
+			if noted != nil {
+				headers = append(headers, "NOTE")
+				colTypes = append(colTypes, outfmt.ColumnPlain)
+			}
 			rows := make([][]string, len(result.Data))
 			for i, reply := range result.Data {
 				text := strings.ReplaceAll(reply.Text, "\n", " ")
@@ -245,18 +380,22 @@ Returns all replies in the conversation in a flattened format.`,
 					text,
 					reply.Timestamp.Format("2006-01-02 15:04"),
 				}
+				if noted != nil {
+					rows[i] = append(rows[i], noted[i])
+				}
 			}
 
 			out := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
-			return out.Table(headers, rows, []outfmt.ColumnType{
-				outfmt.ColumnID,
-				outfmt.ColumnPlain,
-				outfmt.ColumnPlain,
-				outfmt.ColumnDate,
-			})
+			if err := out.Table(headers, rows, colTypes); err != nil {
+				return err
+			}
+
+			writeListFooter(cmd, args, len(result.Data), result.NextCursor())
+			return nil
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of posts to return")
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Pagination cursor for the next page")
 	return cmd
 }
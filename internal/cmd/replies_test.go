@@ -1,6 +1,17 @@
 package cmd
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
 
 func TestRepliesCmd_Structure(t *testing.T) {
 	f := newTestFactory(t)
@@ -55,7 +66,7 @@ func TestRepliesListCmd_Structure(t *testing.T) {
 	}
 
 	if cmd.Args == nil {
-		t.Error("expected Args validator for exactly 1 arg")
+		t.Error("expected Args validator for at least 1 arg")
 	}
 
 	if cmd.RunE == nil {
@@ -86,7 +97,7 @@ func TestRepliesCreateCmd_Structure(t *testing.T) {
 	}
 
 	if cmd.Args == nil {
-		t.Error("expected Args validator for exactly 1 arg")
+		t.Error("expected Args validator for at least 1 arg")
 	}
 
 	if cmd.RunE == nil {
@@ -112,12 +123,12 @@ func TestRepliesHideCmd_Structure(t *testing.T) {
 	f := newTestFactory(t)
 	cmd := newRepliesHideCmd(f)
 
-	if cmd.Use != "hide [reply-id]" {
-		t.Errorf("expected Use='hide [reply-id]', got %s", cmd.Use)
+	if cmd.Use != "hide [reply-id...]" {
+		t.Errorf("expected Use='hide [reply-id...]', got %s", cmd.Use)
 	}
 
 	if cmd.Args == nil {
-		t.Error("expected Args validator for exactly 1 arg")
+		t.Error("expected Args validator for at least 1 arg")
 	}
 
 	if cmd.RunE == nil {
@@ -129,12 +140,12 @@ func TestRepliesUnhideCmd_Structure(t *testing.T) {
 	f := newTestFactory(t)
 	cmd := newRepliesUnhideCmd(f)
 
-	if cmd.Use != "unhide [reply-id]" {
-		t.Errorf("expected Use='unhide [reply-id]', got %s", cmd.Use)
+	if cmd.Use != "unhide [reply-id...]" {
+		t.Errorf("expected Use='unhide [reply-id...]', got %s", cmd.Use)
 	}
 
 	if cmd.Args == nil {
-		t.Error("expected Args validator for exactly 1 arg")
+		t.Error("expected Args validator for at least 1 arg")
 	}
 
 	if cmd.RunE == nil {
@@ -151,7 +162,7 @@ func TestRepliesConversationCmd_Structure(t *testing.T) {
 	}
 
 	if cmd.Args == nil {
-		t.Error("expected Args validator for exactly 1 arg")
+		t.Error("expected Args validator for at least 1 arg")
 	}
 
 	if cmd.RunE == nil {
@@ -172,3 +183,80 @@ func TestRepliesConversationCmd_Flags(t *testing.T) {
 		t.Errorf("expected limit default=25, got %s", limitFlag.DefValue)
 	}
 }
+
+func TestRepliesHide_MultipleUsesBatch(t *testing.T) {
+	var got []api.PostID
+	mock := &mockAPI{
+		hideReplies: func(_ context.Context, replyIDs []api.PostID) error {
+			got = replyIDs
+			return &api.BatchError{Errors: map[string]error{"2": errors.New("not allowed")}}
+		},
+	}
+
+	f, io := newMockAPITestFactory(t, mock)
+
+	cmd := newRepliesHideCmd(f)
+	cmd.SetArgs([]string{"1", "2", "3"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected error for partial failure")
+	}
+	if !strings.Contains(err.Error(), "1 of 3") {
+		t.Errorf("expected failure count in error, got: %v", err)
+	}
+	if len(got) != 3 {
+		t.Errorf("expected 3 reply IDs in one batch, got %v", got)
+	}
+	out := io.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, "FAILED") || !strings.Contains(out, "not allowed") || !strings.Contains(out, "2 succeeded, 1 failed, 0 skipped") {
+		t.Errorf("expected per-reply results and summary, got: %s", out)
+	}
+	if code := ExitCode(err); code != ExitPartial {
+		t.Errorf("expected exit code %d, got %d", ExitPartial, code)
+	}
+}
+
+func TestRepliesHide_BatchJSON(t *testing.T) {
+	var got []api.PostID
+	mock := &mockAPI{
+		hideReplies: func(_ context.Context, replyIDs []api.PostID) error {
+			got = replyIDs
+			return nil
+		},
+	}
+
+	f, io := newMockAPITestFactory(t, mock)
+
+	cmd := newRepliesHideCmd(f)
+	cmd.SetArgs([]string{"1", "2", "1"})
+	cmd.SetContext(outfmt.NewContext(iocontext.WithIO(context.Background(), io), outfmt.JSON))
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("expected the duplicate ID to be sent once, got %v", got)
+	}
+
+	var result struct {
+		Action    string `json:"action"`
+		Succeeded int    `json:"succeeded"`
+		Skipped   int    `json:"skipped"`
+		Items     []struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+			Reason string `json:"reason"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Action != "hide" || result.Succeeded != 2 || result.Skipped != 1 || len(result.Items) != 3 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.Items[2].Status != batchSkipped || result.Items[2].ID != "1" {
+		t.Errorf("expected the repeated ID to be skipped, got %+v", result.Items[2])
+	}
+}
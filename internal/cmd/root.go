@@ -3,10 +3,15 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/salmonumbrella/threads-cli/internal/api"
 	"github.com/salmonumbrella/threads-cli/internal/iocontext"
 	"github.com/salmonumbrella/threads-cli/internal/outfmt"
 )
@@ -18,6 +23,9 @@ var (
 	BuildDate = "unknown"
 )
 
+// outputFormats are the values of --output
+var outputFormats = []string{"text", "json", "ids", "csv", "tsv"}
+
 // RootOptions captures global flags.
 type RootOptions struct {
 	Account string
@@ -25,7 +33,16 @@ type RootOptions struct {
 	Color   string
 	Debug   bool
 	Query   string
+	Raw     bool
 	Yes     bool
+	Flatten bool
+	Footer  bool
+	Strict  bool
+	IDOnly  bool
+	NoCache bool
+
+	DebugHTTPFile    string
+	DebugHTTPMaxBody int
 }
 
 // Execute runs the CLI with a new factory and root command.
@@ -54,20 +71,52 @@ func ExecuteCommand(cmd *cobra.Command, f *Factory) error {
 		cmd.SetErr(f.IO.ErrOut)
 	}
 
-	err := cmd.Execute()
+	executed, err := cmd.ExecuteC()
+	if f != nil {
+		f.closeHTTPTrace()
+	}
+	if io == nil && f != nil {
+		io = f.IO
+	}
+	if io == nil {
+		io = iocontext.DefaultIO()
+	}
+
+	if f != nil && executed != nil {
+		recordAudit(f, io.ErrOut, executed, err)
+	}
+
+	if f != nil && f.Debug && executed != nil && executed.Context() != nil {
+		writeResponseMeta(io.ErrOut, api.ResponseMetasFromContext(executed.Context()))
+	}
+
 	if err != nil {
 		formatted := FormatError(err)
-		if io == nil && f != nil {
-			io = f.IO
-		}
-		if io == nil {
-			io = iocontext.DefaultIO()
+		if executed != nil {
+			formatted = scopeHint(formatted, commandName(executed))
 		}
 		fmt.Fprintln(io.ErrOut, formatted.Error()) //nolint:errcheck // Best-effort output
 	}
 	return err
 }
 
+// writeResponseMeta prints one line per API response for --debug output.
+func writeResponseMeta(w io.Writer, metas []*api.ResponseMeta) {
+	for _, meta := range metas {
+		line := fmt.Sprintf("[DEBUG] %s %s -> %d in %s", meta.Method, meta.Path, meta.StatusCode, meta.Duration.Round(time.Millisecond))
+		if meta.Attempt > 1 {
+			line += fmt.Sprintf(" (attempt %d)", meta.Attempt)
+		}
+		if meta.RequestID != "" {
+			line += " request_id=" + meta.RequestID
+		}
+		if rl := meta.RateLimit; rl != nil && rl.Limit > 0 {
+			line += fmt.Sprintf(" rate_limit=%d/%d", rl.Remaining, rl.Limit)
+		}
+		fmt.Fprintln(w, line) //nolint:errcheck // Best-effort output
+	}
+}
+
 // NewRootCmd constructs the root command and wires subcommands.
 func NewRootCmd(f *Factory) *cobra.Command {
 	opts := &RootOptions{
@@ -75,6 +124,8 @@ func NewRootCmd(f *Factory) *cobra.Command {
 		Output:  f.Config.Output,
 		Color:   f.Config.Color,
 		Debug:   f.Config.Debug,
+		Footer:  f.Config.Footer,
+		Strict:  f.Config.Strict,
 	}
 
 	cmd := &cobra.Command{
@@ -93,6 +144,10 @@ Designed to be agent-friendly for automation with Claude and other AI assistants
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkPolicy(cmd); err != nil {
+				return err
+			}
+
 			ctx := cmd.Context()
 
 			if !iocontext.HasIO(ctx) {
@@ -106,10 +161,35 @@ Designed to be agent-friendly for automation with Claude and other AI assistants
 			if output == "" {
 				output = "text"
 			}
-			if output != "text" && output != "json" {
+			if opts.IDOnly {
+				if cmd.Flags().Changed("output") && opts.Output != "ids" {
+					return &UserFriendlyError{
+						Message:    "--id-only cannot be combined with --output " + opts.Output,
+						Suggestion: "Drop --output or use --output ids",
+					}
+				}
+				output = "ids"
+			}
+			// A jq query only makes sense on JSON, so it implies --output json
+			if opts.Query != "" || opts.Raw {
+				if (cmd.Flags().Changed("output") && opts.Output != "json") || opts.IDOnly {
+					return &UserFriendlyError{
+						Message:    "--jq and --raw-output require JSON output",
+						Suggestion: "Drop --output or use --output json",
+					}
+				}
+				output = "json"
+			}
+			if !slices.Contains(outputFormats, output) {
 				return &UserFriendlyError{
 					Message:    fmt.Sprintf("Invalid output value: %s", output),
-					Suggestion: "Valid values are: text, json",
+					Suggestion: "Valid values are: " + strings.Join(outputFormats, ", "),
+				}
+			}
+			if opts.Flatten && output != "json" {
+				return &UserFriendlyError{
+					Message:    "--flatten requires JSON output",
+					Suggestion: "Add --output json",
 				}
 			}
 
@@ -140,41 +220,94 @@ Designed to be agent-friendly for automation with Claude and other AI assistants
 				account = opts.Account
 			}
 
+			strict := f.Config.Strict
+			if cmd.Flags().Changed("strict") {
+				strict = opts.Strict
+			}
+
 			f.Output = outfmt.ParseFormat(output)
 			f.ColorMode = outfmt.ParseColorMode(color)
 			f.Debug = debug
 			f.Account = account
+			f.Strict = strict
+			f.NoCache = opts.NoCache
+			f.command = commandName(cmd)
 
 			ctx = outfmt.NewContext(ctx, f.Output)
 			ctx = outfmt.WithQuery(ctx, opts.Query)
+			ctx = outfmt.WithRawOutput(ctx, opts.Raw)
+			ctx = outfmt.WithFlatten(ctx, opts.Flatten)
+			ctx = outfmt.WithFooter(ctx, opts.Footer)
 			ctx = outfmt.WithYes(ctx, opts.Yes)
+			ctx = outfmt.WithStrict(ctx, strict)
 			ctx = outfmt.WithColorMode(ctx, f.ColorMode)
+			if debug {
+				ctx = api.WithResponseMeta(ctx)
+			}
 			cmd.SetContext(ctx)
 
+			if opts.DebugHTTPFile != "" {
+				if err := f.openHTTPTrace(opts.DebugHTTPFile, opts.DebugHTTPMaxBody); err != nil {
+					return err
+				}
+			}
+
 			return nil
 		},
 	}
 
 	cmd.PersistentFlags().StringVarP(&opts.Account, "account", "a", opts.Account, "Account name to use (or set THREADS_ACCOUNT)")
-	cmd.PersistentFlags().StringVarP(&opts.Output, "output", "o", opts.Output, "Output format: text, json")
+	cmd.PersistentFlags().StringVarP(&opts.Output, "output", "o", opts.Output, "Output format: "+strings.Join(outputFormats, ", "))
 	cmd.PersistentFlags().StringVar(&opts.Color, "color", opts.Color, "Color output: auto, always, never")
 	cmd.PersistentFlags().BoolVar(&opts.Debug, "debug", opts.Debug, "Enable debug output")
-	cmd.PersistentFlags().StringVarP(&opts.Query, "query", "q", "", "JQ query to filter JSON output")
+	cmd.PersistentFlags().StringVar(&opts.DebugHTTPFile, "debug-http-file", "", "Append full HTTP request/response logs (secrets redacted) to this file")
+	cmd.PersistentFlags().IntVar(&opts.DebugHTTPMaxBody, "debug-http-max-body", api.DefaultHTTPTraceMaxBody, "Maximum bytes of each body written to --debug-http-file (-1 omits bodies)")
+	cmd.PersistentFlags().StringVar(&opts.Query, "jq", "", "jq expression to filter JSON output (implies --output json)")
+	cmd.PersistentFlags().StringVarP(&opts.Query, "query", "q", "", "Alias for --jq")
+	cmd.PersistentFlags().BoolVarP(&opts.Raw, "raw-output", "r", false, "Print string results of --jq without quotes")
+	cmd.PersistentFlags().BoolVar(&opts.Footer, "footer", opts.Footer, "Print a paging summary with the next-page command after list output")
+	cmd.PersistentFlags().BoolVar(&opts.Flatten, "flatten", false, "Flatten nested JSON objects into dotted keys (with --output json)")
 	cmd.PersistentFlags().BoolVarP(&opts.Yes, "yes", "y", false, "Skip confirmation prompts")
+	cmd.PersistentFlags().BoolVar(&opts.Strict, "strict", opts.Strict, "Fail on warnings and never prompt, for scripts and CI (or set THREADS_STRICT)")
+	cmd.PersistentFlags().BoolVar(&opts.IDOnly, "id-only", false, "Print only IDs, one per line (same as --output ids)")
+	cmd.PersistentFlags().BoolVar(&opts.NoCache, "no-cache", false, "Fetch fresh API responses instead of using or updating the response cache")
 
+	cmd.AddCommand(NewAPICmd(f))
+	cmd.AddCommand(NewAuditCmd(f))
 	cmd.AddCommand(NewAuthCmd(f))
+	cmd.AddCommand(NewBookmarksCmd(f))
+	cmd.AddCommand(NewCacheCmd(f))
 	cmd.AddCommand(NewCompletionCmd())
+	cmd.AddCommand(NewDemoCmd(f))
+	cmd.AddCommand(NewDraftsCmd(f))
+	cmd.AddCommand(NewExamplesCmd(f))
+	cmd.AddCommand(NewFixturesCmd(f))
 	cmd.AddCommand(NewInsightsCmd(f))
 	cmd.AddCommand(NewLocationsCmd(f))
 	cmd.AddCommand(NewUsersMeCmd(f))
+	cmd.AddCommand(NewMuteCmd(f))
+	cmd.AddCommand(NewNotesCmd(f))
 	cmd.AddCommand(NewPostsCmd(f))
+	cmd.AddCommand(NewPrivacyCmd(f))
+	cmd.AddCommand(NewQueueCmd(f))
 	cmd.AddCommand(NewRateLimitCmd(f))
+	cmd.AddCommand(NewRecycleCmd(f))
+	cmd.AddCommand(NewReleaseCmd(f))
+	cmd.AddCommand(NewRenderCmd(f))
 	cmd.AddCommand(NewRepliesCmd(f))
+	cmd.AddCommand(NewSchemaCmd(f))
 	cmd.AddCommand(NewSearchCmd(f))
+	cmd.AddCommand(NewSeriesCmd(f))
+	cmd.AddCommand(NewStateCmd(f))
+	cmd.AddCommand(NewSelftestCmd(f))
+	cmd.AddCommand(NewUnrollCmd(f))
 	cmd.AddCommand(NewUsersCmd(f))
 	cmd.AddCommand(NewVersionCmd())
+	cmd.AddCommand(NewWatchCmd(f))
 	cmd.AddCommand(NewWebhooksCmd(f))
 	cmd.AddCommand(NewConfigCmd(f))
+	cmd.AddCommand(NewScaffoldCmd(f))
+	cmd.SetHelpCommand(newHelpCmd(f))
 
 	return cmd
 }
@@ -3,9 +3,16 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/salmonumbrella/threads-cli/internal/api"
 	"github.com/salmonumbrella/threads-cli/internal/iocontext"
 	"github.com/salmonumbrella/threads-cli/internal/outfmt"
 )
@@ -45,18 +52,39 @@ func TestRootCmd_HasSubcommands(t *testing.T) {
 	cmd := NewRootCmd(f)
 
 	expectedSubs := []string{
+		"api",
+		"audit",
 		"auth",
+		"bookmarks",
+		"cache",
 		"completion",
 		"config",
+		"demo",
+		"drafts",
+		"examples",
+		"fixtures",
 		"insights",
 		"locations",
 		"me",
+		"mute",
+		"notes",
 		"posts",
+		"privacy",
+		"queue",
 		"ratelimit",
+		"recycle",
+		"release",
+		"render",
 		"replies",
+		"scaffold",
+		"schema",
 		"search",
+		"selftest",
+		"series",
+		"unroll",
 		"users",
 		"version",
+		"watch",
 		"webhooks",
 	}
 
@@ -85,7 +113,14 @@ func TestRootCmd_GlobalFlags(t *testing.T) {
 		{"output", "o"},
 		{"color", ""},
 		{"debug", ""},
+		{"debug-http-file", ""},
+		{"debug-http-max-body", ""},
+		{"footer", ""},
+		{"flatten", ""},
+		{"jq", ""},
 		{"query", "q"},
+		{"raw-output", "r"},
+		{"strict", ""},
 		{"yes", "y"},
 	}
 
@@ -162,3 +197,228 @@ func TestExecute_WithContext(t *testing.T) {
 		t.Error("expected help output to contain 'Threads CLI'")
 	}
 }
+
+func TestWriteResponseMeta(t *testing.T) {
+	var buf bytes.Buffer
+	writeResponseMeta(&buf, []*api.ResponseMeta{
+		{
+			Method:     "GET",
+			Path:       "/me",
+			StatusCode: 200,
+			RequestID:  "abc",
+			Duration:   1500 * time.Microsecond,
+			Attempt:    2,
+			RateLimit:  &api.RateLimitInfo{Limit: 100, Remaining: 42},
+		},
+	})
+
+	got := buf.String()
+	for _, want := range []string{"GET /me -> 200 in 2ms", "(attempt 2)", "request_id=abc", "rate_limit=42/100"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output, got: %s", want, got)
+		}
+	}
+}
+
+func TestExecute_DebugHTTPFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "refresh_access_token") {
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"12345","username":"testuser","threads_biography":"` + strings.Repeat("x", 200) + `"}`))
+	}))
+	defer server.Close()
+
+	f, io := newIntegrationTestFactory(t, server.URL)
+	logPath := filepath.Join(t.TempDir(), "http.log")
+
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"--debug-http-file", logPath, "--debug-http-max-body", "64", "me"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	if err := ExecuteCommand(cmd, f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.httpTraceFile != nil {
+		t.Error("expected the HTTP debug log to be closed after the command")
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read HTTP debug log: %v", err)
+	}
+	log := string(data)
+
+	if !strings.Contains(log, "--> ") || !strings.Contains(log, "<-- ") {
+		t.Errorf("expected request and response entries, got:\n%s", log)
+	}
+	if strings.Contains(log, "refreshed-token") || strings.Contains(log, "test-access-token") {
+		t.Errorf("expected tokens to be redacted, got:\n%s", log)
+	}
+	if !strings.Contains(log, "[truncated") {
+		t.Errorf("expected the large body to be truncated, got:\n%s", log)
+	}
+	if strings.Contains(io.ErrOut.(*bytes.Buffer).String(), "-->") {
+		t.Error("expected HTTP logs to stay out of terminal output")
+	}
+
+	info, err := os.Stat(logPath)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	// Windows has no permission bits to check
+	if perm := info.Mode().Perm(); perm != 0o600 && runtime.GOOS != "windows" {
+		t.Errorf("expected log permissions 0600, got %o", perm)
+	}
+}
+
+func TestExecute_FlattenRequiresJSON(t *testing.T) {
+	f := newTestFactory(t)
+
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"--flatten", "config", "path"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+
+	err := ExecuteCommand(cmd, f)
+	if err == nil {
+		t.Fatal("expected an error for --flatten without --output json")
+	}
+	if !strings.Contains(err.Error(), "--flatten requires JSON output") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestExecute_JQImpliesJSONAndRawOutput(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "refresh_access_token") {
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"12345","username":"testuser"}`))
+	}))
+	defer server.Close()
+
+	f, io := newIntegrationTestFactory(t, server.URL)
+
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"me", "--jq", ".username", "-r"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	if err := ExecuteCommand(cmd, f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := io.Out.(*bytes.Buffer).String(); got != "testuser\n" {
+		t.Errorf("expected raw username, got %q", got)
+	}
+}
+
+func TestExecute_IDOnlyPrintsListIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "refresh_access_token"):
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+		case strings.HasSuffix(r.URL.Path, "/threads"):
+			_, _ = w.Write([]byte(`{"data":[{"id":"111","text":"a"},{"id":"222","text":"b"}],"paging":{"cursors":{"after":"x"}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"12345","username":"testuser"}`))
+		}
+	}))
+	defer server.Close()
+
+	for _, args := range [][]string{
+		{"posts", "list", "--id-only"},
+		{"posts", "list", "-o", "ids"},
+	} {
+		f, io := newIntegrationTestFactory(t, server.URL)
+		cmd := NewRootCmd(f)
+		cmd.SetArgs(args)
+		cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+		if err := ExecuteCommand(cmd, f); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		if got := io.Out.(*bytes.Buffer).String(); got != "111\n222\n" {
+			t.Errorf("%v: expected one ID per line, got %q", args, got)
+		}
+	}
+}
+
+func TestExecute_CSVPrintsListTable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "refresh_access_token"):
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+		case strings.HasSuffix(r.URL.Path, "/threads"):
+			_, _ = w.Write([]byte(`{"data":[{"id":"111","media_type":"TEXT","text":"hello, world","timestamp":"2025-07-01T10:00:00+0000"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"12345","username":"testuser"}`))
+		}
+	}))
+	defer server.Close()
+
+	f, io := newIntegrationTestFactory(t, server.URL)
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"posts", "list", "-o", "csv"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+
+	if err := ExecuteCommand(cmd, f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(io.Out.(*bytes.Buffer).String(), "\n")
+	if len(lines) != 3 || lines[0] != "ID,TYPE,TEXT,TIMESTAMP" || !strings.HasPrefix(lines[1], `111,TEXT,"hello, world",`) {
+		t.Errorf("expected a header row and a quoted record, got %q", lines)
+	}
+}
+
+func TestExecute_IDOnlyConflicts(t *testing.T) {
+	for _, args := range [][]string{
+		{"--id-only", "--output", "json", "config", "path"},
+		{"--id-only", "--jq", ".", "config", "path"},
+	} {
+		f := newTestFactory(t)
+		cmd := NewRootCmd(f)
+		cmd.SetArgs(args)
+		cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+
+		if err := ExecuteCommand(cmd, f); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
+func TestExecute_JQRejectsTextOutput(t *testing.T) {
+	f := newTestFactory(t)
+
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"--jq", ".", "--output", "text", "config", "path"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+
+	err := ExecuteCommand(cmd, f)
+	if err == nil || !strings.Contains(err.Error(), "require JSON output") {
+		t.Errorf("expected a JSON output error, got %v", err)
+	}
+}
+
+func TestExecute_InvalidJQExitCode(t *testing.T) {
+	f := newTestFactory(t)
+
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"--jq", ".[invalid", "config", "list"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+
+	err := ExecuteCommand(cmd, f)
+	if err == nil {
+		t.Fatal("expected an error for an invalid jq expression")
+	}
+	if code := ExitCode(err); code != ExitQuery {
+		t.Errorf("expected exit code %d, got %d", ExitQuery, code)
+	}
+	if !strings.Contains(f.IO.ErrOut.(*bytes.Buffer).String(), "invalid jq query") {
+		t.Errorf("expected the jq error on stderr, got %q", f.IO.ErrOut.(*bytes.Buffer).String())
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/scaffold"
+)
+
+// NewScaffoldCmd builds the scaffold command group.
+func NewScaffoldCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scaffold",
+		Short: "Generate starter projects",
+		Long:  `Generate starter projects for automating Threads with the CLI.`,
+	}
+
+	cmd.AddCommand(newScaffoldBotCmd(f))
+
+	return cmd
+}
+
+func newScaffoldBotCmd(f *Factory) *cobra.Command {
+	var (
+		lang  string
+		name  string
+		force bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "bot [dir]",
+		Short: "Generate a starter bot project",
+		Long: `Generate a small bot project that drives the threads CLI.
+
+The project includes a token helper that refreshes the stored token before
+it expires, one-off and scheduled posting examples, and a webhook handler
+that replies to mentions. The active account (--account) becomes the
+project's default account.
+
+The directory defaults to ./threads-bot and must be empty unless --force is given.`,
+		Example: `  threads scaffold bot
+  threads scaffold bot ./mybot --lang bash
+  threads scaffold bot ./mybot --lang go --account work`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "threads-bot"
+			if len(args) == 1 {
+				dir = args[0]
+			}
+
+			files, err := scaffold.Generate(scaffold.Options{
+				Lang:    scaffold.Lang(strings.ToLower(lang)),
+				Dir:     dir,
+				Name:    name,
+				Account: f.Account,
+				Force:   force,
+			})
+			if err != nil {
+				return &UserFriendlyError{
+					Message:    fmt.Sprintf("Failed to generate bot project: %v", err),
+					Suggestion: "Choose an empty directory, or pass --force to overwrite files. Supported languages: " + scaffoldLanguages(),
+					Cause:      err,
+				}
+			}
+
+			ctx := cmd.Context()
+			io := iocontext.GetIO(ctx)
+			if outfmt.IsJSON(ctx) {
+				return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
+					"dir":   dir,
+					"lang":  lang,
+					"files": files,
+				})
+			}
+
+			f.UI(ctx).Success("Created %s bot in %s", lang, dir)
+			for _, file := range files {
+				fmt.Fprintf(io.Out, "  %s\n", filepath.Join(dir, file)) //nolint:errcheck // Best-effort output
+			}
+			fmt.Fprintf(io.Out, "\nSee %s for next steps.\n", filepath.Join(dir, "README.md")) //nolint:errcheck // Best-effort output
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&lang, "lang", string(scaffold.LangGo), "Project language: "+scaffoldLanguages())
+	cmd.Flags().StringVar(&name, "name", "", "Project name (default: the directory name)")
+	cmd.Flags().BoolVar(&force, "force", false, "Write into a non-empty directory, overwriting existing files")
+
+	return cmd
+}
+
+func scaffoldLanguages() string {
+	langs := scaffold.Languages()
+	names := make([]string, len(langs))
+	for i, lang := range langs {
+		names[i] = string(lang)
+	}
+	return strings.Join(names, ", ")
+}
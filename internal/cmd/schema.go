@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/jsonschema"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// outputSchema is the type a command prints with --output json
+type outputSchema struct {
+	Value any
+	// NDJSON is set when the command prints one value per line
+	NDJSON bool
+}
+
+// outputSchemas maps command paths, without the root command, to their JSON
+// output. A command printing a new payload type should be added here.
+var outputSchemas = map[string]outputSchema{
+	"auth status":          {Value: authStatus{}},
+	"demo":                 {Value: demoServer{}},
+	"insights account":     {Value: api.InsightsResponse{}},
+	"insights post":        {Value: api.InsightsResponse{}},
+	"insights reach":       {Value: reachReport{}},
+	"posts archive":        {Value: batchResult{}},
+	"posts carousel":       {Value: api.Post{}},
+	"posts create":         {Value: api.Post{}},
+	"posts get":            {Value: postDetail{}},
+	"posts ghost-list":     {Value: postsList{}},
+	"posts list":           {Value: postsList{}},
+	"posts quote":          {Value: api.Post{}},
+	"ratelimit publishing": {Value: api.PublishingLimits{}},
+	"ratelimit status":     {Value: rateLimitStatus{}},
+	"replies conversation": {Value: api.RepliesResponse{}},
+	"replies create":       {Value: api.Post{}},
+	"replies hide":         {Value: batchResult{}},
+	"replies list":         {Value: api.Page[annotatedPost]{}},
+	"replies unhide":       {Value: batchResult{}},
+	"search":               {Value: api.PostsResponse{}},
+	"users mentions":       {Value: api.Page[annotatedPost]{}},
+	"watch":                {Value: api.Post{}, NDJSON: true},
+}
+
+// NewSchemaCmd builds the schema command.
+func NewSchemaCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema [command]",
+		Short: "Print the JSON Schema of a command's JSON output",
+		Long: `Print the JSON Schema (draft 2020-12) of what a command prints with
+--output json, for generating types in other languages or validating output.
+
+Schemas are generated from the types that produce the output, so they match
+the installed version. Without a command, lists the commands with schemas.`,
+		Example: `  threads schema
+  threads schema posts get
+  threads schema replies list > replies.schema.json`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			io := iocontext.GetIO(ctx)
+
+			if len(args) == 0 {
+				names := make([]string, 0, len(outputSchemas))
+				for name := range outputSchemas {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				if outfmt.IsJSON(ctx) {
+					return outfmt.WriteJSONContext(ctx, io.Out, names)
+				}
+				for _, name := range names {
+					fmt.Fprintln(io.Out, name) //nolint:errcheck // Best-effort output
+				}
+				return nil
+			}
+
+			schema, err := commandSchema(cmd.Root(), args)
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(io.Out)
+			enc.SetIndent("", "  ")
+			return enc.Encode(schema)
+		},
+	}
+}
+
+// commandSchema returns the schema of the JSON output of the command at args
+func commandSchema(root *cobra.Command, args []string) (*jsonschema.Schema, error) {
+	name := strings.Join(args, " ")
+	if target, rest, err := root.Find(args); err == nil && len(rest) == 0 {
+		name = strings.TrimPrefix(target.CommandPath(), root.Name()+" ")
+	}
+	out, ok := outputSchemas[name]
+	if !ok {
+		return nil, &UserFriendlyError{
+			Message:    fmt.Sprintf("No JSON output schema for %q", name),
+			Suggestion: "Run 'threads schema' to list the commands with schemas",
+		}
+	}
+
+	g := &jsonschema.Generator{Types: map[reflect.Type]*jsonschema.Schema{
+		reflect.TypeOf(api.Time{}): {Type: "string", Format: "date-time"},
+	}}
+	schema := g.Generate(reflect.TypeOf(out.Value))
+	schema.Title = "threads " + name
+	if out.NDJSON {
+		schema.Description = "Each line of output is one value"
+	}
+	return schema, nil
+}
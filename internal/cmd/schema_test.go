@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/jsonschema"
+)
+
+func TestOutputSchemas_MatchOutput(t *testing.T) {
+	f := newTestFactory(t)
+	root := NewRootCmd(f)
+
+	for name, out := range outputSchemas {
+		target, rest, err := root.Find(strings.Fields(name))
+		if err != nil || len(rest) != 0 || target.CommandPath() != "threads "+name {
+			t.Errorf("%s: no such command", name)
+			continue
+		}
+
+		schema, err := commandSchema(root, strings.Fields(name))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		def := schema.Defs[strings.TrimPrefix(schema.Ref, "#/$defs/")]
+		if def == nil {
+			t.Fatalf("%s: root is not a definition: %+v", name, schema)
+		}
+
+		// Every key the zero value prints is described, and every required one printed
+		value := out.Value
+		if detail, ok := value.(postDetail); ok {
+			detail.Post = &api.Post{}
+			value = detail
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var printed map[string]json.RawMessage
+		if err := json.Unmarshal(data, &printed); err != nil {
+			t.Fatal(err)
+		}
+		for key := range printed {
+			if def.Properties[key] == nil {
+				t.Errorf("%s: printed key %q is not in the schema", name, key)
+			}
+		}
+		for _, key := range def.Required {
+			if _, ok := printed[key]; !ok {
+				t.Errorf("%s: required key %q is not printed", name, key)
+			}
+		}
+	}
+}
+
+func TestSchemaCmd_PrintsSchema(t *testing.T) {
+	f := newTestFactory(t)
+	var out bytes.Buffer
+	f.IO.Out = &out
+
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"schema", "posts", "get"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+		t.Fatalf("expected a JSON schema, got %v:\n%s", err, out.String())
+	}
+	if schema.Draft != jsonschema.Draft || schema.Title != "threads posts get" {
+		t.Errorf("unexpected schema header: %+v", schema)
+	}
+	detail := schema.Defs["PostDetail"]
+	if detail == nil || detail.Properties["metrics"] == nil || detail.Properties["id"] == nil {
+		t.Fatalf("expected the post fields and metrics, got %+v", detail)
+	}
+	if ts := schema.Defs["Post"].Properties["timestamp"]; ts == nil || ts.Format != "date-time" {
+		t.Errorf("expected timestamps to be date-time strings, got %+v", ts)
+	}
+}
+
+func TestSchemaCmd_UnknownCommand(t *testing.T) {
+	f := newTestFactory(t)
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"schema", "posts", "delete"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), f.IO))
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), `No JSON output schema for "posts delete"`) {
+		t.Errorf("expected a missing schema error, got %v", err)
+	}
+}
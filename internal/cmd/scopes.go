@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+// commandName returns the path of cmd without the root command, as
+// api.Endpoint lists its commands
+func commandName(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// checkScopes refuses to run command when the token was granted scopes and
+// one its endpoints need is not among them, instead of failing on the first
+// API call. Tokens stored without their scopes are not checked.
+func checkScopes(command string, granted []string) error {
+	if granted == nil {
+		return nil
+	}
+	var missing []string
+	for _, scope := range api.RequiredScopes(command) {
+		if !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &UserFriendlyError{
+		Message:    fmt.Sprintf("'threads %s' needs the %s scope, which this account's token was not granted", command, strings.Join(missing, ", ")),
+		Suggestion: fmt.Sprintf("Run 'threads auth login --scopes %s' to grant it", strings.Join(mergeScopes(granted, missing), ",")),
+	}
+}
+
+// scopeHint replaces the generic suggestion of an access denied error with
+// the scopes command needs, when the endpoint registry knows them
+func scopeHint(err error, command string) error {
+	var authErr *api.AuthenticationError
+	var ufErr *UserFriendlyError
+	if !errors.As(err, &authErr) || authErr.Code != 403 || !errors.As(err, &ufErr) {
+		return err
+	}
+	scopes := api.RequiredScopes(command)
+	if len(scopes) == 0 {
+		return err
+	}
+	hinted := *ufErr
+	hinted.Suggestion = fmt.Sprintf("'threads %s' needs the %s scope. Run 'threads auth login --scopes %s' to grant it",
+		command, strings.Join(scopes, ", "), strings.Join(mergeScopes(defaultAuthScopes, scopes), ","))
+	return &hinted
+}
+
+// mergeScopes returns base followed by the scopes of extra it lacks
+func mergeScopes(base, extra []string) []string {
+	merged := slices.Clone(base)
+	for _, scope := range extra {
+		if !slices.Contains(merged, scope) {
+			merged = append(merged, scope)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
+)
+
+func TestCheckScopes(t *testing.T) {
+	tests := []struct {
+		command string
+		granted []string
+		wantErr bool
+	}{
+		{"replies hide", nil, false},
+		{"replies hide", []string{"threads_basic", "threads_manage_replies"}, false},
+		{"replies hide", []string{"threads_basic"}, true},
+		// Metrics and conversation stats are skipped without their scopes
+		{"posts get", []string{"threads_basic"}, false},
+		{"config get", []string{}, false},
+	}
+	for _, tt := range tests {
+		if err := checkScopes(tt.command, tt.granted); (err != nil) != tt.wantErr {
+			t.Errorf("checkScopes(%q, %v) = %v, want error %v", tt.command, tt.granted, err, tt.wantErr)
+		}
+	}
+}
+
+func TestClient_RefusesMissingScope(t *testing.T) {
+	called := false
+	f, io := newMockAPITestFactory(t, &mockAPI{
+		hideReplies: func(ctx context.Context, replyIDs []api.PostID) error {
+			called = true
+			return nil
+		},
+	})
+	creds := testCredentials()
+	creds.Scopes = []string{"threads_basic"}
+	f.Store = func() (secrets.Store, error) {
+		return &mockCredentialsStore{creds: creds}, nil
+	}
+
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"replies", "hide", "r1"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "needs the threads_manage_replies scope") ||
+		!strings.Contains(err.Error(), "--scopes threads_basic,threads_manage_replies") {
+		t.Errorf("expected a missing scope error, got %v", err)
+	}
+	if called {
+		t.Error("expected no API call")
+	}
+}
+
+func TestExecute_HintsScopesOnAccessDenied(t *testing.T) {
+	useTempAuditLog(t)
+
+	f, io := newMockAPITestFactory(t, &mockAPI{
+		hideReplies: func(ctx context.Context, replyIDs []api.PostID) error {
+			return api.NewAuthenticationError(403, "Access denied", "")
+		},
+	})
+	cmd := NewRootCmd(f)
+	cmd.SetArgs([]string{"replies", "hide", "r1", "r2"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := ExecuteCommand(cmd, f); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	stderr := io.ErrOut.(*bytes.Buffer).String()
+	if !strings.Contains(stderr, "'threads replies hide' needs the threads_manage_replies scope") {
+		t.Errorf("expected the command's scopes in the suggestion, got:\n%s", stderr)
+	}
+}
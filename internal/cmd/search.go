@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 
@@ -18,10 +17,10 @@ func NewSearchCmd(f *Factory) *cobra.Command {
 		limit      int
 		cursor     string
 		mediaType  string
-		since      string
-		until      string
+		dateRange  dateRangeOptions
 		mode       string
 		searchType string
+		showMuted  bool
 	)
 
 	cmd := &cobra.Command{
@@ -30,7 +29,9 @@ func NewSearchCmd(f *Factory) *cobra.Command {
 		Long: `Search posts by keyword or topic tag.
 
 By default, searches for keywords. Use --mode=tag to search for topic tags instead.
-Results can be sorted by popularity (top) or recency (recent).`,
+Results can be sorted by popularity (top) or recency (recent). Posts from
+users or with keywords muted with 'threads mute' are hidden unless
+--show-muted is set.`,
 		Example: `  # Search for keyword
   threads search "coffee"
 
@@ -41,7 +42,12 @@ Results can be sorted by popularity (top) or recency (recent).`,
   threads search "coffee" --type=recent
 
   # Combine options
-  threads search "technology" --mode=tag --type=recent --media-type=IMAGE`,
+  threads search "technology" --mode=tag --type=recent --media-type=IMAGE
+
+  # Posts from the last week, or a day in a given timezone
+  threads search "coffee" --since 7d
+  threads search "coffee" --month 2025-06
+  threads search "coffee" --since "2025-07-01 00:00 CET" --until "2025-07-02 00:00 CET"`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			query := args[0]
@@ -87,36 +93,22 @@ Results can be sorted by popularity (top) or recency (recent).`,
 				opts.MediaType = mediaType
 			}
 
-			if since != "" {
-				sinceTime, errSince := time.Parse("2006-01-02", since)
-				if errSince != nil {
-					return &UserFriendlyError{
-						Message:    fmt.Sprintf("Invalid --since date: %s", since),
-						Suggestion: "Use YYYY-MM-DD format (e.g., 2024-01-15)",
-					}
-				}
-				opts.Since = sinceTime.Unix()
-			}
-
-			if until != "" {
-				untilTime, errUntil := time.Parse("2006-01-02", until)
-				if errUntil != nil {
-					return &UserFriendlyError{
-						Message:    fmt.Sprintf("Invalid --until date: %s", until),
-						Suggestion: "Use YYYY-MM-DD format (e.g., 2024-01-15)",
-					}
-				}
-				opts.Until = untilTime.Unix()
+			opts.Since, opts.Until, err = dateRange.unixRange(f)
+			if err != nil {
+				return err
 			}
 
 			result, err := client.KeywordSearch(ctx, query, opts)
 			if err != nil {
 				return WrapError("search failed", err)
 			}
+			if err := hideMuted(ctx, result, showMuted); err != nil {
+				return err
+			}
 
 			io := iocontext.GetIO(ctx)
 			if outfmt.IsJSON(ctx) {
-				return outfmt.WriteJSONTo(io.Out, result, outfmt.GetQuery(ctx))
+				return outfmt.WriteJSONContext(ctx, io.Out, result)
 			}
 
 			out := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
@@ -127,6 +119,18 @@ Results can be sorted by popularity (top) or recency (recent).`,
 			}
 
 			headers := []string{"ID", "USER", "TEXT", "TYPE", "DATE"}
+			colTypes := []outfmt.ColumnType{
+				outfmt.ColumnID,
+				outfmt.ColumnPlain,
+				outfmt.ColumnPlain,
+				outfmt.ColumnStatus,
+				outfmt.ColumnDate,
+			}
+			noted := postNotes(ctx, f, len(result.Data), func(i int) *api.Post { return &result.Data[i] })
+			if noted != nil {
+				headers = append(headers, "NOTE")
+				colTypes = append(colTypes, outfmt.ColumnPlain)
+			}
 			rows := make([][]string, len(result.Data))
 			for i, post := range result.Data {
 				text := post.Text
@@ -142,25 +146,27 @@ Results can be sorted by popularity (top) or recency (recent).`,
 					post.MediaType,
 					post.Timestamp.Format("2006-01-02"),
 				}
+				if noted != nil {
+					rows[i] = append(rows[i], noted[i])
+				}
 			}
 
-			return out.Table(headers, rows, []outfmt.ColumnType{
-				outfmt.ColumnID,
-				outfmt.ColumnPlain,
-				outfmt.ColumnPlain,
-				outfmt.ColumnStatus,
-				outfmt.ColumnDate,
-			})
+			if err := out.Table(headers, rows, colTypes); err != nil {
+				return err
+			}
+
+			writeListFooter(cmd, args, len(result.Data), result.NextCursor())
+			return nil
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum results")
 	cmd.Flags().StringVar(&cursor, "cursor", "", "Pagination cursor")
 	cmd.Flags().StringVar(&mediaType, "media-type", "", "Filter by media type (TEXT, IMAGE, VIDEO)")
-	cmd.Flags().StringVar(&since, "since", "", "Posts after date (YYYY-MM-DD)")
-	cmd.Flags().StringVar(&until, "until", "", "Posts before date (YYYY-MM-DD)")
+	addDateRangeFlags(cmd, &dateRange)
 	cmd.Flags().StringVar(&mode, "mode", "keyword", "Search mode: keyword (default) or tag")
 	cmd.Flags().StringVar(&searchType, "type", "top", "Result type: top (default) or recent")
+	addShowMutedFlag(cmd, &showMuted)
 
 	return cmd
 }
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// Selftest step outcomes
+const (
+	selftestPass = "pass"
+	selftestFail = "fail"
+	selftestSkip = "skip"
+)
+
+// selftestStep is the outcome of one step of 'threads selftest'
+type selftestStep struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// selftestRun records steps in order. Once a step fails, later steps are
+// skipped, except cleanup steps which always run.
+type selftestRun struct {
+	steps  []selftestStep
+	failed bool
+}
+
+// step runs fn unless an earlier step failed and reports whether it passed
+func (r *selftestRun) step(name string, fn func() (string, error)) bool {
+	if r.failed {
+		r.skip(name, "an earlier step failed")
+		return false
+	}
+	return r.run(name, fn)
+}
+
+// cleanup runs fn even if an earlier step failed
+func (r *selftestRun) cleanup(name string, fn func() (string, error)) bool {
+	return r.run(name, fn)
+}
+
+func (r *selftestRun) skip(name, reason string) {
+	r.steps = append(r.steps, selftestStep{Name: name, Status: selftestSkip, Detail: reason})
+}
+
+func (r *selftestRun) run(name string, fn func() (string, error)) bool {
+	start := time.Now()
+	detail, err := fn()
+	step := selftestStep{Name: name, Status: selftestPass, Detail: detail, Duration: time.Since(start).Round(time.Millisecond).String()}
+	if err != nil {
+		step.Status = selftestFail
+		step.Detail = FormatError(err).Error()
+		r.failed = true
+	}
+	r.steps = append(r.steps, step)
+	return err == nil
+}
+
+// NewSelftestCmd builds the selftest command.
+func NewSelftestCmd(f *Factory) *cobra.Command {
+	var ghost bool
+
+	cmd := &cobra.Command{
+		Use:         "selftest",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Verify credentials and scopes with a reversible test sequence",
+		Long: `Run a short, reversible sequence against the active account and report
+pass/fail for each step:
+
+  1. authenticate      fetch the account profile
+  2. create post       publish a text post that only you can reply to
+  3. fetch post        read the post back
+  4. reply             reply to the post
+  5. hide reply        hide the reply
+  6. delete reply      remove the reply
+  7. delete post       remove the post
+
+The post and reply are deleted even if a step fails. With --ghost, a ghost
+post is used instead; ghost posts do not accept replies, so the reply steps
+are skipped.
+
+Use a test account: the post is briefly public.`,
+		Example: `  threads selftest --account test
+  threads selftest --account test --ghost --yes -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
+			}
+
+			confirmed, err := f.Confirm(ctx, "This publishes and then deletes a test post. Continue?")
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Fprintln(iocontext.GetIO(ctx).Out, "Cancelled.") //nolint:errcheck // Best-effort output
+				return nil
+			}
+
+			run := runSelftest(ctx, client, ghost)
+			return writeSelftestResult(ctx, f, run)
+		},
+	}
+
+	cmd.Flags().BoolVar(&ghost, "ghost", false, "Use a ghost post (skips the reply steps)")
+	return cmd
+}
+
+func runSelftest(ctx context.Context, client api.API, ghost bool) *selftestRun {
+	run := &selftestRun{}
+	text := fmt.Sprintf("threads-cli selftest %s", time.Now().UTC().Format(time.RFC3339))
+
+	var postID, replyID api.PostID
+
+	run.step("authenticate", func() (string, error) {
+		me, err := client.GetMe(ctx)
+		if err != nil {
+			return "", err
+		}
+		return "@" + me.Username, nil
+	})
+
+	run.step("create post", func() (string, error) {
+		content := &api.TextPostContent{Text: text, ReplyControl: api.ReplyControlParentPostAuthorOnly}
+		if ghost {
+			content = &api.TextPostContent{Text: text, IsGhostPost: true}
+		}
+		post, err := client.CreateTextPost(ctx, content)
+		if err != nil {
+			return "", err
+		}
+		postID = api.PostID(post.ID)
+		return post.ID, nil
+	})
+
+	run.step("fetch post", func() (string, error) {
+		post, err := client.GetPost(ctx, postID)
+		if err != nil {
+			return "", err
+		}
+		if post.Text != text {
+			return "", fmt.Errorf("fetched post text %q does not match %q", post.Text, text)
+		}
+		return post.Permalink, nil
+	})
+
+	if ghost {
+		run.skip("reply", "ghost posts do not accept replies")
+		run.skip("hide reply", "ghost posts do not accept replies")
+	} else {
+		run.step("reply", func() (string, error) {
+			reply, err := client.ReplyToPost(ctx, postID, &api.PostContent{Text: "threads-cli selftest reply"})
+			if err != nil {
+				return "", err
+			}
+			replyID = api.PostID(reply.ID)
+			return reply.ID, nil
+		})
+
+		run.step("hide reply", func() (string, error) {
+			return "", client.HideReply(ctx, replyID)
+		})
+	}
+
+	if replyID.Valid() {
+		run.cleanup("delete reply", func() (string, error) {
+			return "", client.DeletePost(ctx, replyID)
+		})
+	} else if !ghost {
+		run.skip("delete reply", "no reply was created")
+	}
+
+	if postID.Valid() {
+		run.cleanup("delete post", func() (string, error) {
+			return "", client.DeletePost(ctx, postID)
+		})
+	} else {
+		run.skip("delete post", "no post was created")
+	}
+
+	return run
+}
+
+func writeSelftestResult(ctx context.Context, f *Factory, run *selftestRun) error {
+	io := iocontext.GetIO(ctx)
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
+			"passed": !run.failed,
+			"steps":  run.steps,
+		}); err != nil {
+			return err
+		}
+	} else {
+		p := f.UI(ctx)
+		for _, step := range run.steps {
+			switch step.Status {
+			case selftestPass:
+				line := step.Name
+				if step.Detail != "" {
+					line += ": " + step.Detail
+				}
+				fmt.Fprintf(io.Out, "%s %s %s\n", p.Colorize("✓", p.Green), line, p.Dim("("+step.Duration+")")) //nolint:errcheck // Best-effort output
+			case selftestFail:
+				fmt.Fprintf(io.Out, "%s %s: %s\n", p.Colorize("✗", p.Red), step.Name, step.Detail) //nolint:errcheck // Best-effort output
+			default:
+				fmt.Fprintf(io.Out, "%s %s\n", p.Dim("-"), p.Dim(step.Name+": skipped ("+step.Detail+")")) //nolint:errcheck // Best-effort output
+			}
+		}
+	}
+
+	if run.failed {
+		return &UserFriendlyError{
+			Message:    "Selftest failed",
+			Suggestion: "Check the failed step above. Missing scopes usually mean you need to run 'threads auth login' again with the required permissions",
+		}
+	}
+	if !outfmt.IsJSON(ctx) {
+		fmt.Fprintln(io.Out, "\nAll steps passed.") //nolint:errcheck // Best-effort output
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+// selftestAPI records the calls made by runSelftest
+type selftestAPI struct {
+	api.API
+	postText  string
+	createErr error
+	replyErr  error
+	deleted   []api.PostID
+	hidden    []api.PostID
+	ghost     bool
+}
+
+func (m *selftestAPI) GetMe(ctx context.Context) (*api.User, error) {
+	return &api.User{ID: "1", Username: "tester"}, nil
+}
+
+func (m *selftestAPI) CreateTextPost(ctx context.Context, content *api.TextPostContent) (*api.Post, error) {
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+	m.postText = content.Text
+	m.ghost = content.IsGhostPost
+	return &api.Post{ID: "100"}, nil
+}
+
+func (m *selftestAPI) GetPost(ctx context.Context, postID api.PostID) (*api.Post, error) {
+	return &api.Post{ID: string(postID), Text: m.postText, Permalink: "https://threads.net/p/100"}, nil
+}
+
+func (m *selftestAPI) ReplyToPost(ctx context.Context, postID api.PostID, content *api.PostContent) (*api.Post, error) {
+	if m.replyErr != nil {
+		return nil, m.replyErr
+	}
+	return &api.Post{ID: "200"}, nil
+}
+
+func (m *selftestAPI) HideReply(ctx context.Context, replyID api.PostID) error {
+	m.hidden = append(m.hidden, replyID)
+	return nil
+}
+
+func (m *selftestAPI) DeletePost(ctx context.Context, postID api.PostID) error {
+	m.deleted = append(m.deleted, postID)
+	return nil
+}
+
+func selftestStatuses(run *selftestRun) map[string]string {
+	statuses := make(map[string]string, len(run.steps))
+	for _, step := range run.steps {
+		statuses[step.Name] = step.Status
+	}
+	return statuses
+}
+
+func TestRunSelftest_AllPass(t *testing.T) {
+	client := &selftestAPI{}
+	run := runSelftest(context.Background(), client, false)
+
+	if run.failed {
+		t.Fatalf("expected selftest to pass, got steps %+v", run.steps)
+	}
+	if len(run.steps) != 7 {
+		t.Fatalf("expected 7 steps, got %d", len(run.steps))
+	}
+	for _, step := range run.steps {
+		if step.Status != selftestPass {
+			t.Errorf("step %q: expected pass, got %s (%s)", step.Name, step.Status, step.Detail)
+		}
+	}
+	if len(client.hidden) != 1 || client.hidden[0] != "200" {
+		t.Errorf("expected reply 200 to be hidden, got %v", client.hidden)
+	}
+	if len(client.deleted) != 2 || client.deleted[0] != "200" || client.deleted[1] != "100" {
+		t.Errorf("expected reply then post to be deleted, got %v", client.deleted)
+	}
+}
+
+func TestRunSelftest_FailureStillCleansUp(t *testing.T) {
+	client := &selftestAPI{replyErr: errors.New("missing threads_manage_replies")}
+	run := runSelftest(context.Background(), client, false)
+
+	if !run.failed {
+		t.Fatal("expected selftest to fail")
+	}
+	statuses := selftestStatuses(run)
+	want := map[string]string{
+		"authenticate": selftestPass,
+		"create post":  selftestPass,
+		"fetch post":   selftestPass,
+		"reply":        selftestFail,
+		"hide reply":   selftestSkip,
+		"delete reply": selftestSkip,
+		"delete post":  selftestPass,
+	}
+	for name, status := range want {
+		if statuses[name] != status {
+			t.Errorf("step %q: expected %s, got %s", name, status, statuses[name])
+		}
+	}
+	if len(client.deleted) != 1 || client.deleted[0] != "100" {
+		t.Errorf("expected post 100 to be deleted, got %v", client.deleted)
+	}
+}
+
+func TestRunSelftest_CreateFailsSkipsCleanup(t *testing.T) {
+	client := &selftestAPI{createErr: errors.New("boom")}
+	run := runSelftest(context.Background(), client, false)
+
+	statuses := selftestStatuses(run)
+	if statuses["create post"] != selftestFail {
+		t.Errorf("expected create post to fail, got %s", statuses["create post"])
+	}
+	if statuses["delete post"] != selftestSkip {
+		t.Errorf("expected delete post to be skipped, got %s", statuses["delete post"])
+	}
+	if len(client.deleted) != 0 {
+		t.Errorf("expected nothing to be deleted, got %v", client.deleted)
+	}
+}
+
+func TestRunSelftest_Ghost(t *testing.T) {
+	client := &selftestAPI{}
+	run := runSelftest(context.Background(), client, true)
+
+	if run.failed {
+		t.Fatalf("expected selftest to pass, got steps %+v", run.steps)
+	}
+	if !client.ghost {
+		t.Error("expected a ghost post")
+	}
+	statuses := selftestStatuses(run)
+	if statuses["reply"] != selftestSkip || statuses["hide reply"] != selftestSkip {
+		t.Errorf("expected reply steps to be skipped, got %v", statuses)
+	}
+	if _, ok := statuses["delete reply"]; ok {
+		t.Error("expected no delete reply step for ghost posts")
+	}
+	if len(client.deleted) != 1 || client.deleted[0] != "100" {
+		t.Errorf("expected post 100 to be deleted, got %v", client.deleted)
+	}
+}
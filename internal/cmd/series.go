@@ -0,0 +1,466 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/series"
+)
+
+// seriesPath is the series state location. It is replaced in tests.
+var seriesPath = func() string {
+	return filepath.Join(config.DataDir(), series.FileName)
+}
+
+// seriesView is the output model of a series
+type seriesView struct {
+	Series *series.Series
+}
+
+func (v seriesView) viewFields() []viewField {
+	s := v.Series
+	last := "-"
+	var lastAt any
+	if p := s.Last(); p != nil {
+		last = fmt.Sprintf("#%d %s", p.N, p.At.Local().Format("2006-01-02"))
+		lastAt = p.At
+	}
+	return []viewField{
+		{Key: "name", Value: s.Name},
+		{Key: "next", Value: s.Next},
+		{Key: "posts", Value: len(s.Posts)},
+		{Key: "last", Value: lastAt, Text: last},
+		{Key: "template", Value: s.Template, Text: truncateText(s.Template, 50)},
+	}
+}
+
+// NewSeriesCmd builds the series command group.
+func NewSeriesCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "series",
+		Short: "Post numbered recurring content",
+		Long: `Keep numbered series of posts, such as "TIL #42", whose counter goes up by
+one with every post.
+
+Counters are kept in the data directory. To post a series from several
+machines, export the state on one and import it on the other: counters keep
+the higher value, so numbers are not reused, and the newer template wins.`,
+	}
+
+	cmd.AddCommand(newSeriesCreateCmd(f))
+	cmd.AddCommand(newSeriesPostCmd(f))
+	cmd.AddCommand(newSeriesListCmd(f))
+	cmd.AddCommand(newSeriesDeleteCmd(f))
+	cmd.AddCommand(newSeriesExportCmd(f))
+	cmd.AddCommand(newSeriesImportCmd(f))
+
+	return cmd
+}
+
+type seriesCreateOptions struct {
+	Template string
+	Start    int
+}
+
+func newSeriesCreateCmd(f *Factory) *cobra.Command {
+	opts := &seriesCreateOptions{Start: 1}
+
+	cmd := &cobra.Command{
+		Use:   "create [name]",
+		Short: "Start a numbered series",
+		Long: `Start a series. --template is a Go template of the post text, with {{.n}}
+the number of the post and {{.text}} the text given to 'series post'.
+
+Examples:
+  threads series create TIL --template 'TIL #{{.n}}: {{.text}}'
+  threads series create "Weekly digest" --template 'Digest {{.n}}' --start 12`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			name := strings.TrimSpace(args[0])
+			if name == "" {
+				return &UserFriendlyError{Message: "The series needs a name", Suggestion: "Give a name such as TIL"}
+			}
+			if _, err := series.Render(opts.Template, opts.Start, "text"); err != nil {
+				return &UserFriendlyError{
+					Message:    fmt.Sprintf("Invalid --template: %v", err),
+					Suggestion: "Use {{.n}} for the number and {{.text}} for the text, e.g. 'TIL #{{.n}}: {{.text}}'",
+					Cause:      err,
+				}
+			}
+
+			now := time.Now().UTC()
+			created := &series.Series{Name: name, Template: opts.Template, Next: opts.Start, CreatedAt: now, UpdatedAt: now}
+			err := series.Update(seriesPath(), func(state *series.State) error {
+				if existing := state.Get(name); existing != nil {
+					return &UserFriendlyError{
+						Message:    fmt.Sprintf("Series %s already exists, at #%d", existing.Name, existing.Next),
+						Suggestion: "Pick another name, or remove it with 'threads series delete'",
+					}
+				}
+				state.Series = append(state.Series, created)
+				return nil
+			})
+			if err != nil {
+				return FormatError(err)
+			}
+
+			if outfmt.IsJSON(ctx) {
+				return outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, created)
+			}
+			f.UI(ctx).Success("Created series %s, starting at #%d", name, opts.Start)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Template, "template", "", "Template of the post text, with {{.n}} and {{.text}}")
+	cmd.Flags().IntVar(&opts.Start, "start", opts.Start, "Number of the first post")
+	_ = cmd.MarkFlagRequired("template")
+	return cmd
+}
+
+type seriesPostOptions struct {
+	Text     string
+	Stdin    bool
+	Schedule string
+	UTC      bool
+	signatureOptions
+}
+
+func newSeriesPostCmd(f *Factory) *cobra.Command {
+	opts := &seriesPostOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "post [name]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Post the next number of a series",
+		Long: `Publish the next post of a series, its text made by the series template.
+
+The number is taken before publishing, so posts made at the same time get
+different numbers. If publishing fails the number is given back, unless a
+later post already took the next one.
+
+Examples:
+  threads series post TIL --text "go vet catches printf mistakes"
+  echo "context.AfterFunc exists" | threads series post TIL --stdin
+  threads series post TIL --text "..." --schedule "tomorrow 9am"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSeriesPost(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Text, "text", "t", "", "Text for {{.text}} in the template")
+	cmd.Flags().BoolVar(&opts.Stdin, "stdin", false, "Read the text from standard input")
+	cmd.Flags().StringVar(&opts.Schedule, "schedule", "", "Queue the post to be published at a time (\"2025-07-01 09:00\", \"tomorrow 9am\", 2h)")
+	addUTCFlag(cmd, &opts.UTC)
+	cmd.MarkFlagsMutuallyExclusive("text", "stdin")
+	addSignatureFlags(cmd, &opts.signatureOptions)
+	return cmd
+}
+
+func runSeriesPost(cmd *cobra.Command, f *Factory, name string, opts *seriesPostOptions) error {
+	ctx := cmd.Context()
+
+	var scheduleAt time.Time
+	var scheduleLoc *time.Location
+	if opts.Schedule != "" {
+		var err error
+		if scheduleAt, scheduleLoc, err = parseScheduleFlag(f, opts.UTC, "schedule", opts.Schedule); err != nil {
+			return err
+		}
+	}
+	if opts.Stdin {
+		text, err := readStdinText(ctx)
+		if err != nil {
+			return err
+		}
+		opts.Text = text
+	}
+
+	// The number is taken and the text made under the lock; nothing is kept
+	// if the text cannot be made
+	path := seriesPath()
+	var n int
+	var text string
+	err := series.Update(path, func(state *series.State) error {
+		s := state.Get(name)
+		if s == nil {
+			return &UserFriendlyError{
+				Message:    fmt.Sprintf("No series called %s", name),
+				Suggestion: "Run 'threads series list' to see series, or create one with 'threads series create'",
+			}
+		}
+		name = s.Name
+		n = s.Take(time.Now())
+		rendered, err := series.Render(s.Template, n, opts.Text)
+		if err != nil {
+			return WrapError(fmt.Sprintf("failed to make the text of %s #%d", s.Name, n), err)
+		}
+		if text, err = applySignature(cmd, f, &opts.signatureOptions, rendered); err != nil {
+			return err
+		}
+		if len(text) > api.MaxTextLength {
+			return &UserFriendlyError{
+				Message:    fmt.Sprintf("%s #%d is %d characters, over the %d character limit", s.Name, n, len(text), api.MaxTextLength),
+				Suggestion: "Shorten the text or the series template",
+			}
+		}
+		return checkAccountRules(f, text)
+	})
+	if err != nil {
+		return FormatError(err)
+	}
+
+	// release gives the number back after a failure, and record keeps the
+	// post that took it
+	release := func(cause error) error {
+		if err := series.Update(path, func(state *series.State) error {
+			if s := state.Get(name); s != nil {
+				s.Release(n)
+			}
+			return nil
+		}); err != nil {
+			warn(ctx, &UserFriendlyError{Message: fmt.Sprintf("%s #%d could not be given back: %v", name, n, err)}) //nolint:errcheck // Best-effort output to stderr
+		}
+		return cause
+	}
+	record := func(postID, queueID string) error {
+		err := series.Update(path, func(state *series.State) error {
+			if s := state.Get(name); s != nil {
+				s.Record(n, postID, queueID)
+			}
+			return nil
+		})
+		if err != nil {
+			return WrapError("failed to save the series", err)
+		}
+		return nil
+	}
+
+	content := &api.TextPostContent{Text: text}
+	if opts.Schedule != "" {
+		item, err := scheduleContent(f, content, scheduleAt, scheduleLoc)
+		if err != nil {
+			return release(err)
+		}
+		if err := record("", item.ID); err != nil {
+			return err
+		}
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, map[string]any{"series": name, "n": n, "queued": item})
+		}
+		f.UI(ctx).Success("Scheduled %s #%d for %s as %s", name, n, queueDue(*item), item.ID)
+		return nil
+	}
+
+	client, err := f.Client(ctx)
+	if err != nil {
+		return release(err)
+	}
+	post, err := publishContent(ctx, client, content, defaultContainerTimeoutSecs)
+	if err != nil {
+		return release(WrapError(fmt.Sprintf("failed to post %s #%d", name, n), err))
+	}
+	if err := record(post.ID, ""); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, map[string]any{"series": name, "n": n, "post": post}); err != nil {
+			return err
+		}
+		return announcePublished(ctx, f, post)
+	}
+	f.UI(ctx).Success("Posted %s #%d: %s", name, n, post.Permalink)
+	return announcePublished(ctx, f, post)
+}
+
+func newSeriesListCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List series and their counters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			state, err := series.Load(seriesPath())
+			if err != nil {
+				return WrapError("failed to read the series", err)
+			}
+			views := make([]seriesView, len(state.Series))
+			for i, s := range state.Series {
+				views[i] = seriesView{Series: s}
+			}
+			return writeViewList(cmd.Context(), views, nil, "No series")
+		},
+	}
+}
+
+func newSeriesDeleteCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete [name]",
+		Short: "Forget a series and its counter",
+		Long: `Forget a series and its counter. Its posts stay on Threads.
+
+Requires confirmation unless --yes flag is provided.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			confirmed, err := f.Confirm(ctx, fmt.Sprintf("Forget series %s and its counter?", args[0]))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Fprintln(iocontext.GetIO(ctx).Out, "Cancelled.") //nolint:errcheck // Best-effort output
+				return nil
+			}
+			err = series.Update(seriesPath(), func(state *series.State) error {
+				if !state.Remove(args[0]) {
+					return &UserFriendlyError{
+						Message:    fmt.Sprintf("No series called %s", args[0]),
+						Suggestion: "Run 'threads series list' to see series",
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return FormatError(err)
+			}
+			f.UI(ctx).Success("Forgot series %s", args[0])
+			return nil
+		},
+	}
+}
+
+func newSeriesExportCmd(f *Factory) *cobra.Command {
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "export [name]...",
+		Short: "Write the state of series to share with another machine",
+		Long: `Write the counters, templates and posts of the named series, or of all of
+them, as JSON for 'threads series import' on another machine.`,
+		Example: `  threads series export > series.json
+  threads series export TIL --out til.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			state, err := series.Load(seriesPath())
+			if err != nil {
+				return WrapError("failed to read the series", err)
+			}
+			if len(args) > 0 {
+				selected := &series.State{}
+				for _, name := range args {
+					s := state.Get(name)
+					if s == nil {
+						return &UserFriendlyError{
+							Message:    fmt.Sprintf("No series called %s", name),
+							Suggestion: "Run 'threads series list' to see series",
+						}
+					}
+					selected.Series = append(selected.Series, s)
+				}
+				state = selected
+			}
+
+			data, err := json.MarshalIndent(state, "", "  ")
+			if err != nil {
+				return err
+			}
+			data = append(data, '\n')
+			if out == "" {
+				_, err := iocontext.GetIO(ctx).Out.Write(data)
+				return err
+			}
+			if err := os.WriteFile(out, data, 0o600); err != nil {
+				return WrapError("failed to write "+out, err)
+			}
+			f.UI(ctx).Success("Exported %s to %s", pluralize(len(state.Series), "series", "series"), out)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&out, "out", "", "Write to a file instead of standard output")
+	return cmd
+}
+
+func newSeriesImportCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "import [file]",
+		Short: "Merge the state of series from another machine",
+		Long: `Merge a file written by 'threads series export' into the series of this
+machine; - reads standard input. Counters keep the higher value, posts are
+combined by number, and the newer template wins. A number taken by
+different posts on each machine is reported as a conflict.`,
+		Example: `  threads series import series.json
+  ssh laptop threads series export | threads series import -`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			var r io.Reader = iocontext.GetIO(ctx).In
+			if args[0] != "-" {
+				file, err := os.Open(args[0]) //nolint:gosec // Path is chosen by the user
+				if err != nil {
+					return WrapError("failed to open "+args[0], err)
+				}
+				defer file.Close() //nolint:errcheck // Read-only
+				r = file
+			}
+			var imported series.State
+			if err := json.NewDecoder(r).Decode(&imported); err != nil {
+				return &UserFriendlyError{
+					Message:    fmt.Sprintf("%s is not a series export: %v", args[0], err),
+					Suggestion: "Create one with 'threads series export'",
+					Cause:      err,
+				}
+			}
+
+			var result series.MergeResult
+			err := series.Update(seriesPath(), func(state *series.State) error {
+				result = state.Merge(&imported)
+				return nil
+			})
+			if err != nil {
+				return WrapError("failed to save the series", err)
+			}
+
+			if outfmt.IsJSON(ctx) {
+				return outfmt.WriteJSONContext(ctx, iocontext.GetIO(ctx).Out, map[string]any{
+					"added": nonNil(result.Added), "updated": nonNil(result.Updated), "conflicts": nonNil(result.Conflicts),
+				})
+			}
+			p := f.UI(ctx)
+			for _, name := range result.Added {
+				p.Success("Added series %s", name)
+			}
+			for _, name := range result.Updated {
+				p.Success("Updated series %s", name)
+			}
+			if len(result.Added)+len(result.Updated) == 0 {
+				p.Info("The series are up to date")
+			}
+			for _, conflict := range result.Conflicts {
+				p.Warning("%s", conflict)
+			}
+			return nil
+		},
+	}
+}
+
+// nonNil returns s, or an empty slice for JSON output
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/queue"
+	"github.com/salmonumbrella/threads-cli/internal/series"
+)
+
+// useTempSeries points the series state at a temporary file and returns
+// its path
+func useTempSeries(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), series.FileName)
+	orig := seriesPath
+	seriesPath = func() string { return path }
+	t.Cleanup(func() { seriesPath = orig })
+	return path
+}
+
+func runSeriesCmd(t *testing.T, f *Factory, io *iocontext.IO, args ...string) error {
+	t.Helper()
+	cmd := NewSeriesCmd(f)
+	cmd.SetArgs(args)
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	return cmd.Execute()
+}
+
+func TestSeriesPost_NumbersPosts(t *testing.T) {
+	path := useTempSeries(t)
+	var published atomic.Int32
+	f, io := newIntegrationTestFactory(t, publishServer(t, &published).URL)
+
+	if err := runSeriesCmd(t, f, io, "create", "TIL", "--template", "til{{.n}}-{{.text}}", "--start", "41"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSeriesCmd(t, f, io, "create", "til", "--template", "x"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected a duplicate series to fail, got %v", err)
+	}
+	for _, text := range []string{"vet", "fmt"} {
+		if err := runSeriesCmd(t, f, io, "post", "til", "--text", text); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !strings.Contains(io.Out.(*bytes.Buffer).String(), "Posted TIL #42") {
+		t.Errorf("expected the post reported, got %q", io.Out.(*bytes.Buffer).String())
+	}
+
+	state, err := series.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := state.Get("TIL")
+	if s == nil || s.Next != 43 || len(s.Posts) != 2 || s.Posts[0].PostID != "p-til41-vet" || s.Posts[1].PostID != "p-til42-fmt" {
+		t.Fatalf("unexpected series: %+v", s)
+	}
+	if published.Load() != 2 {
+		t.Errorf("expected two posts published, got %d", published.Load())
+	}
+}
+
+func TestSeriesPost_ReleasesNumberOnFailure(t *testing.T) {
+	path := useTempSeries(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Invalid parameter","type":"OAuthException","code":100}}`))
+	}))
+	t.Cleanup(server.Close)
+	f, io := newIntegrationTestFactory(t, server.URL)
+
+	if err := runSeriesCmd(t, f, io, "create", "TIL", "--template", "TIL #{{.n}}: {{.text}}"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSeriesCmd(t, f, io, "post", "TIL", "--text", "lost"); err == nil {
+		t.Fatal("expected the post to fail")
+	}
+	state, err := series.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := state.Get("TIL"); s.Next != 1 || len(s.Posts) != 0 {
+		t.Errorf("expected #1 given back, got %+v", s)
+	}
+}
+
+func TestSeriesPost_Schedule(t *testing.T) {
+	path := useTempSeries(t)
+	queued := useTempQueue(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	if err := runSeriesCmd(t, f, io, "create", "TIL", "--template", "TIL #{{.n}}: {{.text}}"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSeriesCmd(t, f, io, "post", "TIL", "--text", "later", "--schedule", "2h"); err != nil {
+		t.Fatal(err)
+	}
+	items, err := queue.Load(queued)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Text() != "TIL #1: later" {
+		t.Fatalf("unexpected queue: %+v", items)
+	}
+	state, err := series.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := state.Get("TIL"); s.Next != 2 || s.Posts[0].QueueID != items[0].ID {
+		t.Errorf("expected #1 recorded as queued, got %+v", s)
+	}
+}
+
+func TestSeriesExportImport(t *testing.T) {
+	path := useTempSeries(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+
+	// The other machine is further along
+	now := time.Now().UTC()
+	remote := &series.State{Series: []*series.Series{
+		{Name: "TIL", Template: "TIL {{.n}}", Next: 8, Posts: []series.Post{{N: 7, PostID: "p7", At: now}}, UpdatedAt: now},
+		{Name: "Digest", Template: "Digest {{.n}}", Next: 3, UpdatedAt: now},
+	}}
+	exported := filepath.Join(t.TempDir(), "remote.json")
+	if err := series.Save(path, remote); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSeriesCmd(t, f, io, "export", "--out", exported); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runSeriesCmd(t, f, io, "create", "TIL", "--template", "TIL #{{.n}}"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSeriesCmd(t, f, io, "import", exported); err != nil {
+		t.Fatal(err)
+	}
+	state, err := series.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := state.Get("TIL"); s.Next != 8 || len(s.Posts) != 1 || s.Template != "TIL #{{.n}}" {
+		t.Errorf("expected the higher counter and the newer template, got %+v", s)
+	}
+	if state.Get("Digest") == nil {
+		t.Error("expected Digest added")
+	}
+	out := io.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, "Added series Digest") || !strings.Contains(out, "Updated series TIL") {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
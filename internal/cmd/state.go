@@ -0,0 +1,580 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/salmonumbrella/threads-cli/internal/audit"
+	"github.com/salmonumbrella/threads-cli/internal/backup"
+	"github.com/salmonumbrella/threads-cli/internal/bookmarks"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/filelock"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/mute"
+	"github.com/salmonumbrella/threads-cli/internal/notes"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/queue"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
+	"github.com/salmonumbrella/threads-cli/internal/series"
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// backupPassphraseEnv holds the passphrase of encrypted backups where no
+// terminal can prompt for it
+const backupPassphraseEnv = "THREADS_BACKUP_PASSPHRASE"
+
+// stateCredentialsName names the credentials in an encrypted backup
+const stateCredentialsName = "credentials.json"
+
+// stateCategory is one kind of local state a backup holds
+type stateCategory struct {
+	// Name is the file, or directory, in the archive, the same on every
+	// platform
+	Name string
+	// Path returns its location on this machine
+	Path func() string
+	// Contents describes what the category holds
+	Contents string
+}
+
+// stateCategories lists everything 'state backup' and 'state restore'
+// cover. The cache is rebuilt as needed and left out.
+var stateCategories = []stateCategory{
+	{Name: "config.json", Path: config.ConfigPath, Contents: "Settings and account profiles"},
+	{Name: mute.FileName, Path: func() string { return mutesPath() }, Contents: "Muted users and keywords"},
+	{Name: queue.FileName, Path: func() string { return queuePath() }, Contents: "Scheduled posts and drafts"},
+	{Name: series.FileName, Path: func() string { return seriesPath() }, Contents: "Series templates and counters"},
+	{Name: bookmarks.FileName, Path: func() string { return bookmarksPath() }, Contents: "Bookmarked posts"},
+	{Name: notes.FileName, Path: func() string { return notesPath() }, Contents: "Notes on users and posts"},
+	{Name: audit.FileName, Path: func() string { return auditPath() }, Contents: "Audit log"},
+	{Name: "carousels", Path: func() string { return carouselStateDir() }, Contents: "Progress of interrupted carousel posts"},
+}
+
+// stateCredentials are the credentials in an encrypted backup
+type stateCredentials struct {
+	Accounts []stateAccount    `json:"accounts"`
+	Secrets  map[string]string `json:"secrets,omitempty"`
+}
+
+// stateAccount is secrets.Credentials with the token and client secret
+type stateAccount struct {
+	Name         string    `json:"name"`
+	AccessToken  string    `json:"access_token"`
+	UserID       string    `json:"user_id,omitempty"`
+	Username     string    `json:"username,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	ClientID     string    `json:"client_id,omitempty"`
+	ClientSecret string    `json:"client_secret,omitempty"`
+	RedirectURI  string    `json:"redirect_uri,omitempty"`
+	Scopes       []string  `json:"scopes,omitempty"`
+}
+
+// NewStateCmd builds the state command group
+func NewStateCmd(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Back up and restore local state",
+		Long: `Back up the local state of the CLI to a single archive, and restore it, to
+move an automation setup to another machine or recover it:
+
+` + stateCategoryList() + `
+
+Credentials are left out unless backed up with --with-credentials, which
+needs --encrypt. The cache is rebuilt as needed and is left out too.`,
+	}
+
+	cmd.AddCommand(newStateBackupCmd(f))
+	cmd.AddCommand(newStateRestoreCmd(f))
+	return cmd
+}
+
+// stateCategoryList describes stateCategories for help texts
+func stateCategoryList() string {
+	lines := make([]string, len(stateCategories))
+	for i, c := range stateCategories {
+		lines[i] = fmt.Sprintf("  %-15s %s", c.Name, c.Contents)
+	}
+	return strings.Join(lines, "\n")
+}
+
+type stateBackupOptions struct {
+	Out             string
+	Encrypt         bool
+	WithCredentials bool
+}
+
+func newStateBackupCmd(f *Factory) *cobra.Command {
+	opts := &stateBackupOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Write the local state to an archive",
+		Long: `Write the config, queue, drafts, series, mutes, bookmarks, notes, audit
+log and carousel progress to a tar archive for 'threads state restore';
+- writes standard output. The archive is zstd-compressed when its name
+ends in .zst or .tzst, and gzip-compressed otherwise.
+
+With --encrypt the archive is encrypted with a passphrase, asked for on the
+terminal or read from ` + backupPassphraseEnv + `. Only an encrypted
+archive can hold credentials: --with-credentials adds the tokens of every
+account and the secrets of the credential store, so the restored machine
+needs no new login.`,
+		Example: `  threads state backup --out backup.tar.gz
+  threads state backup --out backup.tar.zst --with-credentials --encrypt
+  threads state backup --out - | ssh server 'threads state restore -'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStateBackup(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Out, "out", "", "Archive to write (default threads-state-DATE.tar.gz)")
+	cmd.Flags().BoolVar(&opts.Encrypt, "encrypt", false, "Encrypt the archive with a passphrase")
+	cmd.Flags().BoolVar(&opts.WithCredentials, "with-credentials", false, "Include account tokens and stored secrets (needs --encrypt)")
+	return cmd
+}
+
+func runStateBackup(cmd *cobra.Command, f *Factory, opts *stateBackupOptions) error {
+	ctx := cmd.Context()
+
+	if opts.WithCredentials && !opts.Encrypt {
+		return &UserFriendlyError{
+			Message:    "--with-credentials needs --encrypt",
+			Suggestion: "Add --encrypt; credentials are never written to a backup in the clear",
+		}
+	}
+	out := opts.Out
+	if out == "" {
+		out = "threads-state-" + time.Now().Format("2006-01-02") + ".tar.gz"
+	}
+
+	files, err := collectStateFiles()
+	if err != nil {
+		return WrapError("failed to read the local state", err)
+	}
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.Name
+	}
+	var accounts []string
+	if opts.WithCredentials {
+		file, accountNames, credsErr := backupCredentials(f)
+		if credsErr != nil {
+			return credsErr
+		}
+		files, accounts = append(files, file), accountNames
+	}
+
+	var buf bytes.Buffer
+	if err = backup.Write(&buf, files, backup.CompressionFor(out)); err != nil {
+		return WrapError("failed to write the backup", err)
+	}
+	data := buf.Bytes()
+	if opts.Encrypt {
+		var passphrase string
+		if passphrase, err = backupPassphrase(ctx, true); err != nil {
+			return err
+		}
+		if data, err = backup.Encrypt(data, passphrase); err != nil {
+			return WrapError("failed to encrypt the backup", err)
+		}
+	}
+
+	io := iocontext.GetIO(ctx)
+	if out == "-" {
+		_, err = io.Out.Write(data)
+		return err
+	}
+	if err = os.WriteFile(out, data, 0o600); err != nil {
+		return WrapError("failed to write "+out, err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
+			"path":      out,
+			"files":     names,
+			"accounts":  nonNil(accounts),
+			"encrypted": opts.Encrypt,
+		})
+	}
+	p := f.UI(ctx)
+	p.Success("Backed up %s to %s", pluralize(len(names), "file", "files"), out)
+	if opts.WithCredentials {
+		p.Info("Included the credentials of %s", pluralize(len(accounts), "account", "accounts"))
+	} else {
+		p.Info("Credentials are not included; log in again after restoring, or back up with --with-credentials --encrypt")
+	}
+	return nil
+}
+
+// collectStateFiles reads the files of stateCategories. Temporary files,
+// locks and the backups kept by statefile are left out.
+func collectStateFiles() ([]backup.File, error) {
+	var files []backup.File
+	for _, c := range stateCategories {
+		root := c.Path()
+		err := filepath.WalkDir(root, func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			base := d.Name()
+			if d.IsDir() || !d.Type().IsRegular() || strings.HasPrefix(base, ".") ||
+				strings.HasSuffix(base, ".lock") || strings.HasSuffix(base, statefile.BackupSuffix) {
+				return nil
+			}
+			rel, err := filepath.Rel(root, name)
+			if err != nil {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			data, err := os.ReadFile(name) //nolint:gosec // Path is one of stateCategories
+			if err != nil {
+				return err
+			}
+			files = append(files, backup.File{
+				Name:    path.Join(c.Name, filepath.ToSlash(rel)),
+				Mode:    info.Mode().Perm(),
+				ModTime: info.ModTime(),
+				Data:    data,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// backupCredentials returns the credentials of every account, and the
+// secrets of the credential store, as a file for the archive
+func backupCredentials(f *Factory) (backup.File, []string, error) {
+	store, err := f.Store()
+	if err != nil {
+		return backup.File{}, nil, WrapError("failed to open the credential store", err)
+	}
+	names, err := store.List()
+	if err != nil {
+		return backup.File{}, nil, WrapError("failed to list accounts", err)
+	}
+	slices.Sort(names)
+
+	state := stateCredentials{Accounts: []stateAccount{}}
+	for _, name := range names {
+		creds, getErr := store.Get(name)
+		if getErr != nil {
+			return backup.File{}, nil, WrapError("failed to read the credentials of "+name, getErr)
+		}
+		state.Accounts = append(state.Accounts, stateAccount{
+			Name:         name,
+			AccessToken:  creds.AccessToken,
+			UserID:       creds.UserID,
+			Username:     creds.Username,
+			ExpiresAt:    creds.ExpiresAt,
+			CreatedAt:    creds.CreatedAt,
+			ClientID:     creds.ClientID,
+			ClientSecret: creds.ClientSecret,
+			RedirectURI:  creds.RedirectURI,
+			Scopes:       creds.Scopes,
+		})
+	}
+	if password, secretErr := store.GetSecret(smtpPasswordSecret); secretErr == nil {
+		state.Secrets = map[string]string{smtpPasswordSecret: password}
+	} else if !errors.Is(secretErr, secrets.ErrSecretNotFound) {
+		return backup.File{}, nil, WrapError("failed to read the SMTP password", secretErr)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return backup.File{}, nil, err
+	}
+	return backup.File{Name: stateCredentialsName, Mode: 0o600, ModTime: time.Now(), Data: data}, names, nil
+}
+
+func newStateRestoreCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:         "restore [file]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Restore the local state from an archive",
+		Long: `Restore the files of an archive written by 'threads state backup' to where
+this machine keeps them; - reads standard input. Files of this machine the
+archive does not hold are kept.
+
+An encrypted archive needs its passphrase, asked for on the terminal or
+read from ` + backupPassphraseEnv + `. Credentials in it are added to the
+credential store, replacing accounts of the same name.
+
+Stop daemons such as 'threads queue daemon' first. Requires confirmation
+to replace files or accounts unless --yes flag is provided.`,
+		Example: `  threads state restore backup.tar.gz
+  ` + backupPassphraseEnv + `=... threads state restore backup.tar.gz --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStateRestore(cmd, f, args[0])
+		},
+	}
+}
+
+func runStateRestore(cmd *cobra.Command, f *Factory, name string) error {
+	ctx := cmd.Context()
+	io := iocontext.GetIO(ctx)
+
+	var data []byte
+	var err error
+	if name == "-" {
+		data, err = readAllLimited(io.In)
+	} else {
+		data, err = os.ReadFile(name) //nolint:gosec // Path is chosen by the user
+	}
+	if err != nil {
+		return WrapError("failed to read "+name, err)
+	}
+	if backup.IsEncrypted(data) {
+		var passphrase string
+		if passphrase, err = backupPassphrase(ctx, false); err != nil {
+			return err
+		}
+		if data, err = backup.Decrypt(data, passphrase); err != nil {
+			return &UserFriendlyError{
+				Message:    fmt.Sprintf("Cannot decrypt %s: %v", name, err),
+				Suggestion: "Check the passphrase given to 'threads state backup --encrypt'",
+				Cause:      err,
+			}
+		}
+	}
+	files, err := backup.Read(bytes.NewReader(data))
+	if err != nil {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("%s is not a state backup: %v", name, err),
+			Suggestion: "Create one with 'threads state backup'",
+			Cause:      err,
+		}
+	}
+
+	// changed are the files to write, replaced those of them this machine
+	// has, unchanged and unknown the files left alone
+	type restoredFile struct {
+		name, path string
+		data       []byte
+	}
+	var changed []restoredFile
+	var replaced, unchanged, unknown []string
+	var creds *stateCredentials
+	for _, file := range files {
+		if file.Name == stateCredentialsName {
+			creds = &stateCredentials{}
+			if err = json.Unmarshal(file.Data, creds); err != nil {
+				return WrapError("failed to read the credentials in "+name, err)
+			}
+			continue
+		}
+		local, ok := stateFilePath(file.Name)
+		if !ok {
+			unknown = append(unknown, file.Name)
+			continue
+		}
+		current, readErr := os.ReadFile(local) //nolint:gosec // Path is one of stateCategories
+		switch {
+		case readErr == nil && bytes.Equal(current, file.Data):
+			unchanged = append(unchanged, file.Name)
+			continue
+		case readErr == nil:
+			replaced = append(replaced, file.Name)
+		}
+		changed = append(changed, restoredFile{name: file.Name, path: local, data: file.Data})
+	}
+
+	var store secrets.Store
+	var accounts, replacedAccounts []string
+	if creds != nil {
+		if store, err = f.Store(); err != nil {
+			return WrapError("failed to open the credential store", err)
+		}
+		existing, listErr := store.List()
+		if listErr != nil {
+			return WrapError("failed to list accounts", listErr)
+		}
+		for _, account := range creds.Accounts {
+			accounts = append(accounts, account.Name)
+			if slices.Contains(existing, account.Name) {
+				replacedAccounts = append(replacedAccounts, account.Name)
+			}
+		}
+	}
+
+	if len(replaced) > 0 || len(replacedAccounts) > 0 {
+		var parts []string
+		if len(replaced) > 0 {
+			parts = append(parts, strings.Join(replaced, ", "))
+		}
+		if len(replacedAccounts) > 0 {
+			parts = append(parts, "the credentials of "+strings.Join(replacedAccounts, ", "))
+		}
+		confirmed, confirmErr := f.Confirm(ctx, fmt.Sprintf("Replace %s with the backup?", strings.Join(parts, " and ")))
+		if confirmErr != nil {
+			return confirmErr
+		}
+		if !confirmed {
+			fmt.Fprintln(io.Out, "Cancelled.") //nolint:errcheck // Best-effort output
+			return nil
+		}
+	}
+
+	restored := []string{}
+	for _, file := range changed {
+		if err = restoreStateFile(file.path, file.data); err != nil {
+			return WrapError("failed to restore "+file.name, err)
+		}
+		restored = append(restored, file.name)
+	}
+	if creds != nil {
+		if err = restoreCredentials(store, creds); err != nil {
+			return err
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
+			"restored":  restored,
+			"unchanged": nonNil(unchanged),
+			"unknown":   nonNil(unknown),
+			"accounts":  nonNil(accounts),
+		})
+	}
+	p := f.UI(ctx)
+	p.Success("Restored %s from %s", pluralize(len(restored), "file", "files"), name)
+	if len(unchanged) > 0 {
+		p.Info("%s already up to date", pluralize(len(unchanged), "file was", "files were"))
+	}
+	if creds != nil {
+		p.Success("Restored the credentials of %s", pluralize(len(accounts), "account", "accounts"))
+	}
+	if len(unknown) > 0 {
+		p.Warning("Skipped %s unknown to this version: %s", pluralize(len(unknown), "file", "files"), strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// stateFilePath returns where this machine keeps the file name of an
+// archive, or false for a file of no category
+func stateFilePath(name string) (string, bool) {
+	for _, c := range stateCategories {
+		if name == c.Name {
+			return c.Path(), true
+		}
+		if rest, ok := strings.CutPrefix(name, c.Name+"/"); ok {
+			return filepath.Join(c.Path(), filepath.FromSlash(rest)), true
+		}
+	}
+	return "", false
+}
+
+// restoreStateFile replaces the file at path with data, under the lock the
+// queue and series take, readable by the user alone
+func restoreStateFile(path string, data []byte) error {
+	unlock, err := filelock.Lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return statefile.Write(path, data, 0o600)
+}
+
+// restoreCredentials adds the accounts and secrets of creds to store
+func restoreCredentials(store secrets.Store, creds *stateCredentials) error {
+	for _, account := range creds.Accounts {
+		err := store.Set(account.Name, secrets.Credentials{
+			Name:         account.Name,
+			AccessToken:  account.AccessToken,
+			UserID:       account.UserID,
+			Username:     account.Username,
+			ExpiresAt:    account.ExpiresAt,
+			CreatedAt:    account.CreatedAt,
+			ClientID:     account.ClientID,
+			ClientSecret: account.ClientSecret,
+			RedirectURI:  account.RedirectURI,
+			Scopes:       account.Scopes,
+		})
+		if err != nil {
+			return WrapError("failed to store the credentials of "+account.Name, err)
+		}
+	}
+	for name, value := range creds.Secrets {
+		if err := store.SetSecret(name, value); err != nil {
+			return WrapError("failed to store secret "+name, err)
+		}
+	}
+	return nil
+}
+
+// readAllLimited reads a backup from r, refusing one too large to be
+// local state
+func readAllLimited(r io.Reader) ([]byte, error) {
+	const limit = 1 << 30
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err == nil && len(data) > limit {
+		err = errors.New("larger than 1 GB")
+	}
+	return data, err
+}
+
+// backupPassphrase returns the passphrase of an encrypted backup from
+// backupPassphraseEnv, or asks for it on the terminal, twice when confirm
+// is set so a typo cannot lock the backup away
+func backupPassphrase(ctx context.Context, confirm bool) (string, error) {
+	if passphrase := os.Getenv(backupPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	io := iocontext.GetIO(ctx)
+	in, ok := io.In.(*os.File)
+	if !ok || !isTerminalReader(in) || outfmt.GetStrict(ctx) {
+		return "", &UserFriendlyError{
+			Message:    "An encrypted backup needs a passphrase",
+			Suggestion: "Run in a terminal, or set " + backupPassphraseEnv,
+		}
+	}
+
+	read := func(prompt string) (string, error) {
+		fmt.Fprint(io.ErrOut, prompt) //nolint:errcheck // Best-effort output
+		passphrase, err := term.ReadPassword(int(in.Fd()))
+		fmt.Fprintln(io.ErrOut) //nolint:errcheck // Best-effort output
+		return string(passphrase), err
+	}
+	passphrase, err := read("Backup passphrase: ")
+	if err != nil {
+		return "", WrapError("failed to read the passphrase", err)
+	}
+	if passphrase == "" {
+		return "", &UserFriendlyError{Message: "The passphrase cannot be empty"}
+	}
+	if confirm {
+		again, err := read("Repeat the passphrase: ")
+		if err != nil {
+			return "", WrapError("failed to read the passphrase", err)
+		}
+		if again != passphrase {
+			return "", &UserFriendlyError{Message: "The passphrases do not match"}
+		}
+	}
+	return passphrase, nil
+}
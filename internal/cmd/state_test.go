@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/secrets"
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// useTempState points every state category at a temporary location
+func useTempState(t *testing.T) {
+	t.Helper()
+	useTempConfig(t)
+	useTempMutes(t)
+	useTempQueue(t)
+	useTempSeries(t)
+	useTempBookmarks(t)
+	useTempNotes(t)
+	useTempAuditLog(t)
+	dir := filepath.Join(t.TempDir(), "carousels")
+	orig := carouselStateDir
+	carouselStateDir = func() string { return dir }
+	t.Cleanup(func() { carouselStateDir = orig })
+}
+
+func runStateCmd(ctx context.Context, f *Factory, args ...string) error {
+	cmd := NewStateCmd(f)
+	cmd.SetArgs(args)
+	cmd.SetContext(ctx)
+	return cmd.Execute()
+}
+
+func writeTestFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStateBackupRestore(t *testing.T) {
+	useTempState(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	ctx := iocontext.WithIO(context.Background(), io)
+
+	writeTestFile(t, config.ConfigPath(), `{"output":"json"}`)
+	writeTestFile(t, queuePath(), `[{"id":"a"}]`)
+	writeTestFile(t, filepath.Join(carouselStateDir(), "abc.json"), `{"children":[]}`)
+	// Left out: the backup kept by statefile, a lock and a temporary file
+	writeTestFile(t, queuePath()+statefile.BackupSuffix, `[]`)
+	writeTestFile(t, queuePath()+".lock", ``)
+	writeTestFile(t, filepath.Join(carouselStateDir(), ".abc.json-123"), `{`)
+
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	if err := runStateCmd(ctx, f, "backup", "--out", archive); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(io.ErrOut.(*bytes.Buffer).String()+io.Out.(*bytes.Buffer).String(), "Credentials are not included") {
+		t.Error("expected a note that credentials are left out")
+	}
+
+	// Another machine
+	useTempState(t)
+	writeTestFile(t, queuePath(), `[]`)
+	if err := runStateCmd(ctx, f, "restore", archive); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(queuePath()); err != nil || string(data) != `[]` {
+		t.Errorf("expected the queue kept without confirmation, got %q, %v", data, err)
+	}
+	if !strings.Contains(io.Out.(*bytes.Buffer).String(), "Cancelled.") {
+		t.Error("expected the restore cancelled")
+	}
+
+	if err := runStateCmd(outfmt.WithYes(ctx, true), f, "restore", archive); err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{
+		config.ConfigPath(): `{"output":"json"}`,
+		queuePath():         `[{"id":"a"}]`,
+		filepath.Join(carouselStateDir(), "abc.json"): `{"children":[]}`,
+	} {
+		if data, err := os.ReadFile(path); err != nil || string(data) != want {
+			t.Errorf("%s: got %q, %v, want %q", path, data, err, want)
+		}
+	}
+	entries, err := os.ReadDir(carouselStateDir())
+	if err != nil || len(entries) != 1 {
+		t.Errorf("expected only the carousel state restored, got %v, %v", entries, err)
+	}
+	if _, err := os.Stat(queuePath() + ".lock"); err == nil {
+		t.Error("expected no lock restored or left behind")
+	}
+}
+
+func TestStateBackup_Credentials(t *testing.T) {
+	useTempState(t)
+	t.Setenv(backupPassphraseEnv, "correct horse")
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	store := &mockCredentialsStore{creds: testCredentials(), secrets: map[string]string{smtpPasswordSecret: "smtp-password"}}
+	f.Store = func() (secrets.Store, error) { return store, nil }
+	ctx := iocontext.WithIO(context.Background(), io)
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+
+	err := runStateCmd(ctx, f, "backup", "--out", archive, "--with-credentials")
+	if err == nil || !strings.Contains(err.Error(), "--encrypt") {
+		t.Fatalf("expected credentials refused without --encrypt, got %v", err)
+	}
+	if err := runStateCmd(ctx, f, "backup", "--out", archive, "--with-credentials", "--encrypt"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(archive) //nolint:gosec // Test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("test-access-token")) {
+		t.Error("expected the token encrypted")
+	}
+
+	restoring := &recordingStore{saved: map[string]secrets.Credentials{}}
+	f.Store = func() (secrets.Store, error) { return restoring, nil }
+	t.Setenv(backupPassphraseEnv, "wrong")
+	if err := runStateCmd(ctx, f, "restore", archive); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Fatalf("expected a wrong passphrase refused, got %v", err)
+	}
+	t.Setenv(backupPassphraseEnv, "correct horse")
+	if err := runStateCmd(outfmt.WithYes(ctx, true), f, "restore", archive); err != nil {
+		t.Fatal(err)
+	}
+	creds := restoring.saved["test-user"]
+	if creds.AccessToken != "test-access-token" || creds.ClientSecret != "test-client-secret" || creds.UserID != "12345" {
+		t.Errorf("expected the credentials restored, got %+v", creds)
+	}
+	if restoring.secrets[smtpPasswordSecret] != "smtp-password" {
+		t.Errorf("expected the SMTP password restored, got %v", restoring.secrets)
+	}
+}
+
+func TestStateBackupRestore_Zstd(t *testing.T) {
+	useTempState(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	ctx := iocontext.WithIO(context.Background(), io)
+
+	writeTestFile(t, queuePath(), `[{"id":"a"}]`)
+	archive := filepath.Join(t.TempDir(), "backup.tar.zst")
+	if err := runStateCmd(ctx, f, "backup", "--out", archive); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(archive); err != nil || !bytes.HasPrefix(data, []byte{0x28, 0xb5, 0x2f, 0xfd}) {
+		t.Fatalf("expected a zstd-compressed archive, got %v", err)
+	}
+
+	// Another machine
+	useTempState(t)
+	if err := runStateCmd(outfmt.WithYes(ctx, true), f, "restore", archive); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(queuePath()); err != nil || string(data) != `[{"id":"a"}]` {
+		t.Errorf("expected the queue restored, got %q, %v", data, err)
+	}
+}
+
+func TestStateRestore_NotABackup(t *testing.T) {
+	useTempState(t)
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	ctx := iocontext.WithIO(context.Background(), io)
+
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	writeTestFile(t, path, "notes")
+	if err := runStateCmd(ctx, f, "restore", path); err == nil || !strings.Contains(err.Error(), "not a state backup") {
+		t.Errorf("expected the file refused, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// errStrictPrompt is returned instead of prompting under --strict
+var errStrictPrompt = &UserFriendlyError{
+	Message:    "Confirmation required, but --strict never prompts",
+	Suggestion: "Add --yes to confirm up front",
+}
+
+// warn reports degraded behavior the command carries on with. Under --strict
+// the warning is returned as the command's error instead, so scripts fail
+// rather than proceed. Warnings go to stderr so JSON output stays parseable.
+func warn(ctx context.Context, w *UserFriendlyError) error {
+	if outfmt.GetStrict(ctx) {
+		return w
+	}
+	io := iocontext.GetIO(ctx)
+	fmt.Fprintf(io.ErrOut, "warning: %s\n", w.Message) //nolint:errcheck // Best-effort output to stderr
+	if w.Suggestion != "" {
+		fmt.Fprintf(io.ErrOut, "hint: %s\n", w.Suggestion) //nolint:errcheck // Best-effort output to stderr
+	}
+	return nil
+}
+
+// checkAltText warns when media is posted without alt text, which leaves it
+// undescribed for people using screen readers. missing counts the items
+// without alt text out of total.
+func checkAltText(ctx context.Context, missing, total int) error {
+	switch {
+	case missing == 0:
+		return nil
+	case total == 1:
+		return warn(ctx, &UserFriendlyError{
+			Message:    "The media has no alt text",
+			Suggestion: "Describe it with --alt-text",
+		})
+	default:
+		return warn(ctx, &UserFriendlyError{
+			Message:    fmt.Sprintf("%d of %d carousel items have no alt text", missing, total),
+			Suggestion: "Pass --alt-text once per item, in order",
+		})
+	}
+}
+
+// duplicateLookback is how many recent posts new text is compared with
+const duplicateLookback = 10
+
+// duplicateSimilarity is the share of words two posts must have in common
+// to count as near duplicates
+const duplicateSimilarity = 0.8
+
+// checkDuplicate warns when text nearly repeats one of the account's recent
+// posts. The check is advisory: when the recent posts cannot be loaded it is
+// skipped and the post goes ahead.
+func checkDuplicate(ctx context.Context, f *Factory, client api.API, text string) error {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	creds, err := f.activeCredentials()
+	if err != nil || creds.UserID == "" {
+		return nil
+	}
+	recent, err := client.GetUserPostsWithOptions(ctx, api.UserID(creds.UserID), &api.PostsOptions{Limit: duplicateLookback})
+	if err != nil {
+		return nil
+	}
+	for _, post := range recent.Data {
+		if similarity(text, post.Text) >= duplicateSimilarity {
+			return warn(ctx, &UserFriendlyError{
+				Message:    fmt.Sprintf("The text nearly repeats your post %s", post.ID),
+				Suggestion: "Threads may show repeated posts to fewer people; reword the text",
+			})
+		}
+	}
+	return nil
+}
+
+// similarity is the share of distinct words a and b have in common,
+// ignoring case and punctuation: 1 for the same words, 0 for none shared
+func similarity(a, b string) float64 {
+	wordsA, wordsB := wordSet(a), wordSet(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range wordsA {
+		if wordsB[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+func wordSet(s string) map[string]bool {
+	words := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '#' && r != '@'
+	}) {
+		words[w] = true
+	}
+	return words
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+func TestWarn(t *testing.T) {
+	w := &UserFriendlyError{Message: "Something degraded", Suggestion: "Fix it"}
+
+	errOut := &bytes.Buffer{}
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &bytes.Buffer{}, ErrOut: errOut})
+	if err := warn(ctx, w); err != nil {
+		t.Fatalf("expected a warning only, got %v", err)
+	}
+	if got := errOut.String(); got != "warning: Something degraded\nhint: Fix it\n" {
+		t.Errorf("unexpected warning output: %q", got)
+	}
+
+	errOut.Reset()
+	if err := warn(outfmt.WithStrict(ctx, true), w); err != w {
+		t.Errorf("expected the warning as error under --strict, got %v", err)
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("expected nothing printed under --strict, got %q", errOut.String())
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		near bool
+	}{
+		{"Shipping v2 today!", "shipping V2 today", true},
+		{"New blog post: how we test #golang", "New blog post - how we test #golang.", true},
+		{"deployed 1.2.3", "deployed 1.2.4", false},
+		{"Good morning", "Good night", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := similarity(tt.a, tt.b) >= duplicateSimilarity; got != tt.near {
+			t.Errorf("similarity(%q, %q) = %.2f, near duplicate = %v, want %v", tt.a, tt.b, similarity(tt.a, tt.b), got, tt.near)
+		}
+	}
+}
+
+func TestConfirm_Strict(t *testing.T) {
+	f := newTestFactory(t)
+	ctx := outfmt.WithStrict(iocontext.WithIO(context.Background(), f.IO), true)
+
+	confirmed, err := f.Confirm(ctx, "Delete?")
+	if confirmed || !errors.Is(err, errStrictPrompt) {
+		t.Errorf("expected strict mode to refuse to prompt, got %v, %v", confirmed, err)
+	}
+
+	confirmed, err = f.Confirm(outfmt.WithYes(ctx, true), "Delete?")
+	if !confirmed || err != nil {
+		t.Errorf("expected --yes to confirm under --strict, got %v, %v", confirmed, err)
+	}
+}
+
+func TestPostsCreate_MissingAltText(t *testing.T) {
+	var posted string
+	server := newCreatePostServer(t, &posted)
+	f, io := newIntegrationTestFactory(t, server.URL)
+
+	cmd := newPostsCreateCmd(f)
+	cmd.SetArgs([]string{"--text", "sunset", "--image", "https://example.com/sunset.jpg"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if errOut := io.ErrOut.(*bytes.Buffer).String(); !strings.Contains(errOut, "warning: The media has no alt text") {
+		t.Errorf("expected an alt text warning, got %q", errOut)
+	}
+
+	posted = ""
+	cmd = newPostsCreateCmd(f)
+	cmd.SetArgs([]string{"--text", "sunset", "--image", "https://example.com/sunset.jpg"})
+	cmd.SetContext(outfmt.WithStrict(iocontext.WithIO(context.Background(), io), true))
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "no alt text") {
+		t.Fatalf("expected strict mode to fail on missing alt text, got %v", err)
+	}
+	if posted != "" {
+		t.Error("expected nothing to be posted")
+	}
+}
+
+func TestPostsCreate_NearDuplicate(t *testing.T) {
+	posted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "refresh_access_token"):
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/12345/threads"):
+			_, _ = w.Write([]byte(`{"data":[{"id":"900","text":"Shipping v2 today!"}]}`))
+		default:
+			posted = true
+			_, _ = w.Write([]byte(`{"id":"p1","status":"FINISHED"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	f, io := newIntegrationTestFactory(t, server.URL)
+
+	cmd := newPostsCreateCmd(f)
+	cmd.SetArgs([]string{"--text", "shipping V2 today"})
+	cmd.SetContext(outfmt.WithStrict(iocontext.WithIO(context.Background(), io), true))
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "nearly repeats your post 900") {
+		t.Fatalf("expected strict mode to fail on a near duplicate, got %v", err)
+	}
+	if posted {
+		t.Error("expected nothing to be posted")
+	}
+}
+
+func TestClient_StrictExpiringToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Session has expired","type":"OAuthException","code":190}}`))
+	}))
+	t.Cleanup(server.Close)
+	// The test credentials expire within a day
+	f, io := newIntegrationTestFactory(t, server.URL)
+	ctx := iocontext.WithIO(context.Background(), io)
+
+	if _, err := f.Client(ctx); err != nil {
+		t.Fatalf("expected the refresh to wait for the first request, got %v", err)
+	}
+	_, err := f.Client(outfmt.WithStrict(ctx, true))
+	if err == nil || !strings.Contains(err.Error(), "could not be renewed") {
+		t.Errorf("expected strict mode to fail when the token cannot be renewed, got %v", err)
+	}
+}
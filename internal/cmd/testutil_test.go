@@ -19,9 +19,12 @@ func (s *stubStore) Set(string, secrets.Credentials) error { return errors.New("
 func (s *stubStore) Get(string) (*secrets.Credentials, error) {
 	return nil, errors.New("not implemented")
 }
-func (s *stubStore) Delete(string) error     { return errors.New("not implemented") }
-func (s *stubStore) List() ([]string, error) { return nil, errors.New("not implemented") }
-func (s *stubStore) Keys() ([]string, error) { return nil, errors.New("not implemented") }
+func (s *stubStore) Delete(string) error              { return errors.New("not implemented") }
+func (s *stubStore) List() ([]string, error)          { return nil, errors.New("not implemented") }
+func (s *stubStore) Keys() ([]string, error)          { return nil, errors.New("not implemented") }
+func (s *stubStore) SetSecret(string, string) error   { return errors.New("not implemented") }
+func (s *stubStore) GetSecret(string) (string, error) { return "", errors.New("not implemented") }
+func (s *stubStore) DeleteSecret(string) error        { return errors.New("not implemented") }
 
 func newTestFactory(t *testing.T) *Factory {
 	t.Helper()
@@ -48,7 +51,8 @@ func newTestFactory(t *testing.T) *Factory {
 
 // mockCredentialsStore implements secrets.Store for testing
 type mockCredentialsStore struct {
-	creds *secrets.Credentials
+	creds   *secrets.Credentials
+	secrets map[string]string
 }
 
 func (m *mockCredentialsStore) Set(string, secrets.Credentials) error { return nil }
@@ -58,6 +62,23 @@ func (m *mockCredentialsStore) Get(string) (*secrets.Credentials, error) {
 func (m *mockCredentialsStore) Delete(string) error     { return nil }
 func (m *mockCredentialsStore) List() ([]string, error) { return []string{"test-user"}, nil }
 func (m *mockCredentialsStore) Keys() ([]string, error) { return []string{"test-user"}, nil }
+func (m *mockCredentialsStore) SetSecret(name, value string) error {
+	if m.secrets == nil {
+		m.secrets = map[string]string{}
+	}
+	m.secrets[name] = value
+	return nil
+}
+func (m *mockCredentialsStore) GetSecret(name string) (string, error) {
+	if value, ok := m.secrets[name]; ok {
+		return value, nil
+	}
+	return "", secrets.ErrSecretNotFound
+}
+func (m *mockCredentialsStore) DeleteSecret(name string) error {
+	delete(m.secrets, name)
+	return nil
+}
 
 // testCredentials returns mock credentials for testing
 func testCredentials() *secrets.Credentials {
@@ -75,13 +96,14 @@ func testCredentials() *secrets.Credentials {
 }
 
 // createMockClientFactory creates a NewClient function that uses a test server
-func createMockClientFactory(serverURL string) func(accessToken string, cfg *api.Config) (*api.Client, error) {
-	return func(accessToken string, cfg *api.Config) (*api.Client, error) {
+func createMockClientFactory(serverURL string) func(accessToken string, cfg *api.Config) (api.API, error) {
+	return func(accessToken string, cfg *api.Config) (api.API, error) {
 		// Create config with test server URL - use the captured serverURL
 		config := api.NewConfig()
 		if cfg != nil {
 			config.ClientID = cfg.ClientID
 			config.ClientSecret = cfg.ClientSecret
+			config.HTTPTrace = cfg.HTTPTrace
 		}
 		if config.ClientID == "" {
 			config.ClientID = "test-client-id"
@@ -93,7 +115,7 @@ func createMockClientFactory(serverURL string) func(accessToken string, cfg *api
 		config.BaseURL = serverURL // Always use the test server URL
 
 		// Create client without token validation
-		client, err := api.NewClient(config)
+		client, err := api.NewClient(config) //nolint:staticcheck // Builds the Config the factory passes
 		if err != nil {
 			return nil, err
 		}
@@ -142,3 +164,158 @@ func newIntegrationTestFactory(t *testing.T, serverURL string) (*Factory, *iocon
 
 	return f, io
 }
+
+// mockAPI implements api.API for tests that do not need an HTTP server.
+// Methods not overridden panic via the nil embedded interface.
+type mockAPI struct {
+	api.API
+	getPost      func(ctx context.Context, postID api.PostID) (*api.Post, error)
+	getMe        func(ctx context.Context) (*api.User, error)
+	getUserPosts func(ctx context.Context, userID api.UserID, opts *api.PostsOptions) (*api.PostsResponse, error)
+	hideReplies  func(ctx context.Context, replyIDs []api.PostID) error
+	getLimits    func(ctx context.Context) (*api.PublishingLimits, error)
+
+	// Optional: when nil these return errNotMocked
+	getPostInsights    func(ctx context.Context, postID api.PostID, metrics []string) (*api.InsightsResponse, error)
+	getPostsInsights   func(ctx context.Context, postIDs []api.PostID, metrics []string) (map[api.PostID]*api.InsightsResponse, error)
+	getAccountInsights func(ctx context.Context, userID api.UserID, opts *api.AccountInsightsOptions) (*api.InsightsResponse, error)
+	getConversation    func(ctx context.Context, postID api.PostID, opts *api.RepliesOptions) (*api.RepliesResponse, error)
+	lookupProfile      func(ctx context.Context, username string) (*api.PublicUser, error)
+	getProfilePosts    func(ctx context.Context, username string, opts *api.PostsOptions) (*api.PostsResponse, error)
+	getMentions        func(ctx context.Context, userID api.UserID, opts *api.PaginationOptions) (*api.PostsResponse, error)
+	listWebhooks       func(ctx context.Context) (*api.WebhookSubscriptionsResponse, error)
+	refreshToken       func(ctx context.Context) error
+	tokenInfo          *api.TokenInfo
+}
+
+var errNotMocked = errors.New("not mocked")
+
+func (m *mockAPI) GetPost(ctx context.Context, postID api.PostID) (*api.Post, error) {
+	return m.getPost(ctx, postID)
+}
+
+func (m *mockAPI) GetMe(ctx context.Context) (*api.User, error) {
+	return m.getMe(ctx)
+}
+
+func (m *mockAPI) GetUserPostsWithOptions(ctx context.Context, userID api.UserID, opts *api.PostsOptions) (*api.PostsResponse, error) {
+	return m.getUserPosts(ctx, userID, opts)
+}
+
+func (m *mockAPI) HideReplies(ctx context.Context, replyIDs []api.PostID) error {
+	return m.hideReplies(ctx, replyIDs)
+}
+
+func (m *mockAPI) GetPublishingLimits(ctx context.Context) (*api.PublishingLimits, error) {
+	return m.getLimits(ctx)
+}
+
+func (m *mockAPI) GetPostInsights(ctx context.Context, postID api.PostID, metrics []string) (*api.InsightsResponse, error) {
+	if m.getPostInsights == nil {
+		return nil, errNotMocked
+	}
+	return m.getPostInsights(ctx, postID, metrics)
+}
+
+func (m *mockAPI) GetPostsInsights(ctx context.Context, postIDs []api.PostID, metrics []string) (map[api.PostID]*api.InsightsResponse, error) {
+	if m.getPostsInsights == nil {
+		return nil, errNotMocked
+	}
+	return m.getPostsInsights(ctx, postIDs, metrics)
+}
+
+func (m *mockAPI) GetAccountInsightsWithOptions(ctx context.Context, userID api.UserID, opts *api.AccountInsightsOptions) (*api.InsightsResponse, error) {
+	if m.getAccountInsights == nil {
+		return nil, errNotMocked
+	}
+	return m.getAccountInsights(ctx, userID, opts)
+}
+
+// GetUserInsights makes the requests of opts through getAccountInsights, as
+// the client does
+func (m *mockAPI) GetUserInsights(ctx context.Context, userID api.UserID, opts *api.InsightsOptions) (*api.UserInsights, error) {
+	requests, err := opts.Requests()
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*api.InsightsResponse, len(requests))
+	for i, req := range requests {
+		if responses[i], err = m.GetAccountInsightsWithOptions(ctx, userID, req); err != nil {
+			return nil, err
+		}
+	}
+	return api.NewUserInsights(opts, responses...), nil
+}
+
+func (m *mockAPI) GetConversation(ctx context.Context, postID api.PostID, opts *api.RepliesOptions) (*api.RepliesResponse, error) {
+	if m.getConversation == nil {
+		return nil, errNotMocked
+	}
+	return m.getConversation(ctx, postID, opts)
+}
+
+func (m *mockAPI) LookupPublicProfile(ctx context.Context, username string) (*api.PublicUser, error) {
+	if m.lookupProfile == nil {
+		return nil, errNotMocked
+	}
+	return m.lookupProfile(ctx, username)
+}
+
+func (m *mockAPI) GetPublicProfilePosts(ctx context.Context, username string, opts *api.PostsOptions) (*api.PostsResponse, error) {
+	if m.getProfilePosts == nil {
+		return nil, errNotMocked
+	}
+	return m.getProfilePosts(ctx, username, opts)
+}
+
+func (m *mockAPI) GetUserMentions(ctx context.Context, userID api.UserID, opts *api.PaginationOptions) (*api.PostsResponse, error) {
+	if m.getMentions == nil {
+		return nil, errNotMocked
+	}
+	return m.getMentions(ctx, userID, opts)
+}
+
+func (m *mockAPI) ListWebhookSubscriptions(ctx context.Context) (*api.WebhookSubscriptionsResponse, error) {
+	if m.listWebhooks == nil {
+		return nil, errNotMocked
+	}
+	return m.listWebhooks(ctx)
+}
+
+func (m *mockAPI) RefreshToken(ctx context.Context) error {
+	if m.refreshToken == nil {
+		return errNotMocked
+	}
+	return m.refreshToken(ctx)
+}
+
+func (m *mockAPI) GetTokenInfo() *api.TokenInfo {
+	return m.tokenInfo
+}
+
+// newMockAPITestFactory creates a factory whose clients are the given mock
+func newMockAPITestFactory(t *testing.T, mock api.API) (*Factory, *iocontext.IO) {
+	t.Helper()
+
+	io := &iocontext.IO{
+		Out:    &bytes.Buffer{},
+		ErrOut: &bytes.Buffer{},
+		In:     &bytes.Buffer{},
+	}
+
+	f, err := NewFactory(context.Background(), FactoryOptions{
+		IO:     io,
+		Config: config.Default(),
+		Store: func() (secrets.Store, error) {
+			return &mockCredentialsStore{creds: testCredentials()}, nil
+		},
+		NewClient: func(string, *api.Config) (api.API, error) {
+			return mock, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create factory: %v", err)
+	}
+
+	return f, io
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/timeparse"
+)
+
+// addUTCFlag adds --utc to commands that take dates or times
+func addUTCFlag(cmd *cobra.Command, utc *bool) {
+	cmd.Flags().BoolVar(utc, "utc", false, "Read dates and times without a zone as UTC instead of the account's timezone")
+}
+
+// inputLocation is the zone for dates and times typed without one: UTC with
+// --utc, else the account's timezone (see 'threads auth label --timezone'),
+// else the system's local time
+func (f *Factory) inputLocation(utc bool) (*time.Location, error) {
+	if utc {
+		return time.UTC, nil
+	}
+	name := f.currentAccountName()
+	tz := f.Config.ForAccount(name).Timezone
+	if tz == "" {
+		return time.Local, nil
+	}
+	loc, ok := timeparse.Zone(tz)
+	if !ok {
+		return nil, &UserFriendlyError{
+			Message:    fmt.Sprintf("Unknown timezone %q configured for account %q", tz, name),
+			Suggestion: fmt.Sprintf("Set an IANA name such as Europe/Berlin with 'threads auth label %s --timezone', or pass --utc", name),
+		}
+	}
+	return loc, nil
+}
+
+// parseTimeFlag parses the value of a date flag such as --since. These flags
+// filter the past, so a bare duration like "7d" counts back from now.
+func parseTimeFlag(f *Factory, utc bool, flag, value string) (time.Time, error) {
+	loc, err := f.inputLocation(utc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	now := time.Now()
+	if d, err := timeparse.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := timeparse.Parse(value, now, loc)
+	if err != nil {
+		return time.Time{}, &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --%s value: %s", flag, value),
+			Suggestion: "Use 7d, " + timeparse.Formats,
+			Cause:      err,
+		}
+	}
+	return t, nil
+}
+
+// parseScheduleFlag parses the value of a flag naming a time to come, such
+// as --schedule, and returns it with the zone it was read in. Unlike
+// parseTimeFlag, a bare duration like "2h" counts forward from now, and a
+// time that has passed is an error.
+func parseScheduleFlag(f *Factory, utc bool, flag, value string) (time.Time, *time.Location, error) {
+	loc, err := f.inputLocation(utc)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	now := time.Now()
+	if d, err := timeparse.ParseDuration(value); err == nil {
+		return now.Add(d), loc, nil
+	}
+	t, err := timeparse.Parse(value, now, loc)
+	if err != nil {
+		return time.Time{}, nil, &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --%s value: %s", flag, value),
+			Suggestion: "Use 2h, " + timeparse.Formats,
+			Cause:      err,
+		}
+	}
+	if !t.After(now) {
+		return time.Time{}, nil, &UserFriendlyError{
+			Message:    fmt.Sprintf("--%s is in the past: %s", flag, t.In(loc).Format("2006-01-02 15:04 MST")),
+			Suggestion: "Give a time to come, e.g. \"tomorrow 9am\" or 2h",
+		}
+	}
+	return t, loc, nil
+}
+
+// dateRangeOptions are the --since, --until and --month filters of list and
+// search commands
+type dateRangeOptions struct {
+	Since string
+	Until string
+	Month string
+	UTC   bool
+}
+
+func addDateRangeFlags(cmd *cobra.Command, opts *dateRangeOptions) {
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Only posts after a date or time (2025-06-01, 7d, \"yesterday 9am\")")
+	cmd.Flags().StringVar(&opts.Until, "until", "", "Only posts before a date or time (2025-06-30, yesterday)")
+	cmd.Flags().StringVar(&opts.Month, "month", "", "Only posts from a calendar month (2025-06)")
+	addUTCFlag(cmd, &opts.UTC)
+	cmd.MarkFlagsMutuallyExclusive("month", "since")
+	cmd.MarkFlagsMutuallyExclusive("month", "until")
+}
+
+// unixRange returns the range as the unix timestamps the API takes; 0 means
+// unbounded. Threads has no posts before its launch, which the API enforces
+// with a minimum timestamp, so earlier dates are rejected here with a clear
+// message.
+func (o *dateRangeOptions) unixRange(f *Factory) (since, until int64, err error) {
+	var from, to time.Time
+	switch {
+	case o.Month != "":
+		loc, err := f.inputLocation(o.UTC)
+		if err != nil {
+			return 0, 0, err
+		}
+		month, err := time.ParseInLocation("2006-01", o.Month, loc)
+		if err != nil {
+			return 0, 0, &UserFriendlyError{
+				Message:    fmt.Sprintf("Invalid --month value: %s", o.Month),
+				Suggestion: "Use YYYY-MM, e.g. 2025-06",
+			}
+		}
+		from, to = month, month.AddDate(0, 1, 0)
+	default:
+		if o.Since != "" {
+			if from, err = parseTimeFlag(f, o.UTC, "since", o.Since); err != nil {
+				return 0, 0, err
+			}
+		}
+		if o.Until != "" {
+			if to, err = parseTimeFlag(f, o.UTC, "until", o.Until); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+
+	launch := time.Unix(api.MinSearchTimestamp, 0)
+	switch {
+	case !from.IsZero() && from.Before(launch), !to.IsZero() && to.Before(launch):
+		return 0, 0, &UserFriendlyError{
+			Message:    "Date range starts before Threads launched",
+			Suggestion: fmt.Sprintf("Use dates on or after %s", launch.UTC().Format("2006-01-02")),
+		}
+	case !from.IsZero() && !to.IsZero() && !from.Before(to):
+		return 0, 0, &UserFriendlyError{
+			Message:    fmt.Sprintf("--since (%s) must be before --until (%s)", from.Format(time.RFC3339), to.Format(time.RFC3339)),
+			Suggestion: "Swap the values or widen the range",
+		}
+	}
+
+	if !from.IsZero() {
+		since = from.Unix()
+	}
+	if !to.IsZero() {
+		until = to.Unix()
+	}
+	return since, until, nil
+}
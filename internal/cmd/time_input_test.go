@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/config"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+func TestInputLocation(t *testing.T) {
+	f, _ := newMockAPITestFactory(t, &mockAPI{})
+
+	loc, err := f.inputLocation(false)
+	if err != nil || loc != time.Local {
+		t.Errorf("unlabeled account: got %v, %v; want Local", loc, err)
+	}
+
+	f.Config.SetAccountSettings("test-user", config.AccountSettings{Timezone: "CET"})
+	loc, err = f.inputLocation(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, offset := time.Date(2025, 7, 1, 0, 0, 0, 0, loc).Zone(); offset != 3600 {
+		t.Errorf("account timezone offset = %d, want 3600", offset)
+	}
+
+	if loc, _ := f.inputLocation(true); loc != time.UTC {
+		t.Errorf("--utc: got %v, want UTC", loc)
+	}
+
+	f.Config.SetAccountSettings("test-user", config.AccountSettings{Timezone: "Mars/Olympus"})
+	if _, err := f.inputLocation(false); err == nil || !strings.Contains(err.Error(), "Unknown timezone") {
+		t.Errorf("expected an unknown timezone error, got %v", err)
+	}
+}
+
+func TestParseTimeFlag(t *testing.T) {
+	f, _ := newMockAPITestFactory(t, &mockAPI{})
+
+	got, err := parseTimeFlag(f, true, "since", "7d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ago := time.Since(got); ago < 7*24*time.Hour-time.Minute || ago > 7*24*time.Hour+time.Minute {
+		t.Errorf("7d resolved to %v ago", ago)
+	}
+
+	got, err = parseTimeFlag(f, true, "since", "2025-07-01 14:00")
+	if err != nil || !got.Equal(time.Date(2025, 7, 1, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %v, %v", got, err)
+	}
+
+	if _, err := parseTimeFlag(f, true, "until", "someday"); err == nil || !strings.Contains(err.Error(), "Invalid --until value") {
+		t.Errorf("expected an invalid value error, got %v", err)
+	}
+}
+
+func TestParseScheduleFlag(t *testing.T) {
+	f, _ := newMockAPITestFactory(t, &mockAPI{})
+
+	got, loc, err := parseScheduleFlag(f, true, "schedule", "2h")
+	if err != nil || loc != time.UTC {
+		t.Fatalf("got %v, %v", loc, err)
+	}
+	if ahead := time.Until(got); ahead < 2*time.Hour-time.Minute || ahead > 2*time.Hour+time.Minute {
+		t.Errorf("2h resolved to %v ahead", ahead)
+	}
+
+	if _, _, err := parseScheduleFlag(f, true, "schedule", "2025-07-01 09:00"); err == nil || !strings.Contains(err.Error(), "in the past") {
+		t.Errorf("expected a time in the past to fail, got %v", err)
+	}
+}
+
+func TestSearch_SinceUsesAccountTimezone(t *testing.T) {
+	var since, until string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "refresh_access_token") {
+			_, _ = w.Write([]byte(`{"access_token":"refreshed-token","token_type":"bearer","expires_in":5184000}`))
+			return
+		}
+		since, until = r.URL.Query().Get("since"), r.URL.Query().Get("until")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	f, io := newIntegrationTestFactory(t, server.URL)
+	f.Config.SetAccountSettings("test-user", config.AccountSettings{Timezone: "CET"})
+
+	cmd := NewSearchCmd(f)
+	cmd.SetArgs([]string{"coffee", "--since", "2025-07-01", "--until", "2025-07-01 18:00 UTC"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Midnight CET is 23:00 UTC the day before
+	if want := "1751324400"; since != want {
+		t.Errorf("since = %s, want %s", since, want)
+	}
+	if want := "1751392800"; until != want {
+		t.Errorf("until = %s, want %s", until, want)
+	}
+}
+
+func TestDateRange_UnixRange(t *testing.T) {
+	f, _ := newMockAPITestFactory(t, &mockAPI{})
+
+	tests := []struct {
+		name       string
+		opts       dateRangeOptions
+		wantSince  int64
+		wantUntil  int64
+		wantErrMsg string
+	}{
+		{name: "empty"},
+		{name: "month", opts: dateRangeOptions{Month: "2025-06", UTC: true}, wantSince: 1748736000, wantUntil: 1751328000},
+		{name: "dates", opts: dateRangeOptions{Since: "2025-06-01", Until: "2025-06-02", UTC: true}, wantSince: 1748736000, wantUntil: 1748822400},
+		{name: "bad month", opts: dateRangeOptions{Month: "June"}, wantErrMsg: "Invalid --month value"},
+		{name: "before launch", opts: dateRangeOptions{Since: "2023-01-01", UTC: true}, wantErrMsg: "before Threads launched"},
+		{name: "reversed", opts: dateRangeOptions{Since: "2025-06-02", Until: "2025-06-01", UTC: true}, wantErrMsg: "must be before --until"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			since, until, err := tt.opts.unixRange(f)
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if since != tt.wantSince || until != tt.wantUntil {
+				t.Errorf("got %d..%d, want %d..%d", since, until, tt.wantSince, tt.wantUntil)
+			}
+		})
+	}
+}
+
+func TestPostsList_Month(t *testing.T) {
+	var got *api.PostsOptions
+	f, io := newMockAPITestFactory(t, &mockAPI{
+		getMe: func(context.Context) (*api.User, error) {
+			return &api.User{ID: "12345"}, nil
+		},
+		getUserPosts: func(_ context.Context, _ api.UserID, opts *api.PostsOptions) (*api.PostsResponse, error) {
+			got = opts
+			return &api.PostsResponse{}, nil
+		},
+	})
+
+	cmd := newPostsListCmd(f)
+	cmd.SetArgs([]string{"--month", "2025-06", "--utc"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == nil || got.Since != 1748736000 || got.Until != 1751328000 {
+		t.Errorf("unexpected options: %+v", got)
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/events"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// Token renewal in long-running commands
+const (
+	// tokenCheckInterval is how often daemons read the stored tokens
+	tokenCheckInterval = time.Hour
+	// tokenRetryInterval is how long a daemon waits before trying again to
+	// renew a token it failed to renew
+	tokenRetryInterval = time.Hour
+)
+
+// tokenKeeper renews the token of an account in a long-running command. The
+// client renews an expiring token on its next request, but a daemon can go
+// days without one, and a 60-day token that lapses meanwhile stops it for
+// good.
+type tokenKeeper struct {
+	f       *Factory
+	account string
+	client  api.API
+	retryAt time.Time
+	// failed is the expiry of the token whose failed renewal was reported,
+	// so retries do not report it again
+	failed time.Time
+}
+
+func newTokenKeeper(f *Factory, account string, client api.API) *tokenKeeper {
+	return &tokenKeeper{f: f, account: account, client: client}
+}
+
+// check renews the token when it expires within tokenRefreshWindow, storing
+// it through the client, and publishes the outcome. A failure is a warning:
+// the token still works until it expires.
+func (k *tokenKeeper) check(ctx context.Context, now time.Time) error {
+	token := k.client.GetTokenInfo()
+	if token == nil || token.TokenType == api.TokenTypeApp || token.ExpiresAt.IsZero() {
+		return nil
+	}
+	if token.ExpiresAt.Sub(now) > tokenRefreshWindow || now.Before(k.retryAt) {
+		return nil
+	}
+
+	err := k.client.RefreshToken(ctx)
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err == nil {
+		renewed := token.ExpiresAt
+		if token := k.client.GetTokenInfo(); token != nil {
+			renewed = token.ExpiresAt
+		}
+		if !outfmt.IsJSON(ctx) {
+			fmt.Fprintf(iocontext.GetIO(ctx).ErrOut, "Renewed the access token of %s until %s\n", k.account, renewed.Local().Format("2006-01-02 15:04")) //nolint:errcheck // Best-effort output to stderr
+		}
+		return k.f.Events.Publish(ctx, events.TokenRefreshed{Account: k.account, ExpiresAt: renewed})
+	}
+
+	k.retryAt = now.Add(tokenRetryInterval)
+	if token.ExpiresAt.Equal(k.failed) {
+		return nil
+	}
+	k.failed = token.ExpiresAt
+	if err := warn(ctx, &UserFriendlyError{
+		Message:    fmt.Sprintf("Failed to renew the access token of %s, which expires on %s: %v", k.account, token.ExpiresAt.Local().Format("2006-01-02 15:04"), FormatError(err)),
+		Suggestion: "Run 'threads auth refresh', or 'threads auth login' to re-authenticate",
+		Cause:      err,
+	}); err != nil {
+		return err
+	}
+	return k.f.Events.Publish(ctx, events.TokenRefreshFailed{Account: k.account, ExpiresAt: token.ExpiresAt, Error: FormatError(err).Error()})
+}
+
+// tokenKeepers keep the tokens of the accounts a daemon publishes for. The
+// daemon creates clients as it publishes, so the stored token is the one
+// checked, with a new client when it needs renewing.
+type tokenKeepers struct {
+	f       *Factory
+	keepers map[string]*tokenKeeper
+	next    time.Time
+}
+
+func newTokenKeepers(f *Factory) *tokenKeepers {
+	return &tokenKeepers{f: f, keepers: map[string]*tokenKeeper{}}
+}
+
+// check checks the token of each account, "" being the default one, at most
+// every tokenCheckInterval. An account without usable credentials is
+// skipped: publishing for it reports why.
+func (k *tokenKeepers) check(ctx context.Context, accounts []string, now time.Time) error {
+	if now.Before(k.next) {
+		return nil
+	}
+	k.next = now.Add(tokenCheckInterval)
+
+	store, storeErr := k.f.Store()
+	if storeErr != nil {
+		// Publishing reports it
+		return nil
+	}
+	checked := map[string]bool{}
+	for _, account := range accounts {
+		if account == "" {
+			account = k.f.currentAccountName()
+		}
+		if checked[account] {
+			continue
+		}
+		checked[account] = true
+		creds, err := store.Get(account)
+		if err != nil || creds.IsExpired() || !creds.IsExpiringSoon(tokenRefreshWindow) {
+			continue
+		}
+		keeper, ok := k.keepers[account]
+		if !ok {
+			keeper = newTokenKeeper(k.f, account, nil)
+			k.keepers[account] = keeper
+		}
+		if now.Before(keeper.retryAt) {
+			continue
+		}
+		if keeper.client, err = k.f.clientFor(ctx, account); err != nil {
+			continue
+		}
+		if err = keeper.check(ctx, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
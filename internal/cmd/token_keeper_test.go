@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/events"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+// recordEvents collects the token events published on the bus of f
+func recordEvents(f *Factory) *[]events.Event {
+	var got []events.Event
+	f.Events.Subscribe(func(_ context.Context, e events.Event) error {
+		got = append(got, e)
+		return nil
+	}, events.TypeTokenRefreshed, events.TypeTokenRefreshFailed)
+	return &got
+}
+
+func TestTokenKeeper_RenewsExpiringToken(t *testing.T) {
+	renewed := time.Now().Add(60 * 24 * time.Hour)
+	refreshes := 0
+	mock := &mockAPI{tokenInfo: &api.TokenInfo{ExpiresAt: time.Now().Add(48 * time.Hour)}}
+	mock.refreshToken = func(context.Context) error {
+		refreshes++
+		mock.tokenInfo = &api.TokenInfo{ExpiresAt: renewed}
+		return nil
+	}
+	f, io := newMockAPITestFactory(t, mock)
+	got := recordEvents(f)
+	ctx := iocontext.WithIO(context.Background(), io)
+
+	keeper := newTokenKeeper(f, "work", mock)
+	for range 2 {
+		if err := keeper.check(ctx, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if refreshes != 1 {
+		t.Errorf("expected one refresh, got %d", refreshes)
+	}
+	if len(*got) != 1 {
+		t.Fatalf("expected a refreshed event, got %+v", *got)
+	}
+	if e, ok := (*got)[0].(events.TokenRefreshed); !ok || e.Account != "work" || !e.ExpiresAt.Equal(renewed) {
+		t.Errorf("unexpected event %+v", (*got)[0])
+	}
+	if !strings.Contains(io.ErrOut.(*bytes.Buffer).String(), "Renewed the access token of work") {
+		t.Errorf("expected the renewal reported, got %q", io.ErrOut.(*bytes.Buffer).String())
+	}
+}
+
+func TestTokenKeeper_ReportsFailureOnce(t *testing.T) {
+	expiresAt := time.Now().Add(48 * time.Hour)
+	refreshes := 0
+	mock := &mockAPI{
+		tokenInfo: &api.TokenInfo{ExpiresAt: expiresAt},
+		refreshToken: func(context.Context) error {
+			refreshes++
+			return errors.New("token revoked")
+		},
+	}
+	f, io := newMockAPITestFactory(t, mock)
+	got := recordEvents(f)
+	ctx := iocontext.WithIO(context.Background(), io)
+
+	keeper := newTokenKeeper(f, "work", mock)
+	now := time.Now()
+	for _, at := range []time.Time{now, now.Add(time.Minute), now.Add(tokenRetryInterval + time.Minute)} {
+		if err := keeper.check(ctx, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if refreshes != 2 {
+		t.Errorf("expected a retry after %s only, got %d refreshes", tokenRetryInterval, refreshes)
+	}
+	if len(*got) != 1 {
+		t.Fatalf("expected one failure event, got %+v", *got)
+	}
+	if e, ok := (*got)[0].(events.TokenRefreshFailed); !ok || !e.ExpiresAt.Equal(expiresAt) || !strings.Contains(e.Error, "token revoked") {
+		t.Errorf("unexpected event %+v", (*got)[0])
+	}
+	if got := strings.Count(io.ErrOut.(*bytes.Buffer).String(), "warning: Failed to renew the access token of work"); got != 1 {
+		t.Errorf("expected one warning, got %d", got)
+	}
+}
+
+func TestTokenKeepers_ChecksStoredTokens(t *testing.T) {
+	refreshes := 0
+	mock := &mockAPI{tokenInfo: &api.TokenInfo{ExpiresAt: time.Now().Add(24 * time.Hour)}}
+	mock.refreshToken = func(context.Context) error {
+		refreshes++
+		return nil
+	}
+	f, io := newMockAPITestFactory(t, mock)
+	got := recordEvents(f)
+	ctx := iocontext.WithIO(context.Background(), io)
+
+	// The stored token of testCredentials expires within the refresh window
+	keepers := newTokenKeepers(f)
+	now := time.Now()
+	for _, at := range []time.Time{now, now.Add(time.Minute)} {
+		if err := keepers.check(ctx, []string{"", "test-user"}, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if refreshes != 1 || len(*got) != 1 {
+		t.Errorf("expected the default account renewed once per %s, got %d refreshes and %+v", tokenCheckInterval, refreshes, *got)
+	}
+	if e, ok := (*got)[0].(events.TokenRefreshed); !ok || e.Account != "test-user" {
+		t.Errorf("unexpected event %+v", (*got)[0])
+	}
+}
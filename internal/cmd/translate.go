@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// languagePattern is a BCP 47 language tag such as en, pt-BR or zh-Hant
+var languagePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+func validateLanguage(lang string) error {
+	if !languagePattern.MatchString(lang) {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid language: %q", lang),
+			Suggestion: "Use a language code such as en, es or pt-BR",
+		}
+	}
+	return nil
+}
+
+// translateText translates text to the language lang with the translator in
+// the 'translator' config key. A URL is sent {"text", "target"} as a JSON
+// POST body; anything else runs in the shell with it as JSON on stdin.
+// Either answers with {"text": "..."} or with the plain translation.
+func translateText(ctx context.Context, translator, text, lang string) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return "", nil
+	}
+	body, err := json.Marshal(map[string]string{"text": text, "target": lang})
+	if err != nil {
+		return "", err
+	}
+
+	out, err := callExternal(ctx, translator, body)
+	if err != nil {
+		return "", err
+	}
+	out = bytes.TrimSpace(out)
+	if len(out) > 0 && out[0] == '{' {
+		var answer struct {
+			Text *string `json:"text"`
+		}
+		if err := json.Unmarshal(out, &answer); err != nil || answer.Text == nil {
+			return "", fmt.Errorf("invalid translator answer: expected {\"text\": \"...\"}")
+		}
+		return *answer.Text, nil
+	}
+	return string(out), nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+func TestTranslateText_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("translator commands in tests use sh")
+	}
+	ctx := iocontext.WithIO(context.Background(), newTestFactory(t).IO)
+
+	tests := []struct {
+		translator string
+		want       string
+	}{
+		// The request arrives as JSON on stdin
+		{`grep -q '"target":"de"' && echo '  hallo  '`, "hallo"},
+		{`echo '{"text": "hallo"}'`, "hallo"},
+	}
+	for _, tt := range tests {
+		got, err := translateText(ctx, tt.translator, "hello", "de")
+		if err != nil || got != tt.want {
+			t.Errorf("translateText with %q = %q, %v; want %q", tt.translator, got, err, tt.want)
+		}
+	}
+
+	for _, translator := range []string{"exit 1", `echo '{"translation": "hallo"}'`} {
+		if _, err := translateText(ctx, translator, "hello", "de"); err == nil {
+			t.Errorf("translateText with %q: expected an error", translator)
+		}
+	}
+}
+
+func TestValidateLanguage(t *testing.T) {
+	for _, lang := range []string{"en", "pt-BR", "zh-Hant"} {
+		if err := validateLanguage(lang); err != nil {
+			t.Errorf("validateLanguage(%q): %v", lang, err)
+		}
+	}
+	for _, lang := range []string{"", "e", "en_US", "en; rm -rf"} {
+		if err := validateLanguage(lang); err == nil {
+			t.Errorf("validateLanguage(%q): expected an error", lang)
+		}
+	}
+}
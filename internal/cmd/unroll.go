@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// unrollFormats are the values accepted by 'unroll --format'
+var unrollFormats = []string{"text", "markdown", "html"}
+
+// maxUnrollReplies bounds the replies scanned for the author's chain, so a
+// viral post does not page through its whole conversation
+const maxUnrollReplies = 2000
+
+// postURLPattern matches a post's web URL, capturing the author and the
+// shortcode
+var postURLPattern = regexp.MustCompile(`^https?://(?:www\.)?threads\.(?:net|com)/@([A-Za-z0-9._]+)/post/([A-Za-z0-9_-]+)`)
+
+// postURLLookup is how many of the author's recent posts are searched for
+// the post of a URL
+const postURLLookup = 100
+
+type unrollOptions struct {
+	Format string
+	Out    string
+	UTC    bool
+}
+
+// unrolled is a post and the author's replies continuing it, in order
+type unrolled struct {
+	Author    string     `json:"author"`
+	Permalink string     `json:"permalink"`
+	Posts     []api.Post `json:"posts"`
+	// Truncated is set when the conversation was too long to scan in full
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// NewUnrollCmd builds the unroll command.
+func NewUnrollCmd(f *Factory) *cobra.Command {
+	opts := &unrollOptions{Format: "text"}
+
+	cmd := &cobra.Command{
+		Use:   "unroll [post-id|url]",
+		Short: "Read a thread as one article",
+		Long: `Fetch a post and the author's chain of replies to it and print them as
+one piece of writing, without the replies of others.
+
+Pass the first post of the thread. A permalink works in place of the post
+ID; it is looked up among the author's recent posts, which needs the
+threads_profile_discovery scope.
+
+--format markdown or html renders the thread for saving or publishing,
+with images linked; --out writes it to a file instead of the terminal.
+
+Examples:
+  threads unroll 12345
+  threads unroll https://www.threads.net/@someone/post/C8abcDEFghi
+  threads unroll 12345 --format markdown --out thread.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUnroll(cmd, f, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Format, "format", opts.Format, "Render as text, markdown or html")
+	cmd.Flags().StringVar(&opts.Out, "out", "", "Write the thread to this file")
+	cmd.Flags().BoolVar(&opts.UTC, "utc", false, "Show the date in UTC instead of the account's timezone")
+	return cmd
+}
+
+func runUnroll(cmd *cobra.Command, f *Factory, target string, opts *unrollOptions) error {
+	ctx := cmd.Context()
+
+	if !slices.Contains(unrollFormats, opts.Format) {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid --format: %s", opts.Format),
+			Suggestion: "Valid formats: " + strings.Join(unrollFormats, ", "),
+		}
+	}
+	loc, err := f.inputLocation(opts.UTC)
+	if err != nil {
+		return err
+	}
+
+	client, err := f.Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	post, err := resolvePost(ctx, client, target)
+	if err != nil {
+		return err
+	}
+
+	thread, err := unrollThread(ctx, client, post)
+	if err != nil {
+		return err
+	}
+	if thread.Truncated {
+		f.UI(ctx).Warning("The conversation has over %d replies; the thread may continue past what is shown", maxUnrollReplies)
+	}
+
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) && opts.Out == "" {
+		return outfmt.WriteJSONContext(ctx, io.Out, thread)
+	}
+
+	var rendered string
+	switch opts.Format {
+	case "markdown":
+		rendered = thread.markdown(loc)
+	case "html":
+		rendered = thread.html(loc)
+	default:
+		rendered = thread.text(loc)
+	}
+
+	if opts.Out != "" {
+		if err := os.WriteFile(opts.Out, []byte(rendered), 0o644); err != nil { //nolint:gosec // A document the user asked for
+			return WrapError("failed to write thread", err)
+		}
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
+				"id":    post.ID,
+				"posts": len(thread.Posts),
+				"path":  opts.Out,
+			})
+		}
+		f.UI(ctx).Success("Wrote the %d-post thread by @%s to %s", len(thread.Posts), thread.Author, opts.Out)
+		return nil
+	}
+	if opts.Format == "text" {
+		return page(ctx, rendered)
+	}
+	fmt.Fprint(io.Out, rendered) //nolint:errcheck // Best-effort output
+	return nil
+}
+
+// resolvePost fetches the post ref names, by ID or by URL
+func resolvePost(ctx context.Context, client api.API, ref string) (*api.Post, error) {
+	m := postURLPattern.FindStringSubmatch(ref)
+	if m == nil {
+		post, err := client.GetPost(ctx, api.PostID(ref))
+		if err != nil {
+			return nil, WrapError("failed to get post", err)
+		}
+		return post, nil
+	}
+
+	username, shortcode := m[1], m[2]
+	posts, err := client.GetPublicProfilePosts(ctx, username, &api.PostsOptions{Limit: postURLLookup})
+	if err != nil {
+		return nil, WrapError(fmt.Sprintf("failed to look up @%s's posts", username), err)
+	}
+	for i, post := range posts.Data {
+		if post.Shortcode == shortcode || strings.HasSuffix(strings.TrimSuffix(post.Permalink, "/"), "/post/"+shortcode) {
+			return &posts.Data[i], nil
+		}
+	}
+	return nil, &UserFriendlyError{
+		Message:    fmt.Sprintf("Post %s is not among @%s's %d most recent posts", shortcode, username, postURLLookup),
+		Suggestion: "Use the post ID instead",
+	}
+}
+
+// unrollThread collects the replies of post's author that continue post,
+// directly or through another of their replies, oldest first
+func unrollThread(ctx context.Context, client api.API, post *api.Post) (*unrolled, error) {
+	chronological := false
+	pager := api.NewPager(func(ctx context.Context, cursor string) (*api.Page[api.Post], error) {
+		return client.GetConversation(ctx, api.PostID(post.ID), &api.RepliesOptions{
+			Limit:   100,
+			After:   cursor,
+			Reverse: &chronological,
+		})
+	})
+
+	thread := &unrolled{Author: post.Username, Permalink: post.Permalink}
+	var replies []api.Post
+	for pager.Next(ctx) {
+		if len(replies) == maxUnrollReplies {
+			thread.Truncated = true
+			break
+		}
+		replies = append(replies, pager.Item())
+	}
+	if err := pager.Err(); err != nil {
+		return nil, WrapError("failed to get conversation", err)
+	}
+	slices.SortStableFunc(replies, func(a, b api.Post) int {
+		return a.Timestamp.Compare(b.Timestamp.Time)
+	})
+
+	chain := map[string]bool{post.ID: true}
+	thread.Posts = append(thread.Posts, *post)
+	for _, reply := range replies {
+		if reply.Username != post.Username || reply.RepliedTo == nil || !chain[reply.RepliedTo.ID] {
+			continue
+		}
+		chain[reply.ID] = true
+		thread.Posts = append(thread.Posts, reply)
+	}
+	return thread, nil
+}
+
+// mediaLinks returns the URLs of the images and videos of post
+func mediaLinks(post *api.Post) []string {
+	switch {
+	case post.MediaURL != "":
+		return []string{post.MediaURL}
+	case post.MediaType == api.MediaTypeCarousel && post.Permalink != "":
+		return []string{post.Permalink}
+	}
+	return nil
+}
+
+func (t *unrolled) started(loc *time.Location) string {
+	return t.Posts[0].Timestamp.In(loc).Format("2006-01-02 15:04")
+}
+
+func (t *unrolled) text(loc *time.Location) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "@%s · %s · %d posts\n", t.Author, t.started(loc), len(t.Posts))
+	for i := range t.Posts {
+		post := &t.Posts[i]
+		b.WriteString("\n")
+		if post.Text != "" {
+			b.WriteString(post.Text + "\n")
+		}
+		for _, link := range mediaLinks(post) {
+			fmt.Fprintf(&b, "[%s] %s\n", strings.ToLower(post.MediaType), link)
+		}
+	}
+	fmt.Fprintf(&b, "\n%s\n", t.Permalink)
+	return b.String()
+}
+
+func (t *unrolled) markdown(loc *time.Location) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Thread by @%s\n\n", t.Author)
+	fmt.Fprintf(&b, "*%s · [Original](%s)*\n", t.started(loc), t.Permalink)
+	for i := range t.Posts {
+		post := &t.Posts[i]
+		if post.Text != "" {
+			fmt.Fprintf(&b, "\n%s\n", post.Text)
+		}
+		for _, link := range mediaLinks(post) {
+			if post.MediaType == api.MediaTypeImage {
+				fmt.Fprintf(&b, "\n![%s](%s)\n", post.AltText, link)
+			} else {
+				fmt.Fprintf(&b, "\n[%s](%s)\n", strings.ToLower(post.MediaType), link)
+			}
+		}
+	}
+	return b.String()
+}
+
+func (t *unrolled) html(loc *time.Location) string {
+	var b strings.Builder
+	title := html.EscapeString("Thread by @" + t.Author)
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n<article>\n", title)
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", title)
+	fmt.Fprintf(&b, "<p><small>%s · <a href=\"%s\">Original</a></small></p>\n", t.started(loc), html.EscapeString(t.Permalink))
+	for i := range t.Posts {
+		post := &t.Posts[i]
+		if post.Text != "" {
+			text := strings.ReplaceAll(html.EscapeString(post.Text), "\n", "<br>\n")
+			fmt.Fprintf(&b, "<p>%s</p>\n", text)
+		}
+		for _, link := range mediaLinks(post) {
+			link = html.EscapeString(link)
+			if post.MediaType == api.MediaTypeImage {
+				fmt.Fprintf(&b, "<p><img src=\"%s\" alt=\"%s\"></p>\n", link, html.EscapeString(post.AltText))
+			} else {
+				fmt.Fprintf(&b, "<p><a href=\"%s\">%s</a></p>\n", link, strings.ToLower(post.MediaType))
+			}
+		}
+	}
+	b.WriteString("</article>\n</body>\n</html>\n")
+	return b.String()
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// unrollMock is a thread by alice: post 1, her replies 2 and 4 continuing
+// it, and a reply from bob that she answers with 5
+func unrollMock() *mockAPI {
+	at := func(minute int) api.Time {
+		return api.Time{Time: time.Date(2025, 7, 1, 12, minute, 0, 0, time.UTC)}
+	}
+	root := &api.Post{ID: "1", Username: "alice", Text: "A thread 🧵", Timestamp: at(0), Permalink: "https://www.threads.net/@alice/post/AB"}
+	replies := []api.Post{
+		{ID: "4", Username: "alice", Text: "Part three", Timestamp: at(3), RepliedTo: &api.Post{ID: "2"}, RootPost: root},
+		{ID: "2", Username: "alice", Text: "Part two", Timestamp: at(1), RepliedTo: &api.Post{ID: "1"}, RootPost: root},
+		{ID: "3", Username: "bob", Text: "Nice <3", Timestamp: at(2), RepliedTo: &api.Post{ID: "1"}, RootPost: root},
+		{ID: "5", Username: "alice", Text: "Thanks bob", Timestamp: at(4), RepliedTo: &api.Post{ID: "3"}, RootPost: root},
+	}
+	return &mockAPI{
+		getPost: func(_ context.Context, id api.PostID) (*api.Post, error) {
+			if id != "1" {
+				return nil, errNotMocked
+			}
+			return root, nil
+		},
+		getConversation: func(_ context.Context, id api.PostID, opts *api.RepliesOptions) (*api.RepliesResponse, error) {
+			if id != "1" {
+				return &api.RepliesResponse{}, nil
+			}
+			// Two pages, to check the chain is followed across them
+			if opts.After == "" {
+				page := &api.RepliesResponse{Data: replies[:2]}
+				page.Paging.Cursors = &api.PagingCursors{After: "next"}
+				return page, nil
+			}
+			return &api.RepliesResponse{Data: replies[2:]}, nil
+		},
+	}
+}
+
+func runUnrollCmd(t *testing.T, f *Factory, io *iocontext.IO, format string, args ...string) error {
+	t.Helper()
+	cmd := NewUnrollCmd(f)
+	cmd.SetArgs(append(args, "--utc"))
+	cmd.SetContext(outfmt.WithFormat(iocontext.WithIO(context.Background(), io), format))
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return cmd.Execute()
+}
+
+func TestUnroll_FollowsAuthorChain(t *testing.T) {
+	t.Setenv("PAGER", "cat")
+	f, io := newMockAPITestFactory(t, unrollMock())
+
+	if err := runUnrollCmd(t, f, io, "text", "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := io.Out.(*bytes.Buffer).String()
+	want := "@alice · 2025-07-01 12:00 · 3 posts\n\nA thread 🧵\n\nPart two\n\nPart three\n\nhttps://www.threads.net/@alice/post/AB\n"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestUnroll_JSON(t *testing.T) {
+	f, io := newMockAPITestFactory(t, unrollMock())
+
+	if err := runUnrollCmd(t, f, io, "json", "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got unrolled
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	var ids []string
+	for _, post := range got.Posts {
+		ids = append(ids, post.ID)
+	}
+	if got.Author != "alice" || strings.Join(ids, ",") != "1,2,4" {
+		t.Errorf("expected alice's thread, got @%s %v", got.Author, ids)
+	}
+}
+
+func TestUnroll_WritesHTML(t *testing.T) {
+	f, io := newMockAPITestFactory(t, unrollMock())
+	path := filepath.Join(t.TempDir(), "thread.html")
+
+	if err := runUnrollCmd(t, f, io, "text", "1", "--format", "html", "--out", path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := string(data)
+	if !strings.Contains(doc, "<h1>Thread by @alice</h1>") || !strings.Contains(doc, "<p>Part three</p>") {
+		t.Errorf("unexpected document:\n%s", doc)
+	}
+	if strings.Contains(doc, "Nice") || strings.Contains(doc, "Thanks bob") {
+		t.Errorf("expected replies outside the chain left out:\n%s", doc)
+	}
+}
+
+// profilePostsAPI serves the recent posts of @alice, to look up URLs in
+type profilePostsAPI struct {
+	*mockAPI
+}
+
+func (m profilePostsAPI) GetPublicProfilePosts(_ context.Context, username string, _ *api.PostsOptions) (*api.PostsResponse, error) {
+	if username != "alice" {
+		return &api.PostsResponse{}, nil
+	}
+	return &api.PostsResponse{Data: []api.Post{
+		{ID: "9", Shortcode: "Other"},
+		{ID: "1", Username: "alice", Shortcode: "C8abc"},
+	}}, nil
+}
+
+func TestUnroll_ByURL(t *testing.T) {
+	f, io := newMockAPITestFactory(t, profilePostsAPI{unrollMock()})
+
+	if err := runUnrollCmd(t, f, io, "json", "https://www.threads.com/@alice/post/C8abc?xmt=1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got unrolled
+	if err := json.Unmarshal(io.Out.(*bytes.Buffer).Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got.Posts) != 3 || got.Posts[0].Shortcode != "C8abc" {
+		t.Errorf("expected the thread of the post at the URL, got %+v", got.Posts)
+	}
+
+	err := runUnrollCmd(t, f, io, "json", "https://www.threads.net/@alice/post/Missing")
+	if err == nil || !strings.Contains(err.Error(), "not among @alice's") {
+		t.Errorf("expected an unknown shortcode to be an error, got %v", err)
+	}
+}
+
+func TestUnroll_InvalidFormat(t *testing.T) {
+	f, io := newMockAPITestFactory(t, unrollMock())
+	err := runUnrollCmd(t, f, io, "text", "1", "--format", "pdf")
+	if err == nil || !strings.Contains(err.Error(), "Invalid --format") {
+		t.Errorf("expected invalid format error, got %v", err)
+	}
+}
+
+func FuzzPostURLPattern(f *testing.F) {
+	for _, seed := range []string{
+		"https://www.threads.net/@alice/post/AB",
+		"https://www.threads.com/@alice/post/C8abc?xmt=1",
+		"http://threads.net/@a.b_c/post/x-y_z/",
+		"https://threads.com/@/post/AB",
+		"https://www.threads.net.evil.com/@alice/post/AB",
+		"18063927415508136",
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, ref string) {
+		m := postURLPattern.FindStringSubmatch(ref)
+		if m == nil {
+			return
+		}
+		username, shortcode := m[1], m[2]
+		if username == "" || shortcode == "" || strings.ContainsAny(username+shortcode, "/?#@ ") {
+			t.Fatalf("%q: got username %q and shortcode %q", ref, username, shortcode)
+		}
+		if !strings.HasPrefix(ref, "http") || !strings.Contains(m[0], "/@"+username+"/post/"+shortcode) {
+			t.Errorf("%q: match %q does not hold @%s/post/%s", ref, m[0], username, shortcode)
+		}
+	})
+}
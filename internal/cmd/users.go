@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -92,7 +93,7 @@ func runUsersMe(cmd *cobra.Command, f *Factory) error {
 
 	io := iocontext.GetIO(ctx)
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSONTo(io.Out, userToMap(user), outfmt.GetQuery(ctx))
+		return outfmt.WriteJSONContext(ctx, io.Out, userToMap(user))
 	}
 
 	printUserText(cmd.Context(), f, user)
@@ -114,7 +115,7 @@ func runUsersGet(cmd *cobra.Command, f *Factory, userID string) error {
 
 	io := iocontext.GetIO(ctx)
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSONTo(io.Out, userToMap(user), outfmt.GetQuery(ctx))
+		return outfmt.WriteJSONContext(ctx, io.Out, userToMap(user))
 	}
 
 	printUserText(ctx, f, user)
@@ -136,7 +137,7 @@ func runUsersLookup(cmd *cobra.Command, f *Factory, username string) error {
 
 	io := iocontext.GetIO(ctx)
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSONTo(io.Out, publicUserToMap(publicUser), outfmt.GetQuery(ctx))
+		return outfmt.WriteJSONContext(ctx, io.Out, publicUserToMap(publicUser))
 	}
 
 	printPublicUserText(ctx, f, publicUser)
@@ -223,10 +224,24 @@ func printPublicUserText(ctx context.Context, f *Factory, u *api.PublicUser) {
 func newUsersMentionsCmd(f *Factory) *cobra.Command {
 	var limit int
 	var cursor string
+	var translate string
+	var showMuted bool
 
 	cmd := &cobra.Command{
 		Use:   "mentions",
 		Short: "List posts mentioning you",
+		Long: `List posts mentioning you.
+
+--translate shows each mention next to its translation by the command or URL
+in the 'translator' config key.
+
+Mentions from users or with keywords muted with 'threads mute' are hidden
+unless --show-muted is set.
+
+Examples:
+  threads users mentions
+  threads config set translator ./translate.sh
+  threads users mentions --translate en`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
@@ -245,15 +260,22 @@ func newUsersMentionsCmd(f *Factory) *cobra.Command {
 				After: cursor,
 			}
 
-			result, err := client.GetUserMentions(ctx, api.UserID(me.ID), opts)
+			page, err := client.GetUserMentions(ctx, api.UserID(me.ID), opts)
 			if err != nil {
 				return WrapError("failed to get mentions", err)
 			}
+			if err := hideMuted(ctx, page, showMuted); err != nil {
+				return err
+			}
+			result, err := annotatePosts(ctx, f, page, annotateOptions{Translate: translate})
+			if err != nil {
+				return err
+			}
 
 			// JSON output
 			io := iocontext.GetIO(ctx)
 			if outfmt.IsJSON(ctx) {
-				return outfmt.WriteJSONTo(io.Out, result, outfmt.GetQuery(ctx))
+				return outfmt.WriteJSONContext(ctx, io.Out, result)
 			}
 
 			out := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
@@ -264,6 +286,21 @@ func newUsersMentionsCmd(f *Factory) *cobra.Command {
 			}
 
 			headers := []string{"ID", "FROM", "TEXT", "TIMESTAMP"}
+			colTypes := []outfmt.ColumnType{
+				outfmt.ColumnID,
+				outfmt.ColumnPlain,
+				outfmt.ColumnPlain,
+				outfmt.ColumnDate,
+			}
+			if translate != "" {
+				headers = append(headers, "TRANSLATION")
+				colTypes = append(colTypes, outfmt.ColumnPlain)
+			}
+			noted := postNotes(ctx, f, len(result.Data), func(i int) *api.Post { return result.Data[i].Post })
+			if noted != nil {
+				headers = append(headers, "NOTE")
+				colTypes = append(colTypes, outfmt.ColumnPlain)
+			}
 			rows := make([][]string, len(result.Data))
 			for i, post := range result.Data {
 				text := post.Text
@@ -276,19 +313,27 @@ func newUsersMentionsCmd(f *Factory) *cobra.Command {
 					text,
 					post.Timestamp.Format("2006-01-02 15:04"),
 				}
+				if translate != "" {
+					rows[i] = append(rows[i], fallback(truncateText(strings.ReplaceAll(post.Translation, "\n", " "), 50), "-"))
+				}
+				if noted != nil {
+					rows[i] = append(rows[i], noted[i])
+				}
 			}
 
-			return out.Table(headers, rows, []outfmt.ColumnType{
-				outfmt.ColumnID,
-				outfmt.ColumnPlain,
-				outfmt.ColumnPlain,
-				outfmt.ColumnDate,
-			})
+			if err := out.Table(headers, rows, colTypes); err != nil {
+				return err
+			}
+
+			writeListFooter(cmd, args, len(result.Data), result.NextCursor())
+			return nil
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum results")
 	cmd.Flags().StringVar(&cursor, "cursor", "", "Pagination cursor")
+	cmd.Flags().StringVar(&translate, "translate", "", "Also show mentions translated to this language, e.g. en (uses the configured translator)")
+	addShowMutedFlag(cmd, &showMuted)
 
 	return cmd
 }
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+// viewField is one value of a view model. JSON objects, table rows and
+// detail output are all built from a view's fields, so every output format
+// shows the same data.
+type viewField struct {
+	// Key is the JSON key; table headers and detail labels are derived from it
+	Key string
+	// Value is written to JSON as is
+	Value any
+	// Text is the table cell; empty formats Value
+	Text string
+	Type outfmt.ColumnType
+}
+
+// view is a typed view model of an API object for output
+type view interface {
+	viewFields() []viewField
+}
+
+// viewMap returns the JSON object for v
+func viewMap(v view) map[string]any {
+	fields := v.viewFields()
+	m := make(map[string]any, len(fields))
+	for _, field := range fields {
+		m[field.Key] = field.Value
+	}
+	return m
+}
+
+// viewHeader derives a table header from a JSON key: callback_url -> CALLBACK URL
+func viewHeader(key string) string {
+	return strings.ToUpper(strings.ReplaceAll(key, "_", " "))
+}
+
+// viewLabel derives a detail label from a JSON key: callback_url -> Callback URL
+func viewLabel(key string) string {
+	words := strings.Split(key, "_")
+	for i, word := range words {
+		switch word {
+		case "id", "url":
+			words[i] = strings.ToUpper(word)
+		default:
+			if i == 0 {
+				words[i] = strings.ToUpper(word[:1]) + word[1:]
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// viewCell formats a field for text output
+func viewCell(field viewField) string {
+	if field.Text != "" {
+		return field.Text
+	}
+	switch v := field.Value.(type) {
+	case nil:
+		return "-"
+	case string:
+		if v == "" {
+			return "-"
+		}
+		return v
+	case bool:
+		if v {
+			return "yes"
+		}
+		return "no"
+	case []string:
+		if len(v) == 0 {
+			return "-"
+		}
+		return strings.Join(v, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// writeViewList writes views as {"data": [...], "paging": ...} in JSON mode
+// or as a table with one column per field otherwise.
+func writeViewList[V view](ctx context.Context, views []V, paging *api.Paging, emptyMsg string) error {
+	io := iocontext.GetIO(ctx)
+
+	if outfmt.IsJSON(ctx) {
+		data := make([]map[string]any, len(views))
+		for i, v := range views {
+			data[i] = viewMap(v)
+		}
+		result := map[string]any{"data": data}
+		if paging != nil {
+			result["paging"] = paging
+		}
+		return outfmt.WriteJSONContext(ctx, io.Out, result)
+	}
+
+	out := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
+	if len(views) == 0 {
+		out.Empty(emptyMsg)
+		return nil
+	}
+
+	var headers []string
+	var colTypes []outfmt.ColumnType
+	for _, field := range views[0].viewFields() {
+		headers = append(headers, viewHeader(field.Key))
+		colTypes = append(colTypes, field.Type)
+	}
+
+	rows := make([][]string, len(views))
+	for i, v := range views {
+		for _, field := range v.viewFields() {
+			rows[i] = append(rows[i], viewCell(field))
+		}
+	}
+	return out.Table(headers, rows, colTypes)
+}
+
+// writeViewDetail writes a single view as a JSON object or as labelled lines
+func writeViewDetail(ctx context.Context, v view) error {
+	io := iocontext.GetIO(ctx)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSONContext(ctx, io.Out, viewMap(v))
+	}
+	if outfmt.IsDelimited(ctx) {
+		// A table of one row
+		var headers, row []string
+		for _, field := range v.viewFields() {
+			headers = append(headers, viewHeader(field.Key))
+			row = append(row, viewCell(field))
+		}
+		return outfmt.FromContext(ctx, outfmt.WithWriter(io.Out)).Table(headers, [][]string{row}, nil)
+	}
+
+	w := tabwriter.NewWriter(io.Out, 0, 0, 1, ' ', 0)
+	for _, field := range v.viewFields() {
+		fmt.Fprintf(w, "  %s:\t%s\n", viewLabel(field.Key), viewCell(field)) //nolint:errcheck // Best-effort output
+	}
+	return w.Flush()
+}
+
+// webhookSubscriptionView is the output model of a webhook subscription
+type webhookSubscriptionView struct {
+	sub *api.WebhookSubscription
+	// truncate shortens the callback URL in table cells
+	truncate bool
+}
+
+func (v webhookSubscriptionView) viewFields() []viewField {
+	fields := make([]string, len(v.sub.Fields))
+	for i, f := range v.sub.Fields {
+		fields[i] = f.Name
+	}
+
+	callbackURL := viewField{Key: "callback_url", Value: v.sub.CallbackURL}
+	if v.truncate {
+		callbackURL.Text = truncateURL(v.sub.CallbackURL, 40)
+	}
+
+	return []viewField{
+		{Key: "id", Value: v.sub.ID, Type: outfmt.ColumnID},
+		{Key: "object", Value: v.sub.Object},
+		callbackURL,
+		{Key: "fields", Value: fields},
+		{Key: "active", Value: v.sub.Active, Type: outfmt.ColumnStatus},
+		{Key: "created_time", Value: v.sub.CreatedTime, Type: outfmt.ColumnDate},
+	}
+}
+
+// locationView is the output model of a location
+type locationView struct {
+	loc *api.Location
+}
+
+func (v locationView) viewFields() []viewField {
+	return []viewField{
+		{Key: "id", Value: v.loc.ID, Type: outfmt.ColumnID},
+		{Key: "name", Value: v.loc.Name},
+		{Key: "address", Value: v.loc.Address},
+		{Key: "city", Value: v.loc.City},
+		{Key: "country", Value: v.loc.Country},
+		{Key: "postal_code", Value: v.loc.PostalCode},
+		{Key: "latitude", Value: v.loc.Latitude, Text: formatCoordinate(v.loc.Latitude)},
+		{Key: "longitude", Value: v.loc.Longitude, Text: formatCoordinate(v.loc.Longitude)},
+	}
+}
+
+// formatCoordinate formats a latitude or longitude for text output; 0 means unknown
+func formatCoordinate(c float64) string {
+	if c == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.6f", c)
+}
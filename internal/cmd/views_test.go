@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+func viewTestContext(format outfmt.Format) (context.Context, *bytes.Buffer) {
+	var out bytes.Buffer
+	ctx := iocontext.WithIO(context.Background(), &iocontext.IO{Out: &out, ErrOut: &bytes.Buffer{}})
+	return outfmt.NewContext(ctx, format), &out
+}
+
+func testWebhookSubscription() *api.WebhookSubscription {
+	return &api.WebhookSubscription{
+		ID:          "sub-1",
+		Object:      "user",
+		CallbackURL: "https://example.com/webhooks",
+		Fields:      []api.WebhookField{{Name: "mentions"}, {Name: "publishes"}},
+		Active:      true,
+		CreatedTime: "2026-01-02T03:04:05+0000",
+	}
+}
+
+// Every JSON key must have a table column and vice versa
+func TestViews_JSONAndTableParity(t *testing.T) {
+	views := []view{
+		webhookSubscriptionView{sub: testWebhookSubscription()},
+		locationView{loc: &api.Location{ID: "1", Name: "Cafe"}},
+	}
+
+	for _, v := range views {
+		m := viewMap(v)
+		fields := v.viewFields()
+		if len(m) != len(fields) {
+			t.Errorf("%T: %d JSON keys for %d fields (duplicate key?)", v, len(m), len(fields))
+		}
+		for _, field := range fields {
+			if viewHeader(field.Key) == "" || viewCell(field) == "" {
+				t.Errorf("%T: field %q has no table representation", v, field.Key)
+			}
+		}
+	}
+}
+
+func TestWriteViewList_JSON(t *testing.T) {
+	ctx, out := viewTestContext(outfmt.JSON)
+	views := []webhookSubscriptionView{{sub: testWebhookSubscription(), truncate: true}}
+
+	if err := writeViewList(ctx, views, nil, "none"); err != nil {
+		t.Fatalf("writeViewList: %v", err)
+	}
+
+	var got struct {
+		Data []map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out.String(), err)
+	}
+	if len(got.Data) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(got.Data))
+	}
+	item := got.Data[0]
+	if item["callback_url"] != "https://example.com/webhooks" {
+		t.Errorf("callback_url should not be truncated in JSON, got %v", item["callback_url"])
+	}
+	if fields, ok := item["fields"].([]any); !ok || len(fields) != 2 || fields[0] != "mentions" {
+		t.Errorf("unexpected fields %v", item["fields"])
+	}
+}
+
+func TestWriteViewList_Table(t *testing.T) {
+	ctx, out := viewTestContext(outfmt.Text)
+	views := []webhookSubscriptionView{{sub: testWebhookSubscription(), truncate: true}}
+
+	if err := writeViewList(ctx, views, nil, "none"); err != nil {
+		t.Fatalf("writeViewList: %v", err)
+	}
+
+	text := out.String()
+	for _, want := range []string{"ID", "OBJECT", "CALLBACK URL", "FIELDS", "ACTIVE", "CREATED TIME", "sub-1", "mentions, publishes", "yes"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("table missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestWriteViewList_Empty(t *testing.T) {
+	ctx, out := viewTestContext(outfmt.Text)
+
+	if err := writeViewList[locationView](ctx, nil, nil, "No locations found"); err != nil {
+		t.Fatalf("writeViewList: %v", err)
+	}
+	if !strings.Contains(out.String(), "No locations found") {
+		t.Errorf("expected empty message, got %q", out.String())
+	}
+}
+
+func TestWriteViewDetail_Text(t *testing.T) {
+	ctx, out := viewTestContext(outfmt.Text)
+
+	if err := writeViewDetail(ctx, locationView{loc: &api.Location{ID: "1", Name: "Cafe", PostalCode: "12345", Latitude: 1.5}}); err != nil {
+		t.Fatalf("writeViewDetail: %v", err)
+	}
+
+	text := out.String()
+	for _, want := range []string{"ID:", "Name:", "Cafe", "Postal code:", "12345", "1.500000", "Longitude:"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("detail missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestViewLabel(t *testing.T) {
+	tests := map[string]string{
+		"id":           "ID",
+		"callback_url": "Callback URL",
+		"created_time": "Created time",
+		"name":         "Name",
+	}
+	for key, want := range tests {
+		if got := viewLabel(key); got != want {
+			t.Errorf("viewLabel(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
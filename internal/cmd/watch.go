@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/events"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// Polling bounds of watch
+const (
+	defaultWatchMinInterval = 30 * time.Second
+	defaultWatchMaxInterval = 10 * time.Minute
+	// watchPageSize is how many recent mentions each poll compares with the
+	// seen list; more new mentions than this between two polls are missed
+	watchPageSize = 25
+	// watchTokenWarning is how close to expiry a token must get before watch
+	// warns: the client renews tokens well before, so one this close was not
+	watchTokenWarning = 24 * time.Hour
+	// webhookWatchInterval is how often the webhook event store is read;
+	// reading it costs no API calls
+	webhookWatchInterval = 2 * time.Second
+)
+
+// watchSleep waits between polls. It is replaced in tests.
+var watchSleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type watchOptions struct {
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	ShowMuted   bool
+}
+
+// NewWatchCmd builds the watch command.
+func NewWatchCmd(f *Factory) *cobra.Command {
+	opts := &watchOptions{MinInterval: defaultWatchMinInterval, MaxInterval: defaultWatchMaxInterval}
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Print new mentions as they arrive",
+		Long: `Poll for mentions of your account and print each new one, until interrupted.
+
+The polling interval adapts to activity: it doubles after every poll that
+finds nothing new, up to --max-interval, and drops back to --min-interval as
+soon as a new mention arrives. Quiet accounts use few API calls while busy
+ones are still followed closely.
+
+While 'threads webhooks serve' runs for an app subscribed to mentions, watch
+reads them from its event store instead of polling, and goes back to polling
+when the server stops.
+
+Mentions already printed are remembered in the "seen" cache, so restarting
+watch does not repeat them; 'threads cache clear seen' starts over. With
+--output json each mention is printed as one JSON object per line.
+
+Mentions from users or with keywords muted with 'threads mute' are not
+printed unless --show-muted is set; hooks still receive them.
+
+The access token is renewed a week before it expires, with a token_refreshed
+or token_refresh_failed event.`,
+		Example: `  threads watch
+  threads watch --min-interval 1m --max-interval 30m
+  threads watch -o json | jq -r .permalink`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatch(cmd, f, opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.MinInterval, "min-interval", opts.MinInterval, "Shortest wait between polls, used while mentions keep arriving")
+	cmd.Flags().DurationVar(&opts.MaxInterval, "max-interval", opts.MaxInterval, "Longest wait between polls, reached while the account is quiet")
+	addShowMutedFlag(cmd, &opts.ShowMuted)
+	return cmd
+}
+
+func runWatch(cmd *cobra.Command, f *Factory, opts *watchOptions) error {
+	ctx := cmd.Context()
+
+	if opts.MinInterval < time.Second || opts.MaxInterval < opts.MinInterval {
+		return &UserFriendlyError{
+			Message:    fmt.Sprintf("Invalid polling interval: --min-interval %s, --max-interval %s", opts.MinInterval, opts.MaxInterval),
+			Suggestion: "Use a --min-interval of at least 1s and a --max-interval no shorter than it",
+		}
+	}
+
+	client, err := f.Client(ctx)
+	if err != nil {
+		return err
+	}
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return WrapError("failed to get user info", err)
+	}
+
+	seenPath := filepath.Join(cacheDir(), "seen", "mentions-"+me.ID+".json")
+	seen, err := readSeen(seenPath)
+	if err != nil {
+		return err
+	}
+	mutes, err := loadMutes(opts.ShowMuted)
+	if err != nil {
+		return err
+	}
+
+	io := iocontext.GetIO(ctx)
+	if !outfmt.IsJSON(ctx) {
+		fmt.Fprintf(io.ErrOut, "Watching mentions of @%s (Ctrl+C to stop)\n", me.Username) //nolint:errcheck // Best-effort output to stderr
+	}
+
+	// Printing is one subscriber among any others on the bus
+	defer f.Events.Subscribe(func(ctx context.Context, e events.Event) error {
+		mention := e.(events.NewMention).Post
+		if mutes.Match(&mention) != "" {
+			return nil
+		}
+		return writeMentions(ctx, []api.Post{mention})
+	}, events.TypeNewMention)()
+	defer f.Events.Subscribe(func(ctx context.Context, e events.Event) error {
+		expiring := e.(events.TokenExpiring)
+		return warn(ctx, &UserFriendlyError{
+			Message:    fmt.Sprintf("The access token of @%s expires on %s and was not renewed", expiring.Account, expiring.ExpiresAt.Format("2006-01-02 15:04")),
+			Suggestion: "Run 'threads auth refresh', or 'threads auth login' to re-authenticate",
+		})
+	}, events.TypeTokenExpiring)()
+	keeper := newTokenKeeper(f, f.currentAccountName(), client)
+	tokenWarned := false
+
+	interval := &adaptiveInterval{min: opts.MinInterval, max: opts.MaxInterval}
+	store := newWebhookEventStore(webhookEventsDir())
+	offset := store.size()
+	usingWebhooks := false
+	for {
+		var found []api.Post
+		var err error
+		source := events.SourceWebhook
+		if store.serving(string(api.WebhookEventMentions), time.Now()) {
+			if !usingWebhooks {
+				fmt.Fprintln(io.ErrOut, "Receiving mentions from 'threads webhooks serve'") //nolint:errcheck // Best-effort output to stderr
+				usingWebhooks = true
+			}
+			found, offset, err = readMentionEvents(store, offset, seen)
+		} else {
+			if usingWebhooks {
+				fmt.Fprintln(io.ErrOut, "The webhook server stopped; polling for mentions") //nolint:errcheck // Best-effort output to stderr
+				usingWebhooks = false
+			}
+			source = events.SourcePoll
+			found, err = pollMentions(ctx, client, api.UserID(me.ID), seen)
+			// Events stored meanwhile are covered by the poll
+			offset = store.size()
+		}
+
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case api.IsAuthenticationError(err) || api.IsValidationError(err):
+			return WrapError("failed to get mentions", err)
+		case err != nil:
+			// Transient failures are retried at the next, longer interval
+			fmt.Fprintf(io.ErrOut, "warning: failed to get mentions: %v\n", err) //nolint:errcheck // Best-effort output to stderr
+		case len(found) > 0:
+			for _, post := range found {
+				if err := f.Events.Publish(ctx, events.NewMention{Post: post, Source: source}); err != nil {
+					return err
+				}
+			}
+			if err := writeSeen(seenPath, seen); err != nil {
+				return err
+			}
+		}
+
+		if err := keeper.check(ctx, time.Now()); err != nil {
+			return err
+		}
+		if token := client.GetTokenInfo(); !tokenWarned && token != nil && !token.ExpiresAt.IsZero() && time.Until(token.ExpiresAt) < watchTokenWarning {
+			tokenWarned = true
+			if err := f.Events.Publish(ctx, events.TokenExpiring{Account: me.Username, ExpiresAt: token.ExpiresAt}); err != nil {
+				return err
+			}
+		}
+
+		wait := webhookWatchInterval
+		if !usingWebhooks {
+			wait = interval.next(len(found))
+		}
+		if err := watchSleep(ctx, wait); err != nil {
+			return nil
+		}
+	}
+}
+
+// adaptiveInterval is the wait between polls: it doubles after each poll
+// that finds nothing, up to max, and returns to min when one finds something
+type adaptiveInterval struct {
+	min, max time.Duration
+	current  time.Duration
+}
+
+func (a *adaptiveInterval) next(found int) time.Duration {
+	switch {
+	case found > 0 || a.current == 0:
+		a.current = a.min
+	default:
+		a.current = min(a.current*2, a.max)
+	}
+	return a.current
+}
+
+// pollMentions returns the recent mentions not in seen, oldest first, and
+// replaces seen with the IDs of the recent mentions
+func pollMentions(ctx context.Context, client api.API, userID api.UserID, seen map[string]bool) ([]api.Post, error) {
+	page, err := client.GetUserMentions(ctx, userID, &api.PaginationOptions{Limit: watchPageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	var found []api.Post
+	for i := len(page.Data) - 1; i >= 0; i-- {
+		if !seen[page.Data[i].ID] {
+			found = append(found, page.Data[i])
+		}
+	}
+	// Only the latest page needs remembering: older mentions never come back
+	clear(seen)
+	for _, post := range page.Data {
+		seen[post.ID] = true
+	}
+	return found, nil
+}
+
+// readMentionEvents returns the mentions stored after offset that are not in
+// seen, adding them to it, and the offset after them
+func readMentionEvents(store *webhookEventStore, offset int64, seen map[string]bool) ([]api.Post, int64, error) {
+	events, offset, err := store.readFrom(offset)
+	if err != nil {
+		return nil, offset, err
+	}
+	var found []api.Post
+	for _, event := range events {
+		if event.Mention == nil || event.Mention.ID == "" || seen[event.Mention.ID] {
+			continue
+		}
+		seen[event.Mention.ID] = true
+		found = append(found, event.Mention.Post())
+	}
+	return found, offset, nil
+}
+
+func writeMentions(ctx context.Context, posts []api.Post) error {
+	io := iocontext.GetIO(ctx)
+	if outfmt.IsJSON(ctx) {
+		enc := json.NewEncoder(io.Out)
+		for i := range posts {
+			if err := enc.Encode(&posts[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, post := range posts {
+		text := strings.ReplaceAll(post.Text, "\n", " ")
+		fmt.Fprintf(io.Out, "%s  @%s: %s\n  %s\n", post.Timestamp.Format("2006-01-02 15:04"), post.Username, text, post.Permalink) //nolint:errcheck // Best-effort output
+	}
+	return nil
+}
+
+// readSeen loads a seen list; a missing one is empty
+func readSeen(path string) (map[string]bool, error) {
+	seen := map[string]bool{}
+	var ids []string
+	err := statefile.ReadJSON(path, "seen", 1, &ids)
+	if errors.Is(err, os.ErrNotExist) {
+		return seen, nil
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		// A damaged list only means old mentions are printed again
+		return seen, nil
+	}
+	if err != nil {
+		return nil, WrapError("failed to read seen mentions", err)
+	}
+	for _, id := range ids {
+		seen[id] = true
+	}
+	return seen, nil
+}
+
+func writeSeen(path string, seen map[string]bool) error {
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	if err := statefile.WriteJSON(path, "seen", 1, ids); err != nil {
+		return WrapError("failed to save seen mentions", err)
+	}
+	return nil
+}
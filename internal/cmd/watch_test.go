@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+func TestAdaptiveInterval(t *testing.T) {
+	a := &adaptiveInterval{min: time.Minute, max: 5 * time.Minute}
+	var got []time.Duration
+	for _, found := range []int{0, 0, 0, 0, 0, 2, 0} {
+		got = append(got, a.next(found))
+	}
+	want := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute, time.Minute, 2 * time.Minute}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("intervals = %v, want %v", got, want)
+		}
+	}
+}
+
+// useWatchSleep records the waits of watch and stops it after polls polls
+func useWatchSleep(t *testing.T, cancel context.CancelFunc, polls int) *[]time.Duration {
+	t.Helper()
+	var waits []time.Duration
+	orig := watchSleep
+	watchSleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		if len(waits) == polls {
+			cancel()
+			return ctx.Err()
+		}
+		return nil
+	}
+	t.Cleanup(func() { watchSleep = orig })
+	return &waits
+}
+
+func TestWatch_AdaptsToNewMentions(t *testing.T) {
+	useTempCacheDir(t)
+	pages := [][]api.Post{
+		{{ID: "2", Username: "bob", Text: "hi @me"}, {ID: "1", Username: "ann", Text: "hello"}},
+		{{ID: "2"}, {ID: "1"}},
+		{{ID: "2"}, {ID: "1"}},
+		{{ID: "3", Username: "cat", Text: "ping"}, {ID: "2"}, {ID: "1"}},
+	}
+	polls := 0
+	mock := &mockAPI{
+		getMe: func(context.Context) (*api.User, error) {
+			return &api.User{ID: "me", Username: "me"}, nil
+		},
+		getMentions: func(_ context.Context, _ api.UserID, _ *api.PaginationOptions) (*api.PostsResponse, error) {
+			page := pages[min(polls, len(pages)-1)]
+			polls++
+			return &api.PostsResponse{Data: page}, nil
+		},
+	}
+	f, io := newMockAPITestFactory(t, mock)
+
+	ctx, cancel := context.WithCancel(iocontext.WithIO(context.Background(), io))
+	waits := useWatchSleep(t, cancel, len(pages))
+	cmd := NewWatchCmd(f)
+	cmd.SetArgs([]string{"--min-interval", "1m", "--max-interval", "3m"})
+	cmd.SetContext(ctx)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+
+	want := []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, time.Minute}
+	for i := range want {
+		if (*waits)[i] != want[i] {
+			t.Fatalf("waits = %v, want %v", *waits, want)
+		}
+	}
+	out := io.Out.(*bytes.Buffer).String()
+	ann, bob, cat := strings.Index(out, "@ann"), strings.Index(out, "@bob"), strings.Index(out, "@cat")
+	if ann < 0 || bob < ann || cat < bob {
+		t.Errorf("expected each mention once, oldest first, got:\n%s", out)
+	}
+
+	// A restarted watch remembers what it printed
+	io.Out.(*bytes.Buffer).Reset()
+	ctx, cancel = context.WithCancel(iocontext.WithIO(context.Background(), io))
+	useWatchSleep(t, cancel, 1)
+	cmd = NewWatchCmd(f)
+	cmd.SetContext(ctx)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+	if out := io.Out.(*bytes.Buffer).String(); out != "" {
+		t.Errorf("expected no repeated mentions, got:\n%s", out)
+	}
+}
+
+func TestWatch_InvalidInterval(t *testing.T) {
+	f, io := newMockAPITestFactory(t, &mockAPI{})
+	cmd := NewWatchCmd(f)
+	cmd.SetArgs([]string{"--min-interval", "10m", "--max-interval", "1m"})
+	cmd.SetContext(iocontext.WithIO(context.Background(), io))
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "Invalid polling interval") {
+		t.Errorf("expected an interval error, got %v", err)
+	}
+}
+
+func TestWatch_WarnsOnceAboutExpiringToken(t *testing.T) {
+	useTempCacheDir(t)
+	mock := &mockAPI{
+		getMe: func(context.Context) (*api.User, error) {
+			return &api.User{ID: "me", Username: "me"}, nil
+		},
+		getMentions: func(context.Context, api.UserID, *api.PaginationOptions) (*api.PostsResponse, error) {
+			return &api.PostsResponse{}, nil
+		},
+		tokenInfo: &api.TokenInfo{ExpiresAt: time.Now().Add(time.Hour)},
+	}
+	f, io := newMockAPITestFactory(t, mock)
+	ctx, cancel := context.WithCancel(iocontext.WithIO(context.Background(), io))
+	useWatchSleep(t, cancel, 3)
+
+	cmd := NewWatchCmd(f)
+	cmd.SetContext(ctx)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+	if got := strings.Count(io.ErrOut.(*bytes.Buffer).String(), "warning: The access token of @me expires"); got != 1 {
+		t.Errorf("expected one expiry warning, got %d:\n%s", got, io.ErrOut.(*bytes.Buffer).String())
+	}
+}
@@ -35,6 +35,8 @@ Your callback URL must be:
 	cmd.AddCommand(newWebhooksSubscribeCmd(f))
 	cmd.AddCommand(newWebhooksListCmd(f))
 	cmd.AddCommand(newWebhooksDeleteCmd(f))
+	cmd.AddCommand(newWebhooksSendTestCmd(f))
+	cmd.AddCommand(newWebhooksServeCmd(f))
 
 	return cmd
 }
@@ -47,8 +49,9 @@ func newWebhooksSubscribeCmd(f *Factory) *cobra.Command {
 	)
 
 	cmd := &cobra.Command{
-		Use:   "subscribe",
-		Short: "Subscribe to webhook events",
+		Use:         "subscribe",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Subscribe to webhook events",
 		Long: `Create a new webhook subscription to receive real-time notifications.
 
 Your callback URL must be HTTPS and publicly accessible. Meta will send a
@@ -120,17 +123,10 @@ Supported events:
 				return WrapError("failed to create webhook subscription", err)
 			}
 
-			io := iocontext.GetIO(ctx)
-			if outfmt.IsJSON(ctx) {
-				return outfmt.WriteJSONTo(io.Out, webhookSubscriptionToMap(subscription), outfmt.GetQuery(ctx))
+			if !outfmt.IsJSON(ctx) {
+				f.UI(ctx).Success("Webhook subscription created successfully!")
 			}
-
-			f.UI(ctx).Success("Webhook subscription created successfully!")
-			fmt.Fprintf(io.Out, "  Callback URL: %s\n", subscription.CallbackURL)                 //nolint:errcheck // Best-effort output
-			fmt.Fprintf(io.Out, "  Events:       %s\n", formatWebhookFields(subscription.Fields)) //nolint:errcheck // Best-effort output
-			fmt.Fprintf(io.Out, "  Active:       %v\n", subscription.Active)                      //nolint:errcheck // Best-effort output
-
-			return nil
+			return writeViewDetail(ctx, webhookSubscriptionView{sub: subscription})
 		},
 	}
 
@@ -169,39 +165,11 @@ func newWebhooksListCmd(f *Factory) *cobra.Command {
 				return WrapError("failed to list webhook subscriptions", err)
 			}
 
-			io := iocontext.GetIO(ctx)
-			if outfmt.IsJSON(ctx) {
-				return outfmt.WriteJSONTo(io.Out, result, outfmt.GetQuery(ctx))
-			}
-
-			out := outfmt.FromContext(ctx, outfmt.WithWriter(io.Out))
-			if len(result.Data) == 0 {
-				out.Empty("No webhook subscriptions found")
-				return nil
-			}
-
-			headers := []string{"OBJECT", "CALLBACK URL", "FIELDS", "ACTIVE"}
-			rows := make([][]string, len(result.Data))
-
-			for i, sub := range result.Data {
-				active := "no"
-				if sub.Active {
-					active = "yes"
-				}
-				rows[i] = []string{
-					sub.Object,
-					truncateURL(sub.CallbackURL, 40),
-					formatWebhookFields(sub.Fields),
-					active,
-				}
+			views := make([]webhookSubscriptionView, len(result.Data))
+			for i := range result.Data {
+				views[i] = webhookSubscriptionView{sub: &result.Data[i], truncate: true}
 			}
-
-			return out.Table(headers, rows, []outfmt.ColumnType{
-				outfmt.ColumnPlain,
-				outfmt.ColumnPlain,
-				outfmt.ColumnPlain,
-				outfmt.ColumnStatus,
-			})
+			return writeViewList(ctx, views, result.Paging, "No webhook subscriptions found")
 		},
 	}
 
@@ -210,8 +178,9 @@ func newWebhooksListCmd(f *Factory) *cobra.Command {
 
 func newWebhooksDeleteCmd(f *Factory) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "delete [subscription-id]",
-		Short: "Delete a webhook subscription",
+		Use:         "delete [subscription-id]",
+		Annotations: map[string]string{auditAnnotation: auditRecord},
+		Short:       "Delete a webhook subscription",
 		Long: `Delete a webhook subscription by its ID or object type.
 
 After deletion, your callback URL will no longer receive events for this subscription.`,
@@ -228,7 +197,11 @@ After deletion, your callback URL will no longer receive events for this subscri
 			io := iocontext.GetIO(ctx)
 			if !outfmt.GetYes(ctx) {
 				fmt.Fprintf(io.Out, "Webhook subscription to delete: %s\n\n", subscriptionID) //nolint:errcheck // Best-effort output
-				if !f.Confirm(ctx, "Delete this webhook subscription?") {
+				confirmed, err := f.Confirm(ctx, "Delete this webhook subscription?")
+				if err != nil {
+					return err
+				}
+				if !confirmed {
 					fmt.Fprintln(io.Out, "Cancelled.") //nolint:errcheck // Best-effort output
 					return nil
 				}
@@ -244,10 +217,10 @@ After deletion, your callback URL will no longer receive events for this subscri
 			}
 
 			if outfmt.IsJSON(ctx) {
-				return outfmt.WriteJSONTo(io.Out, map[string]any{
+				return outfmt.WriteJSONContext(ctx, io.Out, map[string]any{
 					"success": true,
 					"deleted": subscriptionID,
-				}, outfmt.GetQuery(ctx))
+				})
 			}
 
 			f.UI(ctx).Success("Webhook subscription deleted successfully")
@@ -258,36 +231,6 @@ After deletion, your callback URL will no longer receive events for this subscri
 	return cmd
 }
 
-// webhookSubscriptionToMap converts a WebhookSubscription to a map for JSON output
-func webhookSubscriptionToMap(sub *api.WebhookSubscription) map[string]any {
-	fields := make([]string, len(sub.Fields))
-	for i, f := range sub.Fields {
-		fields[i] = f.Name
-	}
-
-	return map[string]any{
-		"id":           sub.ID,
-		"object":       sub.Object,
-		"callback_url": sub.CallbackURL,
-		"fields":       fields,
-		"active":       sub.Active,
-		"created_time": sub.CreatedTime,
-	}
-}
-
-// formatWebhookFields formats webhook fields for display
-func formatWebhookFields(fields []api.WebhookField) string {
-	if len(fields) == 0 {
-		return "-"
-	}
-
-	names := make([]string, len(fields))
-	for i, f := range fields {
-		names[i] = f.Name
-	}
-	return strings.Join(names, ", ")
-}
-
 // truncateURL truncates a URL for display
 func truncateURL(url string, maxLen int) string {
 	if len(url) <= maxLen {
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/httpx"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+func runSendTestCmd(t *testing.T, f *Factory, io *iocontext.IO, format string, args ...string) error {
+	t.Helper()
+	cmd := newWebhooksSendTestCmd(f)
+	cmd.SetArgs(args)
+	cmd.SetContext(outfmt.WithFormat(iocontext.WithIO(context.Background(), io), format))
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return cmd.Execute()
+}
+
+func TestWebhooksSendTest_VerifiedByHandler(t *testing.T) {
+	received := &api.WebhookEvent{}
+	handler := httpx.WebhookHandler("test-client-secret", "verify", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body) //nolint:errcheck // Test handler
+		event, err := api.ParseWebhookEvent(body)
+		if err != nil {
+			t.Errorf("invalid payload: %v", err)
+			return
+		}
+		received = event
+	}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	f, out := newMockAPITestFactory(t, &mockAPI{})
+	if err := runSendTestCmd(t, f, out, "text", "--type", "mention", "--to", server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if received.Values.Field != "mentions" || received.TargetID != "12345" || received.AppID != "test-client-id" {
+		t.Errorf("unexpected event: %+v", received)
+	}
+	if received.Mention == nil || !strings.HasPrefix(received.Mention.Text, "@testuser ") {
+		t.Errorf("unexpected mention %+v", received.Mention)
+	}
+	if got := out.Out.(*bytes.Buffer).String(); !strings.Contains(got, "Delivered mentions test event") {
+		t.Errorf("unexpected output: %s", got)
+	}
+}
+
+func TestWebhooksSendTest_SignatureRejected(t *testing.T) {
+	t.Setenv("THREADS_CLIENT_SECRET", "other-secret")
+	server := httptest.NewServer(httpx.WebhookHandler("test-client-secret", "verify", http.NotFoundHandler()))
+	defer server.Close()
+
+	f, out := newMockAPITestFactory(t, &mockAPI{})
+	err := runSendTestCmd(t, f, out, "text", "--type", "publishes", "--to", server.URL)
+	if err == nil || !strings.Contains(err.Error(), "Handler answered 401") || !strings.Contains(err.Error(), "same app secret") {
+		t.Fatalf("expected signature error, got %v", err)
+	}
+}
+
+func TestWebhooksSendTest_DryRun(t *testing.T) {
+	f, out := newMockAPITestFactory(t, &mockAPI{})
+	if err := runSendTestCmd(t, f, out, "json", "--type", "deletes", "--to", "http://localhost:1", "--dry-run"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result struct {
+		Signature string `json:"signature"`
+		Body      string `json:"body"`
+	}
+	if err := json.Unmarshal(out.Out.(*bytes.Buffer).Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if !httpx.ValidSignature("test-client-secret", []byte(result.Body), result.Signature) {
+		t.Errorf("signature %q does not match body %s", result.Signature, result.Body)
+	}
+	if !strings.Contains(result.Body, `"field":"deletes"`) {
+		t.Errorf("unexpected body: %s", result.Body)
+	}
+}
+
+func TestWebhooksSendTest_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"unknown type", []string{"--type", "likes", "--to", "http://localhost:8080"}, "Invalid event type: likes"},
+		{"bad url", []string{"--type", "mentions", "--to", "localhost:8080"}, "Invalid --to URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, out := newMockAPITestFactory(t, &mockAPI{})
+			err := runSendTestCmd(t, f, out, "text", tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewWebhookTestEvent(t *testing.T) {
+	creds := testCredentials()
+	now := time.Date(2025, 7, 1, 14, 0, 0, 0, time.UTC)
+
+	var v map[string]string
+	publish := newWebhookTestEvent(api.WebhookEventPublishes, creds, "shipped", now)
+	if err := json.Unmarshal(publish.Values.Value, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v["username"] != "testuser" || v["text"] != "shipped" || v["timestamp"] != "2025-07-01T14:00:00+0000" {
+		t.Errorf("unexpected publish value: %+v", v)
+	}
+	if !strings.HasPrefix(v["permalink"], "https://www.threads.net/@testuser/post/TEST") {
+		t.Errorf("unexpected permalink %q", v["permalink"])
+	}
+
+	v = nil
+	deleted := newWebhookTestEvent(api.WebhookEventDeletes, creds, "", now)
+	if err := json.Unmarshal(deleted.Values.Value, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v["id"] == "" || v["text"] != "" || v["permalink"] != "" {
+		t.Errorf("unexpected delete value: %+v", v)
+	}
+}
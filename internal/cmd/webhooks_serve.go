@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/events"
+	"github.com/salmonumbrella/threads-cli/internal/httpx"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+	"github.com/salmonumbrella/threads-cli/internal/outfmt"
+)
+
+type webhooksServeOptions struct {
+	Addr        string
+	Path        string
+	VerifyToken string
+}
+
+func newWebhooksServeCmd(f *Factory) *cobra.Command {
+	opts := &webhooksServeOptions{Addr: "127.0.0.1:8080", Path: "/webhooks/threads"}
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Receive webhook deliveries and store them for watch",
+		Long: `Run a webhook endpoint that verifies deliveries with the app secret of the
+active account (or THREADS_CLIENT_SECRET) and appends each event to the local
+event store in the cache directory.
+
+While it runs, 'threads watch' reads mentions from the store instead of
+polling the API, if the app is subscribed to mentions. When the server stops,
+watch falls back to polling.
+
+Meta only delivers to public HTTPS URLs: put the server behind a reverse proxy
+or tunnel, and subscribe that URL with 'threads webhooks subscribe'.
+
+The access token of the account is renewed a week before it expires, with a
+token_refreshed or token_refresh_failed event.`,
+		Example: `  # Listen locally behind a tunnel
+  threads webhooks serve --verify-token my-secret
+
+  # Listen on all interfaces
+  threads webhooks serve --addr :8080 --path /hooks/threads`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			listener, err := net.Listen("tcp", opts.Addr)
+			if err != nil {
+				return &UserFriendlyError{
+					Message:    fmt.Sprintf("Cannot listen on %s", opts.Addr),
+					Suggestion: "Choose another address with --addr",
+					Cause:      err,
+				}
+			}
+			return runWebhooksServe(cmd.Context(), f, listener, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Addr, "addr", opts.Addr, "Address to listen on")
+	cmd.Flags().StringVar(&opts.Path, "path", opts.Path, "URL path of the endpoint")
+	cmd.Flags().StringVar(&opts.VerifyToken, "verify-token", "", "Token to answer subscription challenges with, as given to 'webhooks subscribe'")
+	return cmd
+}
+
+// runWebhooksServe serves deliveries on listener until ctx is done
+func runWebhooksServe(ctx context.Context, f *Factory, listener net.Listener, opts *webhooksServeOptions) error {
+	defer listener.Close() //nolint:errcheck // Also closed by Shutdown
+
+	creds, err := f.activeCredentials()
+	if err != nil {
+		return err
+	}
+	secret := webhookAppSecret(creds)
+	if secret == "" {
+		return &UserFriendlyError{
+			Message:    "No app secret to verify deliveries with",
+			Suggestion: "Log in again with 'threads auth login', or set THREADS_CLIENT_SECRET",
+		}
+	}
+
+	io := iocontext.GetIO(ctx)
+	store := newWebhookEventStore(webhookEventsDir())
+	state := &webhookServerState{
+		PID:     os.Getpid(),
+		Addr:    listener.Addr().String(),
+		Path:    opts.Path,
+		Fields:  subscribedWebhookFields(ctx, f),
+		Started: time.Now().UTC(),
+	}
+	if !slices.Contains(state.Fields, string(api.WebhookEventMentions)) {
+		fmt.Fprintln(io.ErrOut, "warning: the app is not subscribed to mentions, so watch keeps polling") //nolint:errcheck // Best-effort output to stderr
+		fmt.Fprintln(io.ErrOut, "hint: Subscribe with 'threads webhooks subscribe --event mentions'")     //nolint:errcheck // Best-effort output to stderr
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(opts.Path, httpx.WebhookHandler(secret, opts.VerifyToken, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body bytes.Buffer
+		if _, err := body.ReadFrom(r.Body); err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		event, err := api.ParseWebhookEvent(body.Bytes())
+		if err != nil {
+			http.Error(w, "invalid event", http.StatusBadRequest)
+			return
+		}
+		if err := store.append(event); err != nil {
+			http.Error(w, "failed to store event", http.StatusInternalServerError)
+			return
+		}
+		logWebhookEvent(ctx, event)
+		// The event is stored, so a failing subscriber must not cause a redelivery
+		if typed := busEvent(event); typed != nil {
+			typed, _ = f.labelReplyEvent(ctx, typed) //nolint:errcheck // Published unlabeled after the warning
+			f.Events.Publish(ctx, typed)             //nolint:errcheck,gosec // Subscribers report their own failures
+		}
+		w.WriteHeader(http.StatusOK)
+	})))
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	if err := heartbeat(store, state); err != nil {
+		return WrapError("failed to write the webhook server state", err)
+	}
+	// The server makes no API calls of its own that would renew the token
+	keepers := newTokenKeepers(f)
+	keepers.check(ctx, []string{""}, time.Now()) //nolint:errcheck,gosec // Failures are reported as warnings and events
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(webhookHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				server.Shutdown(shutdownCtx) //nolint:errcheck,gosec // Exiting anyway
+				return
+			case <-ticker.C:
+				heartbeat(store, state)                      //nolint:errcheck,gosec // Retried at the next tick
+				keepers.check(ctx, []string{""}, time.Now()) //nolint:errcheck,gosec // Reported like the one above
+			}
+		}
+	}()
+
+	if !outfmt.IsJSON(ctx) {
+		fmt.Fprintf(io.ErrOut, "Listening on http://%s%s, storing events in %s (Ctrl+C to stop)\n", state.Addr, opts.Path, store.dir) //nolint:errcheck // Best-effort output to stderr
+	}
+	err = server.Serve(listener)
+	<-done
+	if removeErr := store.removeState(); removeErr != nil && err == nil {
+		err = removeErr
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func heartbeat(store *webhookEventStore, state *webhookServerState) error {
+	state.Heartbeat = time.Now().UTC()
+	return store.writeState(state)
+}
+
+// subscribedWebhookFields lists the events the app's active subscriptions
+// deliver. It is advisory: when the subscriptions cannot be listed, none are
+// assumed and watch keeps polling.
+func subscribedWebhookFields(ctx context.Context, f *Factory) []string {
+	fields := []string{}
+	client, err := f.Client(ctx)
+	if err != nil {
+		return fields
+	}
+	subs, err := client.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		fmt.Fprintf(iocontext.GetIO(ctx).ErrOut, "warning: failed to list webhook subscriptions: %v\n", err) //nolint:errcheck // Best-effort output to stderr
+		return fields
+	}
+	for _, sub := range subs.Data {
+		if !sub.Active {
+			continue
+		}
+		for _, field := range sub.Fields {
+			if !slices.Contains(fields, field.Name) {
+				fields = append(fields, field.Name)
+			}
+		}
+	}
+	return fields
+}
+
+// logWebhookEvent prints a received event, as a JSON line with --output json
+func logWebhookEvent(ctx context.Context, event *api.WebhookEvent) {
+	out := iocontext.GetIO(ctx).Out
+	if outfmt.IsJSON(ctx) {
+		json.NewEncoder(out).Encode(event) //nolint:errcheck,gosec // Best-effort output
+		return
+	}
+	media := webhookMedia(event)
+	fmt.Fprintf(out, "%s  %s %s", time.Unix(event.Time, 0).Format("2006-01-02 15:04"), event.Values.Field, media.ID) //nolint:errcheck // Best-effort output
+	if media.Username != "" {
+		fmt.Fprintf(out, " @%s", media.Username) //nolint:errcheck // Best-effort output
+	}
+	fmt.Fprintln(out) //nolint:errcheck // Best-effort output
+}
+
+// webhookMedia returns the post an event is about. Fields ParseWebhookEvent
+// leaves untyped, such as deletes, are read from the raw value as far as
+// they match.
+func webhookMedia(event *api.WebhookEvent) api.WebhookMedia {
+	switch {
+	case event.Mention != nil:
+		return event.Mention.WebhookMedia
+	case event.Reply != nil:
+		return event.Reply.WebhookMedia
+	case event.Publish != nil:
+		return event.Publish.WebhookMedia
+	}
+	var media api.WebhookMedia
+	json.Unmarshal(event.Values.Value, &media) //nolint:errcheck,gosec // Only used for logging
+	return media
+}
+
+// busEvent converts a delivery to the event published on the bus, or nil
+// for fields without one
+func busEvent(event *api.WebhookEvent) events.Event {
+	switch {
+	case event.Mention != nil:
+		return events.NewMention{Post: event.Mention.Post(), Source: events.SourceWebhook}
+	case event.Publish != nil:
+		return events.PostPublished{Post: event.Publish.Post(), Source: events.SourceWebhook}
+	case event.Reply != nil:
+		return events.NewReply{Reply: event.Reply.Post(), Source: events.SourceWebhook}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/events"
+	"github.com/salmonumbrella/threads-cli/internal/httpx"
+	"github.com/salmonumbrella/threads-cli/internal/iocontext"
+)
+
+func postWebhookEvent(t *testing.T, url string, event *api.WebhookEvent, secret string) int {
+	t.Helper()
+	body, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(httpx.SignatureHeader, httpx.Sign(secret, body))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close() //nolint:errcheck,gosec // Test request
+	return resp.StatusCode
+}
+
+func TestWebhooksServe_StoresEvents(t *testing.T) {
+	dir := useTempCacheDir(t)
+	mock := &mockAPI{
+		listWebhooks: func(context.Context) (*api.WebhookSubscriptionsResponse, error) {
+			return &api.WebhookSubscriptionsResponse{Data: []api.WebhookSubscription{
+				{Active: true, Fields: []api.WebhookField{{Name: "mentions"}}},
+			}}, nil
+		},
+	}
+	f, io := newMockAPITestFactory(t, mock)
+	published := make(chan events.Event, 1)
+	f.Events.Subscribe(func(_ context.Context, e events.Event) error {
+		published <- e
+		return nil
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(iocontext.WithIO(context.Background(), io))
+	defer cancel()
+	served := make(chan error, 1)
+	go func() {
+		served <- runWebhooksServe(ctx, f, listener, &webhooksServeOptions{Path: "/hooks"})
+	}()
+
+	store := newWebhookEventStore(webhookEventsDir())
+	for deadline := time.Now().Add(5 * time.Second); !store.serving("mentions", time.Now()); {
+		if time.Now().After(deadline) {
+			t.Fatal("server never reported itself running")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	url := "http://" + listener.Addr().String() + "/hooks"
+	event := newWebhookTestEvent(api.WebhookEventMentions, testCredentials(), "@testuser hi", time.Now())
+	if status := postWebhookEvent(t, url, event, "wrong-secret"); status != http.StatusUnauthorized {
+		t.Errorf("expected an unsigned delivery to be rejected, got %d", status)
+	}
+	if status := postWebhookEvent(t, url, event, "test-client-secret"); status != http.StatusOK {
+		t.Fatalf("expected the delivery to be accepted, got %d", status)
+	}
+
+	select {
+	case e := <-published:
+		if m, ok := e.(events.NewMention); !ok || m.Post.ID != webhookMedia(event).ID || m.Source != events.SourceWebhook {
+			t.Errorf("unexpected event on the bus: %+v", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the delivery to be published on the bus")
+	}
+
+	seen := map[string]bool{}
+	found, offset, err := readMentionEvents(store, 0, seen)
+	if err != nil || len(found) != 1 || found[0].ID != webhookMedia(event).ID || found[0].Timestamp.IsZero() {
+		t.Fatalf("expected the stored mention, got %+v, %v", found, err)
+	}
+	if found, _, _ = readMentionEvents(store, offset, seen); len(found) != 0 {
+		t.Errorf("expected nothing after the offset, got %+v", found)
+	}
+
+	cancel()
+	if err := <-served; err != nil {
+		t.Fatalf("serve failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "events", webhookServerFile)); !os.IsNotExist(err) {
+		t.Errorf("expected the server state to be removed, got %v", err)
+	}
+}
+
+func TestWatch_ReadsWebhookEvents(t *testing.T) {
+	useTempCacheDir(t)
+	store := newWebhookEventStore(webhookEventsDir())
+	if err := store.writeState(&webhookServerState{Fields: []string{"mentions"}, Heartbeat: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := &mockAPI{
+		getMe: func(context.Context) (*api.User, error) {
+			return &api.User{ID: "me", Username: "me"}, nil
+		},
+		getMentions: func(context.Context, api.UserID, *api.PaginationOptions) (*api.PostsResponse, error) {
+			t.Error("expected no polling while the webhook server runs")
+			return &api.PostsResponse{}, nil
+		},
+	}
+	f, io := newMockAPITestFactory(t, mock)
+	ctx, cancel := context.WithCancel(iocontext.WithIO(context.Background(), io))
+	waits := useWatchSleep(t, cancel, 2)
+
+	// Only events stored after watch starts are new
+	old := newWebhookTestEvent(api.WebhookEventMentions, testCredentials(), "old mention", time.Now())
+	if err := store.append(old); err != nil {
+		t.Fatal(err)
+	}
+	// A mention arrives during the first wait
+	orig := watchSleep
+	watchSleep = func(ctx context.Context, d time.Duration) error {
+		event := newWebhookTestEvent(api.WebhookEventMentions, testCredentials(), "new mention", time.Now().Add(time.Second))
+		if err := store.append(event); err != nil {
+			t.Fatal(err)
+		}
+		watchSleep = orig
+		return orig(ctx, d)
+	}
+
+	cmd := NewWatchCmd(f)
+	cmd.SetContext(ctx)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+	if (*waits)[0] != webhookWatchInterval {
+		t.Errorf("expected the store to be read every %s, got %v", webhookWatchInterval, *waits)
+	}
+	out := io.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, "@"+webhookTestUsername+": new mention") || strings.Contains(out, "old mention") {
+		t.Errorf("expected only the new mention, got:\n%s", out)
+	}
+}
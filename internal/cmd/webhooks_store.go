@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// Files of the webhook event store
+const (
+	webhookEventsFile = "events.jsonl"
+	webhookServerFile = "server.json"
+)
+
+// webhookHeartbeat is how often 'webhooks serve' confirms it is running. A
+// server whose last heartbeat is three intervals old is taken to be gone.
+const webhookHeartbeat = 15 * time.Second
+
+// webhookEventsDir is the event store under the cache directory
+func webhookEventsDir() string {
+	return filepath.Join(cacheDir(), "events")
+}
+
+// webhookEventStore is where 'webhooks serve' appends the events it receives,
+// one JSON object per line, and reports that it is running. watch reads it
+// instead of polling while the server is up.
+type webhookEventStore struct {
+	dir string
+	mu  sync.Mutex // Serializes appends from concurrent deliveries
+}
+
+func newWebhookEventStore(dir string) *webhookEventStore {
+	return &webhookEventStore{dir: dir}
+}
+
+// webhookServerState describes a running 'webhooks serve'
+type webhookServerState struct {
+	PID  int    `json:"pid"`
+	Addr string `json:"addr"`
+	Path string `json:"path"`
+	// Fields are the events the app's subscriptions deliver to the server
+	Fields    []string  `json:"fields"`
+	Started   time.Time `json:"started"`
+	Heartbeat time.Time `json:"heartbeat"`
+}
+
+func (s *webhookEventStore) append(event *api.WebhookEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(s.dir, webhookEventsFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close() //nolint:errcheck,gosec // Already failing
+		return err
+	}
+	return file.Close()
+}
+
+// size is the offset after the last stored event
+func (s *webhookEventStore) size() int64 {
+	info, err := os.Stat(filepath.Join(s.dir, webhookEventsFile))
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// readFrom returns the events stored after offset and the offset after them.
+// A line still being written is left for the next read.
+func (s *webhookEventStore) readFrom(offset int64) ([]*api.WebhookEvent, int64, error) {
+	file, err := os.Open(filepath.Join(s.dir, webhookEventsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, offset, err
+	}
+	defer file.Close() //nolint:errcheck // Read-only
+
+	// The store was cleared since the last read
+	if info, err := file.Stat(); err == nil && info.Size() < offset {
+		offset = 0
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, err
+	}
+
+	var events []*api.WebhookEvent
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return events, offset, nil
+		}
+		if err != nil {
+			return events, offset, err
+		}
+		offset += int64(len(line))
+		if event, err := api.ParseWebhookEvent(line); err == nil {
+			events = append(events, event)
+		}
+	}
+}
+
+func (s *webhookEventStore) writeState(state *webhookServerState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return statefile.Write(filepath.Join(s.dir, webhookServerFile), append(data, '\n'), 0o600)
+}
+
+func (s *webhookEventStore) removeState() error {
+	err := os.Remove(filepath.Join(s.dir, webhookServerFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// serving reports whether a server with a recent heartbeat receives field
+// events
+func (s *webhookEventStore) serving(field string, now time.Time) bool {
+	data, err := os.ReadFile(filepath.Join(s.dir, webhookServerFile))
+	if err != nil {
+		return false
+	}
+	var state webhookServerState
+	if json.Unmarshal(data, &state) != nil {
+		return false
+	}
+	return now.Sub(state.Heartbeat) < 3*webhookHeartbeat && slices.Contains(state.Fields, field)
+}
@@ -34,6 +34,8 @@ func TestWebhooksCmd_Subcommands(t *testing.T) {
 		"subscribe": true,
 		"list":      true,
 		"delete":    true,
+		"send-test": true,
+		"serve":     true,
 	}
 
 	for _, sub := range cmd.Commands() {
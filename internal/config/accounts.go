@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AccountColors are the colors an account label can use.
+var AccountColors = []string{"red", "green", "yellow", "blue", "cyan", "gray"}
+
+// AccountSettings are per-account options kept in the config file under
+// "accounts", keyed by account name. They are not secret, so they live here
+// rather than in the keyring next to the token.
+type AccountSettings struct {
+	// Note is a free-form label such as "Brand account"
+	Note string `json:"note,omitempty"`
+	// Color is one of AccountColors
+	Color string `json:"color,omitempty"`
+	// Require lists text every post from the account must contain, e.g.
+	// "RELEASE:" on a work account
+	Require []string `json:"require,omitempty"`
+	// Forbid lists text no post from the account may contain
+	Forbid []string `json:"forbid,omitempty"`
+	// Timezone is used for dates and times typed without a zone, e.g.
+	// "Europe/Berlin"; empty means the system's local time
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// IsZero reports whether no setting is set.
+func (s AccountSettings) IsZero() bool {
+	return s.Note == "" && s.Color == "" && len(s.Require) == 0 && len(s.Forbid) == 0 && s.Timezone == ""
+}
+
+// CheckText returns the account's content rules that text breaks, as
+// human-readable descriptions. Matching is case-sensitive.
+func (s AccountSettings) CheckText(text string) []string {
+	var violations []string
+	for _, want := range s.Require {
+		if !strings.Contains(text, want) {
+			violations = append(violations, fmt.Sprintf("must contain %q", want))
+		}
+	}
+	for _, bad := range s.Forbid {
+		if strings.Contains(text, bad) {
+			violations = append(violations, fmt.Sprintf("must not contain %q", bad))
+		}
+	}
+	return violations
+}
+
+// ForAccount returns the settings of the named account; unknown accounts have none.
+func (c *Config) ForAccount(name string) AccountSettings {
+	if c == nil {
+		return AccountSettings{}
+	}
+	if s, ok := c.Accounts[name]; ok {
+		return s
+	}
+	return AccountSettings{}
+}
+
+// SetAccountSettings stores the settings of the named account, removing the
+// entry when s is empty. Blank rules are dropped.
+func (c *Config) SetAccountSettings(name string, s AccountSettings) {
+	s.Require = slices.DeleteFunc(slices.Clone(s.Require), isBlank)
+	s.Forbid = slices.DeleteFunc(slices.Clone(s.Forbid), isBlank)
+	if s.IsZero() {
+		delete(c.Accounts, name)
+		return
+	}
+	if c.Accounts == nil {
+		c.Accounts = make(map[string]AccountSettings)
+	}
+	c.Accounts[name] = s
+}
+
+func isBlank(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
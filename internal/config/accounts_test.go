@@ -0,0 +1,45 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAccountSettings_CheckText(t *testing.T) {
+	work := AccountSettings{Require: []string{"RELEASE:"}}
+	personal := AccountSettings{Forbid: []string{"RELEASE:"}}
+
+	tests := []struct {
+		name     string
+		settings AccountSettings
+		text     string
+		want     []string
+	}{
+		{"work ok", work, "RELEASE: v1.2.0 is out", nil},
+		{"work missing", work, "lunch was great", []string{`must contain "RELEASE:"`}},
+		{"personal ok", personal, "lunch was great", nil},
+		{"personal forbidden", personal, "RELEASE: v1.2.0", []string{`must not contain "RELEASE:"`}},
+		{"case-sensitive", personal, "release: notes", nil},
+		{"no rules", AccountSettings{Note: "Brand"}, "anything", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.CheckText(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckText(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetAccountSettings_DropsEmpty(t *testing.T) {
+	cfg := Default()
+	cfg.SetAccountSettings("work", AccountSettings{Require: []string{"RELEASE:", " "}})
+	if got := cfg.ForAccount("work").Require; !reflect.DeepEqual(got, []string{"RELEASE:"}) {
+		t.Errorf("Require = %v", got)
+	}
+
+	cfg.SetAccountSettings("work", AccountSettings{Require: []string{""}})
+	if _, ok := cfg.Accounts["work"]; ok {
+		t.Error("expected blank settings to remove the account entry")
+	}
+}
@@ -5,25 +5,67 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
-	"strconv"
+
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
 )
 
 const configFileName = "config.json"
 
+// Auth modes select which kind of token commands use.
+const (
+	// AuthModeUser uses the stored user token of the active account (default).
+	AuthModeUser = "user"
+	// AuthModeApp uses an app access token built from THREADS_CLIENT_ID and
+	// THREADS_CLIENT_SECRET, for tools that do not act as a user.
+	AuthModeApp = "app"
+)
+
 // Config represents user-configurable CLI defaults.
 type Config struct {
-	Account string `json:"account,omitempty"`
-	Output  string `json:"output,omitempty"` // text|json
-	Color   string `json:"color,omitempty"`  // auto|always|never
-	Debug   bool   `json:"debug,omitempty"`
+	Version  int    `json:"version,omitempty"`
+	Account  string `json:"account,omitempty"`
+	Output   string `json:"output,omitempty"` // text|json|ids|csv|tsv
+	Color    string `json:"color,omitempty"`  // auto|always|never
+	Debug    bool   `json:"debug,omitempty"`
+	Footer   bool   `json:"footer,omitempty"`
+	Strict   bool   `json:"strict,omitempty"`
+	AuthMode string `json:"auth_mode,omitempty"` // user|app
+	// CallbackHost and CallbackPort are where 'auth login' listens for the
+	// OAuth redirect
+	CallbackHost string `json:"callback_host,omitempty"`
+	CallbackPort int    `json:"callback_port,omitempty"`
+	// Signature is appended to the text of new posts; {version} is replaced
+	// by the CLI version
+	Signature string `json:"signature,omitempty"`
+	// Shortener is a URL template for shortening links; {url} is replaced by
+	// the query-escaped long URL and the response body is the short URL
+	Shortener string `json:"shortener,omitempty"`
+	// Classifier labels incoming replies: a command reading the reply as
+	// JSON on stdin, or an http(s) URL it is POSTed to
+	Classifier string `json:"classifier,omitempty"`
+	// Translator translates replies and mentions for --translate: a command
+	// reading {"text", "target"} as JSON on stdin, or an http(s) URL it is
+	// POSTed to
+	Translator string `json:"translator,omitempty"`
+	// Hooks maps event names to commands run when the event fires; see
+	// HookEvents
+	Hooks map[string]string `json:"hooks,omitempty"`
+	// Notify maps event names to comma-separated Slack, Discord or HTTP
+	// webhook URLs or mailto: addresses told when the event fires; see
+	// HookEvents. Keys email.host, email.port, email.from and
+	// email.username configure the SMTP server of the addresses.
+	Notify map[string]string `json:"notify,omitempty"`
+
+	Accounts map[string]AccountSettings `json:"accounts,omitempty"`
 }
 
 // Default returns a Config with default values.
 func Default() *Config {
 	return &Config{
-		Output: "text",
-		Color:  "auto",
-		Debug:  false,
+		Version: CurrentVersion,
+		Output:  "text",
+		Color:   "auto",
+		Debug:   false,
 	}
 }
 
@@ -46,6 +88,7 @@ func Load() (*Config, error) {
 }
 
 // LoadFile reads config from a specific path without applying env overrides.
+// Files written by older versions are migrated to CurrentVersion.
 func LoadFile(path string) (*Config, error) {
 	cfg := Default()
 	data, err := os.ReadFile(path)
@@ -56,6 +99,11 @@ func LoadFile(path string) (*Config, error) {
 		return nil, err
 	}
 
+	data, err = migrateFile(path, data)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := json.Unmarshal(data, cfg); err != nil {
 		return nil, err
 	}
@@ -69,32 +117,10 @@ func Save(cfg *Config) error {
 		return err
 	}
 	path := ConfigPath()
+	cfg.Version = CurrentVersion
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, data, 0o600)
-}
-
-// applyEnv overrides config values from environment variables.
-func applyEnv(cfg *Config) {
-	if val := os.Getenv("THREADS_ACCOUNT"); val != "" {
-		cfg.Account = val
-	}
-	if val := os.Getenv("THREADS_OUTPUT"); val != "" {
-		cfg.Output = val
-	}
-	if val := os.Getenv("THREADS_COLOR"); val != "" {
-		cfg.Color = val
-	}
-	if val := os.Getenv("THREADS_DEBUG"); val != "" {
-		if parsed, err := strconv.ParseBool(val); err == nil {
-			cfg.Debug = parsed
-		} else {
-			cfg.Debug = true
-		}
-	}
-	if os.Getenv("NO_COLOR") != "" {
-		cfg.Color = "never"
-	}
+	return statefile.Write(path, data, 0o600)
 }
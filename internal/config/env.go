@@ -0,0 +1,137 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// envPrefix is prepended to the upper-cased key to form a setting's environment variable.
+const envPrefix = "THREADS_"
+
+// Source says where the effective value of a setting came from.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// Setting is the effective value of one config key.
+type Setting struct {
+	Key    string `json:"key"`
+	Value  any    `json:"value"`
+	Source Source `json:"source"`
+	EnvVar string `json:"env_var"`
+}
+
+// EnvVar returns the environment variable that overrides the key, e.g. THREADS_AUTH_MODE.
+func (f Field) EnvVar() string {
+	return envPrefix + strings.ToUpper(f.Key)
+}
+
+// Resolve loads the config file at path and the environment and reports the
+// effective value of every settable key along with where it came from.
+// Command-line flags are not known here; callers layer them on top.
+func Resolve(path string) ([]Setting, error) {
+	cfg, err := LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	inFile := map[string]json.RawMessage{}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &inFile)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	var settings []Setting
+	for _, field := range Schema {
+		// Objects have no environment variable and are shown by 'config get'
+		if field.Managed || field.Type == FieldObject {
+			continue
+		}
+
+		setting := Setting{Key: field.Key, Source: SourceDefault, EnvVar: field.EnvVar()}
+		if _, ok := inFile[field.Key]; ok {
+			setting.Source = SourceFile
+		}
+		if applyEnvField(cfg, field) {
+			setting.Source = SourceEnv
+		}
+		if field.Key == "color" && os.Getenv("NO_COLOR") != "" {
+			cfg.Color = "never"
+			setting.Source = SourceEnv
+			setting.EnvVar = "NO_COLOR"
+		}
+
+		value, _ := fieldByKey(cfg, field.Key)
+		setting.Value = value.Interface()
+		if value.IsZero() && field.Default != nil {
+			setting.Value = field.Default
+		}
+		settings = append(settings, setting)
+	}
+	return settings, nil
+}
+
+// applyEnv overrides config values from environment variables. Every
+// settable key other than an object maps to THREADS_<KEY>; NO_COLOR also
+// disables color.
+func applyEnv(cfg *Config) {
+	for _, field := range Schema {
+		if !field.Managed && field.Type != FieldObject {
+			applyEnvField(cfg, field)
+		}
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		cfg.Color = "never"
+	}
+}
+
+// applyEnvField sets the key from its environment variable, reporting whether it was set
+func applyEnvField(cfg *Config, field Field) bool {
+	val := os.Getenv(field.EnvVar())
+	if val == "" {
+		return false
+	}
+	target, ok := fieldByKey(cfg, field.Key)
+	if !ok {
+		return false
+	}
+
+	switch field.Type {
+	case FieldBool:
+		// Any value that is not a recognised boolean turns the setting on
+		parsed, err := strconv.ParseBool(val)
+		target.SetBool(parsed || err != nil)
+	case FieldInt:
+		parsed, err := strconv.Atoi(val)
+		if err != nil {
+			return false
+		}
+		target.SetInt(int64(parsed))
+	default:
+		target.SetString(val)
+	}
+	return true
+}
+
+// fieldByKey returns the field of cfg whose JSON name is key
+func fieldByKey(cfg *Config, key string) (reflect.Value, bool) {
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == key {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSchema_KeysMapToConfigFields(t *testing.T) {
+	for _, field := range Schema {
+		if _, ok := fieldByKey(Default(), field.Key); !ok {
+			t.Errorf("schema key %q has no Config field", field.Key)
+		}
+	}
+}
+
+func TestApplyEnv_MapsEveryKey(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("THREADS_ACCOUNT", "work")
+	t.Setenv("THREADS_OUTPUT", "json")
+	t.Setenv("THREADS_COLOR", "always")
+	t.Setenv("THREADS_DEBUG", "on")
+	t.Setenv("THREADS_AUTH_MODE", "app")
+	t.Setenv("THREADS_CALLBACK_PORT", "9000")
+	t.Setenv("THREADS_VERSION", "7")
+
+	cfg := Default()
+	applyEnv(cfg)
+
+	if cfg.Account != "work" || cfg.Output != "json" || cfg.Color != "always" || cfg.AuthMode != AuthModeApp || cfg.CallbackPort != 9000 {
+		t.Errorf("unexpected config from env: %+v", cfg)
+	}
+	if !cfg.Debug {
+		t.Error("expected an unrecognised boolean to enable debug")
+	}
+	if cfg.Version != CurrentVersion {
+		t.Errorf("managed keys should not be read from the environment, got version %d", cfg.Version)
+	}
+}
+
+func TestResolve_Sources(t *testing.T) {
+	for _, field := range Schema {
+		t.Setenv(field.EnvVar(), "")
+	}
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("THREADS_OUTPUT", "json")
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"version":1,"account":"personal","output":"text"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := Resolve(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string]Setting)
+	for _, s := range settings {
+		got[s.Key] = s
+	}
+	want := map[string]struct {
+		value  any
+		source Source
+	}{
+		"account":       {"personal", SourceFile},
+		"output":        {"json", SourceEnv},
+		"color":         {"auto", SourceDefault},
+		"debug":         {false, SourceDefault},
+		"footer":        {false, SourceDefault},
+		"strict":        {false, SourceDefault},
+		"auth_mode":     {AuthModeUser, SourceDefault},
+		"callback_host": {"127.0.0.1", SourceDefault},
+		"callback_port": {8585, SourceDefault},
+		"signature":     {"", SourceDefault},
+		"shortener":     {"", SourceDefault},
+		"classifier":    {"", SourceDefault},
+		"translator":    {"", SourceDefault},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d settings, got %v", len(want), settings)
+	}
+	for key, w := range want {
+		if got[key].Value != w.value || got[key].Source != w.source {
+			t.Errorf("%s = %v (%s), want %v (%s)", key, got[key].Value, got[key].Source, w.value, w.source)
+		}
+	}
+	if got["auth_mode"].EnvVar != "THREADS_AUTH_MODE" {
+		t.Errorf("unexpected env var for auth_mode: %s", got["auth_mode"].EnvVar)
+	}
+}
+
+func TestResolve_NoColor(t *testing.T) {
+	t.Setenv("THREADS_COLOR", "")
+	t.Setenv("NO_COLOR", "1")
+
+	settings, err := Resolve(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range settings {
+		if s.Key == "color" && (s.Value != "never" || s.EnvVar != "NO_COLOR") {
+			t.Errorf("expected NO_COLOR to force color=never, got %+v", s)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// CurrentVersion is the config file format written by this version of the CLI.
+// Bump it and append a migration whenever a key is renamed or reshaped.
+const CurrentVersion = 1
+
+// migration upgrades a config file from version from to from+1. Steps work
+// on the raw JSON object so keys this version does not know are preserved.
+type migration struct {
+	from        int
+	description string
+	apply       func(raw map[string]json.RawMessage) error
+}
+
+// migrations must be ordered by from and cover every version below CurrentVersion.
+var migrations = []migration{
+	{from: 0, description: "record the config format version", apply: func(map[string]json.RawMessage) error { return nil }},
+}
+
+// renameKey returns a migration step that moves oldKey to newKey. An existing
+// newKey wins, since the user set it explicitly.
+func renameKey(oldKey, newKey string) func(raw map[string]json.RawMessage) error {
+	return func(raw map[string]json.RawMessage) error {
+		if value, ok := raw[oldKey]; ok {
+			if _, exists := raw[newKey]; !exists {
+				raw[newKey] = value
+			}
+			delete(raw, oldKey)
+		}
+		return nil
+	}
+}
+
+// fileVersion returns the format version recorded in raw, 0 if there is none
+func fileVersion(raw map[string]json.RawMessage) (int, error) {
+	value, ok := raw["version"]
+	if !ok {
+		return 0, nil
+	}
+	var version int
+	if err := json.Unmarshal(value, &version); err != nil {
+		return 0, fmt.Errorf("invalid config version %s: %w", value, err)
+	}
+	return version, nil
+}
+
+// migrate upgrades raw in place to target by applying steps in order. It
+// returns the version raw started at. Files newer than target are left alone.
+func migrate(raw map[string]json.RawMessage, steps []migration, target int) (int, error) {
+	from, err := fileVersion(raw)
+	if err != nil {
+		return 0, err
+	}
+
+	version := from
+	for _, step := range steps {
+		if version >= target {
+			break
+		}
+		if step.from != version {
+			continue
+		}
+		if err := step.apply(raw); err != nil {
+			return from, fmt.Errorf("config migration from version %d (%s) failed: %w", step.from, step.description, err)
+		}
+		version++
+		raw["version"] = json.RawMessage(fmt.Sprint(version))
+	}
+
+	if version < target {
+		return from, fmt.Errorf("no config migration from version %d", version)
+	}
+	return from, nil
+}
+
+// migrateFile upgrades the config file at path if it predates
+// CurrentVersion and returns the upgraded contents. The original file is
+// copied to ConfigDir before it is rewritten; if the backup cannot be
+// written, the file is left untouched and only the returned contents are
+// upgraded, so the migration is retried on the next load.
+func migrateFile(path string, data []byte) ([]byte, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		raw = map[string]json.RawMessage{}
+	}
+
+	from, err := migrate(raw, migrations, CurrentVersion)
+	if err != nil {
+		return nil, err
+	}
+	if from >= CurrentVersion {
+		return data, nil
+	}
+
+	migrated, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := backupConfig(path, data, from); err == nil {
+		_ = statefile.Write(path, migrated, 0o600)
+	}
+	return migrated, nil
+}
+
+// backupConfig writes the pre-migration contents of the config file to ConfigDir
+func backupConfig(path string, data []byte, version int) error {
+	if err := EnsureConfigDir(); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s.v%d-%s.bak", filepath.Base(path), version, time.Now().Format("20060102T150405"))
+	return statefile.Write(filepath.Join(ConfigDir(), name), data, 0o600)
+}
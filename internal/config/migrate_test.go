@@ -0,0 +1,119 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestMigrate_AppliesStepsInOrder(t *testing.T) {
+	raw := map[string]json.RawMessage{
+		"format": json.RawMessage(`"json"`),
+		"colour": json.RawMessage(`"never"`),
+		"color":  json.RawMessage(`"always"`),
+	}
+	steps := []migration{
+		{from: 0, description: "rename format", apply: renameKey("format", "output")},
+		{from: 1, description: "rename colour", apply: renameKey("colour", "color")},
+	}
+
+	from, err := migrate(raw, steps, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if from != 0 {
+		t.Errorf("expected to start at version 0, got %d", from)
+	}
+	if string(raw["output"]) != `"json"` {
+		t.Errorf("expected format renamed to output, got %s", raw["output"])
+	}
+	if string(raw["color"]) != `"always"` {
+		t.Errorf("expected explicit color to win over colour, got %s", raw["color"])
+	}
+	if _, ok := raw["colour"]; ok {
+		t.Error("expected colour to be removed")
+	}
+	if string(raw["version"]) != "2" {
+		t.Errorf("expected version 2, got %s", raw["version"])
+	}
+}
+
+func TestMigrate_MissingStep(t *testing.T) {
+	raw := map[string]json.RawMessage{"version": json.RawMessage("1")}
+	if _, err := migrate(raw, nil, 2); err == nil {
+		t.Error("expected error when no migration covers version 1")
+	}
+}
+
+func TestMigrate_NewerFileUntouched(t *testing.T) {
+	raw := map[string]json.RawMessage{"version": json.RawMessage("9")}
+	from, err := migrate(raw, migrations, CurrentVersion)
+	if err != nil || from != 9 {
+		t.Errorf("expected newer file to be left alone, got %d, %v", from, err)
+	}
+}
+
+func TestMigrations_CoverEveryVersion(t *testing.T) {
+	for version := 0; version < CurrentVersion; version++ {
+		found := false
+		for _, step := range migrations {
+			found = found || step.from == version
+		}
+		if !found {
+			t.Errorf("no migration from version %d", version)
+		}
+	}
+}
+
+func TestLoadFile_MigratesAndBacksUp(t *testing.T) {
+	if runtime.GOOS == "darwin" {
+		t.Skip("ConfigDir ignores XDG_CONFIG_HOME on macOS")
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	path := filepath.Join(ConfigDir(), configFileName)
+	if err := EnsureConfigDir(); err != nil {
+		t.Fatal(err)
+	}
+	original := []byte(`{"output":"json","future_key":"kept"}`)
+	if err := os.WriteFile(path, original, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Output != "json" || cfg.Version != CurrentVersion {
+		t.Errorf("unexpected config after migration: %+v", cfg)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"version": 1`) || !strings.Contains(string(data), "future_key") {
+		t.Errorf("expected migrated file to record the version and keep unknown keys, got %s", data)
+	}
+
+	backups, _ := filepath.Glob(filepath.Join(ConfigDir(), "config.json.v0-*.bak"))
+	if len(backups) != 1 {
+		t.Fatalf("expected one backup, got %v", backups)
+	}
+	backup, _ := os.ReadFile(backups[0])
+	if string(backup) != string(original) {
+		t.Errorf("backup = %s, want original contents", backup)
+	}
+
+	// A second load finds nothing to migrate
+	if _, err := LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	backups, _ = filepath.Glob(filepath.Join(ConfigDir(), "*.bak"))
+	if len(backups) != 1 {
+		t.Errorf("expected no further backups, got %v", backups)
+	}
+}
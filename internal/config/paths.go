@@ -8,40 +8,72 @@ import (
 
 const appName = "threads-cli"
 
+// goos is the platform directories are resolved for. It is replaced in tests.
+var goos = runtime.GOOS
+
 // ConfigDir returns the configuration directory path
 func ConfigDir() string {
-	if runtime.GOOS == "darwin" {
-		return filepath.Join(os.Getenv("HOME"), "Library", "Application Support", appName)
+	switch goos {
+	case "darwin":
+		return filepath.Join(homeDir(), "Library", "Application Support", appName)
+	case "windows":
+		return filepath.Join(windowsDir("APPDATA", "Roaming"), appName)
 	}
 	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
 		return filepath.Join(dir, appName)
 	}
-	return filepath.Join(os.Getenv("HOME"), ".config", appName)
+	return filepath.Join(homeDir(), ".config", appName)
 }
 
 // DataDir returns the data directory path
 func DataDir() string {
-	if runtime.GOOS == "darwin" {
-		return filepath.Join(os.Getenv("HOME"), "Library", "Application Support", appName)
+	switch goos {
+	case "darwin":
+		return filepath.Join(homeDir(), "Library", "Application Support", appName)
+	case "windows":
+		return filepath.Join(windowsDir("LOCALAPPDATA", "Local"), appName)
 	}
 	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
 		return filepath.Join(dir, appName)
 	}
-	return filepath.Join(os.Getenv("HOME"), ".local", "share", appName)
+	return filepath.Join(homeDir(), ".local", "share", appName)
 }
 
 // CacheDir returns the cache directory path
 func CacheDir() string {
-	if runtime.GOOS == "darwin" {
-		return filepath.Join(os.Getenv("HOME"), "Library", "Caches", appName)
+	switch goos {
+	case "darwin":
+		return filepath.Join(homeDir(), "Library", "Caches", appName)
+	case "windows":
+		// Data lives in %LOCALAPPDATA%\threads-cli already
+		return filepath.Join(windowsDir("LOCALAPPDATA", "Local"), appName, "Cache")
 	}
 	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
 		return filepath.Join(dir, appName)
 	}
-	return filepath.Join(os.Getenv("HOME"), ".cache", appName)
+	return filepath.Join(homeDir(), ".cache", appName)
 }
 
 // EnsureConfigDir creates the config directory if it doesn't exist
 func EnsureConfigDir() error {
 	return os.MkdirAll(ConfigDir(), 0o700)
 }
+
+// homeDir returns the user's home directory: $HOME, or %USERPROFILE% on
+// Windows, where HOME is usually unset
+func homeDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return home
+}
+
+// windowsDir returns the known folder in env, e.g. %APPDATA%, falling back
+// to its default location under AppData in the profile
+func windowsDir(env, fallback string) string {
+	if dir := os.Getenv(env); dir != "" {
+		return dir
+	}
+	return filepath.Join(homeDir(), "AppData", fallback)
+}
@@ -28,8 +28,8 @@ func TestConfigDir(t *testing.T) {
 }
 
 func TestConfigDir_XDGOverride(t *testing.T) {
-	if runtime.GOOS == "darwin" {
-		t.Skip("XDG override not used on macOS")
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("XDG override not used on macOS or Windows")
 	}
 
 	// Set custom XDG_CONFIG_HOME - t.Setenv restores automatically
@@ -44,8 +44,8 @@ func TestConfigDir_XDGOverride(t *testing.T) {
 }
 
 func TestConfigDir_DefaultFallback(t *testing.T) {
-	if runtime.GOOS == "darwin" {
-		t.Skip("fallback not used on macOS")
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("fallback not used on macOS or Windows")
 	}
 
 	// Unset XDG_CONFIG_HOME to test fallback - set to empty string
@@ -79,8 +79,8 @@ func TestDataDir(t *testing.T) {
 }
 
 func TestDataDir_XDGOverride(t *testing.T) {
-	if runtime.GOOS == "darwin" {
-		t.Skip("XDG override not used on macOS")
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("XDG override not used on macOS or Windows")
 	}
 
 	// Set custom XDG_DATA_HOME
@@ -95,8 +95,8 @@ func TestDataDir_XDGOverride(t *testing.T) {
 }
 
 func TestDataDir_DefaultFallback(t *testing.T) {
-	if runtime.GOOS == "darwin" {
-		t.Skip("fallback not used on macOS")
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("fallback not used on macOS or Windows")
 	}
 
 	// Unset XDG_DATA_HOME to test fallback
@@ -130,8 +130,8 @@ func TestCacheDir(t *testing.T) {
 }
 
 func TestCacheDir_XDGOverride(t *testing.T) {
-	if runtime.GOOS == "darwin" {
-		t.Skip("XDG override not used on macOS")
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("XDG override not used on macOS or Windows")
 	}
 
 	// Set custom XDG_CACHE_HOME
@@ -146,8 +146,8 @@ func TestCacheDir_XDGOverride(t *testing.T) {
 }
 
 func TestCacheDir_DefaultFallback(t *testing.T) {
-	if runtime.GOOS == "darwin" {
-		t.Skip("fallback not used on macOS")
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("fallback not used on macOS or Windows")
 	}
 
 	// Unset XDG_CACHE_HOME to test fallback
@@ -165,43 +165,75 @@ func TestEnsureConfigDir(t *testing.T) {
 	// Create a temp directory for testing
 	tmpDir := t.TempDir()
 
-	if runtime.GOOS == "darwin" {
+	var expectedDir string
+	switch runtime.GOOS {
+	case "darwin":
 		// On macOS, set HOME to temp directory
 		t.Setenv("HOME", tmpDir)
-		expectedDir := filepath.Join(tmpDir, "Library", "Application Support", appName)
+		expectedDir = filepath.Join(tmpDir, "Library", "Application Support", appName)
+	case "windows":
+		t.Setenv("APPDATA", tmpDir)
+		expectedDir = filepath.Join(tmpDir, appName)
+	default:
+		// On Linux, use XDG_CONFIG_HOME
+		t.Setenv("XDG_CONFIG_HOME", tmpDir)
+		expectedDir = filepath.Join(tmpDir, appName)
+	}
 
-		err := EnsureConfigDir()
-		if err != nil {
-			t.Fatalf("EnsureConfigDir failed: %v", err)
-		}
+	err := EnsureConfigDir()
+	if err != nil {
+		t.Fatalf("EnsureConfigDir failed: %v", err)
+	}
+
+	// Check directory was created
+	info, err := os.Stat(expectedDir)
+	if err != nil {
+		t.Fatalf("expected directory to exist at %q: %v", expectedDir, err)
+	}
+	if !info.IsDir() {
+		t.Errorf("expected %q to be a directory", expectedDir)
+	}
+}
 
-		// Check directory was created
-		info, err := os.Stat(expectedDir)
-		if err != nil {
-			t.Fatalf("expected directory to exist at %q: %v", expectedDir, err)
+func TestDirs_Windows(t *testing.T) {
+	orig := goos
+	goos = "windows"
+	t.Cleanup(func() { goos = orig })
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	// XDG variables are not consulted on Windows
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg-ignored")
+	t.Setenv("XDG_DATA_HOME", "/tmp/xdg-ignored")
+	t.Setenv("XDG_CACHE_HOME", "/tmp/xdg-ignored")
+
+	t.Run("known folders", func(t *testing.T) {
+		t.Setenv("APPDATA", filepath.Join(home, "Roaming"))
+		t.Setenv("LOCALAPPDATA", filepath.Join(home, "Local"))
+
+		if got, want := ConfigDir(), filepath.Join(home, "Roaming", appName); got != want {
+			t.Errorf("ConfigDir() = %q, want %q", got, want)
 		}
-		if !info.IsDir() {
-			t.Errorf("expected %q to be a directory", expectedDir)
+		if got, want := DataDir(), filepath.Join(home, "Local", appName); got != want {
+			t.Errorf("DataDir() = %q, want %q", got, want)
 		}
-	} else {
-		// On Linux, use XDG_CONFIG_HOME
-		t.Setenv("XDG_CONFIG_HOME", tmpDir)
-		expectedDir := filepath.Join(tmpDir, appName)
-
-		err := EnsureConfigDir()
-		if err != nil {
-			t.Fatalf("EnsureConfigDir failed: %v", err)
+		if got, want := CacheDir(), filepath.Join(home, "Local", appName, "Cache"); got != want {
+			t.Errorf("CacheDir() = %q, want %q", got, want)
 		}
+	})
 
-		// Check directory was created
-		info, err := os.Stat(expectedDir)
-		if err != nil {
-			t.Fatalf("expected directory to exist at %q: %v", expectedDir, err)
+	t.Run("unset known folders", func(t *testing.T) {
+		t.Setenv("APPDATA", "")
+		t.Setenv("LOCALAPPDATA", "")
+
+		if got, want := ConfigDir(), filepath.Join(home, "AppData", "Roaming", appName); got != want {
+			t.Errorf("ConfigDir() = %q, want %q", got, want)
 		}
-		if !info.IsDir() {
-			t.Errorf("expected %q to be a directory", expectedDir)
+		if got, want := CacheDir(), filepath.Join(home, "AppData", "Local", appName, "Cache"); got != want {
+			t.Errorf("CacheDir() = %q, want %q", got, want)
 		}
-	}
+	})
 }
 
 func TestAppNameConstant(t *testing.T) {
@@ -0,0 +1,350 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/salmonumbrella/threads-cli/internal/notify"
+)
+
+// FieldType is the JSON type of a config value.
+type FieldType string
+
+const (
+	FieldString FieldType = "string"
+	FieldBool   FieldType = "bool"
+	FieldInt    FieldType = "int"
+	FieldObject FieldType = "object"
+)
+
+// Field describes one key of the config file.
+type Field struct {
+	Key         string
+	Type        FieldType
+	Enum        []string // allowed values; empty allows any value of Type
+	Default     any
+	Description string
+	// Deprecated, if set, is a migration hint shown when the key is used.
+	Deprecated string
+	// Managed keys are maintained by the CLI and cannot be set by users.
+	Managed bool
+}
+
+// Schema lists every key the config file may contain.
+var Schema = []Field{
+	{Key: "version", Type: FieldInt, Default: CurrentVersion, Description: "Config file format version", Managed: true},
+	{Key: "account", Type: FieldString, Description: "Account used when --account is not given"},
+	{Key: "output", Type: FieldString, Enum: []string{"text", "json", "ids", "csv", "tsv"}, Default: "text", Description: "Output format"},
+	{Key: "color", Type: FieldString, Enum: []string{"auto", "always", "never"}, Default: "auto", Description: "Color mode"},
+	{Key: "debug", Type: FieldBool, Default: false, Description: "Enable debug output"},
+	{Key: "footer", Type: FieldBool, Default: false, Description: "Print a paging summary after list output"},
+	{Key: "strict", Type: FieldBool, Default: false, Description: "Fail on warnings and never prompt, for scripts and CI"},
+	{Key: "auth_mode", Type: FieldString, Enum: []string{AuthModeUser, AuthModeApp}, Default: AuthModeUser, Description: "Token used for API calls"},
+	{Key: "callback_host", Type: FieldString, Default: "127.0.0.1", Description: "Host 'auth login' listens on for the OAuth redirect"},
+	{Key: "callback_port", Type: FieldInt, Default: 8585, Description: "Port 'auth login' listens on for the OAuth redirect"},
+	{Key: "signature", Type: FieldString, Description: "Footer appended to new posts, e.g. \"posted via threads-cli {version}\""},
+	{Key: "shortener", Type: FieldString, Description: "URL shortener endpoint for --short, with {url} for the link to shorten"},
+	{Key: "classifier", Type: FieldString, Description: "Command or http(s) URL that labels replies, e.g. positive, negative, question or spam"},
+	{Key: "translator", Type: FieldString, Description: "Command or http(s) URL that translates replies and mentions for --translate"},
+	{Key: "hooks", Type: FieldObject, Description: "Commands run on events, set one at a time as hooks.<event>"},
+	{Key: "notify", Type: FieldObject, Description: "Slack, Discord or HTTP webhook URLs or mailto: addresses told of events, set one at a time as notify.<event>, and the SMTP server as notify.email.<setting>"},
+	{Key: "accounts", Type: FieldObject, Description: "Per-account settings, managed with 'threads auth label'", Managed: true},
+}
+
+// HookEvents are the events hooks and notifiers can be configured for, the
+// types of the events package.
+var HookEvents = []string{"new_mention", "new_reply", "post_published", "publish_failed", "token_expiring", "token_refreshed", "token_refresh_failed"}
+
+// LookupField returns the schema entry for key.
+func LookupField(key string) (Field, bool) {
+	for _, field := range Schema {
+		if field.Key == key {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// Keys returns the names of the config keys users can set.
+func Keys() []string {
+	keys := make([]string, 0, len(Schema))
+	for _, field := range Schema {
+		if field.Deprecated == "" && !field.Managed {
+			keys = append(keys, field.Key)
+		}
+	}
+	return keys
+}
+
+// ValidateValue reports whether value is allowed for a string field. The
+// empty string is always allowed and means the key is unset.
+func (f Field) ValidateValue(value string) error {
+	if value == "" || len(f.Enum) == 0 || slices.Contains(f.Enum, value) {
+		return nil
+	}
+	return fmt.Errorf("invalid %s value %q (valid: %s)", f.Key, value, strings.Join(f.Enum, ", "))
+}
+
+// Severity ranks a lint issue.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a problem found by Lint.
+type Issue struct {
+	Key      string   `json:"key,omitempty"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Hint     string   `json:"hint,omitempty"`
+}
+
+func (i Issue) String() string {
+	s := string(i.Severity) + ": "
+	if i.Key != "" {
+		s += i.Key + ": "
+	}
+	s += i.Message
+	if i.Hint != "" {
+		s += " (" + i.Hint + ")"
+	}
+	return s
+}
+
+// HasErrors reports whether any issue is an error rather than a warning.
+func HasErrors(issues []Issue) bool {
+	return slices.ContainsFunc(issues, func(i Issue) bool { return i.Severity == SeverityError })
+}
+
+// LintFile checks the config file at path against Schema. A missing file has no issues.
+func LintFile(path string) ([]Issue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return Lint(data), nil
+}
+
+// Lint checks config file contents against Schema, reporting unknown keys,
+// values of the wrong type, invalid enum values and deprecated keys.
+func Lint(data []byte) []Issue {
+	return lint(data, Schema)
+}
+
+func lint(data []byte, schema []Field) []Issue {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return []Issue{{Severity: SeverityError, Message: "config file is not a valid JSON object: " + err.Error()}}
+	}
+
+	fields := make(map[string]Field, len(schema))
+	for _, field := range schema {
+		fields[field.Key] = field
+	}
+
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var issues []Issue
+	for _, key := range keys {
+		field, ok := fields[key]
+		if !ok {
+			issue := Issue{Key: key, Severity: SeverityError, Message: "unknown key"}
+			if closest := closestKey(key, schema); closest != "" {
+				issue.Hint = fmt.Sprintf("did you mean %q?", closest)
+			}
+			issues = append(issues, issue)
+			continue
+		}
+
+		if field.Deprecated != "" {
+			issues = append(issues, Issue{Key: key, Severity: SeverityWarning, Message: "deprecated", Hint: field.Deprecated})
+		}
+
+		if issue, ok := lintValue(field, raw[key]); !ok {
+			issues = append(issues, issue)
+		} else if key == "hooks" {
+			issues = append(issues, lintEvents(key, raw[key], "command", nil)...)
+		} else if key == "notify" {
+			events, email := splitNotifyEmail(raw[key])
+			issues = append(issues, lintEvents(key, events, "URL list", func(value string) error {
+				_, err := notify.ParseTargets(value)
+				return err
+			})...)
+			issues = append(issues, lintNotifyEmail(email)...)
+		}
+	}
+
+	if version, err := fileVersion(raw); err == nil && version > CurrentVersion {
+		issues = append(issues, Issue{Key: "version", Severity: SeverityWarning,
+			Message: fmt.Sprintf("config was written by a newer threads-cli (format %d, this version supports %d)", version, CurrentVersion),
+			Hint:    "upgrade threads-cli"})
+	}
+	return issues
+}
+
+// lintValue checks a single value against its field, returning false with an issue if it is invalid
+func lintValue(field Field, value json.RawMessage) (Issue, bool) {
+	switch field.Type {
+	case FieldBool:
+		var b bool
+		if err := json.Unmarshal(value, &b); err != nil {
+			return Issue{Key: field.Key, Severity: SeverityError, Message: fmt.Sprintf("expected a boolean, got %s", value), Hint: "use true or false"}, false
+		}
+	case FieldInt:
+		var n int
+		if err := json.Unmarshal(value, &n); err != nil {
+			return Issue{Key: field.Key, Severity: SeverityError, Message: fmt.Sprintf("expected an integer, got %s", value)}, false
+		}
+	case FieldObject:
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(value, &m); err != nil {
+			return Issue{Key: field.Key, Severity: SeverityError, Message: fmt.Sprintf("expected an object, got %s", value)}, false
+		}
+	case FieldString:
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return Issue{Key: field.Key, Severity: SeverityError, Message: fmt.Sprintf("expected a string, got %s", value)}, false
+		}
+		if len(field.Enum) > 0 && s != "" && !slices.Contains(field.Enum, s) {
+			return Issue{Key: field.Key, Severity: SeverityError, Message: fmt.Sprintf("invalid value %q", s),
+				Hint: "valid values: " + strings.Join(field.Enum, ", ")}, false
+		}
+	}
+	return Issue{}, true
+}
+
+// lintEvents checks that every entry of the hooks or notify object at field
+// names a known event and is a string, described by what, that check
+// accepts if set
+func lintEvents(field string, value json.RawMessage, what string, check func(string) error) []Issue {
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(value, &entries); err != nil {
+		return nil
+	}
+	events := make([]string, 0, len(entries))
+	for event := range entries {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+
+	var issues []Issue
+	for _, event := range events {
+		key := field + "." + event
+		if !slices.Contains(HookEvents, event) {
+			issues = append(issues, Issue{Key: key, Severity: SeverityError, Message: "unknown event",
+				Hint: "valid events: " + strings.Join(HookEvents, ", ")})
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(entries[event], &s); err != nil {
+			issues = append(issues, Issue{Key: key, Severity: SeverityError, Message: fmt.Sprintf("expected a %s string, got %s", what, entries[event])})
+			continue
+		}
+		if check != nil {
+			if err := check(s); err != nil {
+				issues = append(issues, Issue{Key: key, Severity: SeverityError, Message: err.Error()})
+			}
+		}
+	}
+	return issues
+}
+
+// closestKey returns the schema key nearest to key, or "" if none is close
+func closestKey(key string, schema []Field) string {
+	best, bestDist := "", 3
+	for _, field := range schema {
+		if d := editDistance(strings.ToLower(key), field.Key); d < bestDist {
+			best, bestDist = field.Key, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// splitNotifyEmail separates the email.<name> SMTP settings of the notify
+// object from its events
+func splitNotifyEmail(value json.RawMessage) (json.RawMessage, map[string]json.RawMessage) {
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(value, &entries); err != nil {
+		return value, nil
+	}
+	email := map[string]json.RawMessage{}
+	for key, entry := range entries {
+		if name, ok := strings.CutPrefix(key, "email."); ok {
+			email[name] = entry
+			delete(entries, key)
+		}
+	}
+	events, err := json.Marshal(entries)
+	if err != nil {
+		return value, nil
+	}
+	return events, email
+}
+
+// lintNotifyEmail checks the SMTP settings of the notify object
+func lintNotifyEmail(settings map[string]json.RawMessage) []Issue {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var issues []Issue
+	for _, name := range names {
+		key := "notify.email." + name
+		if !slices.Contains(notify.EmailSettings, name) {
+			issue := Issue{Key: key, Severity: SeverityError, Message: "unknown email setting",
+				Hint: "valid settings: " + strings.Join(notify.EmailSettings, ", ")}
+			if name == "password" {
+				issue.Message, issue.Hint = "the SMTP password does not belong in the config file",
+					"remove it and run 'threads config set notify.email.password -' to keep it in the credential store"
+			}
+			issues = append(issues, issue)
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(settings[name], &s); err != nil {
+			issues = append(issues, Issue{Key: key, Severity: SeverityError, Message: fmt.Sprintf("expected a string, got %s", settings[name])})
+			continue
+		}
+		if err := notify.CheckEmailSetting(name, s); err != nil {
+			issues = append(issues, Issue{Key: key, Severity: SeverityError, Message: err.Error()})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLint_ValidConfig(t *testing.T) {
+	issues := Lint([]byte(`{"account":"work","output":"json","color":"never","debug":true,"auth_mode":"app"}`))
+	if len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+}
+
+func TestLint_ReportsProblems(t *testing.T) {
+	issues := Lint([]byte(`{"outptu":"json","color":"rainbow","debug":"yes","account":42}`))
+
+	want := map[string]string{
+		"account": "expected a string",
+		"color":   `invalid value "rainbow"`,
+		"debug":   "expected a boolean",
+		"outptu":  "unknown key",
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d issues, got %v", len(want), issues)
+	}
+	for _, issue := range issues {
+		if issue.Severity != SeverityError {
+			t.Errorf("expected error severity for %s, got %s", issue.Key, issue.Severity)
+		}
+		if !strings.Contains(issue.Message, want[issue.Key]) {
+			t.Errorf("issue for %s = %q, want it to contain %q", issue.Key, issue.Message, want[issue.Key])
+		}
+	}
+	if issues[3].Hint != `did you mean "output"?` {
+		t.Errorf("expected a suggestion for the misspelled key, got %q", issues[3].Hint)
+	}
+	if !HasErrors(issues) {
+		t.Error("expected HasErrors to be true")
+	}
+}
+
+func TestLint_Hooks(t *testing.T) {
+	issues := Lint([]byte(`{"hooks":{"post_published":"./notify.sh {{.id}}","new_mention":42,"on_like":"x"}}`))
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %v", issues)
+	}
+	if issues[0].Key != "hooks.new_mention" || !strings.Contains(issues[0].Message, "expected a command string") {
+		t.Errorf("unexpected issue for a non-string hook: %v", issues[0])
+	}
+	if issues[1].Key != "hooks.on_like" || issues[1].Message != "unknown event" {
+		t.Errorf("unexpected issue for an unknown event: %v", issues[1])
+	}
+}
+
+func TestLint_NotifyEmail(t *testing.T) {
+	issues := Lint([]byte(`{"notify":{"publish_failed":"mailto:ops@example.com","email.host":"smtp.example.com","email.port":"smtp","email.password":"x"}}`))
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %v", issues)
+	}
+	if issues[0].Key != "notify.email.password" || !strings.Contains(issues[0].Hint, "credential store") {
+		t.Errorf("unexpected issue for a stored password: %v", issues[0])
+	}
+	if issues[1].Key != "notify.email.port" || !strings.Contains(issues[1].Message, "not a port number") {
+		t.Errorf("unexpected issue for a bad port: %v", issues[1])
+	}
+}
+
+func TestLint_Deprecated(t *testing.T) {
+	schema := append([]Field{{Key: "format", Type: FieldString, Deprecated: "use output instead"}}, Schema...)
+
+	issues := lint([]byte(`{"format":"json"}`), schema)
+	if len(issues) != 1 || issues[0].Severity != SeverityWarning || issues[0].Hint != "use output instead" {
+		t.Fatalf("expected a deprecation warning, got %v", issues)
+	}
+	if HasErrors(issues) {
+		t.Error("deprecation warnings should not count as errors")
+	}
+}
+
+func TestLint_InvalidJSON(t *testing.T) {
+	issues := Lint([]byte(`{"output":`))
+	if len(issues) != 1 || issues[0].Severity != SeverityError {
+		t.Fatalf("expected a single error for invalid JSON, got %v", issues)
+	}
+}
+
+func TestLintFile_Missing(t *testing.T) {
+	issues, err := LintFile(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || issues != nil {
+		t.Errorf("expected no issues for a missing file, got %v, %v", issues, err)
+	}
+}
+
+func TestLintFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"auth_mode":"robot"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := LintFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Key != "auth_mode" {
+		t.Errorf("expected an auth_mode issue, got %v", issues)
+	}
+}
+
+func TestField_ValidateValue(t *testing.T) {
+	field, ok := LookupField("output")
+	if !ok {
+		t.Fatal("expected output in schema")
+	}
+	if err := field.ValidateValue("json"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := field.ValidateValue(""); err != nil {
+		t.Errorf("empty value should be allowed: %v", err)
+	}
+	if err := field.ValidateValue("xml"); err == nil {
+		t.Error("expected error for xml")
+	}
+}
+
+func TestLint_NewerVersion(t *testing.T) {
+	issues := Lint([]byte(`{"version":99}`))
+	if len(issues) != 1 || issues[0].Key != "version" || issues[0].Severity != SeverityWarning {
+		t.Errorf("expected a version warning, got %v", issues)
+	}
+	if keys := Keys(); strings.Contains(strings.Join(keys, ","), "version") {
+		t.Errorf("managed keys should not be listed as settable: %v", keys)
+	}
+}
@@ -0,0 +1,296 @@
+// Package demo generates a made-up Threads account, with an audience, posts
+// and conversations, and serves it as a read-only Graph API. The same seed
+// always generates the same data, so screenshots, talks and tests can use it
+// instead of real accounts:
+//
+//	data := demo.Generate(42, time.Now())
+//	server := httptest.NewServer(data.Handler())
+//
+// Point a client at the server and authenticate with any token; it belongs
+// to data.Account().
+package demo
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+)
+
+// User is a generated profile
+type User struct {
+	ID         string
+	Username   string
+	Name       string
+	Biography  string
+	PictureURL string
+	IsVerified bool
+	Followers  int
+}
+
+// Post is a generated post or reply
+type Post struct {
+	ID        string
+	Owner     *User
+	Text      string
+	MediaType string
+	MediaURL  string
+	AltText   string
+	TopicTag  string
+	Shortcode string
+	Timestamp time.Time
+	// Root is the top-level post of a reply's conversation and RepliedTo
+	// the post it answers; both are nil for top-level posts
+	Root      *Post
+	RepliedTo *Post
+	// Replies are the direct replies, oldest first
+	Replies []*Post
+
+	Views, Likes, Reposts, Quotes int
+}
+
+// Permalink returns the public URL of p
+func (p *Post) Permalink() string {
+	return fmt.Sprintf("https://www.threads.net/@%s/post/%s", p.Owner.Username, p.Shortcode)
+}
+
+// Data is a generated account with its audience
+type Data struct {
+	Seed int64
+	// Users are every profile; the first is the demo account
+	Users []*User
+	// Posts are the top-level posts of every user, newest first
+	Posts []*Post
+	// Replies are every reply at any depth, oldest first
+	Replies []*Post
+}
+
+// Account returns the user the demo token belongs to
+func (d *Data) Account() *User {
+	return d.Users[0]
+}
+
+const (
+	userCount = 12
+	// accountPosts is how many posts the demo account has; others have fewer
+	accountPosts = 15
+)
+
+var (
+	firstNames = []string{
+		"Maya", "Jonas", "Priya", "Teo", "Aiko", "Noah", "Leila", "Felix", "Zara", "Mateo",
+		"Ingrid", "Kwame", "Sofia", "Ravi", "Chloe", "Emeka", "Hana", "Lucas", "Amara", "Oskar",
+	}
+	lastNames = []string{
+		"Okafor", "Lindqvist", "Raman", "Herrera", "Tanaka", "Becker", "Haddad", "Moreau", "Novak", "Silva",
+		"Mensah", "Kowalski", "Ito", "Fischer", "Duarte", "Nakamura", "Osei", "Larsen", "Costa", "Varga",
+	}
+	biographies = []string{
+		"Building small tools for the terminal.",
+		"Designer. Type nerd. Occasional runner.",
+		"Coffee, code and long walks.",
+		"Writing about open source and the people behind it.",
+		"Photographer chasing morning light.",
+		"Product at a tiny startup. Opinions my own.",
+		"Baker on weekends, backend engineer on weekdays.",
+		"Trail runner and map enthusiast.",
+	}
+	topics = []string{
+		"the new release", "our design system", "a tiny CLI", "sourdough", "trail running",
+		"film photography", "the generics refactor", "keyboard shortcuts", "the team offsite",
+		"dark mode", "a weekend hackathon", "our onboarding docs", "bike commuting", "home espresso",
+	}
+	topicTags = []string{"DevTools", "Design", "Photography", "Running", "Coffee", "OpenSource"}
+	templates = []string{
+		"Finally shipped %s. Took longer than planned, but worth it.",
+		"Hot take: %s is underrated.",
+		"Anyone else spending the weekend on %s?",
+		"Three things I learned from %s this week 🧵",
+		"Morning thoughts on %s.",
+		"Small win today: %s is done.",
+		"What's your favorite resource for %s?",
+		"Honest question: how do you approach %s?",
+		"Wrote up some notes on %s. Feedback welcome!",
+	}
+	mentionTemplates = []string{
+		"Great chat with @%s about %s today.",
+		"@%s what did you end up deciding about %s?",
+		"Borrowed an idea from @%s for %s and it worked.",
+	}
+	replyTemplates = []string{
+		"Congrats! 🎉",
+		"This is great, thanks for sharing.",
+		"Does it work with %s yet?",
+		"Same here, %s has been on my list for ages.",
+		"Love this.",
+		"Saving this for later.",
+		"Curious how you handled %s.",
+		"Agreed, 100%.",
+		"Counterpoint: %s is overrated 😄",
+	}
+)
+
+// Generate returns the data for seed. Timestamps count back from now; every
+// other value depends only on seed.
+func Generate(seed int64, now time.Time) *Data {
+	g := &generator{
+		rng: rand.New(rand.NewPCG(uint64(seed), uint64(seed))), //nolint:gosec // Reproducible demo data, not secrets
+		ids: map[string]bool{},
+		now: now.UTC().Truncate(time.Second),
+	}
+	d := &Data{Seed: seed}
+
+	for _, i := range g.rng.Perm(len(firstNames) * len(lastNames))[:userCount] {
+		first, last := firstNames[i/len(lastNames)], lastNames[i%len(lastNames)]
+		user := &User{
+			ID:         g.id("1784"),
+			Username:   strings.ToLower(first + "." + last),
+			Name:       first + " " + last,
+			Biography:  biographies[g.rng.IntN(len(biographies))],
+			PictureURL: fmt.Sprintf("https://example.com/demo/avatars/%s.jpg", g.hex(8)),
+			IsVerified: g.rng.IntN(6) == 0,
+			Followers:  50 + g.rng.IntN(20000),
+		}
+		d.Users = append(d.Users, user)
+	}
+
+	account := d.Account()
+	for i, user := range d.Users {
+		count := 3 + g.rng.IntN(5)
+		if i == 0 {
+			count = accountPosts
+		}
+		at := g.now
+		for range count {
+			at = at.Add(-time.Duration(30+g.rng.IntN(3*24*60)) * time.Minute)
+			post := g.post(user, at)
+			topic := topics[g.rng.IntN(len(topics))]
+			post.Text = fmt.Sprintf(templates[g.rng.IntN(len(templates))], topic)
+			if i > 0 && g.rng.IntN(4) == 0 {
+				post.Text = fmt.Sprintf(mentionTemplates[g.rng.IntN(len(mentionTemplates))], account.Username, topic)
+			}
+			if g.rng.IntN(3) == 0 {
+				post.TopicTag = topicTags[g.rng.IntN(len(topicTags))]
+			}
+			if g.rng.IntN(5) == 0 {
+				post.MediaType = "IMAGE"
+				post.MediaURL = fmt.Sprintf("https://example.com/demo/media/%s.jpg", g.hex(8))
+				post.AltText = "Photo about " + topic
+			}
+			d.Posts = append(d.Posts, post)
+		}
+	}
+	sortNewestFirst(d.Posts)
+
+	for _, post := range d.Posts {
+		d.Replies = append(d.Replies, g.conversation(d, post)...)
+	}
+	sortOldestFirst(d.Replies)
+	return d
+}
+
+type generator struct {
+	rng *rand.Rand
+	ids map[string]bool
+	now time.Time
+}
+
+// id returns a new 17 digit ID starting with prefix
+func (g *generator) id(prefix string) string {
+	for {
+		id := prefix + fmt.Sprintf("%0*d", 17-len(prefix), g.rng.Int64N(pow10(17-len(prefix))))
+		if !g.ids[id] {
+			g.ids[id] = true
+			return id
+		}
+	}
+}
+
+func (g *generator) hex(n int) string {
+	const digits = "0123456789abcdef"
+	b := make([]byte, 2*n)
+	for i := range b {
+		b[i] = digits[g.rng.IntN(len(digits))]
+	}
+	return string(b)
+}
+
+func (g *generator) shortcode() string {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+	b := []byte("DQ")
+	for range 9 {
+		b = append(b, alphabet[g.rng.IntN(len(alphabet))])
+	}
+	return string(b)
+}
+
+func (g *generator) post(owner *User, at time.Time) *Post {
+	views := 100 + g.rng.IntN(owner.Followers/2+100)
+	return &Post{
+		ID:        g.id("18"),
+		Owner:     owner,
+		MediaType: "TEXT_POST",
+		Shortcode: g.shortcode(),
+		Timestamp: at,
+		Views:     views,
+		Likes:     views * (1 + g.rng.IntN(8)) / 100,
+		Reposts:   g.rng.IntN(views/200 + 1),
+		Quotes:    g.rng.IntN(views/500 + 1),
+	}
+}
+
+// conversation generates the replies under root, some answering each other
+func (g *generator) conversation(d *Data, root *Post) []*Post {
+	count := g.rng.IntN(7)
+	if root.Owner != d.Account() {
+		count /= 2
+	}
+	thread := []*Post{root}
+	var replies []*Post
+	at := root.Timestamp
+	for range count {
+		at = at.Add(time.Duration(1+g.rng.IntN(180)) * time.Minute)
+		if at.After(g.now) {
+			break
+		}
+		parent := root
+		if len(thread) > 1 && g.rng.IntN(3) == 0 {
+			parent = thread[1+g.rng.IntN(len(thread)-1)]
+		}
+		owner := d.Users[g.rng.IntN(len(d.Users))]
+		for owner == parent.Owner {
+			owner = d.Users[g.rng.IntN(len(d.Users))]
+		}
+		reply := g.post(owner, at)
+		reply.Views /= 10
+		reply.Likes /= 10
+		reply.Reposts, reply.Quotes = 0, 0
+		reply.Text = replyTemplates[g.rng.IntN(len(replyTemplates))]
+		if strings.Contains(reply.Text, "%s") {
+			reply.Text = fmt.Sprintf(reply.Text, topics[g.rng.IntN(len(topics))])
+		}
+		reply.Root = root
+		reply.RepliedTo = parent
+		parent.Replies = append(parent.Replies, reply)
+		thread = append(thread, reply)
+		replies = append(replies, reply)
+	}
+	return replies
+}
+
+func sortNewestFirst(posts []*Post) {
+	slices.SortStableFunc(posts, func(a, b *Post) int { return b.Timestamp.Compare(a.Timestamp) })
+}
+
+func sortOldestFirst(posts []*Post) {
+	slices.SortStableFunc(posts, func(a, b *Post) int { return a.Timestamp.Compare(b.Timestamp) })
+}
+
+func pow10(n int) int64 {
+	p := int64(1)
+	for range n {
+		p *= 10
+	}
+	return p
+}
@@ -0,0 +1,109 @@
+package demo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+var testNow = time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+
+func TestGenerate_Reproducible(t *testing.T) {
+	a, b := Generate(42, testNow), Generate(42, testNow)
+	if !reflect.DeepEqual(a, b) {
+		t.Error("expected the same data for the same seed")
+	}
+	if c := Generate(7, testNow); c.Account().Username == a.Account().Username && c.Posts[0].Text == a.Posts[0].Text {
+		t.Error("expected other data for another seed")
+	}
+}
+
+func TestGenerate_Shape(t *testing.T) {
+	d := Generate(42, testNow)
+	if len(d.Users) != userCount {
+		t.Errorf("expected %d users, got %d", userCount, len(d.Users))
+	}
+	if got := len(d.postsBy(d.Account())); got != accountPosts {
+		t.Errorf("expected %d posts by the account, got %d", accountPosts, got)
+	}
+	for i, post := range d.Posts {
+		if post.Timestamp.After(testNow) || (i > 0 && post.Timestamp.After(d.Posts[i-1].Timestamp)) {
+			t.Fatalf("posts are not newest first before now: %v", post.Timestamp)
+		}
+	}
+	if len(d.Replies) == 0 {
+		t.Fatal("expected replies")
+	}
+	for _, reply := range d.Replies {
+		if reply.Owner == reply.RepliedTo.Owner || reply.Timestamp.Before(reply.RepliedTo.Timestamp) {
+			t.Errorf("reply %s does not follow the post it answers", reply.ID)
+		}
+		if root := reply.RepliedTo; root.Root != nil && root.Root != reply.Root {
+			t.Errorf("reply %s is in another conversation than its parent", reply.ID)
+		}
+	}
+}
+
+func TestHandler_ServesTheClient(t *testing.T) {
+	d := Generate(42, time.Now())
+	server := httptest.NewServer(d.Handler())
+	defer server.Close()
+
+	client, err := api.NewClientWithToken("demo", &api.Config{ //nolint:staticcheck // Checks the demo answers debug_token
+		ClientID: "demo", ClientSecret: "demo", RedirectURI: "http://127.0.0.1/callback", BaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	me, err := client.GetMe(ctx)
+	if err != nil || me.Username != d.Account().Username {
+		t.Fatalf("GetMe = %+v, %v", me, err)
+	}
+
+	var listed int
+	opts := &api.PaginationOptions{Limit: 4}
+	for {
+		page, err := client.GetUserPosts(ctx, api.UserID(me.ID), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed += len(page.Data)
+		if page.Paging.Cursors == nil || page.Paging.Cursors.After == "" {
+			break
+		}
+		opts.After = page.Paging.Cursors.After
+	}
+	if listed != accountPosts {
+		t.Errorf("expected %d posts over all pages, got %d", accountPosts, listed)
+	}
+
+	root := d.Replies[0].Root
+	conversation, err := client.GetConversation(ctx, api.PostID(root.ID), nil)
+	if err != nil || len(conversation.Data) != len(d.conversationOf(root)) {
+		t.Errorf("GetConversation = %d replies, %v", len(conversation.Data), err)
+	}
+
+	insights, err := client.GetPostInsights(ctx, api.PostID(root.ID), []string{"views", "likes"})
+	if err != nil || len(insights.Data) != 2 || insights.Data[0].Values[0].Value != root.Views {
+		t.Errorf("GetPostInsights = %+v, %v", insights, err)
+	}
+
+	if err := client.DeletePost(ctx, api.PostID(root.ID)); err == nil {
+		t.Error("expected writes to be refused")
+	}
+}
+
+func TestHandler_UnknownObject(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Generate(42, testNow).Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/123", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
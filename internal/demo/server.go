@@ -0,0 +1,382 @@
+package demo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Handler serves d as a read-only Graph API. It answers the read endpoints
+// the CLI calls with the fields the real API returns, accepts any access
+// token and refuses every write.
+func (d *Data) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusBadRequest, 200, "The demo API is read-only")
+			return
+		}
+		query := r.URL.Query()
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if segments[0] == "me" {
+			segments[0] = d.Account().ID
+		}
+
+		switch {
+		case len(segments) == 1 && segments[0] == "debug_token":
+			writeJSON(w, d.debugToken())
+		case len(segments) == 1 && segments[0] == "profile_lookup":
+			if user := d.userByName(query.Get("username")); user != nil {
+				writeJSON(w, d.publicProfile(user))
+				return
+			}
+			writeError(w, http.StatusNotFound, 100, "Profile not found")
+		case len(segments) == 1 && segments[0] == "profile_posts":
+			if user := d.userByName(query.Get("username")); user != nil {
+				writeJSON(w, d.page(d.postsBy(user), query))
+				return
+			}
+			writeError(w, http.StatusNotFound, 100, "Profile not found")
+		case len(segments) == 1 && segments[0] == "keyword_search":
+			writeJSON(w, d.page(d.search(query.Get("q")), query))
+		case len(segments) == 1:
+			if user := d.user(segments[0]); user != nil {
+				writeJSON(w, userJSON(user))
+				return
+			}
+			if post := d.post(segments[0]); post != nil {
+				writeJSON(w, postJSON(post, d.Account()))
+				return
+			}
+			writeError(w, http.StatusNotFound, 100, "Object does not exist")
+		case len(segments) == 2:
+			d.serveEdge(w, segments[0], segments[1], query)
+		default:
+			writeError(w, http.StatusNotFound, 100, "Unsupported request")
+		}
+	})
+}
+
+// serveEdge serves the edge of the user or post with the given ID
+func (d *Data) serveEdge(w http.ResponseWriter, id, edge string, query map[string][]string) {
+	if user := d.user(id); user != nil {
+		switch edge {
+		case "threads":
+			writeJSON(w, d.page(d.postsBy(user), query))
+		case "replies":
+			writeJSON(w, d.page(d.repliesBy(user), query))
+		case "mentions":
+			writeJSON(w, d.page(d.mentionsOf(user), query))
+		case "threads_insights":
+			writeJSON(w, d.accountInsights(user, query))
+		case "threads_publishing_limit":
+			writeJSON(w, d.publishingLimit(user))
+		default:
+			writeError(w, http.StatusNotFound, 100, "Unsupported request")
+		}
+		return
+	}
+	if post := d.post(id); post != nil {
+		switch edge {
+		case "replies":
+			writeJSON(w, d.page(post.Replies, query))
+		case "conversation":
+			writeJSON(w, d.page(d.conversationOf(post), query))
+		case "insights":
+			writeJSON(w, postInsights(post, query))
+		default:
+			writeError(w, http.StatusNotFound, 100, "Unsupported request")
+		}
+		return
+	}
+	writeError(w, http.StatusNotFound, 100, "Object does not exist")
+}
+
+func (d *Data) user(id string) *User {
+	for _, user := range d.Users {
+		if user.ID == id {
+			return user
+		}
+	}
+	return nil
+}
+
+func (d *Data) userByName(username string) *User {
+	username = strings.TrimPrefix(username, "@")
+	for _, user := range d.Users {
+		if strings.EqualFold(user.Username, username) {
+			return user
+		}
+	}
+	return nil
+}
+
+func (d *Data) post(id string) *Post {
+	for _, posts := range [][]*Post{d.Posts, d.Replies} {
+		for _, post := range posts {
+			if post.ID == id {
+				return post
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Data) postsBy(user *User) []*Post {
+	return slices.DeleteFunc(slices.Clone(d.Posts), func(p *Post) bool { return p.Owner != user })
+}
+
+// repliesBy returns the replies user made, newest first
+func (d *Data) repliesBy(user *User) []*Post {
+	replies := slices.DeleteFunc(slices.Clone(d.Replies), func(p *Post) bool { return p.Owner != user })
+	sortNewestFirst(replies)
+	return replies
+}
+
+func (d *Data) mentionsOf(user *User) []*Post {
+	mention := "@" + user.Username
+	return slices.DeleteFunc(slices.Clone(d.Posts), func(p *Post) bool { return !strings.Contains(p.Text, mention) })
+}
+
+// conversationOf returns every reply under post, at any depth, oldest first
+func (d *Data) conversationOf(post *Post) []*Post {
+	return slices.DeleteFunc(slices.Clone(d.Replies), func(p *Post) bool {
+		for parent := p.RepliedTo; parent != nil; parent = parent.RepliedTo {
+			if parent == post {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (d *Data) search(q string) []*Post {
+	q = strings.ToLower(strings.TrimPrefix(q, "#"))
+	return slices.DeleteFunc(slices.Clone(d.Posts), func(p *Post) bool {
+		return q == "" || !strings.Contains(strings.ToLower(p.Text), q) && !strings.EqualFold(p.TopicTag, q)
+	})
+}
+
+func (d *Data) debugToken() any {
+	now := time.Now()
+	return map[string]any{"data": map[string]any{
+		"type":        "USER",
+		"application": "Threads CLI demo",
+		"is_valid":    true,
+		"issued_at":   now.Unix(),
+		"expires_at":  now.Add(60 * 24 * time.Hour).Unix(),
+		"scopes": []string{
+			"threads_basic", "threads_content_publish", "threads_manage_insights", "threads_manage_replies",
+			"threads_read_replies", "threads_manage_mentions", "threads_keyword_search", "threads_profile_discovery",
+		},
+		"user_id": d.Account().ID,
+	}}
+}
+
+func (d *Data) publicProfile(user *User) any {
+	profile := map[string]any{
+		"username":            user.Username,
+		"name":                user.Name,
+		"profile_picture_url": user.PictureURL,
+		"biography":           user.Biography,
+		"is_verified":         user.IsVerified,
+		"follower_count":      user.Followers,
+	}
+	totals := d.totals(user)
+	for _, metric := range []string{"likes", "quotes", "replies", "reposts", "views"} {
+		profile[metric+"_count"] = totals[metric]
+	}
+	return profile
+}
+
+func (d *Data) publishingLimit(user *User) any {
+	day := int((24 * time.Hour).Seconds())
+	var posts, replies int
+	since := time.Now().Add(-24 * time.Hour)
+	for _, post := range d.postsBy(user) {
+		if post.Timestamp.After(since) {
+			posts++
+		}
+	}
+	for _, reply := range d.repliesBy(user) {
+		if reply.Timestamp.After(since) {
+			replies++
+		}
+	}
+	config := func(total int) map[string]int { return map[string]int{"quota_total": total, "quota_duration": day} }
+	return map[string]any{"data": []map[string]any{{
+		"quota_usage":                 posts,
+		"config":                      config(250),
+		"reply_quota_usage":           replies,
+		"reply_config":                config(1000),
+		"delete_quota_usage":          0,
+		"delete_config":               config(100),
+		"location_search_quota_usage": 0,
+		"location_search_config":      config(500),
+	}}}
+}
+
+// totals sums the metrics of the posts of user
+func (d *Data) totals(user *User) map[string]int {
+	totals := map[string]int{}
+	for _, post := range d.postsBy(user) {
+		for metric, value := range metrics(post) {
+			totals[metric] += value
+		}
+	}
+	totals["followers_count"] = user.Followers
+	return totals
+}
+
+func (d *Data) accountInsights(user *User, query map[string][]string) any {
+	totals := d.totals(user)
+	var data []map[string]any
+	for _, metric := range requestedMetrics(query) {
+		data = append(data, map[string]any{
+			"name":        metric,
+			"period":      "day",
+			"title":       insightTitle(metric),
+			"total_value": map[string]int{"value": totals[metric]},
+			"id":          user.ID + "/insights/" + metric + "/day",
+		})
+	}
+	return map[string]any{"data": data}
+}
+
+func postInsights(post *Post, query map[string][]string) any {
+	values := metrics(post)
+	var data []map[string]any
+	for _, metric := range requestedMetrics(query) {
+		data = append(data, map[string]any{
+			"name":   metric,
+			"period": "lifetime",
+			"values": []map[string]int{{"value": values[metric]}},
+			"title":  insightTitle(metric),
+			"id":     post.ID + "/insights/" + metric + "/lifetime",
+		})
+	}
+	return map[string]any{"data": data}
+}
+
+func metrics(post *Post) map[string]int {
+	return map[string]int{
+		"views":   post.Views,
+		"likes":   post.Likes,
+		"replies": len(post.Replies),
+		"reposts": post.Reposts,
+		"quotes":  post.Quotes,
+		"shares":  0,
+	}
+}
+
+func requestedMetrics(query map[string][]string) []string {
+	if values := query["metric"]; len(values) > 0 && values[0] != "" {
+		return strings.Split(values[0], ",")
+	}
+	return []string{"views", "likes", "replies", "reposts", "quotes"}
+}
+
+func insightTitle(metric string) string {
+	if metric == "" {
+		return ""
+	}
+	title := strings.ReplaceAll(metric, "_", " ")
+	return strings.ToUpper(title[:1]) + title[1:]
+}
+
+func userJSON(user *User) any {
+	return map[string]any{
+		"id":                          user.ID,
+		"username":                    user.Username,
+		"name":                        user.Name,
+		"threads_profile_picture_url": user.PictureURL,
+		"threads_biography":           user.Biography,
+		"is_verified":                 user.IsVerified,
+	}
+}
+
+// postJSON returns post as the API does, as seen by account
+func postJSON(post *Post, account *User) map[string]any {
+	out := map[string]any{
+		"id":                 post.ID,
+		"media_product_type": "THREADS",
+		"media_type":         post.MediaType,
+		"permalink":          post.Permalink(),
+		"owner":              map[string]string{"id": post.Owner.ID},
+		"username":           post.Owner.Username,
+		"text":               post.Text,
+		"timestamp":          post.Timestamp.Format("2006-01-02T15:04:05+0000"),
+		"shortcode":          post.Shortcode,
+		"is_quote_post":      false,
+		"has_replies":        len(post.Replies) > 0,
+		"reply_audience":     "EVERYONE",
+	}
+	if post.MediaURL != "" {
+		out["media_url"] = post.MediaURL
+		out["alt_text"] = post.AltText
+	}
+	if post.TopicTag != "" {
+		out["topic_tag"] = post.TopicTag
+	}
+	if post.Root != nil {
+		out["root_post"] = map[string]string{"id": post.Root.ID}
+		out["replied_to"] = map[string]string{"id": post.RepliedTo.ID}
+		out["is_reply"] = true
+		out["is_reply_owned_by_me"] = post.Owner == account
+		out["hide_status"] = "NOT_HUSHED"
+	}
+	return out
+}
+
+// page returns the page of posts the limit and after parameters select,
+// with cursors that are the offsets of its ends
+func (d *Data) page(posts []*Post, query map[string][]string) any {
+	start, limit := 0, 25
+	if values := query["after"]; len(values) > 0 {
+		if decoded, err := base64.RawURLEncoding.DecodeString(values[0]); err == nil {
+			start, _ = strconv.Atoi(string(decoded))
+		}
+	}
+	if values := query["limit"]; len(values) > 0 {
+		if n, err := strconv.Atoi(values[0]); err == nil && n > 0 {
+			limit = n
+		}
+	}
+	start = min(max(start, 0), len(posts))
+	end := min(start+limit, len(posts))
+
+	data := make([]map[string]any, 0, end-start)
+	for _, post := range posts[start:end] {
+		data = append(data, postJSON(post, d.Account()))
+	}
+	out := map[string]any{"data": data}
+	if end > start {
+		cursors := map[string]string{"before": cursor(start)}
+		if end < len(posts) {
+			cursors["after"] = cursor(end)
+		}
+		out["paging"] = map[string]any{"cursors": cursors}
+	}
+	return out
+}
+
+func cursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v) //nolint:errcheck,gosec // The client sees a truncated body
+}
+
+// writeError writes a Graph API error
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck,gosec // The client sees a truncated body
+		"error": map[string]any{"message": message, "type": "OAuthException", "code": code},
+	})
+}
@@ -0,0 +1,177 @@
+// Package events is the event bus of long-running commands. Sources such as
+// watch and webhooks serve publish typed events; printers, rules and
+// notifiers subscribe to the types they handle, so new automation composes
+// with the existing sources instead of polling or parsing deliveries itself.
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+// Type names a kind of event.
+type Type string
+
+// Event types
+const (
+	TypeNewMention         Type = "new_mention"
+	TypeNewReply           Type = "new_reply"
+	TypePostPublished      Type = "post_published"
+	TypePublishFailed      Type = "publish_failed"
+	TypeTokenExpiring      Type = "token_expiring"
+	TypeTokenRefreshed     Type = "token_refreshed"
+	TypeTokenRefreshFailed Type = "token_refresh_failed"
+)
+
+// Event is implemented by every event published on a Bus.
+type Event interface {
+	Type() Type
+}
+
+// Source tells how an event was observed.
+type Source string
+
+// Event sources
+const (
+	SourcePoll    Source = "poll"
+	SourceWebhook Source = "webhook"
+	// SourceCommand is an event caused by the command itself, such as a post
+	// it published
+	SourceCommand Source = "command"
+	// SourceQueue is a post published from the local post queue
+	SourceQueue Source = "queue"
+)
+
+// NewMention is a post mentioning the account.
+type NewMention struct {
+	Post   api.Post `json:"post"`
+	Source Source   `json:"source"`
+}
+
+// NewReply is a reply to one of the account's posts.
+type NewReply struct {
+	Reply  api.Post `json:"reply"`
+	Source Source   `json:"source"`
+	// Labels are what the configured classifier tagged the reply with
+	Labels []string `json:"labels,omitempty"`
+}
+
+// PostPublished is a post the account published.
+type PostPublished struct {
+	Post   api.Post `json:"post"`
+	Source Source   `json:"source"`
+}
+
+// PublishFailed is a post that could not be published and will not be
+// tried again.
+type PublishFailed struct {
+	Text   string `json:"text"`
+	Error  string `json:"error"`
+	Source Source `json:"source"`
+	// QueueID is the queued post, for posts from the queue
+	QueueID string `json:"queue_id,omitempty"`
+	// Account is the stored account the post was for; empty is the default
+	Account string `json:"account,omitempty"`
+}
+
+// TokenExpiring warns that the access token of an account needs renewing.
+type TokenExpiring struct {
+	Account   string    `json:"account"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TokenRefreshed is an access token a long-running command renewed and
+// stored before it expired.
+type TokenRefreshed struct {
+	Account   string    `json:"account"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TokenRefreshFailed is an access token a long-running command could not
+// renew. ExpiresAt is when the current token expires.
+type TokenRefreshFailed struct {
+	Account   string    `json:"account"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Error     string    `json:"error"`
+}
+
+func (NewMention) Type() Type         { return TypeNewMention }
+func (NewReply) Type() Type           { return TypeNewReply }
+func (PostPublished) Type() Type      { return TypePostPublished }
+func (PublishFailed) Type() Type      { return TypePublishFailed }
+func (TokenExpiring) Type() Type      { return TypeTokenExpiring }
+func (TokenRefreshed) Type() Type     { return TypeTokenRefreshed }
+func (TokenRefreshFailed) Type() Type { return TypeTokenRefreshFailed }
+
+// Handler consumes events. A Handler that fails does not stop the others.
+type Handler func(ctx context.Context, e Event) error
+
+type subscription struct {
+	id      int
+	types   []Type
+	handler Handler
+}
+
+// Bus delivers published events to subscribers synchronously, in the order
+// they subscribed. The zero value is ready to use and a Bus is safe for
+// concurrent use.
+type Bus struct {
+	mu     sync.RWMutex
+	nextID int
+	subs   []subscription
+}
+
+// Subscribe registers h for events of the given types, or for all events
+// when none are given. The returned function removes the subscription.
+func (b *Bus) Subscribe(h Handler, types ...Type) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	id := b.nextID
+	b.subs = append(b.subs, subscription{id: id, types: types, handler: h})
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, sub := range b.subs {
+			if sub.id == id {
+				b.subs = append(b.subs[:i:i], b.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish hands e to every subscriber of its type and returns their errors
+// joined.
+func (b *Bus) Publish(ctx context.Context, e Event) error {
+	b.mu.RLock()
+	subs := make([]subscription, len(b.subs))
+	copy(subs, b.subs)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subs {
+		if sub.wants(e.Type()) {
+			if err := sub.handler(ctx, e); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s subscription) wants(t Type) bool {
+	if len(s.types) == 0 {
+		return true
+	}
+	for _, want := range s.types {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+func TestBus_DeliversByType(t *testing.T) {
+	var bus Bus
+	var got []string
+	bus.Subscribe(func(_ context.Context, e Event) error {
+		got = append(got, "mentions:"+e.(NewMention).Post.ID)
+		return nil
+	}, TypeNewMention)
+	bus.Subscribe(func(_ context.Context, e Event) error {
+		got = append(got, "all:"+string(e.Type()))
+		return nil
+	})
+
+	ctx := context.Background()
+	if err := bus.Publish(ctx, NewMention{Post: api.Post{ID: "1"}, Source: SourcePoll}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Publish(ctx, PostPublished{Post: api.Post{ID: "2"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"mentions:1", "all:new_mention", "all:post_published"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestBus_FailingHandlerDoesNotStopOthers(t *testing.T) {
+	var bus Bus
+	boom := errors.New("boom")
+	bus.Subscribe(func(context.Context, Event) error { return boom })
+	called := false
+	bus.Subscribe(func(context.Context, Event) error {
+		called = true
+		return nil
+	})
+
+	if err := bus.Publish(context.Background(), TokenExpiring{Account: "me"}); !errors.Is(err, boom) {
+		t.Errorf("expected the handler error, got %v", err)
+	}
+	if !called {
+		t.Error("expected the second handler to run")
+	}
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	var bus Bus
+	calls := 0
+	unsubscribe := bus.Subscribe(func(context.Context, Event) error {
+		calls++
+		return nil
+	})
+	ctx := context.Background()
+	_ = bus.Publish(ctx, NewReply{})
+	unsubscribe()
+	_ = bus.Publish(ctx, NewReply{})
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
@@ -0,0 +1,52 @@
+// Package filelock guards local state files that several processes of the
+// CLI may change at once, such as a daemon and a command run by hand.
+package filelock
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// wait is how long Lock waits for another process to release a lock
+	wait = 10 * time.Second
+	// stale is the age after which a lock is taken to be left behind by a
+	// process that died
+	stale = time.Minute
+)
+
+// ErrLocked is returned when a file stays locked by another process.
+var ErrLocked = errors.New("locked by another process")
+
+// Lock takes the lock of the file at path, a lock file next to it, waiting
+// for another process holding it. The returned function releases it.
+func Lock(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(wait)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600) //nolint:gosec // Path is chosen by the caller
+		if err == nil {
+			file.Close() //nolint:errcheck,gosec // Only its existence matters
+			return func() {
+				os.Remove(lockPath) //nolint:errcheck,gosec // A lock left behind goes stale
+			}, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > stale {
+			os.Remove(lockPath) //nolint:errcheck,gosec // Another process may have removed it first
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, &fs.PathError{Op: "lock", Path: path, Err: ErrLocked}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,79 @@
+package fixtures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PostIDVar in an Endpoint path is replaced by the ID of the first post an
+// earlier endpoint returned.
+const PostIDVar = "{post_id}"
+
+// Endpoint is an API call whose response is recorded as a fixture.
+type Endpoint struct {
+	// Name is the fixture name, e.g. "post" for testdata/post.json
+	Name   string
+	Path   string
+	Params url.Values
+}
+
+// FetchFunc performs a GET request against the API and returns the body.
+type FetchFunc func(ctx context.Context, path string, params url.Values) ([]byte, error)
+
+// Record fetches each endpoint in order, sanitizes the responses with s and
+// writes them to dir. It returns the paths written. Endpoints that need a
+// post ID are skipped when no earlier response contained a post.
+func Record(ctx context.Context, dir string, endpoints []Endpoint, fetch FetchFunc, s *Scrambler) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gosec // testdata is checked in
+		return nil, err
+	}
+
+	var postID string
+	var written []string
+	for _, ep := range endpoints {
+		endpointPath := ep.Path
+		if strings.Contains(endpointPath, PostIDVar) {
+			if postID == "" {
+				continue
+			}
+			endpointPath = strings.ReplaceAll(endpointPath, PostIDVar, postID)
+		}
+
+		body, err := fetch(ctx, endpointPath, ep.Params)
+		if err != nil {
+			return written, fmt.Errorf("%s: %w", ep.Name, err)
+		}
+		if postID == "" {
+			postID = firstDataID(body)
+		}
+
+		sanitized, err := s.Sanitize(body)
+		if err != nil {
+			return written, fmt.Errorf("%s: invalid JSON response: %w", ep.Name, err)
+		}
+		file := filepath.Join(dir, ep.Name+".json")
+		if err := os.WriteFile(file, sanitized, 0o644); err != nil { //nolint:gosec // testdata is checked in
+			return written, err
+		}
+		written = append(written, file)
+	}
+	return written, nil
+}
+
+// firstDataID returns data[0].id of a list response, or ""
+func firstDataID(body []byte) string {
+	var list struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(body, &list) != nil || len(list.Data) == 0 {
+		return ""
+	}
+	return list.Data[0].ID
+}
@@ -0,0 +1,124 @@
+package fixtures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	fetch := func(_ context.Context, path string, _ url.Values) ([]byte, error) {
+		paths = append(paths, path)
+		switch path {
+		case "/me/threads":
+			return []byte(`{"data":[{"id":"17890455123456789","username":"jane.doe"}]}`), nil
+		case "/17890455123456789":
+			return []byte(`{"id":"17890455123456789","username":"jane.doe"}`), nil
+		}
+		return nil, errors.New("unexpected path " + path)
+	}
+
+	endpoints := []Endpoint{
+		{Name: "user_posts", Path: "/me/threads"},
+		{Name: "post", Path: "/" + PostIDVar},
+	}
+	written, err := Record(context.Background(), dir, endpoints, fetch, newScrambler([]byte("seed")))
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	if want := []string{"/me/threads", "/17890455123456789"}; strings.Join(paths, " ") != strings.Join(want, " ") {
+		t.Errorf("fetched %v, want %v", paths, want)
+	}
+	if len(written) != 2 {
+		t.Fatalf("wrote %v, want 2 files", written)
+	}
+
+	var list struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	var post struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	}
+	readJSON(t, filepath.Join(dir, "user_posts.json"), &list)
+	readJSON(t, filepath.Join(dir, "post.json"), &post)
+	if post.ID == "17890455123456789" || post.Username == "jane.doe" {
+		t.Errorf("post.json not sanitized: %+v", post)
+	}
+	if len(list.Data) != 1 || list.Data[0].ID != post.ID {
+		t.Errorf("post IDs differ between fixtures: %+v vs %q", list.Data, post.ID)
+	}
+}
+
+func TestRecord_SkipsPostEndpointsWithoutPosts(t *testing.T) {
+	fetch := func(_ context.Context, path string, _ url.Values) ([]byte, error) {
+		if path != "/me/threads" {
+			t.Errorf("unexpected fetch of %s", path)
+		}
+		return []byte(`{"data":[]}`), nil
+	}
+	endpoints := []Endpoint{
+		{Name: "user_posts", Path: "/me/threads"},
+		{Name: "post", Path: "/" + PostIDVar},
+	}
+	written, err := Record(context.Background(), t.TempDir(), endpoints, fetch, NewScrambler())
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if len(written) != 1 {
+		t.Errorf("wrote %v, want only user_posts", written)
+	}
+}
+
+func TestRecord_FetchError(t *testing.T) {
+	fetch := func(context.Context, string, url.Values) ([]byte, error) {
+		return nil, errors.New("boom")
+	}
+	_, err := Record(context.Background(), t.TempDir(), []Endpoint{{Name: "me", Path: "/me"}}, fetch, NewScrambler())
+	if err == nil || !strings.Contains(err.Error(), "me: boom") {
+		t.Errorf("Record() error = %v, want the endpoint name and cause", err)
+	}
+}
+
+func TestFixtures_ValidJSON(t *testing.T) {
+	names := Names()
+	if len(names) == 0 {
+		t.Fatal("no fixtures embedded")
+	}
+	for _, name := range names {
+		var doc any
+		if err := json.Unmarshal(Get(name), &doc); err != nil {
+			t.Errorf("fixture %s is not valid JSON: %v", name, err)
+		}
+	}
+}
+
+func TestGet_MissingPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Get() of a missing fixture did not panic")
+		}
+	}()
+	Get("does_not_exist")
+}
+
+func readJSON(t *testing.T, path string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+}
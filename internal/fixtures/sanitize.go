@@ -0,0 +1,262 @@
+package fixtures
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Redacted replaces credentials in sanitized responses.
+const Redacted = "REDACTED"
+
+// SanitizedName replaces display names in sanitized responses.
+const SanitizedName = "Test User"
+
+// secretKeys are JSON keys whose values are credentials
+var secretKeys = map[string]bool{
+	"access_token":    true,
+	"refresh_token":   true,
+	"client_secret":   true,
+	"code":            true,
+	"appsecret_proof": true,
+}
+
+// minIdentifierLength is the shortest value collected for replacement
+// everywhere; shorter ones would match unrelated numbers and words
+const minIdentifierLength = 6
+
+// cursorKeys hold opaque paging cursors, which may encode IDs
+var cursorKeys = map[string]bool{
+	"before": true,
+	"after":  true,
+}
+
+var (
+	tokenParamPattern = regexp.MustCompile(`(access_token|appsecret_proof)=[^&"\s]+`)
+	mentionPattern    = regexp.MustCompile(`@[A-Za-z0-9._]+`)
+)
+
+// Scrambler replaces identifying values in API responses with fakes of the
+// same shape. Scrambling is deterministic for a Scrambler, so an ID shared by
+// two responses, or a username inside a permalink, stays consistent across a
+// recording; the random seed keeps the originals from being recovered by
+// hashing guesses.
+type Scrambler struct {
+	seed []byte
+	// known maps every original value seen so far to its replacement
+	known map[string]string
+	// fakes are the usernames handed out, which must not be scrambled again
+	fakes map[string]bool
+}
+
+// NewScrambler returns a Scrambler with a random seed.
+func NewScrambler() *Scrambler {
+	seed := make([]byte, 32)
+	rand.Read(seed) //nolint:errcheck,gosec // crypto/rand.Read never fails
+	return newScrambler(seed)
+}
+
+func newScrambler(seed []byte) *Scrambler {
+	return &Scrambler{seed: seed, known: make(map[string]string), fakes: make(map[string]bool)}
+}
+
+// Sanitize returns body with IDs, usernames, shortcodes and cursors
+// scrambled, display names replaced, media URLs pointed at example.com and
+// credentials redacted. The result is indented JSON.
+func (s *Scrambler) Sanitize(body []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	s.collect(doc)
+	doc = s.rewrite("", doc, nil)
+
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// collect records the identifying values in doc, so they are replaced
+// wherever they appear, including inside URLs and text
+func (s *Scrambler) collect(doc any) {
+	switch v := doc.(type) {
+	case map[string]any:
+		for key, value := range v {
+			str, ok := value.(string)
+			switch {
+			case !ok || str == "":
+			case key == "username":
+				// Usernames are replaced where they follow an @, as in
+				// mentions and permalinks; any length is identifying
+				s.remember("@"+str, "@"+s.fakeUsername(str))
+			case len(str) < minIdentifierLength:
+			case isIDKey(key):
+				// Composite IDs such as "<post-id>/insights/views/lifetime"
+				// contain the IDs of other objects
+				for _, part := range strings.Split(str, "/") {
+					if len(part) >= minIdentifierLength && isNumeric(part) {
+						s.remember(part, s.fakeID(part))
+					}
+				}
+			case key == "shortcode", cursorKeys[key]:
+				s.remember(str, s.fakeCode(str))
+			}
+			s.collect(value)
+		}
+	case []any:
+		for _, item := range v {
+			s.collect(item)
+		}
+	}
+}
+
+func (s *Scrambler) remember(original, fake string) {
+	s.known[original] = fake
+}
+
+func (s *Scrambler) rewrite(key string, doc any, parent map[string]any) any {
+	switch v := doc.(type) {
+	case map[string]any:
+		for k, value := range v {
+			v[k] = s.rewrite(k, value, v)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = s.rewrite(key, item, parent)
+		}
+		return v
+	case string:
+		return s.rewriteString(key, v, parent)
+	}
+	return doc
+}
+
+func (s *Scrambler) rewriteString(key, value string, parent map[string]any) string {
+	if value == "" {
+		return value
+	}
+	switch {
+	case secretKeys[key]:
+		return Redacted
+	case isIDKey(key) && isNumeric(value):
+		return s.fakeID(value)
+	case key == "username":
+		return s.fakeUsername(value)
+	case key == "shortcode", cursorKeys[key]:
+		return s.fakeCode(value)
+	case key == "name" && parent["username"] != nil:
+		return SanitizedName
+	case strings.HasSuffix(key, "_url") && key != "permalink":
+		return s.fakeMediaURL(value)
+	}
+
+	value = tokenParamPattern.ReplaceAllString(value, "${1}="+Redacted)
+	value = s.replaceKnown(value)
+	if key == "text" || key == "threads_biography" {
+		// Mentions of accounts that are not in the response itself
+		value = mentionPattern.ReplaceAllStringFunc(value, func(m string) string {
+			if s.fakes[m[1:]] {
+				return m
+			}
+			return "@" + s.fakeUsername(m[1:])
+		})
+	}
+	return value
+}
+
+// replaceKnown replaces every collected value inside s, longest first so an
+// ID is not partly replaced by a shorter one it contains
+func (s *Scrambler) replaceKnown(value string) string {
+	originals := make([]string, 0, len(s.known))
+	for original := range s.known {
+		originals = append(originals, original)
+	}
+	sort.Slice(originals, func(i, j int) bool {
+		if len(originals[i]) != len(originals[j]) {
+			return len(originals[i]) > len(originals[j])
+		}
+		return originals[i] < originals[j]
+	})
+	pairs := make([]string, 0, 2*len(originals))
+	for _, original := range originals {
+		pairs = append(pairs, original, s.known[original])
+	}
+	return strings.NewReplacer(pairs...).Replace(value)
+}
+
+// isIDKey reports whether key holds an ID: "id", "user_id", "target_id", ...
+func isIDKey(key string) bool {
+	return key == "id" || strings.HasSuffix(key, "_id")
+}
+
+func isNumeric(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
+
+// digest is the keyed hash fakes are derived from
+func (s *Scrambler) digest(kind, value string) []byte {
+	h := sha256.New()
+	h.Write(s.seed)        //nolint:errcheck // hash.Hash writes never fail
+	h.Write([]byte(kind))  //nolint:errcheck // hash.Hash writes never fail
+	h.Write([]byte{0})     //nolint:errcheck // hash.Hash writes never fail
+	h.Write([]byte(value)) //nolint:errcheck // hash.Hash writes never fail
+	return h.Sum(nil)
+}
+
+// fake returns n characters from alphabet derived from value
+func (s *Scrambler) fake(kind, value, alphabet string, n int) string {
+	var b strings.Builder
+	for i := 0; b.Len() < n; i++ {
+		for _, c := range s.digest(kind+string(rune('a'+i)), value) {
+			if b.Len() == n {
+				break
+			}
+			b.WriteByte(alphabet[int(c)%len(alphabet)])
+		}
+	}
+	return b.String()
+}
+
+// fakeID keeps the length of numeric IDs
+func (s *Scrambler) fakeID(id string) string {
+	fake := s.fake("id", id, "0123456789", len(id))
+	if fake[0] == '0' {
+		fake = "1" + fake[1:]
+	}
+	return fake
+}
+
+func (s *Scrambler) fakeUsername(username string) string {
+	fake := "user_" + s.fake("username", username, "abcdefghijklmnopqrstuvwxyz0123456789", 8)
+	s.fakes[fake] = true
+	return fake
+}
+
+// fakeCode scrambles shortcodes and cursors, keeping their length
+func (s *Scrambler) fakeCode(code string) string {
+	return s.fake("code", code, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789", len(code))
+}
+
+// fakeMediaURL points media at example.com, keeping the file extension
+func (s *Scrambler) fakeMediaURL(raw string) string {
+	ext := path.Ext(strings.SplitN(raw, "?", 2)[0])
+	if len(ext) > 5 {
+		ext = ""
+	}
+	return "https://example.com/media/" + hex.EncodeToString(s.digest("url", raw)[:8]) + ext
+}
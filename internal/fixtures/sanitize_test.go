@@ -0,0 +1,156 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const rawPosts = `{
+  "data": [
+    {
+      "id": "17890455123456789",
+      "username": "jane.doe",
+      "name": "Jane Doe",
+      "text": "Hello @bob_smith and @jane.doe",
+      "permalink": "https://www.threads.net/@jane.doe/post/C3xYz1AbCdE",
+      "shortcode": "C3xYz1AbCdE",
+      "media_url": "https://scontent.cdninstagram.com/v/t51/photo.jpg?stp=dst&oh=abc",
+      "owner": {"id": "12345678"}
+    },
+    {
+      "id": "17890455987654321",
+      "username": "bob_smith",
+      "text": "Reply to 17890455123456789",
+      "replied_to": {"id": "17890455123456789"}
+    }
+  ],
+  "paging": {
+    "cursors": {"before": "QVFIUabc", "after": "QVFIUxyz"},
+    "next": "https://graph.threads.net/v1.0/12345678/threads?access_token=THAAsecret&after=QVFIUxyz"
+  }
+}`
+
+func sanitize(t *testing.T, s *Scrambler, raw string) (string, map[string]any) {
+	t.Helper()
+	out, err := s.Sanitize([]byte(raw))
+	if err != nil {
+		t.Fatalf("Sanitize() error = %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("sanitized output is not JSON: %v\n%s", err, out)
+	}
+	return string(out), doc
+}
+
+func TestSanitize_RemovesOriginals(t *testing.T) {
+	out, _ := sanitize(t, newScrambler([]byte("seed")), rawPosts)
+
+	for _, original := range []string{
+		"17890455123456789", "17890455987654321", "12345678",
+		"jane.doe", "bob_smith", "Jane Doe", "C3xYz1AbCdE",
+		"QVFIUabc", "QVFIUxyz", "THAAsecret", "scontent",
+	} {
+		if strings.Contains(out, original) {
+			t.Errorf("sanitized output still contains %q:\n%s", original, out)
+		}
+	}
+	if !strings.Contains(out, "access_token="+Redacted) {
+		t.Errorf("access token not redacted:\n%s", out)
+	}
+}
+
+func TestSanitize_Consistent(t *testing.T) {
+	_, doc := sanitize(t, newScrambler([]byte("seed")), rawPosts)
+
+	data := doc["data"].([]any)
+	first := data[0].(map[string]any)
+	second := data[1].(map[string]any)
+
+	id := first["id"].(string)
+	if len(id) != len("17890455123456789") || !isNumeric(id) {
+		t.Errorf("id = %q, want 17 digits", id)
+	}
+	if got := second["replied_to"].(map[string]any)["id"]; got != id {
+		t.Errorf("replied_to.id = %v, want %v", got, id)
+	}
+	if got := second["text"]; got != "Reply to "+id {
+		t.Errorf("text = %q, want the scrambled ID", got)
+	}
+
+	username := first["username"].(string)
+	if !strings.HasPrefix(username, "user_") {
+		t.Errorf("username = %q, want user_ prefix", username)
+	}
+	shortcode := first["shortcode"].(string)
+	wantPermalink := "https://www.threads.net/@" + username + "/post/" + shortcode
+	if got := first["permalink"]; got != wantPermalink {
+		t.Errorf("permalink = %q, want %q", got, wantPermalink)
+	}
+	wantText := "Hello @" + second["username"].(string) + " and @" + username
+	if got := first["text"]; got != wantText {
+		t.Errorf("text = %q, want %q", got, wantText)
+	}
+
+	if got := first["name"]; got != SanitizedName {
+		t.Errorf("name = %q, want %q", got, SanitizedName)
+	}
+	if got := first["media_url"].(string); !strings.HasPrefix(got, "https://example.com/media/") || !strings.HasSuffix(got, ".jpg") {
+		t.Errorf("media_url = %q", got)
+	}
+}
+
+func TestSanitize_SameSeedSameOutput(t *testing.T) {
+	a, _ := sanitize(t, newScrambler([]byte("seed")), rawPosts)
+	b, _ := sanitize(t, newScrambler([]byte("seed")), rawPosts)
+	c, _ := sanitize(t, newScrambler([]byte("other")), rawPosts)
+
+	if a != b {
+		t.Error("same seed produced different output")
+	}
+	if a == c {
+		t.Error("different seeds produced the same output")
+	}
+}
+
+func TestSanitize_KeepsMetricNames(t *testing.T) {
+	raw := `{"data":[{"name":"views","period":"lifetime","values":[{"value":42}],` +
+		`"id":"17890455123456789/insights/views/lifetime"}]}`
+	_, doc := sanitize(t, newScrambler([]byte("seed")), raw)
+
+	insight := doc["data"].([]any)[0].(map[string]any)
+	if insight["name"] != "views" {
+		t.Errorf("name = %v, want views", insight["name"])
+	}
+	value := insight["values"].([]any)[0].(map[string]any)["value"]
+	if value != float64(42) {
+		t.Errorf("value = %v, want 42", value)
+	}
+	id := insight["id"].(string)
+	if strings.Contains(id, "17890455123456789") || !strings.HasSuffix(id, "/insights/views/lifetime") {
+		t.Errorf("id = %q, want a scrambled post ID with the metric suffix", id)
+	}
+}
+
+func TestSanitize_InvalidJSON(t *testing.T) {
+	if _, err := NewScrambler().Sanitize([]byte("<html>")); err == nil {
+		t.Error("Sanitize() error = nil, want an error for non-JSON input")
+	}
+}
+
+func FuzzSanitize(f *testing.F) {
+	f.Add([]byte(rawPosts))
+	f.Add([]byte(`{"data":[{"name":"views","values":[{"value":1e400}],"id":"1/insights"}]}`))
+	f.Add([]byte(`[{"username":""},{"id":123},null,"@"]`))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		out, err := newScrambler([]byte("seed")).Sanitize(body)
+		if err != nil {
+			return
+		}
+		if !json.Valid(out) {
+			t.Errorf("Sanitize(%q) produced invalid JSON: %s", body, out)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+// Package guides holds the long-form guides shipped in the binary, so help
+// is available offline and matches the installed version.
+package guides
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed *.md
+var files embed.FS
+
+// Guide describes one guide.
+type Guide struct {
+	// Name is the topic, e.g. "auth"
+	Name string `json:"name"`
+	// Title is the first heading
+	Title string `json:"title"`
+	// Summary is the first paragraph
+	Summary string `json:"summary"`
+}
+
+// List returns every guide, sorted by name.
+func List() []Guide {
+	entries, err := files.ReadDir(".")
+	if err != nil {
+		panic(err) // The embedded directory always exists
+	}
+	var guides []Guide
+	for _, e := range entries {
+		content, err := files.ReadFile(e.Name())
+		if err != nil {
+			panic(err)
+		}
+		guides = append(guides, describe(strings.TrimSuffix(e.Name(), path.Ext(e.Name())), string(content)))
+	}
+	sort.Slice(guides, func(i, j int) bool { return guides[i].Name < guides[j].Name })
+	return guides
+}
+
+// Names returns the topic of every guide.
+func Names() []string {
+	var names []string
+	for _, g := range List() {
+		names = append(names, g.Name)
+	}
+	return names
+}
+
+// Read returns the Markdown of the guide on topic.
+func Read(topic string) (string, error) {
+	data, err := files.ReadFile(topic + ".md")
+	if err != nil {
+		return "", fmt.Errorf("no guide on %q; topics: %s", topic, strings.Join(Names(), ", "))
+	}
+	return string(data), nil
+}
+
+// describe takes the title from the first heading and the summary from the
+// paragraph after it
+func describe(name, content string) Guide {
+	g := Guide{Name: name}
+	var summary []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case g.Title == "" && strings.HasPrefix(line, "# "):
+			g.Title = strings.TrimPrefix(line, "# ")
+		case g.Title == "":
+		case line == "" && len(summary) > 0:
+			g.Summary = strings.Join(summary, " ")
+			return g
+		case line != "":
+			summary = append(summary, line)
+		}
+	}
+	g.Summary = strings.Join(summary, " ")
+	return g
+}
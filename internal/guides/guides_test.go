@@ -0,0 +1,52 @@
+package guides
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	guides := List()
+	names := Names()
+	if !slices.Equal(names, []string{"auth", "media", "scheduling", "webhooks"}) {
+		t.Errorf("Names() = %v", names)
+	}
+	for _, g := range guides {
+		if g.Title == "" || g.Summary == "" {
+			t.Errorf("%s: missing title or summary: %+v", g.Name, g)
+		}
+		if strings.Contains(g.Summary, "\n") || strings.HasPrefix(g.Summary, "#") {
+			t.Errorf("%s: summary is not the first paragraph: %q", g.Name, g.Summary)
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	content, err := Read("webhooks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(content, "# Webhooks\n") {
+		t.Errorf("unexpected content: %.40q", content)
+	}
+
+	for _, topic := range []string{"nope", "../guides", "auth.md", ""} {
+		_, err := Read(topic)
+		if err == nil {
+			t.Errorf("Read(%q) succeeded, want an error", topic)
+			continue
+		}
+		if !strings.Contains(err.Error(), "auth, media, scheduling, webhooks") {
+			t.Errorf("Read(%q) error should list the topics: %v", topic, err)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	g := describe("x", "# Title\n\nFirst line\nsecond line.\n\n## Next\n\nMore.\n")
+	want := Guide{Name: "x", Title: "Title", Summary: "First line second line."}
+	if g != want {
+		t.Errorf("describe() = %+v, want %+v", g, want)
+	}
+}
@@ -32,6 +32,11 @@ func ConfirmOrYes(ctx context.Context, prompt string) (bool, error) {
 		return true, nil
 	}
 
+	// Strict mode never prompts
+	if outfmt.GetStrict(ctx) {
+		return false, fmt.Errorf("confirmation required, but --strict never prompts; use --yes to confirm")
+	}
+
 	// Require terminal for interactive confirmation
 	if !isTerminal() {
 		return false, fmt.Errorf("stdin is not a terminal; use --yes to confirm non-interactively")
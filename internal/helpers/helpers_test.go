@@ -91,6 +91,30 @@ func TestConfirmOrYes_UserSaysY(t *testing.T) {
 	}
 }
 
+func TestConfirmOrYes_CRLF(t *testing.T) {
+	var outBuf bytes.Buffer
+	ctx := context.Background()
+	ctx = outfmt.WithFormat(ctx, "text")
+	ctx = outfmt.WithYes(ctx, false)
+	ctx = iocontext.WithIO(ctx, &iocontext.IO{
+		Out:    &outBuf,
+		ErrOut: &outBuf,
+		In:     strings.NewReader("yes\r\n"),
+	})
+
+	oldIsTerminal := isTerminal
+	isTerminal = func() bool { return true }
+	defer func() { isTerminal = oldIsTerminal }()
+
+	confirmed, err := ConfirmOrYes(ctx, "Delete?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !confirmed {
+		t.Error("expected confirmation when a Windows console sends yes\\r\\n")
+	}
+}
+
 func TestConfirmOrYes_UserSaysYesCaseInsensitive(t *testing.T) {
 	var outBuf bytes.Buffer
 	ctx := context.Background()
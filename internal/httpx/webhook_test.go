@@ -0,0 +1,162 @@
+package httpx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignature(t *testing.T) {
+	const body = `{"object":"user","entry":[]}`
+
+	var received string
+	handler := VerifySignature("s3cret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		received = string(data)
+	}))
+
+	tests := []struct {
+		name      string
+		signature string
+		want      int
+	}{
+		{"valid", sign("s3cret", body), http.StatusOK},
+		{"wrong secret", sign("other", body), http.StatusUnauthorized},
+		{"missing", "", http.StatusUnauthorized},
+		{"malformed", "sha256=zz", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = ""
+			req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+			if tt.signature != "" {
+				req.Header.Set(SignatureHeader, tt.signature)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want == http.StatusOK && received != body {
+				t.Errorf("next handler got body %q", received)
+			}
+		})
+	}
+}
+
+func TestChallengeHandler(t *testing.T) {
+	handler := ChallengeHandler("verify-me")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhook?hub.mode=subscribe&hub.verify_token=verify-me&hub.challenge=12345", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "12345" {
+		t.Errorf("expected challenge echo, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhook?hub.mode=subscribe&hub.verify_token=wrong&hub.challenge=12345", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for wrong token, got %d", rec.Code)
+	}
+}
+
+func TestWebhookHandler_Methods(t *testing.T) {
+	handler := WebhookHandler("s3cret", "verify-me", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("{}"))
+	req.Header.Set(SignatureHeader, sign("s3cret", "{}"))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("expected delivery to reach handler, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/webhook", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"object":"user","entry":[]}`)
+	got := Sign("s3cret", body)
+	if got != sign("s3cret", string(body)) {
+		t.Errorf("Sign = %q", got)
+	}
+	if !ValidSignature("s3cret", body, got) {
+		t.Error("signature from Sign should validate")
+	}
+}
+
+func FuzzValidSignature(f *testing.F) {
+	f.Add("s3cret", []byte(`{"object":"user","entry":[]}`), "sha256=00")
+	f.Add("", []byte("{}"), "sha256=")
+	f.Add("s3cret", []byte{}, "sha1=abcdef")
+
+	f.Fuzz(func(t *testing.T, secret string, body []byte, header string) {
+		valid := ValidSignature(secret, body, header)
+		if secret == "" {
+			if valid {
+				t.Error("a signature validated without an app secret")
+			}
+			return
+		}
+		if !ValidSignature(secret, body, Sign(secret, body)) {
+			t.Error("signature from Sign did not validate")
+		}
+		if valid && !strings.EqualFold(header, Sign(secret, body)) {
+			t.Errorf("header %q validated but differs from %q", header, Sign(secret, body))
+		}
+	})
+}
+
+func FuzzWebhookHandler(f *testing.F) {
+	f.Add("POST", "", []byte(`{"values":{"field":"mentions"}}`), true)
+	f.Add("POST", "", []byte("{}"), false)
+	f.Add("GET", "hub.mode=subscribe&hub.verify_token=verify-me&hub.challenge=42", []byte(nil), false)
+	f.Add("GET", "hub.mode=subscribe&hub.verify_token=%zz", []byte(nil), false)
+
+	f.Fuzz(func(t *testing.T, method, query string, body []byte, signed bool) {
+		if method == "" || strings.ContainsAny(method, " \t\r\n") {
+			return // Not a request line net/http would accept
+		}
+		var reached []byte
+		handler := WebhookHandler("s3cret", "verify-me", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reached, _ = io.ReadAll(r.Body)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/webhook", strings.NewReader(string(body)))
+		req.Method = method
+		req.URL.RawQuery = query
+		if signed {
+			req.Header.Set(SignatureHeader, Sign("s3cret", body))
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		if reached == nil {
+			return
+		}
+		if method != http.MethodPost || !signed {
+			t.Errorf("%s request with signed=%v reached the handler", method, signed)
+		}
+		if string(reached) != string(body) {
+			t.Error("the handler did not get the signed body")
+		}
+	})
+}
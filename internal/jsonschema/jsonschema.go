@@ -0,0 +1,200 @@
+// Package jsonschema generates JSON Schemas (draft 2020-12) from Go types,
+// following the rules encoding/json uses to marshal them, so the schema of a
+// command's JSON output cannot drift from the type that produces it.
+package jsonschema
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema. Only the keywords the generator uses are present.
+type Schema struct {
+	Draft                string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Generator builds schemas, sharing one definition per named struct type so
+// recursive types such as a post quoting a post are finite.
+type Generator struct {
+	// Types fixes the schema of types with a custom JSON encoding, which
+	// reflection cannot see. time.Time is always a date-time string.
+	Types map[reflect.Type]*Schema
+
+	defs  map[string]*Schema
+	names map[reflect.Type]string
+}
+
+// Generate returns the schema of the JSON encoding of values of type t, with
+// the definitions it refers to.
+func (g *Generator) Generate(t reflect.Type) *Schema {
+	g.defs = map[string]*Schema{}
+	g.names = map[reflect.Type]string{}
+
+	root := g.schema(t)
+	root.Draft = Draft
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (g *Generator) schema(t reflect.Type) *Schema {
+	if s, ok := g.Types[t]; ok {
+		copied := *s
+		return &copied
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json writes byte slices as base64
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return &Schema{Ref: "#/$defs/" + g.define(t)}
+	default:
+		// Interfaces hold any value
+		return &Schema{}
+	}
+}
+
+// define adds the definition of the named struct type t once and returns its name
+func (g *Generator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := defName(t)
+	for base, i := name, 2; g.defs[name] != nil; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	g.names[t] = name
+	// Reserve the name before recursing so self-references find it
+	g.defs[name] = &Schema{}
+	*g.defs[name] = *g.object(t)
+	return name
+}
+
+var qualifier = regexp.MustCompile(`[\w./-]*\.`)
+
+// defName is the name of t without package paths and capitalized, as types
+// generated from the schema are public: PagePost for Page[api.Post]
+func defName(t reflect.Type) string {
+	parts := strings.FieldsFunc(qualifier.ReplaceAllString(t.Name(), ""), func(r rune) bool {
+		return r == '[' || r == ']' || r == ',' || r == '*'
+	})
+	var name strings.Builder
+	for _, part := range parts {
+		name.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return name.String()
+}
+
+// object is the schema of the fields of struct type t. Embedded structs are
+// flattened as encoding/json does, with shallower fields winning.
+func (g *Generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.addFields(s, t, map[string]bool{})
+	if len(s.Properties) == 0 {
+		s.Properties = nil
+	}
+	return s
+}
+
+func (g *Generator) addFields(s *Schema, t reflect.Type, seen map[string]bool) {
+	var embedded []reflect.Type
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		ft := field.Type
+		if field.Anonymous && name == "" {
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, ft)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		prop := g.schema(ft)
+		if hasOption(opts, "string") && (prop.Type == "integer" || prop.Type == "number" || prop.Type == "boolean") {
+			prop = &Schema{Type: "string"}
+		}
+		omitempty := hasOption(opts, "omitempty") || hasOption(opts, "omitzero")
+		if ft.Kind() == reflect.Pointer && !omitempty {
+			prop = &Schema{AnyOf: []*Schema{prop, {Type: "null"}}}
+		}
+		s.Properties[name] = prop
+		if !omitempty {
+			s.Required = append(s.Required, name)
+		}
+	}
+	// Fields of embedded structs only fill names the outer struct left free
+	for _, et := range embedded {
+		g.addFields(s, et, seen)
+	}
+}
+
+// hasOption reports whether the comma-separated options of a json tag include opt
+func hasOption(opts, opt string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+type stamp struct{ time.Time }
+
+type base struct {
+	ID   string `json:"id"`
+	Note string `json:"note,omitempty"`
+}
+
+type node struct {
+	*base
+	Note     int               `json:"note"`
+	Count    int64             `json:"count,string"`
+	Parent   *node             `json:"parent"`
+	Children []node            `json:"children,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Created  stamp             `json:"created"`
+	Updated  time.Time         `json:"updated"`
+	Extra    any               `json:"extra,omitempty"`
+	Skipped  string            `json:"-"`
+	internal string
+}
+
+func TestGenerate(t *testing.T) {
+	g := &Generator{Types: map[reflect.Type]*Schema{
+		reflect.TypeOf(stamp{}): {Type: "string", Format: "date-time"},
+	}}
+	s := g.Generate(reflect.TypeOf(&node{}))
+
+	if s.Draft != Draft || s.Ref != "#/$defs/Node" {
+		t.Fatalf("unexpected root: %+v", s)
+	}
+	def := s.Defs["Node"]
+	if def == nil || def.Type != "object" {
+		t.Fatalf("expected a node definition, got %+v", s.Defs)
+	}
+
+	want := map[string]string{
+		"id":       `{"type":"string"}`,
+		"note":     `{"type":"integer"}`,
+		"count":    `{"type":"string"}`,
+		"parent":   `{"anyOf":[{"$ref":"#/$defs/Node"},{"type":"null"}]}`,
+		"children": `{"type":"array","items":{"$ref":"#/$defs/Node"}}`,
+		"labels":   `{"type":"object","additionalProperties":{"type":"string"}}`,
+		"created":  `{"type":"string","format":"date-time"}`,
+		"updated":  `{"type":"string","format":"date-time"}`,
+		"extra":    `{}`,
+	}
+	if len(def.Properties) != len(want) {
+		t.Errorf("properties = %v, want %v", keys(def.Properties), want)
+	}
+	for name, w := range want {
+		got, err := json.Marshal(def.Properties[name])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != w {
+			t.Errorf("%s = %s, want %s", name, got, w)
+		}
+	}
+
+	wantRequired := []string{"note", "count", "parent", "created", "updated", "id"}
+	if !slices.Equal(def.Required, wantRequired) {
+		t.Errorf("required = %v, want %v", def.Required, wantRequired)
+	}
+}
+
+func TestGenerate_GenericNames(t *testing.T) {
+	type page[T any] struct {
+		Data []T `json:"data"`
+	}
+	s := (&Generator{}).Generate(reflect.TypeOf(page[base]{}))
+	if s.Ref != "#/$defs/PageBase" || len(s.Defs) != 2 || s.Defs["Base"] == nil {
+		t.Errorf("expected package paths to be dropped from the names, got %s and %v", s.Ref, keys(s.Defs))
+	}
+}
+
+func keys(m map[string]*Schema) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	slices.Sort(out)
+	return out
+}
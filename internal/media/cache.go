@@ -0,0 +1,271 @@
+package media
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// cacheIndexName is the cache manifest, mapping URLs to stored objects
+const cacheIndexName = "index.json"
+
+// Object is a file stored in the cache under its SHA-256.
+type Object struct {
+	Ext      string    `json:"ext"`
+	Bytes    int64     `json:"bytes"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+type cacheIndex struct {
+	// Objects is keyed by SHA-256
+	Objects map[string]*Object `json:"objects"`
+	// URLs maps a cache key (see cacheKey) to the SHA-256 of its content
+	URLs map[string]string `json:"urls"`
+}
+
+// Cache is a content-addressed store of downloaded media. Files are named by
+// their SHA-256, so the same image fetched for several posts or archive runs
+// is stored once. The index records which URL produced which object and when
+// each object was last used, for LRU pruning.
+type Cache struct {
+	dir   string
+	index cacheIndex
+}
+
+// OpenCache opens the cache in dir, creating it if needed.
+func OpenCache(dir string) (*Cache, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "objects"), 0o755); err != nil {
+		return nil, err
+	}
+
+	c := &Cache{dir: dir}
+	data, err := os.ReadFile(filepath.Join(dir, cacheIndexName))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, &c.index); err != nil {
+			return nil, fmt.Errorf("invalid cache index %s: %w", filepath.Join(dir, cacheIndexName), err)
+		}
+	}
+	if c.index.Objects == nil {
+		c.index.Objects = make(map[string]*Object)
+	}
+	if c.index.URLs == nil {
+		c.index.URLs = make(map[string]string)
+	}
+	return c, nil
+}
+
+// Dir returns the cache directory.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+func (c *Cache) objectPath(sum string, obj *Object) string {
+	return filepath.Join(c.dir, "objects", sum[:2], sum+obj.Ext)
+}
+
+// cacheKey identifies a URL across fetches. CDN URLs carry signatures in the
+// query string that change on every API call, so only host and path are used.
+func cacheKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Host + u.Path
+}
+
+// lookup returns the stored object for rawURL. An object whose file is missing
+// or no longer matches its hash is dropped and reported as a miss.
+func (c *Cache) lookup(rawURL string) (string, string, *Object, bool) {
+	sum, ok := c.index.URLs[cacheKey(rawURL)]
+	if !ok {
+		return "", "", nil, false
+	}
+	path, obj, ok := c.object(sum)
+	return path, sum, obj, ok
+}
+
+// object returns the stored object with the given hash, verifying its file
+func (c *Cache) object(sum string) (string, *Object, bool) {
+	obj, ok := c.index.Objects[sum]
+	if !ok {
+		return "", nil, false
+	}
+	path := c.objectPath(sum, obj)
+	if !verify(path, sum) {
+		c.remove(sum)
+		return "", nil, false
+	}
+	obj.LastUsed = time.Now().UTC()
+	return path, obj, true
+}
+
+// put moves the downloaded file at tmpPath into the cache and returns the
+// object's path. If an identical object is already stored, tmpPath is removed.
+func (c *Cache) put(tmpPath, rawURL, ext string, size int64, sum string) (string, error) {
+	c.index.URLs[cacheKey(rawURL)] = sum
+	if path, _, ok := c.object(sum); ok {
+		return path, os.Remove(tmpPath)
+	}
+
+	obj := &Object{Ext: ext, Bytes: size, LastUsed: time.Now().UTC()}
+	path := c.objectPath(sum, obj)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return "", err
+	}
+	c.index.Objects[sum] = obj
+	return path, nil
+}
+
+// remove deletes an object and every URL pointing at it
+func (c *Cache) remove(sum string) {
+	if obj, ok := c.index.Objects[sum]; ok {
+		os.Remove(c.objectPath(sum, obj)) //nolint:errcheck,gosec // Already gone is fine
+		delete(c.index.Objects, sum)
+	}
+	for key, s := range c.index.URLs {
+		if s == sum {
+			delete(c.index.URLs, key)
+		}
+	}
+}
+
+// Size returns the number of objects and their total size.
+func (c *Cache) Size() (int, int64) {
+	var total int64
+	for _, obj := range c.index.Objects {
+		total += obj.Bytes
+	}
+	return len(c.index.Objects), total
+}
+
+// Save writes the cache index.
+func (c *Cache) Save() error {
+	data, err := json.MarshalIndent(c.index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return statefile.Write(filepath.Join(c.dir, cacheIndexName), append(data, '\n'), 0o644)
+}
+
+// PruneResult reports what Prune removed.
+type PruneResult struct {
+	Removed        int   `json:"removed"`
+	FreedBytes     int64 `json:"freed_bytes"`
+	Remaining      int   `json:"remaining"`
+	RemainingBytes int64 `json:"remaining_bytes"`
+}
+
+// Prune removes the least recently used objects until the cache holds at
+// most maxBytes. With dryRun, nothing is deleted but the result is the same.
+func (c *Cache) Prune(maxBytes int64, dryRun bool) (*PruneResult, error) {
+	sums := make([]string, 0, len(c.index.Objects))
+	for sum := range c.index.Objects {
+		sums = append(sums, sum)
+	}
+	sort.Slice(sums, func(i, j int) bool {
+		return c.index.Objects[sums[i]].LastUsed.Before(c.index.Objects[sums[j]].LastUsed)
+	})
+
+	result := &PruneResult{}
+	result.Remaining, result.RemainingBytes = c.Size()
+	for _, sum := range sums {
+		if result.RemainingBytes <= maxBytes {
+			break
+		}
+		size := c.index.Objects[sum].Bytes
+		if !dryRun {
+			c.remove(sum)
+		}
+		result.Removed++
+		result.FreedBytes += size
+		result.Remaining--
+		result.RemainingBytes -= size
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	return result, c.Save()
+}
+
+// linkFile places the cached object at src at dst, hard-linking when possible
+// so exports don't double disk usage, and copying otherwise.
+func linkFile(src, dst string) error {
+	if err := os.Remove(dst); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src) //nolint:gosec // Path is inside the cache
+	if err != nil {
+		return err
+	}
+	defer in.Close() //nolint:errcheck // Read-only
+
+	out, err := os.Create(dst) //nolint:gosec // Path is chosen by the user
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close() //nolint:errcheck,gosec // Already failing
+		return err
+	}
+	return out.Close()
+}
+
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"TB", 1 << 40},
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// ParseSize parses a size such as "500MB", "2GB" or "1024" (bytes).
+func ParseSize(s string) (int64, error) {
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	mult := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(upper, unit.suffix) {
+			upper, mult = strings.TrimSpace(strings.TrimSuffix(upper, unit.suffix)), unit.bytes
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(upper, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q (use e.g. 500MB or 2GB)", s)
+	}
+	return int64(n * float64(mult)), nil
+}
+
+// FormatSize formats a byte count for display, e.g. 1.5 MB.
+func FormatSize(n int64) string {
+	for _, unit := range sizeUnits[:len(sizeUnits)-1] {
+		if n >= unit.bytes {
+			return fmt.Sprintf("%.1f %s", float64(n)/float64(unit.bytes), unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%d B", n)
+}
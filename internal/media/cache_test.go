@@ -0,0 +1,158 @@
+package media
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDownload_CacheSkipsRepeatedURLs(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write([]byte("same bytes")) //nolint:errcheck,gosec // Test server
+	}))
+	defer srv.Close()
+
+	cache, err := OpenCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenCache: %v", err)
+	}
+	d := newTestDownloader(0).WithCache(cache)
+
+	// The signature in the query string changes between API calls
+	first := filepath.Join(t.TempDir(), "first")
+	if _, err := d.Download(context.Background(), first, []Item{{PostID: "1", URL: srv.URL + "/p.jpg?sig=a", Name: "1"}}); err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	second := filepath.Join(t.TempDir(), "second")
+	m, err := d.Download(context.Background(), second, []Item{{PostID: "1", URL: srv.URL + "/p.jpg?sig=b", Name: "1"}})
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected one request, got %d", calls.Load())
+	}
+	if e := m.Entries[0]; e.File != "1.jpg" || e.SHA256 != sha("same bytes") {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if data, err := os.ReadFile(filepath.Join(second, "1.jpg")); err != nil || string(data) != "same bytes" {
+		t.Errorf("1.jpg = %q, %v", data, err)
+	}
+
+	// Objects are named by their hash and survive reopening the cache
+	sum := sha("same bytes")
+	if _, err := os.Stat(filepath.Join(cache.Dir(), "objects", sum[:2], sum+".jpg")); err != nil {
+		t.Errorf("object not stored by hash: %v", err)
+	}
+	reopened, err := OpenCache(cache.Dir())
+	if err != nil {
+		t.Fatalf("OpenCache: %v", err)
+	}
+	if n, size := reopened.Size(); n != 1 || size != int64(len("same bytes")) {
+		t.Errorf("Size() = %d, %d", n, size)
+	}
+}
+
+func TestDownload_CacheDeduplicatesContent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("shared")) //nolint:errcheck,gosec // Test server
+	}))
+	defer srv.Close()
+
+	cache, err := OpenCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenCache: %v", err)
+	}
+	items := []Item{
+		{PostID: "1", URL: srv.URL + "/a.png", Name: "1"},
+		{PostID: "2", URL: srv.URL + "/b.png", Name: "2"},
+	}
+	if _, err := newTestDownloader(0).WithCache(cache).Download(context.Background(), t.TempDir(), items); err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if n, _ := cache.Size(); n != 1 {
+		t.Errorf("expected identical content stored once, got %d objects", n)
+	}
+}
+
+func TestCache_PruneLeastRecentlyUsed(t *testing.T) {
+	cache, err := OpenCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenCache: %v", err)
+	}
+
+	now := time.Now()
+	for i, content := range []string{"oldest", "middle", "newest"} {
+		tmp := filepath.Join(cache.Dir(), "tmp")
+		if err := os.WriteFile(tmp, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cache.put(tmp, "https://cdn/"+content, ".bin", int64(len(content)), sha(content)); err != nil {
+			t.Fatalf("put: %v", err)
+		}
+		cache.index.Objects[sha(content)].LastUsed = now.Add(time.Duration(i) * time.Hour)
+	}
+
+	dry, err := cache.Prune(6, true)
+	if err != nil {
+		t.Fatalf("Prune dry run: %v", err)
+	}
+	if dry.Removed != 2 || dry.RemainingBytes != 6 {
+		t.Errorf("unexpected dry run result: %+v", dry)
+	}
+	if n, _ := cache.Size(); n != 3 {
+		t.Errorf("dry run removed objects")
+	}
+
+	result, err := cache.Prune(6, false)
+	if err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if result.Removed != 2 || result.FreedBytes != 12 || result.Remaining != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if _, _, _, ok := cache.lookup("https://cdn/newest"); !ok {
+		t.Error("most recently used object was pruned")
+	}
+	if _, _, _, ok := cache.lookup("https://cdn/oldest"); ok {
+		t.Error("least recently used object was kept")
+	}
+	if _, err := os.Stat(filepath.Join(cache.Dir(), "objects", sha("oldest")[:2], sha("oldest")+".bin")); !os.IsNotExist(err) {
+		t.Errorf("pruned file still on disk: %v", err)
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"1024", 1024},
+		{"500MB", 500 << 20},
+		{"1.5gb", 3 << 29},
+		{"0", 0},
+	}
+	for _, tt := range tests {
+		got, err := ParseSize(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseSize(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "big", "-1MB"} {
+		if _, err := ParseSize(bad); err == nil {
+			t.Errorf("ParseSize(%q) should fail", bad)
+		}
+	}
+	if got := FormatSize(1536); got != "1.5 KB" {
+		t.Errorf("FormatSize(1536) = %q", got)
+	}
+}
@@ -0,0 +1,326 @@
+// Package media downloads the images and videos attached to posts, for backups.
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// ManifestName is the file written next to the downloads
+const ManifestName = "manifest.json"
+
+// Item is one media file to download.
+type Item struct {
+	PostID    string
+	MediaType string
+	URL       string
+	// Name is the file name without extension, unique within a download
+	Name string
+}
+
+// Entry records one downloaded file in the manifest.
+type Entry struct {
+	PostID    string    `json:"post_id"`
+	MediaType string    `json:"media_type,omitempty"`
+	URL       string    `json:"url"`
+	File      string    `json:"file,omitempty"`
+	Bytes     int64     `json:"bytes,omitempty"`
+	SHA256    string    `json:"sha256,omitempty"`
+	Fetched   time.Time `json:"fetched,omitzero"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Manifest lists every file in a download directory with its checksum.
+type Manifest struct {
+	Updated time.Time `json:"updated"`
+	Entries []Entry   `json:"entries"`
+}
+
+// Failed returns the entries whose download failed.
+func (m *Manifest) Failed() []Entry {
+	var failed []Entry
+	for _, e := range m.Entries {
+		if e.Error != "" {
+			failed = append(failed, e)
+		}
+	}
+	return failed
+}
+
+// Downloader fetches media from CDN URLs. The zero value is not usable; use
+// NewDownloader.
+type Downloader struct {
+	client  *http.Client
+	retries int
+	backoff time.Duration
+	cache   *Cache
+}
+
+// NewDownloader returns a Downloader that retries each file up to retries
+// times with exponential backoff. CDN URLs are signed, so no credentials are sent.
+func NewDownloader(client *http.Client, retries int) *Downloader {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Minute}
+	}
+	return &Downloader{client: client, retries: retries, backoff: time.Second}
+}
+
+// WithCache stores downloads in cache and serves repeated URLs from it. Files
+// in the download directory are then hard links to the cached objects.
+func (d *Downloader) WithCache(cache *Cache) *Downloader {
+	d.cache = cache
+	return d
+}
+
+// Download fetches items into dir and updates the manifest there. Files
+// already listed in the manifest whose checksum still matches are skipped,
+// so re-running a backup only fetches what is new or damaged. A failed item
+// is recorded in the manifest rather than aborting the others.
+func (d *Downloader) Download(ctx context.Context, dir string, items []Item) (*Manifest, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]Entry, len(manifest.Entries))
+	for _, e := range manifest.Entries {
+		existing[e.URL] = e
+	}
+
+	for _, item := range items {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if prev, ok := existing[item.URL]; ok && prev.Error == "" && verify(filepath.Join(dir, prev.File), prev.SHA256) {
+			if d.cache != nil {
+				d.cache.object(prev.SHA256) // Mark as recently used
+			}
+			continue
+		}
+		existing[item.URL] = d.download(ctx, dir, item)
+	}
+
+	manifest.Entries = manifest.Entries[:0]
+	for _, e := range existing {
+		manifest.Entries = append(manifest.Entries, e)
+	}
+	sort.Slice(manifest.Entries, func(i, j int) bool {
+		a, b := manifest.Entries[i], manifest.Entries[j]
+		if a.PostID != b.PostID {
+			return a.PostID < b.PostID
+		}
+		return a.File < b.File
+	})
+	manifest.Updated = time.Now().UTC()
+
+	if d.cache != nil {
+		if err := d.cache.Save(); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, writeManifest(dir, manifest)
+}
+
+// download places one item in dir, from the cache when possible
+func (d *Downloader) download(ctx context.Context, dir string, item Item) Entry {
+	entry := Entry{PostID: item.PostID, MediaType: item.MediaType, URL: item.URL}
+	fail := func(err error) Entry {
+		entry.Error = err.Error()
+		return entry
+	}
+	done := func(file string, size int64, sum string) Entry {
+		entry.File, entry.Bytes, entry.SHA256, entry.Fetched = file, size, sum, time.Now().UTC()
+		return entry
+	}
+
+	if d.cache != nil {
+		if path, sum, obj, ok := d.cache.lookup(item.URL); ok {
+			name := item.Name + obj.Ext
+			if err := linkFile(path, filepath.Join(dir, name)); err != nil {
+				return fail(err)
+			}
+			return done(name, obj.Bytes, sum)
+		}
+	}
+
+	tmpDir := dir
+	if d.cache != nil {
+		tmpDir = d.cache.Dir() // Same filesystem as the objects, for the rename
+	}
+	tmp, ext, size, sum, err := d.fetchWithRetry(ctx, tmpDir, item)
+	if err != nil {
+		return fail(err)
+	}
+	defer os.Remove(tmp) //nolint:errcheck // No-op after a successful rename
+
+	name := item.Name + ext
+	if d.cache != nil {
+		var path string
+		if path, err = d.cache.put(tmp, item.URL, ext, size, sum); err == nil {
+			err = linkFile(path, filepath.Join(dir, name))
+		}
+	} else {
+		err = os.Rename(tmp, filepath.Join(dir, name))
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	return done(name, size, sum)
+}
+
+// ReadManifest loads the manifest in dir; a missing manifest is empty.
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestName))
+	if errors.Is(err, os.ErrNotExist) {
+		return &Manifest{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ManifestName, err)
+	}
+	return &m, nil
+}
+
+func writeManifest(dir string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return statefile.Write(filepath.Join(dir, ManifestName), append(data, '\n'), 0o644)
+}
+
+// statusError is an unexpected HTTP status from the CDN
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status %d", e.code)
+}
+
+func (e *statusError) retryable() bool {
+	return e.code == http.StatusTooManyRequests || e.code >= 500
+}
+
+func (d *Downloader) fetchWithRetry(ctx context.Context, tmpDir string, item Item) (string, string, int64, string, error) {
+	backoff := d.backoff
+	for attempt := 0; ; attempt++ {
+		tmp, ext, size, sum, err := d.fetch(ctx, tmpDir, item)
+		if err == nil {
+			return tmp, ext, size, sum, nil
+		}
+
+		var se *statusError
+		if attempt >= d.retries || ctx.Err() != nil || (errors.As(err, &se) && !se.retryable()) {
+			return "", "", 0, "", err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return "", "", 0, "", ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// fetch downloads one item to a temporary file in tmpDir, returning its
+// path, file extension, size and SHA-256
+func (d *Downloader) fetch(ctx context.Context, tmpDir string, item Item) (string, string, int64, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, item.URL, nil)
+	if err != nil {
+		return "", "", 0, "", err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return "", "", 0, "", err
+	}
+	defer resp.Body.Close() //nolint:errcheck // Best-effort close
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", 0, "", &statusError{code: resp.StatusCode}
+	}
+
+	tmp, err := os.CreateTemp(tmpDir, ".download-*")
+	if err != nil {
+		return "", "", 0, "", err
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), resp.Body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name()) //nolint:errcheck,gosec // Already failing
+		return "", "", 0, "", err
+	}
+
+	ext := extension(resp.Header.Get("Content-Type"), item.URL)
+	return tmp.Name(), ext, size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// extension picks a file extension from the response type, falling back to the URL path
+func extension(contentType, rawURL string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch mediaType {
+		case "image/jpeg":
+			return ".jpg"
+		case "image/png":
+			return ".png"
+		case "image/gif":
+			return ".gif"
+		case "image/webp":
+			return ".webp"
+		case "video/mp4":
+			return ".mp4"
+		}
+	}
+
+	p := rawURL
+	if i := strings.IndexAny(p, "?#"); i >= 0 {
+		p = p[:i]
+	}
+	if ext := path.Ext(p); ext != "" && len(ext) <= 5 {
+		return strings.ToLower(ext)
+	}
+	return ".bin"
+}
+
+// verify reports whether the file at path has the given SHA-256
+func verify(path, sum string) bool {
+	f, err := os.Open(path) //nolint:gosec // Path comes from our own manifest
+	if err != nil {
+		return false
+	}
+	defer f.Close() //nolint:errcheck // Read-only
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return false
+	}
+	return hex.EncodeToString(hash.Sum(nil)) == sum
+}
@@ -0,0 +1,179 @@
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestDownloader(retries int) *Downloader {
+	d := NewDownloader(nil, retries)
+	d.backoff = time.Millisecond
+	return d
+}
+
+func sha(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestDownload_WritesFilesAndManifest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a":
+			w.Header().Set("Content-Type", "image/jpeg")
+			w.Write([]byte("image-a")) //nolint:errcheck,gosec // Test server
+		case "/b.mp4":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write([]byte("video-b")) //nolint:errcheck,gosec // Test server
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	items := []Item{
+		{PostID: "1", MediaType: "IMAGE", URL: srv.URL + "/a", Name: "1"},
+		{PostID: "2", MediaType: "VIDEO", URL: srv.URL + "/b.mp4?sig=x", Name: "2"},
+		{PostID: "3", MediaType: "IMAGE", URL: srv.URL + "/missing", Name: "3"},
+	}
+
+	m, err := newTestDownloader(2).Download(context.Background(), dir, items)
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+
+	if len(m.Entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(m.Entries))
+	}
+	if e := m.Entries[0]; e.File != "1.jpg" || e.SHA256 != sha("image-a") || e.Bytes != 7 {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if e := m.Entries[1]; e.File != "2.mp4" || e.SHA256 != sha("video-b") {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if failed := m.Failed(); len(failed) != 1 || failed[0].PostID != "3" || failed[0].Error != "unexpected status 404" {
+		t.Errorf("unexpected failures: %+v", failed)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "1.jpg"))
+	if err != nil || string(data) != "image-a" {
+		t.Errorf("1.jpg = %q, %v", data, err)
+	}
+
+	saved, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatalf("ReadManifest: %v", err)
+	}
+	if len(saved.Entries) != 3 {
+		t.Errorf("saved manifest has %d entries", len(saved.Entries))
+	}
+
+	leftovers, _ := filepath.Glob(filepath.Join(dir, ".download-*"))
+	if len(leftovers) != 0 {
+		t.Errorf("temporary files left behind: %v", leftovers)
+	}
+}
+
+func TestDownload_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png")) //nolint:errcheck,gosec // Test server
+	}))
+	defer srv.Close()
+
+	m, err := newTestDownloader(3).Download(context.Background(), t.TempDir(), []Item{{PostID: "1", URL: srv.URL, Name: "1"}})
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", calls.Load())
+	}
+	if e := m.Entries[0]; e.Error != "" || e.File != "1.png" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+}
+
+func TestDownload_GivesUpAfterRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	m, err := newTestDownloader(2).Download(context.Background(), t.TempDir(), []Item{{PostID: "1", URL: srv.URL, Name: "1"}})
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", calls.Load())
+	}
+	if len(m.Failed()) != 1 {
+		t.Errorf("expected a failed entry, got %+v", m.Entries)
+	}
+}
+
+func TestDownload_SkipsVerifiedFiles(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write([]byte("jpeg")) //nolint:errcheck,gosec // Test server
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	items := []Item{{PostID: "1", URL: srv.URL, Name: "1"}}
+	d := newTestDownloader(0)
+
+	if _, err := d.Download(context.Background(), dir, items); err != nil {
+		t.Fatalf("first Download: %v", err)
+	}
+	if _, err := d.Download(context.Background(), dir, items); err != nil {
+		t.Fatalf("second Download: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("verified file was downloaded again (%d requests)", calls.Load())
+	}
+
+	// A damaged file fails verification and is fetched again
+	if err := os.WriteFile(filepath.Join(dir, "1.jpg"), []byte("corrupt"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Download(context.Background(), dir, items); err != nil {
+		t.Fatalf("third Download: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("damaged file was not downloaded again (%d requests)", calls.Load())
+	}
+}
+
+func TestExtension(t *testing.T) {
+	tests := []struct {
+		contentType, url, want string
+	}{
+		{"image/jpeg", "https://cdn/x", ".jpg"},
+		{"video/mp4; codecs=avc1", "https://cdn/x", ".mp4"},
+		{"", "https://cdn/photo.WEBP?sig=1", ".webp"},
+		{"application/octet-stream", "https://cdn/blob", ".bin"},
+	}
+	for _, tt := range tests {
+		if got := extension(tt.contentType, tt.url); got != tt.want {
+			t.Errorf("extension(%q, %q) = %q, want %q", tt.contentType, tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package mute keeps the local lists of users and keywords whose posts the
+// CLI leaves out of what it displays. They are independent of muting on
+// Threads itself: nothing is sent to the API.
+package mute
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// FileName is the name of the mute lists in the config directory.
+const FileName = "mutes.json"
+
+// schema and version label the mute lists file
+const (
+	schema  = "mutes"
+	version = 1
+)
+
+// Lists are the muted users and keywords, stored lowercase.
+type Lists struct {
+	// Users are usernames without the leading @
+	Users []string `json:"users"`
+	// Keywords are words or phrases matched as whole words
+	Keywords []string `json:"keywords"`
+}
+
+// Load returns the mute lists at path. A missing file mutes nothing.
+func Load(path string) (*Lists, error) {
+	lists := &Lists{}
+	err := statefile.ReadJSON(path, schema, version, lists)
+	if errors.Is(err, fs.ErrNotExist) {
+		return lists, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// Save replaces the mute lists at path atomically.
+func Save(path string, lists *Lists) error {
+	out := Lists{Users: []string{}, Keywords: []string{}}
+	if lists != nil {
+		out.Users = append(out.Users, lists.Users...)
+		out.Keywords = append(out.Keywords, lists.Keywords...)
+	}
+	return statefile.WriteJSON(path, schema, version, out)
+}
+
+// NormalizeUser returns username as it is stored: lowercase, without the
+// leading @
+func NormalizeUser(username string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(username), "@"))
+}
+
+// NormalizeKeyword returns keyword as it is stored: lowercase, with runs of
+// whitespace collapsed to one space
+func NormalizeKeyword(keyword string) string {
+	return strings.ToLower(strings.Join(strings.Fields(keyword), " "))
+}
+
+// AddUser mutes username and reports whether it was not muted yet
+func (l *Lists) AddUser(username string) bool {
+	return add(&l.Users, NormalizeUser(username))
+}
+
+// AddKeyword mutes keyword and reports whether it was not muted yet
+func (l *Lists) AddKeyword(keyword string) bool {
+	return add(&l.Keywords, NormalizeKeyword(keyword))
+}
+
+// RemoveUser unmutes username and reports whether it was muted
+func (l *Lists) RemoveUser(username string) bool {
+	return remove(&l.Users, NormalizeUser(username))
+}
+
+// RemoveKeyword unmutes keyword and reports whether it was muted
+func (l *Lists) RemoveKeyword(keyword string) bool {
+	return remove(&l.Keywords, NormalizeKeyword(keyword))
+}
+
+func add(list *[]string, value string) bool {
+	if value == "" || slices.Contains(*list, value) {
+		return false
+	}
+	*list = append(*list, value)
+	return true
+}
+
+func remove(list *[]string, value string) bool {
+	i := slices.Index(*list, value)
+	if i < 0 {
+		return false
+	}
+	*list = slices.Delete(*list, i, i+1)
+	return true
+}
+
+// Empty reports whether nothing is muted
+func (l *Lists) Empty() bool {
+	return l == nil || len(l.Users) == 0 && len(l.Keywords) == 0
+}
+
+// Match returns why post is muted, such as "@someone" or "keyword spoilers",
+// or "" if it is not. A post is muted when its author is, or when its text
+// or alt text contains a muted keyword as a whole word, ignoring case; the
+// post it quotes or reposts counts too.
+func (l *Lists) Match(post *api.Post) string {
+	if l.Empty() || post == nil {
+		return ""
+	}
+	if slices.Contains(l.Users, strings.ToLower(post.Username)) {
+		return "@" + strings.ToLower(post.Username)
+	}
+	for _, keyword := range l.Keywords {
+		for _, text := range []string{post.Text, post.AltText} {
+			if containsWord(strings.ToLower(text), keyword) {
+				return "keyword " + keyword
+			}
+		}
+	}
+	if reason := l.Match(post.QuotedPost); reason != "" {
+		return reason
+	}
+	return l.Match(post.RepostedPost)
+}
+
+// containsWord reports whether text contains word with no letter or digit
+// right before or after it, so "art" does not match "start"
+func containsWord(text, word string) bool {
+	if word == "" {
+		return false
+	}
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], word)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(word)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
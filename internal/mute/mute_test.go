@@ -0,0 +1,71 @@
+package mute
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+func TestLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	lists, err := Load(path)
+	if err != nil || !lists.Empty() {
+		t.Fatalf("expected a missing file to mute nothing, got %+v, %v", lists, err)
+	}
+
+	if !lists.AddUser("@SomeOne") || lists.AddUser("someone") {
+		t.Error("expected users to be added once, ignoring case and @")
+	}
+	if !lists.AddKeyword("  Season   Finale ") || lists.AddKeyword("season finale") {
+		t.Error("expected keywords to be added once, normalized")
+	}
+	if err := Save(path, lists); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(loaded.Users, []string{"someone"}) || !slices.Equal(loaded.Keywords, []string{"season finale"}) {
+		t.Errorf("unexpected lists: %+v", loaded)
+	}
+	if !loaded.RemoveUser("@someone") || loaded.RemoveUser("someone") || !loaded.RemoveKeyword("SEASON FINALE") {
+		t.Error("expected entries to be removed once")
+	}
+	if !loaded.Empty() {
+		t.Errorf("expected empty lists, got %+v", loaded)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	lists := &Lists{Users: []string{"spammer"}, Keywords: []string{"art", "season finale", "#spoilers"}}
+
+	tests := []struct {
+		name string
+		post *api.Post
+		want string
+	}{
+		{"muted user", &api.Post{Username: "Spammer", Text: "hi"}, "@spammer"},
+		{"whole word", &api.Post{Text: "Modern ART, finally"}, "keyword art"},
+		{"part of a word", &api.Post{Text: "starting the party"}, ""},
+		{"phrase", &api.Post{Text: "no spoilers for the season finale!"}, "keyword season finale"},
+		{"tag", &api.Post{Text: "tonight #spoilers"}, "keyword #spoilers"},
+		{"alt text", &api.Post{AltText: "street art"}, "keyword art"},
+		{"quoted post", &api.Post{Text: "look", QuotedPost: &api.Post{Username: "spammer"}}, "@spammer"},
+		{"reposted post", &api.Post{RepostedPost: &api.Post{Text: "art"}}, "keyword art"},
+		{"nothing", &api.Post{Username: "friend", Text: "hello"}, ""},
+	}
+	for _, tt := range tests {
+		if got := lists.Match(tt.post); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	var none *Lists
+	if none.Match(&api.Post{Username: "spammer"}) != "" {
+		t.Error("expected nil lists to mute nothing")
+	}
+}
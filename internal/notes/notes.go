@@ -0,0 +1,154 @@
+// Package notes keeps private notes on users and posts, stored on this
+// machine only, so they can be shown next to those users and posts in
+// listings.
+package notes
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// FileName is the name of the note store in the data directory.
+const FileName = "notes.json"
+
+// schema and version label the note store file
+const (
+	schema  = "notes"
+	version = 1
+)
+
+// Kind is what a note is about.
+type Kind string
+
+// Kinds of notes.
+const (
+	User Kind = "user"
+	Post Kind = "post"
+)
+
+// Kinds lists every kind of note, for validation and help text.
+var Kinds = []Kind{User, Post}
+
+// Note is a private note on a user or a post.
+type Note struct {
+	Kind Kind `json:"kind"`
+	// Target is the username, without @ and in lower case, or the post ID
+	Target    string    `json:"target"`
+	Text      string    `json:"text"`
+	AddedAt   time.Time `json:"added_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ParseKind returns the kind named s
+func ParseKind(s string) (Kind, error) {
+	kind := Kind(strings.ToLower(s))
+	if !slices.Contains(Kinds, kind) {
+		return "", fmt.Errorf("unknown note kind %q: use user or post", s)
+	}
+	return kind, nil
+}
+
+// NormalizeTarget returns target as notes of kind store it: usernames lose
+// a leading @ and are compared without case
+func NormalizeTarget(kind Kind, target string) string {
+	target = strings.TrimSpace(target)
+	if kind == User {
+		return strings.ToLower(strings.TrimPrefix(target, "@"))
+	}
+	return target
+}
+
+// Load returns the notes in the store at path, oldest first. A missing
+// store has no notes.
+func Load(path string) ([]Note, error) {
+	var notes []Note
+	err := statefile.ReadJSON(path, schema, version, &notes)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// Save replaces the store at path with notes. The store is replaced
+// atomically, so a crash leaves either the old or the new one, and is
+// readable by the user alone.
+func Save(path string, notes []Note) error {
+	if notes == nil {
+		notes = []Note{}
+	}
+	return statefile.WriteJSON(path, schema, version, notes)
+}
+
+// Add adds n to notes, or appends its text on a new line to the note on
+// the same target, keeping when that was first added. It reports whether n
+// was new.
+func Add(notes []Note, n Note) ([]Note, bool) {
+	n.Target = NormalizeTarget(n.Kind, n.Target)
+	i := index(notes, n.Kind, n.Target)
+	if i < 0 {
+		return append(notes, n), true
+	}
+	notes[i].Text += "\n" + n.Text
+	notes[i].UpdatedAt = n.UpdatedAt
+	return notes, false
+}
+
+// Remove deletes the note on target and reports whether there was one
+func Remove(notes []Note, kind Kind, target string) ([]Note, bool) {
+	i := index(notes, kind, NormalizeTarget(kind, target))
+	if i < 0 {
+		return notes, false
+	}
+	return slices.Delete(notes, i, i+1), true
+}
+
+func index(notes []Note, kind Kind, target string) int {
+	return slices.IndexFunc(notes, func(n Note) bool { return n.Kind == kind && n.Target == target })
+}
+
+// Index looks up notes by target, for showing them in listings.
+type Index struct {
+	users map[string]string
+	posts map[string]string
+}
+
+// NewIndex indexes notes
+func NewIndex(notes []Note) *Index {
+	ix := &Index{users: map[string]string{}, posts: map[string]string{}}
+	for _, n := range notes {
+		switch n.Kind {
+		case User:
+			ix.users[n.Target] = n.Text
+		case Post:
+			ix.posts[n.Target] = n.Text
+		}
+	}
+	return ix
+}
+
+// User returns the note on username, or ""
+func (ix *Index) User(username string) string {
+	return ix.users[NormalizeTarget(User, username)]
+}
+
+// Post returns what is noted on the post with id and on its author: the
+// note on the post, the note on the author, or both, post first
+func (ix *Index) Post(id, username string) string {
+	post, user := ix.posts[id], ix.User(username)
+	switch {
+	case post == "":
+		return user
+	case user == "":
+		return post
+	}
+	return post + "; @" + NormalizeTarget(User, username) + ": " + user
+}
@@ -0,0 +1,74 @@
+package notes
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAddAppendsAndNormalizes(t *testing.T) {
+	first := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	later := first.Add(time.Hour)
+
+	notes, added := Add(nil, Note{Kind: User, Target: "@Someone", Text: "met at conf", AddedAt: first, UpdatedAt: first})
+	if !added || notes[0].Target != "someone" {
+		t.Fatalf("expected a new note on someone, got %+v", notes)
+	}
+	notes, added = Add(notes, Note{Kind: User, Target: "someone", Text: "prefers DMs", AddedAt: later, UpdatedAt: later})
+	if added || len(notes) != 1 {
+		t.Fatalf("expected the note appended to, got %+v", notes)
+	}
+	if notes[0].Text != "met at conf\nprefers DMs" || !notes[0].AddedAt.Equal(first) || !notes[0].UpdatedAt.Equal(later) {
+		t.Errorf("unexpected note: %+v", notes[0])
+	}
+
+	notes, added = Add(notes, Note{Kind: Post, Target: "someone", Text: "a post ID that looks like a name"})
+	if !added || len(notes) != 2 {
+		t.Errorf("expected notes on users and posts kept apart, got %+v", notes)
+	}
+}
+
+func TestSaveLoadRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", FileName)
+	if notes, err := Load(path); err != nil || notes != nil {
+		t.Fatalf("expected no notes before saving, got %v, %v", notes, err)
+	}
+
+	notes, _ := Add(nil, Note{Kind: Post, Target: "123", Text: "case study"})
+	notes, _ = Add(notes, Note{Kind: User, Target: "someone", Text: "met at conf"})
+	if err := Save(path, notes); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil || len(loaded) != 2 {
+		t.Fatalf("expected two notes, got %v, %v", loaded, err)
+	}
+
+	loaded, removed := Remove(loaded, User, "@SOMEONE")
+	if !removed || len(loaded) != 1 || loaded[0].Target != "123" {
+		t.Errorf("expected the user note removed, got %+v", loaded)
+	}
+	if _, removed := Remove(loaded, User, "someone"); removed {
+		t.Error("expected removing twice to find nothing")
+	}
+}
+
+func TestIndexPost(t *testing.T) {
+	ix := NewIndex([]Note{
+		{Kind: Post, Target: "1", Text: "case study"},
+		{Kind: User, Target: "alice", Text: "met at conf"},
+	})
+	tests := []struct {
+		id, username, want string
+	}{
+		{"1", "bob", "case study"},
+		{"2", "Alice", "met at conf"},
+		{"1", "alice", "case study; @alice: met at conf"},
+		{"2", "bob", ""},
+	}
+	for _, tt := range tests {
+		if got := ix.Post(tt.id, tt.username); got != tt.want {
+			t.Errorf("Post(%q, %q) = %q, want %q", tt.id, tt.username, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSMTPPort is the submission port, which upgrades to TLS with
+// STARTTLS
+const DefaultSMTPPort = 587
+
+// smtpsPort is the port of SMTP over implicit TLS
+const smtpsPort = 465
+
+// EmailSettings are the SMTP settings, kept as email.<name> beside the
+// targets of each event
+var EmailSettings = []string{"host", "port", "from", "username"}
+
+// CheckEmailSetting checks the value of the SMTP setting name.
+func CheckEmailSetting(name, value string) error {
+	switch name {
+	case "host":
+		if strings.ContainsAny(value, " /:") {
+			return fmt.Errorf("%q is not a host name", value)
+		}
+	case "port":
+		if port, err := strconv.Atoi(value); err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("%q is not a port number", value)
+		}
+	case "from":
+		if _, err := mail.ParseAddress(value); err != nil {
+			return fmt.Errorf("%q is not an email address", value)
+		}
+	case "username":
+	default:
+		return fmt.Errorf("unknown email setting %q", name)
+	}
+	return nil
+}
+
+// SMTP is the server emails are sent through.
+type SMTP struct {
+	Host string
+	// Port is DefaultSMTPPort if zero; 465 connects with TLS at once, any
+	// other port upgrades with STARTTLS when the server offers it
+	Port int
+	// Username and Password log in if Username is set. The password is
+	// only sent over TLS, or to a server on this machine.
+	Username string
+	Password string
+	From     string
+}
+
+// SendEmail emails m to the addresses to through s.
+func SendEmail(ctx context.Context, s SMTP, to []string, m Message) error {
+	if s.Host == "" || s.From == "" {
+		return errors.New("no SMTP server configured")
+	}
+	from, err := mail.ParseAddress(s.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender %q: %w", s.From, err)
+	}
+	recipients := make([]*mail.Address, len(to))
+	for i, address := range to {
+		if recipients[i], err = mail.ParseAddress(address); err != nil {
+			return fmt.Errorf("invalid recipient %q: %w", address, err)
+		}
+	}
+	if len(recipients) == 0 {
+		return errors.New("no recipients")
+	}
+	body, err := emailBody(from, recipients, m, time.Now())
+	if err != nil {
+		return err
+	}
+
+	port := s.Port
+	if port == 0 {
+		port = DefaultSMTPPort
+	}
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(port))
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	var conn net.Conn
+	if port == smtpsPort {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: s.Host, MinVersion: tls.VersionTLS12}}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("smtp %s: %w", addr, err)
+	}
+	// net/smtp takes no context, so its deadline bounds the conversation
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline) //nolint:errcheck,gosec // Best-effort bound
+	} else {
+		conn.SetDeadline(time.Now().Add(time.Minute)) //nolint:errcheck,gosec // Best-effort bound
+	}
+
+	client, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		conn.Close() //nolint:errcheck,gosec // The error above is the one reported
+		return fmt.Errorf("smtp %s: %w", addr, err)
+	}
+	defer client.Close() //nolint:errcheck // Quit below reports failures
+
+	if err := sendSMTP(client, s, from, recipients, body); err != nil {
+		return fmt.Errorf("smtp %s: %w", addr, err)
+	}
+	return nil
+}
+
+// sendSMTP holds the conversation with the server of s
+func sendSMTP(client *smtp.Client, s SMTP, from *mail.Address, to []*mail.Address, body []byte) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+	if s.Username != "" {
+		// PlainAuth refuses to send the password without TLS, except to
+		// localhost
+		if err := client.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return fmt.Errorf("login failed: %w", err)
+		}
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, address := range to {
+		if err := client.Rcpt(address.Address); err != nil {
+			return fmt.Errorf("recipient %s: %w", address.Address, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// emailBody is m as a plain-text email. The title is the subject; the text
+// and link are quoted-printable, so long lines and any character survive.
+func emailBody(from *mail.Address, to []*mail.Address, m Message, now time.Time) ([]byte, error) {
+	addresses := make([]string, len(to))
+	for i, address := range to {
+		addresses[i] = address.String()
+	}
+
+	var b bytes.Buffer
+	header := func(name, value string) {
+		b.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", from.String())
+	header("To", strings.Join(addresses, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Title))
+	header("Date", now.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	if m.Event != "" {
+		header("X-Threads-Event", m.Event)
+	}
+	b.WriteString("\r\n")
+
+	var parts []string
+	if m.Text != "" {
+		parts = append(parts, m.Text)
+	}
+	if m.Link != "" {
+		parts = append(parts, m.Link)
+	}
+	text := strings.ReplaceAll(strings.Join(parts, "\n\n"), "\r\n", "\n")
+	qp := quotedprintable.NewWriter(&b)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(text, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	b.WriteString("\r\n")
+	return b.Bytes(), nil
+}
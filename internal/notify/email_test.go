@@ -0,0 +1,166 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpSession is what a client told fakeSMTP
+type smtpSession struct {
+	Auth string
+	From string
+	To   []string
+	Data string
+}
+
+// fakeSMTP accepts one message without TLS, answering RCPT of reject with
+// an error
+func fakeSMTP(t *testing.T, reject string) (host string, port int, got chan smtpSession) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() }) //nolint:errcheck,gosec // Test cleanup
+	got = make(chan smtpSession, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close() //nolint:errcheck // Test server
+		var session smtpSession
+		defer func() { got <- session }()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) } //nolint:errcheck,gosec // Test server
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch verb {
+			case "EHLO":
+				reply("250-localhost")
+				reply("250 AUTH PLAIN")
+			case "AUTH":
+				session.Auth = line
+				reply("235 Authenticated")
+			case "MAIL":
+				session.From = line
+				reply("250 OK")
+			case "RCPT":
+				if reject != "" && strings.Contains(line, reject) {
+					reply("550 No such user")
+					continue
+				}
+				session.To = append(session.To, line)
+				reply("250 OK")
+			case "DATA":
+				reply("354 Go ahead")
+				var data strings.Builder
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				session.Data = data.String()
+				reply("250 Queued")
+			case "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	host, portText, _ := net.SplitHostPort(ln.Addr().String())
+	port, _ = strconv.Atoi(portText)
+	return host, port, got
+}
+
+func TestSendEmail(t *testing.T) {
+	host, port, got := fakeSMTP(t, "")
+	s := SMTP{Host: host, Port: port, Username: "bot", Password: "hunter2", From: "Threads <bot@example.com>"}
+	m := Message{Event: "publish_failed", Title: "Failed to publish queued post q1 — ünïcode", Text: "Invalid parameter\n\n" + strings.Repeat("long ", 40), Link: "https://www.threads.net/t/1"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := SendEmail(ctx, s, []string{"ops@example.com", "Ana <ana@example.com>"}, m); err != nil {
+		t.Fatal(err)
+	}
+	session := <-got
+
+	if auth, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(session.Auth, "AUTH PLAIN ")); string(auth) != "\x00bot\x00hunter2" {
+		t.Errorf("expected a PLAIN login, got %q", session.Auth)
+	}
+	if session.From != "MAIL FROM:<bot@example.com>" || len(session.To) != 2 || session.To[1] != "RCPT TO:<ana@example.com>" {
+		t.Errorf("unexpected envelope: %+v", session)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(session.Data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != m.Title {
+		t.Errorf("expected the title as subject, got %q (%v)", subject, err)
+	}
+	if msg.Header.Get("X-Threads-Event") != "publish_failed" {
+		t.Errorf("expected the event header, got %v", msg.Header)
+	}
+	body := new(strings.Builder)
+	if _, err := bufio.NewReader(quotedprintable.NewReader(msg.Body)).WriteTo(body); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.ReplaceAll(m.Text+"\n\n"+m.Link, "\n", "\r\n") + "\r\n"
+	if body.String() != want {
+		t.Errorf("unexpected body:\n%q\nwant\n%q", body.String(), want)
+	}
+}
+
+func TestSendEmail_Errors(t *testing.T) {
+	ctx := context.Background()
+	m := Message{Title: "hi"}
+	if err := SendEmail(ctx, SMTP{From: "bot@example.com"}, []string{"ops@example.com"}, m); err == nil {
+		t.Error("expected an error without a server")
+	}
+	if err := SendEmail(ctx, SMTP{Host: "localhost", From: "bot@example.com"}, []string{"not an address"}, m); err == nil {
+		t.Error("expected a bad recipient rejected")
+	}
+
+	host, port, _ := fakeSMTP(t, "gone@")
+	err := SendEmail(ctx, SMTP{Host: host, Port: port, From: "bot@example.com"}, []string{"ops@example.com", "gone@example.com"}, m)
+	if err == nil || !strings.Contains(err.Error(), "recipient gone@example.com") {
+		t.Errorf("expected the rejected recipient named, got %v", err)
+	}
+}
+
+func TestCheckEmailSetting(t *testing.T) {
+	for _, ok := range [][2]string{{"host", "smtp.example.com"}, {"port", "465"}, {"from", "Threads <bot@example.com>"}, {"username", "bot"}} {
+		if err := CheckEmailSetting(ok[0], ok[1]); err != nil {
+			t.Errorf("expected %s=%q accepted, got %v", ok[0], ok[1], err)
+		}
+	}
+	for _, bad := range [][2]string{{"host", "smtp://example.com"}, {"port", "99999"}, {"from", "bot"}, {"password", "x"}} {
+		if err := CheckEmailSetting(bad[0], bad[1]); err == nil {
+			t.Errorf("expected %s=%q rejected", bad[0], bad[1])
+		}
+	}
+}
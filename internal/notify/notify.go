@@ -0,0 +1,212 @@
+// Package notify sends short messages about events to chat and HTTP
+// endpoints: Slack incoming webhooks, Discord webhooks, any URL that
+// accepts a JSON POST, or email addresses through an SMTP server.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kind is the message format a target expects.
+type Kind string
+
+// Target kinds
+const (
+	KindSlack   Kind = "slack"
+	KindDiscord Kind = "discord"
+	// KindHTTP is any endpoint taking the message and the event as JSON
+	KindHTTP Kind = "http"
+	// KindEmail is a mailto: address, sent with SendEmail
+	KindEmail Kind = "email"
+)
+
+// Kinds lists the target kinds.
+func Kinds() []Kind {
+	return []Kind{KindSlack, KindDiscord, KindHTTP, KindEmail}
+}
+
+// discordMaxContent is the longest message Discord accepts
+const discordMaxContent = 2000
+
+// Target is an endpoint notified of events.
+type Target struct {
+	Kind Kind
+	URL  string
+}
+
+// Address is the email address of a KindEmail target.
+func (t Target) Address() string {
+	return strings.TrimPrefix(t.URL, "mailto:")
+}
+
+// String returns the target without the path of its URL, which holds the
+// secret of Slack and Discord webhooks.
+func (t Target) String() string {
+	if t.Kind == KindEmail {
+		return string(t.Kind) + " " + t.Address()
+	}
+	if u, err := url.Parse(t.URL); err == nil && u.Host != "" {
+		return string(t.Kind) + " " + u.Scheme + "://" + u.Host
+	}
+	return string(t.Kind)
+}
+
+// ParseTargets reads a comma-separated list of URLs. The kind of each is
+// told by its host, hooks.slack.com or discord.com, and can be given as a
+// prefix such as "slack:" for compatible services on other hosts. A
+// mailto: URL is an email address.
+func ParseTargets(value string) ([]Target, error) {
+	var targets []Target
+	for _, spec := range strings.Split(value, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		var target Target
+		for _, kind := range Kinds() {
+			if rest, ok := strings.CutPrefix(spec, string(kind)+":"); ok && !strings.HasPrefix(rest, "//") {
+				target.Kind, spec = kind, rest
+				break
+			}
+		}
+		address, isMailto := strings.CutPrefix(spec, "mailto:")
+		if isMailto && target.Kind == "" {
+			target.Kind = KindEmail
+		}
+		if target.Kind == KindEmail {
+			parsed, err := mail.ParseAddress(address)
+			if err != nil || parsed.Name != "" {
+				return nil, fmt.Errorf("%q is not an email address", address)
+			}
+			targets = append(targets, Target{Kind: KindEmail, URL: "mailto:" + parsed.Address})
+			continue
+		}
+		u, err := url.Parse(spec)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%q is not an http(s) URL", spec)
+		}
+		target.URL = spec
+		if target.Kind == "" {
+			target.Kind = kindOf(u)
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+func kindOf(u *url.URL) Kind {
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "hooks.slack.com":
+		return KindSlack
+	case (host == "discord.com" || host == "discordapp.com" || strings.HasSuffix(host, ".discord.com")) && strings.HasPrefix(u.Path, "/api/webhooks/"):
+		return KindDiscord
+	default:
+		return KindHTTP
+	}
+}
+
+// Message is what a notification says about an event.
+type Message struct {
+	// Event is the event type, such as post_published
+	Event string
+	// Title is a one-line summary
+	Title string
+	// Text is the detail, such as the text of a post; may be empty
+	Text string
+	// Link points at the post the event is about; may be empty
+	Link string
+	// Data is the event itself, sent to http targets
+	Data any
+}
+
+// Send delivers m to t with client. Any 2xx answer is success. Email
+// targets are sent with SendEmail instead.
+func Send(ctx context.Context, client *http.Client, t Target, m Message) error {
+	if t.Kind == KindEmail {
+		return fmt.Errorf("%s: email is sent through SMTP", t)
+	}
+	body, err := json.Marshal(payload(t.Kind, m))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: %w", t, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		// The error repeats the URL, and with it the webhook secret
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("%s: %w", t, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // Read-only body
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16)) //nolint:errcheck,gosec // Drained for connection reuse
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned %s", t, resp.Status)
+	}
+	return nil
+}
+
+// payload is the request body of m for a target of kind
+func payload(kind Kind, m Message) any {
+	switch kind {
+	case KindSlack:
+		lines := []string{"*" + slackEscape(m.Title) + "*"}
+		if m.Text != "" {
+			lines = append(lines, slackEscape(m.Text))
+		}
+		if m.Link != "" {
+			lines = append(lines, m.Link)
+		}
+		return map[string]string{"text": strings.Join(lines, "\n")}
+	case KindDiscord:
+		lines := []string{"**" + m.Title + "**"}
+		if m.Link != "" {
+			lines = append(lines, m.Link)
+		}
+		if m.Text != "" {
+			// The text gives way so the title and link always fit
+			room := discordMaxContent - utf8.RuneCountInString(strings.Join(lines, "\n")) - 1
+			lines = slices.Insert(lines, 1, truncate(m.Text, max(room, 1)))
+		}
+		// Mentions in post text must not ping the server
+		return map[string]any{
+			"content":          strings.Join(lines, "\n"),
+			"allowed_mentions": map[string]any{"parse": []string{}},
+		}
+	default:
+		return map[string]any{"event": m.Event, "title": m.Title, "text": m.Text, "link": m.Link, "data": m.Data}
+	}
+}
+
+// slackEscape escapes the characters Slack reads as markup
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// truncate cuts s to at most n characters, ending it with "…" if cut
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParseTargets(t *testing.T) {
+	targets, err := ParseTargets("https://hooks.slack.com/services/T/B/x, https://discord.com/api/webhooks/1/y,slack:https://chat.example.com/hooks/z,https://example.com/notify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Kind{KindSlack, KindDiscord, KindSlack, KindHTTP}
+	if len(targets) != len(want) {
+		t.Fatalf("expected %d targets, got %+v", len(want), targets)
+	}
+	for i, kind := range want {
+		if targets[i].Kind != kind {
+			t.Errorf("target %d: expected %s, got %s", i, kind, targets[i].Kind)
+		}
+	}
+	if targets[2].URL != "https://chat.example.com/hooks/z" {
+		t.Errorf("expected the prefix removed, got %q", targets[2].URL)
+	}
+	if s := targets[0].String(); s != "slack https://hooks.slack.com" {
+		t.Errorf("expected the secret path hidden, got %q", s)
+	}
+
+	targets, err = ParseTargets("mailto:ops@example.com,email:ana@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(targets) != 2 || targets[0].Kind != KindEmail || targets[1].Address() != "ana@example.com" || targets[0].String() != "email ops@example.com" {
+		t.Errorf("expected two email targets, got %+v", targets)
+	}
+
+	for _, bad := range []string{"hooks.slack.com/x", "ftp://example.com", "discord:", "mailto:ops", "email:https://example.com", "slack:mailto:ops@example.com"} {
+		if _, err := ParseTargets(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestSend_Payloads(t *testing.T) {
+	var got map[string]any
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = nil
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("invalid JSON: %v", err)
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	m := Message{Event: "new_mention", Title: "@ana mentioned you", Text: "a <b> & c", Link: "https://www.threads.net/t/1", Data: map[string]string{"id": "1"}}
+	ctx := context.Background()
+
+	if err := Send(ctx, server.Client(), Target{Kind: KindSlack, URL: server.URL}, m); err != nil {
+		t.Fatal(err)
+	}
+	if got["text"] != "*@ana mentioned you*\na &lt;b&gt; &amp; c\nhttps://www.threads.net/t/1" {
+		t.Errorf("unexpected Slack message: %v", got)
+	}
+
+	m.Text = strings.Repeat("x", 3000)
+	if err := Send(ctx, server.Client(), Target{Kind: KindDiscord, URL: server.URL}, m); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := got["content"].(string)
+	if utf8.RuneCountInString(content) > discordMaxContent || !strings.HasSuffix(content, "https://www.threads.net/t/1") {
+		t.Errorf("expected the text cut to fit the link, got %d characters", utf8.RuneCountInString(content))
+	}
+
+	if err := Send(ctx, server.Client(), Target{Kind: KindHTTP, URL: server.URL}, m); err != nil {
+		t.Fatal(err)
+	}
+	if got["event"] != "new_mention" || got["data"].(map[string]any)["id"] != "1" {
+		t.Errorf("unexpected HTTP payload: %v", got)
+	}
+
+	status = http.StatusForbidden
+	err := Send(ctx, server.Client(), Target{Kind: KindSlack, URL: server.URL + "/secret-path"}, m)
+	if err == nil || !strings.Contains(err.Error(), "403") || strings.Contains(err.Error(), "secret-path") {
+		t.Errorf("expected a 403 error without the URL path, got %v", err)
+	}
+}
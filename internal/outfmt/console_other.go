@@ -0,0 +1,11 @@
+//go:build !windows
+
+package outfmt
+
+import "os"
+
+// EnableVirtualTerminal reports whether the terminal behind file processes
+// escape codes, which terminals outside Windows always do.
+func EnableVirtualTerminal(file *os.File) bool {
+	return true
+}
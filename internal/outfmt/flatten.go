@@ -0,0 +1,87 @@
+package outfmt
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// WithFlatten enables flattening of nested JSON objects (--flatten)
+func WithFlatten(ctx context.Context, flatten bool) context.Context {
+	return context.WithValue(ctx, flattenKey, flatten)
+}
+
+// GetFlatten reports whether JSON output should be flattened
+func GetFlatten(ctx context.Context) bool {
+	if f, ok := ctx.Value(flattenKey).(bool); ok {
+		return f
+	}
+	return false
+}
+
+// WriteJSONContext writes data as JSON using the query, raw output and
+// flatten settings from the context. In IDs mode only the result IDs are
+// written.
+func WriteJSONContext(ctx context.Context, w io.Writer, data any) error {
+	if GetFormat(ctx) == IDs {
+		return writeIDs(w, data)
+	}
+	data, err := flattenIfEnabled(ctx, data)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, data, GetQuery(ctx), GetRawOutput(ctx))
+}
+
+// Flatten collapses nested JSON objects into a single level with dotted keys,
+// e.g. {"paging":{"cursors":{"after":"x"}}} becomes {"paging.cursors.after":"x"}.
+// Arrays are kept; objects inside them are flattened individually. data is
+// first round-tripped through encoding/json so struct tags are honoured.
+func Flatten(data any) (any, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return flattenValue(v), nil
+}
+
+func flattenIfEnabled(ctx context.Context, data any) (any, error) {
+	if !GetFlatten(ctx) {
+		return data, nil
+	}
+	return Flatten(data)
+}
+
+func flattenValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		flattenInto(out, "", v)
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = flattenValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func flattenInto(out map[string]any, prefix string, obj map[string]any) {
+	for key, value := range obj {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+			flattenInto(out, key, nested)
+			continue
+		}
+		out[key] = flattenValue(value)
+	}
+}
@@ -0,0 +1,91 @@
+package outfmt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	input := map[string]any{
+		"id": "1",
+		"paging": map[string]any{
+			"cursors": map[string]any{"after": "abc"},
+		},
+		"data": []any{
+			map[string]any{"name": "x", "owner": map[string]any{"id": "7"}},
+		},
+		"tags":  []string{"a", "b"},
+		"empty": map[string]any{},
+	}
+
+	got, err := Flatten(input)
+	if err != nil {
+		t.Fatalf("Flatten: %v", err)
+	}
+
+	want := map[string]any{
+		"id":                   "1",
+		"paging.cursors.after": "abc",
+		"data": []any{
+			map[string]any{"name": "x", "owner.id": "7"},
+		},
+		"tags":  []any{"a", "b"},
+		"empty": map[string]any{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Flatten() = %#v, want %#v", got, want)
+	}
+}
+
+func TestFlatten_HonoursStructTags(t *testing.T) {
+	type inner struct {
+		After string `json:"after"`
+	}
+	type outer struct {
+		Paging inner `json:"paging"`
+	}
+
+	got, err := Flatten(outer{Paging: inner{After: "x"}})
+	if err != nil {
+		t.Fatalf("Flatten: %v", err)
+	}
+	if want := map[string]any{"paging.after": "x"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Flatten() = %#v, want %#v", got, want)
+	}
+}
+
+func TestWriteJSONContext_FlattenThenQuery(t *testing.T) {
+	ctx := WithFlatten(context.Background(), true)
+	ctx = WithQuery(ctx, `.["a.b"]`)
+
+	var buf bytes.Buffer
+	if err := WriteJSONContext(ctx, &buf, map[string]any{"a": map[string]any{"b": 2}}); err != nil {
+		t.Fatalf("WriteJSONContext: %v", err)
+	}
+
+	var got int
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if got != 2 {
+		t.Errorf("got %d, want 2", got)
+	}
+}
+
+func TestWriteJSONContext_NoFlatten(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSONContext(context.Background(), &buf, map[string]any{"a": map[string]any{"b": 2}}); err != nil {
+		t.Fatalf("WriteJSONContext: %v", err)
+	}
+
+	var got map[string]map[string]int
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if got["a"]["b"] != 2 {
+		t.Errorf("expected nested output, got %s", buf.String())
+	}
+}
@@ -0,0 +1,93 @@
+package outfmt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ErrNoIDs is returned in IDs mode when a command's output has no IDs.
+var ErrNoIDs = errors.New("output has no IDs")
+
+// writeIDs prints the ID of each result in data, one per line. An object with
+// an "id" field is a single result; otherwise the objects in its arrays (such
+// as "data" or "posts") are the results. Paging and other metadata are dropped.
+func writeIDs(w io.Writer, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return err
+	}
+
+	ids, ok := collectIDs(v)
+	if !ok {
+		return ErrNoIDs
+	}
+	for _, id := range ids {
+		if _, err := fmt.Fprintln(w, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectIDs returns the result IDs in v and whether v has results at all;
+// an empty list has results but no IDs
+func collectIDs(v any) ([]string, bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		if id, ok := objectID(v); ok {
+			return []string{id}, true
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var ids []string
+		found := false
+		for _, key := range keys {
+			if list, ok := v[key].([]any); ok {
+				found = true
+				listIDs, _ := collectIDs(list)
+				ids = append(ids, listIDs...)
+			}
+		}
+		return ids, found
+	case []any:
+		var ids []string
+		for _, item := range v {
+			if obj, ok := item.(map[string]any); ok {
+				if id, ok := objectID(obj); ok {
+					ids = append(ids, id)
+				}
+			}
+		}
+		return ids, true
+	default:
+		return nil, false
+	}
+}
+
+// objectID returns the "id" field of obj; table rows use the "ID" header
+func objectID(obj map[string]any) (string, bool) {
+	for key, value := range obj {
+		if !strings.EqualFold(key, "id") {
+			continue
+		}
+		switch id := value.(type) {
+		case string:
+			return id, id != ""
+		case float64:
+			return fmt.Sprintf("%.0f", id), true
+		}
+	}
+	return "", false
+}
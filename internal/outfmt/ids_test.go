@@ -0,0 +1,54 @@
+package outfmt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWriteJSONContext_IDs(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		want string
+	}{
+		{"single object", map[string]any{"id": "123", "text": "hi"}, "123\n"},
+		{"list with paging", map[string]any{
+			"data":   []map[string]any{{"id": "1"}, {"id": "2"}},
+			"paging": map[string]any{"cursors": map[string]any{"after": "x"}},
+		}, "1\n2\n"},
+		{"top-level array", []map[string]any{{"id": "a"}, {"name": "no id"}, {"id": "b"}}, "a\nb\n"},
+		{"table rows", []map[string]string{{"ID": "9", "TEXT": "x"}}, "9\n"},
+		{"empty list", map[string]any{"posts": []any{}}, ""},
+		{"numeric id", map[string]any{"id": 42}, "42\n"},
+	}
+
+	ctx := WithFormat(context.Background(), "ids")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteJSONContext(ctx, &buf, tt.data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteJSONContext_NoIDs(t *testing.T) {
+	ctx := WithFormat(context.Background(), "ids")
+	var buf bytes.Buffer
+	err := WriteJSONContext(ctx, &buf, map[string]any{"remaining": 5})
+	if !errors.Is(err, ErrNoIDs) {
+		t.Errorf("expected ErrNoIDs, got %v", err)
+	}
+}
+
+func TestIsJSON_IDs(t *testing.T) {
+	if !IsJSON(WithFormat(context.Background(), "ids")) {
+		t.Error("ids output should build structured output")
+	}
+}
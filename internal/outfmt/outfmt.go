@@ -2,13 +2,14 @@ package outfmt
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"text/tabwriter"
 
-	"github.com/itchyny/gojq"
 	"golang.org/x/term"
 )
 
@@ -18,6 +19,12 @@ type Format int
 const (
 	Text Format = iota
 	JSON
+	// IDs prints only result IDs, one per line (-o ids, --id-only)
+	IDs
+	// CSV and TSV print tables as comma or tab separated values with a
+	// header row (-o csv, -o tsv)
+	CSV
+	TSV
 )
 
 // ParseFormat parses an output format string.
@@ -25,6 +32,12 @@ func ParseFormat(value string) Format {
 	switch value {
 	case "json":
 		return JSON
+	case "ids":
+		return IDs
+	case "csv":
+		return CSV
+	case "tsv":
+		return TSV
 	default:
 		return Text
 	}
@@ -33,11 +46,15 @@ func ParseFormat(value string) Format {
 type contextKey string
 
 const (
-	formatKey contextKey = "output_format"
-	queryKey  contextKey = "output_query"
-	yesKey    contextKey = "yes_flag"
-	limitKey  contextKey = "limit_flag"
-	colorKey  contextKey = "output_color"
+	formatKey  contextKey = "output_format"
+	queryKey   contextKey = "output_query"
+	yesKey     contextKey = "yes_flag"
+	limitKey   contextKey = "limit_flag"
+	colorKey   contextKey = "output_color"
+	flattenKey contextKey = "output_flatten"
+	rawKey     contextKey = "output_raw"
+	footerKey  contextKey = "output_footer"
+	strictKey  contextKey = "strict_flag"
 )
 
 // ColorMode controls colored output.
@@ -109,9 +126,9 @@ func formatColumn(value string, colType ColumnType, colorEnabled bool) string {
 // formatStatus colors status values based on their meaning
 func formatStatus(status string) string {
 	switch status {
-	case "PUBLISHED", "ACTIVE", "FINISHED", "COMPLETED", "SUCCESS":
+	case "PUBLISHED", "ACTIVE", "FINISHED", "COMPLETED", "SUCCESS", "SUCCEEDED":
 		return colorGreen + status + colorReset
-	case "IN_PROGRESS", "PUBLISHING", "PENDING", "PROCESSING":
+	case "IN_PROGRESS", "PUBLISHING", "PENDING", "PROCESSING", "SKIPPED":
 		return colorYellow + status + colorReset
 	case "FAILED", "ERROR", "CANCELLED", "REJECTED":
 		return colorRed + status + colorReset
@@ -153,12 +170,7 @@ func NewContext(ctx context.Context, format Format) context.Context {
 
 // WithFormat adds output format to context (string-based for CLI flags)
 func WithFormat(ctx context.Context, format string) context.Context {
-	switch format {
-	case "json":
-		return context.WithValue(ctx, formatKey, JSON)
-	default:
-		return context.WithValue(ctx, formatKey, Text)
-	}
+	return context.WithValue(ctx, formatKey, ParseFormat(format))
 }
 
 // WithQuery adds JQ query to context
@@ -187,11 +199,38 @@ func GetYes(ctx context.Context) bool {
 	return false
 }
 
+// WithStrict adds strict flag to context (warnings fail the command and
+// nothing prompts)
+func WithStrict(ctx context.Context, strict bool) context.Context {
+	return context.WithValue(ctx, strictKey, strict)
+}
+
+// GetStrict retrieves strict flag from context
+func GetStrict(ctx context.Context) bool {
+	if s, ok := ctx.Value(strictKey).(bool); ok {
+		return s
+	}
+	return false
+}
+
 // WithLimit adds limit to context
 func WithLimit(ctx context.Context, limit int) context.Context {
 	return context.WithValue(ctx, limitKey, limit)
 }
 
+// WithFooter enables the paging summary after list output
+func WithFooter(ctx context.Context, footer bool) context.Context {
+	return context.WithValue(ctx, footerKey, footer)
+}
+
+// GetFooter reports whether list output should end with a paging summary
+func GetFooter(ctx context.Context) bool {
+	if f, ok := ctx.Value(footerKey).(bool); ok {
+		return f
+	}
+	return false
+}
+
 // WithColorMode adds color mode to context
 func WithColorMode(ctx context.Context, mode ColorMode) context.Context {
 	return context.WithValue(ctx, colorKey, mode)
@@ -221,9 +260,18 @@ func GetFormat(ctx context.Context) Format {
 	return Text
 }
 
-// IsJSON checks if context has JSON output format
+// IsJSON checks if commands should build structured output. That is the
+// case for JSON and for IDs, which is rendered from the same data.
 func IsJSON(ctx context.Context) bool {
-	return GetFormat(ctx) == JSON
+	format := GetFormat(ctx)
+	return format == JSON || format == IDs
+}
+
+// IsDelimited checks if tables should be printed as CSV or TSV rather than
+// aligned text
+func IsDelimited(ctx context.Context) bool {
+	format := GetFormat(ctx)
+	return format == CSV || format == TSV
 }
 
 // Output writes data in the appropriate format (legacy, use Formatter.Output instead)
@@ -245,13 +293,7 @@ func WriteJSON(data any, query string) error {
 
 // WriteJSONTo outputs JSON to a writer, optionally filtered by JQ query.
 func WriteJSONTo(w io.Writer, data any, query string) error {
-	if query != "" {
-		return writeFilteredJSONTo(w, data, query)
-	}
-
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-	return enc.Encode(data)
+	return writeJSON(w, data, query, false)
 }
 
 // writeFilteredJSON is a legacy helper that writes to stdout.
@@ -260,43 +302,7 @@ func writeFilteredJSON(data any, query string) error {
 }
 
 func writeFilteredJSONTo(w io.Writer, data any, query string) error {
-	q, err := gojq.Parse(query)
-	if err != nil {
-		return fmt.Errorf("invalid jq query: %w", err)
-	}
-
-	code, err := gojq.Compile(q)
-	if err != nil {
-		return fmt.Errorf("failed to compile jq query: %w", err)
-	}
-
-	// Convert data to interface{} for gojq
-	jsonBytes, err := json.Marshal(data)
-	if err != nil {
-		return err
-	}
-	var input any
-	if err := json.Unmarshal(jsonBytes, &input); err != nil {
-		return err
-	}
-
-	iter := code.Run(input)
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-
-	for {
-		v, ok := iter.Next()
-		if !ok {
-			break
-		}
-		if err, ok := v.(error); ok {
-			return err
-		}
-		if err := enc.Encode(v); err != nil {
-			return err
-		}
-	}
-	return nil
+	return runQuery(w, data, query, false)
 }
 
 // OutputOption configures the Formatter
@@ -315,6 +321,8 @@ type Formatter struct {
 	ctx context.Context
 	out io.Writer
 	w   *tabwriter.Writer
+	// delim replaces w for CSV and TSV output
+	delim *csv.Writer
 }
 
 // NewFormatter creates a new text formatter (legacy, use FromContext instead)
@@ -336,11 +344,21 @@ func FromContext(ctx context.Context, opts ...OutputOption) *Formatter {
 	for _, opt := range opts {
 		opt(f)
 	}
+	if IsDelimited(ctx) {
+		f.delim = csv.NewWriter(f.out)
+		if GetFormat(ctx) == TSV {
+			f.delim.Comma = '\t'
+		}
+	}
 	return f
 }
 
 // Header writes a header row
 func (f *Formatter) Header(cols ...string) {
+	if f.delim != nil {
+		f.delim.Write(cols) //nolint:errcheck,gosec // Best-effort output
+		return
+	}
 	for i, col := range cols {
 		if i > 0 {
 			fmt.Fprint(f.w, "\t") //nolint:errcheck // Best-effort output
@@ -352,6 +370,14 @@ func (f *Formatter) Header(cols ...string) {
 
 // Row writes a data row
 func (f *Formatter) Row(cols ...any) {
+	if f.delim != nil {
+		record := make([]string, len(cols))
+		for i, col := range cols {
+			record[i] = fmt.Sprint(col)
+		}
+		f.delim.Write(record) //nolint:errcheck,gosec // Best-effort output
+		return
+	}
 	for i, col := range cols {
 		if i > 0 {
 			fmt.Fprint(f.w, "\t") //nolint:errcheck // Best-effort output
@@ -363,6 +389,10 @@ func (f *Formatter) Row(cols ...any) {
 
 // Flush writes all buffered output
 func (f *Formatter) Flush() {
+	if f.delim != nil {
+		f.delim.Flush()
+		return
+	}
 	f.w.Flush() //nolint:errcheck,gosec // Best-effort flush
 }
 
@@ -382,9 +412,9 @@ func (f *Formatter) colorEnabled() bool {
 		return false
 	}
 
-	// Check if output is a TTY
+	// Check if output is a TTY that understands escape codes
 	if file, ok := f.out.(*os.File); ok {
-		return term.IsTerminal(int(file.Fd()))
+		return term.IsTerminal(int(file.Fd())) && EnableVirtualTerminal(file)
 	}
 
 	// Non-file writers (like bytes.Buffer in tests) - disable color
@@ -397,6 +427,9 @@ func (f *Formatter) Table(headers []string, rows [][]string, colTypes []ColumnTy
 	if IsJSON(f.ctx) {
 		return f.tableJSON(headers, rows)
 	}
+	if f.delim != nil {
+		return f.tableDelimited(headers, rows)
+	}
 
 	// Text mode - use tabwriter
 	return f.tableText(headers, rows, colTypes)
@@ -415,55 +448,19 @@ func (f *Formatter) tableJSON(headers []string, rows [][]string) error {
 		result = append(result, obj)
 	}
 
-	query := GetQuery(f.ctx)
-	if query != "" {
-		return f.writeFilteredJSONTo(result, query)
-	}
-
-	enc := json.NewEncoder(f.out)
-	enc.SetIndent("", "  ")
-	return enc.Encode(result)
+	return WriteJSONContext(f.ctx, f.out, result)
 }
 
-// writeFilteredJSONTo applies JQ filter and writes to output
-func (f *Formatter) writeFilteredJSONTo(data any, query string) error {
-	q, err := gojq.Parse(query)
-	if err != nil {
-		return fmt.Errorf("invalid jq query: %w", err)
-	}
-
-	code, err := gojq.Compile(q)
-	if err != nil {
-		return fmt.Errorf("failed to compile jq query: %w", err)
-	}
-
-	// Convert data to interface{} for gojq
-	jsonBytes, err := json.Marshal(data)
-	if err != nil {
+// tableDelimited outputs table data as CSV or TSV, quoting cells that
+// contain the separator, quotes or line breaks
+func (f *Formatter) tableDelimited(headers []string, rows [][]string) error {
+	if err := f.delim.Write(headers); err != nil {
 		return err
 	}
-	var input any
-	if err := json.Unmarshal(jsonBytes, &input); err != nil {
+	if err := f.delim.WriteAll(rows); err != nil {
 		return err
 	}
-
-	iter := code.Run(input)
-	enc := json.NewEncoder(f.out)
-	enc.SetIndent("", "  ")
-
-	for {
-		v, ok := iter.Next()
-		if !ok {
-			break
-		}
-		if err, ok := v.(error); ok {
-			return err
-		}
-		if err := enc.Encode(v); err != nil {
-			return err
-		}
-	}
-	return nil
+	return f.delim.Error()
 }
 
 // tableText outputs table data in aligned text format
@@ -485,6 +482,8 @@ func (f *Formatter) tableText(headers []string, rows [][]string, colTypes []Colu
 			if i > 0 {
 				fmt.Fprint(f.w, "\t") //nolint:errcheck // Best-effort output
 			}
+			// A tab in a cell would start a new column
+			cell = strings.ReplaceAll(cell, "\t", " ")
 			// Apply column type formatting if provided
 			if colTypes != nil && i < len(colTypes) {
 				cell = formatColumn(cell, colTypes[i], colorOn)
@@ -500,13 +499,7 @@ func (f *Formatter) tableText(headers []string, rows [][]string, colTypes []Colu
 // Output writes data in the appropriate format (JSON or pretty-print)
 func (f *Formatter) Output(data any) error {
 	if IsJSON(f.ctx) {
-		query := GetQuery(f.ctx)
-		if query != "" {
-			return f.writeFilteredJSONTo(data, query)
-		}
-		enc := json.NewEncoder(f.out)
-		enc.SetIndent("", "  ")
-		return enc.Encode(data)
+		return WriteJSONContext(f.ctx, f.out, data)
 	}
 
 	// For text output, just print the value
@@ -514,8 +507,12 @@ func (f *Formatter) Output(data any) error {
 	return nil
 }
 
-// Empty prints an empty result message
+// Empty prints an empty result message. IDs, CSV and TSV output stay
+// empty, so the message cannot be mistaken for a result.
 func (f *Formatter) Empty(msg string) {
+	if format := GetFormat(f.ctx); format == IDs || format == CSV || format == TSV {
+		return
+	}
 	if IsJSON(f.ctx) {
 		enc := json.NewEncoder(f.out)
 		enc.SetIndent("", "  ")
@@ -207,6 +207,8 @@ func TestWithFormat(t *testing.T) {
 	}{
 		{"json", JSON},
 		{"text", Text},
+		{"csv", CSV},
+		{"tsv", TSV},
 		{"", Text},
 		{"invalid", Text},
 	}
@@ -267,6 +269,18 @@ func TestWithYes(t *testing.T) {
 	}
 }
 
+func TestWithStrict(t *testing.T) {
+	ctx := context.Background()
+	if GetStrict(ctx) {
+		t.Error("default strict should be false")
+	}
+
+	ctx = WithStrict(ctx, true)
+	if !GetStrict(ctx) {
+		t.Error("strict should be true after setting")
+	}
+}
+
 func TestWithLimit(t *testing.T) {
 	ctx := context.Background()
 	if GetLimit(ctx) != 0 {
@@ -381,6 +395,49 @@ func TestFormatter_Table_WithColumnTypes(t *testing.T) {
 	}
 }
 
+func TestFormatter_Table_Delimited(t *testing.T) {
+	headers := []string{"ID", "TEXT"}
+	rows := [][]string{{"1", "plain"}, {"2", "has, comma and \"quotes\""}, {"3", "two\nlines\tand a tab"}}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"csv", "ID,TEXT\n1,plain\n2,\"has, comma and \"\"quotes\"\"\"\n3,\"two\nlines\tand a tab\"\n"},
+		{"tsv", "ID\tTEXT\n1\tplain\n2\t\"has, comma and \"\"quotes\"\"\"\n3\t\"two\nlines\tand a tab\"\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		f := FromContext(WithFormat(context.Background(), tt.format), WithWriter(&buf))
+		if err := f.Table(headers, rows, []ColumnType{ColumnID, ColumnPlain}); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestFormatter_HeaderRow_Delimited(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := WithColorMode(WithFormat(context.Background(), "tsv"), ColorAlways)
+	f := FromContext(ctx, WithWriter(&buf))
+	f.Header("ACCOUNT", "STATUS")
+	f.Row("work *", "active")
+	f.Row("home", 3)
+	f.Flush()
+
+	if got, want := buf.String(), "ACCOUNT\tSTATUS\nwork *\tactive\nhome\t3\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	FromContext(ctx, WithWriter(&buf)).Empty("Nothing here")
+	if buf.Len() != 0 {
+		t.Errorf("expected empty delimited output, got %q", buf.String())
+	}
+}
+
 func TestFormatter_Output(t *testing.T) {
 	t.Run("text mode", func(t *testing.T) {
 		var buf bytes.Buffer
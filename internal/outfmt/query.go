@@ -0,0 +1,99 @@
+package outfmt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/itchyny/gojq"
+)
+
+// QueryError is returned when a jq query cannot be parsed, compiled or run.
+type QueryError struct {
+	Query string
+	Err   error
+}
+
+func (e *QueryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// WithRawOutput makes string results of a jq query print without quotes (-r)
+func WithRawOutput(ctx context.Context, raw bool) context.Context {
+	return context.WithValue(ctx, rawKey, raw)
+}
+
+// GetRawOutput reports whether raw output is enabled
+func GetRawOutput(ctx context.Context) bool {
+	if r, ok := ctx.Value(rawKey).(bool); ok {
+		return r
+	}
+	return false
+}
+
+// writeJSON writes data as indented JSON, filtered through query if set.
+// With raw, string results are written without quotes, like jq -r.
+func writeJSON(w io.Writer, data any, query string, raw bool) error {
+	if query == "" && !raw {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	}
+	if query == "" {
+		query = "."
+	}
+	return runQuery(w, data, query, raw)
+}
+
+// runQuery runs query against data with the embedded gojq engine and writes
+// each result. All failures are returned as *QueryError.
+func runQuery(w io.Writer, data any, query string, raw bool) error {
+	q, err := gojq.Parse(query)
+	if err != nil {
+		return &QueryError{Query: query, Err: fmt.Errorf("invalid jq query: %w", err)}
+	}
+
+	code, err := gojq.Compile(q)
+	if err != nil {
+		return &QueryError{Query: query, Err: fmt.Errorf("failed to compile jq query: %w", err)}
+	}
+
+	// gojq works on plain JSON values, so round-trip data to honour struct tags
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var input any
+	if err := json.Unmarshal(jsonBytes, &input); err != nil {
+		return err
+	}
+
+	iter := code.Run(input)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := v.(error); ok {
+			return &QueryError{Query: query, Err: fmt.Errorf("jq query failed: %w", err)}
+		}
+		if s, ok := v.(string); ok && raw {
+			if _, err := fmt.Fprintln(w, s); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
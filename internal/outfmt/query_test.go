@@ -0,0 +1,55 @@
+package outfmt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWriteJSONContext_RawOutput(t *testing.T) {
+	ctx := WithQuery(context.Background(), ".[].name")
+	ctx = WithRawOutput(ctx, true)
+
+	var buf bytes.Buffer
+	data := []map[string]any{{"name": "a"}, {"name": "b"}}
+	if err := WriteJSONContext(ctx, &buf, data); err != nil {
+		t.Fatalf("WriteJSONContext: %v", err)
+	}
+	if got := buf.String(); got != "a\nb\n" {
+		t.Errorf("got %q, want raw strings", got)
+	}
+}
+
+func TestWriteJSONContext_RawOutputNonString(t *testing.T) {
+	ctx := WithQuery(context.Background(), ".count")
+	ctx = WithRawOutput(ctx, true)
+
+	var buf bytes.Buffer
+	if err := WriteJSONContext(ctx, &buf, map[string]int{"count": 3}); err != nil {
+		t.Fatalf("WriteJSONContext: %v", err)
+	}
+	if got := buf.String(); got != "3\n" {
+		t.Errorf("got %q, want 3", got)
+	}
+}
+
+func TestRunQuery_ErrorsAreQueryErrors(t *testing.T) {
+	tests := map[string]string{
+		"parse":   ".[invalid",
+		"compile": "$undefined",
+		"runtime": `error("boom")`,
+	}
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := runQuery(&bytes.Buffer{}, map[string]string{"a": "b"}, query, false)
+			var queryErr *QueryError
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("expected *QueryError, got %T: %v", err, err)
+			}
+			if queryErr.Query != query {
+				t.Errorf("expected query %q, got %q", query, queryErr.Query)
+			}
+		})
+	}
+}
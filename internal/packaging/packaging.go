@@ -0,0 +1,349 @@
+// Package packaging generates package manager manifests for a release from
+// the metadata GoReleaser writes to its dist directory.
+package packaging
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// Project describes what is packaged, independent of a release.
+type Project struct {
+	// Name is the binary and package name
+	Name        string
+	Description string
+	Homepage    string
+	License     string
+	// Maintainer is the deb/rpm maintainer, "Name <email>"
+	Maintainer string
+	// Repo is the GitHub repository releases are downloaded from, "owner/name"
+	Repo string
+}
+
+// Archive is a release archive for one platform.
+type Archive struct {
+	Name   string
+	OS     string
+	Arch   string
+	SHA256 string
+}
+
+// Binary is an executable built for one platform; Path is relative to the
+// directory GoReleaser ran in.
+type Binary struct {
+	Path string
+	OS   string
+	Arch string
+}
+
+// Release is one GoReleaser build.
+type Release struct {
+	Tag      string
+	Version  string
+	Archives []Archive
+	Binaries []Binary
+}
+
+// metadata is GoReleaser's dist/metadata.json
+type metadata struct {
+	Tag     string `json:"tag"`
+	Version string `json:"version"`
+}
+
+// artifact is an entry of GoReleaser's dist/artifacts.json
+type artifact struct {
+	Name   string         `json:"name"`
+	Path   string         `json:"path"`
+	Goos   string         `json:"goos"`
+	Goarch string         `json:"goarch"`
+	Type   string         `json:"type"`
+	Extra  map[string]any `json:"extra"`
+}
+
+// Load reads the release GoReleaser built into dist. Archives without a
+// recorded checksum are hashed from disk.
+func Load(dist string) (*Release, error) {
+	var meta metadata
+	if err := readJSON(filepath.Join(dist, "metadata.json"), &meta); err != nil {
+		return nil, err
+	}
+	if meta.Tag == "" || meta.Version == "" {
+		return nil, fmt.Errorf("%s has no tag or version", filepath.Join(dist, "metadata.json"))
+	}
+
+	var artifacts []artifact
+	if err := readJSON(filepath.Join(dist, "artifacts.json"), &artifacts); err != nil {
+		return nil, err
+	}
+
+	release := &Release{Tag: meta.Tag, Version: meta.Version}
+	// Artifact paths are relative to where GoReleaser ran, the parent of dist
+	root := filepath.Dir(filepath.Clean(dist))
+	for _, a := range artifacts {
+		switch a.Type {
+		case "Archive":
+			sum, err := archiveChecksum(a, root)
+			if err != nil {
+				return nil, err
+			}
+			release.Archives = append(release.Archives, Archive{Name: a.Name, OS: a.Goos, Arch: a.Goarch, SHA256: sum})
+		case "Binary":
+			release.Binaries = append(release.Binaries, Binary{Path: a.Path, OS: a.Goos, Arch: a.Goarch})
+		}
+	}
+	if len(release.Archives) == 0 {
+		return nil, fmt.Errorf("no archives in %s; run goreleaser first", filepath.Join(dist, "artifacts.json"))
+	}
+	return release, nil
+}
+
+func readJSON(name string, v any) error {
+	data, err := os.ReadFile(name) //nolint:gosec // Path is chosen by the user
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// archiveChecksum returns the SHA-256 GoReleaser recorded as "sha256:<hex>",
+// or hashes the file
+func archiveChecksum(a artifact, root string) (string, error) {
+	if sum, ok := a.Extra["Checksum"].(string); ok {
+		if hexSum, ok := strings.CutPrefix(sum, "sha256:"); ok {
+			return hexSum, nil
+		}
+	}
+
+	name := filepath.FromSlash(a.Path)
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(root, name)
+	}
+	file, err := os.Open(name) //nolint:gosec // Path comes from GoReleaser's artifact list
+	if err != nil {
+		return "", fmt.Errorf("checksum %s: %w", a.Name, err)
+	}
+	defer file.Close() //nolint:errcheck // Read-only
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("checksum %s: %w", a.Name, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// downloadURL returns where an archive of a release is published
+func (p Project) downloadURL(tag, archive string) string {
+	return "https://github.com/" + p.Repo + "/releases/download/" + tag + "/" + archive
+}
+
+// Generate writes the Homebrew formula, Scoop manifest and nfpm configs for
+// the platforms the release has and returns the paths written, relative to
+// dir. Each manifest is skipped when the release has nothing for it.
+func Generate(p Project, r *Release, dir string) ([]string, error) {
+	files := map[string][]byte{}
+
+	formula, err := homebrewFormula(p, r)
+	if err != nil {
+		return nil, err
+	}
+	if formula != nil {
+		files["homebrew/"+p.Name+".rb"] = formula
+	}
+
+	manifest, err := scoopManifest(p, r)
+	if err != nil {
+		return nil, err
+	}
+	if manifest != nil {
+		files["scoop/"+p.Name+".json"] = manifest
+	}
+
+	for _, b := range r.Binaries {
+		if b.OS != "linux" {
+			continue
+		}
+		rel := "nfpm/" + p.Name + "_" + b.Arch + ".yaml"
+		config, err := nfpmConfig(p, r, b, filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, err
+		}
+		files[rel] = config
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("the release has no darwin, linux or windows builds to package")
+	}
+
+	written := make([]string, 0, len(files))
+	for rel := range files {
+		written = append(written, rel)
+	}
+	sort.Strings(written)
+	for _, rel := range written {
+		dest := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(dest, files[rel], 0o644); err != nil { //nolint:gosec // Manifests are published
+			return nil, err
+		}
+	}
+	return written, nil
+}
+
+// brewPlatform is an on_macos or on_linux block of the formula
+type brewPlatform struct {
+	OS       string
+	Archives []brewArchive
+}
+
+type brewArchive struct {
+	CPU    string
+	URL    string
+	SHA256 string
+}
+
+// brewCPUs maps Go architectures to Homebrew's Hardware::CPU predicates
+var brewCPUs = map[string]string{"arm64": "arm", "amd64": "intel"}
+
+func homebrewFormula(p Project, r *Release) ([]byte, error) {
+	var platforms []brewPlatform
+	for _, goos := range []string{"darwin", "linux"} {
+		platform := brewPlatform{OS: goos}
+		if goos == "darwin" {
+			platform.OS = "macos"
+		}
+		for _, a := range r.Archives {
+			cpu := brewCPUs[a.Arch]
+			if a.OS != goos || cpu == "" || strings.HasSuffix(a.Name, ".zip") {
+				continue
+			}
+			platform.Archives = append(platform.Archives, brewArchive{CPU: cpu, URL: p.downloadURL(r.Tag, a.Name), SHA256: a.SHA256})
+		}
+		sort.Slice(platform.Archives, func(i, j int) bool { return platform.Archives[i].CPU < platform.Archives[j].CPU })
+		if len(platform.Archives) > 0 {
+			platforms = append(platforms, platform)
+		}
+	}
+	if len(platforms) == 0 {
+		return nil, nil
+	}
+
+	return render("homebrew.rb.tmpl", struct {
+		Project
+		Tag       string
+		Version   string
+		Class     string
+		Platforms []brewPlatform
+	}{p, r.Tag, r.Version, formulaClass(p.Name), platforms})
+}
+
+// formulaClass is the Ruby class of a formula: "threads-cli" -> "ThreadsCli"
+func formulaClass(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// scoopArchitectures maps Go architectures to Scoop's
+var scoopArchitectures = map[string]string{"amd64": "64bit", "386": "32bit", "arm64": "arm64"}
+
+type scoopArch struct {
+	URL  string `json:"url"`
+	Hash string `json:"hash,omitempty"`
+}
+
+func scoopManifest(p Project, r *Release) ([]byte, error) {
+	architectures := map[string]scoopArch{}
+	autoupdate := map[string]scoopArch{}
+	for _, a := range r.Archives {
+		arch := scoopArchitectures[a.Arch]
+		if a.OS != "windows" || arch == "" || !strings.HasSuffix(a.Name, ".zip") {
+			continue
+		}
+		architectures[arch] = scoopArch{URL: p.downloadURL(r.Tag, a.Name), Hash: a.SHA256}
+		// Scoop fills in $version for later releases and fetches the hash
+		name := strings.ReplaceAll(a.Name, r.Version, "$version")
+		tag := strings.ReplaceAll(r.Tag, r.Version, "$version")
+		autoupdate[arch] = scoopArch{URL: p.downloadURL(tag, name)}
+	}
+	if len(architectures) == 0 {
+		return nil, nil
+	}
+
+	manifest := map[string]any{
+		"version":      r.Version,
+		"description":  p.Description,
+		"homepage":     p.Homepage,
+		"license":      p.License,
+		"architecture": architectures,
+		"bin":          p.Name + ".exe",
+		"checkver":     map[string]string{"github": "https://github.com/" + p.Repo},
+		"autoupdate":   map[string]any{"architecture": autoupdate},
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func nfpmConfig(p Project, r *Release, b Binary, file string) ([]byte, error) {
+	return render("nfpm.yaml.tmpl", struct {
+		Project
+		Tag     string
+		Version string
+		Arch    string
+		Binary  string
+		File    string
+	}{p, r.Tag, r.Version, b.Arch, filepath.ToSlash(b.Path), filepath.ToSlash(file)})
+}
+
+var funcs = template.FuncMap{
+	// quote writes a double-quoted string, valid in Ruby and YAML alike
+	"quote": func(s string) string {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.Encode(s) //nolint:errcheck,gosec // Encoding a string cannot fail
+		return strings.TrimSuffix(buf.String(), "\n")
+	},
+}
+
+func render(name string, data any) ([]byte, error) {
+	src, err := templates.ReadFile(path.Join("templates", name))
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New(name).Funcs(funcs).Parse(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("render %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
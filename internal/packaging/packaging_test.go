@@ -0,0 +1,231 @@
+package packaging
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var testProject = Project{
+	Name:        "threads",
+	Description: "Interact with Meta Threads from the command line",
+	Homepage:    "https://github.com/salmonumbrella/threads-cli",
+	License:     "MIT",
+	Maintainer:  "Test Maintainer <test@example.com>",
+	Repo:        "salmonumbrella/threads-cli",
+}
+
+const testSum = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+// writeDist lays out what GoReleaser leaves in dist after building v1.2.3.
+// The linux amd64 archive has no recorded checksum, so it must be hashed.
+func writeDist(t *testing.T) (dist, linuxSum string) {
+	t.Helper()
+	root := t.TempDir()
+	dist = filepath.Join(root, "dist")
+	if err := os.MkdirAll(dist, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := []byte("linux archive")
+	if err := os.WriteFile(filepath.Join(dist, "threads_1.2.3_linux_amd64.tar.gz"), archive, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(archive)
+
+	writeFile(t, filepath.Join(dist, "metadata.json"), `{"project_name":"threads","tag":"v1.2.3","version":"1.2.3","commit":"abc1234"}`)
+	writeFile(t, filepath.Join(dist, "artifacts.json"), `[
+  {"name":"threads_1.2.3_darwin_amd64.tar.gz","path":"dist/threads_1.2.3_darwin_amd64.tar.gz","goos":"darwin","goarch":"amd64","type":"Archive","extra":{"Checksum":"sha256:`+testSum+`"}},
+  {"name":"threads_1.2.3_darwin_arm64.tar.gz","path":"dist/threads_1.2.3_darwin_arm64.tar.gz","goos":"darwin","goarch":"arm64","type":"Archive","extra":{"Checksum":"sha256:`+testSum+`"}},
+  {"name":"threads_1.2.3_linux_amd64.tar.gz","path":"dist/threads_1.2.3_linux_amd64.tar.gz","goos":"linux","goarch":"amd64","type":"Archive","extra":{}},
+  {"name":"threads_1.2.3_windows_amd64.zip","path":"dist/threads_1.2.3_windows_amd64.zip","goos":"windows","goarch":"amd64","type":"Archive","extra":{"Checksum":"sha256:`+testSum+`"}},
+  {"name":"threads","path":"dist/threads-other_linux_amd64_v1/threads","goos":"linux","goarch":"amd64","type":"Binary","extra":{}},
+  {"name":"threads","path":"dist/threads-other_linux_arm64_v8.0/threads","goos":"linux","goarch":"arm64","type":"Binary","extra":{}},
+  {"name":"threads","path":"dist/threads-darwin_darwin_arm64_v8.0/threads","goos":"darwin","goarch":"arm64","type":"Binary","extra":{}},
+  {"name":"checksums.txt","path":"dist/checksums.txt","type":"Checksum"}
+]`)
+	return dist, hex.EncodeToString(sum[:])
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dist, linuxSum := writeDist(t)
+
+	release, err := Load(dist)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if release.Tag != "v1.2.3" || release.Version != "1.2.3" {
+		t.Errorf("tag, version = %q, %q", release.Tag, release.Version)
+	}
+	if len(release.Archives) != 4 || len(release.Binaries) != 3 {
+		t.Fatalf("got %d archives and %d binaries, want 4 and 3", len(release.Archives), len(release.Binaries))
+	}
+	for _, a := range release.Archives {
+		want := testSum
+		if a.OS == "linux" {
+			want = linuxSum
+		}
+		if a.SHA256 != want {
+			t.Errorf("%s: SHA256 = %q, want %q", a.Name, a.SHA256, want)
+		}
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Run("missing dist", func(t *testing.T) {
+		if _, err := Load(filepath.Join(t.TempDir(), "dist")); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("missing archive", func(t *testing.T) {
+		dist := t.TempDir()
+		writeFile(t, filepath.Join(dist, "metadata.json"), `{"tag":"v1.0.0","version":"1.0.0"}`)
+		writeFile(t, filepath.Join(dist, "artifacts.json"), `[{"name":"a.tar.gz","path":"dist/a.tar.gz","goos":"linux","goarch":"amd64","type":"Archive"}]`)
+		_, err := Load(dist)
+		if err == nil || !strings.Contains(err.Error(), "a.tar.gz") {
+			t.Errorf("expected an error naming the archive, got %v", err)
+		}
+	})
+
+	t.Run("no archives", func(t *testing.T) {
+		dist := t.TempDir()
+		writeFile(t, filepath.Join(dist, "metadata.json"), `{"tag":"v1.0.0","version":"1.0.0"}`)
+		writeFile(t, filepath.Join(dist, "artifacts.json"), `[]`)
+		if _, err := Load(dist); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	dist, linuxSum := writeDist(t)
+	release, err := Load(dist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := t.TempDir()
+
+	written, err := Generate(testProject, release, out)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	want := []string{"homebrew/threads.rb", "nfpm/threads_amd64.yaml", "nfpm/threads_arm64.yaml", "scoop/threads.json"}
+	if !slices.Equal(written, want) {
+		t.Fatalf("written = %v, want %v", written, want)
+	}
+
+	read := func(rel string) string {
+		data, err := os.ReadFile(filepath.Join(out, filepath.FromSlash(rel)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	t.Run("homebrew", func(t *testing.T) {
+		formula := read("homebrew/threads.rb")
+		for _, s := range []string{
+			"class Threads < Formula",
+			`version "1.2.3"`,
+			`url "https://github.com/salmonumbrella/threads-cli/releases/download/v1.2.3/threads_1.2.3_darwin_arm64.tar.gz"`,
+			`url "https://github.com/salmonumbrella/threads-cli/releases/download/v1.2.3/threads_1.2.3_linux_amd64.tar.gz"`,
+			`sha256 "` + linuxSum + `"`,
+			"on_macos do\n    if Hardware::CPU.arm?",
+			"on_linux do\n    if Hardware::CPU.intel?",
+			`bin.install "threads"`,
+		} {
+			if !strings.Contains(formula, s) {
+				t.Errorf("formula is missing %q:\n%s", s, formula)
+			}
+		}
+		if strings.Contains(formula, ".zip") {
+			t.Errorf("formula must not use the Windows archive:\n%s", formula)
+		}
+	})
+
+	t.Run("scoop", func(t *testing.T) {
+		var manifest struct {
+			Version      string
+			Bin          string
+			Architecture map[string]struct{ URL, Hash string }
+			Autoupdate   struct {
+				Architecture map[string]struct{ URL string }
+			}
+		}
+		if err := json.Unmarshal([]byte(read("scoop/threads.json")), &manifest); err != nil {
+			t.Fatal(err)
+		}
+		if manifest.Version != "1.2.3" || manifest.Bin != "threads.exe" {
+			t.Errorf("version, bin = %q, %q", manifest.Version, manifest.Bin)
+		}
+		arch := manifest.Architecture["64bit"]
+		if arch.URL != "https://github.com/salmonumbrella/threads-cli/releases/download/v1.2.3/threads_1.2.3_windows_amd64.zip" || arch.Hash != testSum {
+			t.Errorf("64bit = %+v", arch)
+		}
+		if got := manifest.Autoupdate.Architecture["64bit"].URL; got != "https://github.com/salmonumbrella/threads-cli/releases/download/v$version/threads_$version_windows_amd64.zip" {
+			t.Errorf("autoupdate url = %q", got)
+		}
+	})
+
+	t.Run("nfpm", func(t *testing.T) {
+		config := read("nfpm/threads_arm64.yaml")
+		for _, s := range []string{
+			`arch: "arm64"`,
+			`version: "1.2.3"`,
+			`maintainer: "Test Maintainer <test@example.com>"`,
+			`- src: "dist/threads-other_linux_arm64_v8.0/threads"`,
+			`dst: "/usr/bin/threads"`,
+		} {
+			if !strings.Contains(config, s) {
+				t.Errorf("nfpm config is missing %q:\n%s", s, config)
+			}
+		}
+	})
+}
+
+func TestGenerate_OnlyAvailablePlatforms(t *testing.T) {
+	release := &Release{
+		Tag:      "v2.0.0",
+		Version:  "2.0.0",
+		Archives: []Archive{{Name: "threads_2.0.0_windows_amd64.zip", OS: "windows", Arch: "amd64", SHA256: testSum}},
+	}
+
+	written, err := Generate(testProject, release, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(written, []string{"scoop/threads.json"}) {
+		t.Errorf("written = %v, want only the Scoop manifest", written)
+	}
+
+	release.Archives = []Archive{{Name: "threads_2.0.0_freebsd_amd64.tar.gz", OS: "freebsd", Arch: "amd64"}}
+	if _, err := Generate(testProject, release, t.TempDir()); err == nil {
+		t.Error("expected an error when nothing can be packaged")
+	}
+}
+
+func TestFormulaClass(t *testing.T) {
+	tests := map[string]string{
+		"threads":      "Threads",
+		"threads-cli":  "ThreadsCli",
+		"my_tool.beta": "MyToolBeta",
+	}
+	for name, want := range tests {
+		if got := formulaClass(name); got != want {
+			t.Errorf("formulaClass(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
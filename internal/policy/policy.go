@@ -0,0 +1,121 @@
+// Package policy restricts which commands may run on a machine. An
+// administrator writes the policy file to a system location that users
+// cannot change, e.g. to deny deleting posts on a shared kiosk.
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// DefaultPath returns the system-wide policy file location.
+func DefaultPath() string {
+	if runtime.GOOS == "windows" {
+		dir := os.Getenv("ProgramData")
+		if dir == "" {
+			dir = `C:\ProgramData`
+		}
+		return filepath.Join(dir, "threads-cli", "policy.json")
+	}
+	return "/etc/threads-cli/policy.json"
+}
+
+// Policy lists command rules. A rule is a command path without the program
+// name, such as "posts delete"; it also matches the subcommands of the path,
+// so "auth" covers every auth command. "*" matches any single word, so
+// "* delete" covers every delete command.
+type Policy struct {
+	// Allow, when not empty, is the only set of commands that may run
+	Allow []string `json:"allow,omitempty"`
+	// Deny lists commands that may not run; it wins over Allow
+	Deny []string `json:"deny,omitempty"`
+
+	// Path is the file the policy was loaded from
+	Path string `json:"-"`
+}
+
+// DeniedError is returned by Check for a command the policy does not allow.
+type DeniedError struct {
+	Command string
+	// Rule is the deny rule that matched; empty when the command is not in
+	// the allow list
+	Rule string
+	Path string
+}
+
+func (e *DeniedError) Error() string {
+	if e.Rule != "" {
+		return fmt.Sprintf("command %q is denied by rule %q in %s", e.Command, e.Rule, e.Path)
+	}
+	return fmt.Sprintf("command %q is not in the allow list in %s", e.Command, e.Path)
+}
+
+// Load reads the policy file at path. A missing file is no policy (nil). A
+// file that cannot be parsed, or that any user can write on Unix, is an
+// error, so a broken policy fails closed instead of allowing everything.
+func Load(path string) (*Policy, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o002 != 0 {
+		return nil, fmt.Errorf("policy file %s is writable by every user", path)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path is the fixed policy location
+	if err != nil {
+		return nil, err
+	}
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", path, err)
+	}
+	p.Path = path
+	return &p, nil
+}
+
+// Check returns a *DeniedError if command, a command path without the program
+// name, may not run. A nil policy allows everything.
+func (p *Policy) Check(command string) error {
+	if p == nil {
+		return nil
+	}
+	for _, rule := range p.Deny {
+		if Match(rule, command) {
+			return &DeniedError{Command: command, Rule: rule, Path: p.Path}
+		}
+	}
+	if len(p.Allow) == 0 {
+		return nil
+	}
+	for _, rule := range p.Allow {
+		if Match(rule, command) {
+			return nil
+		}
+	}
+	return &DeniedError{Command: command, Path: p.Path}
+}
+
+// Match reports whether rule covers command; see Policy.
+func Match(rule, command string) bool {
+	ruleWords := strings.Fields(rule)
+	commandWords := strings.Fields(command)
+	if len(ruleWords) == 0 || len(ruleWords) > len(commandWords) {
+		return false
+	}
+	for i, word := range ruleWords {
+		if word != "*" && word != commandWords[i] {
+			return false
+		}
+	}
+	return true
+}
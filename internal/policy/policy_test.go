@@ -0,0 +1,99 @@
+package policy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		rule, command string
+		want          bool
+	}{
+		{"posts delete", "posts delete", true},
+		{"posts", "posts delete", true},
+		{"posts delete", "posts", false},
+		{"posts delete", "posts create", false},
+		{"* delete", "webhooks delete", true},
+		{"* delete", "posts create", false},
+		{"auth", "authx", false},
+		{"", "posts", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.rule, tt.command); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.rule, tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	p := &Policy{
+		Allow: []string{"posts", "me"},
+		Deny:  []string{"* delete"},
+		Path:  "/etc/threads-cli/policy.json",
+	}
+
+	if err := p.Check("posts create"); err != nil {
+		t.Errorf("posts create: unexpected error %v", err)
+	}
+
+	var denied *DeniedError
+	if err := p.Check("posts delete"); !errors.As(err, &denied) || denied.Rule != "* delete" {
+		t.Errorf("posts delete: expected denial by rule, got %v", err)
+	}
+	if err := p.Check("replies hide"); !errors.As(err, &denied) || denied.Rule != "" {
+		t.Errorf("replies hide: expected denial by allow list, got %v", err)
+	}
+
+	var none *Policy
+	if err := none.Check("posts delete"); err != nil {
+		t.Errorf("nil policy: unexpected error %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := Load(filepath.Join(dir, "missing.json"))
+	if p != nil || err != nil {
+		t.Errorf("missing file: got %v, %v", p, err)
+	}
+
+	path := filepath.Join(dir, "policy.json")
+	if err := os.WriteFile(path, []byte(`{"deny":["posts delete"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err = Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Deny) != 1 || p.Path != path {
+		t.Errorf("unexpected policy: %+v", p)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"deny":`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected an error for an invalid policy file")
+	}
+}
+
+func TestLoad_WorldWritable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not used on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected a world-writable policy file to be rejected")
+	}
+}
@@ -0,0 +1,253 @@
+package qr
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestRSRemainder_KnownVector(t *testing.T) {
+	// "HELLO WORLD" at 1-M, from the worked example of the standard
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	got := rsRemainder(data, rsDivisor(10))
+	if !bytes.Equal(got, want) {
+		t.Errorf("ecc = %v, want %v", got, want)
+	}
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	if got := formatBits(0); got != 0b101010000010010 {
+		t.Errorf("formatBits(0) = %015b", got)
+	}
+	if got := formatBits(5); got != 0b100000011001110 {
+		t.Errorf("formatBits(5) = %015b", got)
+	}
+	if got := versionBits(7); got != 0x07C94 {
+		t.Errorf("versionBits(7) = %#x", got)
+	}
+	if got := versionBits(10); got != 0x0A4D3 {
+		t.Errorf("versionBits(10) = %#x", got)
+	}
+}
+
+func TestEncode_Versions(t *testing.T) {
+	tests := []struct {
+		length  int
+		version int
+	}{
+		{1, 1},
+		{14, 1},
+		{15, 2},
+		{42, 3},
+		{43, 4},
+		{180, 9},
+		{181, 10},
+		{213, 10},
+	}
+	for _, tt := range tests {
+		code, err := Encode(strings.Repeat("a", tt.length))
+		if err != nil {
+			t.Fatalf("%d bytes: %v", tt.length, err)
+		}
+		if code.Version != tt.version || code.Size != 17+4*tt.version {
+			t.Errorf("%d bytes: version %d size %d, want version %d", tt.length, code.Version, code.Size, tt.version)
+		}
+	}
+
+	if _, err := Encode(strings.Repeat("a", 214)); !errors.Is(err, ErrTooLong) {
+		t.Errorf("expected ErrTooLong, got %v", err)
+	}
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	for _, text := range []string{
+		"https://www.threads.net/@someone/post/C8abcDEFghi",
+		strings.Repeat("https://example.com/", 9),
+		"ümlaut ✓",
+	} {
+		code, err := Encode(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := decode(t, code); got != text {
+			t.Errorf("decoded %q, want %q", got, text)
+		}
+	}
+}
+
+func FuzzEncode(f *testing.F) {
+	f.Add("https://www.threads.net/@someone/post/C8abcDEFghi")
+	f.Add("")
+	f.Add(strings.Repeat("x", 213))
+	f.Add("\x00\xff ümlaut")
+
+	f.Fuzz(func(t *testing.T, text string) {
+		code, err := Encode(text)
+		if err != nil {
+			if !errors.Is(err, ErrTooLong) || len(text) <= 213 {
+				t.Fatalf("Encode(%d bytes) error = %v", len(text), err)
+			}
+			return
+		}
+		if got := decode(t, code); got != text {
+			t.Errorf("decoded %q, want %q", got, text)
+		}
+	})
+}
+
+func TestEncode_FinderPatterns(t *testing.T) {
+	code, err := Encode("https://www.threads.net")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, corner := range [][2]int{{0, 0}, {code.Size - 7, 0}, {0, code.Size - 7}} {
+		for dy := range 7 {
+			for dx := range 7 {
+				ring := max(abs(dx-3), abs(dy-3))
+				if want := ring != 2; code.Dark(corner[0]+dx, corner[1]+dy) != want {
+					t.Fatalf("finder at %v: module %d,%d wrong", corner, dx, dy)
+				}
+			}
+		}
+	}
+}
+
+func TestText_Dimensions(t *testing.T) {
+	code, err := Encode("hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(code.Text(false), "\n"), "\n")
+	side := code.Size + 2*QuietZone
+	if len(lines) != (side+1)/2 {
+		t.Errorf("got %d lines, want %d", len(lines), (side+1)/2)
+	}
+	for _, line := range lines {
+		if n := len([]rune(line)); n != side {
+			t.Fatalf("line width %d, want %d", n, side)
+		}
+	}
+	if code.Text(false) == code.Text(true) {
+		t.Error("inverted text should differ")
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	code, err := Encode("hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := code.WritePNG(&buf, 3); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if side := (code.Size + 2*QuietZone) * 3; img.Bounds().Dx() != side || img.Bounds().Dy() != side {
+		t.Errorf("image is %v, want %dx%d", img.Bounds(), side, side)
+	}
+}
+
+// decode reads a code back the way a scanner would once the grid is
+// sampled: format bits, unmasking, codewords, error check, and payload.
+func decode(t *testing.T, c *Code) string {
+	t.Helper()
+
+	var format int
+	for i := range 6 {
+		format |= b2i(c.Dark(8, i)) << i
+	}
+	format |= b2i(c.Dark(8, 7))<<6 | b2i(c.Dark(8, 8))<<7 | b2i(c.Dark(7, 8))<<8
+	for i := 9; i < 15; i++ {
+		format |= b2i(c.Dark(14-i, 8)) << i
+	}
+	format ^= 0x5412
+	if level := format >> 13; level != formatBitsM {
+		t.Fatalf("format level = %d", level)
+	}
+	mask := (format >> 10) & 7
+
+	var bits []bool
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := range c.Size {
+			y := vert
+			if (right+1)&2 == 0 {
+				y = c.Size - 1 - vert
+			}
+			for j := range 2 {
+				x := right - j
+				if !c.function[y][x] {
+					bits = append(bits, c.modules[y][x] != maskBit(mask, x, y))
+				}
+			}
+		}
+	}
+
+	v := versions[c.Version]
+	codewords := make([]byte, v.totalCodewords)
+	for i := range codewords {
+		for j := range 8 {
+			codewords[i] = codewords[i]<<1 | byte(b2i(bits[i*8+j]))
+		}
+	}
+
+	// De-interleave and check each block
+	dataLen := v.dataCodewords()
+	shortLen := dataLen / v.blocks
+	longBlocks := dataLen % v.blocks
+	blocks := make([][]byte, v.blocks)
+	k := 0
+	for i := 0; i <= shortLen; i++ {
+		for b := range blocks {
+			if i < shortLen || b >= v.blocks-longBlocks {
+				blocks[b] = append(blocks[b], codewords[k])
+				k++
+			}
+		}
+	}
+	var data []byte
+	divisor := rsDivisor(v.eccPerBlock)
+	for b := range blocks {
+		ecc := make([]byte, v.eccPerBlock)
+		for i := range ecc {
+			ecc[i] = codewords[dataLen+i*v.blocks+b]
+		}
+		if got := rsRemainder(blocks[b], divisor); !bytes.Equal(got, ecc) {
+			t.Fatalf("block %d: error correction mismatch", b)
+		}
+		data = append(data, blocks[b]...)
+	}
+
+	readBits := func(pos, n int) int {
+		v := 0
+		for i := range n {
+			v = v<<1 | int((data[(pos+i)/8]>>(7-(pos+i)%8))&1)
+		}
+		return v
+	}
+	if mode := readBits(0, 4); mode != 0b0100 {
+		t.Fatalf("mode = %04b", mode)
+	}
+	n := readBits(4, countBits(c.Version))
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = byte(readBits(4+countBits(c.Version)+8*i, 8))
+	}
+	return string(out)
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,63 @@
+package qr
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// QuietZone is the light border, in modules, scanners need around a code.
+const QuietZone = 4
+
+// Text renders the code with half-block characters, two module rows per
+// line, quiet zone included. Filled cells are light modules, which suits
+// the light-on-dark text of most terminals; invert fills the dark modules
+// instead, for light backgrounds and print.
+func (c *Code) Text(invert bool) string {
+	filled := func(x, y int) bool { return c.Dark(x, y) == invert }
+
+	var b strings.Builder
+	for y := -QuietZone; y < c.Size+QuietZone; y += 2 {
+		for x := -QuietZone; x < c.Size+QuietZone; x++ {
+			top := filled(x, y)
+			bottom := y+1 < c.Size+QuietZone && filled(x, y+1)
+			switch {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Image renders the code as a black-on-white image with scale pixels per
+// module, quiet zone included.
+func (c *Code) Image(scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+	side := (c.Size + 2*QuietZone) * scale
+	img := image.NewPaletted(image.Rect(0, 0, side, side), color.Palette{color.White, color.Black})
+	for py := range side {
+		for px := range side {
+			if c.Dark(px/scale-QuietZone, py/scale-QuietZone) {
+				img.SetColorIndex(px, py, 1)
+			}
+		}
+	}
+	return img
+}
+
+// WritePNG writes the code as a PNG image with scale pixels per module.
+func (c *Code) WritePNG(w io.Writer, scale int) error {
+	return png.Encode(w, c.Image(scale))
+}
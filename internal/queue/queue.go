@@ -0,0 +1,248 @@
+// Package queue keeps posts waiting to be published at a set time, in the
+// data directory, so they survive restarts of the CLI and of the machine.
+package queue
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+	"github.com/salmonumbrella/threads-cli/internal/filelock"
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// FileName is the name of the queue in the data directory.
+const FileName = "queue.json"
+
+// schema and version label the queue file
+const (
+	schema  = "queue"
+	version = 1
+)
+
+// MaxAttempts is how many times a post whose publishing failed with a
+// retryable error is tried before it is marked failed.
+const MaxAttempts = 5
+
+// Status is where an item is in its life.
+type Status string
+
+// Item statuses
+const (
+	StatusPending Status = "pending"
+	// StatusPublishing is an item a runner claimed and is publishing
+	StatusPublishing Status = "publishing"
+	StatusPublished  Status = "published"
+	StatusFailed     Status = "failed"
+)
+
+// Item is a post waiting in the queue.
+type Item struct {
+	ID string `json:"id"`
+	// One of TextPost, ImagePost and VideoPost is set
+	TextPost  *api.TextPostContent  `json:"text_post,omitempty"`
+	ImagePost *api.ImagePostContent `json:"image_post,omitempty"`
+	VideoPost *api.VideoPostContent `json:"video_post,omitempty"`
+	// At is when the post is due
+	At time.Time `json:"at"`
+	// Zone is the timezone At was given in, for showing it the same way
+	Zone      string    `json:"zone,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// Account is the stored account to publish as; empty is the default one
+	Account string `json:"account,omitempty"`
+	// RecycledFrom is the ID of the earlier post this one brings back
+	RecycledFrom string `json:"recycled_from,omitempty"`
+	// Approval is set when someone signed off on the content
+	Approval *Approval `json:"approval,omitempty"`
+
+	Status    Status     `json:"status"`
+	Attempts  int        `json:"attempts,omitempty"`
+	ClaimedAt *time.Time `json:"claimed_at,omitempty"`
+	// Error is the reason of the last failed attempt
+	Error       string     `json:"error,omitempty"`
+	PostID      string     `json:"post_id,omitempty"`
+	Permalink   string     `json:"permalink,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+// Approval records that the content of an item was signed off for
+// publishing.
+type Approval struct {
+	At time.Time `json:"at"`
+	// Hash is the ContentHash of the item when it was approved
+	Hash string `json:"hash"`
+}
+
+// ErrModifiedSinceApproval is returned by CheckApproval for an item whose
+// content changed after it was approved.
+var ErrModifiedSinceApproval = errors.New("modified since it was approved")
+
+// ContentHash returns the SHA-256 of the content of the item: its text,
+// media and post options, but not when or as whom it is published.
+func (i *Item) ContentHash() string {
+	// The content types are plain structs, which always marshal
+	data, _ := json.Marshal(struct { //nolint:errcheck // See above
+		TextPost  *api.TextPostContent  `json:"text_post,omitempty"`
+		ImagePost *api.ImagePostContent `json:"image_post,omitempty"`
+		VideoPost *api.VideoPostContent `json:"video_post,omitempty"`
+	}{i.TextPost, i.ImagePost, i.VideoPost})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Approve records that the current content of the item was signed off at
+// now.
+func (i *Item) Approve(now time.Time) {
+	i.Approval = &Approval{At: now.UTC(), Hash: i.ContentHash()}
+}
+
+// CheckApproval returns ErrModifiedSinceApproval if the item was approved
+// and its content has changed since. An item never approved passes.
+func (i *Item) CheckApproval() error {
+	if i.Approval == nil || i.Approval.Hash == i.ContentHash() {
+		return nil
+	}
+	return fmt.Errorf("%w at %s", ErrModifiedSinceApproval, i.Approval.At.Format(time.RFC3339))
+}
+
+// Content returns the post content to publish: an *api.TextPostContent,
+// *api.ImagePostContent or *api.VideoPostContent.
+func (i *Item) Content() any {
+	switch {
+	case i.ImagePost != nil:
+		return i.ImagePost
+	case i.VideoPost != nil:
+		return i.VideoPost
+	default:
+		return i.TextPost
+	}
+}
+
+// Text returns the text of the post.
+func (i *Item) Text() string {
+	switch {
+	case i.ImagePost != nil:
+		return i.ImagePost.Text
+	case i.VideoPost != nil:
+		return i.VideoPost.Text
+	case i.TextPost != nil:
+		return i.TextPost.Text
+	}
+	return ""
+}
+
+// IsReply reports whether the item is a reply to another post.
+func (i *Item) IsReply() bool {
+	switch {
+	case i.ImagePost != nil:
+		return i.ImagePost.ReplyTo != ""
+	case i.VideoPost != nil:
+		return i.VideoPost.ReplyTo != ""
+	case i.TextPost != nil:
+		return i.TextPost.ReplyTo != ""
+	}
+	return false
+}
+
+// Location returns the timezone the item was scheduled in, or loc if it
+// is unknown.
+func (i *Item) Location(loc *time.Location) *time.Location {
+	if i.Zone == "" {
+		return loc
+	}
+	if zone, err := time.LoadLocation(i.Zone); err == nil {
+		return zone
+	}
+	return loc
+}
+
+// NewID returns a random item ID
+func NewID() string {
+	b := make([]byte, 6)
+	rand.Read(b) //nolint:errcheck,gosec // crypto/rand.Read does not fail
+	return hex.EncodeToString(b)
+}
+
+// Load returns the items in the queue at path. A missing queue is empty.
+func Load(path string) ([]Item, error) {
+	var items []Item
+	err := statefile.ReadJSON(path, schema, version, &items)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		if items[i].Status == "" {
+			items[i].Status = StatusPending
+		}
+	}
+	return items, nil
+}
+
+// Save replaces the queue at path with items. The queue is replaced
+// atomically and is readable by the user alone.
+func Save(path string, items []Item) error {
+	if items == nil {
+		items = []Item{}
+	}
+	return statefile.WriteJSON(path, schema, version, items)
+}
+
+// Update changes the queue at path with fn while holding its lock, so
+// commands adding posts and runners publishing them do not undo each
+// other's changes. The queue is saved unless fn fails.
+func Update(path string, fn func([]Item) ([]Item, error)) error {
+	unlock, err := filelock.Lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	items, err := Load(path)
+	if err != nil {
+		return err
+	}
+	items, err = fn(items)
+	if err != nil {
+		return err
+	}
+	return Save(path, items)
+}
+
+// Claim marks the pending items due at now as publishing and returns them,
+// oldest first. An item that has been publishing for longer than stale
+// belongs to a runner that stopped midway; it may or may not have been
+// published, so it is marked failed rather than published twice.
+func Claim(path string, now time.Time, stale time.Duration) ([]Item, error) {
+	var claimed []Item
+	err := Update(path, func(items []Item) ([]Item, error) {
+		for i := range items {
+			item := &items[i]
+			switch {
+			case item.Status == StatusPublishing && item.ClaimedAt != nil && now.Sub(*item.ClaimedAt) > stale:
+				item.Status = StatusFailed
+				item.Error = "interrupted while publishing; check whether it was posted before scheduling it again"
+			case item.Status == StatusPending && !item.At.After(now):
+				at := now.UTC()
+				item.Status, item.ClaimedAt = StatusPublishing, &at
+				item.Attempts++
+				claimed = append(claimed, *item)
+			}
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(claimed, func(a, b Item) int { return a.At.Compare(b.At) })
+	return claimed, nil
+}
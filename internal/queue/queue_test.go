@@ -0,0 +1,147 @@
+package queue
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+func TestLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", FileName)
+	items, err := Load(path)
+	if err != nil || items != nil {
+		t.Fatalf("expected a missing queue to be empty, got %+v, %v", items, err)
+	}
+
+	at := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+	item := Item{ID: NewID(), TextPost: &api.TextPostContent{Text: "hello"}, At: at, Zone: "Europe/Berlin", CreatedAt: at.Add(-time.Hour), RecycledFrom: "111", Status: StatusPending}
+	if err := Save(path, []Item{item}); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("expected a queue readable by the user alone, got %v, %v", info, err)
+	}
+
+	items, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Text() != "hello" || !items[0].At.Equal(at) || items[0].RecycledFrom != "111" {
+		t.Errorf("unexpected items: %+v", items)
+	}
+	if loc := items[0].Location(time.UTC); loc.String() != "Europe/Berlin" {
+		t.Errorf("expected the item's zone, got %v", loc)
+	}
+	if len(item.ID) != 12 || NewID() == item.ID {
+		t.Errorf("expected random 12 character IDs, got %q", item.ID)
+	}
+}
+
+func TestLoad_Damaged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected an error for a damaged queue")
+	}
+}
+
+func TestClaim(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	now := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+	claimedAt := now.Add(-time.Hour)
+	err := Save(path, []Item{
+		{ID: "later", At: now.Add(time.Minute), Status: StatusPending},
+		{ID: "due", At: now.Add(-time.Minute), Status: StatusPending},
+		{ID: "overdue", At: now.Add(-24 * time.Hour)},
+		{ID: "stuck", At: now.Add(-2 * time.Hour), Status: StatusPublishing, ClaimedAt: &claimedAt},
+		{ID: "done", At: now.Add(-time.Hour), Status: StatusPublished},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claimed, err := Claim(path, now, 30*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 2 || claimed[0].ID != "overdue" || claimed[1].ID != "due" || claimed[0].Attempts != 1 {
+		t.Fatalf("expected the due posts, oldest first, got %+v", claimed)
+	}
+
+	items, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := map[string]Status{}
+	for _, item := range items {
+		status[item.ID] = item.Status
+	}
+	want := map[string]Status{"later": StatusPending, "due": StatusPublishing, "overdue": StatusPublishing, "stuck": StatusFailed, "done": StatusPublished}
+	for id, s := range want {
+		if status[id] != s {
+			t.Errorf("%s: expected %s, got %s", id, s, status[id])
+		}
+	}
+
+	// Claimed posts are not claimed again
+	if claimed, err := Claim(path, now, 30*time.Minute); err != nil || len(claimed) != 0 {
+		t.Errorf("expected nothing to claim, got %+v, %v", claimed, err)
+	}
+}
+
+func TestItem_Approval(t *testing.T) {
+	item := Item{ID: "a", TextPost: &api.TextPostContent{Text: "hello"}, At: time.Now()}
+	if err := item.CheckApproval(); err != nil {
+		t.Errorf("expected an unapproved item to pass, got %v", err)
+	}
+
+	item.Approve(time.Now())
+	// Moving or reassigning the post is not a change of content
+	item.At, item.Account = item.At.Add(time.Hour), "work"
+	if err := item.CheckApproval(); err != nil {
+		t.Errorf("expected the approval to hold, got %v", err)
+	}
+
+	item.TextPost.Text = "hello!"
+	if err := item.CheckApproval(); !errors.Is(err, ErrModifiedSinceApproval) {
+		t.Errorf("expected a changed text caught, got %v", err)
+	}
+	item.TextPost = nil
+	item.ImagePost = &api.ImagePostContent{Text: "hello", ImageURL: "https://example.com/a.jpg"}
+	if err := item.CheckApproval(); !errors.Is(err, ErrModifiedSinceApproval) {
+		t.Errorf("expected a changed post type caught, got %v", err)
+	}
+}
+
+func TestLoad_RecoversTornWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	first := []Item{{ID: "a", Status: StatusPending}}
+	if err := Save(path, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(path, append(first, Item{ID: "b", Status: StatusPending})); err != nil {
+		t.Fatal(err)
+	}
+
+	// A queue cut short by a crash on a file system that reordered writes
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"schema": "queue", "version": 1, "data": [{"id": "a"`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := Load(path)
+	if err != nil {
+		t.Fatalf("expected the previous queue, got %v", err)
+	}
+	if len(items) != 1 || items[0].ID != "a" {
+		t.Errorf("expected the previous queue, got %+v", items)
+	}
+}
@@ -0,0 +1,122 @@
+package ratecoord
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+// timeout bounds each exchange with the server, so a server that hangs
+// cannot hold requests back
+const timeout = 2 * time.Second
+
+// Client reaches the Server on a socket for the accounting of one key,
+// usually the ID of the account whose token it sends. It implements
+// api.RateCoordinator and is safe for concurrent use.
+type Client struct {
+	path string
+	key  string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+var _ api.RateCoordinator = (*Client)(nil)
+
+// NewClient returns a client of the server on the socket at path. It
+// connects on first use, and again after losing the connection.
+func NewClient(path, key string) *Client {
+	return &Client{path: path, key: key}
+}
+
+// Acquire counts a request about to be sent, or returns how long to wait
+// before asking again.
+func (c *Client) Acquire(ctx context.Context) (time.Duration, error) {
+	rep, err := c.exchange(ctx, &request{Op: opAcquire, Key: c.key})
+	if err != nil {
+		return 0, err
+	}
+	return rep.Wait, nil
+}
+
+// Update reports the limits of a response. Failures are ignored: the next
+// Acquire reports them.
+func (c *Client) Update(info *api.RateLimitInfo) {
+	if info == nil {
+		return
+	}
+	c.exchange(context.Background(), &request{Op: opUpdate, Key: c.key, Limit: info.Limit, Remaining: info.Remaining, Reset: info.Reset}) //nolint:errcheck,gosec // See above
+}
+
+// RateLimited reports a 429 response that lasts until reset. Failures are
+// ignored like those of Update.
+func (c *Client) RateLimited(reset time.Time) {
+	c.exchange(context.Background(), &request{Op: opLimited, Key: c.key, Reset: reset}) //nolint:errcheck,gosec // See Update
+}
+
+// Close closes the connection, if any.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closeConn()
+}
+
+// exchange sends req and reads the reply, connecting first if needed
+func (c *Client) exchange(ctx context.Context, req *request) (*reply, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		dialer := net.Dialer{Timeout: timeout}
+		conn, err := dialer.DialContext(ctx, "unix", c.path)
+		if err != nil {
+			return nil, err
+		}
+		c.conn, c.reader = conn, bufio.NewReader(conn)
+	}
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	c.conn.SetDeadline(deadline) //nolint:errcheck,gosec // A failed write or read below reports it
+
+	var rep reply
+	if _, err := c.conn.Write(append(data, '\n')); err != nil {
+		c.closeConn() //nolint:errcheck,gosec // The error above is the one reported
+		return nil, err
+	}
+	line, err := c.reader.ReadBytes('\n')
+	if err == nil {
+		err = json.Unmarshal(line, &rep)
+	}
+	if err != nil {
+		c.closeConn() //nolint:errcheck,gosec // The error above is the one reported
+		return nil, err
+	}
+	if rep.Error != "" {
+		return nil, errors.New(rep.Error)
+	}
+	return &rep, nil
+}
+
+func (c *Client) closeConn() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn, c.reader = nil, nil
+	return err
+}
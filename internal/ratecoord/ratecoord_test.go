@@ -0,0 +1,119 @@
+package ratecoord
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+// startServer serves a coordinator on a socket in a temporary directory
+func startServer(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rl.sock")
+	ln, err := Listen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- NewServer().Serve(ctx, ln) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	})
+	return path
+}
+
+func TestClients_ShareQuota(t *testing.T) {
+	path := startServer(t)
+	ctx := context.Background()
+	cron, shell, other := NewClient(path, "1"), NewClient(path, "1"), NewClient(path, "2")
+	defer cron.Close()  //nolint:errcheck // Test cleanup
+	defer shell.Close() //nolint:errcheck // Test cleanup
+	defer other.Close() //nolint:errcheck // Test cleanup
+
+	// Until a response reports limits, requests go through
+	if wait, err := cron.Acquire(ctx); err != nil || wait != 0 {
+		t.Fatalf("expected a request let through, got %s, %v", wait, err)
+	}
+	reset := time.Now().Add(time.Hour)
+	cron.Update(&api.RateLimitInfo{Limit: 100, Remaining: 2, Reset: reset})
+
+	for _, c := range []*Client{shell, cron} {
+		if wait, err := c.Acquire(ctx); err != nil || wait != 0 {
+			t.Fatalf("expected the quota shared, got %s, %v", wait, err)
+		}
+	}
+	wait, err := shell.Acquire(ctx)
+	if err != nil || wait < 59*time.Minute || wait > time.Hour {
+		t.Errorf("expected to wait for the reset once the quota is spent, got %s, %v", wait, err)
+	}
+	if wait, err := other.Acquire(ctx); err != nil || wait != 0 {
+		t.Errorf("expected other accounts unaffected, got %s, %v", wait, err)
+	}
+}
+
+func TestClients_RateLimited(t *testing.T) {
+	path := startServer(t)
+	ctx := context.Background()
+	a, b := NewClient(path, "1"), NewClient(path, "1")
+	defer a.Close() //nolint:errcheck // Test cleanup
+	defer b.Close() //nolint:errcheck // Test cleanup
+
+	a.RateLimited(time.Now().Add(10 * time.Minute))
+	if wait, err := b.Acquire(ctx); err != nil || wait < 9*time.Minute {
+		t.Errorf("expected a 429 to hold other clients back, got %s, %v", wait, err)
+	}
+}
+
+func TestAccount_NewWindow(t *testing.T) {
+	now := time.Now()
+	acct := &account{limit: 5, remaining: 0, reset: now.Add(time.Minute)}
+	if wait := acct.acquire(now); wait != time.Minute {
+		t.Errorf("expected to wait a minute, got %s", wait)
+	}
+	if wait := acct.acquire(now.Add(time.Minute)); wait != 0 || acct.remaining != 4 {
+		t.Errorf("expected the quota restored after the reset, got %s with %d left", wait, acct.remaining)
+	}
+	// Spent without a known reset: the API decides
+	acct = &account{limit: 5, remaining: 0}
+	if wait := acct.acquire(now); wait != 0 {
+		t.Errorf("expected a request let through without a reset time, got %s", wait)
+	}
+}
+
+func TestListen(t *testing.T) {
+	path := startServer(t)
+	if _, err := Listen(path); err == nil {
+		t.Error("expected a second coordinator on the socket refused")
+	}
+
+	// A socket without a server is replaced
+	stale := filepath.Join(t.TempDir(), "stale.sock")
+	ln, err := Listen(stale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln.(interface{ SetUnlinkOnClose(bool) }).SetUnlinkOnClose(false)
+	ln.Close() //nolint:errcheck,gosec // Leaves the socket file behind
+	ln, err = Listen(stale)
+	if err != nil {
+		t.Fatalf("expected a stale socket replaced, got %v", err)
+	}
+	ln.Close() //nolint:errcheck,gosec // Test cleanup
+}
+
+func TestClient_NoServer(t *testing.T) {
+	c := NewClient(filepath.Join(t.TempDir(), "missing.sock"), "1")
+	if _, err := c.Acquire(context.Background()); err == nil {
+		t.Error("expected an error without a server")
+	}
+	// Reports are dropped
+	c.Update(&api.RateLimitInfo{Limit: 1})
+	c.RateLimited(time.Now())
+}
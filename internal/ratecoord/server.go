@@ -0,0 +1,197 @@
+// Package ratecoord shares rate-limit accounting between processes of the
+// CLI on one machine, such as cron jobs and commands run by hand, so that
+// together they stay within an account's quota. A Server keeps the
+// accounting and Clients reach it over a local socket.
+package ratecoord
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Operations of a request
+const (
+	opAcquire = "acquire"
+	opUpdate  = "update"
+	opLimited = "limited"
+)
+
+// request is one line a Client sends
+type request struct {
+	Op  string `json:"op"`
+	Key string `json:"key"`
+	// Limit, Remaining and Reset are the limits of a response for update,
+	// and Reset the end of a 429 for limited
+	Limit     int       `json:"limit,omitempty"`
+	Remaining int       `json:"remaining,omitempty"`
+	Reset     time.Time `json:"reset,omitzero"`
+}
+
+// reply is the line a Server answers with
+type reply struct {
+	// Wait is how long the client waits before asking again, for acquire
+	Wait  time.Duration `json:"wait,omitempty"`
+	Error string        `json:"error,omitempty"`
+}
+
+// account is the accounting of one key
+type account struct {
+	// limit and remaining are as of the latest response, less the requests
+	// let through since; limit is zero until a response reports it
+	limit     int
+	remaining int
+	reset     time.Time
+	// limitedUntil is the end of a 429
+	limitedUntil time.Time
+}
+
+// Server keeps the accounting of every key its clients use. Requests are
+// handled one at a time, so two processes never both spend the last call.
+type Server struct {
+	mu       sync.Mutex
+	accounts map[string]*account
+	now      func() time.Time
+}
+
+// NewServer returns a server with no accounting yet.
+func NewServer() *Server {
+	return &Server{accounts: map[string]*account{}, now: time.Now}
+}
+
+// Listen opens the socket at path for a Server. A socket left behind by a
+// server that is no longer running is replaced; one in use is an error.
+func Listen(path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close() //nolint:errcheck,gosec // Only probing
+		return nil, fmt.Errorf("a coordinator is already running on %s", path)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		ln.Close() //nolint:errcheck,gosec // The error above is the one reported
+		return nil, err
+	}
+	return ln, nil
+}
+
+// Serve answers the clients connecting to ln until ctx is done, then closes
+// ln.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	var wg sync.WaitGroup
+	stop := context.AfterFunc(ctx, func() { ln.Close() }) //nolint:errcheck,gosec // Ends Accept below
+	defer stop()
+	defer wg.Wait()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.serveConn(ctx, conn)
+		}()
+	}
+}
+
+// serveConn answers the requests of one client, one line each
+func (s *Server) serveConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()                                      //nolint:errcheck // Nothing to report to
+	stop := context.AfterFunc(ctx, func() { conn.Close() }) //nolint:errcheck,gosec // Ends the read below
+	defer stop()
+
+	scanner := bufio.NewScanner(conn)
+	enc := json.NewEncoder(conn)
+	for scanner.Scan() {
+		var req request
+		var rep reply
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			rep.Error = "invalid request: " + err.Error()
+		} else {
+			rep = s.handle(&req)
+		}
+		if enc.Encode(rep) != nil {
+			return
+		}
+	}
+}
+
+// handle applies one request to the accounting
+func (s *Server) handle(req *request) reply {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	acct := s.accounts[req.Key]
+	if acct == nil {
+		acct = &account{}
+		s.accounts[req.Key] = acct
+	}
+	now := s.now()
+
+	switch req.Op {
+	case opAcquire:
+		return reply{Wait: acct.acquire(now)}
+	case opUpdate:
+		if req.Limit > 0 {
+			acct.limit = req.Limit
+		}
+		acct.remaining = req.Remaining
+		if !req.Reset.IsZero() {
+			acct.reset = req.Reset
+		}
+	case opLimited:
+		if req.Reset.After(acct.limitedUntil) {
+			acct.limitedUntil = req.Reset
+		}
+		acct.remaining = 0
+	default:
+		return reply{Error: fmt.Sprintf("unknown operation %q", req.Op)}
+	}
+	return reply{}
+}
+
+// acquire counts a request at now, or returns how long until one may be
+// sent
+func (a *account) acquire(now time.Time) time.Duration {
+	if now.Before(a.limitedUntil) {
+		return a.limitedUntil.Sub(now)
+	}
+	if !a.reset.IsZero() && !now.Before(a.reset) {
+		// A new window starts with the whole quota
+		a.remaining = a.limit
+		a.reset = time.Time{}
+	}
+	if a.limit > 0 && a.remaining <= 0 {
+		if a.reset.IsZero() {
+			// The API has not said when the window ends; let requests
+			// through until it answers 429
+			return 0
+		}
+		return a.reset.Sub(now)
+	}
+	if a.limit > 0 {
+		a.remaining--
+	}
+	return 0
+}
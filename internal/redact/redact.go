@@ -0,0 +1,92 @@
+// Package redact anonymizes posts so they can be shared for analysis or bug
+// reports. Usernames, including mentions in text, are replaced by keyed
+// hashes, so one account keeps one pseudonym across a dataset without the
+// original being recoverable by hashing guesses; URLs and email addresses are
+// removed.
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+// Placeholders replacing what is removed from text
+const (
+	URL   = "[url]"
+	Email = "[email]"
+)
+
+var (
+	emailPattern   = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	urlPattern     = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]+`)
+	mentionPattern = regexp.MustCompile(`(^|[^A-Za-z0-9._])@([A-Za-z0-9._]+)`)
+)
+
+// Redactor redacts with one key; posts redacted with the same key get the
+// same pseudonyms.
+type Redactor struct {
+	key []byte
+}
+
+// New returns a Redactor for key.
+func New(key []byte) *Redactor {
+	return &Redactor{key: key}
+}
+
+// NewKey returns a random key.
+func NewKey() []byte {
+	key := make([]byte, 32)
+	rand.Read(key) //nolint:errcheck,gosec // crypto/rand.Read never fails
+	return key
+}
+
+// Username returns the pseudonym of username.
+func (r *Redactor) Username(username string) string {
+	if username == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, r.key)
+	mac.Write([]byte(username)) //nolint:errcheck // hash.Hash writes never fail
+	return "user_" + hex.EncodeToString(mac.Sum(nil)[:6])
+}
+
+// Text returns s without email addresses and URLs and with mentions
+// replaced by pseudonyms.
+func (r *Redactor) Text(s string) string {
+	s = emailPattern.ReplaceAllString(s, Email)
+	s = urlPattern.ReplaceAllString(s, URL)
+	return mentionPattern.ReplaceAllStringFunc(s, func(m string) string {
+		parts := mentionPattern.FindStringSubmatch(m)
+		return parts[1] + "@" + r.Username(parts[2])
+	})
+}
+
+// Post redacts p and the posts it embeds in place. IDs, timestamps and
+// media types are kept; permalinks, shortcodes and media URLs, which lead
+// back to the account, are cleared.
+func (r *Redactor) Post(p *api.Post) {
+	if p == nil {
+		return
+	}
+	p.Username = r.Username(p.Username)
+	p.Text = r.Text(p.Text)
+	p.AltText = r.Text(p.AltText)
+	p.Permalink = ""
+	p.Shortcode = ""
+	p.MediaURL = ""
+	p.ThumbnailURL = ""
+	p.LinkAttachmentURL = ""
+	p.GifURL = ""
+	if poll := p.PollAttachment; poll != nil {
+		poll.OptionA, poll.OptionB = r.Text(poll.OptionA), r.Text(poll.OptionB)
+		poll.OptionC, poll.OptionD = r.Text(poll.OptionC), r.Text(poll.OptionD)
+	}
+	for _, embedded := range []*api.Post{p.QuotedPost, p.RepostedPost, p.RootPost, p.RepliedTo} {
+		r.Post(embedded)
+	}
+}
@@ -0,0 +1,68 @@
+package redact
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/threads-cli/internal/api"
+)
+
+func TestText(t *testing.T) {
+	r := New([]byte("key"))
+	alice := r.Username("alice")
+
+	tests := []struct {
+		in, want string
+	}{
+		{"Mail me at alice@example.com", "Mail me at " + Email},
+		{"Docs at https://example.com/a?b=c, and www.example.org.", "Docs at " + URL + " and " + URL},
+		{"Thanks @alice!", "Thanks @" + alice + "!"},
+		{"(@alice) and @alice.", "(@" + alice + ") and @" + r.Username("alice.")},
+		{"No identifiers here", "No identifiers here"},
+	}
+	for _, tt := range tests {
+		if got := r.Text(tt.in); got != tt.want {
+			t.Errorf("Text(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestUsername_KeyedAndStable(t *testing.T) {
+	a, b := New([]byte("one")), New([]byte("two"))
+	if a.Username("alice") != a.Username("alice") {
+		t.Error("expected the same pseudonym for the same key")
+	}
+	if a.Username("alice") == b.Username("alice") || a.Username("alice") == a.Username("bob") {
+		t.Error("expected pseudonyms to depend on the key and the username")
+	}
+	if got := a.Username("alice"); !strings.HasPrefix(got, "user_") || strings.Contains(got, "alice") {
+		t.Errorf("unexpected pseudonym %q", got)
+	}
+}
+
+func TestPost(t *testing.T) {
+	r := New([]byte("key"))
+	post := &api.Post{
+		ID:        "123",
+		Username:  "alice",
+		Text:      "Ask @bob at bob@example.com",
+		Permalink: "https://www.threads.net/@alice/post/ABC",
+		Shortcode: "ABC",
+		MediaURL:  "https://cdn.example.com/a.jpg",
+		QuotedPost: &api.Post{
+			Username: "bob",
+			Text:     "See https://example.com",
+		},
+	}
+	r.Post(post)
+
+	if post.ID != "123" || post.Username != r.Username("alice") || post.Permalink != "" || post.Shortcode != "" || post.MediaURL != "" {
+		t.Errorf("unexpected post: %+v", post)
+	}
+	if want := "Ask @" + r.Username("bob") + " at " + Email; post.Text != want {
+		t.Errorf("Text = %q, want %q", post.Text, want)
+	}
+	if post.QuotedPost.Username != r.Username("bob") || post.QuotedPost.Text != "See "+URL {
+		t.Errorf("expected the quoted post redacted, got %+v", post.QuotedPost)
+	}
+}
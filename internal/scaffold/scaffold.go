@@ -0,0 +1,135 @@
+// Package scaffold generates starter projects for automating Threads with the CLI.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// Lang is the language of a generated project.
+type Lang string
+
+const (
+	LangGo   Lang = "go"
+	LangBash Lang = "bash"
+)
+
+// Languages lists the supported project languages.
+func Languages() []Lang {
+	return []Lang{LangGo, LangBash}
+}
+
+// Options configures Generate.
+type Options struct {
+	Lang Lang
+	// Dir is where the project is written; it is created if needed
+	Dir string
+	// Name is the project (and Go module) name; default: the base name of Dir
+	Name string
+	// Account is the default account baked into the project; empty uses the CLI default
+	Account string
+	// Force allows writing into a non-empty directory, overwriting files
+	Force bool
+}
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Generate writes a bot project to opts.Dir and returns the paths written,
+// relative to the directory.
+func Generate(opts Options) ([]string, error) {
+	root := path.Join("templates", string(opts.Lang))
+	if _, err := fs.Stat(templates, root); err != nil {
+		return nil, fmt.Errorf("unsupported language %q", opts.Lang)
+	}
+
+	name := opts.Name
+	if name == "" {
+		abs, err := filepath.Abs(opts.Dir)
+		if err != nil {
+			return nil, err
+		}
+		name = filepath.Base(abs)
+	}
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("invalid project name %q: use letters, digits, '.', '-' and '_'", name)
+	}
+
+	// The account is substituted into source and shell scripts unquoted
+	if opts.Account != "" && !validName.MatchString(opts.Account) {
+		return nil, fmt.Errorf("invalid account name %q", opts.Account)
+	}
+
+	if !opts.Force {
+		entries, err := os.ReadDir(opts.Dir)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if len(entries) > 0 {
+			return nil, fmt.Errorf("directory %s is not empty", opts.Dir)
+		}
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	data := struct{ Name, Account string }{Name: name, Account: opts.Account}
+
+	var written []string
+	err := fs.WalkDir(templates, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel := strings.TrimSuffix(strings.TrimPrefix(p, root+"/"), ".tmpl")
+		content, err := render(p, data)
+		if err != nil {
+			return err
+		}
+
+		mode := os.FileMode(0o644)
+		if strings.HasSuffix(rel, ".sh") {
+			mode = 0o755
+		}
+		dest := filepath.Join(opts.Dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dest, content, mode); err != nil {
+			return err
+		}
+		written = append(written, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return written, nil
+}
+
+func render(name string, data any) ([]byte, error) {
+	src, err := templates.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New(path.Base(name)).Parse(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("render %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
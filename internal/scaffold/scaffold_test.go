@@ -0,0 +1,105 @@
+package scaffold
+
+import (
+	"go/format"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGenerate_Go(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mybot")
+
+	files, err := Generate(Options{Lang: LangGo, Dir: dir, Account: "work"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := []string{"README.md", "go.mod", "main.go", "threads.go", "webhook.go"}
+	slices.Sort(files)
+	if !slices.Equal(files, want) {
+		t.Fatalf("files = %v, want %v", files, want)
+	}
+
+	gomod, _ := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if !strings.HasPrefix(string(gomod), "module mybot\n") {
+		t.Errorf("expected module named after the directory, got %q", gomod)
+	}
+
+	for _, name := range []string{"main.go", "threads.go", "webhook.go"} {
+		src, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		formatted, err := format.Source(src)
+		if err != nil {
+			t.Errorf("%s does not parse: %v", name, err)
+			continue
+		}
+		if string(formatted) != string(src) {
+			t.Errorf("%s is not gofmt-formatted", name)
+		}
+	}
+
+	threads, _ := os.ReadFile(filepath.Join(dir, "threads.go"))
+	if !strings.Contains(string(threads), `account = "work"`) {
+		t.Error("expected the default account to be baked into threads.go")
+	}
+}
+
+func TestGenerate_Bash(t *testing.T) {
+	dir := t.TempDir()
+
+	files, err := Generate(Options{Lang: LangBash, Dir: dir, Name: "bashbot"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %v", files)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "bot.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Windows has no executable bit
+	if info.Mode().Perm()&0o100 == 0 && runtime.GOOS != "windows" {
+		t.Errorf("expected bot.sh to be executable, got %v", info.Mode())
+	}
+
+	script, _ := os.ReadFile(filepath.Join(dir, "bot.sh"))
+	if !strings.HasPrefix(string(script), "#!/usr/bin/env bash\n# bashbot") {
+		t.Errorf("unexpected script header: %q", strings.SplitN(string(script), "\n", 3)[:2])
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	nonEmpty := t.TempDir()
+	if err := os.WriteFile(filepath.Join(nonEmpty, "existing"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"unsupported language", Options{Lang: "ruby", Dir: t.TempDir()}},
+		{"non-empty directory", Options{Lang: LangGo, Dir: nonEmpty}},
+		{"invalid name", Options{Lang: LangGo, Dir: t.TempDir(), Name: "bad name"}},
+		{"invalid account", Options{Lang: LangBash, Dir: t.TempDir(), Account: "$(rm -rf /)"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Generate(tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	if _, err := Generate(Options{Lang: LangGo, Dir: nonEmpty, Name: "forced", Force: true}); err != nil {
+		t.Errorf("expected --force to allow a non-empty directory: %v", err)
+	}
+}
@@ -2,16 +2,22 @@ package secrets
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
 	"github.com/99designs/keyring"
+
+	"github.com/salmonumbrella/threads-cli/internal/config"
 )
 
 const (
 	serviceName   = "threads-cli"
 	accountPrefix = "account:"
+	secretPrefix  = "secret:"
 	rotationDays  = 55 // Warn before 60-day expiry
 )
 
@@ -26,6 +32,8 @@ type Credentials struct {
 	ClientID     string    `json:"client_id,omitempty"`
 	ClientSecret string    `json:"-"` // Excluded from JSON for security
 	RedirectURI  string    `json:"redirect_uri,omitempty"`
+	// Scopes are the permissions granted to the token, or nil if unknown
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 // storedCredentials is the internal format for keyring storage
@@ -38,6 +46,7 @@ type storedCredentials struct {
 	ClientID     string    `json:"client_id,omitempty"`
 	ClientSecret string    `json:"client_secret,omitempty"`
 	RedirectURI  string    `json:"redirect_uri,omitempty"`
+	Scopes       []string  `json:"scopes,omitempty"`
 }
 
 // Store provides secure credential storage
@@ -47,8 +56,16 @@ type Store interface {
 	Delete(name string) error
 	List() ([]string, error)
 	Keys() ([]string, error)
+	// SetSecret, GetSecret and DeleteSecret keep other secrets by name,
+	// such as the SMTP password of email notifications
+	SetSecret(name, value string) error
+	GetSecret(name string) (string, error)
+	DeleteSecret(name string) error
 }
 
+// ErrSecretNotFound is returned by GetSecret for a secret that was never set
+var ErrSecretNotFound = errors.New("secret not found")
+
 // KeyringStore implements Store using the system keyring
 type KeyringStore struct {
 	ring           keyring.Keyring
@@ -57,7 +74,38 @@ type KeyringStore struct {
 
 // OpenDefault opens the default keyring store
 func OpenDefault() (*KeyringStore, error) {
-	ring, err := keyring.Open(keyring.Config{
+	return open(keyringConfig(runtime.GOOS))
+}
+
+// OpenDefaultNoPrompt opens the default keyring store without asking for
+// the password of the file fallback, which fails instead
+func OpenDefaultNoPrompt() (*KeyringStore, error) {
+	cfg := keyringConfig(runtime.GOOS)
+	cfg.FilePasswordFunc = func(string) (string, error) {
+		return "", ErrPasswordPrompt
+	}
+	return open(cfg)
+}
+
+// ErrPasswordPrompt is returned when the file keyring needs a password but
+// prompting for it is not allowed
+var ErrPasswordPrompt = errors.New("the file keyring needs its password, but prompting is disabled")
+
+func open(cfg keyring.Config) (*KeyringStore, error) {
+	ring, err := keyring.Open(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open keyring: %w", err)
+	}
+	return &KeyringStore{
+		ring:           ring,
+		warnedAccounts: make(map[string]bool),
+	}, nil
+}
+
+// keyringConfig configures the native store of each platform with the
+// encrypted file as fallback
+func keyringConfig(goos string) keyring.Config {
+	cfg := keyring.Config{
 		ServiceName: serviceName,
 		// macOS Keychain
 		KeychainName:             "login",
@@ -69,14 +117,25 @@ func OpenDefault() (*KeyringStore, error) {
 		// File-based fallback
 		FileDir:          "~/.config/threads-cli/keyring",
 		FilePasswordFunc: keyring.TerminalPrompt,
-	})
+	}
+	if goos == "windows" {
+		// Only Credential Manager, rather than whichever backend happens to
+		// be found on PATH, such as pass from Git Bash; the file fallback
+		// lives with the rest of the config in %APPDATA%
+		cfg.AllowedBackends = []keyring.BackendType{keyring.WinCredBackend, keyring.FileBackend}
+		cfg.FileDir = filepath.Join(config.ConfigDir(), "keyring")
+	}
+	return cfg
+}
+
+// FileDir returns the directory of the encrypted file keyring, which holds
+// the credentials where no system keyring is available
+func FileDir() string {
+	dir, err := keyring.ExpandTilde(keyringConfig(runtime.GOOS).FileDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open keyring: %w", err)
+		return keyringConfig(runtime.GOOS).FileDir
 	}
-	return &KeyringStore{
-		ring:           ring,
-		warnedAccounts: make(map[string]bool),
-	}, nil
+	return dir
 }
 
 // Set stores credentials for an account
@@ -98,6 +157,7 @@ func (s *KeyringStore) Set(name string, creds Credentials) error {
 		ClientID:     creds.ClientID,
 		ClientSecret: creds.ClientSecret,
 		RedirectURI:  creds.RedirectURI,
+		Scopes:       creds.Scopes,
 	}
 	if stored.CreatedAt.IsZero() {
 		stored.CreatedAt = time.Now()
@@ -140,6 +200,7 @@ func (s *KeyringStore) Get(name string) (*Credentials, error) {
 		ClientID:     stored.ClientID,
 		ClientSecret: stored.ClientSecret,
 		RedirectURI:  stored.RedirectURI,
+		Scopes:       stored.Scopes,
 	}
 
 	// Warn about expiring tokens (once per session)
@@ -181,6 +242,43 @@ func (s *KeyringStore) Keys() ([]string, error) {
 	return accounts, nil
 }
 
+// SetSecret stores a secret that is not tied to an account
+func (s *KeyringStore) SetSecret(name, value string) error {
+	name = normalizeName(name)
+	if name == "" {
+		return fmt.Errorf("secret name cannot be empty")
+	}
+	if value == "" {
+		return fmt.Errorf("secret cannot be empty")
+	}
+	return s.ring.Set(keyring.Item{
+		Key:  secretPrefix + name,
+		Data: []byte(value),
+	})
+}
+
+// GetSecret retrieves a secret stored with SetSecret
+func (s *KeyringStore) GetSecret(name string) (string, error) {
+	item, err := s.ring.Get(secretPrefix + normalizeName(name))
+	if err != nil {
+		if err == keyring.ErrKeyNotFound {
+			return "", ErrSecretNotFound
+		}
+		return "", fmt.Errorf("failed to get secret: %w", err)
+	}
+	return string(item.Data), nil
+}
+
+// DeleteSecret removes a secret stored with SetSecret; removing one that
+// does not exist is not an error
+func (s *KeyringStore) DeleteSecret(name string) error {
+	err := s.ring.Remove(secretPrefix + normalizeName(name))
+	if err == keyring.ErrKeyNotFound {
+		return nil
+	}
+	return err
+}
+
 // IsExpired checks if credentials are expired
 func (c *Credentials) IsExpired() bool {
 	if c.ExpiresAt.IsZero() {
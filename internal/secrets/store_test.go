@@ -3,6 +3,8 @@ package secrets
 import (
 	"encoding/json"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -333,6 +335,22 @@ func TestKeyringStore_Get(t *testing.T) {
 		}
 	})
 
+	t.Run("round-trips scopes", func(t *testing.T) {
+		mock := newMockKeyring()
+		store := &KeyringStore{ring: mock, warnedAccounts: make(map[string]bool)}
+
+		if err := store.Set("test", Credentials{AccessToken: "token123", Scopes: []string{"threads_basic", "threads_delete"}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		creds, err := store.Get("test")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(creds.Scopes) != 2 || creds.Scopes[1] != "threads_delete" {
+			t.Errorf("expected the stored scopes, got %v", creds.Scopes)
+		}
+	})
+
 	t.Run("not found error", func(t *testing.T) {
 		mock := newMockKeyring()
 		store := &KeyringStore{ring: mock, warnedAccounts: make(map[string]bool)}
@@ -488,6 +506,39 @@ func TestKeyringStore_List(t *testing.T) {
 	}
 }
 
+func TestKeyringStore_Secrets(t *testing.T) {
+	mock := newMockKeyring()
+	store := &KeyringStore{ring: mock, warnedAccounts: make(map[string]bool)}
+
+	if _, err := store.GetSecret("smtp"); !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected ErrSecretNotFound, got %v", err)
+	}
+	if err := store.SetSecret(" SMTP ", "hunter2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value, err := store.GetSecret("smtp"); err != nil || value != "hunter2" {
+		t.Errorf("expected the secret back, got %q, %v", value, err)
+	}
+
+	// Secrets are not accounts
+	if accounts, _ := store.List(); len(accounts) != 0 {
+		t.Errorf("expected no accounts, got %v", accounts)
+	}
+
+	if err := store.SetSecret("smtp", ""); err == nil {
+		t.Error("expected an empty secret rejected")
+	}
+	if err := store.DeleteSecret("smtp"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.DeleteSecret("smtp"); err != nil {
+		t.Errorf("expected deleting a missing secret to succeed, got %v", err)
+	}
+	if _, ok := mock.items["secret:smtp"]; ok {
+		t.Error("expected the secret deleted")
+	}
+}
+
 // Test constants
 
 func TestConstants(t *testing.T) {
@@ -628,3 +679,31 @@ func TestKeyringStore_Delete_Error(t *testing.T) {
 		t.Error("expected error when ring.Remove fails")
 	}
 }
+
+func TestKeyringConfig(t *testing.T) {
+	t.Run("windows", func(t *testing.T) {
+		cfg := keyringConfig("windows")
+		want := []keyring.BackendType{keyring.WinCredBackend, keyring.FileBackend}
+		if !slices.Equal(cfg.AllowedBackends, want) {
+			t.Errorf("AllowedBackends = %v, want %v", cfg.AllowedBackends, want)
+		}
+		if cfg.WinCredPrefix != serviceName {
+			t.Errorf("WinCredPrefix = %q, want %q", cfg.WinCredPrefix, serviceName)
+		}
+		if strings.HasPrefix(cfg.FileDir, "~") {
+			t.Errorf("FileDir = %q, want a path without ~", cfg.FileDir)
+		}
+	})
+
+	t.Run("other platforms", func(t *testing.T) {
+		for _, goos := range []string{"linux", "darwin", "freebsd"} {
+			cfg := keyringConfig(goos)
+			if cfg.AllowedBackends != nil {
+				t.Errorf("%s: AllowedBackends = %v, want every available backend", goos, cfg.AllowedBackends)
+			}
+			if cfg.FileDir != "~/.config/threads-cli/keyring" {
+				t.Errorf("%s: FileDir = %q, existing file keyrings would be lost", goos, cfg.FileDir)
+			}
+		}
+	})
+}
@@ -0,0 +1,221 @@
+// Package series keeps the counters of numbered recurring posts, such as
+// "TIL #42", so each post of a series gets the next number. The state can
+// be exported and merged into the state of another machine.
+package series
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/salmonumbrella/threads-cli/internal/filelock"
+	"github.com/salmonumbrella/threads-cli/internal/statefile"
+)
+
+// FileName is the name of the series state in the data directory.
+const FileName = "series.json"
+
+// schema and version label the series state file
+const (
+	schema  = "series"
+	version = 1
+)
+
+// State is every series of a machine.
+type State struct {
+	Series []*Series `json:"series"`
+}
+
+// Series is a numbered recurring post.
+type Series struct {
+	Name string `json:"name"`
+	// Template makes the text of a post from {{.n}}, its number, and
+	// {{.text}}
+	Template string `json:"template"`
+	// Next is the number of the next post
+	Next      int       `json:"next"`
+	Posts     []Post    `json:"posts,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is when the template last changed; a merge keeps the
+	// newer template
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Post is a number taken by a post of a series.
+type Post struct {
+	N int `json:"n"`
+	// PostID is the published post; QueueID the queued one, until then
+	PostID  string    `json:"post_id,omitempty"`
+	QueueID string    `json:"queue_id,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// MergeResult tells what a merge changed.
+type MergeResult struct {
+	// Added are series that were not known
+	Added []string
+	// Updated are series whose counter, posts or template changed
+	Updated []string
+	// Conflicts describe numbers taken by different posts on each side
+	Conflicts []string
+}
+
+// Load returns the series state at path. A missing file has no series.
+func Load(path string) (*State, error) {
+	state := &State{}
+	err := statefile.ReadJSON(path, schema, version, state)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Save replaces the series state at path atomically.
+func Save(path string, state *State) error {
+	out := State{Series: []*Series{}}
+	if state != nil {
+		out.Series = append(out.Series, state.Series...)
+	}
+	return statefile.WriteJSON(path, schema, version, out)
+}
+
+// Update changes the state at path with fn while holding its lock, so two
+// posts of a series never get the same number. The state is saved unless
+// fn fails.
+func Update(path string, fn func(*State) error) error {
+	unlock, err := filelock.Lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if err := fn(state); err != nil {
+		return err
+	}
+	return Save(path, state)
+}
+
+// Get returns the series called name, ignoring case, or nil.
+func (s *State) Get(name string) *Series {
+	for _, series := range s.Series {
+		if strings.EqualFold(series.Name, name) {
+			return series
+		}
+	}
+	return nil
+}
+
+// Remove deletes the series called name and reports whether it existed.
+func (s *State) Remove(name string) bool {
+	n := len(s.Series)
+	s.Series = slices.DeleteFunc(s.Series, func(series *Series) bool { return strings.EqualFold(series.Name, name) })
+	return len(s.Series) < n
+}
+
+// Take reserves the next number of the series, at now.
+func (s *Series) Take(now time.Time) int {
+	n := s.Next
+	s.Next++
+	s.Posts = append(s.Posts, Post{N: n, At: now.UTC()})
+	return n
+}
+
+// Release gives back number n when its post could not be made. Only the
+// last number taken is reused; an earlier one stays a gap, as later posts
+// already have theirs.
+func (s *Series) Release(n int) {
+	s.Posts = slices.DeleteFunc(s.Posts, func(p Post) bool { return p.N == n && p.PostID == "" && p.QueueID == "" })
+	if s.Next == n+1 {
+		s.Next = n
+	}
+}
+
+// Record sets the post or queued post that took number n.
+func (s *Series) Record(n int, postID, queueID string) {
+	for i := range s.Posts {
+		if s.Posts[i].N == n {
+			s.Posts[i].PostID, s.Posts[i].QueueID = postID, queueID
+		}
+	}
+}
+
+// Last returns the post with the highest number, or nil.
+func (s *Series) Last() *Post {
+	var last *Post
+	for i := range s.Posts {
+		if last == nil || s.Posts[i].N > last.N {
+			last = &s.Posts[i]
+		}
+	}
+	return last
+}
+
+// Render makes the text of post n of a series from tmpl.
+func Render(tmpl string, n int, text string) (string, error) {
+	t, err := template.New("series").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, map[string]any{"n": n, "text": text}); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Merge adds the series of other to s. Counters keep the higher value, so
+// the next post is numbered after the posts of both machines; posts are
+// combined by number, and the newer template wins.
+func (s *State) Merge(other *State) MergeResult {
+	var result MergeResult
+	for _, theirs := range other.Series {
+		ours := s.Get(theirs.Name)
+		if ours == nil {
+			copied := *theirs
+			copied.Posts = slices.Clone(theirs.Posts)
+			s.Series = append(s.Series, &copied)
+			result.Added = append(result.Added, theirs.Name)
+			continue
+		}
+
+		changed := false
+		if theirs.Next > ours.Next {
+			ours.Next, changed = theirs.Next, true
+		}
+		if theirs.UpdatedAt.After(ours.UpdatedAt) && theirs.Template != ours.Template {
+			ours.Template, ours.UpdatedAt, changed = theirs.Template, theirs.UpdatedAt, true
+		}
+		for _, post := range theirs.Posts {
+			i := slices.IndexFunc(ours.Posts, func(p Post) bool { return p.N == post.N })
+			switch {
+			case i < 0:
+				ours.Posts = append(ours.Posts, post)
+				changed = true
+			case ours.Posts[i].PostID == "" && post.PostID != "":
+				ours.Posts[i].PostID = post.PostID
+				changed = true
+			case post.PostID != "" && ours.Posts[i].PostID != post.PostID:
+				result.Conflicts = append(result.Conflicts, fmt.Sprintf("%s #%d is post %s here and post %s in the import", ours.Name, post.N, ours.Posts[i].PostID, post.PostID))
+			}
+		}
+		if last := ours.Last(); last != nil && last.N >= ours.Next {
+			ours.Next, changed = last.N+1, true
+		}
+		if changed {
+			slices.SortFunc(ours.Posts, func(a, b Post) int { return a.N - b.N })
+			result.Updated = append(result.Updated, ours.Name)
+		}
+	}
+	return result
+}
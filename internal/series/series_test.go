@@ -0,0 +1,80 @@
+package series
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTakeReleaseRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	err := Update(path, func(state *State) error {
+		state.Series = append(state.Series, &Series{Name: "TIL", Template: "TIL #{{.n}}: {{.text}}", Next: 1})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	til := state.Get("til")
+	if til == nil {
+		t.Fatal("expected the series, ignoring case")
+	}
+	first, second := til.Take(now), til.Take(now)
+	if first != 1 || second != 2 || til.Next != 3 {
+		t.Fatalf("expected numbers 1 and 2, got %d, %d, next %d", first, second, til.Next)
+	}
+	til.Record(first, "p1", "")
+
+	// Only the last number is given back
+	til.Release(first)
+	if til.Next != 3 || len(til.Posts) != 2 {
+		t.Errorf("expected a recorded number to be kept, got %+v", til)
+	}
+	til.Release(second)
+	if til.Next != 2 || len(til.Posts) != 1 || til.Last().PostID != "p1" {
+		t.Errorf("expected the last number back, got %+v", til)
+	}
+
+	text, err := Render(til.Template, til.Next, "go vet catches printf mistakes")
+	if err != nil || text != "TIL #2: go vet catches printf mistakes" {
+		t.Errorf("got %q, %v", text, err)
+	}
+	if _, err := Render("{{.count}}", 1, ""); err == nil {
+		t.Error("expected an unknown field to fail")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	older := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	ours := &State{Series: []*Series{
+		{Name: "TIL", Template: "TIL #{{.n}} {{.text}}", Next: 4, UpdatedAt: older, Posts: []Post{{N: 2, PostID: "a2"}, {N: 3, PostID: "a3"}}},
+	}}
+	theirs := &State{Series: []*Series{
+		{Name: "til", Template: "TIL #{{.n}}: {{.text}}", Next: 5, UpdatedAt: older.Add(time.Hour), Posts: []Post{{N: 3, PostID: "b3"}, {N: 4, PostID: "b4"}}},
+		{Name: "Weekly", Template: "Week {{.n}}", Next: 10},
+	}}
+
+	result := ours.Merge(theirs)
+	if len(result.Added) != 1 || result.Added[0] != "Weekly" || len(result.Updated) != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(result.Conflicts) != 1 || !strings.Contains(result.Conflicts[0], "#3") {
+		t.Errorf("expected #3 to conflict, got %v", result.Conflicts)
+	}
+	til := ours.Get("TIL")
+	if til.Next != 5 || til.Template != "TIL #{{.n}}: {{.text}}" || len(til.Posts) != 3 || til.Posts[1].PostID != "a3" {
+		t.Errorf("unexpected merged series: %+v", til)
+	}
+
+	// Merging again changes nothing
+	if result := ours.Merge(theirs); len(result.Added)+len(result.Updated) != 0 {
+		t.Errorf("expected a repeated merge to change nothing, got %+v", result)
+	}
+}
@@ -0,0 +1,163 @@
+// Package statefile writes the local state of the CLI, such as the post
+// queue, caches and the config file, so that a crash or power loss in the
+// middle of a write leaves either the old or the new contents, never half
+// of them.
+//
+// Write replaces a file through a synced temporary file and rename.
+// WriteJSON also labels the contents with a schema and version, so a newer
+// format is refused rather than misread, and keeps the previous contents
+// next to the file for ReadJSON to fall back on if the file is found
+// damaged anyway, as after a crash on a file system that does not order
+// writes.
+package statefile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// BackupSuffix names the previous contents of a file written by WriteJSON
+const BackupSuffix = ".bak"
+
+// ErrNewerVersion is returned for a file written by a newer version of the
+// CLI in a format this one does not know.
+var ErrNewerVersion = errors.New("written by a newer version of threads")
+
+// envelope labels the contents of a file written by WriteJSON
+type envelope struct {
+	Schema  string          `json:"schema"`
+	Version int             `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Write replaces the file at path with data, readable as perm. The data is
+// synced to disk before it replaces the file, and the directory, created
+// readable by the user alone if missing, after.
+func Write(path string, data []byte, perm fs.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // No-op after a successful rename
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // Already failing
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // Already failing
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// CreateTemp makes the file readable by the user alone
+	if perm != 0o600 {
+		if err := os.Chmod(tmp.Name(), perm); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	syncDir(dir)
+	return nil
+}
+
+// WriteJSON replaces the file at path, readable by the user alone, with v
+// labelled as version of schema. The previous contents are kept at
+// path+BackupSuffix.
+func WriteJSON(path, schema string, version int, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(envelope{Schema: schema, Version: version, Data: data}, "", "  ")
+	if err != nil {
+		return err
+	}
+	backup(path)
+	return Write(path, append(out, '\n'), 0o600)
+}
+
+// ReadJSON decodes into v the file at path written by WriteJSON for schema,
+// up to version. Files written before they were labelled are read as they
+// are. A damaged file is read from its backup if that is intact. A
+// missing file is an error wrapping fs.ErrNotExist.
+func ReadJSON(path, schema string, version int, v any) error {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is chosen by the caller
+	if err != nil {
+		return err
+	}
+	err = decode(data, schema, version, v)
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		return nil
+	}
+
+	if backupData, readErr := os.ReadFile(path + BackupSuffix); readErr == nil { //nolint:gosec // Path is chosen by the caller
+		// The next write replaces the damaged file
+		if decode(backupData, schema, version, v) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is damaged: %w", path, err)
+}
+
+// Remove deletes the file at path written by WriteJSON, with its backup. A
+// missing file is not an error.
+func Remove(path string) error {
+	for _, name := range []string{path, path + BackupSuffix} {
+		if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// decode decodes data, labelled or not, into v
+func decode(data []byte, schema string, version int, v any) error {
+	var env envelope
+	if json.Unmarshal(data, &env) == nil && env.Schema != "" && env.Data != nil {
+		if env.Schema != schema {
+			return fmt.Errorf("holds %s, not %s", env.Schema, schema)
+		}
+		if env.Version > version {
+			return fmt.Errorf("%w (%s version %d)", ErrNewerVersion, schema, env.Version)
+		}
+		return json.Unmarshal(env.Data, v)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// backup keeps the current contents of path at path+BackupSuffix. It links
+// rather than copies, so the backup costs nothing; file systems without
+// links go without.
+func backup(path string) {
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+	os.Remove(path + BackupSuffix)   //nolint:errcheck,gosec // Link reports a backup left in place
+	os.Link(path, path+BackupSuffix) //nolint:errcheck,gosec // Best effort
+}
+
+// syncDir makes a rename in dir durable. Some systems, such as Windows,
+// cannot sync directories; their renames are durable already.
+func syncDir(dir string) {
+	d, err := os.Open(dir) //nolint:gosec // Path is chosen by the caller
+	if err != nil {
+		return
+	}
+	d.Sync()  //nolint:errcheck,gosec // See above
+	d.Close() //nolint:errcheck,gosec // Read-only
+}
@@ -0,0 +1,140 @@
+package statefile
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+type state struct {
+	Items []string `json:"items"`
+}
+
+func TestWriteJSON_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "queue.json")
+
+	if err := ReadJSON(path, "queue", 1, &state{}); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected a missing file, got %v", err)
+	}
+	for _, items := range [][]string{{"a"}, {"a", "b"}} {
+		if err := WriteJSON(path, "queue", 1, state{Items: items}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got state
+	if err := ReadJSON(path, "queue", 1, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Items) != 2 {
+		t.Errorf("expected the latest state, got %+v", got)
+	}
+	var previous state
+	if err := ReadJSON(path+BackupSuffix, "queue", 1, &previous); err != nil || len(previous.Items) != 1 {
+		t.Errorf("expected the previous state kept, got %+v, %v", previous, err)
+	}
+	if runtime.GOOS != "windows" {
+		if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+			t.Errorf("expected a file readable by the user alone, got %v", info.Mode())
+		}
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path)) //nolint:errcheck // Checked below
+	if len(entries) != 2 {
+		t.Errorf("expected no temporary files left, got %v", entries)
+	}
+}
+
+func TestReadJSON_RecoversDamagedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.json")
+	for _, items := range [][]string{{"a"}, {"a", "b"}} {
+		if err := WriteJSON(path, "queue", 1, state{Items: items}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A write cut short by a crash
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data[:len(data)/2], 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var got state
+	if err := ReadJSON(path, "queue", 1, &got); err != nil {
+		t.Fatalf("expected the backup read, got %v", err)
+	}
+	if len(got.Items) != 1 {
+		t.Errorf("expected the previous state, got %+v", got)
+	}
+
+	if err := os.WriteFile(path+BackupSuffix, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReadJSON(path, "queue", 1, &got); err == nil {
+		t.Error("expected an error with no intact copy")
+	}
+}
+
+func TestReadJSON_Versions(t *testing.T) {
+	dir := t.TempDir()
+
+	// Files from before the labels are read as they are
+	legacy := filepath.Join(dir, "legacy.json")
+	if err := os.WriteFile(legacy, []byte(`{"items":["a"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var got state
+	if err := ReadJSON(legacy, "queue", 1, &got); err != nil || len(got.Items) != 1 {
+		t.Errorf("expected the unlabelled file read, got %+v, %v", got, err)
+	}
+
+	newer := filepath.Join(dir, "newer.json")
+	if err := WriteJSON(newer, "queue", 2, state{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReadJSON(newer, "queue", 1, &got); !errors.Is(err, ErrNewerVersion) {
+		t.Errorf("expected a newer version refused, got %v", err)
+	}
+	if err := ReadJSON(newer, "bookmarks", 2, &got); err == nil {
+		t.Error("expected another schema refused")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "carousel.json")
+	for range 2 {
+		if err := WriteJSON(path, "carousel", 1, state{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 0 {
+		t.Errorf("expected the file and its backup removed, got %v", entries)
+	}
+	if err := Remove(path); err != nil {
+		t.Errorf("expected a missing file ignored, got %v", err)
+	}
+}
+
+func TestWrite_Permissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows has no permission bits")
+	}
+	path := filepath.Join(t.TempDir(), "index.json")
+	if err := Write(path, []byte("{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o644 {
+		t.Errorf("expected mode 0644, got %v", info.Mode())
+	}
+}
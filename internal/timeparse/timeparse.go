@@ -0,0 +1,241 @@
+// Package timeparse parses the dates and times users type on the command
+// line: "tomorrow 9am", "2025-07-01 14:00 CET", "in 2h", "3 days ago", as
+// well as RFC 3339 timestamps and unix seconds.
+package timeparse
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrSyntax is wrapped by the errors Parse returns for unrecognized input.
+var ErrSyntax = errors.New("unrecognized date or time")
+
+// Formats describes the accepted input, for help texts and error hints.
+const Formats = `"now", "today", "tomorrow 9am", "yesterday 18:30", "in 2h", "3 days ago", "2025-07-01", "2025-07-01 14:00 CET", RFC 3339, or unix seconds`
+
+// zoneAbbreviations are fixed-offset zones users commonly type. They win
+// over IANA names, so "CET" is always UTC+1 as written, never CEST.
+var zoneAbbreviations = map[string]time.Duration{
+	"UTC": 0, "GMT": 0, "Z": 0,
+	"WET": 0, "WEST": 1 * time.Hour, "BST": 1 * time.Hour,
+	"CET": 1 * time.Hour, "CEST": 2 * time.Hour,
+	"EET": 2 * time.Hour, "EEST": 3 * time.Hour,
+	"MSK": 3 * time.Hour, "IST": 5*time.Hour + 30*time.Minute,
+	"SGT": 8 * time.Hour, "HKT": 8 * time.Hour, "JST": 9 * time.Hour, "KST": 9 * time.Hour,
+	"AEST": 10 * time.Hour, "AEDT": 11 * time.Hour, "NZST": 12 * time.Hour, "NZDT": 13 * time.Hour,
+	"AST": -4 * time.Hour, "EST": -5 * time.Hour, "EDT": -4 * time.Hour,
+	"CST": -6 * time.Hour, "CDT": -5 * time.Hour,
+	"MST": -7 * time.Hour, "MDT": -6 * time.Hour, "PST": -8 * time.Hour, "PDT": -7 * time.Hour,
+	"AKST": -9 * time.Hour, "AKDT": -8 * time.Hour, "HST": -10 * time.Hour,
+}
+
+// Zone returns the location named by an abbreviation (CET), a numeric
+// offset (+02:00, -0700), or an IANA name (Europe/Berlin).
+func Zone(name string) (*time.Location, bool) {
+	if offset, ok := zoneAbbreviations[strings.ToUpper(name)]; ok {
+		return time.FixedZone(strings.ToUpper(name), int(offset.Seconds())), true
+	}
+	if m := offsetPattern.FindStringSubmatch(name); m != nil {
+		hours, _ := strconv.Atoi(m[2])   //nolint:errcheck // Digits by the pattern
+		minutes, _ := strconv.Atoi(m[3]) //nolint:errcheck // Digits by the pattern
+		// Real offsets run from -12:00 to +14:00
+		if hours > 14 || minutes > 59 {
+			return nil, false
+		}
+		seconds := hours*3600 + minutes*60
+		if m[1] == "-" {
+			seconds = -seconds
+		}
+		return time.FixedZone(name, seconds), true
+	}
+	if strings.Contains(name, "/") {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc, true
+		}
+	}
+	return nil, false
+}
+
+var (
+	offsetPattern   = regexp.MustCompile(`^([+-])(\d{2}):?(\d{2})$`)
+	unixPattern     = regexp.MustCompile(`^\d{9,}$`)
+	durationPattern = regexp.MustCompile(`(\d+)\s*([a-z]+)`)
+	clockPattern    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?(am|pm)?$`)
+)
+
+// Parse parses s relative to now. Input without an explicit zone is read in
+// loc.
+func Parse(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	input := strings.TrimSpace(s)
+	if input == "" {
+		return time.Time{}, fmt.Errorf("%w: empty value", ErrSyntax)
+	}
+	if t, err := time.Parse(time.RFC3339, input); err == nil {
+		return t, nil
+	}
+	if unixPattern.MatchString(input) {
+		sec, err := strconv.ParseInt(input, 10, 64)
+		if err == nil {
+			return time.Unix(sec, 0).In(loc), nil
+		}
+	}
+
+	words := strings.Fields(input)
+	if len(words) > 1 {
+		if zone, ok := Zone(words[len(words)-1]); ok {
+			loc = zone
+			words = words[:len(words)-1]
+		}
+	}
+	lower := strings.ToLower(strings.Join(words, " "))
+	now = now.In(loc)
+
+	switch {
+	case lower == "now":
+		return now, nil
+	case strings.HasPrefix(lower, "in "):
+		d, err := ParseDuration(strings.TrimPrefix(lower, "in "))
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(d), nil
+	case strings.HasSuffix(lower, " ago"):
+		d, err := ParseDuration(strings.TrimSuffix(lower, " ago"))
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(-d), nil
+	}
+
+	day, rest, err := parseDay(words, now, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+	if rest == "" {
+		return day, nil
+	}
+	hour, minute, second, err := parseClock(rest)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, loc), nil
+}
+
+// parseDay reads the day from the first word and returns its midnight and the
+// remaining words as a time of day. A lone time of day means today.
+func parseDay(words []string, now time.Time, loc *time.Location) (time.Time, string, error) {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	rest := strings.ToLower(strings.Join(words[1:], ""))
+
+	switch first := strings.ToLower(words[0]); first {
+	case "today":
+		return midnight, rest, nil
+	case "tomorrow":
+		return midnight.AddDate(0, 0, 1), rest, nil
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1), rest, nil
+	case "noon", "midnight":
+		return midnight, first + rest, nil
+	}
+
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, words[0], loc); err == nil {
+			if rest != "" {
+				return time.Time{}, "", ErrSyntax
+			}
+			return t, "", nil
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02", words[0], loc); err == nil {
+		return t, rest, nil
+	}
+	if _, _, _, err := parseClock(strings.ToLower(strings.Join(words, ""))); err == nil {
+		return midnight, strings.ToLower(strings.Join(words, "")), nil
+	}
+	return time.Time{}, "", ErrSyntax
+}
+
+// parseClock parses a time of day: 14:00, 14:00:30, 9am, 9:30pm, noon, midnight
+func parseClock(s string) (hour, minute, second int, err error) {
+	switch s {
+	case "noon":
+		return 12, 0, 0, nil
+	case "midnight":
+		return 0, 0, 0, nil
+	}
+	m := clockPattern.FindStringSubmatch(s)
+	if m == nil || (m[2] == "" && m[4] == "") {
+		return 0, 0, 0, ErrSyntax
+	}
+	hour, _ = strconv.Atoi(m[1]) //nolint:errcheck // Digits by the pattern
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2]) //nolint:errcheck // Digits by the pattern
+	}
+	if m[3] != "" {
+		second, _ = strconv.Atoi(m[3]) //nolint:errcheck // Digits by the pattern
+	}
+	switch m[4] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, 0, ErrSyntax
+		}
+		hour %= 12
+		if m[4] == "pm" {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 || second > 59 {
+		return 0, 0, 0, ErrSyntax
+	}
+	return hour, minute, second, nil
+}
+
+// ParseDuration parses a human duration such as "2h", "90 minutes",
+// "1 day 6h", or "2w". Days are 24 hours.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	matches := durationPattern.FindAllStringSubmatch(s, -1)
+	if len(matches) == 0 || strings.TrimSpace(durationPattern.ReplaceAllString(s, "")) != "" {
+		return 0, fmt.Errorf("%w: invalid duration %q", ErrSyntax, s)
+	}
+
+	var total time.Duration
+	for _, m := range matches {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return 0, fmt.Errorf("%w: invalid duration %q", ErrSyntax, s)
+		}
+		unit, ok := durationUnit(m[2])
+		if !ok {
+			return 0, fmt.Errorf("%w: unknown unit %q", ErrSyntax, m[2])
+		}
+		// Checked so huge counts fail instead of wrapping around
+		if time.Duration(n) > (math.MaxInt64-total)/unit {
+			return 0, fmt.Errorf("%w: duration %q is too long", ErrSyntax, s)
+		}
+		total += time.Duration(n) * unit
+	}
+	return total, nil
+}
+
+func durationUnit(word string) (time.Duration, bool) {
+	switch word {
+	case "s", "sec", "secs", "second", "seconds":
+		return time.Second, true
+	case "m", "min", "mins", "minute", "minutes":
+		return time.Minute, true
+	case "h", "hr", "hrs", "hour", "hours":
+		return time.Hour, true
+	case "d", "day", "days":
+		return 24 * time.Hour, true
+	case "w", "wk", "week", "weeks":
+		return 7 * 24 * time.Hour, true
+	}
+	return 0, false
+}
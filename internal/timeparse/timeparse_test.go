@@ -0,0 +1,140 @@
+package timeparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	berlin := time.FixedZone("Berlin", 2*3600)
+	now := time.Date(2025, 6, 30, 15, 4, 5, 0, berlin)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"now", now},
+		{"today", time.Date(2025, 6, 30, 0, 0, 0, 0, berlin)},
+		{"tomorrow 9am", time.Date(2025, 7, 1, 9, 0, 0, 0, berlin)},
+		{"Tomorrow 9 AM", time.Date(2025, 7, 1, 9, 0, 0, 0, berlin)},
+		{"yesterday 18:30", time.Date(2025, 6, 29, 18, 30, 0, 0, berlin)},
+		{"tomorrow noon", time.Date(2025, 7, 1, 12, 0, 0, 0, berlin)},
+		{"12am", time.Date(2025, 6, 30, 0, 0, 0, 0, berlin)},
+		{"9:30pm", time.Date(2025, 6, 30, 21, 30, 0, 0, berlin)},
+		{"in 2h", now.Add(2 * time.Hour)},
+		{"in 1 day 6h", now.Add(30 * time.Hour)},
+		{"3 days ago", now.Add(-72 * time.Hour)},
+		{"90 minutes ago", now.Add(-90 * time.Minute)},
+		{"2025-07-01", time.Date(2025, 7, 1, 0, 0, 0, 0, berlin)},
+		{"2025-07-01 14:00", time.Date(2025, 7, 1, 14, 0, 0, 0, berlin)},
+		{"2025-07-01T14:00", time.Date(2025, 7, 1, 14, 0, 0, 0, berlin)},
+		{"2025-07-01 14:00 CET", time.Date(2025, 7, 1, 13, 0, 0, 0, time.UTC)},
+		{"2025-07-01 14:00 UTC", time.Date(2025, 7, 1, 14, 0, 0, 0, time.UTC)},
+		{"2025-07-01 14:00 -07:00", time.Date(2025, 7, 1, 21, 0, 0, 0, time.UTC)},
+		{"tomorrow 9am PST", time.Date(2025, 7, 1, 17, 0, 0, 0, time.UTC)},
+		{"2025-07-01T14:00:00Z", time.Date(2025, 7, 1, 14, 0, 0, 0, time.UTC)},
+		{"1751378400", time.Unix(1751378400, 0)},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in, now, berlin)
+		if err != nil {
+			t.Errorf("Parse(%q): unexpected error %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	now := time.Date(2025, 6, 30, 15, 0, 0, 0, time.UTC)
+	for _, in := range []string{"", "someday", "in forever", "2025-13-01", "25:00", "13pm", "tomorrow at", "2025-07-01T14:00 9am"} {
+		if _, err := Parse(in, now, time.UTC); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Parse(%q): expected ErrSyntax, got %v", in, err)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"2h":         2 * time.Hour,
+		"2h30m":      150 * time.Minute,
+		"90 minutes": 90 * time.Minute,
+		"7d":         7 * 24 * time.Hour,
+		"2 weeks":    14 * 24 * time.Hour,
+	}
+	for in, want := range tests {
+		got, err := ParseDuration(in)
+		if err != nil || got != want {
+			t.Errorf("ParseDuration(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "h", "2 fortnights", "2h and 3m"} {
+		if _, err := ParseDuration(in); err == nil {
+			t.Errorf("ParseDuration(%q): expected an error", in)
+		}
+	}
+}
+
+func TestZone(t *testing.T) {
+	for name, offset := range map[string]int{"CET": 3600, "ist": 19800, "+05:30": 19800, "-0700": -25200} {
+		loc, ok := Zone(name)
+		if !ok {
+			t.Errorf("Zone(%q) not found", name)
+			continue
+		}
+		if _, got := time.Date(2025, 1, 1, 0, 0, 0, 0, loc).Zone(); got != offset {
+			t.Errorf("Zone(%q) offset = %d, want %d", name, got, offset)
+		}
+	}
+	for _, name := range []string{"ago", "+99:99", "-2400", "+05:60"} {
+		if _, ok := Zone(name); ok {
+			t.Errorf("Zone(%q) should not be a zone", name)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"2024-06-01T09:30:00Z", "1717234200", "now", "in 2h", "3 days ago",
+		"tomorrow 9am", "today noon", "2024-06-01 14:00 CET", "2024-06-01T09:30 Europe/Berlin",
+		"14:30 +02:00", "yesterday 23:59:59", "in 9999999999 weeks", "12pm -0700",
+	} {
+		f.Add(seed)
+	}
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	f.Fuzz(func(t *testing.T, s string) {
+		got, err := Parse(s, now, time.UTC)
+		if err != nil {
+			if !errors.Is(err, ErrSyntax) {
+				t.Errorf("Parse(%q) error = %v, want ErrSyntax", s, err)
+			}
+			return
+		}
+		if got.IsZero() {
+			t.Errorf("Parse(%q) = zero time without an error", s)
+		}
+	})
+}
+
+func FuzzParseDuration(f *testing.F) {
+	for _, seed := range []string{"2h", "90 minutes", "1 day 6h", "2w", "0s", "9999999999 weeks", "1h1h1h"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		d, err := ParseDuration(s)
+		if err != nil {
+			if !errors.Is(err, ErrSyntax) {
+				t.Errorf("ParseDuration(%q) error = %v, want ErrSyntax", s, err)
+			}
+			return
+		}
+		if d < 0 {
+			t.Errorf("ParseDuration(%q) = %v, want a non-negative duration", s, d)
+		}
+	})
+}
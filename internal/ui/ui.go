@@ -44,6 +44,11 @@ func New(io *iocontext.IO, colorMode outfmt.ColorMode) *Printer {
 		opts = append(opts, termenv.WithUnsafe())
 	case outfmt.ColorNever:
 		opts = append(opts, termenv.WithProfile(termenv.Ascii))
+	default:
+		// Legacy Windows consoles print escape codes literally
+		if file, ok := out.(*os.File); ok && !outfmt.EnableVirtualTerminal(file) {
+			opts = append(opts, termenv.WithProfile(termenv.Ascii))
+		}
 	}
 
 	output := termenv.NewOutput(out, opts...)
@@ -107,6 +112,27 @@ func (p *Printer) Colorize(s string, color termenv.Color) string {
 	return p.output.String(s).Foreground(color).String()
 }
 
+// NamedColor returns the palette color with the given name (red, green,
+// yellow, blue, cyan, gray), or nil for unknown names.
+func (p *Printer) NamedColor(name string) termenv.Color {
+	switch name {
+	case "red":
+		return p.Red
+	case "green":
+		return p.Green
+	case "yellow":
+		return p.Yellow
+	case "blue":
+		return p.Blue
+	case "cyan":
+		return p.Cyan
+	case "gray":
+		return p.Gray
+	default:
+		return nil
+	}
+}
+
 // StatusColor returns appropriate color for a status.
 func (p *Printer) StatusColor(status string) termenv.Color {
 	switch status {